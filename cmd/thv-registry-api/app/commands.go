@@ -40,6 +40,12 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(primeDbCmd)
+	rootCmd.AddCommand(generateSiteCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(installServiceCmd)
+	rootCmd.AddCommand(uninstallServiceCmd)
+	rootCmd.AddCommand(healthcheckCmd)
+	rootCmd.AddCommand(mockRegistryCmd)
 
 	return rootCmd
 }
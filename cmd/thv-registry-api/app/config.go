@@ -0,0 +1,56 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configuration file utilities",
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the configuration file",
+	Long: `Print the JSON Schema of the configuration file understood by this binary.
+
+The schema is generated from the configuration types compiled into the binary, so
+Helm charts and operators can validate their values against the exact version they
+deploy. It describes the known keys and their types, and rejects unknown keys; semantic
+constraints (e.g. mutually exclusive fields) are only checked when the server starts.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigSchema,
+}
+
+func init() {
+	configSchemaCmd.Flags().String("output", "", "File to write the schema to (defaults to standard output)")
+	configCmd.AddCommand(configSchemaCmd)
+}
+
+func runConfigSchema(cmd *cobra.Command, _ []string) error {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get output flag: %w", err)
+	}
+
+	data, err := json.MarshalIndent(config.Schema(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration schema: %w", err)
+	}
+	data = append(data, '\n')
+
+	if output == "" {
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if err := os.WriteFile(output, data, 0600); err != nil {
+		return fmt.Errorf("failed to write configuration schema: %w", err)
+	}
+	return nil
+}
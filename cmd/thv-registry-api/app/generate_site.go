@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/site"
+	"github.com/stacklok/toolhive-registry-server/internal/sources"
+)
+
+var generateSiteCmd = &cobra.Command{
+	Use:   "generate-site",
+	Short: "Generate a static HTML catalog from synced registry data",
+	Long: `Generate a static, human-browsable HTML catalog of the registry.
+
+This command reads the registry data previously synced to file storage by the
+serve command (see fileStorage.baseDir in the configuration) and renders:
+- An index page with client-side search
+- One page per server, showing its latest version and the digests pinning its
+  packages (OCI image digests and file SHA-256 hashes)
+- A sitemap.xml, when --base-url is provided
+
+The output directory can be hosted by any static web server.`,
+	RunE: runGenerateSite,
+}
+
+func init() {
+	generateSiteCmd.Flags().String("config", "", "Path to configuration file (YAML format, required)")
+	generateSiteCmd.Flags().String("output", "./site", "Directory to write the generated site to")
+	generateSiteCmd.Flags().String("base-url", "", "Public URL the site is hosted at (enables sitemap.xml)")
+	generateSiteCmd.Flags().String("title", "", "Title shown on every page")
+
+	if err := generateSiteCmd.MarkFlagRequired("config"); err != nil {
+		slog.Error("Failed to mark config flag as required", "error", err)
+		os.Exit(1)
+	}
+}
+
+func runGenerateSite(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return fmt.Errorf("failed to get config flag: %w", err)
+	}
+	outputDir, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get output flag: %w", err)
+	}
+	baseURL, err := cmd.Flags().GetString("base-url")
+	if err != nil {
+		return fmt.Errorf("failed to get base-url flag: %w", err)
+	}
+	title, err := cmd.Flags().GetString("title")
+	if err != nil {
+		return fmt.Errorf("failed to get title flag: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(config.WithConfigPath(configPath))
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.GetStorageType() != config.StorageTypeFile {
+		return fmt.Errorf("generate-site requires file storage, got %s", cfg.GetStorageType())
+	}
+
+	storageManager := sources.NewFileStorageManager(cfg.GetFileStorageBaseDir())
+	registries, err := storageManager.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read synced registry data: %w", err)
+	}
+	if len(registries) == 0 {
+		return fmt.Errorf("no synced registry data found in %s", cfg.GetFileStorageBaseDir())
+	}
+
+	var opts []site.Option
+	if baseURL != "" {
+		opts = append(opts, site.WithBaseURL(baseURL))
+	}
+	if title != "" {
+		opts = append(opts, site.WithTitle(title))
+	}
+
+	if err := site.Generate(outputDir, registries, opts...); err != nil {
+		return fmt.Errorf("failed to generate site: %w", err)
+	}
+
+	slog.Info("Static site generated",
+		"output", outputDir,
+		"registry_count", len(registries))
+
+	return nil
+}
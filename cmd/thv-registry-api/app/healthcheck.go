@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+)
+
+const (
+	// defaultHealthcheckTimeout bounds the health probe
+	defaultHealthcheckTimeout = 5 * time.Second
+	// maxHealthcheckBodySize caps the part of failed probe responses reported
+	maxHealthcheckBodySize = 512
+)
+
+var healthcheckCmd = &cobra.Command{
+	Use:   "healthcheck",
+	Short: "Probe the health endpoint of a local server",
+	Long: `Probe the /health endpoint (or /readiness with --readiness) of a server running on
+this host, and exit with a non-zero status if it does not respond with a 2xx status.
+
+This lets container images run health checks without shipping curl, e.g. with a
+Docker HEALTHCHECK or a Kubernetes exec probe:
+
+  HEALTHCHECK CMD ["/main", "healthcheck", "--address", ":8080"]
+
+The address is the one given to the serve command; a missing or wildcard host
+probes the loopback interface.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runHealthcheck,
+}
+
+func init() {
+	healthcheckCmd.Flags().String("address", ":8080", "Address the server listens on")
+	healthcheckCmd.Flags().Bool("readiness", false, "Probe /readiness instead of /health")
+	healthcheckCmd.Flags().Duration("timeout", defaultHealthcheckTimeout, "Timeout of the probe")
+}
+
+func runHealthcheck(cmd *cobra.Command, _ []string) error {
+	address, err := cmd.Flags().GetString("address")
+	if err != nil {
+		return fmt.Errorf("failed to get address flag: %w", err)
+	}
+	readiness, err := cmd.Flags().GetBool("readiness")
+	if err != nil {
+		return fmt.Errorf("failed to get readiness flag: %w", err)
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return fmt.Errorf("failed to get timeout flag: %w", err)
+	}
+
+	path := "/health"
+	if readiness {
+		path = "/readiness"
+	}
+	probeURL, err := healthcheckURL(address, path)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := probe(ctx, probeURL); err != nil {
+		return err
+	}
+	slog.Debug("Health check passed", "url", probeURL)
+	return nil
+}
+
+// healthcheckURL returns the URL of an endpoint of the server listening on address, probing the
+// loopback interface when the address has no host or a wildcard host
+func healthcheckURL(address, path string) (string, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %s: %w", address, err)
+	}
+	switch host {
+	case "", "0.0.0.0":
+		host = "127.0.0.1"
+	case "::":
+		host = "::1"
+	}
+	return "http://" + net.JoinHostPort(host, port) + path, nil
+}
+
+// probe fails unless the URL responds with a 2xx status
+func probe(ctx context.Context, probeURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", httpclient.UserAgent())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("health check of %s failed: %w", probeURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxHealthcheckBodySize))
+		return fmt.Errorf("health check of %s failed: HTTP %d: %s", probeURL, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
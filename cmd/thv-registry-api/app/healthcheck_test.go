@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthcheckURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		address string
+		want    string
+		wantErr string
+	}{
+		{address: ":8080", want: "http://127.0.0.1:8080/health"},
+		{address: "0.0.0.0:9090", want: "http://127.0.0.1:9090/health"},
+		{address: "[::]:8080", want: "http://[::1]:8080/health"},
+		{address: "10.0.0.5:8080", want: "http://10.0.0.5:8080/health"},
+		{address: "localhost:8080", want: "http://localhost:8080/health"},
+		{address: "8080", wantErr: "invalid address 8080"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			t.Parallel()
+			got, err := healthcheckURL(tt.address, "/health")
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProbe(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/readiness" {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	require.NoError(t, probe(context.Background(), server.URL+"/health"))
+	require.ErrorContains(t, probe(context.Background(), server.URL+"/readiness"), "HTTP 503: not ready")
+
+	server.Close()
+	require.ErrorContains(t, probe(context.Background(), server.URL+"/health"), "health check of")
+}
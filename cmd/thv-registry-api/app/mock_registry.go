@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive-registry-server/internal/fixtures"
+)
+
+const (
+	// mockRegistryReadHeaderTimeout bounds the time to read request headers
+	mockRegistryReadHeaderTimeout = 10 * time.Second
+	// mockRegistryShutdownTimeout bounds the graceful shutdown of the mock registry
+	mockRegistryShutdownTimeout = 5 * time.Second
+)
+
+var mockRegistryCmd = &cobra.Command{
+	Use:   "mock-registry",
+	Short: "Serve a synthetic catalog with the upstream MCP Registry API",
+	Long: `Serve a synthetic catalog of MCP servers with the API of the upstream MCP Registry,
+for local development, demos and load tests without depending on a real registry.
+
+The catalog is generated from --seed, so the same seed and sizes always serve the same
+servers, with realistic tags, tools, packages and metadata. Point an API registry
+source at it to sync the catalog:
+
+  thv-registry-api mock-registry --address :8090 --servers 1000
+  # registries:
+  #   - name: mock
+  #     api:
+  #       endpoint: http://localhost:8090`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runMockRegistry,
+}
+
+func init() {
+	mockRegistryCmd.Flags().String("address", ":8090", "Address to listen on")
+	mockRegistryCmd.Flags().Uint64("seed", 1, "Seed of the generated catalog")
+	mockRegistryCmd.Flags().Int("servers", 100, "Number of servers of the generated catalog")
+	mockRegistryCmd.Flags().Int("max-versions", 3, "Maximum number of versions of each server")
+}
+
+func runMockRegistry(cmd *cobra.Command, _ []string) error {
+	address, err := cmd.Flags().GetString("address")
+	if err != nil {
+		return fmt.Errorf("failed to get address flag: %w", err)
+	}
+	seed, err := cmd.Flags().GetUint64("seed")
+	if err != nil {
+		return fmt.Errorf("failed to get seed flag: %w", err)
+	}
+	count, err := cmd.Flags().GetInt("servers")
+	if err != nil {
+		return fmt.Errorf("failed to get servers flag: %w", err)
+	}
+	maxVersions, err := cmd.Flags().GetInt("max-versions")
+	if err != nil {
+		return fmt.Errorf("failed to get max-versions flag: %w", err)
+	}
+	if count < 0 {
+		return fmt.Errorf("invalid servers flag %d: must not be negative", count)
+	}
+
+	servers := fixtures.Servers(seed, count, fixtures.WithMaxVersions(maxVersions))
+	server := &http.Server{
+		Addr:              address,
+		Handler:           fixtures.NewUpstreamAPIHandler(servers),
+		ReadHeaderTimeout: mockRegistryReadHeaderTimeout,
+	}
+
+	stop, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+	slog.Info("Serving mock registry", "address", address, "seed", seed, "servers", count, "versions", len(servers))
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("mock registry failed: %w", err)
+	case <-stop.Done():
+	}
+
+	ctx, cancelShutdown := context.WithTimeout(context.Background(), mockRegistryShutdownTimeout)
+	defer cancelShutdown()
+	if err := server.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to shut down mock registry: %w", err)
+	}
+	return nil
+}
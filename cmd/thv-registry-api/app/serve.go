@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -16,6 +14,8 @@ import (
 	"github.com/stacklok/toolhive-registry-server/database"
 	registryapp "github.com/stacklok/toolhive-registry-server/internal/app"
 	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/osservice"
+	"github.com/stacklok/toolhive-registry-server/internal/preflight"
 )
 
 var serveCmd = &cobra.Command{
@@ -29,6 +29,11 @@ The server requires a configuration file (--config) that specifies:
 - Authentication configuration
 - All other operational settings
 
+Before starting, the server checks that its registry sources are reachable and their
+data parses, that the auth configuration is valid and its identity providers reachable,
+and that the storage directory is writable. It exits listing every problem found, unless
+--skip-preflight is set.
+
 If database configuration is present, migrations will run automatically on startup.
 
 See examples/ directory for sample configurations.`,
@@ -43,6 +48,7 @@ func init() {
 	serveCmd.Flags().String("address", ":8080", "Address to listen on")
 	serveCmd.Flags().String("config", "", "Path to configuration file (YAML format, required)")
 	serveCmd.Flags().String("auth-mode", "", "Override auth mode from config (anonymous or oauth)")
+	serveCmd.Flags().Bool("skip-preflight", false, "Start without checking sources, auth and storage first")
 
 	err := viper.BindPFlag("address", serveCmd.Flags().Lookup("address"))
 	if err != nil {
@@ -63,6 +69,14 @@ func init() {
 }
 
 func runServe(cmd *cobra.Command, _ []string) error {
+	// Under the Windows service control manager, stop requests come from the manager rather than signals
+	return osservice.Run(osservice.DefaultName, func(stop context.Context) error {
+		return serve(stop, cmd)
+	})
+}
+
+// serve runs the registry API server until stop is canceled
+func serve(stop context.Context, cmd *cobra.Command) error {
 	ctx := context.Background()
 
 	// Load and validate configuration
@@ -84,6 +98,13 @@ func runServe(cmd *cobra.Command, _ []string) error {
 		"registry_name", cfg.GetRegistryName(),
 		"registry_count", len(cfg.Registries))
 
+	// Fail fast on problems that would otherwise only surface on first sync or request
+	if skip, _ := cmd.Flags().GetBool("skip-preflight"); skip {
+		slog.Warn("Skipping configuration preflight checks")
+	} else if err := preflight.Run(ctx, cfg); err != nil {
+		return err
+	}
+
 	// Run database migrations if database is configured
 	if cfg.Database != nil {
 		slog.Info("Database configuration found, running migrations")
@@ -113,10 +134,8 @@ func runServe(cmd *cobra.Command, _ []string) error {
 		}
 	}()
 
-	// Wait for interrupt signal to gracefully shutdown the server
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	// Wait for a stop request to gracefully shutdown the server
+	<-stop.Done()
 
 	// Graceful shutdown
 	return app.Stop(defaultGracefulTimeout)
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/toolhive-registry-server/internal/osservice"
+)
+
+var installServiceCmd = &cobra.Command{
+	Use:   "install-service",
+	Short: "Install the serve command as a systemd unit or Windows service",
+	Long: `Install the serve command of this executable as an operating system service, started
+at boot and restarted on failure.
+
+On Linux, a systemd unit is written to /etc/systemd/system/<name>.service, then enabled
+and started. On Windows, a service starting automatically is created and started. Both
+require administrative privileges.
+
+The configuration file and this executable are referenced by absolute path, so they
+must stay in place while the service is installed. Use --dry-run to print the unit or
+service command line without installing it.`,
+	Args: cobra.NoArgs,
+	RunE: runInstallService,
+}
+
+var uninstallServiceCmd = &cobra.Command{
+	Use:   "uninstall-service",
+	Short: "Stop and remove the systemd unit or Windows service",
+	Long: `Stop and remove the operating system service installed by install-service.
+The configuration file and registry data are left in place.`,
+	Args: cobra.NoArgs,
+	RunE: runUninstallService,
+}
+
+func init() {
+	installServiceCmd.Flags().String("config", "", "Path to configuration file (YAML format, required)")
+	installServiceCmd.Flags().String("address", ":8080", "Address the service listens on")
+	installServiceCmd.Flags().String("name", osservice.DefaultName, "Name of the service")
+	installServiceCmd.Flags().String("user", "", "User the service runs as (systemd only, defaults to root)")
+	installServiceCmd.Flags().Bool("dry-run", false, "Print the service definition without installing it")
+	uninstallServiceCmd.Flags().String("name", osservice.DefaultName, "Name of the service")
+
+	if err := installServiceCmd.MarkFlagRequired("config"); err != nil {
+		slog.Error("Failed to mark config flag as required", "error", err)
+		os.Exit(1)
+	}
+}
+
+func runInstallService(cmd *cobra.Command, _ []string) error {
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return fmt.Errorf("failed to get config flag: %w", err)
+	}
+	address, err := cmd.Flags().GetString("address")
+	if err != nil {
+		return fmt.Errorf("failed to get address flag: %w", err)
+	}
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return fmt.Errorf("failed to get name flag: %w", err)
+	}
+	user, err := cmd.Flags().GetString("user")
+	if err != nil {
+		return fmt.Errorf("failed to get user flag: %w", err)
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get dry-run flag: %w", err)
+	}
+
+	// The service does not run from the current directory, so paths must be absolute
+	configPath, err = filepath.Abs(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve configuration path: %w", err)
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate executable: %w", err)
+	}
+	executable, err = filepath.EvalSymlinks(executable)
+	if err != nil {
+		return fmt.Errorf("failed to resolve executable path: %w", err)
+	}
+
+	spec := osservice.Spec{
+		Name:        name,
+		Description: osservice.DefaultDescription,
+		Executable:  executable,
+		Args:        []string{"serve", "--config", configPath, "--address", address},
+		User:        user,
+	}
+	if dryRun {
+		_, err = fmt.Fprint(cmd.OutOrStdout(), osservice.Describe(spec))
+		return err
+	}
+
+	if err := osservice.Install(context.Background(), spec); err != nil {
+		return fmt.Errorf("failed to install service: %w", err)
+	}
+	slog.Info("Service installed and started", "name", name, "config_path", configPath, "address", address)
+	return nil
+}
+
+func runUninstallService(cmd *cobra.Command, _ []string) error {
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return fmt.Errorf("failed to get name flag: %w", err)
+	}
+
+	if err := osservice.Uninstall(context.Background(), name); err != nil {
+		return fmt.Errorf("failed to uninstall service: %w", err)
+	}
+	slog.Info("Service uninstalled", "name", name)
+	return nil
+}
@@ -1,42 +1,102 @@
 -- name: ListServers :many
-SELECT r.reg_type as registry_type,
-       s.id,
-       s.name,
-       s.version,
-       (l.latest_server_id IS NOT NULL)::boolean AS is_latest,
-       s.created_at,
-       s.updated_at,
-       s.description,
-       s.title,
-       s.website,
-       s.upstream_meta,
-       s.server_meta,
-       s.repository_url,
-       s.repository_id,
-       s.repository_subfolder,
-       s.repository_type
-  FROM mcp_server s
-  JOIN registry r ON s.reg_id = r.id
-  LEFT JOIN latest_server_version l ON s.id = l.latest_server_id
- WHERE (sqlc.narg(next)::timestamp with time zone IS NULL OR s.created_at > sqlc.narg(next)::timestamp with time zone)
-   AND (sqlc.narg(prev)::timestamp with time zone IS NULL OR s.created_at < sqlc.narg(prev)::timestamp with time zone)
-   AND (sqlc.narg(registry_name)::text IS NULL OR r.name = sqlc.narg(registry_name)::text)
-   AND (sqlc.narg(search)::text IS NULL OR (
-       LOWER(s.name) LIKE LOWER('%' || sqlc.narg(search)::text || '%')
-       OR LOWER(s.title) LIKE LOWER('%' || sqlc.narg(search)::text || '%')
-       OR LOWER(s.description) LIKE LOWER('%' || sqlc.narg(search)::text || '%')
-   ))
+SELECT listed.registry_type,
+       listed.registry_name,
+       listed.id,
+       listed.name,
+       listed.version,
+       listed.is_latest,
+       listed.created_at,
+       listed.updated_at,
+       listed.description,
+       listed.title,
+       listed.website,
+       listed.upstream_meta,
+       listed.server_meta,
+       listed.repository_url,
+       listed.repository_id,
+       listed.repository_subfolder,
+       listed.repository_type,
+       listed.sort_type_position,
+       listed.sort_time,
+       listed.sort_created_at
+  FROM (
+    SELECT r.reg_type as registry_type,
+           r.name as registry_name,
+           s.id,
+           s.name,
+           s.version,
+           (l.latest_server_id IS NOT NULL)::boolean AS is_latest,
+           s.created_at,
+           s.updated_at,
+           s.description,
+           s.title,
+           s.website,
+           s.upstream_meta,
+           s.server_meta,
+           s.repository_url,
+           s.repository_id,
+           s.repository_subfolder,
+           s.repository_type,
+           (CASE WHEN sqlc.narg(sort_key)::text IS NULL
+                 THEN array_position(enum_range(NULL::registry_type), r.reg_type)
+                 ELSE 0 END)::integer AS sort_type_position,
+           (CASE sqlc.narg(sort_key)::text
+                 WHEN 'published_at' THEN COALESCE(s.created_at, 'epoch')
+                 WHEN 'updated_at' THEN COALESCE(s.updated_at, s.created_at, 'epoch')
+                 ELSE 'epoch' END)::timestamp with time zone AS sort_time,
+           (CASE WHEN sqlc.narg(sort_key)::text IS NULL
+                 THEN COALESCE(s.created_at, 'epoch')
+                 ELSE 'epoch' END)::timestamp with time zone AS sort_created_at
+      FROM mcp_server s
+      JOIN registry r ON s.reg_id = r.id
+      LEFT JOIN latest_server_version l ON s.id = l.latest_server_id
+     WHERE (sqlc.narg(next)::timestamp with time zone IS NULL OR s.created_at > sqlc.narg(next)::timestamp with time zone)
+       AND (sqlc.narg(prev)::timestamp with time zone IS NULL OR s.created_at < sqlc.narg(prev)::timestamp with time zone)
+       AND (sqlc.narg(registry_name)::text IS NULL OR r.name = sqlc.narg(registry_name)::text)
+       AND (sqlc.narg(search)::text IS NULL OR (
+           LOWER(s.name) LIKE LOWER('%' || sqlc.narg(search)::text || '%')
+           OR LOWER(s.title) LIKE LOWER('%' || sqlc.narg(search)::text || '%')
+           OR LOWER(s.description) LIKE LOWER('%' || sqlc.narg(search)::text || '%')
+       ))
+       AND (sqlc.narg(updated_since)::timestamp with time zone IS NULL OR COALESCE(s.updated_at, s.created_at) > sqlc.narg(updated_since)::timestamp with time zone)
+       AND (sqlc.narg(published_before)::timestamp with time zone IS NULL OR s.created_at < sqlc.narg(published_before)::timestamp with time zone)
+       -- lifecycle status kept by the registry in the official metadata, servers without one are active
+       AND (sqlc.arg(include_deprecated)::boolean
+            OR LOWER(COALESCE(s.server_meta -> 'io.modelcontextprotocol.registry/official' ->> 'status', '')) <> 'deprecated')
+       AND (sqlc.arg(include_deleted)::boolean
+            OR LOWER(COALESCE(s.server_meta -> 'io.modelcontextprotocol.registry/official' ->> 'status', '')) <> 'deleted')
+  ) listed
+ -- servers are listed in a total order: the requested sorting, or else the registry type, name, creation
+ -- timestamp and version, with the server id as the final tie breaker, so that the page after a cursor
+ -- (the sort keys of the last server of the previous page) is found with a keyset condition. Names and
+ -- versions are compared byte-wise and missing timestamps sort as the epoch, although the sync and the
+ -- publish operation always set them.
+ WHERE sqlc.narg(cursor_name)::text IS NULL
+    OR (NOT sqlc.arg(sort_desc)::boolean
+        AND (listed.sort_type_position, listed.sort_time, listed.name COLLATE "C",
+             listed.sort_created_at, listed.version COLLATE "C", listed.id)
+          > (sqlc.arg(cursor_type_position)::integer, sqlc.arg(cursor_time)::timestamp with time zone,
+             sqlc.narg(cursor_name)::text COLLATE "C", sqlc.arg(cursor_created_at)::timestamp with time zone,
+             sqlc.arg(cursor_version)::text COLLATE "C", sqlc.arg(cursor_id)::uuid))
+    OR (sqlc.arg(sort_desc)::boolean
+        AND (listed.sort_type_position, listed.sort_time, listed.name COLLATE "C",
+             listed.sort_created_at, listed.version COLLATE "C", listed.id)
+          < (sqlc.arg(cursor_type_position)::integer, sqlc.arg(cursor_time)::timestamp with time zone,
+             sqlc.narg(cursor_name)::text COLLATE "C", sqlc.arg(cursor_created_at)::timestamp with time zone,
+             sqlc.arg(cursor_version)::text COLLATE "C", sqlc.arg(cursor_id)::uuid))
  ORDER BY
- -- next page sorting
- CASE WHEN sqlc.narg(next)::timestamp with time zone IS NULL THEN r.reg_type END ASC,
- CASE WHEN sqlc.narg(next)::timestamp with time zone IS NULL THEN s.name END ASC,
- CASE WHEN sqlc.narg(next)::timestamp with time zone IS NULL THEN s.created_at END ASC,
- CASE WHEN sqlc.narg(next)::timestamp with time zone IS NULL THEN s.version END ASC, -- acts as tie breaker
- -- previous page sorting
- CASE WHEN sqlc.narg(prev)::timestamp with time zone IS NULL THEN r.reg_type END DESC,
- CASE WHEN sqlc.narg(prev)::timestamp with time zone IS NULL THEN s.name END DESC,
- CASE WHEN sqlc.narg(prev)::timestamp with time zone IS NULL THEN s.created_at END DESC,
- CASE WHEN sqlc.narg(prev)::timestamp with time zone IS NULL THEN s.version END DESC -- acts as tie breaker
+ CASE WHEN NOT sqlc.arg(sort_desc)::boolean THEN listed.sort_type_position END ASC,
+ CASE WHEN NOT sqlc.arg(sort_desc)::boolean THEN listed.sort_time END ASC,
+ CASE WHEN NOT sqlc.arg(sort_desc)::boolean THEN listed.name COLLATE "C" END ASC,
+ CASE WHEN NOT sqlc.arg(sort_desc)::boolean THEN listed.sort_created_at END ASC,
+ CASE WHEN NOT sqlc.arg(sort_desc)::boolean THEN listed.version COLLATE "C" END ASC,
+ CASE WHEN NOT sqlc.arg(sort_desc)::boolean THEN listed.id END ASC,
+ CASE WHEN sqlc.arg(sort_desc)::boolean THEN listed.sort_type_position END DESC,
+ CASE WHEN sqlc.arg(sort_desc)::boolean THEN listed.sort_time END DESC,
+ CASE WHEN sqlc.arg(sort_desc)::boolean THEN listed.name COLLATE "C" END DESC,
+ CASE WHEN sqlc.arg(sort_desc)::boolean THEN listed.sort_created_at END DESC,
+ CASE WHEN sqlc.arg(sort_desc)::boolean THEN listed.version COLLATE "C" END DESC,
+ CASE WHEN sqlc.arg(sort_desc)::boolean THEN listed.id END DESC
  LIMIT sqlc.arg(size)::bigint;
 
 -- name: ListServerVersions :many
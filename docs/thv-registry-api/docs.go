@@ -6,10 +6,10 @@ import "github.com/swaggo/swag/v2"
 
 const docTemplate = `{
     "schemes": {{ marshal .Schemes }},
-    "components": {"schemas":{"github_com_stacklok_toolhive-registry-server_internal_service.RegistryInfo":{"properties":{"createdAt":{"type":"string"},"name":{"type":"string"},"syncStatus":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistrySyncStatus"},"type":{"description":"MANAGED, FILE, REMOTE","type":"string"},"updatedAt":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_service.RegistryListResponse":{"properties":{"registries":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryInfo"},"type":"array","uniqueItems":false}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_service.RegistrySyncStatus":{"properties":{"attemptCount":{"description":"Number of sync attempts","type":"integer"},"lastAttempt":{"description":"Last sync attempt","type":"string"},"lastSyncTime":{"description":"Last successful sync","type":"string"},"message":{"description":"Status or error message","type":"string"},"phase":{"description":"complete, syncing, failed","type":"string"},"serverCount":{"description":"Number of servers in registry","type":"integer"}},"type":"object"},"model.Argument":{"properties":{"choices":{"items":{"type":"string"},"type":"array","uniqueItems":false},"default":{"type":"string"},"description":{"type":"string"},"format":{"$ref":"#/components/schemas/model.Format"},"isRepeated":{"type":"boolean"},"isRequired":{"type":"boolean"},"isSecret":{"type":"boolean"},"name":{"example":"--port","type":"string"},"placeholder":{"type":"string"},"type":{"$ref":"#/components/schemas/model.ArgumentType"},"value":{"type":"string"},"valueHint":{"example":"file_path","type":"string"},"variables":{"additionalProperties":{"$ref":"#/components/schemas/model.Input"},"type":"object"}},"type":"object"},"model.ArgumentType":{"example":"positional","type":"string","x-enum-varnames":["ArgumentTypePositional","ArgumentTypeNamed"]},"model.Format":{"type":"string","x-enum-varnames":["FormatString","FormatNumber","FormatBoolean","FormatFilePath"]},"model.Icon":{"properties":{"mimeType":{"example":"image/png","type":"string"},"sizes":{"items":{"type":"string"},"type":"array","uniqueItems":false},"src":{"example":"https://example.com/icon.png","format":"uri","maxLength":255,"type":"string"},"theme":{"type":"string"}},"type":"object"},"model.Input":{"properties":{"choices":{"items":{"type":"string"},"type":"array","uniqueItems":false},"default":{"type":"string"},"description":{"type":"string"},"format":{"$ref":"#/components/schemas/model.Format"},"isRequired":{"type":"boolean"},"isSecret":{"type":"boolean"},"placeholder":{"type":"string"},"value":{"type":"string"}},"type":"object"},"model.KeyValueInput":{"properties":{"choices":{"items":{"type":"string"},"type":"array","uniqueItems":false},"default":{"type":"string"},"description":{"type":"string"},"format":{"$ref":"#/components/schemas/model.Format"},"isRequired":{"type":"boolean"},"isSecret":{"type":"boolean"},"name":{"example":"SOME_VARIABLE","type":"string"},"placeholder":{"type":"string"},"value":{"type":"string"},"variables":{"additionalProperties":{"$ref":"#/components/schemas/model.Input"},"type":"object"}},"type":"object"},"model.Package":{"properties":{"environmentVariables":{"description":"EnvironmentVariables are set when running the package","items":{"$ref":"#/components/schemas/model.KeyValueInput"},"type":"array","uniqueItems":false},"fileSha256":{"description":"FileSHA256 is the SHA-256 hash for integrity verification (required for mcpb, optional for others)","example":"fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce","pattern":"^[a-f0-9]{64}$","type":"string"},"identifier":{"description":"Identifier is the package identifier:\n  - For NPM/PyPI/NuGet: package name or ID\n  - For OCI: full image reference (e.g., \"ghcr.io/owner/repo:v1.0.0\")\n  - For MCPB: direct download URL","example":"@modelcontextprotocol/server-brave-search","minLength":1,"type":"string"},"packageArguments":{"description":"PackageArguments are passed to the package's binary","items":{"$ref":"#/components/schemas/model.Argument"},"type":"array","uniqueItems":false},"registryBaseUrl":{"description":"RegistryBaseURL is the base URL of the package registry (used by npm, pypi, nuget; not used by oci, mcpb)","example":"https://registry.npmjs.org","format":"uri","type":"string"},"registryType":{"description":"RegistryType indicates how to download packages (e.g., \"npm\", \"pypi\", \"oci\", \"nuget\", \"mcpb\")","example":"npm","minLength":1,"type":"string"},"runtimeArguments":{"description":"RuntimeArguments are passed to the package's runtime command (e.g., docker, npx)","items":{"$ref":"#/components/schemas/model.Argument"},"type":"array","uniqueItems":false},"runtimeHint":{"description":"RunTimeHint suggests the appropriate runtime for the package","example":"npx","type":"string"},"transport":{"$ref":"#/components/schemas/model.Transport"},"version":{"description":"Version is the package version (required for npm, pypi, nuget; optional for mcpb; not used by oci where version is in the identifier)","example":"1.0.2","minLength":1,"type":"string"}},"type":"object"},"model.Repository":{"properties":{"id":{"example":"b94b5f7e-c7c6-d760-2c78-a5e9b8a5b8c9","type":"string"},"source":{"example":"github","type":"string"},"subfolder":{"example":"src/everything","type":"string"},"url":{"example":"https://github.com/modelcontextprotocol/servers","format":"uri","type":"string"}},"type":"object"},"model.Status":{"type":"string","x-enum-varnames":["StatusActive","StatusDeprecated","StatusDeleted"]},"model.Transport":{"description":"Transport is required and specifies the transport protocol configuration","properties":{"headers":{"items":{"$ref":"#/components/schemas/model.KeyValueInput"},"type":"array","uniqueItems":false},"type":{"example":"stdio","type":"string"},"url":{"example":"https://api.example.com/mcp","type":"string"}},"type":"object"},"v0.Metadata":{"properties":{"count":{"type":"integer"},"nextCursor":{"type":"string"}},"type":"object"},"v0.RegistryExtensions":{"properties":{"isLatest":{"type":"boolean"},"publishedAt":{"format":"date-time","type":"string"},"status":{"$ref":"#/components/schemas/model.Status"},"updatedAt":{"format":"date-time","type":"string"}},"type":"object"},"v0.ResponseMeta":{"properties":{"io.modelcontextprotocol.registry/official":{"$ref":"#/components/schemas/v0.RegistryExtensions"}},"type":"object"},"v0.ServerJSON":{"properties":{"$schema":{"example":"https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json","format":"uri","minLength":1,"type":"string"},"_meta":{"$ref":"#/components/schemas/v0.ServerMeta"},"description":{"example":"MCP server providing weather data and forecasts via OpenWeatherMap API","maxLength":100,"minLength":1,"type":"string"},"icons":{"items":{"$ref":"#/components/schemas/model.Icon"},"type":"array","uniqueItems":false},"name":{"example":"io.github.user/weather","maxLength":200,"minLength":3,"pattern":"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$","type":"string"},"packages":{"items":{"$ref":"#/components/schemas/model.Package"},"type":"array","uniqueItems":false},"remotes":{"items":{"$ref":"#/components/schemas/model.Transport"},"type":"array","uniqueItems":false},"repository":{"$ref":"#/components/schemas/model.Repository"},"title":{"example":"Weather API","maxLength":100,"minLength":1,"type":"string"},"version":{"example":"1.0.2","type":"string"},"websiteUrl":{"example":"https://modelcontextprotocol.io/examples","format":"uri","type":"string"}},"type":"object"},"v0.ServerListResponse":{"properties":{"metadata":{"$ref":"#/components/schemas/v0.Metadata"},"servers":{"items":{"$ref":"#/components/schemas/v0.ServerResponse"},"type":"array","uniqueItems":false}},"type":"object"},"v0.ServerMeta":{"properties":{"io.modelcontextprotocol.registry/publisher-provided":{"additionalProperties":{},"type":"object"}},"type":"object"},"v0.ServerResponse":{"properties":{"_meta":{"$ref":"#/components/schemas/v0.ResponseMeta"},"server":{"$ref":"#/components/schemas/v0.ServerJSON"}},"type":"object"}},"securitySchemes":{"BearerAuth":{"description":"OAuth 2.0 Bearer token authentication. Format: \"Bearer {token}\"","in":"header","name":"Authorization","type":"apiKey"}}},
+    "components": {"schemas":{"github_com_stacklok_toolhive-registry-server_internal_annotations.ServerAnnotation":{"properties":{"approvalStatus":{"description":"ApprovalStatus is one of ApprovalStatuses, or empty if not recorded","type":"string"},"notes":{"type":"string"},"ownerTeam":{"type":"string"},"server":{"type":"string"},"updatedAt":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_cache.BudgetStats":{"properties":{"bytes":{"type":"integer"},"caches":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_cache.Stats"},"type":"array","uniqueItems":false},"maxBytes":{"description":"MaxBytes is the memory budget, 0 when unbounded","type":"integer"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_cache.Stats":{"properties":{"bytes":{"description":"Bytes is the approximate size of the cached entries","type":"integer"},"entries":{"description":"Entries is the number of cached entries","type":"integer"},"evictions":{"type":"integer"},"hits":{"type":"integer"},"maxBytes":{"description":"MaxBytes is the quota of the cache, 0 when it is only bound by the budget","type":"integer"},"misses":{"type":"integer"},"name":{"type":"string"},"rejections":{"description":"Rejections counts entries that were not cached because they did not fit","type":"integer"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_clientconfig.Config":{"properties":{"client":{"type":"string"},"config":{"additionalProperties":{},"description":"Config is the JSON block to merge into the configuration file of the client","type":"object"},"servers":{"description":"Servers describe the entries of the configuration","items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_clientconfig.Entry"},"type":"array","uniqueItems":false},"warnings":{"description":"Warnings are what users must know about servers left out or configured partially","items":{"type":"string"},"type":"array","uniqueItems":false}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_clientconfig.Entry":{"properties":{"identifier":{"type":"string"},"key":{"description":"Key is the key of the entry in the configuration","type":"string"},"registryType":{"description":"RegistryType and Identifier identify the package launched, if any","type":"string"},"server":{"type":"string"},"transport":{"description":"Transport and URL identify the remote endpoint connected to, if any","type":"string"},"url":{"type":"string"},"variables":{"description":"Variables are the environment variables, header values and argument values of the entry users\nmust provide","items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_clientconfig.Variable"},"type":"array","uniqueItems":false},"version":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_clientconfig.Variable":{"properties":{"argument":{"description":"Argument is the flag (e.g. \"--port\") or value hint of the argument the value is passed in, if any","type":"string"},"description":{"type":"string"},"header":{"description":"Header is the HTTP header the value is sent in, if any","type":"string"},"name":{"type":"string"},"placeholder":{"description":"Placeholder is the value of the variable in the configuration, to be replaced by users","type":"string"},"secret":{"type":"boolean"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_contextpack.EnvironmentVariable":{"properties":{"default":{"type":"string"},"description":{"type":"string"},"name":{"type":"string"},"required":{"type":"boolean"},"secret":{"type":"boolean"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_contextpack.EnvironmentVariablePage":{"properties":{"environmentVariables":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_contextpack.EnvironmentVariable"},"type":"array","uniqueItems":false},"nextCursor":{"description":"NextCursor fetches the next page, empty on the last page","type":"string"},"server":{"type":"string"},"total":{"description":"Total is the number of environment variables of the server","type":"integer"},"version":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_contextpack.Pack":{"properties":{"caveats":{"description":"Caveats are what an agent should know before recommending the server","items":{"type":"string"},"type":"array","uniqueItems":false},"condensed":{"description":"Condensed is true when parts of the pack were shortened or left out to fit the token budget","type":"boolean"},"environmentVariables":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_contextpack.EnvironmentVariable"},"type":"array","uniqueItems":false},"environmentVariablesCursor":{"description":"EnvironmentVariablesCursor fetches the environment variables left out page by page\n(see EnvironmentVariables)","type":"string"},"estimatedTokens":{"description":"EstimatedTokens is the estimated size of the Markdown rendering of the pack","type":"integer"},"moreEnvironmentVariables":{"description":"MoreEnvironmentVariables is the number of optional environment variables left out","type":"integer"},"moreTools":{"description":"MoreTools is the number of tools left out of Tools","type":"integer"},"quickstart":{"description":"Quickstart are the commands running the server, or connecting to it for remote servers","items":{"type":"string"},"type":"array","uniqueItems":false},"server":{"type":"string"},"summary":{"type":"string"},"tier":{"type":"string"},"title":{"type":"string"},"tools":{"description":"Tools are the names of the tools of the server, as listed in its ToolHive metadata","items":{"type":"string"},"type":"array","uniqueItems":false},"toolsCursor":{"description":"ToolsCursor fetches the tools left out of Tools page by page (see Tools)","type":"string"},"version":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_contextpack.ToolPage":{"properties":{"nextCursor":{"description":"NextCursor fetches the next page, empty on the last page","type":"string"},"server":{"type":"string"},"tools":{"items":{"type":"string"},"type":"array","uniqueItems":false},"total":{"description":"Total is the number of tools of the server","type":"integer"},"version":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_experiment.Report":{"properties":{"experiment":{"type":"string"},"startedAt":{"type":"string"},"variants":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_experiment.VariantStats"},"type":"array","uniqueItems":false}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_experiment.VariantStats":{"properties":{"details":{"description":"Details is the number of search results whose details were fetched","type":"integer"},"installs":{"description":"Installs is the number of search results installed or bundled for installation","type":"integer"},"meanReciprocalRank":{"description":"MeanReciprocalRank is the mean of 1/rank of the search results acted upon, higher is better","type":"number"},"name":{"type":"string"},"searches":{"description":"Searches is the number of searches, paging through results excluded","type":"integer"},"sessions":{"description":"Sessions is the number of sessions that searched","type":"integer"},"weight":{"type":"integer"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_feedback.ServerFeedback":{"properties":{"accepted":{"type":"integer"},"adjustment":{"description":"Adjustment is the score added to the server when ranking search results","type":"integer"},"lastFeedback":{"type":"string"},"rejected":{"type":"integer"},"server":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_kubernetes.InstallStatus":{"properties":{"image":{"description":"Image is the container image the resource runs","type":"string"},"message":{"description":"Message details the phase","type":"string"},"name":{"type":"string"},"namespace":{"description":"Namespace and Name identify the MCPServer resource","type":"string"},"phase":{"description":"Phase is the phase reported by the ToolHive operator, Pending until it reconciles the resource","type":"string","x-enum-varnames":["MCPServerPhasePending","MCPServerPhaseRunning","MCPServerPhaseFailed","MCPServerPhaseTerminating"]},"server":{"description":"Server and Version identify the registry entry the resource was created from","type":"string"},"url":{"description":"URL is the URL the server is reachable at, once running","type":"string"},"version":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_linkcheck.Result":{"properties":{"checkedAt":{"type":"string"},"health":{"description":"Health is registry.HealthOK or registry.HealthStale","type":"string"},"name":{"type":"string"},"problems":{"description":"Problems describe the broken links of stale servers","items":{"type":"string"},"type":"array","uniqueItems":false},"version":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_offline.Artifact":{"properties":{"fetch":{"description":"Fetch are the commands to run on a host with network access","items":{"type":"string"},"type":"array","uniqueItems":false},"mirror":{"description":"Mirror is the package reference in the internal artifact store, if mirrored","type":"string"},"note":{"description":"Note explains what is left to do for artifacts that are not fully mirrored","type":"string"},"publish":{"description":"Publish are the commands to run in the disconnected environment","items":{"type":"string"},"type":"array","uniqueItems":false},"registryType":{"type":"string"},"source":{"description":"Source is the original package reference","type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_offline.Bundle":{"properties":{"artifacts":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_offline.Artifact"},"type":"array","uniqueItems":false},"server":{"$ref":"#/components/schemas/v0.ServerJSON"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_registry.EnvVarReport":{"properties":{"conflicts":{"description":"Conflicts are the names of the variables whose usages conflict","items":{"type":"string"},"type":"array","uniqueItems":false},"shared":{"description":"Shared are the names of the variables used by more than one server","items":{"type":"string"},"type":"array","uniqueItems":false},"variables":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_registry.EnvVarSummary"},"type":"array","uniqueItems":false}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_registry.EnvVarSummary":{"properties":{"conflicts":{"description":"Conflicts explain how the servers disagree on the meaning of the variable","items":{"type":"string"},"type":"array","uniqueItems":false},"name":{"type":"string"},"required":{"description":"Required is true if any server requires the variable","type":"boolean"},"servers":{"description":"Servers are the names of the servers using the variable","items":{"type":"string"},"type":"array","uniqueItems":false},"shared":{"description":"Shared is true if more than one server uses the variable, e.g. a shared API key","type":"boolean"},"usages":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_registry.EnvVarUsage"},"type":"array","uniqueItems":false}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_registry.EnvVarUsage":{"properties":{"choices":{"items":{"type":"string"},"type":"array","uniqueItems":false},"default":{"type":"string"},"description":{"type":"string"},"format":{"type":"string"},"required":{"type":"boolean"},"secret":{"type":"boolean"},"server":{"type":"string"},"version":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_registry.InjectionRisk":{"properties":{"score":{"description":"Score ranges from 0 (no suspicious content) to MaxInjectionRiskScore","type":"integer"},"signals":{"description":"Signals name the suspicious patterns found, e.g. \"instruction-override\"","items":{"type":"string"},"type":"array","uniqueItems":false}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_registry.Installation":{"properties":{"image":{"description":"Image is the container image the resource runs","type":"string"},"name":{"type":"string"},"namespace":{"description":"Namespace and Name identify the MCPServer resource","type":"string"},"version":{"description":"Version is the tag, or digest, of the image","type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_registry.InstalledServer":{"properties":{"installations":{"description":"Installations are the MCPServer resources running one of its images, in any version","items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_registry.Installation"},"type":"array","uniqueItems":false},"name":{"description":"Name is the name of the catalog server","type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_registry.LintIssue":{"properties":{"field":{"description":"Field is the path of the field at fault (e.g. \"packages[0].identifier\"), empty for the whole entry","type":"string"},"message":{"type":"string"},"severity":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_registry.RiskProfile":{"properties":{"credentialLikeValues":{"description":"CredentialLikeValues are the fields whose example values resemble credentials","items":{"type":"string"},"type":"array","uniqueItems":false},"injectionRisk":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_registry.InjectionRisk"},"lifecycleStatus":{"type":"string"},"remotes":{"description":"Remotes are the URLs of the remote endpoints the server connects to","items":{"type":"string"},"type":"array","uniqueItems":false},"secretEnvironmentVariables":{"description":"SecretEnvironmentVariables are the names of the environment variables marked as secrets","items":{"type":"string"},"type":"array","uniqueItems":false},"unpinnedPackages":{"description":"UnpinnedPackages are the identifiers of the packages not pinned by a content digest","items":{"type":"string"},"type":"array","uniqueItems":false}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_registry.Suggestion":{"properties":{"distance":{"type":"integer"},"kind":{"type":"string"},"value":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_registry.TagCount":{"properties":{"count":{"type":"integer"},"tag":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_reviews.Rating":{"properties":{"average":{"description":"Average is the mean rating, rounded to two decimals","type":"number"},"count":{"type":"integer"},"distribution":{"description":"Distribution is the number of reviews of each rating, from MinRating to MaxRating","items":{"type":"integer"},"type":"array","uniqueItems":false},"server":{"type":"string"},"snippets":{"description":"Snippets are the comments of the most recent reviews, shortened","items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_reviews.Snippet"},"type":"array","uniqueItems":false}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_reviews.Review":{"properties":{"author":{"type":"string"},"comment":{"type":"string"},"id":{"description":"ID identifies the review in its source. A review ingested again with the same source and ID\nreplaces the previous one.","type":"string"},"rating":{"description":"Rating is from MinRating to MaxRating","type":"integer"},"server":{"type":"string"},"source":{"description":"Source is where the review was collected, e.g. a survey","type":"string"},"submittedAt":{"description":"SubmittedAt is when the user gave the review, the time of ingestion if not set","type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_reviews.Snippet":{"properties":{"author":{"type":"string"},"comment":{"type":"string"},"rating":{"type":"integer"},"submittedAt":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_sbom.Package":{"properties":{"name":{"type":"string"},"type":{"description":"Type is the package URL type (e.g. \"npm\", \"deb\", \"golang\"), or \"unknown\"","type":"string"},"version":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_sbom.Summary":{"properties":{"ageSeconds":{"description":"AgeSeconds is the time elapsed since the SBOM was fetched","type":"integer"},"digest":{"description":"Digest is the manifest digest of the image the SBOM is attached to","type":"string"},"fetchedAt":{"description":"FetchedAt is when the SBOM was fetched, summaries being cached for a freshness window","type":"string"},"format":{"type":"string"},"image":{"type":"string"},"notablePackages":{"description":"NotablePackages are system libraries and runtimes worth reviewing first","items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_sbom.Package"},"type":"array","uniqueItems":false},"packageCount":{"type":"integer"},"packagesByType":{"additionalProperties":{"type":"integer"},"description":"PackagesByType counts packages per package URL type","type":"object"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_service.RegistryInfo":{"properties":{"createdAt":{"type":"string"},"name":{"type":"string"},"syncStatus":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistrySyncStatus"},"type":{"description":"MANAGED, FILE, REMOTE","type":"string"},"updatedAt":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_service.RegistryListResponse":{"properties":{"registries":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryInfo"},"type":"array","uniqueItems":false}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_service.RegistrySyncStatus":{"properties":{"attemptCount":{"description":"Number of sync attempts","type":"integer"},"contentHash":{"description":"ContentHash is the SHA256 hash of the source data served, identifying the artifact revision","type":"string"},"lastAttempt":{"description":"Last sync attempt","type":"string"},"lastSyncTime":{"description":"Last successful sync","type":"string"},"message":{"description":"Status or error message","type":"string"},"phase":{"description":"complete, syncing, failed","type":"string"},"serverCount":{"description":"Number of servers in registry","type":"integer"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_service_searchstats.ZeroResultQuery":{"properties":{"count":{"type":"integer"},"firstSeen":{"type":"string"},"lastSeen":{"type":"string"},"query":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_setupguide.Guide":{"properties":{"packages":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_setupguide.PackageGuide"},"type":"array","uniqueItems":false},"server":{"type":"string"},"version":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_setupguide.PackageGuide":{"properties":{"identifier":{"type":"string"},"platforms":{"description":"Platforms are the instructions for each platform, a single one without platform when the\nidentifier has no {os} or {arch} placeholder","items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_setupguide.PlatformGuide"},"type":"array","uniqueItems":false},"registryType":{"type":"string"}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_setupguide.PlatformGuide":{"properties":{"platform":{"description":"Platform is the operating system and architecture (e.g. \"linux/amd64\"), empty when the\ndownload is the same for all platforms","type":"string"},"shell":{"description":"Shell is the shell the steps are written for: sh or powershell","type":"string"},"steps":{"items":{"type":"string"},"type":"array","uniqueItems":false},"url":{"type":"string"},"warnings":{"description":"Warnings are what to check that the steps cannot, e.g. an unpublished checksum","items":{"type":"string"},"type":"array","uniqueItems":false}},"type":"object"},"github_com_stacklok_toolhive-registry-server_internal_supervisor.WorkerStatus":{"properties":{"last_error":{"description":"LastError is the error of the last crash, if any","type":"string"},"name":{"type":"string"},"restarts":{"description":"Restarts is the number of times the worker was restarted after a crash","type":"integer"},"started_at":{"description":"StartedAt is when the worker was last started","type":"string"},"state":{"description":"State is one of StateRunning, StateRestarting, StateStopped or StateFailed","type":"string"}},"type":"object"},"internal_api.healthResponse":{"properties":{"status":{"description":"Status is \"healthy\", or \"degraded\" when a background worker crashed","type":"string"},"workers":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_supervisor.WorkerStatus"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.AnnotationListResponse":{"properties":{"annotations":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_annotations.ServerAnnotation"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.AnnotationRequestBody":{"properties":{"approvalStatus":{"description":"ApprovalStatus is approved, pending or rejected","type":"string"},"notes":{"description":"Notes are internal notes on the server","type":"string"},"ownerTeam":{"description":"OwnerTeam is the team owning the server in the organization","type":"string"}},"type":"object"},"internal_api_extension_v0.ApprovalRequestBody":{"properties":{"justification":{"type":"string"},"requester":{"type":"string"}},"type":"object"},"internal_api_extension_v0.ApprovalRequestResponse":{"properties":{"riskProfile":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_registry.RiskProfile"},"server":{"type":"string"},"ticket":{"type":"string"},"url":{"type":"string"},"version":{"type":"string"}},"type":"object"},"internal_api_extension_v0.ApprovalRequiredResponse":{"properties":{"error":{"type":"string"},"reason":{"type":"string"},"ticket":{"description":"Ticket identifies the approval request filed with the approval system, if any","type":"string"}},"type":"object"},"internal_api_extension_v0.BadgeResponse":{"properties":{"color":{"type":"string"},"label":{"type":"string"},"message":{"type":"string"},"schemaVersion":{"type":"integer"}},"type":"object"},"internal_api_extension_v0.ChangelogResponse":{"properties":{"matchesVersion":{"description":"MatchesVersion tells whether the notes are those of the requested version, rather than\nthose of the latest release or the top of the changelog file","type":"boolean"},"name":{"type":"string"},"notes":{"type":"string"},"publishedAt":{"type":"string"},"repository":{"description":"Repository is the URL of the GitHub repository the notes come from","type":"string"},"server":{"type":"string"},"source":{"description":"Source is SourceRelease or SourceChangelogFile","type":"string"},"tag":{"type":"string"},"truncated":{"description":"Truncated tells whether the notes were cut to MaxNotesSize","type":"boolean"},"url":{"type":"string"},"version":{"type":"string"}},"type":"object"},"internal_api_extension_v0.Event":{"properties":{"condition":{"description":"Condition, Status, Reason and Message detail the condition transitions of cluster events","type":"string"},"message":{"type":"string"},"name":{"type":"string"},"namespace":{"description":"Namespace and Name identify the MCPServer resource of cluster events","type":"string"},"reason":{"type":"string"},"server":{"description":"Server and Version identify the server version","type":"string"},"source":{"description":"Source is EventSourceCatalog or EventSourceCluster","type":"string"},"status":{"type":"string"},"time":{"type":"string"},"type":{"description":"Type is EventPublished, EventUpdated or the new lifecycle status (e.g. \"deprecated\") for\ncatalog events, and the type of the kubernetes.ActivityEvent for cluster events","type":"string"},"version":{"type":"string"}},"type":"object"},"internal_api_extension_v0.EventsResponse":{"properties":{"events":{"items":{"$ref":"#/components/schemas/internal_api_extension_v0.Event"},"type":"array","uniqueItems":false},"since":{"type":"string"}},"type":"object"},"internal_api_extension_v0.FeedbackRequestBody":{"properties":{"accepted":{"description":"Accepted is true when the user accepted the server, false when they rejected it","type":"boolean"},"query":{"description":"Query is the search the server was recommended for, if any","type":"string"}},"type":"object"},"internal_api_extension_v0.ImagePlatforms":{"properties":{"error":{"type":"string"},"image":{"type":"string"},"platforms":{"items":{"type":"string"},"type":"array","uniqueItems":false},"supported":{"description":"Supported tells whether the image runs on the requested platform, if one was requested","type":"boolean"}},"type":"object"},"internal_api_extension_v0.ImageSBOM":{"properties":{"error":{"type":"string"},"image":{"type":"string"},"sbom":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_sbom.Summary"}},"type":"object"},"internal_api_extension_v0.IngestReviewsRequestBody":{"properties":{"reviews":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_reviews.Review"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.IngestReviewsResponse":{"properties":{"ingested":{"type":"integer"}},"type":"object"},"internal_api_extension_v0.InjectionRiskResponse":{"properties":{"score":{"description":"Score ranges from 0 (no suspicious content) to MaxInjectionRiskScore","type":"integer"},"server":{"type":"string"},"signals":{"description":"Signals name the suspicious patterns found, e.g. \"instruction-override\"","items":{"type":"string"},"type":"array","uniqueItems":false},"version":{"type":"string"}},"type":"object"},"internal_api_extension_v0.InstallRequestBody":{"properties":{"confirm":{"description":"Confirm creates the MCPServer resource. Without it, the resource that would be\ncreated is returned for review and nothing is installed.","type":"boolean"},"env":{"additionalProperties":{"type":"string"},"description":"Env holds the values of the environment variables of the server. Secret\nvariables cannot be set here and must be given as Secrets instead.","type":"object"},"name":{"description":"Name is the name of the MCPServer resource, derived from the server name when empty","type":"string"},"namespace":{"description":"Namespace is the namespace to create the MCPServer resource in","type":"string"},"secrets":{"description":"Secrets are Kubernetes secrets exposed to the server as environment variables","items":{"$ref":"#/components/schemas/v1alpha1.SecretRef"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.InstallResponse":{"properties":{"confirmed":{"type":"boolean"},"manifest":{"$ref":"#/components/schemas/v1alpha1.MCPServer"},"status":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_kubernetes.InstallStatus"}},"type":"object"},"internal_api_extension_v0.InstallationsResponse":{"properties":{"servers":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_registry.InstalledServer"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.InstalledServersResponse":{"properties":{"servers":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_kubernetes.InstallStatus"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.PackageStats":{"properties":{"error":{"type":"string"},"identifier":{"type":"string"},"latestVersion":{"type":"string"},"outdated":{"description":"Outdated tells whether a newer version was published than the one recorded in the registry","type":"boolean"},"registryType":{"type":"string"},"version":{"description":"Version is the package version recorded in the registry","type":"string"},"weeklyDownloads":{"type":"integer"}},"type":"object"},"internal_api_extension_v0.PackageStatsResponse":{"properties":{"packages":{"items":{"$ref":"#/components/schemas/internal_api_extension_v0.PackageStats"},"type":"array","uniqueItems":false},"server":{"type":"string"},"version":{"type":"string"},"warnings":{"description":"Warnings explain which packages are outdated in the registry","items":{"type":"string"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.PlatformsResponse":{"properties":{"images":{"items":{"$ref":"#/components/schemas/internal_api_extension_v0.ImagePlatforms"},"type":"array","uniqueItems":false},"platform":{"type":"string"},"server":{"type":"string"},"version":{"type":"string"},"warnings":{"description":"Warnings explain which images don't run on the requested platform","items":{"type":"string"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.RatingsResponse":{"properties":{"servers":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_reviews.Rating"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.SBOMResponse":{"properties":{"images":{"items":{"$ref":"#/components/schemas/internal_api_extension_v0.ImageSBOM"},"type":"array","uniqueItems":false},"server":{"type":"string"},"version":{"type":"string"}},"type":"object"},"internal_api_extension_v0.SearchFeedbackResponse":{"properties":{"servers":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_feedback.ServerFeedback"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.StaleServersResponse":{"properties":{"checkedAt":{"description":"CheckedAt is when the last link check completed, absent if none did yet","type":"string"},"servers":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_linkcheck.Result"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.SuggestionsResponse":{"properties":{"search":{"type":"string"},"suggestions":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_registry.Suggestion"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.TagsResponse":{"properties":{"tags":{"description":"Tags are the tags in use with the number of servers carrying each, most used first","items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_registry.TagCount"},"type":"array","uniqueItems":false}},"type":"object"},"internal_api_extension_v0.UninstallResponse":{"properties":{"confirmed":{"type":"boolean"},"status":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_kubernetes.InstallStatus"}},"type":"object"},"internal_api_extension_v0.ValidateServerRequestBody":{"properties":{"entry":{"description":"Entry is a server entry to validate before publishing it","type":"object"},"name":{"description":"Name is the name of a server of the registry to validate, with Version (latest by default)","type":"string"},"version":{"type":"string"}},"type":"object"},"internal_api_extension_v0.ValidateServerResponse":{"properties":{"errors":{"type":"integer"},"issues":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_registry.LintIssue"},"type":"array","uniqueItems":false},"linksChecked":{"description":"LinksChecked is true when the links of the entry were requested to find broken ones.\nOnly the links of entries of the registry, given by name, are checked.","type":"boolean"},"valid":{"description":"Valid is false when an issue is an error","type":"boolean"},"warnings":{"type":"integer"}},"type":"object"},"internal_api_extension_v0.VersionResolutionResponse":{"properties":{"candidates":{"items":{"type":"string"},"type":"array","uniqueItems":false},"constraint":{"type":"string"},"server":{"$ref":"#/components/schemas/v0.ServerJSON"},"version":{"type":"string"}},"type":"object"},"internal_api_extension_v0.ZeroResultSearchesResponse":{"properties":{"queries":{"items":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service_searchstats.ZeroResultQuery"},"type":"array","uniqueItems":false}},"type":"object"},"k8s_io_apimachinery_pkg_apis_meta_v1.ConditionStatus":{"description":"status of the condition, one of True, False, Unknown.\n+required\n+kubebuilder:validation:Required\n+kubebuilder:validation:Enum=True;False;Unknown","type":"string","x-enum-varnames":["ConditionTrue","ConditionFalse","ConditionUnknown"]},"model.Argument":{"properties":{"choices":{"items":{"type":"string"},"type":"array","uniqueItems":false},"default":{"type":"string"},"description":{"type":"string"},"format":{"$ref":"#/components/schemas/model.Format"},"isRepeated":{"type":"boolean"},"isRequired":{"type":"boolean"},"isSecret":{"type":"boolean"},"name":{"example":"--port","type":"string"},"placeholder":{"type":"string"},"type":{"$ref":"#/components/schemas/model.ArgumentType"},"value":{"type":"string"},"valueHint":{"example":"file_path","type":"string"},"variables":{"additionalProperties":{"$ref":"#/components/schemas/model.Input"},"type":"object"}},"type":"object"},"model.ArgumentType":{"example":"positional","type":"string","x-enum-varnames":["ArgumentTypePositional","ArgumentTypeNamed"]},"model.Format":{"type":"string","x-enum-varnames":["FormatString","FormatNumber","FormatBoolean","FormatFilePath"]},"model.Icon":{"properties":{"mimeType":{"example":"image/png","type":"string"},"sizes":{"items":{"type":"string"},"type":"array","uniqueItems":false},"src":{"example":"https://example.com/icon.png","format":"uri","maxLength":255,"type":"string"},"theme":{"type":"string"}},"type":"object"},"model.Input":{"properties":{"choices":{"items":{"type":"string"},"type":"array","uniqueItems":false},"default":{"type":"string"},"description":{"type":"string"},"format":{"$ref":"#/components/schemas/model.Format"},"isRequired":{"type":"boolean"},"isSecret":{"type":"boolean"},"placeholder":{"type":"string"},"value":{"type":"string"}},"type":"object"},"model.KeyValueInput":{"properties":{"choices":{"items":{"type":"string"},"type":"array","uniqueItems":false},"default":{"type":"string"},"description":{"type":"string"},"format":{"$ref":"#/components/schemas/model.Format"},"isRequired":{"type":"boolean"},"isSecret":{"type":"boolean"},"name":{"example":"SOME_VARIABLE","type":"string"},"placeholder":{"type":"string"},"value":{"type":"string"},"variables":{"additionalProperties":{"$ref":"#/components/schemas/model.Input"},"type":"object"}},"type":"object"},"model.Package":{"properties":{"environmentVariables":{"description":"EnvironmentVariables are set when running the package","items":{"$ref":"#/components/schemas/model.KeyValueInput"},"type":"array","uniqueItems":false},"fileSha256":{"description":"FileSHA256 is the SHA-256 hash for integrity verification (required for mcpb, optional for others)","example":"fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce","pattern":"^[a-f0-9]{64}$","type":"string"},"identifier":{"description":"Identifier is the package identifier:\n  - For NPM/PyPI/NuGet: package name or ID\n  - For OCI: full image reference (e.g., \"ghcr.io/owner/repo:v1.0.0\")\n  - For MCPB: direct download URL","example":"@modelcontextprotocol/server-brave-search","minLength":1,"type":"string"},"packageArguments":{"description":"PackageArguments are passed to the package's binary","items":{"$ref":"#/components/schemas/model.Argument"},"type":"array","uniqueItems":false},"registryBaseUrl":{"description":"RegistryBaseURL is the base URL of the package registry (used by npm, pypi, nuget; not used by oci, mcpb)","example":"https://registry.npmjs.org","format":"uri","type":"string"},"registryType":{"description":"RegistryType indicates how to download packages (e.g., \"npm\", \"pypi\", \"oci\", \"nuget\", \"mcpb\")","example":"npm","minLength":1,"type":"string"},"runtimeArguments":{"description":"RuntimeArguments are passed to the package's runtime command (e.g., docker, npx)","items":{"$ref":"#/components/schemas/model.Argument"},"type":"array","uniqueItems":false},"runtimeHint":{"description":"RunTimeHint suggests the appropriate runtime for the package","example":"npx","type":"string"},"transport":{"$ref":"#/components/schemas/model.Transport"},"version":{"description":"Version is the package version (required for npm, pypi, nuget; optional for mcpb; not used by oci where version is in the identifier)","example":"1.0.2","minLength":1,"type":"string"}},"type":"object"},"model.Repository":{"properties":{"id":{"example":"b94b5f7e-c7c6-d760-2c78-a5e9b8a5b8c9","type":"string"},"source":{"example":"github","type":"string"},"subfolder":{"example":"src/everything","type":"string"},"url":{"example":"https://github.com/modelcontextprotocol/servers","format":"uri","type":"string"}},"type":"object"},"model.Status":{"type":"string","x-enum-varnames":["StatusActive","StatusDeprecated","StatusDeleted"]},"model.Transport":{"description":"Transport is required and specifies the transport protocol configuration","properties":{"headers":{"items":{"$ref":"#/components/schemas/model.KeyValueInput"},"type":"array","uniqueItems":false},"type":{"example":"stdio","type":"string"},"url":{"example":"https://api.example.com/mcp","type":"string"}},"type":"object"},"runtime.RawExtension":{"description":"PodTemplateSpec defines the pod template to use for the MCP server\nThis allows for customizing the pod configuration beyond what is provided by the other fields.\nNote that to modify the specific container the MCP server runs in, you must specify\nthe ` + "`" + `mcp` + "`" + ` container name in the PodTemplateSpec.\nThis field accepts a PodTemplateSpec object as JSON/YAML.\n+optional\n+kubebuilder:pruning:PreserveUnknownFields\n+kubebuilder:validation:Type=object","type":"object"},"v0.Metadata":{"properties":{"count":{"type":"integer"},"nextCursor":{"type":"string"}},"type":"object"},"v0.RegistryExtensions":{"properties":{"isLatest":{"type":"boolean"},"publishedAt":{"format":"date-time","type":"string"},"status":{"$ref":"#/components/schemas/model.Status"},"updatedAt":{"format":"date-time","type":"string"}},"type":"object"},"v0.ResponseMeta":{"properties":{"io.modelcontextprotocol.registry/official":{"$ref":"#/components/schemas/v0.RegistryExtensions"}},"type":"object"},"v0.ServerJSON":{"description":"Server is the server entry rewritten to point at the internal mirrors","properties":{"$schema":{"example":"https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json","format":"uri","minLength":1,"type":"string"},"_meta":{"$ref":"#/components/schemas/v0.ServerMeta"},"description":{"example":"MCP server providing weather data and forecasts via OpenWeatherMap API","maxLength":100,"minLength":1,"type":"string"},"icons":{"items":{"$ref":"#/components/schemas/model.Icon"},"type":"array","uniqueItems":false},"name":{"example":"io.github.user/weather","maxLength":200,"minLength":3,"pattern":"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$","type":"string"},"packages":{"items":{"$ref":"#/components/schemas/model.Package"},"type":"array","uniqueItems":false},"remotes":{"items":{"$ref":"#/components/schemas/model.Transport"},"type":"array","uniqueItems":false},"repository":{"$ref":"#/components/schemas/model.Repository"},"title":{"example":"Weather API","maxLength":100,"minLength":1,"type":"string"},"version":{"example":"1.0.2","type":"string"},"websiteUrl":{"example":"https://modelcontextprotocol.io/examples","format":"uri","type":"string"}},"type":"object"},"v0.ServerListResponse":{"properties":{"metadata":{"$ref":"#/components/schemas/v0.Metadata"},"servers":{"items":{"$ref":"#/components/schemas/v0.ServerResponse"},"type":"array","uniqueItems":false}},"type":"object"},"v0.ServerMeta":{"properties":{"io.modelcontextprotocol.registry/publisher-provided":{"additionalProperties":{},"type":"object"}},"type":"object"},"v0.ServerResponse":{"properties":{"_meta":{"$ref":"#/components/schemas/v0.ResponseMeta"},"server":{"$ref":"#/components/schemas/v0.ServerJSON"}},"type":"object"},"v1.Condition":{"properties":{"lastTransitionTime":{"description":"lastTransitionTime is the last time the condition transitioned from one status to another.\nThis should be when the underlying condition changed.  If that is not known, then using the time when the API field changed is acceptable.\n+required\n+kubebuilder:validation:Required\n+kubebuilder:validation:Type=string\n+kubebuilder:validation:Format=date-time","type":"string"},"message":{"description":"message is a human readable message indicating details about the transition.\nThis may be an empty string.\n+required\n+kubebuilder:validation:Required\n+kubebuilder:validation:MaxLength=32768","type":"string"},"observedGeneration":{"description":"observedGeneration represents the .metadata.generation that the condition was set based upon.\nFor instance, if .metadata.generation is currently 12, but the .status.conditions[x].observedGeneration is 9, the condition is out of date\nwith respect to the current state of the instance.\n+optional\n+kubebuilder:validation:Minimum=0","type":"integer"},"reason":{"description":"reason contains a programmatic identifier indicating the reason for the condition's last transition.\nProducers of specific condition types may define expected values and meanings for this field,\nand whether the values are considered a guaranteed API.\nThe value should be a CamelCase string.\nThis field may not be empty.\n+required\n+kubebuilder:validation:Required\n+kubebuilder:validation:MaxLength=1024\n+kubebuilder:validation:MinLength=1\n+kubebuilder:validation:Pattern=` + "`" + `^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$` + "`" + `","type":"string"},"status":{"$ref":"#/components/schemas/k8s_io_apimachinery_pkg_apis_meta_v1.ConditionStatus"},"type":{"description":"type of condition in CamelCase or in foo.example.com/CamelCase.\n---\nMany .condition.type values are consistent across resources like Available, but because arbitrary conditions can be\nuseful (see .node.status.conditions), the ability to deconflict is important.\nThe regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)\n+required\n+kubebuilder:validation:Required\n+kubebuilder:validation:Pattern=` + "`" + `^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$` + "`" + `\n+kubebuilder:validation:MaxLength=316","type":"string"}},"type":"object"},"v1.FieldsV1":{"description":"FieldsV1 holds the first JSON version format as described in the \"FieldsV1\" type.\n+optional","type":"object"},"v1.ManagedFieldsEntry":{"properties":{"apiVersion":{"description":"APIVersion defines the version of this resource that this field set\napplies to. The format is \"group/version\" just like the top-level\nAPIVersion field. It is necessary to track the version of a field\nset because it cannot be automatically converted.","type":"string"},"fieldsType":{"description":"FieldsType is the discriminator for the different fields format and version.\nThere is currently only one possible value: \"FieldsV1\"","type":"string"},"fieldsV1":{"$ref":"#/components/schemas/v1.FieldsV1"},"manager":{"description":"Manager is an identifier of the workflow managing these fields.","type":"string"},"operation":{"$ref":"#/components/schemas/v1.ManagedFieldsOperationType"},"subresource":{"description":"Subresource is the name of the subresource used to update that object, or\nempty string if the object was updated through the main resource. The\nvalue of this field is used to distinguish between managers, even if they\nshare the same name. For example, a status update will be distinct from a\nregular update using the same manager name.\nNote that the APIVersion field is not related to the Subresource field and\nit always corresponds to the version of the main resource.","type":"string"},"time":{"description":"Time is the timestamp of when the ManagedFields entry was added. The\ntimestamp will also be updated if a field is added, the manager\nchanges any of the owned fields value or removes a field. The\ntimestamp does not update when a field is removed from the entry\nbecause another manager took it over.\n+optional","type":"string"}},"type":"object"},"v1.ManagedFieldsOperationType":{"description":"Operation is the type of operation which lead to this ManagedFieldsEntry being created.\nThe only valid values for this field are 'Apply' and 'Update'.","type":"string","x-enum-varnames":["ManagedFieldsOperationApply","ManagedFieldsOperationUpdate"]},"v1.ObjectMeta":{"properties":{"annotations":{"additionalProperties":{"type":"string"},"description":"Annotations is an unstructured key value map stored with a resource that may be\nset by external tools to store and retrieve arbitrary metadata. They are not\nqueryable and should be preserved when modifying objects.\nMore info: https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations\n+optional","type":"object"},"creationTimestamp":{"description":"CreationTimestamp is a timestamp representing the server time when this object was\ncreated. It is not guaranteed to be set in happens-before order across separate operations.\nClients may not set this value. It is represented in RFC3339 form and is in UTC.\n\nPopulated by the system.\nRead-only.\nNull for lists.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata\n+optional","type":"string"},"deletionGracePeriodSeconds":{"description":"Number of seconds allowed for this object to gracefully terminate before\nit will be removed from the system. Only set when deletionTimestamp is also set.\nMay only be shortened.\nRead-only.\n+optional","type":"integer"},"deletionTimestamp":{"description":"DeletionTimestamp is RFC 3339 date and time at which this resource will be deleted. This\nfield is set by the server when a graceful deletion is requested by the user, and is not\ndirectly settable by a client. The resource is expected to be deleted (no longer visible\nfrom resource lists, and not reachable by name) after the time in this field, once the\nfinalizers list is empty. As long as the finalizers list contains items, deletion is blocked.\nOnce the deletionTimestamp is set, this value may not be unset or be set further into the\nfuture, although it may be shortened or the resource may be deleted prior to this time.\nFor example, a user may request that a pod is deleted in 30 seconds. The Kubelet will react\nby sending a graceful termination signal to the containers in the pod. After that 30 seconds,\nthe Kubelet will send a hard termination signal (SIGKILL) to the container and after cleanup,\nremove the pod from the API. In the presence of network partitions, this object may still\nexist after this timestamp, until an administrator or automated process can determine the\nresource is fully terminated.\nIf not set, graceful deletion of the object has not been requested.\n\nPopulated by the system when a graceful deletion is requested.\nRead-only.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata\n+optional","type":"string"},"finalizers":{"description":"Must be empty before the object is deleted from the registry. Each entry\nis an identifier for the responsible component that will remove the entry\nfrom the list. If the deletionTimestamp of the object is non-nil, entries\nin this list can only be removed.\nFinalizers may be processed and removed in any order.  Order is NOT enforced\nbecause it introduces significant risk of stuck finalizers.\nfinalizers is a shared field, any actor with permission can reorder it.\nIf the finalizer list is processed in order, then this can lead to a situation\nin which the component responsible for the first finalizer in the list is\nwaiting for a signal (field value, external system, or other) produced by a\ncomponent responsible for a finalizer later in the list, resulting in a deadlock.\nWithout enforced ordering finalizers are free to order amongst themselves and\nare not vulnerable to ordering changes in the list.\n+optional\n+patchStrategy=merge\n+listType=set","items":{"type":"string"},"type":"array","uniqueItems":false},"generateName":{"description":"GenerateName is an optional prefix, used by the server, to generate a unique\nname ONLY IF the Name field has not been provided.\nIf this field is used, the name returned to the client will be different\nthan the name passed. This value will also be combined with a unique suffix.\nThe provided value has the same validation rules as the Name field,\nand may be truncated by the length of the suffix required to make the value\nunique on the server.\n\nIf this field is specified and the generated name exists, the server will return a 409.\n\nApplied only if Name is not specified.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#idempotency\n+optional","type":"string"},"generation":{"description":"A sequence number representing a specific generation of the desired state.\nPopulated by the system. Read-only.\n+optional","type":"integer"},"labels":{"additionalProperties":{"type":"string"},"description":"Map of string keys and values that can be used to organize and categorize\n(scope and select) objects. May match selectors of replication controllers\nand services.\nMore info: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels\n+optional","type":"object"},"managedFields":{"description":"ManagedFields maps workflow-id and version to the set of fields\nthat are managed by that workflow. This is mostly for internal\nhousekeeping, and users typically shouldn't need to set or\nunderstand this field. A workflow can be the user's name, a\ncontroller's name, or the name of a specific apply path like\n\"ci-cd\". The set of fields is always in the version that the\nworkflow used when modifying the object.\n\n+optional\n+listType=atomic","items":{"$ref":"#/components/schemas/v1.ManagedFieldsEntry"},"type":"array","uniqueItems":false},"name":{"description":"Name must be unique within a namespace. Is required when creating resources, although\nsome resources may allow a client to request the generation of an appropriate name\nautomatically. Name is primarily intended for creation idempotence and configuration\ndefinition.\nCannot be updated.\nMore info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names#names\n+optional","type":"string"},"namespace":{"description":"Namespace defines the space within which each name must be unique. An empty namespace is\nequivalent to the \"default\" namespace, but \"default\" is the canonical representation.\nNot all objects are required to be scoped to a namespace - the value of this field for\nthose objects will be empty.\n\nMust be a DNS_LABEL.\nCannot be updated.\nMore info: https://kubernetes.io/docs/concepts/overview/working-with-objects/namespaces\n+optional","type":"string"},"ownerReferences":{"description":"List of objects depended by this object. If ALL objects in the list have\nbeen deleted, this object will be garbage collected. If this object is managed by a controller,\nthen an entry in this list will point to this controller, with the controller field set to true.\nThere cannot be more than one managing controller.\n+optional\n+patchMergeKey=uid\n+patchStrategy=merge\n+listType=map\n+listMapKey=uid","items":{"$ref":"#/components/schemas/v1.OwnerReference"},"type":"array","uniqueItems":false},"resourceVersion":{"description":"An opaque value that represents the internal version of this object that can\nbe used by clients to determine when objects have changed. May be used for optimistic\nconcurrency, change detection, and the watch operation on a resource or set of resources.\nClients must treat these values as opaque and passed unmodified back to the server.\nThey may only be valid for a particular resource or set of resources.\n\nPopulated by the system.\nRead-only.\nValue must be treated as opaque by clients and .\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#concurrency-control-and-consistency\n+optional","type":"string"},"selfLink":{"description":"Deprecated: selfLink is a legacy read-only field that is no longer populated by the system.\n+optional","type":"string"},"uid":{"description":"UID is the unique in time and space value for this object. It is typically generated by\nthe server on successful creation of a resource and is not allowed to change on PUT\noperations.\n\nPopulated by the system.\nRead-only.\nMore info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names#uids\n+optional","type":"string"}},"type":"object"},"v1.OwnerReference":{"properties":{"apiVersion":{"description":"API version of the referent.","type":"string"},"blockOwnerDeletion":{"description":"If true, AND if the owner has the \"foregroundDeletion\" finalizer, then\nthe owner cannot be deleted from the key-value store until this\nreference is removed.\nSee https://kubernetes.io/docs/concepts/architecture/garbage-collection/#foreground-deletion\nfor how the garbage collector interacts with this field and enforces the foreground deletion.\nDefaults to false.\nTo set this field, a user needs \"delete\" permission of the owner,\notherwise 422 (Unprocessable Entity) will be returned.\n+optional","type":"boolean"},"controller":{"description":"If true, this reference points to the managing controller.\n+optional","type":"boolean"},"kind":{"description":"Kind of the referent.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds","type":"string"},"name":{"description":"Name of the referent.\nMore info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names#names","type":"string"},"uid":{"description":"UID of the referent.\nMore info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names#uids","type":"string"}},"type":"object"},"v1alpha1.AuditConfig":{"description":"Audit defines audit logging configuration for the MCP server\n+optional","properties":{"enabled":{"description":"Enabled controls whether audit logging is enabled\nWhen true, enables audit logging with default configuration\n+kubebuilder:default=false\n+optional","type":"boolean"}},"type":"object"},"v1alpha1.AuthzConfigRef":{"description":"AuthzConfig defines authorization policy configuration for the MCP server\n+optional","properties":{"configMap":{"$ref":"#/components/schemas/v1alpha1.ConfigMapAuthzRef"},"inline":{"$ref":"#/components/schemas/v1alpha1.InlineAuthzConfig"},"type":{"description":"Type is the type of authorization configuration\n+kubebuilder:validation:Enum=configMap;inline\n+kubebuilder:default=configMap","type":"string"}},"type":"object"},"v1alpha1.ConfigMapAuthzRef":{"description":"ConfigMap references a ConfigMap containing authorization configuration\nOnly used when Type is \"configMap\"\n+optional","properties":{"key":{"description":"Key is the key in the ConfigMap that contains the authorization configuration\n+kubebuilder:default=authz.json\n+optional","type":"string"},"name":{"description":"Name is the name of the ConfigMap\n+kubebuilder:validation:Required","type":"string"}},"type":"object"},"v1alpha1.ConfigMapOIDCRef":{"description":"ConfigMap references a ConfigMap containing OIDC configuration\nOnly used when Type is \"configmap\"\n+optional","properties":{"key":{"description":"Key is the key in the ConfigMap that contains the OIDC configuration\n+kubebuilder:default=oidc.json\n+optional","type":"string"},"name":{"description":"Name is the name of the ConfigMap\n+kubebuilder:validation:Required","type":"string"}},"type":"object"},"v1alpha1.EnvVar":{"properties":{"name":{"description":"Name of the environment variable\n+kubebuilder:validation:Required","type":"string"},"value":{"description":"Value of the environment variable\n+kubebuilder:validation:Required","type":"string"}},"type":"object"},"v1alpha1.ExternalAuthConfigRef":{"description":"ExternalAuthConfigRef references a MCPExternalAuthConfig resource for external authentication.\nThe referenced MCPExternalAuthConfig must exist in the same namespace as this MCPServer.\n+optional","properties":{"name":{"description":"Name is the name of the MCPExternalAuthConfig resource\n+kubebuilder:validation:Required","type":"string"}},"type":"object"},"v1alpha1.InlineAuthzConfig":{"description":"Inline contains direct authorization configuration\nOnly used when Type is \"inline\"\n+optional","properties":{"entitiesJson":{"description":"EntitiesJSON is a JSON string representing Cedar entities\n+kubebuilder:default=\"[]\"\n+optional","type":"string"},"policies":{"description":"Policies is a list of Cedar policy strings\n+kubebuilder:validation:Required\n+kubebuilder:validation:MinItems=1","items":{"type":"string"},"type":"array","uniqueItems":false}},"type":"object"},"v1alpha1.InlineOIDCConfig":{"description":"Inline contains direct OIDC configuration\nOnly used when Type is \"inline\"\n+optional","properties":{"audience":{"description":"Audience is the expected audience for the token\n+optional","type":"string"},"clientId":{"description":"ClientID is the OIDC client ID\n+optional","type":"string"},"clientSecret":{"description":"ClientSecret is the client secret for introspection (optional)\nDeprecated: Use ClientSecretRef instead for better security\n+optional","type":"string"},"clientSecretRef":{"$ref":"#/components/schemas/v1alpha1.SecretKeyRef"},"insecureAllowHTTP":{"description":"InsecureAllowHTTP allows HTTP (non-HTTPS) OIDC issuers for development/testing\nWARNING: This is insecure and should NEVER be used in production\nOnly enable for local development, testing, or trusted internal networks\n+kubebuilder:default=false\n+optional","type":"boolean"},"introspectionUrl":{"description":"IntrospectionURL is the URL for token introspection endpoint\n+optional","type":"string"},"issuer":{"description":"Issuer is the OIDC issuer URL\n+kubebuilder:validation:Required","type":"string"},"jwksAllowPrivateIP":{"description":"JWKSAllowPrivateIP allows JWKS/OIDC endpoints on private IP addresses\nUse with caution - only enable for trusted internal IDPs\n+kubebuilder:default=false\n+optional","type":"boolean"},"jwksAuthTokenPath":{"description":"JWKSAuthTokenPath is the path to file containing bearer token for JWKS/OIDC requests\nThe file must be mounted into the pod (e.g., via Secret volume)\n+optional","type":"string"},"jwksUrl":{"description":"JWKSURL is the URL to fetch the JWKS from\n+optional","type":"string"},"protectedResourceAllowPrivateIP":{"description":"ProtectedResourceAllowPrivateIP allows protected resource endpoint on private IP addresses\nUse with caution - only enable for trusted internal IDPs or testing\n+kubebuilder:default=false\n+optional","type":"boolean"},"thvCABundlePath":{"description":"ThvCABundlePath is the path to CA certificate bundle file for HTTPS requests\nThe file must be mounted into the pod (e.g., via ConfigMap or Secret volume)\n+optional","type":"string"}},"type":"object"},"v1alpha1.KubernetesOIDCConfig":{"description":"Kubernetes configures OIDC for Kubernetes service account token validation\nOnly used when Type is \"kubernetes\"\n+optional","properties":{"audience":{"description":"Audience is the expected audience for the token\n+kubebuilder:default=toolhive\n+optional","type":"string"},"introspectionUrl":{"description":"IntrospectionURL is the URL for token introspection endpoint\nIf empty, OIDC discovery will be used to automatically determine the introspection URL\n+optional","type":"string"},"issuer":{"description":"Issuer is the OIDC issuer URL\n+kubebuilder:default=\"https://kubernetes.default.svc\"\n+optional","type":"string"},"jwksUrl":{"description":"JWKSURL is the URL to fetch the JWKS from\nIf empty, OIDC discovery will be used to automatically determine the JWKS URL\n+optional","type":"string"},"namespace":{"description":"Namespace is the namespace of the service account\nIf empty, uses the MCPServer's namespace\n+optional","type":"string"},"serviceAccount":{"description":"ServiceAccount is the name of the service account to validate tokens for\nIf empty, uses the pod's service account\n+optional","type":"string"},"useClusterAuth":{"description":"UseClusterAuth enables using the Kubernetes cluster's CA bundle and service account token\nWhen true, uses /var/run/secrets/kubernetes.io/serviceaccount/ca.crt for TLS verification\nand /var/run/secrets/kubernetes.io/serviceaccount/token for bearer token authentication\nDefaults to true if not specified\n+optional","type":"boolean"}},"type":"object"},"v1alpha1.MCPServer":{"properties":{"apiVersion":{"description":"APIVersion defines the versioned schema of this representation of an object.\nServers should convert recognized schemas to the latest internal value, and\nmay reject unrecognized values.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources\n+optional","type":"string"},"kind":{"description":"Kind is a string value representing the REST resource this object represents.\nServers may infer this from the endpoint the client submits requests to.\nCannot be updated.\nIn CamelCase.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds\n+optional","type":"string"},"metadata":{"$ref":"#/components/schemas/v1.ObjectMeta"},"spec":{"$ref":"#/components/schemas/v1alpha1.MCPServerSpec"},"status":{"$ref":"#/components/schemas/v1alpha1.MCPServerStatus"}},"type":"object"},"v1alpha1.MCPServerPhase":{"description":"Phase is the current phase of the MCPServer\n+optional","type":"string","x-enum-varnames":["MCPServerPhasePending","MCPServerPhaseRunning","MCPServerPhaseFailed","MCPServerPhaseTerminating"]},"v1alpha1.MCPServerSpec":{"properties":{"args":{"description":"Args are additional arguments to pass to the MCP server\n+optional","items":{"type":"string"},"type":"array","uniqueItems":false},"audit":{"$ref":"#/components/schemas/v1alpha1.AuditConfig"},"authzConfig":{"$ref":"#/components/schemas/v1alpha1.AuthzConfigRef"},"env":{"description":"Env are environment variables to set in the MCP server container\n+optional","items":{"$ref":"#/components/schemas/v1alpha1.EnvVar"},"type":"array","uniqueItems":false},"externalAuthConfigRef":{"$ref":"#/components/schemas/v1alpha1.ExternalAuthConfigRef"},"groupRef":{"description":"GroupRef is the name of the MCPGroup this server belongs to\nMust reference an existing MCPGroup in the same namespace\n+optional","type":"string"},"image":{"description":"Image is the container image for the MCP server\n+kubebuilder:validation:Required","type":"string"},"mcpPort":{"description":"McpPort is the port that MCP server listens to\n+kubebuilder:validation:Minimum=1\n+kubebuilder:validation:Maximum=65535\n+optional","type":"integer"},"oidcConfig":{"$ref":"#/components/schemas/v1alpha1.OIDCConfigRef"},"permissionProfile":{"$ref":"#/components/schemas/v1alpha1.PermissionProfileRef"},"podTemplateSpec":{"$ref":"#/components/schemas/runtime.RawExtension"},"port":{"description":"Port is the port to expose the MCP server on\n+kubebuilder:validation:Minimum=1\n+kubebuilder:validation:Maximum=65535\n+kubebuilder:default=8080\nDeprecated: Use ProxyPort instead","type":"integer"},"proxyMode":{"description":"ProxyMode is the proxy mode for stdio transport (sse or streamable-http)\nThis setting is only used when Transport is \"stdio\"\n+kubebuilder:validation:Enum=sse;streamable-http\n+kubebuilder:default=streamable-http\n+optional","type":"string"},"proxyPort":{"description":"ProxyPort is the port to expose the proxy runner on\n+kubebuilder:validation:Minimum=1\n+kubebuilder:validation:Maximum=65535\n+kubebuilder:default=8080","type":"integer"},"resourceOverrides":{"$ref":"#/components/schemas/v1alpha1.ResourceOverrides"},"resources":{"$ref":"#/components/schemas/v1alpha1.ResourceRequirements"},"secrets":{"description":"Secrets are references to secrets to mount in the MCP server container\n+optional","items":{"$ref":"#/components/schemas/v1alpha1.SecretRef"},"type":"array","uniqueItems":false},"serviceAccount":{"description":"ServiceAccount is the name of an already existing service account to use by the MCP server.\nIf not specified, a ServiceAccount will be created automatically and used by the MCP server.\n+optional","type":"string"},"targetPort":{"description":"TargetPort is the port that MCP server listens to\n+kubebuilder:validation:Minimum=1\n+kubebuilder:validation:Maximum=65535\n+optional\nDeprecated: Use McpPort instead","type":"integer"},"telemetry":{"$ref":"#/components/schemas/v1alpha1.TelemetryConfig"},"toolConfigRef":{"$ref":"#/components/schemas/v1alpha1.ToolConfigRef"},"tools":{"description":"ToolsFilter is the filter on tools applied to the MCP server\nDeprecated: Use ToolConfigRef instead\n+optional","items":{"type":"string"},"type":"array","uniqueItems":false},"transport":{"description":"Transport is the transport method for the MCP server (stdio, streamable-http or sse)\n+kubebuilder:validation:Enum=stdio;streamable-http;sse\n+kubebuilder:default=stdio","type":"string"},"trustProxyHeaders":{"description":"TrustProxyHeaders indicates whether to trust X-Forwarded-* headers from reverse proxies\nWhen enabled, the proxy will use X-Forwarded-Proto, X-Forwarded-Host, X-Forwarded-Port,\nand X-Forwarded-Prefix headers to construct endpoint URLs\n+kubebuilder:default=false\n+optional","type":"boolean"},"volumes":{"description":"Volumes are volumes to mount in the MCP server container\n+optional","items":{"$ref":"#/components/schemas/v1alpha1.Volume"},"type":"array","uniqueItems":false}},"type":"object"},"v1alpha1.MCPServerStatus":{"properties":{"conditions":{"description":"Conditions represent the latest available observations of the MCPServer's state\n+optional","items":{"$ref":"#/components/schemas/v1.Condition"},"type":"array","uniqueItems":false},"externalAuthConfigHash":{"description":"ExternalAuthConfigHash is the hash of the referenced MCPExternalAuthConfig spec\n+optional","type":"string"},"message":{"description":"Message provides additional information about the current phase\n+optional","type":"string"},"phase":{"$ref":"#/components/schemas/v1alpha1.MCPServerPhase"},"toolConfigHash":{"description":"ToolConfigHash stores the hash of the referenced ToolConfig for change detection\n+optional","type":"string"},"url":{"description":"URL is the URL where the MCP server can be accessed\n+optional","type":"string"}},"type":"object"},"v1alpha1.OIDCConfigRef":{"description":"OIDCConfig defines OIDC authentication configuration for the MCP server\n+optional","properties":{"configMap":{"$ref":"#/components/schemas/v1alpha1.ConfigMapOIDCRef"},"inline":{"$ref":"#/components/schemas/v1alpha1.InlineOIDCConfig"},"kubernetes":{"$ref":"#/components/schemas/v1alpha1.KubernetesOIDCConfig"},"resourceUrl":{"description":"ResourceURL is the explicit resource URL for OAuth discovery endpoint (RFC 9728)\nIf not specified, defaults to the in-cluster Kubernetes service URL\n+optional","type":"string"},"type":{"description":"Type is the type of OIDC configuration\n+kubebuilder:validation:Enum=kubernetes;configMap;inline\n+kubebuilder:default=kubernetes","type":"string"}},"type":"object"},"v1alpha1.OpenTelemetryConfig":{"description":"OpenTelemetry defines OpenTelemetry configuration\n+optional","properties":{"enabled":{"description":"Enabled controls whether OpenTelemetry is enabled\n+kubebuilder:default=false\n+optional","type":"boolean"},"endpoint":{"description":"Endpoint is the OTLP endpoint URL for tracing and metrics\n+optional","type":"string"},"headers":{"description":"Headers contains authentication headers for the OTLP endpoint\nSpecified as key=value pairs\n+optional","items":{"type":"string"},"type":"array","uniqueItems":false},"insecure":{"description":"Insecure indicates whether to use HTTP instead of HTTPS for the OTLP endpoint\n+kubebuilder:default=false\n+optional","type":"boolean"},"metrics":{"$ref":"#/components/schemas/v1alpha1.OpenTelemetryMetricsConfig"},"serviceName":{"description":"ServiceName is the service name for telemetry\nIf not specified, defaults to the MCPServer name\n+optional","type":"string"},"tracing":{"$ref":"#/components/schemas/v1alpha1.OpenTelemetryTracingConfig"}},"type":"object"},"v1alpha1.OpenTelemetryMetricsConfig":{"description":"Metrics defines OpenTelemetry metrics-specific configuration\n+optional","properties":{"enabled":{"description":"Enabled controls whether OTLP metrics are sent\n+kubebuilder:default=false\n+optional","type":"boolean"}},"type":"object"},"v1alpha1.OpenTelemetryTracingConfig":{"description":"Tracing defines OpenTelemetry tracing configuration\n+optional","properties":{"enabled":{"description":"Enabled controls whether OTLP tracing is sent\n+kubebuilder:default=false\n+optional","type":"boolean"},"samplingRate":{"description":"SamplingRate is the trace sampling rate (0.0-1.0)\n+kubebuilder:default=\"0.05\"\n+optional","type":"string"}},"type":"object"},"v1alpha1.PermissionProfileRef":{"description":"PermissionProfile defines the permission profile to use\n+optional","properties":{"key":{"description":"Key is the key in the ConfigMap that contains the permission profile\nOnly used when Type is \"configmap\"\n+optional","type":"string"},"name":{"description":"Name is the name of the permission profile\nIf Type is \"builtin\", Name must be one of: \"none\", \"network\"\nIf Type is \"configmap\", Name is the name of the ConfigMap\n+kubebuilder:validation:Required","type":"string"},"type":{"description":"Type is the type of permission profile reference\n+kubebuilder:validation:Enum=builtin;configmap\n+kubebuilder:default=builtin","type":"string"}},"type":"object"},"v1alpha1.PrometheusConfig":{"description":"Prometheus defines Prometheus-specific configuration\n+optional","properties":{"enabled":{"description":"Enabled controls whether Prometheus metrics endpoint is exposed\n+kubebuilder:default=false\n+optional","type":"boolean"}},"type":"object"},"v1alpha1.ProxyDeploymentOverrides":{"description":"ProxyDeployment defines overrides for the Proxy Deployment resource (toolhive proxy)\n+optional","properties":{"annotations":{"additionalProperties":{"type":"string"},"description":"Annotations to add or override on the resource\n+optional","type":"object"},"env":{"description":"Env are environment variables to set in the proxy container (thv run process)\nThese affect the toolhive proxy itself, not the MCP server it manages\nUse TOOLHIVE_DEBUG=true to enable debug logging in the proxy\n+optional","items":{"$ref":"#/components/schemas/v1alpha1.EnvVar"},"type":"array","uniqueItems":false},"labels":{"additionalProperties":{"type":"string"},"description":"Labels to add or override on the resource\n+optional","type":"object"},"podTemplateMetadataOverrides":{"$ref":"#/components/schemas/v1alpha1.ResourceMetadataOverrides"}},"type":"object"},"v1alpha1.ResourceList":{"description":"Requests describes the minimum amount of compute resources required\n+optional","properties":{"cpu":{"description":"CPU is the CPU limit in cores (e.g., \"500m\" for 0.5 cores)\n+optional","type":"string"},"memory":{"description":"Memory is the memory limit in bytes (e.g., \"64Mi\" for 64 megabytes)\n+optional","type":"string"}},"type":"object"},"v1alpha1.ResourceMetadataOverrides":{"description":"ProxyService defines overrides for the Proxy Service resource (points to the proxy deployment)\n+optional","properties":{"annotations":{"additionalProperties":{"type":"string"},"description":"Annotations to add or override on the resource\n+optional","type":"object"},"labels":{"additionalProperties":{"type":"string"},"description":"Labels to add or override on the resource\n+optional","type":"object"}},"type":"object"},"v1alpha1.ResourceOverrides":{"description":"ResourceOverrides allows overriding annotations and labels for resources created by the operator\n+optional","properties":{"proxyDeployment":{"$ref":"#/components/schemas/v1alpha1.ProxyDeploymentOverrides"},"proxyService":{"$ref":"#/components/schemas/v1alpha1.ResourceMetadataOverrides"}},"type":"object"},"v1alpha1.ResourceRequirements":{"description":"Resources defines the resource requirements for the MCP server container\n+optional","properties":{"limits":{"$ref":"#/components/schemas/v1alpha1.ResourceList"},"requests":{"$ref":"#/components/schemas/v1alpha1.ResourceList"}},"type":"object"},"v1alpha1.SecretKeyRef":{"description":"ClientSecretRef is a reference to a Kubernetes Secret containing the client secret\nIf both ClientSecret and ClientSecretRef are provided, ClientSecretRef takes precedence\n+optional","properties":{"key":{"description":"Key is the key within the secret\n+kubebuilder:validation:Required","type":"string"},"name":{"description":"Name is the name of the secret\n+kubebuilder:validation:Required","type":"string"}},"type":"object"},"v1alpha1.SecretRef":{"properties":{"key":{"description":"Key is the key in the secret itself\n+kubebuilder:validation:Required","type":"string"},"name":{"description":"Name is the name of the secret\n+kubebuilder:validation:Required","type":"string"},"targetEnvName":{"description":"TargetEnvName is the environment variable to be used when setting up the secret in the MCP server\nIf left unspecified, it defaults to the key\n+optional","type":"string"}},"type":"object"},"v1alpha1.TelemetryConfig":{"description":"Telemetry defines observability configuration for the MCP server\n+optional","properties":{"openTelemetry":{"$ref":"#/components/schemas/v1alpha1.OpenTelemetryConfig"},"prometheus":{"$ref":"#/components/schemas/v1alpha1.PrometheusConfig"}},"type":"object"},"v1alpha1.ToolConfigRef":{"description":"ToolConfigRef references a MCPToolConfig resource for tool filtering and renaming.\nThe referenced MCPToolConfig must exist in the same namespace as this MCPServer.\nCross-namespace references are not supported for security and isolation reasons.\nIf specified, this takes precedence over the inline ToolsFilter field.\n+optional","properties":{"name":{"description":"Name is the name of the MCPToolConfig resource in the same namespace\n+kubebuilder:validation:Required","type":"string"}},"type":"object"},"v1alpha1.Volume":{"properties":{"hostPath":{"description":"HostPath is the path on the host to mount\n+kubebuilder:validation:Required","type":"string"},"mountPath":{"description":"MountPath is the path in the container to mount to\n+kubebuilder:validation:Required","type":"string"},"name":{"description":"Name is the name of the volume\n+kubebuilder:validation:Required","type":"string"},"readOnly":{"description":"ReadOnly specifies whether the volume should be mounted read-only\n+kubebuilder:default=false\n+optional","type":"boolean"}},"type":"object"}},"securitySchemes":{"BearerAuth":{"description":"OAuth 2.0 Bearer token authentication. Format: \"Bearer {token}\"","in":"header","name":"Authorization","type":"apiKey"}}},
     "info": {"contact":{"url":"https://github.com/stacklok/toolhive"},"description":"{{escape .Description}}","license":{"name":"Apache 2.0","url":"http://www.apache.org/licenses/LICENSE-2.0.html"},"title":"{{.Title}}","version":"{{.Version}}"},
     "externalDocs": {"description":"","url":""},
-    "paths": {"/extension/v0/registries":{"get":{"description":"List all registries","requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryListResponse"}}},"description":"List of registries"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"List registries","tags":["extension"]}},"/extension/v0/registries/{registryName}":{"delete":{"description":"Delete a registry","parameters":[{"description":"Registry Name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Delete registry","tags":["extension"]},"get":{"description":"Get a registry by name","parameters":[{"description":"Registry Name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryInfo"}}},"description":"Registry details"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Registry not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Get registry","tags":["extension"]},"put":{"description":"Create or update a registry","parameters":[{"description":"Registry Name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Create or update registry","tags":["extension"]}},"/extension/v0/registries/{registryName}/servers/{serverName}/versions/{version}":{"put":{"description":"Create or update a server in the registry","parameters":[{"description":"Registry Name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version to retrieve (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Create or update server","tags":["extension"]}},"/health":{"get":{"description":"Check if the registry API is healthy","responses":{"200":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"OK"}},"summary":"Health check","tags":["system"]}},"/openapi.json":{"get":{"description":"Get the OpenAPI 3.1.0 specification for this API","responses":{"200":{"content":{"application/json":{"schema":{"type":"object"}}},"description":"OpenAPI 3.1.0 specification"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal Server Error"}},"summary":"OpenAPI specification","tags":["system"]}},"/readiness":{"get":{"description":"Check if the registry API is ready to serve requests","responses":{"200":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"OK"},"503":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Service Unavailable"}},"summary":"Readiness check","tags":["system"]}},"/registry/v0.1/publish":{"post":{"description":"Publish a server to the registry. This server does not support publishing via this endpoint.\nUse the registry-specific endpoint /{registryName}/v0.1/publish instead.","requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Publish server","tags":["registry","official"]}},"/registry/v0.1/servers":{"get":{"description":"Get a list of available servers in the registry","parameters":[{"description":"Pagination cursor for retrieving next set of results","in":"query","name":"cursor","schema":{"type":"string"}},{"description":"Maximum number of items to return","in":"query","name":"limit","schema":{"type":"integer"}},{"description":"Search servers by name (substring match)","in":"query","name":"search","schema":{"type":"string"}},{"description":"Filter by version ('latest' for latest version, or an exact version like '1.2.3')","in":"query","name":"version","schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerListResponse"}}},"description":"OK"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"}},"security":[{"BearerAuth":[]}],"summary":"List servers","tags":["registry","official"]}},"/registry/v0.1/servers/{serverName}/versions":{"get":{"description":"Returns all available versions for a specific MCP server, ordered by publication date (newest first)","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerListResponse"}}},"description":"A list of all versions for the server"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"}},"security":[{"BearerAuth":[]}],"summary":"List all versions of an MCP server","tags":["registry","official"]}},"/registry/v0.1/servers/{serverName}/versions/{version}":{"get":{"description":"Returns detailed information about a specific version of an MCP server.\nUse the special version ` + "`" + `latest` + "`" + ` to get the latest version.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version to retrieve (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerResponse"}}},"description":"Detailed server information"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server or version not found"}},"security":[{"BearerAuth":[]}],"summary":"Get specific MCP server version","tags":["registry","official"]}},"/registry/{registryName}/v0.1/servers":{"get":{"description":"Get a list of available servers in the registry","parameters":[{"description":"Registry name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}},{"description":"Pagination cursor for retrieving next set of results","in":"query","name":"cursor","schema":{"type":"string"}},{"description":"Maximum number of items to return","in":"query","name":"limit","schema":{"type":"integer"}},{"description":"Search servers by name (substring match)","in":"query","name":"search","schema":{"type":"string"}},{"description":"Filter by version ('latest' for latest version, or an exact version like '1.2.3')","in":"query","name":"version","schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerListResponse"}}},"description":"OK"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"}},"security":[{"BearerAuth":[]}],"summary":"List servers","tags":["registry","official"]}},"/registry/{registryName}/v0.1/servers/{serverName}/versions":{"get":{"description":"Returns all available versions for a specific MCP server, ordered by publication date (newest first)","parameters":[{"description":"Registry name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerListResponse"}}},"description":"A list of all versions for the server"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"}},"security":[{"BearerAuth":[]}],"summary":"List all versions of an MCP server","tags":["registry","official"]}},"/registry/{registryName}/v0.1/servers/{serverName}/versions/{version}":{"get":{"description":"Returns detailed information about a specific version of an MCP server.\nUse the special version ` + "`" + `latest` + "`" + ` to get the latest version.","parameters":[{"description":"Registry name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version to retrieve (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerResponse"}}},"description":"Detailed server information"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server or version not found"}},"security":[{"BearerAuth":[]}],"summary":"Get specific MCP server version","tags":["registry","official"]}},"/version":{"get":{"description":"Get version information about the registry API","responses":{"200":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"OK"}},"summary":"Version information","tags":["system"]}},"/{registryName}/v0.1/publish":{"post":{"description":"Publish a server version to a specific managed registry","parameters":[{"description":"Registry name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerJSON"}}},"description":"Server data","required":true},"responses":{"201":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerJSON"}}},"description":"Created"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not a managed registry"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Registry not found"},"409":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Version already exists"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Publish server to specific registry","tags":["registry","official"]}},"/{registryName}/v0.1/servers/{serverName}/versions/{version}":{"delete":{"description":"Delete a server version from a specific managed registry","parameters":[{"description":"Registry name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}},{"description":"Server name (URL-encoded)","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"Version (URL-encoded)","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"204":{"description":"No content"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not a managed registry"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server version not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Delete server version from specific registry","tags":["registry","official"]}}},
+    "paths": {"/extension/v0/annotations":{"get":{"description":"List the annotations operators recorded about servers for the users of this deployment, by server name","parameters":[{"description":"Filter by approval status: approved, pending, or rejected","in":"query","name":"approval_status","schema":{"type":"string"}},{"description":"Filter by owner team","in":"query","name":"owner_team","schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.AnnotationListResponse"}}},"description":"Annotations of servers"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"List server annotations","tags":["extension"]}},"/extension/v0/badges/{serverName}/{badge}":{"get":{"description":"Get a shields.io-compatible badge for the latest version of a server.\nSupported badges are ` + "`" + `stars` + "`" + `, ` + "`" + `tier` + "`" + `, and ` + "`" + `status` + "`" + `.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"Badge kind (stars, tier, status)","in":"path","name":"badge","required":true,"schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.BadgeResponse"}}},"description":"shields.io endpoint badge"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Get server badge","tags":["extension"]}},"/extension/v0/client-config":{"get":{"description":"Generate the configuration of an MCP client (claude-desktop, cursor, vscode, windsurf or zed) launching\nor connecting to several servers, as a single JSON block to merge into its configuration file. Servers\nexposing a remote endpoint are connected to by URL with their headers; others are launched from their\nfirst package the client can start. Environment variables and header values users must provide are set\nto placeholders (inputs prompted for by vscode). Other servers are left out with a warning.\nDefault values resembling credentials are replaced with placeholders.\nWhen an approval system is configured, servers it has not approved are answered with 403.","parameters":[{"description":"Client to configure: claude-desktop, cursor, vscode, windsurf or zed","in":"query","name":"client","required":true,"schema":{"type":"string"}},{"description":"Server name with optional @version suffix (latest by default)","in":"query","name":"server","required":true,"schema":{"items":{"type":"string"},"type":"array"},"style":"form"}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_clientconfig.Config"}}},"description":"Client configuration"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.ApprovalRequiredResponse"}}},"description":"Server requires approval"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"503":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Approval check failed"}},"security":[{"BearerAuth":[]}],"summary":"Generate client configuration","tags":["extension"]}},"/extension/v0/events":{"get":{"description":"Get a single feed of the recent changes of the catalog (server versions published, updated,\ndeprecated or deleted) and, when cluster installation is enabled, of the lifecycle events of\nthe servers installed in the cluster (installed, uninstalling and status condition changes),\nmost recent first.","parameters":[{"description":"Only report events at or after this time (RFC3339, default 24 hours ago)","in":"query","name":"since","schema":{"type":"string"}},{"description":"Only report events of this source: catalog or cluster","in":"query","name":"source","schema":{"type":"string"}},{"description":"Only report the events of this server","in":"query","name":"server","schema":{"type":"string"}},{"description":"Only report the cluster events of this namespace","in":"query","name":"namespace","schema":{"type":"string"}},{"description":"Maximum number of events to return (default 100, max 1000)","in":"query","name":"limit","schema":{"type":"integer"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.EventsResponse"}}},"description":"Recent events"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Access denied by the cluster"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Get recent events","tags":["extension"]}},"/extension/v0/installations":{"get":{"description":"List the catalog servers already running in the cluster, with the namespace, name and image version\nof the MCPServer resources running them. Resources are read from the Kubernetes registries and\nmatch a catalog server when their image is one of its OCI packages, regardless of version.","parameters":[{"description":"Only report the installations of this server","in":"query","name":"server","schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.InstallationsResponse"}}},"description":"Installed servers"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"List installed servers","tags":["extension"]}},"/extension/v0/installed-servers":{"get":{"description":"List the MCPServer resources installed from the registry with the install endpoint, with their\nstatus as reported by the ToolHive operator. Resources created by other means are not listed.","parameters":[{"description":"Only list the resources of this namespace","in":"query","name":"namespace","schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.InstalledServersResponse"}}},"description":"Installed servers"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Access denied by the cluster"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Cluster installation not configured"}},"security":[{"BearerAuth":[]}],"summary":"List servers installed in the cluster","tags":["extension"]}},"/extension/v0/installed-servers/{namespace}/{name}":{"delete":{"description":"Delete an MCPServer resource installed from the registry, which the ToolHive operator then tears\ndown. Unless confirm is set, the resource is only returned for review. Resources created by other\nmeans are never deleted and are answered with 404. Restricted to the administrators of the API.","parameters":[{"description":"Namespace of the MCPServer resource","in":"path","name":"namespace","required":true,"schema":{"type":"string"}},{"description":"Name of the MCPServer resource","in":"path","name":"name","required":true,"schema":{"type":"string"}},{"description":"Delete the resource","in":"query","name":"confirm","schema":{"type":"boolean"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.UninstallResponse"}}},"description":"Resource to delete, or deleted"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Caller is not an administrator, or access denied by the cluster"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not installed"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Cluster installation not configured"}},"security":[{"BearerAuth":[]}],"summary":"Uninstall server from the cluster","tags":["extension"]}},"/extension/v0/registries":{"get":{"description":"List all registries","requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryListResponse"}}},"description":"List of registries"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"List registries","tags":["extension"]}},"/extension/v0/registries/{registryName}":{"delete":{"description":"Delete a registry","parameters":[{"description":"Registry Name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Delete registry","tags":["extension"]},"get":{"description":"Get a registry by name","parameters":[{"description":"Registry Name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_service.RegistryInfo"}}},"description":"Registry details"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Registry not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Get registry","tags":["extension"]},"put":{"description":"Create or update a registry","parameters":[{"description":"Registry Name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Create or update registry","tags":["extension"]}},"/extension/v0/registries/{registryName}/servers/{serverName}/versions/{version}":{"put":{"description":"Create or update a server in the registry","parameters":[{"description":"Registry Name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version to retrieve (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Create or update server","tags":["extension"]}},"/extension/v0/reports/caches":{"get":{"description":"Report the memory used by the in-memory caches against their quotas and the shared\nmemory budget, along with hit, miss and eviction counts since the server started.","parameters":[{"description":"Add the timing breakdown of the report to its metadata (default false)","in":"query","name":"debug_timings","schema":{"type":"boolean"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_cache.BudgetStats"}}},"description":"Cache usage"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Cache usage report","tags":["extension"]}},"/extension/v0/reports/env-vars":{"get":{"description":"Aggregate the environment variables required by several servers, to configure them side by side.\nVariables used by more than one server are reported as shared (e.g. a common API key), and as\nconflicting when the servers disagree on their format, secrecy, or allowed values.\nDefault values resembling credentials are replaced with placeholders.","parameters":[{"description":"Server name with optional @version suffix (latest by default)","in":"query","name":"server","required":true,"schema":{"items":{"type":"string"},"type":"array"},"style":"form"},{"description":"Add the timing breakdown of the report to its metadata (default false)","in":"query","name":"debug_timings","schema":{"type":"boolean"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_registry.EnvVarReport"}}},"description":"Environment variable report"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Environment variable compatibility report","tags":["extension"]}},"/extension/v0/reports/ratings":{"get":{"description":"Compare the ratings of the given servers side by side, in the given order, or list the best rated\nservers, highest average first, when no server is given. The report can be rendered as a Markdown\nor CSV table with one row per server and the columns server, average, count, rating_1 to rating_5\n(number of reviews of each rating) and latest_comment.","parameters":[{"description":"Name of a server to compare","in":"query","name":"server","schema":{"items":{"type":"string"},"type":"array"},"style":"form"},{"description":"Maximum number of servers to return without server (default 50)","in":"query","name":"limit","schema":{"type":"integer"}},{"description":"Output format: json (default), markdown or csv table","in":"query","name":"format","schema":{"type":"string"}},{"description":"Comma-separated table columns (default server,average,count)","in":"query","name":"columns","schema":{"type":"string"}},{"description":"Add the timing breakdown of the report to its metadata (default false)","in":"query","name":"debug_timings","schema":{"type":"boolean"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.RatingsResponse"}},"text/csv":{"schema":{"type":"string"}}},"description":"Ratings of servers"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Ratings report","tags":["extension"]}},"/extension/v0/reports/search-experiment":{"get":{"description":"Report the outcome signals of the search ranking experiment for each of its variants: the number\nof searches, the search results whose details were fetched or that were installed, and the mean\nreciprocal rank of those results. Signals are tracked in memory since the server started.","parameters":[{"description":"Add the timing breakdown of the report to its metadata (default false)","in":"query","name":"debug_timings","schema":{"type":"boolean"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_experiment.Report"}}},"description":"Search experiment report"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Search experiment report","tags":["extension"]}},"/extension/v0/reports/search-feedback":{"get":{"description":"List the servers clients accepted or rejected as search results, with the score their feedback\nadds to their ranking, largest adjustments first. Feedback is kept in memory since the server started.","parameters":[{"description":"Maximum number of servers to return (default 50)","in":"query","name":"limit","schema":{"type":"integer"}},{"description":"Add the timing breakdown of the report to its metadata (default false)","in":"query","name":"debug_timings","schema":{"type":"boolean"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.SearchFeedbackResponse"}}},"description":"Search feedback"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Search feedback report","tags":["extension"]}},"/extension/v0/reports/stale-servers":{"get":{"description":"List the servers flagged as stale by the last periodic link check, with the problems found:\nbroken repository or website URLs, archived repositories and packages missing from npm or PyPI.","parameters":[{"description":"Add the timing breakdown of the report to its metadata (default false)","in":"query","name":"debug_timings","schema":{"type":"boolean"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.StaleServersResponse"}}},"description":"Stale servers"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Stale servers report","tags":["extension"]}},"/extension/v0/reports/zero-result-searches":{"get":{"description":"List recent search queries that returned no servers, most frequent first.\nQueries are tracked in memory since the server started and normalized to lower case.\nRestricted to the administrators of the API, since queries may reveal what users look for.","parameters":[{"description":"Maximum number of queries to return (default 50)","in":"query","name":"limit","schema":{"type":"integer"}},{"description":"Add the timing breakdown of the report to its metadata (default false)","in":"query","name":"debug_timings","schema":{"type":"boolean"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.ZeroResultSearchesResponse"}}},"description":"Zero-result searches"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Caller is not an administrator"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Zero-result searches report","tags":["extension"]}},"/extension/v0/reviews":{"post":{"description":"Ingest the ratings (1 to 5) and reviews users gave servers, e.g. collected by an internal survey or a\ncompanion UI. A review with the source and ID of a previously ingested one replaces it. Either all\nreviews of a request are ingested, or none. Restricted to the administrators of the API.","requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.IngestReviewsRequestBody"}}},"description":"Reviews to ingest","required":true},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.IngestReviewsResponse"}}},"description":"Number of reviews ingested"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Caller is not an administrator"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Ingest reviews","tags":["extension"]}},"/extension/v0/servers/{serverName}/annotation":{"delete":{"description":"Delete the annotation operators recorded about a server. Restricted to the administrators of the API.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}}],"responses":{"204":{"description":"Annotation deleted"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Caller is not an administrator"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server has no annotation"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Delete server annotation","tags":["extension"]},"get":{"description":"Get the annotation operators recorded about a server for the users of this deployment","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_annotations.ServerAnnotation"}}},"description":"Annotation of the server"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server has no annotation"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Get server annotation","tags":["extension"]},"put":{"description":"Record internal notes, an approval status and an owner team about a server, replacing its previous\nannotation. Annotations are only visible in this deployment, under the io.github.stacklok/annotation\nmetadata key of the server in registry API responses. Restricted to the administrators of the API.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.AnnotationRequestBody"}}},"description":"Annotation of the server","required":true},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_annotations.ServerAnnotation"}}},"description":"Annotation recorded for the server"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Caller is not an administrator"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Set server annotation","tags":["extension"]}},"/extension/v0/servers/{serverName}/feedback":{"post":{"description":"Report that the user accepted or rejected a server recommended by a search. Accepted servers rank\nhigher in later searches of this deployment and rejected servers lower, within the configured cap.\nFeedback is kept in memory since the server started.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.FeedbackRequestBody"}}},"description":"Whether the server was accepted","required":true},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_feedback.ServerFeedback"}}},"description":"Feedback recorded for the server"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Report search result feedback","tags":["extension"]}},"/extension/v0/servers/{serverName}/resolve":{"get":{"description":"Resolve a semantic versioning range (e.g. ` + "`" + `^1.2` + "`" + ` or ` + "`" + `\u003e=2.0 \u003c3` + "`" + `) against the published\nversions of a server, returning the highest matching version and all versions satisfying the range.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"Semantic versioning range","in":"query","name":"version","required":true,"schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.VersionResolutionResponse"}}},"description":"Resolved version"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found or no version satisfies the range"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Resolve server version range","tags":["extension"]}},"/extension/v0/servers/{serverName}/reviews":{"get":{"description":"Get the average rating of a server, the number of reviews of each rating and the comments of its most\nrecent reviews. Servers without reviews have a count of 0.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_reviews.Rating"}}},"description":"Rating of the server"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Get server rating","tags":["extension"]}},"/extension/v0/servers/{serverName}/versions/{version}/approval-requests":{"post":{"description":"File a ticket in the configured ticketing system (Jira or ServiceNow) requesting the approval\nof a server version, with the server metadata and its risk profile attached.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.ApprovalRequestBody"}}},"description":"Requester and justification"},"responses":{"201":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.ApprovalRequestResponse"}}},"description":"Ticket filed"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"No ticketing system configured"},"502":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Ticketing system error"}},"security":[{"BearerAuth":[]}],"summary":"Request server approval","tags":["extension"]}},"/extension/v0/servers/{serverName}/versions/{version}/changelog":{"get":{"description":"Get the release notes of a server version from its GitHub repository: the GitHub release tagged\nwith the version, or else the section of the CHANGELOG.md file headed with the version. When neither\nexists, the notes of the latest release or the top of the CHANGELOG.md file are returned with\nmatchesVersion set to false. Notes are cached for an hour and cut to 32 KiB.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.ChangelogResponse"}}},"description":"Release notes"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found, not hosted on GitHub or without release notes"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"502":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"GitHub API error"}},"security":[{"BearerAuth":[]}],"summary":"Get server changelog","tags":["extension"]}},"/extension/v0/servers/{serverName}/versions/{version}/context-pack":{"get":{"description":"Get a compact briefing on a server version for AI agents: summary, tool names, environment\nvariables, quickstart commands and caveats, sized under a token budget estimated at 4 bytes of\nMarkdown per token. Descriptions, optional variables and long tool lists are condensed first\nwhen the budget is exceeded; caveats are always kept. Credential-like example values are redacted.\nLong descriptions are replaced with their summary when summaries are configured.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}},{"description":"Token budget, between 50 and 4000 (default 500)","in":"query","name":"max_tokens","schema":{"type":"integer"}},{"description":"Output format: json (default) or markdown","in":"query","name":"format","schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_contextpack.Pack"}},"text/markdown":{"schema":{"type":"string"}}},"description":"Context pack"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Get server context pack","tags":["extension"]}},"/extension/v0/servers/{serverName}/versions/{version}/environment-variables":{"get":{"description":"List the environment variables of a server version page by page, required first, e.g. the\nvariables left out of its context pack from the environmentVariablesCursor of the pack.\nCredential-like example values are redacted.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}},{"description":"Cursor of the page, the first page if not given","in":"query","name":"cursor","schema":{"type":"string"}},{"description":"Number of variables per page, up to 200 (default 50)","in":"query","name":"limit","schema":{"type":"integer"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_contextpack.EnvironmentVariablePage"}}},"description":"Page of environment variables"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"List server environment variables","tags":["extension"]}},"/extension/v0/servers/{serverName}/versions/{version}/injection-risk":{"get":{"description":"Score the title and description of a server version for patterns commonly used to inject\ninstructions into agent context, from 0 (none found) to 100, along with the signals found.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.InjectionRiskResponse"}}},"description":"Prompt-injection risk"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Get prompt-injection risk","tags":["extension"]}},"/extension/v0/servers/{serverName}/versions/{version}/install":{"post":{"description":"Install a server version in the Kubernetes cluster by creating the MCPServer resource running its\nOCI package, which the ToolHive operator then deploys. Unless confirm is set, the resource is only\nreturned for review. Secret environment variables must be given as Kubernetes secret references.\nWhen an approval system is configured, servers it has not approved are answered with 403.\nRestricted to the administrators of the API.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.InstallRequestBody"}}},"description":"Installation parameters","required":true},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.InstallResponse"}}},"description":"Resource to create, for review"},"201":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.InstallResponse"}}},"description":"Resource created"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.ApprovalRequiredResponse"}}},"description":"Server requires approval, caller not an administrator, or access denied"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"409":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server already installed"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Cluster installation not configured"},"503":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Approval check failed"}},"security":[{"BearerAuth":[]}],"summary":"Install server in the cluster","tags":["extension"]}},"/extension/v0/servers/{serverName}/versions/{version}/offline-bundle":{"get":{"description":"Get the commands mirroring the packages of a server version into internal artifact stores\n(docker save, npm pack, pip download, dotnet nuget push) for disconnected environments, along with\nthe server entry rewritten to point at the mirrors. Packages whose mirror is not given are not rewritten.\nExample values resembling credentials are replaced with placeholders.\nWhen an approval system is configured, servers it has not approved are answered with 403.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}},{"description":"Internal container registry host, with optional path prefix","in":"query","name":"oci_mirror","schema":{"type":"string"}},{"description":"Internal npm registry URL","in":"query","name":"npm_mirror","schema":{"type":"string"}},{"description":"Internal Python package repository upload URL","in":"query","name":"pypi_mirror","schema":{"type":"string"}},{"description":"Internal NuGet feed push URL","in":"query","name":"nuget_mirror","schema":{"type":"string"}},{"description":"Output format: json (default) or script","in":"query","name":"format","schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_offline.Bundle"}},"text/x-shellscript":{"schema":{"type":"string"}}},"description":"Offline install bundle"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.ApprovalRequiredResponse"}}},"description":"Server requires approval"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"503":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Approval check failed"}},"security":[{"BearerAuth":[]}],"summary":"Get offline install bundle","tags":["extension"]}},"/extension/v0/servers/{serverName}/versions/{version}/package-stats":{"get":{"description":"Get the latest published version and the weekly download count of the npm and PyPI packages\nof a server version, from their package registries. A warning is returned for each package\nwhose version recorded in the registry lags behind the latest published version.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.PackageStatsResponse"}}},"description":"Package stats"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found or without npm or PyPI packages"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Get server package stats","tags":["extension"]}},"/extension/v0/servers/{serverName}/versions/{version}/platforms":{"get":{"description":"Get the platforms (e.g. linux/amd64, linux/arm64) the container images of a server version are\nbuilt for, read from their manifest lists. Given the platform of the user, each image reports\nwhether it is supported and a warning is returned for the images that are not.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}},{"description":"Platform of the user as os/architecture[/variant] (e.g., \\","in":"query","name":"platform","schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.PlatformsResponse"}}},"description":"Image platforms"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found or without container images"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Get server platforms","tags":["extension"]}},"/extension/v0/servers/{serverName}/versions/{version}/sbom":{"get":{"description":"Get a summary of the software bills of materials (SPDX or CycloneDX) attached to the\ncontainer images of a server version through the OCI referrers API: package counts per\ntype and notable system packages. Images without an attached SBOM are reported with an error.\nSummaries are cached by image digest for a freshness window, and report when the SBOM was fetched.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.SBOMResponse"}}},"description":"SBOM summaries"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found or without container images"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Get server SBOM","tags":["extension"]}},"/extension/v0/servers/{serverName}/versions/{version}/setup-guide":{"get":{"description":"Get the platform-specific instructions setting up the binary packages of a server version (registry\ntype binary or github-release): downloading the binary, verifying its SHA-256 hash, making it executable\nand adding it to the PATH. Packages of other registry types are left out.\nWhen an approval system is configured, servers it has not approved are answered with 403.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}},{"description":"Platform to write instructions for, as \u003cos\u003e/\u003carch\u003e (default linux, darwin and windows on amd64 and arm64)","in":"query","name":"platform","schema":{"items":{"type":"string"},"type":"array"},"style":"form"}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_setupguide.Guide"}}},"description":"Setup guide"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.ApprovalRequiredResponse"}}},"description":"Server requires approval"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"},"503":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Approval check failed"}},"security":[{"BearerAuth":[]}],"summary":"Get binary setup guide","tags":["extension"]}},"/extension/v0/servers/{serverName}/versions/{version}/tools":{"get":{"description":"List the tools of a server version page by page, e.g. the tools left out of its context pack\nfrom the toolsCursor of the pack.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}},{"description":"Cursor of the page, the first page if not given","in":"query","name":"cursor","schema":{"type":"string"}},{"description":"Number of tools per page, up to 200 (default 50)","in":"query","name":"limit","schema":{"type":"integer"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/github_com_stacklok_toolhive-registry-server_internal_contextpack.ToolPage"}}},"description":"Page of tools"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"List server tools","tags":["extension"]}},"/extension/v0/suggestions":{"get":{"description":"Get \"did you mean\" suggestions for a search query, typically one that returned no servers.\nSuggestions are server names and tags within a small edit distance of the query, closest first.","parameters":[{"description":"Search query to find suggestions for","in":"query","name":"search","required":true,"schema":{"type":"string"}},{"description":"Maximum number of suggestions to return (default 5, max 20)","in":"query","name":"limit","schema":{"type":"integer"}}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.SuggestionsResponse"}}},"description":"Suggestions"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Get search suggestions","tags":["extension"]}},"/extension/v0/tags":{"get":{"description":"List the tags used in the catalog with the number of servers carrying each, most used first,\nto discover the tag values to search servers with. Servers are counted once whatever the\nnumber of their versions.","responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.TagsResponse"}}},"description":"Tags"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"List tags","tags":["extension"]}},"/extension/v0/validate":{"post":{"description":"Lint a registry entry, given by name or as the JSON entry itself, before publishing it: missing name,\nversion or description, no tags, malformed repository or remote URLs, no package or remote endpoint,\nand ToolHive metadata of the wrong shape. When link checks are enabled, the repository and website\nURLs and the npm and PyPI packages of entries given by name are also requested to find broken ones.\nThe links of pasted entries are never requested.","requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.ValidateServerRequestBody"}}},"description":"Entry to validate","required":true},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api_extension_v0.ValidateServerResponse"}}},"description":"Issues found"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Validate server entry","tags":["extension"]}},"/health":{"get":{"description":"Check if the registry API is healthy and report the status of its background workers","responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/internal_api.healthResponse"}}},"description":"OK"}},"summary":"Health check","tags":["system"]}},"/openapi.json":{"get":{"description":"Get the OpenAPI 3.1.0 specification for this API","responses":{"200":{"content":{"application/json":{"schema":{"type":"object"}}},"description":"OpenAPI 3.1.0 specification"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal Server Error"}},"summary":"OpenAPI specification","tags":["system"]}},"/readiness":{"get":{"description":"Check if the registry API is ready to serve requests","responses":{"200":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"OK"},"503":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Service Unavailable"}},"summary":"Readiness check","tags":["system"]}},"/registry/v0.1/publish":{"post":{"description":"Publish a server to the registry. This server does not support publishing via this endpoint.\nUse the registry-specific endpoint /{registryName}/v0.1/publish instead.","requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"501":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not implemented"}},"security":[{"BearerAuth":[]}],"summary":"Publish server","tags":["registry","official"]}},"/registry/v0.1/servers":{"get":{"description":"Get a list of available servers in the registry","parameters":[{"description":"Pagination cursor for retrieving next set of results","in":"query","name":"cursor","schema":{"type":"string"}},{"description":"Maximum number of items to return","in":"query","name":"limit","schema":{"type":"integer"}},{"description":"Search servers by name (substring match)","in":"query","name":"search","schema":{"type":"string"}},{"description":"Sort by name, published_at, or updated_at (prefix with '-' for descending order)","in":"query","name":"sort","schema":{"type":"string"}},{"description":"Include servers marked as deprecated (default false)","in":"query","name":"include_deprecated","schema":{"type":"boolean"}},{"description":"Include servers marked as deleted (default false)","in":"query","name":"include_deleted","schema":{"type":"boolean"}},{"description":"Exclude servers whose prompt-injection risk score (0-100) exceeds the value","in":"query","name":"max_injection_risk","schema":{"type":"integer"}},{"description":"Filter by version ('latest' for latest version, or an exact version like '1.2.3')","in":"query","name":"version","schema":{"type":"string"}},{"description":"Merge servers found in several registries with the same repository (default false)","in":"query","name":"dedupe","schema":{"type":"boolean"}},{"description":"Filter by link check health: ok, or stale (broken links or archived repository)","in":"query","name":"health","schema":{"type":"string"}},{"description":"Filter by annotated approval status: approved, pending, or rejected","in":"query","name":"approval_status","schema":{"type":"string"}},{"description":"Filter by annotated owner team","in":"query","name":"owner_team","schema":{"type":"string"}},{"description":"Output format: json (default), markdown or csv table","in":"query","name":"format","schema":{"type":"string"}},{"description":"Comma-separated table columns (default name,version,description,tags)","in":"query","name":"columns","schema":{"type":"string"}},{"description":"Add the timing breakdown of the response to its metadata (default false)","in":"query","name":"debug_timings","schema":{"type":"boolean"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerListResponse"}},"text/csv":{"schema":{"type":"string"}}},"description":"OK"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"}},"security":[{"BearerAuth":[]}],"summary":"List servers","tags":["registry","official"]}},"/registry/v0.1/servers/{serverName}/versions":{"get":{"description":"Returns all available versions for a specific MCP server, ordered by publication date (newest first)","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerListResponse"}}},"description":"A list of all versions for the server"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"}},"security":[{"BearerAuth":[]}],"summary":"List all versions of an MCP server","tags":["registry","official"]}},"/registry/v0.1/servers/{serverName}/versions/{version}":{"get":{"description":"Returns detailed information about a specific version of an MCP server.\nUse the special version ` + "`" + `latest` + "`" + ` to get the latest version, or a semantic versioning\nrange (e.g. ` + "`" + `^1.2` + "`" + ` or ` + "`" + `\u003e=2.0,\u003c3` + "`" + `) to get the highest version satisfying it.","parameters":[{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version to retrieve (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerResponse"}}},"description":"Detailed server information"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server or version not found"}},"security":[{"BearerAuth":[]}],"summary":"Get specific MCP server version","tags":["registry","official"]}},"/registry/{registryName}/v0.1/servers":{"get":{"description":"Get a list of available servers in the registry","parameters":[{"description":"Registry name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}},{"description":"Pagination cursor for retrieving next set of results","in":"query","name":"cursor","schema":{"type":"string"}},{"description":"Maximum number of items to return","in":"query","name":"limit","schema":{"type":"integer"}},{"description":"Search servers by name (substring match)","in":"query","name":"search","schema":{"type":"string"}},{"description":"Sort by name, published_at, or updated_at (prefix with '-' for descending order)","in":"query","name":"sort","schema":{"type":"string"}},{"description":"Include servers marked as deprecated (default false)","in":"query","name":"include_deprecated","schema":{"type":"boolean"}},{"description":"Include servers marked as deleted (default false)","in":"query","name":"include_deleted","schema":{"type":"boolean"}},{"description":"Exclude servers whose prompt-injection risk score (0-100) exceeds the value","in":"query","name":"max_injection_risk","schema":{"type":"integer"}},{"description":"Filter by version ('latest' for latest version, or an exact version like '1.2.3')","in":"query","name":"version","schema":{"type":"string"}},{"description":"Output format: json (default), markdown or csv table","in":"query","name":"format","schema":{"type":"string"}},{"description":"Comma-separated table columns (default name,version,description,tags)","in":"query","name":"columns","schema":{"type":"string"}},{"description":"Add the timing breakdown of the response to its metadata (default false)","in":"query","name":"debug_timings","schema":{"type":"boolean"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerListResponse"}},"text/csv":{"schema":{"type":"string"}}},"description":"OK"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"}},"security":[{"BearerAuth":[]}],"summary":"List servers","tags":["registry","official"]}},"/registry/{registryName}/v0.1/servers/{serverName}/versions":{"get":{"description":"Returns all available versions for a specific MCP server, ordered by publication date (newest first)","parameters":[{"description":"Registry name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerListResponse"}}},"description":"A list of all versions for the server"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server not found"}},"security":[{"BearerAuth":[]}],"summary":"List all versions of an MCP server","tags":["registry","official"]}},"/registry/{registryName}/v0.1/servers/{serverName}/versions/{version}":{"get":{"description":"Returns detailed information about a specific version of an MCP server.\nUse the special version ` + "`" + `latest` + "`" + ` to get the latest version, or a semantic versioning\nrange (e.g. ` + "`" + `^1.2` + "`" + ` or ` + "`" + `\u003e=2.0,\u003c3` + "`" + `) to get the highest version satisfying it.","parameters":[{"description":"Registry name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded server name (e.g., \\","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"URL-encoded version to retrieve (e.g., \\","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerResponse"}}},"description":"Detailed server information"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server or version not found"}},"security":[{"BearerAuth":[]}],"summary":"Get specific MCP server version","tags":["registry","official"]}},"/version":{"get":{"description":"Get version information about the registry API","responses":{"200":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"OK"}},"summary":"Version information","tags":["system"]}},"/{registryName}/v0.1/publish":{"post":{"description":"Publish a server version to a specific managed registry","parameters":[{"description":"Registry name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerJSON"}}},"description":"Server data","required":true},"responses":{"201":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/v0.ServerJSON"}}},"description":"Created"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not a managed registry"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Registry not found"},"409":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Version already exists"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Publish server to specific registry","tags":["registry","official"]}},"/{registryName}/v0.1/servers/{serverName}/versions/{version}":{"delete":{"description":"Delete a server version from a specific managed registry","parameters":[{"description":"Registry name","in":"path","name":"registryName","required":true,"schema":{"type":"string"}},{"description":"Server name (URL-encoded)","in":"path","name":"serverName","required":true,"schema":{"type":"string"}},{"description":"Version (URL-encoded)","in":"path","name":"version","required":true,"schema":{"type":"string"}}],"requestBody":{"content":{"application/json":{"schema":{"type":"object"}}}},"responses":{"204":{"description":"No content"},"400":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Bad request"},"401":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Unauthorized"},"403":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Not a managed registry"},"404":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Server version not found"},"500":{"content":{"application/json":{"schema":{"additionalProperties":{"type":"string"},"type":"object"}}},"description":"Internal server error"}},"security":[{"BearerAuth":[]}],"summary":"Delete server version from specific registry","tags":["registry","official"]}}},
     "openapi": "3.1.0"
 }`
 
@@ -2,11 +2,15 @@ package examples
 
 import (
 	"embed"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/stacklok/toolhive-registry-server/internal/config"
 )
 
@@ -64,3 +68,85 @@ func TestConfigFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigFilesMatchSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := config.Schema()
+	defs := schema["$defs"].(map[string]any)
+
+	matches, err := fs.Glob(configFS, "config-*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to find config files: %v", err)
+	}
+
+	for _, configPath := range matches {
+		t.Run(filepath.Base(configPath), func(t *testing.T) {
+			t.Parallel()
+
+			data, err := configFS.ReadFile(configPath)
+			if err != nil {
+				t.Fatalf("Failed to read file: %v", err)
+			}
+			var values any
+			if err := yaml.Unmarshal(data, &values); err != nil {
+				t.Fatalf("Failed to parse file: %v", err)
+			}
+			checkSchema(t, "$", values, schema, defs)
+		})
+	}
+}
+
+// checkSchema checks the keys and types of a decoded YAML value against the configuration schema
+func checkSchema(t *testing.T, path string, value any, schema, defs map[string]any) {
+	t.Helper()
+
+	if ref, ok := schema["$ref"].(string); ok {
+		schema = defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+	}
+	if value == nil {
+		return
+	}
+
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			t.Errorf("%s: expected an object, got %T", path, value)
+			return
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		for key, item := range object {
+			itemSchema, ok := properties[key].(map[string]any)
+			if !ok {
+				itemSchema, ok = schema["additionalProperties"].(map[string]any)
+			}
+			if !ok {
+				t.Errorf("%s: unknown key %q", path, key)
+				continue
+			}
+			checkSchema(t, path+"."+key, item, itemSchema, defs)
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			t.Errorf("%s: expected an array, got %T", path, value)
+			return
+		}
+		for i, item := range items {
+			checkSchema(t, fmt.Sprintf("%s[%d]", path, i), item, schema["items"].(map[string]any), defs)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			t.Errorf("%s: expected a string, got %T", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			t.Errorf("%s: expected a boolean, got %T", path, value)
+		}
+	case "integer":
+		if _, ok := value.(int); !ok {
+			t.Errorf("%s: expected an integer, got %T", path, value)
+		}
+	}
+}
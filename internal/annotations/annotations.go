@@ -0,0 +1,214 @@
+// Package annotations stores what the operators of a deployment record about servers for its users
+// only: internal notes, approval status and owner team. Annotations are kept in a JSON file, and are
+// recorded in the server metadata of API responses and usable as search filters.
+package annotations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+const (
+	// maxNotesLength caps the length of the notes of an annotation
+	maxNotesLength = 4096
+	// maxOwnerTeamLength caps the length of the owner team of an annotation
+	maxOwnerTeamLength = 256
+)
+
+// ErrInvalidAnnotation is returned when an annotation has an unknown approval status, a field too long,
+// or no field set
+var ErrInvalidAnnotation = errors.New("invalid annotation")
+
+// ServerAnnotation is the annotation of a server
+type ServerAnnotation struct {
+	Server string `json:"server"`
+	registry.Annotation
+}
+
+// Store keeps the annotations of servers in memory and persists them to a JSON file on every change.
+// It is safe for concurrent use.
+type Store struct {
+	path string
+	now  func() time.Time
+
+	mu          sync.RWMutex
+	annotations map[string]registry.Annotation
+}
+
+var _ registry.AnnotationLookup = (*Store)(nil)
+
+// NewStore creates a store persisted to the file at path, loading the annotations it holds, if any
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:        path,
+		now:         time.Now,
+		annotations: make(map[string]registry.Annotation),
+	}
+
+	// #nosec G304 -- the path is set by the operator in the configuration
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read annotations file: %w", err)
+	}
+	var annotations []ServerAnnotation
+	if err := json.Unmarshal(data, &annotations); err != nil {
+		return nil, fmt.Errorf("failed to parse annotations file: %w", err)
+	}
+	for _, annotation := range annotations {
+		s.annotations[annotation.Server] = annotation.Annotation
+	}
+	return s, nil
+}
+
+// Annotation implements registry.AnnotationLookup
+func (s *Store) Annotation(name string) (registry.Annotation, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	annotation, ok := s.annotations[name]
+	return annotation, ok
+}
+
+// List returns the annotations matching the filter, by server name
+func (s *Store) List(filter registry.AnnotationFilter) []ServerAnnotation {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []ServerAnnotation{}
+	for _, name := range slices.Sorted(maps.Keys(s.annotations)) {
+		if annotation := s.annotations[name]; filter.Matches(annotation) {
+			result = append(result, ServerAnnotation{Server: name, Annotation: annotation})
+		}
+	}
+	return result
+}
+
+// Set validates and records the annotation of a server, replacing the previous one, and returns it
+// with its update time
+func (s *Store) Set(name string, annotation registry.Annotation) (registry.Annotation, error) {
+	if err := validate(annotation); err != nil {
+		return registry.Annotation{}, err
+	}
+	annotation.UpdatedAt = s.now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, existed := s.annotations[name]
+	s.annotations[name] = annotation
+	if err := s.saveLocked(); err != nil {
+		if existed {
+			s.annotations[name] = previous
+		} else {
+			delete(s.annotations, name)
+		}
+		return registry.Annotation{}, err
+	}
+	return annotation, nil
+}
+
+// Delete removes the annotation of a server, and returns false if it had none
+func (s *Store) Delete(name string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.annotations[name]
+	if !ok {
+		return false, nil
+	}
+	delete(s.annotations, name)
+	if err := s.saveLocked(); err != nil {
+		s.annotations[name] = previous
+		return false, err
+	}
+	return true, nil
+}
+
+// Annotate records the annotation of a server, if any, in its metadata under registry.AnnotationMetaKey
+func (s *Store) Annotate(server upstreamv0.ServerJSON) upstreamv0.ServerJSON {
+	annotation, ok := s.Annotation(server.Name)
+	if !ok {
+		return server
+	}
+
+	meta := upstreamv0.ServerMeta{}
+	if server.Meta != nil {
+		meta = *server.Meta
+	}
+	publisherProvided := make(map[string]interface{}, len(meta.PublisherProvided)+1)
+	for key, value := range meta.PublisherProvided {
+		publisherProvided[key] = value
+	}
+	entry := map[string]interface{}{
+		"updatedAt": annotation.UpdatedAt.Format(time.RFC3339),
+	}
+	if annotation.Notes != "" {
+		entry["notes"] = annotation.Notes
+	}
+	if annotation.ApprovalStatus != "" {
+		entry["approvalStatus"] = annotation.ApprovalStatus
+	}
+	if annotation.OwnerTeam != "" {
+		entry["ownerTeam"] = annotation.OwnerTeam
+	}
+	publisherProvided[registry.AnnotationMetaKey] = entry
+	meta.PublisherProvided = publisherProvided
+	server.Meta = &meta
+	return server
+}
+
+// saveLocked writes the annotations to the file, replacing it atomically.
+// Caller must hold s.mu write lock.
+func (s *Store) saveLocked() error {
+	annotations := make([]ServerAnnotation, 0, len(s.annotations))
+	for _, name := range slices.Sorted(maps.Keys(s.annotations)) {
+		annotations = append(annotations, ServerAnnotation{Server: name, Annotation: s.annotations[name]})
+	}
+	data, err := json.MarshalIndent(annotations, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal annotations: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0750); err != nil {
+		return fmt.Errorf("failed to create annotations directory: %w", err)
+	}
+	tempPath := s.path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write temporary annotations file: %w", err)
+	}
+	if err := os.Rename(tempPath, s.path); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to rename annotations file: %w", err)
+	}
+	return nil
+}
+
+// validate checks the approval status of an annotation and the length of its fields
+func validate(annotation registry.Annotation) error {
+	if annotation.ApprovalStatus != "" && !slices.Contains(registry.ApprovalStatuses(), annotation.ApprovalStatus) {
+		return fmt.Errorf("%w: approval status must be one of %s",
+			ErrInvalidAnnotation, strings.Join(registry.ApprovalStatuses(), ", "))
+	}
+	if len(annotation.Notes) > maxNotesLength {
+		return fmt.Errorf("%w: notes must be at most %d bytes", ErrInvalidAnnotation, maxNotesLength)
+	}
+	if len(annotation.OwnerTeam) > maxOwnerTeamLength {
+		return fmt.Errorf("%w: owner team must be at most %d bytes", ErrInvalidAnnotation, maxOwnerTeamLength)
+	}
+	if annotation.Notes == "" && annotation.ApprovalStatus == "" && annotation.OwnerTeam == "" {
+		return fmt.Errorf("%w: notes, approval status or owner team is required", ErrInvalidAnnotation)
+	}
+	return nil
+}
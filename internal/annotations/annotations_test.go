@@ -0,0 +1,124 @@
+package annotations
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "annotations", "annotations.json")
+	store, err := NewStore(path)
+	require.NoError(t, err)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	recorded, err := store.Set("io.test/approved", registry.Annotation{
+		Notes:          "Vetted by security",
+		ApprovalStatus: registry.ApprovalApproved,
+		OwnerTeam:      "platform",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, now, recorded.UpdatedAt)
+	_, err = store.Set("io.test/pending", registry.Annotation{ApprovalStatus: registry.ApprovalPending})
+	require.NoError(t, err)
+
+	annotation, ok := store.Annotation("io.test/approved")
+	require.True(t, ok)
+	assert.Equal(t, recorded, annotation)
+	_, ok = store.Annotation("io.test/unknown")
+	assert.False(t, ok)
+
+	servers := func(annotations []ServerAnnotation) []string {
+		names := make([]string, 0, len(annotations))
+		for _, annotation := range annotations {
+			names = append(names, annotation.Server)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"io.test/approved", "io.test/pending"}, servers(store.List(registry.AnnotationFilter{})))
+	assert.Equal(t, []string{"io.test/pending"},
+		servers(store.List(registry.AnnotationFilter{ApprovalStatus: registry.ApprovalPending})))
+
+	// Annotations are loaded back from the file
+	reloaded, err := NewStore(path)
+	require.NoError(t, err)
+	assert.Equal(t, store.List(registry.AnnotationFilter{}), reloaded.List(registry.AnnotationFilter{}))
+
+	deleted, err := store.Delete("io.test/pending")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = store.Delete("io.test/pending")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	reloaded, err = NewStore(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"io.test/approved"}, servers(reloaded.List(registry.AnnotationFilter{})))
+}
+
+func TestStoreSetInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		annotation registry.Annotation
+	}{
+		{
+			name:       "unknown approval status",
+			annotation: registry.Annotation{ApprovalStatus: "maybe"},
+		},
+		{
+			name:       "notes too long",
+			annotation: registry.Annotation{Notes: strings.Repeat("a", maxNotesLength+1)},
+		},
+		{
+			name:       "owner team too long",
+			annotation: registry.Annotation{OwnerTeam: strings.Repeat("a", maxOwnerTeamLength+1)},
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			store, err := NewStore(filepath.Join(t.TempDir(), "annotations.json"))
+			require.NoError(t, err)
+
+			_, err = store.Set("io.test/server", tt.annotation)
+			require.ErrorIs(t, err, ErrInvalidAnnotation)
+			_, ok := store.Annotation("io.test/server")
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewStore(filepath.Join(t.TempDir(), "annotations.json"))
+	require.NoError(t, err)
+	store.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	_, err = store.Set("io.test/annotated", registry.Annotation{OwnerTeam: "platform"})
+	require.NoError(t, err)
+
+	unannotated := registry.NewTestServer("io.test/unannotated")
+	assert.Equal(t, unannotated, store.Annotate(unannotated))
+
+	annotated := store.Annotate(registry.NewTestServer("io.test/annotated"))
+	require.NotNil(t, annotated.Meta)
+	assert.Equal(t, map[string]interface{}{
+		"ownerTeam": "platform",
+		"updatedAt": "2026-01-02T03:04:05Z",
+	}, annotated.Meta.PublisherProvided[registry.AnnotationMetaKey])
+}
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"net/http"
+)
+
+// ConcurrencyLimit returns a middleware allowing at most limit requests to be handled at once.
+// Further requests wait for a slot and are rejected with 503 Service Unavailable if their
+// context is done first. A non-positive limit disables the middleware.
+func ConcurrencyLimit(limit int) func(http.Handler) http.Handler {
+	if limit <= 0 {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+
+	slots := make(chan struct{}, limit)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			select {
+			case slots <- struct{}{}:
+			case <-req.Context().Done():
+				w.Header().Set("Retry-After", "1")
+				WriteErrorResponse(w, "Too many concurrent requests", http.StatusServiceUnavailable)
+				return
+			}
+			defer func() { <-slots }()
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
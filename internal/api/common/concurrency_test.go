@@ -0,0 +1,68 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	handler := ConcurrencyLimit(1)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		entered <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Occupy the only slot
+	var wg sync.WaitGroup
+	first := httptest.NewRecorder()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		handler.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+	<-entered
+
+	// A request whose context ends while waiting is rejected
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rejected := httptest.NewRecorder()
+	handler.ServeHTTP(rejected, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusServiceUnavailable, rejected.Code)
+	assert.Equal(t, "1", rejected.Header().Get("Retry-After"))
+
+	// A waiting request proceeds once the slot is released
+	second := httptest.NewRecorder()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		handler.ServeHTTP(second, httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+	close(release)
+	<-entered
+	wg.Wait()
+
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusOK, second.Code)
+}
+
+func TestConcurrencyLimitDisabled(t *testing.T) {
+	t.Parallel()
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	for _, limit := range []int{0, -1} {
+		rec := httptest.NewRecorder()
+		ConcurrencyLimit(limit)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	}
+}
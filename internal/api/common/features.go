@@ -0,0 +1,22 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/stacklok/toolhive-registry-server/internal/features"
+)
+
+// FeatureGate returns a middleware responding with 404 Not Found when the given feature is
+// disabled, as if the endpoint did not exist
+func FeatureGate(flags *features.Flags, name string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if !flags.Enabled(name) {
+				WriteErrorResponse(w, fmt.Sprintf("Feature %q is disabled", name), http.StatusNotFound)
+				return
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
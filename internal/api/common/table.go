@@ -0,0 +1,98 @@
+package common
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Formats of list responses
+const (
+	// FormatJSON renders list responses as JSON
+	FormatJSON = "json"
+	// FormatMarkdown renders list responses as a Markdown table
+	FormatMarkdown = "markdown"
+	// FormatCSV renders list responses as CSV
+	FormatCSV = "csv"
+)
+
+// Table is the format of a list response and the columns of its rows when rendered as a table
+type Table struct {
+	Format  string
+	Columns []string
+}
+
+// IsJSON returns true when the response is not rendered as a table
+func (t Table) IsJSON() bool {
+	return t.Format == FormatJSON
+}
+
+// ParseTable parses the format (json by default, markdown or csv) and columns (comma-separated,
+// defaultColumns by default) query parameters of a list response, with columns one of available.
+// It writes a bad request response and returns false as second value if they are malformed.
+func ParseTable(w http.ResponseWriter, req *http.Request, available, defaultColumns []string) (Table, bool) {
+	query := req.URL.Query()
+	table := Table{Format: query.Get("format"), Columns: defaultColumns}
+	if table.Format == "" {
+		table.Format = FormatJSON
+	}
+	if table.Format != FormatJSON && table.Format != FormatMarkdown && table.Format != FormatCSV {
+		WriteErrorResponse(w, fmt.Sprintf("Invalid format parameter: must be one of %s, %s or %s",
+			FormatJSON, FormatMarkdown, FormatCSV), http.StatusBadRequest)
+		return Table{}, false
+	}
+
+	if columns := query.Get("columns"); columns != "" {
+		table.Columns = nil
+		for _, column := range strings.Split(columns, ",") {
+			column = strings.TrimSpace(column)
+			if !slices.Contains(available, column) {
+				WriteErrorResponse(w, fmt.Sprintf("Invalid columns parameter: %q is not one of %s",
+					column, strings.Join(available, ", ")), http.StatusBadRequest)
+				return Table{}, false
+			}
+			table.Columns = append(table.Columns, column)
+		}
+	}
+	return table, true
+}
+
+// WriteTable writes rows, holding the values of the columns of the table, as a Markdown table or CSV
+func WriteTable(w http.ResponseWriter, table Table, rows [][]string, statusCode int) {
+	if table.Format == FormatCSV {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.WriteHeader(statusCode)
+		writer := csv.NewWriter(w)
+		_ = writer.Write(table.Columns)
+		_ = writer.WriteAll(rows)
+		return
+	}
+
+	var b strings.Builder
+	writeMarkdownRow(&b, table.Columns)
+	b.WriteString("|")
+	for range table.Columns {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n")
+	for _, row := range rows {
+		writeMarkdownRow(&b, row)
+	}
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write([]byte(b.String()))
+}
+
+// markdownCell escapes the pipes and line breaks of Markdown table cells
+var markdownCell = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+
+// writeMarkdownRow writes a row of a Markdown table
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		b.WriteString(" " + markdownCell.Replace(cell) + " |")
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTable(t *testing.T) {
+	t.Parallel()
+
+	available := []string{"name", "version", "tags"}
+	defaults := []string{"name"}
+	tests := []struct {
+		name      string
+		query     string
+		wantOK    bool
+		wantTable Table
+	}{
+		{name: "json by default", query: "", wantOK: true, wantTable: Table{Format: FormatJSON, Columns: defaults}},
+		{name: "default columns", query: "format=csv", wantOK: true, wantTable: Table{Format: FormatCSV, Columns: defaults}},
+		{
+			name:      "selected columns",
+			query:     "format=markdown&columns=tags,%20name",
+			wantOK:    true,
+			wantTable: Table{Format: FormatMarkdown, Columns: []string{"tags", "name"}},
+		},
+		{name: "unknown format", query: "format=yaml"},
+		{name: "unknown column", query: "format=csv&columns=name,owner"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr := httptest.NewRecorder()
+			table, ok := ParseTable(rr, httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil), available, defaults)
+			require.Equal(t, tt.wantOK, ok)
+			if !ok {
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				return
+			}
+			assert.Equal(t, tt.wantTable, table)
+		})
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	t.Parallel()
+
+	rows := [][]string{{"a|b", "line\nbreak"}, {"plain", "with, comma"}}
+
+	rr := httptest.NewRecorder()
+	WriteTable(rr, Table{Format: FormatMarkdown, Columns: []string{"first", "second"}}, rows, http.StatusOK)
+	assert.Equal(t, "text/markdown; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "| first | second |\n| --- | --- |\n| a\\|b | line break |\n| plain | with, comma |\n", rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	WriteTable(rr, Table{Format: FormatCSV, Columns: []string{"first", "second"}}, rows, http.StatusOK)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "first,second\na|b,\"line\nbreak\"\nplain,\"with, comma\"\n", rr.Body.String())
+}
@@ -0,0 +1,115 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+	"github.com/stacklok/toolhive-registry-server/internal/timings"
+)
+
+// DebugTimingsParam is the query parameter asking for the timing breakdown of a response
+const DebugTimingsParam = "debug_timings"
+
+// ResponseTimings is the timing breakdown of a response, in milliseconds
+type ResponseTimings struct {
+	// FetchMs is the time spent fetching the registry data, from memory or the database
+	FetchMs float64 `json:"fetchMs"`
+	// UpstreamMs is the time spent waiting for upstream services, e.g. package registries
+	UpstreamMs float64 `json:"upstreamMs"`
+	// FilterMs is the time spent filtering servers
+	FilterMs float64 `json:"filterMs"`
+	// SortMs is the time spent sorting servers
+	SortMs float64 `json:"sortMs"`
+	// SerializeMs is the time spent encoding the response
+	SerializeMs float64 `json:"serializeMs"`
+	// TotalMs is the time spent serving the request, up to writing the response
+	TotalMs float64 `json:"totalMs"`
+}
+
+// DebugTimings is a middleware recording the timings of the requests asking for their timing breakdown
+// with the debug_timings query parameter, for WriteJSONResponseWithTimings to report them
+func DebugTimings(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if debug, _ := strconv.ParseBool(r.URL.Query().Get(DebugTimingsParam)); debug {
+			ctx, _ := timings.NewContext(r.Context())
+			if httpclient.UpstreamTimingsFromContext(ctx) == nil {
+				ctx, _ = httpclient.WithUpstreamTimings(ctx)
+			}
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WriteJSONResponseWithTimings writes a JSON response like WriteJSONResponse. When the request asked
+// for its timing breakdown, the breakdown is added as "timings" to the "metadata" object of the
+// response, which is created if needed.
+func WriteJSONResponseWithTimings(w http.ResponseWriter, r *http.Request, data any, statusCode int) {
+	recorder := timings.FromContext(r.Context())
+	if recorder == nil {
+		WriteJSONResponse(w, data, statusCode)
+		return
+	}
+
+	start := time.Now()
+	body, err := json.Marshal(data)
+	if err != nil {
+		WriteErrorResponse(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	recorder.Add(timings.PhaseSerialize, time.Since(start))
+
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err != nil {
+		// Only objects have metadata
+		writeJSONBody(w, body, statusCode)
+		return
+	}
+	metadata := map[string]json.RawMessage{}
+	if raw, ok := response["metadata"]; ok {
+		if err := json.Unmarshal(raw, &metadata); err != nil {
+			writeJSONBody(w, body, statusCode)
+			return
+		}
+	}
+	metadata["timings"], _ = json.Marshal(responseTimings(r, recorder))
+	response["metadata"], _ = json.Marshal(metadata)
+
+	body, err = json.Marshal(response)
+	if err != nil {
+		WriteErrorResponse(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	writeJSONBody(w, body, statusCode)
+}
+
+// responseTimings returns the timing breakdown of the request
+func responseTimings(r *http.Request, recorder *timings.Recorder) ResponseTimings {
+	phases := recorder.Phases()
+	result := ResponseTimings{
+		FetchMs:     milliseconds(phases[timings.PhaseFetch]),
+		FilterMs:    milliseconds(phases[timings.PhaseFilter]),
+		SortMs:      milliseconds(phases[timings.PhaseSort]),
+		SerializeMs: milliseconds(phases[timings.PhaseSerialize]),
+		TotalMs:     milliseconds(recorder.Elapsed()),
+	}
+	if upstream := httpclient.UpstreamTimingsFromContext(r.Context()); upstream != nil {
+		result.UpstreamMs = milliseconds(upstream.Total())
+	}
+	return result
+}
+
+// milliseconds returns d in milliseconds, with microsecond precision
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// writeJSONBody writes an encoded JSON response
+func writeJSONBody(w http.ResponseWriter, body []byte, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(append(body, '\n'))
+}
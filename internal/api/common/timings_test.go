@@ -0,0 +1,104 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/timings"
+)
+
+func TestWriteJSONResponseWithTimings(t *testing.T) {
+	t.Parallel()
+
+	type listResponse struct {
+		Servers  []string       `json:"servers"`
+		Metadata map[string]any `json:"metadata"`
+	}
+	type report struct {
+		Queries []string `json:"queries"`
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		data        any
+		wantTimings bool
+		wantKeys    []string
+	}{
+		{
+			name:     "without debug_timings",
+			data:     listResponse{Servers: []string{"a"}, Metadata: map[string]any{"count": 1}},
+			wantKeys: []string{"metadata", "servers"},
+		},
+		{
+			name:        "added to existing metadata",
+			query:       "?debug_timings=true",
+			data:        listResponse{Servers: []string{"a"}, Metadata: map[string]any{"count": 1}},
+			wantTimings: true,
+			wantKeys:    []string{"metadata", "servers"},
+		},
+		{
+			name:        "metadata created",
+			query:       "?debug_timings=1",
+			data:        report{Queries: []string{"q"}},
+			wantTimings: true,
+			wantKeys:    []string{"metadata", "queries"},
+		},
+		{
+			name:     "invalid debug_timings ignored",
+			query:    "?debug_timings=maybe",
+			data:     report{Queries: []string{"q"}},
+			wantKeys: []string{"queries"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			handler := DebugTimings(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				timings.Track(r.Context(), timings.PhaseFilter)()
+				if recorder := timings.FromContext(r.Context()); recorder != nil {
+					recorder.Add(timings.PhaseSort, 1500*time.Microsecond)
+				}
+				WriteJSONResponseWithTimings(w, r, tt.data, http.StatusOK)
+			}))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/report"+tt.query, nil))
+			require.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+			var body map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			keys := make([]string, 0, len(body))
+			for key := range body {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, tt.wantKeys, keys)
+
+			var metadata struct {
+				Count   int              `json:"count"`
+				Timings *ResponseTimings `json:"timings"`
+			}
+			if raw, ok := body["metadata"]; ok {
+				require.NoError(t, json.Unmarshal(raw, &metadata))
+			}
+			if !tt.wantTimings {
+				assert.Nil(t, metadata.Timings)
+				return
+			}
+			require.NotNil(t, metadata.Timings)
+			assert.InDelta(t, 1.5, metadata.Timings.SortMs, 0.001)
+			assert.GreaterOrEqual(t, metadata.Timings.TotalMs, metadata.Timings.SerializeMs)
+			if _, ok := tt.data.(listResponse); ok {
+				assert.Equal(t, 1, metadata.Count, "existing metadata is kept")
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/stacklok/toolhive-registry-server/internal/annotations"
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// maxAnnotationRequestSize caps the size of annotation request bodies
+const maxAnnotationRequestSize = 16 * 1024
+
+// AnnotationRequestBody is the annotation operators record about a server for the users of this deployment only
+type AnnotationRequestBody struct {
+	// Notes are internal notes on the server
+	Notes string `json:"notes,omitempty"`
+	// ApprovalStatus is approved, pending or rejected
+	ApprovalStatus string `json:"approvalStatus,omitempty"`
+	// OwnerTeam is the team owning the server in the organization
+	OwnerTeam string `json:"ownerTeam,omitempty"`
+}
+
+// AnnotationListResponse lists the annotations of servers
+type AnnotationListResponse struct {
+	Annotations []annotations.ServerAnnotation `json:"annotations"`
+}
+
+// listAnnotations handles GET /extension/v0/annotations
+//
+// @Summary		List server annotations
+// @Description	List the annotations operators recorded about servers for the users of this deployment, by server name
+// @Tags		extension
+// @Produce		json
+// @Param		approval_status	query	string	false	"Filter by approval status: approved, pending, or rejected"
+// @Param		owner_team		query	string	false	"Filter by owner team"
+// @Success		200	{object}	AnnotationListResponse	"Annotations of servers"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/annotations [get]
+func (r *Routes) listAnnotations(w http.ResponseWriter, req *http.Request) {
+	if r.annotations == nil {
+		common.WriteErrorResponse(w, "Annotations are not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	filter := registry.AnnotationFilter{
+		ApprovalStatus: req.URL.Query().Get("approval_status"),
+		OwnerTeam:      req.URL.Query().Get("owner_team"),
+	}
+	if filter.ApprovalStatus != "" && !slices.Contains(registry.ApprovalStatuses(), filter.ApprovalStatus) {
+		common.WriteErrorResponse(
+			w,
+			fmt.Sprintf("Invalid approval_status parameter: must be one of %s",
+				strings.Join(registry.ApprovalStatuses(), ", ")),
+			http.StatusBadRequest,
+		)
+		return
+	}
+
+	common.WriteJSONResponse(w, AnnotationListResponse{Annotations: r.annotations.List(filter)}, http.StatusOK)
+}
+
+// getServerAnnotation handles GET /extension/v0/servers/{serverName}/annotation
+//
+// @Summary		Get server annotation
+// @Description	Get the annotation operators recorded about a server for the users of this deployment
+// @Tags		extension
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Success		200	{object}	annotations.ServerAnnotation	"Annotation of the server"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server has no annotation"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/annotation [get]
+func (r *Routes) getServerAnnotation(w http.ResponseWriter, req *http.Request) {
+	if r.annotations == nil {
+		common.WriteErrorResponse(w, "Annotations are not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	annotation, ok := r.annotations.Annotation(serverName)
+	if !ok {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s has no annotation", serverName), http.StatusNotFound)
+		return
+	}
+	common.WriteJSONResponse(w, annotations.ServerAnnotation{Server: serverName, Annotation: annotation}, http.StatusOK)
+}
+
+// putServerAnnotation handles PUT /extension/v0/servers/{serverName}/annotation
+//
+// @Summary		Set server annotation
+// @Description	Record internal notes, an approval status and an owner team about a server, replacing its previous
+// @Description	annotation. Annotations are only visible in this deployment, under the io.github.stacklok/annotation
+// @Description	metadata key of the server in registry API responses. Restricted to the administrators of the API.
+// @Tags		extension
+// @Accept		json
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		annotation	body	AnnotationRequestBody	true	"Annotation of the server"
+// @Success		200	{object}	annotations.ServerAnnotation	"Annotation recorded for the server"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Caller is not an administrator"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/annotation [put]
+func (r *Routes) putServerAnnotation(w http.ResponseWriter, req *http.Request) {
+	if r.annotations == nil {
+		common.WriteErrorResponse(w, "Annotations are not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var body AnnotationRequestBody
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxAnnotationRequestSize)).Decode(&body); err != nil {
+		common.WriteErrorResponse(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Only servers of the catalog are annotated
+	server, err := r.latestServerVersion(req, serverName)
+	if errors.Is(err, service.ErrServerNotFound) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	annotation, err := r.annotations.Set(server.Name, registry.Annotation{
+		Notes:          body.Notes,
+		ApprovalStatus: body.ApprovalStatus,
+		OwnerTeam:      body.OwnerTeam,
+	})
+	if errors.Is(err, annotations.ErrInvalidAnnotation) {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	slog.InfoContext(req.Context(), "Server annotation set",
+		"server", server.Name, "approval_status", annotation.ApprovalStatus, "owner_team", annotation.OwnerTeam)
+
+	common.WriteJSONResponse(w, annotations.ServerAnnotation{Server: server.Name, Annotation: annotation}, http.StatusOK)
+}
+
+// deleteServerAnnotation handles DELETE /extension/v0/servers/{serverName}/annotation
+//
+// @Summary		Delete server annotation
+// @Description	Delete the annotation operators recorded about a server. Restricted to the administrators of the API.
+// @Tags		extension
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Success		204	"Annotation deleted"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Caller is not an administrator"
+// @Failure		404	{object}	map[string]string	"Server has no annotation"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/annotation [delete]
+func (r *Routes) deleteServerAnnotation(w http.ResponseWriter, req *http.Request) {
+	if r.annotations == nil {
+		common.WriteErrorResponse(w, "Annotations are not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deleted, err := r.annotations.Delete(serverName)
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !deleted {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s has no annotation", serverName), http.StatusNotFound)
+		return
+	}
+	slog.InfoContext(req.Context(), "Server annotation deleted", "server", serverName)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,221 @@
+package v0
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/annotations"
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/features"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+// testAdminGroup is the group of the administrators of the API in tests
+const testAdminGroup = "registry-admins"
+
+// newTestAdmins returns the administrators of the API in tests, the members of testAdminGroup
+func newTestAdmins() *auth.Admins {
+	return auth.NewAdmins(&config.AdminConfig{Values: []string{testAdminGroup}})
+}
+
+// withCaller returns a copy of req authenticated as a caller member of the given groups
+func withCaller(req *http.Request, groups ...string) *http.Request {
+	return req.WithContext(auth.ContextWithClaims(req.Context(), jwt.MapClaims{"sub": "alice", "groups": groups}))
+}
+
+func TestPutServerAnnotation(t *testing.T) {
+	t.Parallel()
+
+	older := registry.NewTestServer("com.example/test-server", registry.WithServerVersion("1.0.0"))
+	latest := registry.NewTestServer("com.example/test-server", registry.WithServerVersion("1.1.0"))
+	versions := []*upstreamv0.ServerJSON{&latest, &older}
+
+	tests := []struct {
+		name       string
+		store      bool
+		writes     bool
+		noAdmins   bool
+		groups     []string
+		body       string
+		versions   []*upstreamv0.ServerJSON
+		wantStatus int
+	}{
+		{
+			name:       "annotation set",
+			store:      true,
+			writes:     true,
+			groups:     []string{testAdminGroup},
+			body:       `{"notes":"Vetted","approvalStatus":"approved","ownerTeam":"platform"}`,
+			versions:   versions,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid approval status",
+			store:      true,
+			writes:     true,
+			groups:     []string{testAdminGroup},
+			body:       `{"approvalStatus":"maybe"}`,
+			versions:   versions,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid body",
+			store:      true,
+			writes:     true,
+			groups:     []string{testAdminGroup},
+			body:       `{"notes":1}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "server not found",
+			store:      true,
+			writes:     true,
+			groups:     []string{testAdminGroup},
+			body:       `{"ownerTeam":"platform"}`,
+			versions:   []*upstreamv0.ServerJSON{},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "caller is not an administrator",
+			store:      true,
+			writes:     true,
+			groups:     []string{"developers"},
+			body:       `{"ownerTeam":"platform"}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "administrators not configured",
+			store:      true,
+			writes:     true,
+			noAdmins:   true,
+			groups:     []string{testAdminGroup},
+			body:       `{"ownerTeam":"platform"}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "writes disabled",
+			store:      true,
+			groups:     []string{testAdminGroup},
+			body:       `{"ownerTeam":"platform"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "annotations not enabled",
+			writes:     true,
+			groups:     []string{testAdminGroup},
+			body:       `{"ownerTeam":"platform"}`,
+			wantStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			if tt.versions != nil {
+				expectServerVersions(t, mockSvc, "com.example/test-server", tt.versions, nil)
+			}
+			store, err := annotations.NewStore(filepath.Join(t.TempDir(), "annotations.json"))
+			require.NoError(t, err)
+			flags, err := features.New(map[string]bool{features.RegistryWrites: tt.writes},
+				features.WithLookupEnv(func(string) (string, bool) { return "", false }))
+			require.NoError(t, err)
+			opts := []RouterOption{WithFeatures(flags)}
+			if !tt.noAdmins {
+				opts = append(opts, WithAdmins(newTestAdmins()))
+			}
+			if tt.store {
+				opts = append(opts, WithAnnotations(store))
+			}
+			router := Router(mockSvc, opts...)
+
+			req, err := http.NewRequest(http.MethodPut, "/servers/com.example%2Ftest-server/annotation",
+				strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, withCaller(req, tt.groups...))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				assert.Empty(t, store.List(registry.AnnotationFilter{}))
+				return
+			}
+
+			var response annotations.ServerAnnotation
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, "com.example/test-server", response.Server)
+			assert.Equal(t, registry.ApprovalApproved, response.ApprovalStatus)
+			assert.False(t, response.UpdatedAt.IsZero())
+			stored, ok := store.Annotation("com.example/test-server")
+			require.True(t, ok)
+			assert.Equal(t, response.Annotation, stored)
+		})
+	}
+}
+
+func TestServerAnnotationEndpoints(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	store, err := annotations.NewStore(filepath.Join(t.TempDir(), "annotations.json"))
+	require.NoError(t, err)
+	_, err = store.Set("com.example/approved", registry.Annotation{ApprovalStatus: registry.ApprovalApproved})
+	require.NoError(t, err)
+	_, err = store.Set("com.example/pending", registry.Annotation{ApprovalStatus: registry.ApprovalPending})
+	require.NoError(t, err)
+	router := Router(mocks.NewMockRegistryService(ctrl), WithAnnotations(store), WithAdmins(newTestAdmins()))
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, withCaller(httptest.NewRequest(method, path, nil), testAdminGroup))
+		return rr
+	}
+
+	rr := serve(http.MethodGet, "/annotations?approval_status=pending")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var list AnnotationListResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+	require.Len(t, list.Annotations, 1)
+	assert.Equal(t, "com.example/pending", list.Annotations[0].Server)
+
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/annotations?approval_status=maybe").Code)
+
+	rr = serve(http.MethodGet, "/servers/com.example%2Fapproved/annotation")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var annotation annotations.ServerAnnotation
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &annotation))
+	assert.Equal(t, registry.ApprovalApproved, annotation.ApprovalStatus)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, withCaller(httptest.NewRequest(http.MethodDelete, "/servers/com.example%2Fapproved/annotation", nil)))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/servers/com.example%2Fapproved/annotation", nil))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/servers/com.example%2Fapproved/annotation").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, "/servers/com.example%2Fapproved/annotation").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/servers/com.example%2Fapproved/annotation").Code)
+
+	unconfigured := Router(mocks.NewMockRegistryService(ctrl))
+	rr = httptest.NewRecorder()
+	unconfigured.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/annotations", nil))
+	assert.Equal(t, http.StatusNotImplemented, rr.Code)
+}
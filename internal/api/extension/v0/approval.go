@@ -0,0 +1,213 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/approval"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// maxApprovalRequestSize caps the size of approval request bodies
+const maxApprovalRequestSize = 64 * 1024
+
+// ApprovalRequiredResponse is returned instead of install instructions for servers awaiting approval
+type ApprovalRequiredResponse struct {
+	Error string `json:"error"`
+	// Ticket identifies the approval request filed with the approval system, if any
+	Ticket string `json:"ticket,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// checkApproval consults the approval checker, if any, before install instructions for a server
+// are handed out. It writes the response and returns false when the instructions must be withheld.
+// Failed checks withhold the instructions.
+func (r *Routes) checkApproval(
+	w http.ResponseWriter,
+	req *http.Request,
+	server *upstreamv0.ServerJSON,
+	action string,
+) bool {
+	if r.approvalChecker == nil {
+		return true
+	}
+
+	decision, err := r.approvalChecker.Check(req.Context(), approval.Request{
+		Server:  server.Name,
+		Version: server.Version,
+		Action:  action,
+	})
+	if err != nil {
+		slog.ErrorContext(req.Context(), "Approval check failed",
+			"server", server.Name, "version", server.Version, "error", err)
+		common.WriteErrorResponse(w, "Approval check failed, try again later", http.StatusServiceUnavailable)
+		return false
+	}
+	if decision.Approved {
+		return true
+	}
+
+	message := fmt.Sprintf("Server %s requires approval", server.Name)
+	if decision.Ticket != "" {
+		message += "; request filed as " + decision.Ticket
+	}
+	common.WriteJSONResponse(w, ApprovalRequiredResponse{
+		Error:  message,
+		Ticket: decision.Ticket,
+		Reason: decision.Reason,
+	}, http.StatusForbidden)
+	return false
+}
+
+// ApprovalRequestBody describes who requests the approval of a server and why
+type ApprovalRequestBody struct {
+	Requester     string `json:"requester,omitempty"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// ApprovalRequestResponse identifies the ticket filed to request the approval of a server
+type ApprovalRequestResponse struct {
+	Server      string               `json:"server"`
+	Version     string               `json:"version"`
+	Ticket      string               `json:"ticket"`
+	URL         string               `json:"url"`
+	RiskProfile registry.RiskProfile `json:"riskProfile"`
+}
+
+// requestServerApproval handles POST /extension/v0/servers/{serverName}/versions/{version}/approval-requests
+//
+// @Summary		Request server approval
+// @Description	File a ticket in the configured ticketing system (Jira or ServiceNow) requesting the approval
+// @Description	of a server version, with the server metadata and its risk profile attached.
+// @Tags		extension
+// @Accept		json
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		path	string	true	"URL-encoded version (e.g., \"1.0.0\" or \"latest\")"
+// @Param		request		body	ApprovalRequestBody	false	"Requester and justification"
+// @Success		201	{object}	ApprovalRequestResponse	"Ticket filed"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Failure		501	{object}	map[string]string	"No ticketing system configured"
+// @Failure		502	{object}	map[string]string	"Ticketing system error"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/versions/{version}/approval-requests [post]
+func (r *Routes) requestServerApproval(w http.ResponseWriter, req *http.Request) {
+	if r.ticketFiler == nil {
+		common.WriteErrorResponse(w, "No ticketing system is configured", http.StatusNotImplemented)
+		return
+	}
+
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := common.GetAndValidateURLParam(req, "version")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The body is optional
+	var body ApprovalRequestBody
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxApprovalRequestSize)).Decode(&body); err != nil &&
+		!errors.Is(err, io.EOF) {
+		common.WriteErrorResponse(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	server, err := r.service.GetServerVersion(
+		req.Context(),
+		service.WithName[service.GetServerVersionOptions](serverName),
+		service.WithVersion[service.GetServerVersionOptions](version),
+	)
+	if errors.Is(err, service.ErrServerNotFound) || (err == nil && server == nil) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	profile := registry.RiskProfileOf(server)
+	filed, err := r.ticketFiler.File(req.Context(), approvalTicket(server, profile, body))
+	if err != nil {
+		slog.ErrorContext(req.Context(), "Failed to file approval request",
+			"server", server.Name, "version", server.Version, "error", err)
+		common.WriteErrorResponse(w, "Failed to file approval request", http.StatusBadGateway)
+		return
+	}
+
+	common.WriteJSONResponse(w, ApprovalRequestResponse{
+		Server:      server.Name,
+		Version:     server.Version,
+		Ticket:      filed.ID,
+		URL:         filed.URL,
+		RiskProfile: profile,
+	}, http.StatusCreated)
+}
+
+// approvalTicket describes the approval request of a server for a ticketing system
+func approvalTicket(
+	server *upstreamv0.ServerJSON,
+	profile registry.RiskProfile,
+	body ApprovalRequestBody,
+) approval.Ticket {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Approval requested for MCP server %s version %s.\n\n", server.Name, server.Version)
+	if body.Requester != "" {
+		fmt.Fprintf(&sb, "Requested by: %s\n", body.Requester)
+	}
+	if body.Justification != "" {
+		fmt.Fprintf(&sb, "Justification: %s\n", body.Justification)
+	}
+	if server.Title != "" {
+		fmt.Fprintf(&sb, "Title: %s\n", server.Title)
+	}
+	fmt.Fprintf(&sb, "Description: %s\n", server.Description)
+	if server.Repository != nil && server.Repository.URL != "" {
+		fmt.Fprintf(&sb, "Repository: %s\n", server.Repository.URL)
+	}
+	if server.WebsiteURL != "" {
+		fmt.Fprintf(&sb, "Website: %s\n", server.WebsiteURL)
+	}
+	for _, pkg := range server.Packages {
+		fmt.Fprintf(&sb, "Package: %s %s\n", pkg.RegistryType, pkg.Identifier)
+	}
+
+	sb.WriteString("\nRisk profile:\n")
+	fmt.Fprintf(&sb, "- Lifecycle status: %s\n", profile.LifecycleStatus)
+	fmt.Fprintf(&sb, "- Prompt-injection risk: %d/%d %s\n",
+		profile.InjectionRisk.Score, registry.MaxInjectionRiskScore, listOrNone(profile.InjectionRisk.Signals))
+	fmt.Fprintf(&sb, "- Secret environment variables: %s\n", listOrNone(profile.SecretEnvironmentVariables))
+	fmt.Fprintf(&sb, "- Values resembling credentials: %s\n", listOrNone(profile.CredentialLikeValues))
+	fmt.Fprintf(&sb, "- Packages not pinned by digest: %s\n", listOrNone(profile.UnpinnedPackages))
+	fmt.Fprintf(&sb, "- Remote endpoints: %s\n", listOrNone(profile.Remotes))
+
+	return approval.Ticket{
+		Summary:     fmt.Sprintf("Approve MCP server %s %s", server.Name, server.Version),
+		Description: sb.String(),
+	}
+}
+
+// listOrNone joins values for a ticket description, or returns "none" for empty lists
+func listOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
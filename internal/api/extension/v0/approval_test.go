@@ -0,0 +1,215 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/approval"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+// fakeApprovalChecker answers approval checks with a fixed decision
+type fakeApprovalChecker struct {
+	decision *approval.Decision
+	err      error
+	requests []approval.Request
+}
+
+func (c *fakeApprovalChecker) Check(_ context.Context, req approval.Request) (*approval.Decision, error) {
+	c.requests = append(c.requests, req)
+	return c.decision, c.err
+}
+
+func TestOfflineBundleApproval(t *testing.T) {
+	t.Parallel()
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithOCIPackage("ghcr.io/example/test-server:1.0.0"),
+	)
+
+	tests := []struct {
+		name       string
+		checker    *fakeApprovalChecker
+		wantStatus int
+		wantError  string
+		wantTicket string
+	}{
+		{
+			name:       "approved",
+			checker:    &fakeApprovalChecker{decision: &approval.Decision{Approved: true}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "approval requested",
+			checker: &fakeApprovalChecker{decision: &approval.Decision{
+				Ticket: "TICKET-123",
+				Reason: "server is not on the allow list",
+			}},
+			wantStatus: http.StatusForbidden,
+			wantError:  "Server com.example/test-server requires approval; request filed as TICKET-123",
+			wantTicket: "TICKET-123",
+		},
+		{
+			name:       "denied without ticket",
+			checker:    &fakeApprovalChecker{decision: &approval.Decision{}},
+			wantStatus: http.StatusForbidden,
+			wantError:  "Server com.example/test-server requires approval",
+		},
+		{
+			name:       "approval system unavailable",
+			checker:    &fakeApprovalChecker{err: errors.New("connection refused")},
+			wantStatus: http.StatusServiceUnavailable,
+			wantError:  "Approval check failed, try again later",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			mockSvc.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			router := Router(mockSvc, WithApprovalChecker(tt.checker))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet,
+				"/servers/com.example%2Ftest-server/versions/1.0.0/offline-bundle", nil))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			assert.Equal(t, []approval.Request{{
+				Server:  "com.example/test-server",
+				Version: "1.0.0",
+				Action:  EndpointOfflineBundle,
+			}}, tt.checker.requests)
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+
+			var response ApprovalRequiredResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantError, response.Error)
+			assert.Equal(t, tt.wantTicket, response.Ticket)
+		})
+	}
+}
+
+// fakeTicketFiler records the tickets it files
+type fakeTicketFiler struct {
+	filed   *approval.FiledTicket
+	err     error
+	tickets []approval.Ticket
+}
+
+func (f *fakeTicketFiler) File(_ context.Context, ticket approval.Ticket) (*approval.FiledTicket, error) {
+	f.tickets = append(f.tickets, ticket)
+	return f.filed, f.err
+}
+
+func TestRequestServerApproval(t *testing.T) {
+	t.Parallel()
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithDescription("Weather forecasts"),
+		registry.WithOCIPackage("ghcr.io/example/test-server:1.0.0"),
+	)
+	const path = "/servers/com.example%2Ftest-server/versions/1.0.0/approval-requests"
+
+	tests := []struct {
+		name       string
+		filer      *fakeTicketFiler
+		body       string
+		setupMocks func(*mocks.MockRegistryService)
+		wantStatus int
+	}{
+		{
+			name:  "ticket filed",
+			filer: &fakeTicketFiler{filed: &approval.FiledTicket{ID: "MCP-123", URL: "https://jira.example.com/browse/MCP-123"}},
+			body:  `{"requester": "alice@example.com", "justification": "Weather data for the travel agent"}`,
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:  "ticket filed without body",
+			filer: &fakeTicketFiler{filed: &approval.FiledTicket{ID: "MCP-124", URL: "https://jira.example.com/browse/MCP-124"}},
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "invalid body",
+			filer:      &fakeTicketFiler{},
+			body:       `{"requester":`,
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "ticketing system error",
+			filer: &fakeTicketFiler{err: errors.New("HTTP 401")},
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus: http.StatusBadGateway,
+		},
+		{
+			name:       "no ticketing system",
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			var opts []RouterOption
+			if tt.filer != nil {
+				opts = append(opts, WithTicketFiler(tt.filer))
+			}
+			router := Router(mockSvc, opts...)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+
+			var response ApprovalRequestResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.filer.filed.ID, response.Ticket)
+			assert.Equal(t, tt.filer.filed.URL, response.URL)
+			assert.Equal(t, []string{"ghcr.io/example/test-server:1.0.0"}, response.RiskProfile.UnpinnedPackages)
+
+			require.Len(t, tt.filer.tickets, 1)
+			ticket := tt.filer.tickets[0]
+			assert.Equal(t, "Approve MCP server com.example/test-server 1.0.0", ticket.Summary)
+			assert.Contains(t, ticket.Description, "Package: oci ghcr.io/example/test-server:1.0.0")
+			assert.Contains(t, ticket.Description, "- Prompt-injection risk: 0/100 none")
+			if tt.body != "" {
+				assert.Contains(t, ticket.Description, "Requested by: alice@example.com")
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+const (
+	// BadgeStars renders the repository star count of a server
+	BadgeStars = "stars"
+	// BadgeTier renders the ToolHive tier of a server
+	BadgeTier = "tier"
+	// BadgeStatus renders the ToolHive status of a server
+	BadgeStatus = "status"
+
+	badgeColorUnknown = "lightgrey"
+	badgeMessageNone  = "unknown"
+)
+
+// BadgeResponse is a shields.io endpoint badge
+// See https://shields.io/badges/endpoint-badge for the schema
+type BadgeResponse struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// getBadge handles GET /extension/v0/badges/{serverName}/{badge}
+//
+// @Summary		Get server badge
+// @Description	Get a shields.io-compatible badge for the latest version of a server.
+// @Description	Supported badges are `stars`, `tier`, and `status`.
+// @Tags		extension
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		badge		path	string	true	"Badge kind (stars, tier, status)"
+// @Success		200	{object}	BadgeResponse	"shields.io endpoint badge"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/badges/{serverName}/{badge} [get]
+func (r *Routes) getBadge(w http.ResponseWriter, req *http.Request) {
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	badge, err := common.GetAndValidateURLParam(req, "badge")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if badge != BadgeStars && badge != BadgeTier && badge != BadgeStatus {
+		common.WriteErrorResponse(w, fmt.Sprintf("Unsupported badge %s", badge), http.StatusBadRequest)
+		return
+	}
+
+	server, err := r.latestServerVersion(req, serverName)
+	if errors.Is(err, service.ErrServerNotFound) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := BadgeResponse{
+		SchemaVersion: 1,
+		Label:         badge,
+		Message:       badgeMessageNone,
+		Color:         badgeColorUnknown,
+	}
+
+	switch badge {
+	case BadgeStars:
+		if stars, ok := registry.ExtractStars(server); ok {
+			response.Message = strconv.Itoa(stars)
+			response.Color = "blue"
+		}
+	case BadgeTier:
+		if tier := registry.ExtractTier(server); tier != "" {
+			response.Message = tier
+			response.Color = tierColor(tier)
+		}
+	case BadgeStatus:
+		if status := registry.ExtractStatus(server); status != "" {
+			response.Message = status
+			response.Color = statusColor(status)
+		}
+	}
+
+	common.WriteJSONResponse(w, response, http.StatusOK)
+}
+
+// tierColor returns the badge color for a ToolHive tier
+func tierColor(tier string) string {
+	switch tier {
+	case "Official":
+		return "brightgreen"
+	case "Community":
+		return "blue"
+	default:
+		return badgeColorUnknown
+	}
+}
+
+// statusColor returns the badge color for a ToolHive status
+func statusColor(status string) string {
+	switch status {
+	case "Active":
+		return "brightgreen"
+	case "Deprecated":
+		return "red"
+	default:
+		return "yellow"
+	}
+}
@@ -0,0 +1,133 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestGetBadge(t *testing.T) {
+	t.Parallel()
+
+	oldVersion := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithToolHiveMetadata("tier", "Community"),
+	)
+	newVersion := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("2.0.0"),
+		registry.WithToolHiveMetadata("tier", "Official"),
+		registry.WithToolHiveMetadata("status", "Deprecated"),
+		registry.WithToolHiveMetadata("metadata", map[string]any{"stars": float64(42)}),
+	)
+	bareServer := registry.NewTestServer("com.example/test-server")
+
+	tests := []struct {
+		name       string
+		path       string
+		setupMocks func(*testing.T, *mocks.MockRegistryService)
+		wantStatus int
+		wantBadge  BadgeResponse
+	}{
+		{
+			name: "stars badge uses latest version whatever the order",
+			path: "/badges/com.example%2Ftest-server/stars",
+			setupMocks: func(t *testing.T, m *mocks.MockRegistryService) {
+				expectServerVersions(t, m, "com.example/test-server", []*upstreamv0.ServerJSON{&newVersion, &oldVersion}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBadge:  BadgeResponse{SchemaVersion: 1, Label: "stars", Message: "42", Color: "blue"},
+		},
+		{
+			name: "tier badge",
+			path: "/badges/com.example%2Ftest-server/tier",
+			setupMocks: func(t *testing.T, m *mocks.MockRegistryService) {
+				expectServerVersions(t, m, "com.example/test-server", []*upstreamv0.ServerJSON{&newVersion, &oldVersion}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBadge:  BadgeResponse{SchemaVersion: 1, Label: "tier", Message: "Official", Color: "brightgreen"},
+		},
+		{
+			name: "status badge",
+			path: "/badges/com.example%2Ftest-server/status",
+			setupMocks: func(t *testing.T, m *mocks.MockRegistryService) {
+				expectServerVersions(t, m, "com.example/test-server", []*upstreamv0.ServerJSON{&newVersion}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBadge:  BadgeResponse{SchemaVersion: 1, Label: "status", Message: "Deprecated", Color: "red"},
+		},
+		{
+			name: "missing metadata renders unknown",
+			path: "/badges/com.example%2Ftest-server/stars",
+			setupMocks: func(t *testing.T, m *mocks.MockRegistryService) {
+				expectServerVersions(t, m, "com.example/test-server", []*upstreamv0.ServerJSON{&bareServer}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBadge:  BadgeResponse{SchemaVersion: 1, Label: "stars", Message: "unknown", Color: "lightgrey"},
+		},
+		{
+			name: "server not found",
+			path: "/badges/com.example%2Fmissing/tier",
+			setupMocks: func(t *testing.T, m *mocks.MockRegistryService) {
+				expectServerVersions(t, m, "com.example/missing", []*upstreamv0.ServerJSON{}, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "service error",
+			path: "/badges/com.example%2Ftest-server/tier",
+			setupMocks: func(t *testing.T, m *mocks.MockRegistryService) {
+				expectServerVersions(t, m, "com.example/test-server", nil, errors.New("boom"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "unsupported badge",
+			path:       "/badges/com.example%2Ftest-server/quality",
+			setupMocks: func(_ *testing.T, _ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid server name",
+			path:       "/badges/invalid-name/tier",
+			setupMocks: func(_ *testing.T, _ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(t, mockSvc)
+			router := Router(mockSvc)
+
+			req, err := http.NewRequest(http.MethodGet, tt.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var badge BadgeResponse
+				err = json.Unmarshal(rr.Body.Bytes(), &badge)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantBadge, badge)
+			}
+		})
+	}
+}
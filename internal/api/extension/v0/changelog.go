@@ -0,0 +1,87 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/changelog"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// ChangelogResponse is the release notes of a server version
+type ChangelogResponse struct {
+	Server  string `json:"server"`
+	Version string `json:"version"`
+	changelog.Changelog
+}
+
+// getServerChangelog handles GET /extension/v0/servers/{serverName}/versions/{version}/changelog
+//
+// @Summary		Get server changelog
+// @Description	Get the release notes of a server version from its GitHub repository: the GitHub release tagged
+// @Description	with the version, or else the section of the CHANGELOG.md file headed with the version. When neither
+// @Description	exists, the notes of the latest release or the top of the CHANGELOG.md file are returned with
+// @Description	matchesVersion set to false. Notes are cached for an hour and cut to 32 KiB.
+// @Tags		extension
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		path	string	true	"URL-encoded version (e.g., \"1.0.0\" or \"latest\")"
+// @Success		200	{object}	ChangelogResponse	"Release notes"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found, not hosted on GitHub or without release notes"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Failure		502	{object}	map[string]string	"GitHub API error"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/versions/{version}/changelog [get]
+func (r *Routes) getServerChangelog(w http.ResponseWriter, req *http.Request) {
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := common.GetAndValidateURLParam(req, "version")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	server, err := r.service.GetServerVersion(
+		req.Context(),
+		service.WithName[service.GetServerVersionOptions](serverName),
+		service.WithVersion[service.GetServerVersionOptions](version),
+	)
+	if errors.Is(err, service.ErrServerNotFound) || (err == nil && server == nil) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if server.Repository == nil || server.Repository.URL == "" {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s has no repository", serverName), http.StatusNotFound)
+		return
+	}
+
+	notes, err := r.changelogFetcher.Fetch(req.Context(), server.Repository.URL, server.Repository.Subfolder, server.Version)
+	switch {
+	case errors.Is(err, changelog.ErrUnsupportedRepository):
+		common.WriteErrorResponse(w, fmt.Sprintf("Repository of server %s is not hosted on GitHub", serverName), http.StatusNotFound)
+		return
+	case errors.Is(err, changelog.ErrNotFound):
+		common.WriteErrorResponse(w, fmt.Sprintf("No release notes found for server %s", serverName), http.StatusNotFound)
+		return
+	case err != nil:
+		slog.WarnContext(req.Context(), "Failed to get release notes", "server", serverName, "error", err)
+		common.WriteErrorResponse(w, "Failed to get release notes from GitHub", http.StatusBadGateway)
+		return
+	}
+
+	common.WriteJSONResponse(w, ChangelogResponse{Server: server.Name, Version: server.Version, Changelog: *notes}, http.StatusOK)
+}
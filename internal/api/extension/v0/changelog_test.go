@@ -0,0 +1,131 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/changelog"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+// newTestChangelogFetcher serves the v1.0.0 release of acme/server, fails for acme/unavailable
+// and knows no other repository
+func newTestChangelogFetcher(t *testing.T) *changelog.Fetcher {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/server/releases/tags/v1.0.0":
+			_, _ = w.Write([]byte(`{"tag_name":"v1.0.0","body":"First release","html_url":"https://github.com/acme/server/releases/tag/v1.0.0"}`))
+		case "/repos/acme/unavailable/releases/tags/v1.0.0":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return changelog.NewFetcher(changelog.WithGitHubAPIURL(server.URL))
+}
+
+// withRepository sets the repository URL of the server
+func withRepository(repositoryURL string) registry.ServerOption {
+	return func(server *upstreamv0.ServerJSON) {
+		server.Repository = &model.Repository{URL: repositoryURL, Source: "github"}
+	}
+}
+
+func TestGetServerChangelog(t *testing.T) {
+	t.Parallel()
+	fetcher := newTestChangelogFetcher(t)
+
+	newServer := func(opts ...registry.ServerOption) *upstreamv0.ServerJSON {
+		server := registry.NewTestServer("com.example/test-server", opts...)
+		return &server
+	}
+
+	tests := []struct {
+		name       string
+		server     *upstreamv0.ServerJSON
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "release notes of the version",
+			server:     newServer(withRepository("https://github.com/acme/server")),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "server without repository",
+			server:     newServer(),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "repository not hosted on GitHub",
+			server:     newServer(withRepository("https://gitlab.com/acme/server")),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "repository without release notes",
+			server:     newServer(withRepository("https://github.com/acme/empty")),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "GitHub API error",
+			server:     newServer(withRepository("https://github.com/acme/unavailable")),
+			wantStatus: http.StatusBadGateway,
+		},
+		{
+			name:       "server not found",
+			err:        service.ErrServerNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "service error",
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			mockSvc.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(tt.server, tt.err)
+			router := Router(mockSvc, WithChangelogFetcher(fetcher))
+
+			req, err := http.NewRequest(http.MethodGet, "/servers/com.example%2Ftest-server/versions/1.0.0/changelog", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response ChangelogResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, "com.example/test-server", response.Server)
+			assert.Equal(t, "1.0.0", response.Version)
+			assert.Equal(t, changelog.SourceRelease, response.Source)
+			assert.Equal(t, "v1.0.0", response.Tag)
+			assert.True(t, response.MatchesVersion)
+			assert.Equal(t, "First release", response.Notes)
+			assert.Equal(t, "https://github.com/acme/server", response.Repository)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/clientconfig"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// maxClientConfigServers caps the number of servers configured in one client configuration
+const maxClientConfigServers = 20
+
+// getClientConfig handles GET /extension/v0/client-config
+//
+// @Summary		Generate client configuration
+// @Description	Generate the configuration of an MCP client (claude-desktop, cursor, vscode, windsurf or zed) launching
+// @Description	or connecting to several servers, as a single JSON block to merge into its configuration file. Servers
+// @Description	exposing a remote endpoint are connected to by URL with their headers; others are launched from their
+// @Description	first package the client can start. Environment variables and header values users must provide are set
+// @Description	to placeholders (inputs prompted for by vscode). Other servers are left out with a warning.
+// @Description	Default values resembling credentials are replaced with placeholders.
+// @Description	When an approval system is configured, servers it has not approved are answered with 403.
+// @Tags		extension
+// @Produce		json
+// @Param		client	query	string	true	"Client to configure: claude-desktop, cursor, vscode, windsurf or zed"
+// @Param		server	query	[]string	true	"Server name with optional @version suffix (latest by default)"	collectionFormat(multi)
+// @Success		200	{object}	clientconfig.Config	"Client configuration"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	ApprovalRequiredResponse	"Server requires approval"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Failure		503	{object}	map[string]string	"Approval check failed"
+// @Security	BearerAuth
+// @Router		/extension/v0/client-config [get]
+func (r *Routes) getClientConfig(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	client := query.Get("client")
+	if client == "" {
+		common.WriteErrorResponse(w, "client parameter is required", http.StatusBadRequest)
+		return
+	}
+	refs := query["server"]
+	if len(refs) == 0 {
+		common.WriteErrorResponse(w, "server parameter is required", http.StatusBadRequest)
+		return
+	}
+	if len(refs) > maxClientConfigServers {
+		common.WriteErrorResponse(w,
+			fmt.Sprintf("At most %d servers can be configured at once", maxClientConfigServers), http.StatusBadRequest)
+		return
+	}
+
+	if err := clientconfig.ValidateClient(client); err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	servers := make([]*upstreamv0.ServerJSON, 0, len(refs))
+	for _, ref := range refs {
+		name, version := splitServerReference(ref)
+		if name == "" {
+			common.WriteErrorResponse(w, fmt.Sprintf("Invalid server reference %q", ref), http.StatusBadRequest)
+			return
+		}
+
+		server, err := r.getServerReference(req, name, version)
+		if errors.Is(err, service.ErrServerNotFound) {
+			common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", ref), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !r.checkApproval(w, req, server, EndpointClientConfig) {
+			return
+		}
+		servers = append(servers, redactSecrets(req, server))
+	}
+
+	config, err := clientconfig.Generate(client, servers)
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	common.WriteJSONResponse(w, config, http.StatusOK)
+}
@@ -0,0 +1,101 @@
+package v0
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/clientconfig"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestGetClientConfig(t *testing.T) {
+	t.Parallel()
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithOCIPackage("ghcr.io/example/test-server:1.0.0"),
+	)
+
+	tests := []struct {
+		name       string
+		path       string
+		setupMocks func(*mocks.MockRegistryService)
+		wantStatus int
+		wantKey    string
+	}{
+		{
+			name: "latest version",
+			path: "/client-config?client=cursor&server=com.example/test-server",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).
+					Return([]*upstreamv0.ServerJSON{&server}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantKey:    "mcpServers",
+		},
+		{
+			name: "given version",
+			path: "/client-config?client=zed&server=com.example/test-server@1.0.0",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantKey:    "context_servers",
+		},
+		{
+			name:       "unsupported client",
+			path:       "/client-config?client=notepad&server=com.example/test-server",
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing server",
+			path:       "/client-config?client=cursor",
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "server not found",
+			path: "/client-config?client=cursor&server=com.example/missing",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).Return(nil, service.ErrServerNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			router := Router(mockSvc)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var config clientconfig.Config
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &config))
+			require.Len(t, config.Servers, 1)
+			assert.Equal(t, "test-server", config.Servers[0].Key)
+			assert.Contains(t, config.Config, tt.wantKey)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/contextpack"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+const (
+	// ContextPackFormatJSON renders the context pack as JSON
+	ContextPackFormatJSON = "json"
+	// ContextPackFormatMarkdown renders the context pack as Markdown
+	ContextPackFormatMarkdown = "markdown"
+)
+
+// getContextPack handles GET /extension/v0/servers/{serverName}/versions/{version}/context-pack
+//
+// @Summary		Get server context pack
+// @Description	Get a compact briefing on a server version for AI agents: summary, tool names, environment
+// @Description	variables, quickstart commands and caveats, sized under a token budget estimated at 4 bytes of
+// @Description	Markdown per token. Descriptions, optional variables and long tool lists are condensed first
+// @Description	when the budget is exceeded; caveats are always kept. Credential-like example values are redacted.
+// @Description	Long descriptions are replaced with their summary when summaries are configured.
+// @Tags		extension
+// @Produce		json
+// @Produce		text/markdown
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		path	string	true	"URL-encoded version (e.g., \"1.0.0\" or \"latest\")"
+// @Param		max_tokens	query	int		false	"Token budget, between 50 and 4000 (default 500)"
+// @Param		format		query	string	false	"Output format: json (default) or markdown"
+// @Success		200	{object}	contextpack.Pack	"Context pack"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/versions/{version}/context-pack [get]
+func (r *Routes) getContextPack(w http.ResponseWriter, req *http.Request) {
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := common.GetAndValidateURLParam(req, "version")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := req.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = ContextPackFormatJSON
+	}
+	if format != ContextPackFormatJSON && format != ContextPackFormatMarkdown {
+		common.WriteErrorResponse(w, fmt.Sprintf("Unsupported format %s", format), http.StatusBadRequest)
+		return
+	}
+
+	budget := contextpack.DefaultTokenBudget
+	if budgetStr := query.Get("max_tokens"); budgetStr != "" {
+		budget, err = strconv.Atoi(budgetStr)
+		if err != nil || budget < contextpack.MinTokenBudget || budget > contextpack.MaxTokenBudget {
+			common.WriteErrorResponse(w, fmt.Sprintf("Invalid max_tokens parameter: must be an integer between %d and %d",
+				contextpack.MinTokenBudget, contextpack.MaxTokenBudget), http.StatusBadRequest)
+			return
+		}
+	}
+
+	server, err := r.service.GetServerVersion(
+		req.Context(),
+		service.WithName[service.GetServerVersionOptions](serverName),
+		service.WithVersion[service.GetServerVersionOptions](version),
+	)
+	if errors.Is(err, service.ErrServerNotFound) || (err == nil && server == nil) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var opts []contextpack.Option
+	if r.summaries != nil {
+		opts = append(opts, contextpack.WithSummary(r.summaries.Summarize(req.Context(), server.Description)))
+	}
+	pack := contextpack.New(redactSecrets(req, server), budget, opts...)
+	if format == ContextPackFormatMarkdown {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(pack.Markdown()))
+		return
+	}
+
+	common.WriteJSONResponse(w, pack, http.StatusOK)
+}
+
+// getServerTools handles GET /extension/v0/servers/{serverName}/versions/{version}/tools
+//
+// @Summary		List server tools
+// @Description	List the tools of a server version page by page, e.g. the tools left out of its context pack
+// @Description	from the toolsCursor of the pack.
+// @Tags		extension
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		path	string	true	"URL-encoded version (e.g., \"1.0.0\" or \"latest\")"
+// @Param		cursor		query	string	false	"Cursor of the page, the first page if not given"
+// @Param		limit		query	int		false	"Number of tools per page, up to 200 (default 50)"
+// @Success		200	{object}	contextpack.ToolPage	"Page of tools"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/versions/{version}/tools [get]
+func (r *Routes) getServerTools(w http.ResponseWriter, req *http.Request) {
+	server, cursor, limit, ok := r.getServerPage(w, req)
+	if !ok {
+		return
+	}
+
+	page, err := contextpack.Tools(server, cursor, limit)
+	if err != nil {
+		common.WriteErrorResponse(w, "Invalid cursor parameter", http.StatusBadRequest)
+		return
+	}
+	common.WriteJSONResponse(w, page, http.StatusOK)
+}
+
+// getServerEnvironmentVariables handles GET /extension/v0/servers/{serverName}/versions/{version}/environment-variables
+//
+// @Summary		List server environment variables
+// @Description	List the environment variables of a server version page by page, required first, e.g. the
+// @Description	variables left out of its context pack from the environmentVariablesCursor of the pack.
+// @Description	Credential-like example values are redacted.
+// @Tags		extension
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		path	string	true	"URL-encoded version (e.g., \"1.0.0\" or \"latest\")"
+// @Param		cursor		query	string	false	"Cursor of the page, the first page if not given"
+// @Param		limit		query	int		false	"Number of variables per page, up to 200 (default 50)"
+// @Success		200	{object}	contextpack.EnvironmentVariablePage	"Page of environment variables"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/versions/{version}/environment-variables [get]
+func (r *Routes) getServerEnvironmentVariables(w http.ResponseWriter, req *http.Request) {
+	server, cursor, limit, ok := r.getServerPage(w, req)
+	if !ok {
+		return
+	}
+
+	page, err := contextpack.EnvironmentVariables(redactSecrets(req, server), cursor, limit)
+	if err != nil {
+		common.WriteErrorResponse(w, "Invalid cursor parameter", http.StatusBadRequest)
+		return
+	}
+	common.WriteJSONResponse(w, page, http.StatusOK)
+}
+
+// getServerPage gets the server version of a paged listing along with the cursor and limit of the page.
+// The error response is written when it returns false.
+func (r *Routes) getServerPage(
+	w http.ResponseWriter,
+	req *http.Request,
+) (server *upstreamv0.ServerJSON, cursor string, limit int, ok bool) {
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return nil, "", 0, false
+	}
+
+	version, err := common.GetAndValidateURLParam(req, "version")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return nil, "", 0, false
+	}
+
+	query := req.URL.Query()
+	limit = contextpack.DefaultPageSize
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > contextpack.MaxPageSize {
+			common.WriteErrorResponse(w, fmt.Sprintf("Invalid limit parameter: must be an integer between 1 and %d",
+				contextpack.MaxPageSize), http.StatusBadRequest)
+			return nil, "", 0, false
+		}
+	}
+
+	server, err = r.service.GetServerVersion(
+		req.Context(),
+		service.WithName[service.GetServerVersionOptions](serverName),
+		service.WithVersion[service.GetServerVersionOptions](version),
+	)
+	if errors.Is(err, service.ErrServerNotFound) || (err == nil && server == nil) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return nil, "", 0, false
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return nil, "", 0, false
+	}
+	return server, query.Get("cursor"), limit, true
+}
@@ -0,0 +1,249 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/contextpack"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+	"github.com/stacklok/toolhive-registry-server/internal/summary"
+)
+
+func TestGetContextPack(t *testing.T) {
+	t.Parallel()
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithToolHiveMetadata("tools", []any{"query"}),
+		registry.WithOCIPackage("ghcr.io/example/test-server:1.0.0"),
+	)
+
+	tests := []struct {
+		name            string
+		query           string
+		server          *upstreamv0.ServerJSON
+		err             error
+		wantStatus      int
+		wantContentType string
+	}{
+		{
+			name:            "json pack",
+			server:          &server,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+		},
+		{
+			name:            "markdown pack",
+			query:           "?format=markdown&max_tokens=100",
+			server:          &server,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/markdown; charset=utf-8",
+		},
+		{
+			name:       "unsupported format",
+			query:      "?format=yaml",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "budget too small",
+			query:      "?max_tokens=10",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid budget",
+			query:      "?max_tokens=many",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "server not found",
+			err:        service.ErrServerNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "service error",
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			if tt.server != nil || tt.err != nil {
+				mockSvc.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(tt.server, tt.err)
+			}
+			router := Router(mockSvc)
+
+			req, err := http.NewRequest(http.MethodGet,
+				"/servers/com.example%2Ftest-server/versions/1.0.0/context-pack"+tt.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			assert.Equal(t, tt.wantContentType, rr.Header().Get("Content-Type"))
+
+			if tt.wantContentType != "application/json" {
+				assert.Contains(t, rr.Body.String(), "# com.example/test-server 1.0.0\n")
+				assert.Contains(t, rr.Body.String(), "docker run -i --rm ghcr.io/example/test-server:1.0.0\n")
+				return
+			}
+			var pack contextpack.Pack
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &pack))
+			assert.Equal(t, "com.example/test-server", pack.Server)
+			assert.Equal(t, []string{"query"}, pack.Tools)
+			assert.Equal(t, []string{"docker run -i --rm ghcr.io/example/test-server:1.0.0"}, pack.Quickstart)
+			assert.LessOrEqual(t, pack.EstimatedTokens, contextpack.DefaultTokenBudget)
+		})
+	}
+}
+
+func TestGetContextPackSummary(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithDescription("Queries the example database. "+strings.Repeat("It supports many options. ", 20)),
+	)
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+	mockSvc.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+	router := Router(mockSvc, WithSummaries(summary.New(&config.SummariesConfig{})))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet,
+		"/servers/com.example%2Ftest-server/versions/1.0.0/context-pack?max_tokens=4000", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var pack contextpack.Pack
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &pack))
+	assert.Equal(t, "Queries the example database. It supports many options. It supports many options.", pack.Summary)
+}
+
+func TestGetServerTools(t *testing.T) {
+	t.Parallel()
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithToolHiveMetadata("tools", []any{"query", "insert", "delete"}),
+	)
+
+	tests := []struct {
+		name       string
+		query      string
+		server     *upstreamv0.ServerJSON
+		err        error
+		wantStatus int
+		wantTools  []string
+		wantNext   bool
+	}{
+		{
+			name:       "first page",
+			query:      "?limit=2",
+			server:     &server,
+			wantStatus: http.StatusOK,
+			wantTools:  []string{"query", "insert"},
+			wantNext:   true,
+		},
+		{
+			name:       "all tools",
+			server:     &server,
+			wantStatus: http.StatusOK,
+			wantTools:  []string{"query", "insert", "delete"},
+		},
+		{
+			name:       "invalid cursor",
+			query:      "?cursor=%21",
+			server:     &server,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid limit",
+			query:      "?limit=0",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "server not found",
+			err:        service.ErrServerNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			if tt.server != nil || tt.err != nil {
+				mockSvc.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(tt.server, tt.err)
+			}
+			router := Router(mockSvc)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet,
+				"/servers/com.example%2Ftest-server/versions/1.0.0/tools"+tt.query, nil))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var page contextpack.ToolPage
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+			assert.Equal(t, tt.wantTools, page.Tools)
+			assert.Equal(t, 3, page.Total)
+			assert.Equal(t, tt.wantNext, page.NextCursor != "")
+		})
+	}
+}
+
+func TestGetServerEnvironmentVariables(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithOCIPackage("ghcr.io/example/test-server:1.0.0"),
+		registry.WithEnvironmentVariables(
+			model.KeyValueInput{Name: "LOG_LEVEL"},
+			model.KeyValueInput{Name: "API_KEY", InputWithVariables: model.InputWithVariables{
+				Input: model.Input{IsRequired: true, IsSecret: true},
+			}},
+		),
+	)
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+	mockSvc.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+	router := Router(mockSvc)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet,
+		"/servers/com.example%2Ftest-server/versions/1.0.0/environment-variables?limit=1", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var page contextpack.EnvironmentVariablePage
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	assert.Equal(t, []contextpack.EnvironmentVariable{{Name: "API_KEY", Required: true, Secret: true}},
+		page.EnvironmentVariables, "required variables come first")
+	assert.Equal(t, 2, page.Total)
+	assert.NotEmpty(t, page.NextCursor)
+}
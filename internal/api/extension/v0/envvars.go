@@ -0,0 +1,93 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// maxEnvVarReportServers caps the number of servers compared in one environment variable report
+const maxEnvVarReportServers = 20
+
+// getEnvVarReport handles GET /extension/v0/reports/env-vars
+//
+// @Summary		Environment variable compatibility report
+// @Description	Aggregate the environment variables required by several servers, to configure them side by side.
+// @Description	Variables used by more than one server are reported as shared (e.g. a common API key), and as
+// @Description	conflicting when the servers disagree on their format, secrecy, or allowed values.
+// @Description	Default values resembling credentials are replaced with placeholders.
+// @Tags		extension
+// @Produce		json
+// @Param		server	query	[]string	true	"Server name with optional @version suffix (latest by default)"	collectionFormat(multi)
+// @Param		debug_timings	query	bool	false	"Add the timing breakdown of the report to its metadata (default false)"
+// @Success		200	{object}	registry.EnvVarReport	"Environment variable report"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/reports/env-vars [get]
+func (r *Routes) getEnvVarReport(w http.ResponseWriter, req *http.Request) {
+	refs := req.URL.Query()["server"]
+	if len(refs) == 0 {
+		common.WriteErrorResponse(w, "server parameter is required", http.StatusBadRequest)
+		return
+	}
+	if len(refs) > maxEnvVarReportServers {
+		common.WriteErrorResponse(w,
+			fmt.Sprintf("At most %d servers can be compared", maxEnvVarReportServers), http.StatusBadRequest)
+		return
+	}
+
+	servers := make([]*upstreamv0.ServerJSON, 0, len(refs))
+	for _, ref := range refs {
+		name, version := splitServerReference(ref)
+		if name == "" {
+			common.WriteErrorResponse(w, fmt.Sprintf("Invalid server reference %q", ref), http.StatusBadRequest)
+			return
+		}
+
+		server, err := r.getServerReference(req, name, version)
+		if errors.Is(err, service.ErrServerNotFound) {
+			common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", ref), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		servers = append(servers, redactSecrets(req, server))
+	}
+
+	common.WriteJSONResponseWithTimings(w, req, registry.EnvVarCompatibility(servers), http.StatusOK)
+}
+
+// getServerReference gets the given version of a server, or its latest version if version is empty
+func (r *Routes) getServerReference(req *http.Request, name, version string) (*upstreamv0.ServerJSON, error) {
+	if version != "" {
+		server, err := r.service.GetServerVersion(
+			req.Context(),
+			service.WithName[service.GetServerVersionOptions](name),
+			service.WithVersion[service.GetServerVersionOptions](version),
+		)
+		if err == nil && server == nil {
+			return nil, service.ErrServerNotFound
+		}
+		return server, err
+	}
+
+	return r.latestServerVersion(req, name)
+}
+
+// splitServerReference splits a "name@version" server reference; the version is empty if not given
+func splitServerReference(ref string) (name, version string) {
+	name, version, _ = strings.Cut(strings.TrimSpace(ref), "@")
+	return name, version
+}
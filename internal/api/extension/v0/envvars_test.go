@@ -0,0 +1,131 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestGetEnvVarReport(t *testing.T) {
+	t.Parallel()
+
+	token := model.KeyValueInput{Name: "API_TOKEN"}
+	token.IsSecret = true
+	first := registry.NewTestServer("com.example/first",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithOCIPackage("ghcr.io/example/first:1.0.0"),
+		registry.WithEnvironmentVariables(token),
+	)
+	second := registry.NewTestServer("com.example/second",
+		registry.WithServerVersion("2.0.0"),
+		registry.WithOCIPackage("ghcr.io/example/second:2.0.0"),
+		registry.WithEnvironmentVariables(token),
+	)
+	older := registry.NewTestServer("com.example/second", registry.WithServerVersion("1.0.0"))
+
+	tests := []struct {
+		name       string
+		path       string
+		setupMocks func(*testing.T, *mocks.MockRegistryService)
+		wantStatus int
+		wantShared []string
+	}{
+		{
+			name: "pinned and latest versions",
+			path: "/reports/env-vars?server=com.example/first@1.0.0&server=com.example/second",
+			setupMocks: func(t *testing.T, m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&first, nil)
+				// Versions are not listed in any particular order
+				expectServerVersions(t, m, "com.example/second", []*upstreamv0.ServerJSON{&second, &older}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantShared: []string{"API_TOKEN"},
+		},
+		{
+			name:       "missing server parameter",
+			path:       "/reports/env-vars",
+			setupMocks: func(_ *testing.T, _ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "empty server name",
+			path:       "/reports/env-vars?server=@1.0.0",
+			setupMocks: func(_ *testing.T, _ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown version",
+			path: "/reports/env-vars?server=com.example/first@9.9.9",
+			setupMocks: func(_ *testing.T, m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(nil, service.ErrServerNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "unknown server",
+			path: "/reports/env-vars?server=com.example/missing",
+			setupMocks: func(t *testing.T, m *mocks.MockRegistryService) {
+				expectServerVersions(t, m, "com.example/missing", nil, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "service error",
+			path: "/reports/env-vars?server=com.example/first",
+			setupMocks: func(t *testing.T, m *mocks.MockRegistryService) {
+				expectServerVersions(t, m, "com.example/first", nil, errors.New("boom"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(t, mockSvc)
+
+			rec := httptest.NewRecorder()
+			Router(mockSvc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var report registry.EnvVarReport
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+			assert.Equal(t, tt.wantShared, report.Shared)
+			require.Len(t, report.Variables, 1)
+			assert.Equal(t, []string{"com.example/first", "com.example/second"}, report.Variables[0].Servers)
+			assert.Equal(t, "2.0.0", report.Variables[0].Usages[1].Version)
+		})
+	}
+}
+
+func TestGetEnvVarReportTooManyServers(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	path := "/reports/env-vars?"
+	for range maxEnvVarReportServers + 1 {
+		path += "server=com.example/server&"
+	}
+
+	rec := httptest.NewRecorder()
+	Router(mocks.NewMockRegistryService(ctrl)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
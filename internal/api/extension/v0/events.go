@@ -0,0 +1,207 @@
+package v0
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/features"
+	"github.com/stacklok/toolhive-registry-server/internal/kubernetes"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// Sources of the recent events
+const (
+	// EventSourceCatalog are the changes of the catalog: publications, updates and lifecycle status changes
+	EventSourceCatalog = "catalog"
+	// EventSourceCluster are the lifecycle events of the servers installed in the cluster
+	EventSourceCluster = "cluster"
+)
+
+// Types of the catalog events
+const (
+	EventPublished = "published"
+	EventUpdated   = "updated"
+)
+
+const (
+	// defaultEventsWindow is how far back events are reported when no since is given
+	defaultEventsWindow = 24 * time.Hour
+	// defaultEventLimit is the number of events returned when no limit is given
+	defaultEventLimit = 100
+	// maxEventLimit caps the number of events a client can request
+	maxEventLimit = 1000
+	// eventCandidateLimit caps the number of servers considered for catalog events
+	eventCandidateLimit = 1000
+)
+
+// Event is a change of the catalog or a lifecycle event of a server installed in the cluster
+type Event struct {
+	Time time.Time `json:"time"`
+	// Source is EventSourceCatalog or EventSourceCluster
+	Source string `json:"source"`
+	// Type is EventPublished, EventUpdated or the new lifecycle status (e.g. "deprecated") for
+	// catalog events, and the type of the kubernetes.ActivityEvent for cluster events
+	Type string `json:"type"`
+	// Server and Version identify the server version
+	Server  string `json:"server"`
+	Version string `json:"version,omitempty"`
+	// Namespace and Name identify the MCPServer resource of cluster events
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Condition, Status, Reason and Message detail the condition transitions of cluster events
+	Condition string `json:"condition,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// EventsResponse lists the events that happened since a point in time, most recent first
+type EventsResponse struct {
+	Since  time.Time `json:"since"`
+	Events []Event   `json:"events"`
+}
+
+// getEvents handles GET /extension/v0/events
+//
+// @Summary		Get recent events
+// @Description	Get a single feed of the recent changes of the catalog (server versions published, updated,
+// @Description	deprecated or deleted) and, when cluster installation is enabled, of the lifecycle events of
+// @Description	the servers installed in the cluster (installed, uninstalling and status condition changes),
+// @Description	most recent first.
+// @Tags		extension
+// @Produce		json
+// @Param		since		query	string	false	"Only report events at or after this time (RFC3339, default 24 hours ago)"
+// @Param		source		query	string	false	"Only report events of this source: catalog or cluster"
+// @Param		server		query	string	false	"Only report the events of this server"
+// @Param		namespace	query	string	false	"Only report the cluster events of this namespace"
+// @Param		limit		query	int		false	"Maximum number of events to return (default 100, max 1000)"
+// @Success		200	{object}	EventsResponse	"Recent events"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Access denied by the cluster"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/events [get]
+func (r *Routes) getEvents(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	since := time.Now().Add(-defaultEventsWindow).UTC()
+	if sinceStr := query.Get("since"); sinceStr != "" {
+		parsed, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			common.WriteErrorResponse(w,
+				"Invalid since parameter: must be RFC3339 format (e.g., 2025-08-07T13:15:04.280Z)", http.StatusBadRequest)
+			return
+		}
+		since = parsed
+	}
+
+	source := query.Get("source")
+	if source != "" && source != EventSourceCatalog && source != EventSourceCluster {
+		common.WriteErrorResponse(w, fmt.Sprintf("Unsupported source %s", source), http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultEventLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limitVal, err := strconv.Atoi(limitStr)
+		if err != nil || limitVal <= 0 {
+			common.WriteErrorResponse(w, "Invalid limit parameter: must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = min(limitVal, maxEventLimit)
+	}
+
+	events := []Event{}
+	if source != EventSourceCluster {
+		servers, err := r.service.ListServers(
+			req.Context(),
+			service.WithUpdatedSince(since),
+			service.WithIncludeDeprecated(),
+			service.WithIncludeDeleted(),
+			service.WithLimit[service.ListServersOptions](eventCandidateLimit),
+		)
+		if err != nil {
+			common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		events = append(events, catalogEvents(servers, since)...)
+	}
+
+	// Cluster events are only reported in integrated mode
+	if source != EventSourceCatalog && r.installer != nil && r.features.Enabled(features.ClusterInstall) {
+		activity, err := r.installer.Activity(req.Context(), query.Get("namespace"), since)
+		if err != nil {
+			writeInstallError(w, err)
+			return
+		}
+		events = append(events, clusterEvents(activity)...)
+	}
+
+	if server := query.Get("server"); server != "" {
+		events = slices.DeleteFunc(events, func(event Event) bool { return event.Server != server })
+	}
+	slices.SortStableFunc(events, func(a, b Event) int {
+		return cmp.Or(b.Time.Compare(a.Time), cmp.Compare(a.Source, b.Source), cmp.Compare(a.Server, b.Server))
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	common.WriteJSONResponse(w, EventsResponse{Since: since, Events: events}, http.StatusOK)
+}
+
+// catalogEvents returns the publications and updates of server versions at or after since.
+// Updates changing the lifecycle status of a version are reported with the new status as type.
+func catalogEvents(servers []*upstreamv0.ServerJSON, since time.Time) []Event {
+	var events []Event
+	for _, server := range servers {
+		facts := registry.ExtractFacts(server)
+		event := Event{Source: EventSourceCatalog, Server: server.Name, Version: server.Version}
+
+		if facts.HasPublishedAt && !facts.PublishedAt.Before(since) {
+			published := event
+			published.Time, published.Type = facts.PublishedAt, EventPublished
+			events = append(events, published)
+		}
+		if facts.HasUpdatedAt && !facts.UpdatedAt.Before(since) &&
+			(!facts.HasPublishedAt || facts.UpdatedAt.After(facts.PublishedAt)) {
+			updated := event
+			updated.Time, updated.Type = facts.UpdatedAt, EventUpdated
+			if facts.LifecycleStatus != registry.StatusActive {
+				updated.Type = facts.LifecycleStatus
+			}
+			events = append(events, updated)
+		}
+	}
+	return events
+}
+
+// clusterEvents converts the lifecycle events of installed servers to events
+func clusterEvents(activity []kubernetes.ActivityEvent) []Event {
+	events := make([]Event, 0, len(activity))
+	for _, a := range activity {
+		events = append(events, Event{
+			Time:      a.Time,
+			Source:    EventSourceCluster,
+			Type:      a.Type,
+			Server:    a.Server,
+			Version:   a.Version,
+			Namespace: a.Namespace,
+			Name:      a.Name,
+			Condition: a.Condition,
+			Status:    a.Status,
+			Reason:    a.Reason,
+			Message:   a.Message,
+		})
+	}
+	return events
+}
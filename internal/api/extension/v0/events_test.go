@@ -0,0 +1,139 @@
+package v0
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/stacklok/toolhive-registry-server/internal/features"
+	"github.com/stacklok/toolhive-registry-server/internal/kubernetes"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestGetEvents(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return since.Add(time.Duration(hours) * time.Hour) }
+
+	published := registry.NewTestServer("com.example/weather",
+		registry.WithServerVersion("2.0.0"), registry.WithTimestamps(at(1), at(1)))
+	deprecated := registry.NewTestServer("com.example/fetch",
+		registry.WithServerVersion("1.0.0"), registry.WithTimestamps(at(-48), at(4)),
+		registry.WithLifecycleStatus(registry.StatusDeprecated))
+	catalog := []*upstreamv0.ServerJSON{&published, &deprecated}
+
+	installed := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "mcp",
+			Name:              "weather",
+			CreationTimestamp: metav1.NewTime(at(2)),
+			Labels:            map[string]string{kubernetes.InstalledByLabel: kubernetes.InstalledByValue},
+			Annotations:       map[string]string{"toolhive.stacklok.dev/registry-server": "com.example/weather"},
+		},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, mcpv1alpha1.AddToScheme(scheme))
+	installer := kubernetes.NewInstaller(fake.NewClientBuilder().WithScheme(scheme).WithObjects(installed).Build())
+
+	enabled, err := features.New(map[string]bool{features.ClusterInstall: true},
+		features.WithLookupEnv(func(string) (string, bool) { return "", false }))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		query       string
+		flags       *features.Flags
+		wantCatalog bool
+		wantStatus  int
+		wantEvents  []string
+	}{
+		{
+			name:        "catalog and cluster events",
+			query:       "?since=2026-10-15T00:00:00Z",
+			flags:       enabled,
+			wantCatalog: true,
+			wantStatus:  http.StatusOK,
+			wantEvents: []string{
+				"catalog/deprecated/com.example/fetch",
+				"cluster/installed/com.example/weather",
+				"catalog/published/com.example/weather",
+			},
+		},
+		{
+			name:        "cluster events need cluster installation",
+			query:       "?since=2026-10-15T00:00:00Z",
+			wantCatalog: true,
+			wantStatus:  http.StatusOK,
+			wantEvents: []string{
+				"catalog/deprecated/com.example/fetch",
+				"catalog/published/com.example/weather",
+			},
+		},
+		{
+			name:       "cluster source",
+			query:      "?since=2026-10-15T00:00:00Z&source=cluster",
+			flags:      enabled,
+			wantStatus: http.StatusOK,
+			wantEvents: []string{"cluster/installed/com.example/weather"},
+		},
+		{
+			name:        "server filter and limit",
+			query:       "?since=2026-10-15T00:00:00Z&server=com.example/weather&limit=1",
+			flags:       enabled,
+			wantCatalog: true,
+			wantStatus:  http.StatusOK,
+			wantEvents:  []string{"cluster/installed/com.example/weather"},
+		},
+		{
+			name:       "invalid since",
+			query:      "?since=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid source",
+			query:      "?source=audit",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			if tt.wantCatalog {
+				mockSvc.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(catalog, nil)
+			}
+			router := Router(mockSvc, WithFeatures(tt.flags), WithInstaller(installer))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/events"+tt.query, nil))
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response EventsResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.True(t, since.Equal(response.Since))
+			events := make([]string, 0, len(response.Events))
+			for _, event := range response.Events {
+				events = append(events, event.Source+"/"+event.Type+"/"+event.Server)
+			}
+			assert.Equal(t, tt.wantEvents, events)
+		})
+	}
+}
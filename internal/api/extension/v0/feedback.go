@@ -0,0 +1,89 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/feedback"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// maxFeedbackRequestSize caps the size of feedback request bodies
+const maxFeedbackRequestSize = 4 * 1024
+
+// FeedbackRequestBody reports whether the user accepted or rejected a recommended server
+type FeedbackRequestBody struct {
+	// Accepted is true when the user accepted the server, false when they rejected it
+	Accepted *bool `json:"accepted"`
+	// Query is the search the server was recommended for, if any
+	Query string `json:"query,omitempty"`
+}
+
+// reportResultFeedback handles POST /extension/v0/servers/{serverName}/feedback
+//
+// @Summary		Report search result feedback
+// @Description	Report that the user accepted or rejected a server recommended by a search. Accepted servers rank
+// @Description	higher in later searches of this deployment and rejected servers lower, within the configured cap.
+// @Description	Feedback is kept in memory since the server started.
+// @Tags		extension
+// @Accept		json
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		feedback	body	FeedbackRequestBody	true	"Whether the server was accepted"
+// @Success		200	{object}	feedback.ServerFeedback	"Feedback recorded for the server"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/feedback [post]
+func (r *Routes) reportResultFeedback(w http.ResponseWriter, req *http.Request) {
+	if r.feedbackStore == nil {
+		common.WriteErrorResponse(w, "Search feedback is not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var body FeedbackRequestBody
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxFeedbackRequestSize)).Decode(&body); err != nil {
+		common.WriteErrorResponse(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if body.Accepted == nil {
+		common.WriteErrorResponse(w, "accepted is required", http.StatusBadRequest)
+		return
+	}
+
+	// Only feedback on servers of the catalog is recorded
+	server, err := r.latestServerVersion(req, serverName)
+	if errors.Is(err, service.ErrServerNotFound) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	recorded := r.feedbackStore.Record(server.Name, *body.Accepted)
+	slog.InfoContext(req.Context(), "Search result feedback",
+		"server", server.Name, "accepted", *body.Accepted, "query", body.Query, "adjustment", recorded.Adjustment)
+
+	common.WriteJSONResponse(w, recorded, http.StatusOK)
+}
+
+// SearchFeedbackResponse lists the feedback recorded for servers
+type SearchFeedbackResponse struct {
+	Servers []feedback.ServerFeedback `json:"servers"`
+}
@@ -0,0 +1,200 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/feedback"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestReportResultFeedback(t *testing.T) {
+	t.Parallel()
+
+	older := registry.NewTestServer("com.example/test-server", registry.WithServerVersion("1.0.0"))
+	latest := registry.NewTestServer("com.example/test-server", registry.WithServerVersion("1.1.0"))
+	versions := []*upstreamv0.ServerJSON{&latest, &older}
+
+	tests := []struct {
+		name           string
+		store          bool
+		body           string
+		versions       []*upstreamv0.ServerJSON
+		err            error
+		wantStatus     int
+		wantAdjustment int
+	}{
+		{
+			name:           "accepted",
+			store:          true,
+			body:           `{"accepted":true,"query":"slack"}`,
+			versions:       versions,
+			wantStatus:     http.StatusOK,
+			wantAdjustment: 1,
+		},
+		{
+			name:           "rejected",
+			store:          true,
+			body:           `{"accepted":false}`,
+			versions:       versions,
+			wantStatus:     http.StatusOK,
+			wantAdjustment: -1,
+		},
+		{
+			name:       "missing accepted",
+			store:      true,
+			body:       `{"query":"slack"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid body",
+			store:      true,
+			body:       `{"accepted":"yes"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "server not found",
+			store:      true,
+			body:       `{"accepted":true}`,
+			versions:   []*upstreamv0.ServerJSON{},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "service error",
+			store:      true,
+			body:       `{"accepted":true}`,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "feedback not enabled",
+			body:       `{"accepted":true}`,
+			wantStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			if tt.versions != nil || tt.err != nil {
+				expectServerVersions(t, mockSvc, "com.example/test-server", tt.versions, tt.err)
+			}
+			var opts []RouterOption
+			store := feedback.NewStore(&config.SearchFeedbackConfig{})
+			if tt.store {
+				opts = append(opts, WithFeedbackStore(store))
+			}
+			router := Router(mockSvc, opts...)
+
+			req, err := http.NewRequest(http.MethodPost, "/servers/com.example%2Ftest-server/feedback", strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				assert.Empty(t, store.Top(0))
+				return
+			}
+
+			var response feedback.ServerFeedback
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, "com.example/test-server", response.Server)
+			assert.Equal(t, tt.wantAdjustment, response.Adjustment)
+			assert.Equal(t, tt.wantAdjustment, store.Adjustment("com.example/test-server"))
+		})
+	}
+}
+
+func TestGetSearchFeedbackReport(t *testing.T) {
+	t.Parallel()
+
+	newStore := func() *feedback.Store {
+		store := feedback.NewStore(&config.SearchFeedbackConfig{})
+		store.Record("com.example/liked", true)
+		store.Record("com.example/liked", true)
+		store.Record("com.example/disliked", false)
+		return store
+	}
+
+	tests := []struct {
+		name        string
+		store       *feedback.Store
+		query       string
+		wantStatus  int
+		wantServers []string
+	}{
+		{
+			name:        "report lists servers",
+			store:       newStore(),
+			wantStatus:  http.StatusOK,
+			wantServers: []string{"com.example/liked", "com.example/disliked"},
+		},
+		{
+			name:        "limit",
+			store:       newStore(),
+			query:       "?limit=1",
+			wantStatus:  http.StatusOK,
+			wantServers: []string{"com.example/liked"},
+		},
+		{
+			name:       "invalid limit",
+			store:      newStore(),
+			query:      "?limit=0",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "feedback not enabled",
+			wantStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			var opts []RouterOption
+			if tt.store != nil {
+				opts = append(opts, WithFeedbackStore(tt.store))
+			}
+			router := Router(mocks.NewMockRegistryService(ctrl), opts...)
+
+			req, err := http.NewRequest(http.MethodGet, "/reports/search-feedback"+tt.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response SearchFeedbackResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			servers := make([]string, 0, len(response.Servers))
+			for _, entry := range response.Servers {
+				servers = append(servers, entry.Server)
+			}
+			assert.Equal(t, tt.wantServers, servers)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// InjectionRiskResponse is the prompt-injection risk assessment of a server version
+type InjectionRiskResponse struct {
+	Server  string `json:"server"`
+	Version string `json:"version"`
+	registry.InjectionRisk
+}
+
+// getInjectionRisk handles GET /extension/v0/servers/{serverName}/versions/{version}/injection-risk
+//
+// @Summary		Get prompt-injection risk
+// @Description	Score the title and description of a server version for patterns commonly used to inject
+// @Description	instructions into agent context, from 0 (none found) to 100, along with the signals found.
+// @Tags		extension
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		path	string	true	"URL-encoded version (e.g., \"1.0.0\" or \"latest\")"
+// @Success		200	{object}	InjectionRiskResponse	"Prompt-injection risk"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/versions/{version}/injection-risk [get]
+func (r *Routes) getInjectionRisk(w http.ResponseWriter, req *http.Request) {
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := common.GetAndValidateURLParam(req, "version")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	server, err := r.service.GetServerVersion(
+		req.Context(),
+		service.WithName[service.GetServerVersionOptions](serverName),
+		service.WithVersion[service.GetServerVersionOptions](version),
+	)
+	if errors.Is(err, service.ErrServerNotFound) || (err == nil && server == nil) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.WriteJSONResponse(w, InjectionRiskResponse{
+		Server:        server.Name,
+		Version:       server.Version,
+		InjectionRisk: registry.InjectionRiskOf(server),
+	}, http.StatusOK)
+}
@@ -0,0 +1,146 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/experiment"
+	"github.com/stacklok/toolhive-registry-server/internal/kubernetes"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// maxInstallRequestSize caps the size of install request bodies
+const maxInstallRequestSize = 64 * 1024
+
+// InstallRequestBody holds the parameters of an installation, reviewed by the user before
+// the installation is confirmed
+type InstallRequestBody struct {
+	kubernetes.InstallRequest
+	// Confirm creates the MCPServer resource. Without it, the resource that would be
+	// created is returned for review and nothing is installed.
+	Confirm bool `json:"confirm,omitempty"`
+}
+
+// InstallResponse is the outcome of an install request: the resource to create when the
+// installation is not confirmed, the status of the created resource otherwise
+type InstallResponse struct {
+	Confirmed bool                      `json:"confirmed"`
+	Manifest  *mcpv1alpha1.MCPServer    `json:"manifest,omitempty"`
+	Status    *kubernetes.InstallStatus `json:"status,omitempty"`
+}
+
+// installServer handles POST /extension/v0/servers/{serverName}/versions/{version}/install
+//
+// @Summary		Install server in the cluster
+// @Description	Install a server version in the Kubernetes cluster by creating the MCPServer resource running its
+// @Description	OCI package, which the ToolHive operator then deploys. Unless confirm is set, the resource is only
+// @Description	returned for review. Secret environment variables must be given as Kubernetes secret references.
+// @Description	When an approval system is configured, servers it has not approved are answered with 403.
+// @Description	Restricted to the administrators of the API.
+// @Tags		extension
+// @Accept		json
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		path	string	true	"URL-encoded version (e.g., \"1.0.0\" or \"latest\")"
+// @Param		request		body	InstallRequestBody	true	"Installation parameters"
+// @Success		200	{object}	InstallResponse	"Resource to create, for review"
+// @Success		201	{object}	InstallResponse	"Resource created"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	ApprovalRequiredResponse	"Server requires approval, caller not an administrator, or access denied"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		409	{object}	map[string]string	"Server already installed"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Failure		501	{object}	map[string]string	"Cluster installation not configured"
+// @Failure		503	{object}	map[string]string	"Approval check failed"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/versions/{version}/install [post]
+func (r *Routes) installServer(w http.ResponseWriter, req *http.Request) {
+	if r.installer == nil {
+		common.WriteErrorResponse(w, "Cluster installation is not configured", http.StatusNotImplemented)
+		return
+	}
+
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := common.GetAndValidateURLParam(req, "version")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var body InstallRequestBody
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxInstallRequestSize)).Decode(&body); err != nil {
+		common.WriteErrorResponse(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	server, err := r.service.GetServerVersion(
+		req.Context(),
+		service.WithName[service.GetServerVersionOptions](serverName),
+		service.WithVersion[service.GetServerVersionOptions](version),
+	)
+	if errors.Is(err, service.ErrServerNotFound) || (err == nil && server == nil) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !r.checkApproval(w, req, server, EndpointInstall) {
+		return
+	}
+
+	if !body.Confirm {
+		manifest, err := r.installer.Manifest(server, body.InstallRequest)
+		if err != nil {
+			writeInstallError(w, err)
+			return
+		}
+		common.WriteJSONResponse(w, InstallResponse{Manifest: manifest}, http.StatusOK)
+		return
+	}
+
+	status, err := r.installer.Install(req.Context(), server, body.InstallRequest)
+	if err != nil {
+		slog.ErrorContext(req.Context(), "Failed to install server",
+			"server", server.Name, "version", server.Version, "namespace", body.Namespace, "error", err)
+		writeInstallError(w, err)
+		return
+	}
+	slog.InfoContext(req.Context(), "Server installed",
+		"server", server.Name, "version", server.Version, "namespace", status.Namespace, "name", status.Name)
+	experiment.RecordOutcome(req.Context(), experiment.OutcomeInstall, server.Name)
+
+	common.WriteJSONResponse(w, InstallResponse{Confirmed: true, Status: status}, http.StatusCreated)
+}
+
+// writeInstallError writes the response of a failed installer operation
+func writeInstallError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, kubernetes.ErrNotInstallable), errors.Is(err, kubernetes.ErrInvalidInstallRequest):
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, kubernetes.ErrAlreadyInstalled):
+		common.WriteErrorResponse(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, kubernetes.ErrNotInstalled):
+		common.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, kubernetes.ErrForbidden), errors.Is(err, kubernetes.ErrNamespaceNotAllowed),
+		errors.Is(err, kubernetes.ErrNoIdentity):
+		common.WriteErrorResponse(w, err.Error(), http.StatusForbidden)
+	default:
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+	}
+}
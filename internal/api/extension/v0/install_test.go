@@ -0,0 +1,162 @@
+package v0
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/stacklok/toolhive-registry-server/internal/features"
+	"github.com/stacklok/toolhive-registry-server/internal/kubernetes"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestInstallServer(t *testing.T) {
+	t.Parallel()
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithOCIPackage("ghcr.io/example/test-server:1.0.0"),
+	)
+	httpOnly := registry.NewTestServer("com.example/http-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithHTTPPackage("https://example.com/http-server"),
+	)
+
+	flags, err := features.New(map[string]bool{features.ClusterInstall: true},
+		features.WithLookupEnv(func(string) (string, bool) { return "", false }))
+	require.NoError(t, err)
+
+	// A single cluster shared by the cases, each installing under its own namespace
+	scheme := runtime.NewScheme()
+	require.NoError(t, mcpv1alpha1.AddToScheme(scheme))
+	existing := &mcpv1alpha1.MCPServer{}
+	existing.Namespace, existing.Name = "taken", "test-server"
+	installer := kubernetes.NewInstaller(fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build())
+
+	tests := []struct {
+		name          string
+		body          string
+		noInstaller   bool
+		notAdmin      bool
+		setupMocks    func(*mocks.MockRegistryService)
+		wantStatus    int
+		wantConfirmed bool
+	}{
+		{
+			name: "preview without confirmation",
+			body: `{"namespace":"preview"}`,
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "confirmed installation",
+			body: `{"namespace":"mcp","confirm":true}`,
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus:    http.StatusCreated,
+			wantConfirmed: true,
+		},
+		{
+			name: "already installed",
+			body: `{"namespace":"taken","confirm":true}`,
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "no OCI package",
+			body: `{"namespace":"mcp","confirm":true}`,
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&httpOnly, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid body",
+			body:       `{"namespace":`,
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "server not found",
+			body: `{"namespace":"mcp"}`,
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(nil, service.ErrServerNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "caller is not an administrator",
+			body:       `{"namespace":"mcp","confirm":true}`,
+			notAdmin:   true,
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "installer not configured",
+			body:        `{"namespace":"mcp"}`,
+			noInstaller: true,
+			setupMocks:  func(_ *mocks.MockRegistryService) {},
+			wantStatus:  http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+
+			opts := []RouterOption{WithFeatures(flags), WithAdmins(newTestAdmins())}
+			if !tt.noInstaller {
+				opts = append(opts, WithInstaller(installer))
+			}
+			router := Router(mockSvc, opts...)
+
+			req := httptest.NewRequest(http.MethodPost, "/servers/com.example%2Ftest-server/versions/1.0.0/install",
+				strings.NewReader(tt.body))
+			if tt.notAdmin {
+				req = withCaller(req, "developers")
+			} else {
+				req = withCaller(req, testAdminGroup)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			if rr.Code != http.StatusOK && rr.Code != http.StatusCreated {
+				return
+			}
+
+			var response InstallResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantConfirmed, response.Confirmed)
+			if tt.wantConfirmed {
+				require.NotNil(t, response.Status)
+				assert.Equal(t, "test-server", response.Status.Name)
+				assert.Equal(t, mcpv1alpha1.MCPServerPhasePending, response.Status.Phase)
+				assert.Nil(t, response.Manifest)
+			} else {
+				require.NotNil(t, response.Manifest)
+				assert.Equal(t, "ghcr.io/example/test-server:1.0.0", response.Manifest.Spec.Image)
+				assert.Nil(t, response.Status)
+			}
+		})
+	}
+}
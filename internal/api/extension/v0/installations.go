@@ -0,0 +1,55 @@
+package v0
+
+import (
+	"net/http"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// installationCandidateLimit caps the number of servers cross-referenced for installations
+const installationCandidateLimit = 1000
+
+// InstallationsResponse lists the catalog servers running in the cluster
+type InstallationsResponse struct {
+	Servers []registry.InstalledServer `json:"servers"`
+}
+
+// getInstallations handles GET /extension/v0/installations
+//
+// @Summary		List installed servers
+// @Description	List the catalog servers already running in the cluster, with the namespace, name and image version
+// @Description	of the MCPServer resources running them. Resources are read from the Kubernetes registries and
+// @Description	match a catalog server when their image is one of its OCI packages, regardless of version.
+// @Tags		extension
+// @Produce		json
+// @Param		server	query	string	false	"Only report the installations of this server"
+// @Success		200	{object}	InstallationsResponse	"Installed servers"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/installations [get]
+func (r *Routes) getInstallations(w http.ResponseWriter, req *http.Request) {
+	servers, err := r.service.ListServers(
+		req.Context(),
+		service.WithLimit[service.ListServersOptions](installationCandidateLimit),
+	)
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	installed := registry.MatchInstallations(servers)
+	if name := req.URL.Query().Get("server"); name != "" {
+		filtered := make([]registry.InstalledServer, 0, 1)
+		for _, server := range installed {
+			if server.Name == name {
+				filtered = append(filtered, server)
+			}
+		}
+		installed = filtered
+	}
+
+	common.WriteJSONResponse(w, InstallationsResponse{Servers: installed}, http.StatusOK)
+}
@@ -0,0 +1,108 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestGetInstallations(t *testing.T) {
+	t.Parallel()
+
+	fetch := registry.NewTestServer("io.example/fetch", registry.WithOCIPackage("ghcr.io/example/fetch:1.0.0"))
+	notes := registry.NewTestServer("io.example/notes", registry.WithOCIPackage("ghcr.io/example/notes:1.0.0"))
+	deployed := registry.NewTestServer("com.toolhive.k8s.team-a/fetch",
+		registry.WithOCIPackage("ghcr.io/example/fetch:0.9.0"),
+		registry.WithMetadata("kubernetes_namespace", "team-a"),
+		registry.WithMetadata("kubernetes_name", "fetch"),
+		registry.WithMetadata("kubernetes_image", "ghcr.io/example/fetch:0.9.0"),
+	)
+	servers := []*upstreamv0.ServerJSON{&fetch, &notes, &deployed}
+	fetchInstalled := registry.InstalledServer{
+		Name: "io.example/fetch",
+		Installations: []registry.Installation{
+			{Namespace: "team-a", Name: "fetch", Image: "ghcr.io/example/fetch:0.9.0", Version: "0.9.0"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		setupMocks  func(*mocks.MockRegistryService)
+		wantStatus  int
+		wantServers []registry.InstalledServer
+	}{
+		{
+			name: "all installed servers",
+			path: "/installations",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(servers, nil)
+			},
+			wantStatus:  http.StatusOK,
+			wantServers: []registry.InstalledServer{fetchInstalled},
+		},
+		{
+			name: "installations of a server",
+			path: "/installations?server=io.example%2Ffetch",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(servers, nil)
+			},
+			wantStatus:  http.StatusOK,
+			wantServers: []registry.InstalledServer{fetchInstalled},
+		},
+		{
+			name: "server not installed",
+			path: "/installations?server=io.example%2Fnotes",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(servers, nil)
+			},
+			wantStatus:  http.StatusOK,
+			wantServers: []registry.InstalledServer{},
+		},
+		{
+			name: "service error",
+			path: "/installations",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			router := Router(mockSvc)
+
+			req, err := http.NewRequest(http.MethodGet, tt.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var response InstallationsResponse
+				err = json.Unmarshal(rr.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantServers, response.Servers)
+			}
+		})
+	}
+}
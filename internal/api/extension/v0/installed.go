@@ -0,0 +1,121 @@
+package v0
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/kubernetes"
+)
+
+// InstalledServersResponse lists the MCPServer resources installed from the registry
+type InstalledServersResponse struct {
+	Servers []kubernetes.InstallStatus `json:"servers"`
+}
+
+// UninstallResponse is the outcome of an uninstall request: the resource that would be deleted
+// when the uninstallation is not confirmed, its last status once deleted otherwise
+type UninstallResponse struct {
+	Confirmed bool                      `json:"confirmed"`
+	Status    *kubernetes.InstallStatus `json:"status"`
+}
+
+// listInstalledServers handles GET /extension/v0/installed-servers
+//
+// @Summary		List servers installed in the cluster
+// @Description	List the MCPServer resources installed from the registry with the install endpoint, with their
+// @Description	status as reported by the ToolHive operator. Resources created by other means are not listed.
+// @Tags		extension
+// @Produce		json
+// @Param		namespace	query	string	false	"Only list the resources of this namespace"
+// @Success		200	{object}	InstalledServersResponse	"Installed servers"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Access denied by the cluster"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Failure		501	{object}	map[string]string	"Cluster installation not configured"
+// @Security	BearerAuth
+// @Router		/extension/v0/installed-servers [get]
+func (r *Routes) listInstalledServers(w http.ResponseWriter, req *http.Request) {
+	if r.installer == nil {
+		common.WriteErrorResponse(w, "Cluster installation is not configured", http.StatusNotImplemented)
+		return
+	}
+
+	servers, err := r.installer.List(req.Context(), req.URL.Query().Get("namespace"))
+	if err != nil {
+		writeInstallError(w, err)
+		return
+	}
+
+	common.WriteJSONResponse(w, InstalledServersResponse{Servers: servers}, http.StatusOK)
+}
+
+// uninstallServer handles DELETE /extension/v0/installed-servers/{namespace}/{name}
+//
+// @Summary		Uninstall server from the cluster
+// @Description	Delete an MCPServer resource installed from the registry, which the ToolHive operator then tears
+// @Description	down. Unless confirm is set, the resource is only returned for review. Resources created by other
+// @Description	means are never deleted and are answered with 404. Restricted to the administrators of the API.
+// @Tags		extension
+// @Produce		json
+// @Param		namespace	path	string	true	"Namespace of the MCPServer resource"
+// @Param		name		path	string	true	"Name of the MCPServer resource"
+// @Param		confirm		query	bool	false	"Delete the resource"
+// @Success		200	{object}	UninstallResponse	"Resource to delete, or deleted"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Caller is not an administrator, or access denied by the cluster"
+// @Failure		404	{object}	map[string]string	"Server not installed"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Failure		501	{object}	map[string]string	"Cluster installation not configured"
+// @Security	BearerAuth
+// @Router		/extension/v0/installed-servers/{namespace}/{name} [delete]
+func (r *Routes) uninstallServer(w http.ResponseWriter, req *http.Request) {
+	if r.installer == nil {
+		common.WriteErrorResponse(w, "Cluster installation is not configured", http.StatusNotImplemented)
+		return
+	}
+
+	namespace, err := common.GetAndValidateURLParam(req, "namespace")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	name, err := common.GetAndValidateURLParam(req, "name")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	confirm := false
+	if value := req.URL.Query().Get("confirm"); value != "" {
+		confirm, err = strconv.ParseBool(value)
+		if err != nil {
+			common.WriteErrorResponse(w, "Invalid confirm parameter: "+value, http.StatusBadRequest)
+			return
+		}
+	}
+
+	if !confirm {
+		status, err := r.installer.Get(req.Context(), namespace, name)
+		if err != nil {
+			writeInstallError(w, err)
+			return
+		}
+		common.WriteJSONResponse(w, UninstallResponse{Status: status}, http.StatusOK)
+		return
+	}
+
+	status, err := r.installer.Uninstall(req.Context(), namespace, name)
+	if err != nil {
+		slog.ErrorContext(req.Context(), "Failed to uninstall server", "namespace", namespace, "name", name, "error", err)
+		writeInstallError(w, err)
+		return
+	}
+	slog.InfoContext(req.Context(), "Server uninstalled",
+		"server", status.Server, "version", status.Version, "namespace", namespace, "name", name)
+
+	common.WriteJSONResponse(w, UninstallResponse{Confirmed: true, Status: status}, http.StatusOK)
+}
@@ -0,0 +1,216 @@
+package v0
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/stacklok/toolhive-registry-server/internal/features"
+	"github.com/stacklok/toolhive-registry-server/internal/kubernetes"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+// newClusterInstallRouter returns a router managing the given MCPServer resources
+func newClusterInstallRouter(
+	t *testing.T,
+	opts []kubernetes.InstallerOption,
+	objects ...*mcpv1alpha1.MCPServer,
+) http.Handler {
+	t.Helper()
+
+	flags, err := features.New(map[string]bool{features.ClusterInstall: true},
+		features.WithLookupEnv(func(string) (string, bool) { return "", false }))
+	require.NoError(t, err)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, mcpv1alpha1.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, object := range objects {
+		builder = builder.WithObjects(object)
+	}
+
+	// No expectations: the cluster endpoints don't query the catalog
+	svc := mocks.NewMockRegistryService(gomock.NewController(t))
+	return Router(svc, WithFeatures(flags), WithAdmins(newTestAdmins()),
+		WithInstaller(kubernetes.NewInstaller(builder.Build(), opts...)))
+}
+
+// newInstalledResource returns an MCPServer resource as created by the install endpoint
+func newInstalledResource(namespace, name string) *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{kubernetes.InstalledByLabel: kubernetes.InstalledByValue},
+		},
+		Spec: mcpv1alpha1.MCPServerSpec{Image: "ghcr.io/example/" + name + ":1.0.0"},
+	}
+}
+
+func TestListInstalledServers(t *testing.T) {
+	t.Parallel()
+
+	router := newClusterInstallRouter(t, nil,
+		newInstalledResource("mcp", "weather"),
+		newInstalledResource("tools", "github"),
+		&mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Namespace: "mcp", Name: "hand-made"}},
+	)
+
+	tests := []struct {
+		name      string
+		path      string
+		wantNames []string
+	}{
+		{name: "all namespaces", path: "/installed-servers", wantNames: []string{"weather", "github"}},
+		{name: "one namespace", path: "/installed-servers?namespace=tools", wantNames: []string{"github"}},
+		{name: "empty namespace", path: "/installed-servers?namespace=empty", wantNames: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+			var response InstalledServersResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			names := make([]string, 0, len(response.Servers))
+			for _, server := range response.Servers {
+				names = append(names, server.Name)
+			}
+			assert.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestUninstallServer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		path          string
+		wantStatus    int
+		wantConfirmed bool
+		wantRemaining int
+	}{
+		{
+			name:          "preview without confirmation",
+			path:          "/installed-servers/mcp/weather",
+			wantStatus:    http.StatusOK,
+			wantRemaining: 1,
+		},
+		{
+			name:          "confirmed uninstallation",
+			path:          "/installed-servers/mcp/weather?confirm=true",
+			wantStatus:    http.StatusOK,
+			wantConfirmed: true,
+			wantRemaining: 0,
+		},
+		{
+			name:          "not installed from the registry",
+			path:          "/installed-servers/mcp/hand-made?confirm=true",
+			wantStatus:    http.StatusNotFound,
+			wantRemaining: 1,
+		},
+		{
+			name:          "invalid confirmation",
+			path:          "/installed-servers/mcp/weather?confirm=maybe",
+			wantStatus:    http.StatusBadRequest,
+			wantRemaining: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			router := newClusterInstallRouter(t, nil,
+				newInstalledResource("mcp", "weather"),
+				&mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Namespace: "mcp", Name: "hand-made"}},
+			)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, withCaller(httptest.NewRequest(http.MethodDelete, tt.path, nil), testAdminGroup))
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+
+			if rr.Code == http.StatusOK {
+				var response UninstallResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tt.wantConfirmed, response.Confirmed)
+				require.NotNil(t, response.Status)
+				assert.Equal(t, "weather", response.Status.Name)
+			}
+
+			list := httptest.NewRecorder()
+			router.ServeHTTP(list, httptest.NewRequest(http.MethodGet, "/installed-servers", nil))
+			var installed InstalledServersResponse
+			require.NoError(t, json.Unmarshal(list.Body.Bytes(), &installed))
+			assert.Len(t, installed.Servers, tt.wantRemaining)
+		})
+	}
+}
+
+func TestClusterAccessDenied(t *testing.T) {
+	t.Parallel()
+
+	router := newClusterInstallRouter(t,
+		[]kubernetes.InstallerOption{kubernetes.WithAllowedNamespaces("mcp")},
+		newInstalledResource("mcp", "weather"),
+		newInstalledResource("tools", "github"),
+	)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{
+			name:       "list allowed namespace",
+			method:     http.MethodGet,
+			path:       "/installed-servers?namespace=mcp",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "list other namespace",
+			method:     http.MethodGet,
+			path:       "/installed-servers?namespace=tools",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "uninstall other namespace",
+			method:     http.MethodDelete,
+			path:       "/installed-servers/tools/github?confirm=true",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, withCaller(httptest.NewRequest(tt.method, tt.path, nil), testAdminGroup))
+			assert.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			if tt.wantStatus == http.StatusForbidden {
+				assert.Contains(t, rr.Body.String(), "namespace not allowed")
+			}
+		})
+	}
+
+	// Listing all namespaces only reports the allowed ones
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/installed-servers", nil))
+	var response InstalledServersResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Len(t, response.Servers, 1)
+	assert.Equal(t, "weather", response.Servers[0].Name)
+}
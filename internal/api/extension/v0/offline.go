@@ -0,0 +1,110 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/experiment"
+	"github.com/stacklok/toolhive-registry-server/internal/offline"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+const (
+	// OfflineBundleFormatJSON renders the offline bundle as a JSON manifest
+	OfflineBundleFormatJSON = "json"
+	// OfflineBundleFormatScript renders the offline bundle as a shell script
+	OfflineBundleFormatScript = "script"
+)
+
+// getOfflineBundle handles GET /extension/v0/servers/{serverName}/versions/{version}/offline-bundle
+//
+// @Summary		Get offline install bundle
+// @Description	Get the commands mirroring the packages of a server version into internal artifact stores
+// @Description	(docker save, npm pack, pip download, dotnet nuget push) for disconnected environments, along with
+// @Description	the server entry rewritten to point at the mirrors. Packages whose mirror is not given are not rewritten.
+// @Description	Example values resembling credentials are replaced with placeholders.
+// @Description	When an approval system is configured, servers it has not approved are answered with 403.
+// @Tags		extension
+// @Produce		json
+// @Produce		text/x-shellscript
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		path	string	true	"URL-encoded version (e.g., \"1.0.0\" or \"latest\")"
+// @Param		oci_mirror	query	string	false	"Internal container registry host, with optional path prefix"
+// @Param		npm_mirror	query	string	false	"Internal npm registry URL"
+// @Param		pypi_mirror	query	string	false	"Internal Python package repository upload URL"
+// @Param		nuget_mirror	query	string	false	"Internal NuGet feed push URL"
+// @Param		format		query	string	false	"Output format: json (default) or script"
+// @Success		200	{object}	offline.Bundle	"Offline install bundle"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	ApprovalRequiredResponse	"Server requires approval"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Failure		503	{object}	map[string]string	"Approval check failed"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/versions/{version}/offline-bundle [get]
+func (r *Routes) getOfflineBundle(w http.ResponseWriter, req *http.Request) {
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := common.GetAndValidateURLParam(req, "version")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := req.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = OfflineBundleFormatJSON
+	}
+	if format != OfflineBundleFormatJSON && format != OfflineBundleFormatScript {
+		common.WriteErrorResponse(w, fmt.Sprintf("Unsupported format %s", format), http.StatusBadRequest)
+		return
+	}
+
+	mirrors := offline.Mirrors{
+		OCI:   query.Get("oci_mirror"),
+		NPM:   query.Get("npm_mirror"),
+		PyPI:  query.Get("pypi_mirror"),
+		NuGet: query.Get("nuget_mirror"),
+	}
+	if err := mirrors.Validate(); err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	server, err := r.service.GetServerVersion(
+		req.Context(),
+		service.WithName[service.GetServerVersionOptions](serverName),
+		service.WithVersion[service.GetServerVersionOptions](version),
+	)
+	if errors.Is(err, service.ErrServerNotFound) || (err == nil && server == nil) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !r.checkApproval(w, req, server, EndpointOfflineBundle) {
+		return
+	}
+
+	experiment.RecordOutcome(req.Context(), experiment.OutcomeInstall, server.Name)
+	bundle := offline.NewBundle(redactSecrets(req, server), mirrors)
+	if format == OfflineBundleFormatScript {
+		w.Header().Set("Content-Type", "text/x-shellscript")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(bundle.Script()))
+		return
+	}
+
+	common.WriteJSONResponse(w, bundle, http.StatusOK)
+}
@@ -0,0 +1,147 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/offline"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestGetOfflineBundle(t *testing.T) {
+	t.Parallel()
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithOCIPackage("ghcr.io/example/test-server:1.0.0"),
+	)
+
+	tests := []struct {
+		name            string
+		path            string
+		setupMocks      func(*mocks.MockRegistryService)
+		wantStatus      int
+		wantContentType string
+		wantIdentifier  string
+	}{
+		{
+			name: "json bundle with OCI mirror",
+			path: "/servers/com.example%2Ftest-server/versions/1.0.0/offline-bundle?oci_mirror=registry.internal",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantIdentifier:  "registry.internal/example/test-server:1.0.0",
+		},
+		{
+			name: "script bundle",
+			path: "/servers/com.example%2Ftest-server/versions/latest/offline-bundle?oci_mirror=registry.internal&format=script",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/x-shellscript",
+		},
+		{
+			name:       "unsupported format",
+			path:       "/servers/com.example%2Ftest-server/versions/1.0.0/offline-bundle?format=zip",
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid mirror",
+			path:       "/servers/com.example%2Ftest-server/versions/1.0.0/offline-bundle?npm_mirror=npm.internal",
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "server not found",
+			path: "/servers/com.example%2Fmissing/versions/1.0.0/offline-bundle",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(nil, service.ErrServerNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "service error",
+			path: "/servers/com.example%2Ftest-server/versions/1.0.0/offline-bundle",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			router := Router(mockSvc)
+
+			req, err := http.NewRequest(http.MethodGet, tt.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			assert.Equal(t, tt.wantContentType, rr.Header().Get("Content-Type"))
+
+			if tt.wantIdentifier != "" {
+				var bundle offline.Bundle
+				err = json.Unmarshal(rr.Body.Bytes(), &bundle)
+				require.NoError(t, err)
+				require.Len(t, bundle.Server.Packages, 1)
+				assert.Equal(t, tt.wantIdentifier, bundle.Server.Packages[0].Identifier)
+			} else {
+				assert.Contains(t, rr.Body.String(), "docker push registry.internal/example/test-server:1.0.0")
+			}
+		})
+	}
+}
+
+func TestGetOfflineBundleRedactsSecrets(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	leaked := "ghp_" + "abcdefghijklmnopqrstuvwxyz0123456789"
+	token := model.KeyValueInput{Name: "GITHUB_TOKEN"}
+	token.Default = leaked
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithOCIPackage("ghcr.io/example/test-server:1.0.0"),
+		registry.WithEnvironmentVariables(token),
+	)
+
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+	mockSvc.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+
+	rr := httptest.NewRecorder()
+	Router(mockSvc).ServeHTTP(rr, httptest.NewRequest(http.MethodGet,
+		"/servers/com.example%2Ftest-server/versions/1.0.0/offline-bundle", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.NotContains(t, rr.Body.String(), leaked)
+
+	var bundle offline.Bundle
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &bundle))
+	assert.Equal(t, "<GITHUB_TOKEN>", bundle.Server.Packages[0].EnvironmentVariables[0].Default)
+}
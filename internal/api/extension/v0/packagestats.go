@@ -0,0 +1,112 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/pkgstats"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// PackageStats compares the version of an npm or PyPI package recorded in the registry with the
+// latest version published to its package registry
+type PackageStats struct {
+	RegistryType string `json:"registryType"`
+	Identifier   string `json:"identifier"`
+	// Version is the package version recorded in the registry
+	Version         string `json:"version,omitempty"`
+	LatestVersion   string `json:"latestVersion,omitempty"`
+	WeeklyDownloads *int64 `json:"weeklyDownloads,omitempty"`
+	// Outdated tells whether a newer version was published than the one recorded in the registry
+	Outdated bool   `json:"outdated"`
+	Error    string `json:"error,omitempty"`
+}
+
+// PackageStatsResponse lists the live stats of the npm and PyPI packages of a server version
+type PackageStatsResponse struct {
+	Server   string         `json:"server"`
+	Version  string         `json:"version"`
+	Packages []PackageStats `json:"packages"`
+	// Warnings explain which packages are outdated in the registry
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// getServerPackageStats handles GET /extension/v0/servers/{serverName}/versions/{version}/package-stats
+//
+// @Summary		Get server package stats
+// @Description	Get the latest published version and the weekly download count of the npm and PyPI packages
+// @Description	of a server version, from their package registries. A warning is returned for each package
+// @Description	whose version recorded in the registry lags behind the latest published version.
+// @Tags		extension
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		path	string	true	"URL-encoded version (e.g., \"1.0.0\" or \"latest\")"
+// @Success		200	{object}	PackageStatsResponse	"Package stats"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found or without npm or PyPI packages"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/versions/{version}/package-stats [get]
+func (r *Routes) getServerPackageStats(w http.ResponseWriter, req *http.Request) {
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := common.GetAndValidateURLParam(req, "version")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	server, err := r.service.GetServerVersion(
+		req.Context(),
+		service.WithName[service.GetServerVersionOptions](serverName),
+		service.WithVersion[service.GetServerVersionOptions](version),
+	)
+	if errors.Is(err, service.ErrServerNotFound) || (err == nil && server == nil) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := PackageStatsResponse{Server: server.Name, Version: server.Version, Packages: []PackageStats{}}
+	for _, pkg := range server.Packages {
+		if !pkgstats.Supported(pkg.RegistryType) {
+			continue
+		}
+		entry := PackageStats{RegistryType: pkg.RegistryType, Identifier: pkg.Identifier, Version: pkg.Version}
+		stats, err := r.packageFetcher.Fetch(req.Context(), pkg.RegistryType, pkg.Identifier)
+		if err != nil {
+			slog.WarnContext(req.Context(), "Failed to get package stats",
+				"registry_type", pkg.RegistryType, "package", pkg.Identifier, "error", err)
+			entry.Error = err.Error()
+			response.Packages = append(response.Packages, entry)
+			continue
+		}
+
+		entry.LatestVersion = stats.LatestVersion
+		entry.WeeklyDownloads = stats.WeeklyDownloads
+		if pkg.Version != "" && stats.LatestVersion != "" && registry.CompareVersions(pkg.Version, stats.LatestVersion) < 0 {
+			entry.Outdated = true
+			response.Warnings = append(response.Warnings, fmt.Sprintf("Registry lists %s %s at version %s but %s was published",
+				pkg.RegistryType, pkg.Identifier, pkg.Version, stats.LatestVersion))
+		}
+		response.Packages = append(response.Packages, entry)
+	}
+	if len(response.Packages) == 0 {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s has no npm or PyPI packages", serverName), http.StatusNotFound)
+		return
+	}
+
+	common.WriteJSONResponse(w, response, http.StatusOK)
+}
@@ -0,0 +1,186 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/pkgstats"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+// newTestPackageFetcher serves example-server 2.0.0 on npm and PyPI, with npm download stats only
+func newTestPackageFetcher(t *testing.T) *pkgstats.Fetcher {
+	t.Helper()
+	routes := map[string]string{
+		"/npm/example-server/latest":                              `{"version":"2.0.0"}`,
+		"/npm-downloads/downloads/point/last-week/example-server": `{"downloads":42}`,
+		"/pypi/pypi/example-server/json":                          `{"info":{"version":"2.0.0"}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return pkgstats.NewFetcher(
+		pkgstats.WithNPMURLs(server.URL+"/npm", server.URL+"/npm-downloads"),
+		pkgstats.WithPyPIURLs(server.URL+"/pypi", server.URL+"/pypistats"),
+	)
+}
+
+// withPackage adds a package of the given registry type, identifier and version to the server
+func withPackage(registryType, identifier, version string) registry.ServerOption {
+	return func(server *upstreamv0.ServerJSON) {
+		server.Packages = append(server.Packages, model.Package{
+			RegistryType: registryType,
+			Identifier:   identifier,
+			Version:      version,
+			Transport:    model.Transport{Type: "stdio"},
+		})
+	}
+}
+
+func TestGetServerPackageStats(t *testing.T) {
+	t.Parallel()
+	fetcher := newTestPackageFetcher(t)
+
+	upToDate := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		withPackage("npm", "example-server", "2.0.0"),
+		registry.WithOCIPackage("ghcr.io/example/server:1.0.0"),
+	)
+	outdated := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		withPackage("npm", "example-server", "1.9.0"),
+		withPackage("pypi", "example-server", "1.2.0"),
+	)
+	missingPackage := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		withPackage("npm", "missing-server", "1.0.0"),
+	)
+	ociOnly := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithOCIPackage("ghcr.io/example/server:1.0.0"),
+	)
+
+	tests := []struct {
+		name         string
+		setupMocks   func(*mocks.MockRegistryService)
+		wantStatus   int
+		wantPackages []PackageStats
+		wantWarnings int
+	}{
+		{
+			name: "package up to date",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&upToDate, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantPackages: []PackageStats{
+				{RegistryType: "npm", Identifier: "example-server", Version: "2.0.0", LatestVersion: "2.0.0", WeeklyDownloads: int64Ptr(42)},
+			},
+		},
+		{
+			name: "outdated packages",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&outdated, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantPackages: []PackageStats{
+				{
+					RegistryType: "npm", Identifier: "example-server", Version: "1.9.0", LatestVersion: "2.0.0",
+					WeeklyDownloads: int64Ptr(42), Outdated: true,
+				},
+				{RegistryType: "pypi", Identifier: "example-server", Version: "1.2.0", LatestVersion: "2.0.0", Outdated: true},
+			},
+			wantWarnings: 2,
+		},
+		{
+			name: "package not found",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&missingPackage, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantPackages: []PackageStats{
+				{
+					RegistryType: "npm", Identifier: "missing-server", Version: "1.0.0",
+					Error: "failed to get latest version of npm package missing-server",
+				},
+			},
+		},
+		{
+			name: "server without npm or PyPI packages",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&ociOnly, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "server not found",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(nil, service.ErrServerNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "service error",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			router := Router(mockSvc, WithPackageFetcher(fetcher))
+
+			req, err := http.NewRequest(http.MethodGet, "/servers/com.example%2Ftest-server/versions/1.0.0/package-stats", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response PackageStatsResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			require.Len(t, response.Packages, len(tt.wantPackages))
+			for i, want := range tt.wantPackages {
+				got := response.Packages[i]
+				// Errors carry the URL of the test server, so only their prefix is compared
+				assert.Contains(t, got.Error, want.Error)
+				got.Error, want.Error = "", ""
+				assert.Equal(t, want, got)
+			}
+			assert.Len(t, response.Warnings, tt.wantWarnings)
+		})
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
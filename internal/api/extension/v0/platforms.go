@@ -0,0 +1,128 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/sbom"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// ImagePlatforms lists the platforms a container image of a server is built for, or why they are unknown
+type ImagePlatforms struct {
+	Image     string   `json:"image"`
+	Platforms []string `json:"platforms"`
+	// Supported tells whether the image runs on the requested platform, if one was requested
+	Supported *bool  `json:"supported,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// PlatformsResponse lists the platforms of the container images of a server version
+type PlatformsResponse struct {
+	Server   string           `json:"server"`
+	Version  string           `json:"version"`
+	Platform string           `json:"platform,omitempty"`
+	Images   []ImagePlatforms `json:"images"`
+	// Warnings explain which images don't run on the requested platform
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// getServerPlatforms handles GET /extension/v0/servers/{serverName}/versions/{version}/platforms
+//
+// @Summary		Get server platforms
+// @Description	Get the platforms (e.g. linux/amd64, linux/arm64) the container images of a server version are
+// @Description	built for, read from their manifest lists. Given the platform of the user, each image reports
+// @Description	whether it is supported and a warning is returned for the images that are not.
+// @Tags		extension
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		path	string	true	"URL-encoded version (e.g., \"1.0.0\" or \"latest\")"
+// @Param		platform	query	string	false	"Platform of the user as os/architecture[/variant] (e.g., \"linux/arm64\")"
+// @Success		200	{object}	PlatformsResponse	"Image platforms"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found or without container images"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/versions/{version}/platforms [get]
+func (r *Routes) getServerPlatforms(w http.ResponseWriter, req *http.Request) {
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := common.GetAndValidateURLParam(req, "version")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var requested *sbom.Platform
+	if value := req.URL.Query().Get("platform"); value != "" {
+		platform, err := sbom.ParsePlatform(value)
+		if err != nil {
+			common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requested = &platform
+	}
+
+	server, err := r.service.GetServerVersion(
+		req.Context(),
+		service.WithName[service.GetServerVersionOptions](serverName),
+		service.WithVersion[service.GetServerVersionOptions](version),
+	)
+	if errors.Is(err, service.ErrServerNotFound) || (err == nil && server == nil) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := PlatformsResponse{Server: server.Name, Version: server.Version, Images: []ImagePlatforms{}}
+	if requested != nil {
+		response.Platform = requested.String()
+	}
+	for _, pkg := range server.Packages {
+		if pkg.RegistryType != model.RegistryTypeOCI {
+			continue
+		}
+		image := ImagePlatforms{Image: pkg.Identifier, Platforms: []string{}}
+		platforms, err := r.sbomFetcher.Platforms(req.Context(), pkg.Identifier)
+		if err != nil {
+			slog.WarnContext(req.Context(), "Failed to get image platforms", "image", pkg.Identifier, "error", err)
+			image.Error = err.Error()
+			response.Images = append(response.Images, image)
+			continue
+		}
+
+		supported := false
+		for _, platform := range platforms {
+			image.Platforms = append(image.Platforms, platform.String())
+			supported = supported || (requested != nil && platform.Supports(*requested))
+		}
+		if requested != nil && len(platforms) > 0 {
+			image.Supported = &supported
+			if !supported {
+				response.Warnings = append(response.Warnings, fmt.Sprintf("Image %s is not built for %s (available: %s)",
+					pkg.Identifier, requested, strings.Join(image.Platforms, ", ")))
+			}
+		}
+		response.Images = append(response.Images, image)
+	}
+	if len(response.Images) == 0 {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s has no container images", serverName), http.StatusNotFound)
+		return
+	}
+
+	common.WriteJSONResponse(w, response, http.StatusOK)
+}
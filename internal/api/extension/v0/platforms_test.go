@@ -0,0 +1,161 @@
+package v0
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/sbom"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+// newTestMultiArchRegistry serves example/multi-arch:1.0.0 built for linux/amd64 and linux/arm64
+func newTestMultiArchRegistry(t *testing.T) string {
+	t.Helper()
+	const index = `{"manifests":[{"platform":{"os":"linux","architecture":"amd64"}},` +
+		`{"platform":{"os":"linux","architecture":"arm64"}}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/example/multi-arch/manifests/1.0.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(index))
+	}))
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func TestGetServerPlatforms(t *testing.T) {
+	t.Parallel()
+	host := newTestMultiArchRegistry(t)
+	supported, unsupported := true, false
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithOCIPackage(host+"/example/multi-arch:1.0.0"),
+	)
+	missingImage := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithOCIPackage(host+"/example/missing:1.0.0"),
+	)
+	remoteOnly := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithHTTPPackage("https://example.com/mcp"),
+	)
+
+	tests := []struct {
+		name          string
+		query         string
+		setupMocks    func(*mocks.MockRegistryService)
+		wantStatus    int
+		wantPlatforms []string
+		wantSupported *bool
+		wantWarning   bool
+		wantError     bool
+	}{
+		{
+			name: "platforms without requested platform",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus:    http.StatusOK,
+			wantPlatforms: []string{"linux/amd64", "linux/arm64"},
+		},
+		{
+			name:  "supported platform given as alias",
+			query: "?platform=linux/aarch64",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus:    http.StatusOK,
+			wantPlatforms: []string{"linux/amd64", "linux/arm64"},
+			wantSupported: &supported,
+		},
+		{
+			name:  "unsupported platform",
+			query: "?platform=linux/s390x",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus:    http.StatusOK,
+			wantPlatforms: []string{"linux/amd64", "linux/arm64"},
+			wantSupported: &unsupported,
+			wantWarning:   true,
+		},
+		{
+			name: "image not found",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&missingImage, nil)
+			},
+			wantStatus:    http.StatusOK,
+			wantPlatforms: []string{},
+			wantError:     true,
+		},
+		{
+			name:       "invalid platform",
+			query:      "?platform=linux",
+			setupMocks: func(*mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "server without container images",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&remoteOnly, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "server not found",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(nil, service.ErrServerNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			router := Router(mockSvc, WithSBOMFetcher(sbom.NewFetcher(sbom.WithPlainHTTP())))
+
+			req, err := http.NewRequest(http.MethodGet,
+				"/servers/com.example%2Ftest-server/versions/1.0.0/platforms"+tt.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response PlatformsResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			require.Len(t, response.Images, 1)
+			image := response.Images[0]
+			assert.Equal(t, tt.wantPlatforms, image.Platforms)
+			assert.Equal(t, tt.wantSupported, image.Supported)
+			assert.Equal(t, tt.wantError, image.Error != "")
+			if tt.wantWarning {
+				require.Len(t, response.Warnings, 1)
+				assert.Contains(t, response.Warnings[0], "linux/amd64, linux/arm64")
+			} else {
+				assert.Empty(t, response.Warnings)
+			}
+		})
+	}
+}
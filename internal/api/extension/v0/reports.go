@@ -0,0 +1,182 @@
+package v0
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/cache"
+	"github.com/stacklok/toolhive-registry-server/internal/experiment"
+	"github.com/stacklok/toolhive-registry-server/internal/linkcheck"
+	"github.com/stacklok/toolhive-registry-server/internal/service/searchstats"
+)
+
+// defaultReportLimit is the number of report entries returned when no limit is given
+const defaultReportLimit = 50
+
+// ZeroResultSearchesResponse lists search queries that returned no servers
+type ZeroResultSearchesResponse struct {
+	Queries []searchstats.ZeroResultQuery `json:"queries"`
+}
+
+// getZeroResultSearches handles GET /extension/v0/reports/zero-result-searches
+//
+// @Summary		Zero-result searches report
+// @Description	List recent search queries that returned no servers, most frequent first.
+// @Description	Queries are tracked in memory since the server started and normalized to lower case.
+// @Description	Restricted to the administrators of the API, since queries may reveal what users look for.
+// @Tags		extension
+// @Produce		json
+// @Param		limit	query	int	false	"Maximum number of queries to return (default 50)"
+// @Param		debug_timings	query	bool	false	"Add the timing breakdown of the report to its metadata (default false)"
+// @Success		200	{object}	ZeroResultSearchesResponse	"Zero-result searches"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Caller is not an administrator"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/reports/zero-result-searches [get]
+func (r *Routes) getZeroResultSearches(w http.ResponseWriter, req *http.Request) {
+	if r.searchTracker == nil {
+		common.WriteErrorResponse(w, "Search tracking is not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	limit, ok := reportLimit(w, req)
+	if !ok {
+		return
+	}
+
+	response := ZeroResultSearchesResponse{
+		Queries: r.searchTracker.Top(limit),
+	}
+
+	common.WriteJSONResponseWithTimings(w, req, response, http.StatusOK)
+}
+
+// reportLimit returns the limit query parameter of a report, or defaultReportLimit if not given.
+// It writes a bad request response and returns false when the limit is invalid.
+func reportLimit(w http.ResponseWriter, req *http.Request) (int, bool) {
+	limitStr := req.URL.Query().Get("limit")
+	if limitStr == "" {
+		return defaultReportLimit, true
+	}
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit <= 0 {
+		common.WriteErrorResponse(w, "Invalid limit parameter: must be a positive integer", http.StatusBadRequest)
+		return 0, false
+	}
+	return limit, true
+}
+
+// getSearchFeedbackReport handles GET /extension/v0/reports/search-feedback
+//
+// @Summary		Search feedback report
+// @Description	List the servers clients accepted or rejected as search results, with the score their feedback
+// @Description	adds to their ranking, largest adjustments first. Feedback is kept in memory since the server started.
+// @Tags		extension
+// @Produce		json
+// @Param		limit	query	int	false	"Maximum number of servers to return (default 50)"
+// @Param		debug_timings	query	bool	false	"Add the timing breakdown of the report to its metadata (default false)"
+// @Success		200	{object}	SearchFeedbackResponse	"Search feedback"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/reports/search-feedback [get]
+func (r *Routes) getSearchFeedbackReport(w http.ResponseWriter, req *http.Request) {
+	if r.feedbackStore == nil {
+		common.WriteErrorResponse(w, "Search feedback is not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	limit, ok := reportLimit(w, req)
+	if !ok {
+		return
+	}
+
+	common.WriteJSONResponseWithTimings(w, req, SearchFeedbackResponse{Servers: r.feedbackStore.Top(limit)}, http.StatusOK)
+}
+
+// getCacheReport handles GET /extension/v0/reports/caches
+//
+// @Summary		Cache usage report
+// @Description	Report the memory used by the in-memory caches against their quotas and the shared
+// @Description	memory budget, along with hit, miss and eviction counts since the server started.
+// @Tags		extension
+// @Produce		json
+// @Param		debug_timings	query	bool	false	"Add the timing breakdown of the report to its metadata (default false)"
+// @Success		200	{object}	cache.BudgetStats	"Cache usage"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/reports/caches [get]
+func (r *Routes) getCacheReport(w http.ResponseWriter, req *http.Request) {
+	if r.cacheBudget == nil {
+		common.WriteErrorResponse(w, "Cache reporting is not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	var stats cache.BudgetStats = r.cacheBudget.Stats()
+	common.WriteJSONResponseWithTimings(w, req, stats, http.StatusOK)
+}
+
+// StaleServersResponse lists the servers flagged as stale by the last link check
+type StaleServersResponse struct {
+	// CheckedAt is when the last link check completed, absent if none did yet
+	CheckedAt *time.Time         `json:"checkedAt,omitempty"`
+	Servers   []linkcheck.Result `json:"servers"`
+}
+
+// getStaleServersReport handles GET /extension/v0/reports/stale-servers
+//
+// @Summary		Stale servers report
+// @Description	List the servers flagged as stale by the last periodic link check, with the problems found:
+// @Description	broken repository or website URLs, archived repositories and packages missing from npm or PyPI.
+// @Tags		extension
+// @Produce		json
+// @Param		debug_timings	query	bool	false	"Add the timing breakdown of the report to its metadata (default false)"
+// @Success		200	{object}	StaleServersResponse	"Stale servers"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/reports/stale-servers [get]
+func (r *Routes) getStaleServersReport(w http.ResponseWriter, req *http.Request) {
+	if r.linkChecker == nil {
+		common.WriteErrorResponse(w, "Link checks are not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	stale, checkedAt := r.linkChecker.Stale()
+	response := StaleServersResponse{Servers: stale}
+	if !checkedAt.IsZero() {
+		response.CheckedAt = &checkedAt
+	}
+
+	common.WriteJSONResponseWithTimings(w, req, response, http.StatusOK)
+}
+
+// getSearchExperimentReport handles GET /extension/v0/reports/search-experiment
+//
+// @Summary		Search experiment report
+// @Description	Report the outcome signals of the search ranking experiment for each of its variants: the number
+// @Description	of searches, the search results whose details were fetched or that were installed, and the mean
+// @Description	reciprocal rank of those results. Signals are tracked in memory since the server started.
+// @Tags		extension
+// @Produce		json
+// @Param		debug_timings	query	bool	false	"Add the timing breakdown of the report to its metadata (default false)"
+// @Success		200	{object}	experiment.Report	"Search experiment report"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/reports/search-experiment [get]
+func (r *Routes) getSearchExperimentReport(w http.ResponseWriter, req *http.Request) {
+	if r.experiment == nil {
+		common.WriteErrorResponse(w, "No search experiment is configured", http.StatusNotImplemented)
+		return
+	}
+
+	var report experiment.Report = r.experiment.Report()
+	common.WriteJSONResponseWithTimings(w, req, report, http.StatusOK)
+}
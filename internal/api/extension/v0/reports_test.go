@@ -0,0 +1,319 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/cache"
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/experiment"
+	"github.com/stacklok/toolhive-registry-server/internal/linkcheck"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+	"github.com/stacklok/toolhive-registry-server/internal/service/searchstats"
+)
+
+func TestGetZeroResultSearches(t *testing.T) {
+	t.Parallel()
+
+	newTracker := func() *searchstats.Tracker {
+		tracker := searchstats.NewTracker(0)
+		tracker.Record("slack")
+		tracker.Record("slack")
+		tracker.Record("jira")
+		return tracker
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		tracker     *searchstats.Tracker
+		groups      []string
+		wantStatus  int
+		wantQueries []string
+	}{
+		{
+			name:        "report lists queries",
+			path:        "/reports/zero-result-searches",
+			tracker:     newTracker(),
+			groups:      []string{testAdminGroup},
+			wantStatus:  http.StatusOK,
+			wantQueries: []string{"slack", "jira"},
+		},
+		{
+			name:        "report with limit",
+			path:        "/reports/zero-result-searches?limit=1",
+			tracker:     newTracker(),
+			groups:      []string{testAdminGroup},
+			wantStatus:  http.StatusOK,
+			wantQueries: []string{"slack"},
+		},
+		{
+			name:       "invalid limit",
+			path:       "/reports/zero-result-searches?limit=abc",
+			tracker:    newTracker(),
+			groups:     []string{testAdminGroup},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "caller not an administrator",
+			path:       "/reports/zero-result-searches",
+			tracker:    newTracker(),
+			groups:     []string{"dev"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "tracking not enabled",
+			path:       "/reports/zero-result-searches",
+			groups:     []string{testAdminGroup},
+			wantStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			opts := []RouterOption{WithAdmins(newTestAdmins())}
+			if tt.tracker != nil {
+				opts = append(opts, WithSearchTracker(tt.tracker))
+			}
+			router := Router(mocks.NewMockRegistryService(ctrl), opts...)
+
+			req, err := http.NewRequest(http.MethodGet, tt.path, nil)
+			require.NoError(t, err)
+			req = withCaller(req, tt.groups...)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var response ZeroResultSearchesResponse
+				err = json.Unmarshal(rr.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				queries := make([]string, 0, len(response.Queries))
+				for _, entry := range response.Queries {
+					queries = append(queries, entry.Query)
+				}
+				assert.Equal(t, tt.wantQueries, queries)
+			}
+		})
+	}
+}
+
+func TestGetCacheReport(t *testing.T) {
+	t.Parallel()
+
+	newBudget := func() *cache.Budget {
+		budget := cache.NewBudget(1024)
+		sbomCache := cache.New[string, string]("sbom", cache.WithBudget(budget), cache.WithMaxBytes(100))
+		sbomCache.Add("ghcr.io/example/server:1.0.0", "summary", 80)
+		sbomCache.Add("ghcr.io/example/server:2.0.0", "summary", 80)
+		return budget
+	}
+
+	tests := []struct {
+		name       string
+		budget     *cache.Budget
+		wantStatus int
+	}{
+		{
+			name:       "report lists caches",
+			budget:     newBudget(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "reporting not enabled",
+			wantStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			var opts []RouterOption
+			if tt.budget != nil {
+				opts = append(opts, WithCacheBudget(tt.budget))
+			}
+			router := Router(mocks.NewMockRegistryService(ctrl), opts...)
+
+			req, err := http.NewRequest(http.MethodGet, "/reports/caches", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var response cache.BudgetStats
+				err = json.Unmarshal(rr.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, int64(1024), response.MaxBytes)
+				assert.Equal(t, int64(80), response.Bytes)
+				require.Len(t, response.Caches, 1)
+				assert.Equal(t, "sbom", response.Caches[0].Name)
+				assert.Equal(t, 1, response.Caches[0].Entries)
+				assert.Equal(t, uint64(1), response.Caches[0].Evictions)
+			}
+		})
+	}
+}
+
+func TestGetStaleServersReport(t *testing.T) {
+	t.Parallel()
+
+	website := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(website.Close)
+	newChecker := func(check bool) *linkcheck.Checker {
+		checker := linkcheck.NewChecker()
+		if check {
+			stale := registry.NewTestServer("io.example/stale")
+			stale.WebsiteURL = website.URL + "/missing"
+			healthy := registry.NewTestServer("io.example/healthy")
+			checker.Check(context.Background(), []*upstreamv0.ServerJSON{&stale, &healthy})
+		}
+		return checker
+	}
+
+	tests := []struct {
+		name          string
+		checker       *linkcheck.Checker
+		wantStatus    int
+		wantServers   []string
+		wantCheckedAt bool
+	}{
+		{
+			name:          "report lists stale servers",
+			checker:       newChecker(true),
+			wantStatus:    http.StatusOK,
+			wantServers:   []string{"io.example/stale"},
+			wantCheckedAt: true,
+		},
+		{
+			name:        "no check completed yet",
+			checker:     newChecker(false),
+			wantStatus:  http.StatusOK,
+			wantServers: []string{},
+		},
+		{
+			name:       "link checks not enabled",
+			wantStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			var opts []RouterOption
+			if tt.checker != nil {
+				opts = append(opts, WithLinkChecker(tt.checker))
+			}
+			router := Router(mocks.NewMockRegistryService(ctrl), opts...)
+
+			req, err := http.NewRequest(http.MethodGet, "/reports/stale-servers", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var response StaleServersResponse
+				err = json.Unmarshal(rr.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				names := make([]string, 0, len(response.Servers))
+				for _, server := range response.Servers {
+					names = append(names, server.Name)
+				}
+				assert.Equal(t, tt.wantServers, names)
+				assert.Equal(t, tt.wantCheckedAt, response.CheckedAt != nil)
+			}
+		})
+	}
+}
+
+func TestGetSearchExperimentReport(t *testing.T) {
+	t.Parallel()
+
+	newExperiment := func() *experiment.Experiment {
+		exp, err := experiment.New(&config.SearchExperimentConfig{
+			Name: "official-first",
+			Variants: []config.SearchVariantConfig{
+				{Name: "control", Weight: 1},
+				{Name: "official", Weight: 1, Boosts: []config.SearchBoostConfig{{Tier: "Official", Weight: 10}}},
+			},
+		})
+		require.NoError(t, err)
+		return exp
+	}
+
+	tests := []struct {
+		name       string
+		experiment *experiment.Experiment
+		wantStatus int
+	}{
+		{
+			name:       "report lists variants",
+			experiment: newExperiment(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "no experiment configured",
+			wantStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			var opts []RouterOption
+			if tt.experiment != nil {
+				opts = append(opts, WithExperiment(tt.experiment))
+			}
+			router := Router(mocks.NewMockRegistryService(ctrl), opts...)
+
+			req, err := http.NewRequest(http.MethodGet, "/reports/search-experiment", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var response experiment.Report
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, "official-first", response.Experiment)
+				require.Len(t, response.Variants, 2)
+				assert.Equal(t, "control", response.Variants[0].Name)
+				assert.Equal(t, "official", response.Variants[1].Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package v0
+
+import (
+	"fmt"
+	"net/http"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// VersionResolutionResponse is the outcome of resolving a version range against the published versions of a server
+type VersionResolutionResponse struct {
+	Constraint string                 `json:"constraint"`
+	Version    string                 `json:"version"`
+	Candidates []string               `json:"candidates"`
+	Server     *upstreamv0.ServerJSON `json:"server"`
+}
+
+// resolveVersion handles GET /extension/v0/servers/{serverName}/resolve
+//
+// @Summary		Resolve server version range
+// @Description	Resolve a semantic versioning range (e.g. `^1.2` or `>=2.0 <3`) against the published
+// @Description	versions of a server, returning the highest matching version and all versions satisfying the range.
+// @Tags		extension
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		query	string	true	"Semantic versioning range"
+// @Success		200	{object}	VersionResolutionResponse	"Resolved version"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found or no version satisfies the range"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/resolve [get]
+func (r *Routes) resolveVersion(w http.ResponseWriter, req *http.Request) {
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	constraint := req.URL.Query().Get("version")
+	if constraint == "" {
+		common.WriteErrorResponse(w, "version parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	versions, err := r.service.ListServerVersions(
+		req.Context(),
+		service.WithName[service.ListServerVersionsOptions](serverName),
+		service.WithAllVersions(),
+	)
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(versions) == 0 {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+
+	resolution, err := registry.ResolveVersion(constraint, versions)
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if resolution.Server == nil {
+		common.WriteErrorResponse(
+			w,
+			fmt.Sprintf("No version of server %s satisfies %s", serverName, constraint),
+			http.StatusNotFound,
+		)
+		return
+	}
+
+	common.WriteJSONResponse(w, VersionResolutionResponse{
+		Constraint: resolution.Constraint,
+		Version:    resolution.Server.Version,
+		Candidates: resolution.Candidates,
+		Server:     resolution.Server,
+	}, http.StatusOK)
+}
@@ -0,0 +1,135 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestResolveVersion(t *testing.T) {
+	t.Parallel()
+
+	v1 := registry.NewTestServer("com.example/test-server", registry.WithServerVersion("1.2.0"))
+	v1Patch := registry.NewTestServer("com.example/test-server", registry.WithServerVersion("1.2.5"))
+	v2 := registry.NewTestServer("com.example/test-server", registry.WithServerVersion("2.0.0"))
+	versions := []*upstreamv0.ServerJSON{&v1, &v1Patch, &v2}
+
+	tests := []struct {
+		name           string
+		path           string
+		setupMocks     func(*mocks.MockRegistryService)
+		wantStatus     int
+		wantVersion    string
+		wantCandidates []string
+	}{
+		{
+			name: "resolves highest matching version",
+			path: "/servers/com.example%2Ftest-server/resolve?version=%5E1.2",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, opts ...service.Option[service.ListServerVersionsOptions]) ([]*upstreamv0.ServerJSON, error) {
+						options := &service.ListServerVersionsOptions{}
+						for _, opt := range opts {
+							_ = opt(options)
+						}
+						if !options.All {
+							return nil, errors.New("ranges must be resolved among all versions")
+						}
+						return versions, nil
+					})
+			},
+			wantStatus:     http.StatusOK,
+			wantVersion:    "1.2.5",
+			wantCandidates: []string{"1.2.5", "1.2.0"},
+		},
+		{
+			name: "space separated range",
+			path: "/servers/com.example%2Ftest-server/resolve?version=%3E%3D2.0+%3C3",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).Return(versions, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantVersion:    "2.0.0",
+			wantCandidates: []string{"2.0.0"},
+		},
+		{
+			name: "no version satisfies the range",
+			path: "/servers/com.example%2Ftest-server/resolve?version=%5E3",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).Return(versions, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "server not found",
+			path: "/servers/com.example%2Fmissing/resolve?version=%5E1",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).Return([]*upstreamv0.ServerJSON{}, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "invalid range",
+			path: "/servers/com.example%2Ftest-server/resolve?version=%3E%3E1",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).Return(versions, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing version",
+			path:       "/servers/com.example%2Ftest-server/resolve",
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			path: "/servers/com.example%2Ftest-server/resolve?version=%5E1",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			router := Router(mockSvc)
+
+			req, err := http.NewRequest(http.MethodGet, tt.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var response VersionResolutionResponse
+				err = json.Unmarshal(rr.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantVersion, response.Version)
+				assert.Equal(t, tt.wantVersion, response.Server.Version)
+				assert.Equal(t, tt.wantCandidates, response.Candidates)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/reviews"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+const (
+	// maxReviewsRequestSize caps the size of review ingestion request bodies
+	maxReviewsRequestSize = 1024 * 1024
+	// maxIngestedReviews caps the number of reviews ingested in one request
+	maxIngestedReviews = 1000
+	// maxRatingsReportServers caps the number of servers compared in one ratings report
+	maxRatingsReportServers = 20
+)
+
+// IngestReviewsRequestBody holds the reviews to ingest
+type IngestReviewsRequestBody struct {
+	Reviews []reviews.Review `json:"reviews"`
+}
+
+// IngestReviewsResponse reports the number of reviews ingested
+type IngestReviewsResponse struct {
+	Ingested int `json:"ingested"`
+}
+
+// RatingsResponse lists the ratings of servers
+type RatingsResponse struct {
+	Servers []reviews.Rating `json:"servers"`
+}
+
+// ratingColumnNames are the columns of ratings reports rendered as tables: the number of reviews of
+// each rating is in rating_1 to rating_5
+var ratingColumnNames = []string{
+	"server", "average", "count", "rating_1", "rating_2", "rating_3", "rating_4", "rating_5", "latest_comment",
+}
+
+// defaultRatingColumns are the columns of ratings reports rendered as tables when none are selected
+var defaultRatingColumns = []string{"server", "average", "count"}
+
+// ratingColumn returns the value of a column of ratings reports rendered as tables
+func ratingColumn(rating reviews.Rating, column string) string {
+	switch column {
+	case "server":
+		return rating.Server
+	case "average":
+		return strconv.FormatFloat(rating.Average, 'f', -1, 64)
+	case "count":
+		return strconv.Itoa(rating.Count)
+	case "latest_comment":
+		if len(rating.Snippets) == 0 {
+			return ""
+		}
+		return rating.Snippets[0].Comment
+	default:
+		value, _ := strconv.Atoi(strings.TrimPrefix(column, "rating_"))
+		return strconv.Itoa(rating.Distribution[value-reviews.MinRating])
+	}
+}
+
+// writeRatingsReport writes a ratings report as JSON or as a table with the selected columns
+func writeRatingsReport(w http.ResponseWriter, req *http.Request, table common.Table, ratings []reviews.Rating) {
+	if table.IsJSON() {
+		common.WriteJSONResponseWithTimings(w, req, RatingsResponse{Servers: ratings}, http.StatusOK)
+		return
+	}
+	rows := make([][]string, len(ratings))
+	for i, rating := range ratings {
+		for _, column := range table.Columns {
+			rows[i] = append(rows[i], ratingColumn(rating, column))
+		}
+	}
+	common.WriteTable(w, table, rows, http.StatusOK)
+}
+
+// ingestReviews handles POST /extension/v0/reviews
+//
+// @Summary		Ingest reviews
+// @Description	Ingest the ratings (1 to 5) and reviews users gave servers, e.g. collected by an internal survey or a
+// @Description	companion UI. A review with the source and ID of a previously ingested one replaces it. Either all
+// @Description	reviews of a request are ingested, or none. Restricted to the administrators of the API.
+// @Tags		extension
+// @Accept		json
+// @Produce		json
+// @Param		reviews	body	IngestReviewsRequestBody	true	"Reviews to ingest"
+// @Success		200	{object}	IngestReviewsResponse	"Number of reviews ingested"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	map[string]string	"Caller is not an administrator"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/reviews [post]
+func (r *Routes) ingestReviews(w http.ResponseWriter, req *http.Request) {
+	if r.reviews == nil {
+		common.WriteErrorResponse(w, "Reviews are not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	var body IngestReviewsRequestBody
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxReviewsRequestSize)).Decode(&body); err != nil {
+		common.WriteErrorResponse(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body.Reviews) == 0 {
+		common.WriteErrorResponse(w, "reviews is required", http.StatusBadRequest)
+		return
+	}
+	if len(body.Reviews) > maxIngestedReviews {
+		common.WriteErrorResponse(w,
+			fmt.Sprintf("At most %d reviews can be ingested at once", maxIngestedReviews), http.StatusBadRequest)
+		return
+	}
+
+	// Only reviews of servers of the catalog are ingested
+	checked := make(map[string]bool)
+	for _, review := range body.Reviews {
+		if review.Server == "" || checked[review.Server] {
+			continue
+		}
+		_, err := r.getServerReference(req, review.Server, "")
+		if errors.Is(err, service.ErrServerNotFound) {
+			common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", review.Server), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		checked[review.Server] = true
+	}
+
+	ingested, err := r.reviews.Ingest(body.Reviews)
+	if errors.Is(err, reviews.ErrInvalidReview) {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	slog.InfoContext(req.Context(), "Reviews ingested", "reviews", ingested, "servers", len(checked))
+
+	common.WriteJSONResponse(w, IngestReviewsResponse{Ingested: ingested}, http.StatusOK)
+}
+
+// getServerRating handles GET /extension/v0/servers/{serverName}/reviews
+//
+// @Summary		Get server rating
+// @Description	Get the average rating of a server, the number of reviews of each rating and the comments of its most
+// @Description	recent reviews. Servers without reviews have a count of 0.
+// @Tags		extension
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Success		200	{object}	reviews.Rating	"Rating of the server"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/reviews [get]
+func (r *Routes) getServerRating(w http.ResponseWriter, req *http.Request) {
+	if r.reviews == nil {
+		common.WriteErrorResponse(w, "Reviews are not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	common.WriteJSONResponse(w, r.rating(serverName), http.StatusOK)
+}
+
+// getRatingsReport handles GET /extension/v0/reports/ratings
+//
+// @Summary		Ratings report
+// @Description	Compare the ratings of the given servers side by side, in the given order, or list the best rated
+// @Description	servers, highest average first, when no server is given. The report can be rendered as a Markdown
+// @Description	or CSV table with one row per server and the columns server, average, count, rating_1 to rating_5
+// @Description	(number of reviews of each rating) and latest_comment.
+// @Tags		extension
+// @Produce		json
+// @Produce		text/markdown
+// @Produce		text/csv
+// @Param		server	query	[]string	false	"Name of a server to compare"	collectionFormat(multi)
+// @Param		limit	query	int	false	"Maximum number of servers to return without server (default 50)"
+// @Param		format	query	string	false	"Output format: json (default), markdown or csv table"
+// @Param		columns	query	string	false	"Comma-separated table columns (default server,average,count)"
+// @Param		debug_timings	query	bool	false	"Add the timing breakdown of the report to its metadata (default false)"
+// @Success		200	{object}	RatingsResponse	"Ratings of servers"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		501	{object}	map[string]string	"Not implemented"
+// @Security	BearerAuth
+// @Router		/extension/v0/reports/ratings [get]
+func (r *Routes) getRatingsReport(w http.ResponseWriter, req *http.Request) {
+	if r.reviews == nil {
+		common.WriteErrorResponse(w, "Reviews are not enabled", http.StatusNotImplemented)
+		return
+	}
+
+	table, ok := common.ParseTable(w, req, ratingColumnNames, defaultRatingColumns)
+	if !ok {
+		return
+	}
+
+	names := req.URL.Query()["server"]
+	if len(names) == 0 {
+		limit, ok := reportLimit(w, req)
+		if !ok {
+			return
+		}
+		ratings := r.reviews.Ratings()
+		writeRatingsReport(w, req, table, ratings[:min(limit, len(ratings))])
+		return
+	}
+	if len(names) > maxRatingsReportServers {
+		common.WriteErrorResponse(w,
+			fmt.Sprintf("At most %d servers can be compared", maxRatingsReportServers), http.StatusBadRequest)
+		return
+	}
+
+	ratings := make([]reviews.Rating, 0, len(names))
+	for _, name := range names {
+		ratings = append(ratings, r.rating(name))
+	}
+	writeRatingsReport(w, req, table, ratings)
+}
+
+// rating returns the rating of a server, with a count of 0 if it has no reviews
+func (r *Routes) rating(server string) reviews.Rating {
+	if rating, ok := r.reviews.Rating(server); ok {
+		return rating
+	}
+	return reviews.Rating{Server: server, Snippets: []reviews.Snippet{}}
+}
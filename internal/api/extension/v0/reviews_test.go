@@ -0,0 +1,216 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/reviews"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestIngestReviews(t *testing.T) {
+	t.Parallel()
+
+	server := registry.NewTestServer("com.example/test-server")
+
+	tests := []struct {
+		name         string
+		store        bool
+		noAdmins     bool
+		groups       []string
+		body         string
+		versions     []*upstreamv0.ServerJSON
+		err          error
+		wantStatus   int
+		wantIngested int
+	}{
+		{
+			name:         "reviews ingested",
+			store:        true,
+			groups:       []string{testAdminGroup},
+			body:         `{"reviews":[{"server":"com.example/test-server","rating":5},{"server":"com.example/test-server","rating":3}]}`,
+			versions:     []*upstreamv0.ServerJSON{&server},
+			wantStatus:   http.StatusOK,
+			wantIngested: 2,
+		},
+		{
+			name:       "invalid rating",
+			store:      true,
+			groups:     []string{testAdminGroup},
+			body:       `{"reviews":[{"server":"com.example/test-server","rating":9}]}`,
+			versions:   []*upstreamv0.ServerJSON{&server},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown server",
+			store:      true,
+			groups:     []string{testAdminGroup},
+			body:       `{"reviews":[{"server":"com.example/test-server","rating":5}]}`,
+			versions:   []*upstreamv0.ServerJSON{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "service error",
+			store:      true,
+			groups:     []string{testAdminGroup},
+			body:       `{"reviews":[{"server":"com.example/test-server","rating":5}]}`,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "no reviews",
+			store:      true,
+			groups:     []string{testAdminGroup},
+			body:       `{"reviews":[]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "caller is not an administrator",
+			store:      true,
+			groups:     []string{"developers"},
+			body:       `{"reviews":[{"server":"com.example/test-server","rating":5}]}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "administrators not configured",
+			store:      true,
+			noAdmins:   true,
+			groups:     []string{testAdminGroup},
+			body:       `{"reviews":[{"server":"com.example/test-server","rating":5}]}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "reviews not enabled",
+			groups:     []string{testAdminGroup},
+			body:       `{"reviews":[{"server":"com.example/test-server","rating":5}]}`,
+			wantStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			if tt.versions != nil || tt.err != nil {
+				mockSvc.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).Return(tt.versions, tt.err)
+			}
+			store, err := reviews.NewStore(filepath.Join(t.TempDir(), "reviews.json"))
+			require.NoError(t, err)
+			var opts []RouterOption
+			if !tt.noAdmins {
+				opts = append(opts, WithAdmins(newTestAdmins()))
+			}
+			if tt.store {
+				opts = append(opts, WithReviews(store))
+			}
+			router := Router(mockSvc, opts...)
+
+			req, err := http.NewRequest(http.MethodPost, "/reviews", strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, withCaller(req, tt.groups...))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				assert.Empty(t, store.Ratings())
+				return
+			}
+
+			var response IngestReviewsResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantIngested, response.Ingested)
+			rating, ok := store.Rating("com.example/test-server")
+			require.True(t, ok)
+			assert.Equal(t, 4.0, rating.Average)
+		})
+	}
+}
+
+func TestRatingEndpoints(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	store, err := reviews.NewStore(filepath.Join(t.TempDir(), "reviews.json"))
+	require.NoError(t, err)
+	_, err = store.Ingest([]reviews.Review{
+		{Server: "com.example/liked", Rating: 5, Comment: "Great"},
+		{Server: "com.example/disliked", Rating: 2},
+	})
+	require.NoError(t, err)
+	router := Router(mocks.NewMockRegistryService(ctrl), WithReviews(store))
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr
+	}
+	servers := func(rr *httptest.ResponseRecorder) []string {
+		var response RatingsResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		names := make([]string, 0, len(response.Servers))
+		for _, rating := range response.Servers {
+			names = append(names, rating.Server)
+		}
+		return names
+	}
+
+	rr := serve("/servers/com.example%2Fliked/reviews")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var rating reviews.Rating
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &rating))
+	assert.Equal(t, 5.0, rating.Average)
+	require.Len(t, rating.Snippets, 1)
+	assert.Equal(t, "Great", rating.Snippets[0].Comment)
+
+	rr = serve("/servers/com.example%2Funreviewed/reviews")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &rating))
+	assert.Zero(t, rating.Count)
+
+	rr = serve("/reports/ratings")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{"com.example/liked", "com.example/disliked"}, servers(rr))
+
+	rr = serve("/reports/ratings?limit=1")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{"com.example/liked"}, servers(rr))
+
+	rr = serve("/reports/ratings?server=com.example/disliked&server=com.example/unreviewed")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{"com.example/disliked", "com.example/unreviewed"}, servers(rr))
+
+	rr = serve("/reports/ratings?server=com.example/liked&server=com.example/disliked&format=csv" +
+		"&columns=server,average,rating_5,latest_comment")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "server,average,rating_5,latest_comment\ncom.example/liked,5,1,Great\ncom.example/disliked,2,0,\n",
+		rr.Body.String())
+
+	rr = serve("/reports/ratings?format=markdown&limit=1")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "| server | average | count |\n| --- | --- | --- |\n| com.example/liked | 5 | 1 |\n", rr.Body.String())
+
+	assert.Equal(t, http.StatusBadRequest, serve("/reports/ratings?limit=0").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("/reports/ratings?format=csv&columns=rating_6").Code)
+
+	unconfigured := Router(mocks.NewMockRegistryService(ctrl))
+	rr = httptest.NewRecorder()
+	unconfigured.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/reports/ratings", nil))
+	assert.Equal(t, http.StatusNotImplemented, rr.Code)
+}
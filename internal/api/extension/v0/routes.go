@@ -4,42 +4,347 @@ package v0
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/stacklok/toolhive-registry-server/internal/annotations"
 	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/approval"
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/cache"
+	"github.com/stacklok/toolhive-registry-server/internal/changelog"
+	"github.com/stacklok/toolhive-registry-server/internal/experiment"
+	"github.com/stacklok/toolhive-registry-server/internal/features"
+	"github.com/stacklok/toolhive-registry-server/internal/feedback"
+	"github.com/stacklok/toolhive-registry-server/internal/kubernetes"
+	"github.com/stacklok/toolhive-registry-server/internal/linkcheck"
+	"github.com/stacklok/toolhive-registry-server/internal/pkgstats"
+	"github.com/stacklok/toolhive-registry-server/internal/reviews"
+	"github.com/stacklok/toolhive-registry-server/internal/sbom"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/searchstats"
+	"github.com/stacklok/toolhive-registry-server/internal/summary"
 )
 
+// Names of the extension endpoints whose concurrency can be limited
+const (
+	EndpointBadges        = "badges"
+	EndpointResolve       = "resolve"
+	EndpointOfflineBundle = "offline-bundle"
+	EndpointSuggestions   = "suggestions"
+	EndpointSBOM          = "sbom"
+	EndpointPlatforms     = "platforms"
+	EndpointPackageStats  = "package-stats"
+	EndpointChangelog     = "changelog"
+	EndpointInstall       = "install"
+	EndpointSetupGuide    = "setup-guide"
+	EndpointClientConfig  = "client-config"
+	EndpointValidate      = "validate"
+)
+
+// ConcurrencyLimitedEndpoints are the names of the endpoints whose concurrency can be limited
+var ConcurrencyLimitedEndpoints = []string{
+	EndpointBadges, EndpointResolve, EndpointOfflineBundle, EndpointSuggestions, EndpointSBOM, EndpointPlatforms,
+	EndpointPackageStats, EndpointChangelog, EndpointInstall, EndpointSetupGuide, EndpointClientConfig,
+	EndpointValidate,
+}
+
+// ValidateConcurrencyLimits checks that the configured concurrency limits (see
+// config.ConcurrencyConfig) name endpoints whose concurrency can be limited
+func ValidateConcurrencyLimits(limits map[string]int) error {
+	for _, endpoint := range slices.Sorted(maps.Keys(limits)) {
+		if !slices.Contains(ConcurrencyLimitedEndpoints, endpoint) {
+			return fmt.Errorf("concurrency.limits: unknown endpoint '%s' (supported: %s)",
+				endpoint, strings.Join(ConcurrencyLimitedEndpoints, ", "))
+		}
+	}
+	return nil
+}
+
+// DefaultConcurrencyLimits are the concurrency limits applied to endpoints not configured otherwise.
+// Suggestions consider the whole catalog, SBOMs and image manifests are downloaded from
+// container registries, package stats from npm and PyPI, release notes from GitHub and the links
+// of validated entries are requested, so bursts of them are capped by default.
+var DefaultConcurrencyLimits = map[string]int{
+	EndpointSuggestions:  4,
+	EndpointSBOM:         4,
+	EndpointPlatforms:    4,
+	EndpointPackageStats: 4,
+	EndpointChangelog:    4,
+	EndpointValidate:     4,
+}
+
 // Routes handles HTTP requests for extension API v0 endpoints.
 type Routes struct {
-	service service.RegistryService
+	service           service.RegistryService
+	searchTracker     *searchstats.Tracker
+	sbomFetcher       *sbom.Fetcher
+	packageFetcher    *pkgstats.Fetcher
+	changelogFetcher  *changelog.Fetcher
+	approvalChecker   approval.Checker
+	ticketFiler       approval.TicketFiler
+	cacheBudget       *cache.Budget
+	concurrencyLimits map[string]int
+	features          *features.Flags
+	installer         *kubernetes.Installer
+	linkChecker       *linkcheck.Checker
+	experiment        *experiment.Experiment
+	feedbackStore     *feedback.Store
+	summaries         *summary.Summaries
+	annotations       *annotations.Store
+	reviews           *reviews.Store
+	admins            *auth.Admins
+}
+
+// RouterOption configures the extension API v0 router
+type RouterOption func(*Routes)
+
+// WithSearchTracker sets the tracker backing the zero-result searches report.
+// Without a tracker, the report endpoint responds with 501 Not Implemented.
+func WithSearchTracker(tracker *searchstats.Tracker) RouterOption {
+	return func(r *Routes) {
+		r.searchTracker = tracker
+	}
+}
+
+// WithSBOMFetcher sets the fetcher retrieving the SBOMs of container images
+func WithSBOMFetcher(fetcher *sbom.Fetcher) RouterOption {
+	return func(r *Routes) {
+		r.sbomFetcher = fetcher
+	}
+}
+
+// WithPackageFetcher sets the fetcher retrieving the stats of npm and PyPI packages
+func WithPackageFetcher(fetcher *pkgstats.Fetcher) RouterOption {
+	return func(r *Routes) {
+		r.packageFetcher = fetcher
+	}
+}
+
+// WithChangelogFetcher sets the fetcher retrieving the release notes of servers from GitHub
+func WithChangelogFetcher(fetcher *changelog.Fetcher) RouterOption {
+	return func(r *Routes) {
+		r.changelogFetcher = fetcher
+	}
+}
+
+// WithApprovalChecker sets the approval system consulted before install instructions are handed out.
+// Without a checker, all servers are considered approved.
+func WithApprovalChecker(checker approval.Checker) RouterOption {
+	return func(r *Routes) {
+		r.approvalChecker = checker
+	}
+}
+
+// WithTicketFiler sets the ticketing system approval requests are filed in.
+// Without a filer, the approval request endpoint responds with 501 Not Implemented.
+func WithTicketFiler(filer approval.TicketFiler) RouterOption {
+	return func(r *Routes) {
+		r.ticketFiler = filer
+	}
+}
+
+// WithCacheBudget sets the memory budget whose cache usage is reported.
+// Without a budget, the cache report endpoint responds with 501 Not Implemented.
+func WithCacheBudget(budget *cache.Budget) RouterOption {
+	return func(r *Routes) {
+		r.cacheBudget = budget
+	}
+}
+
+// WithConcurrencyLimits sets the maximum number of concurrent requests per endpoint,
+// keyed by endpoint name (e.g. EndpointSuggestions). The limits override DefaultConcurrencyLimits.
+func WithConcurrencyLimits(limits map[string]int) RouterOption {
+	return func(r *Routes) {
+		for endpoint, limit := range limits {
+			r.concurrencyLimits[endpoint] = limit
+		}
+	}
+}
+
+// WithFeatures sets the feature flags gating the experimental endpoints, which respond with
+// 404 Not Found when disabled. Without flags, all features are in their default state.
+func WithFeatures(flags *features.Flags) RouterOption {
+	return func(r *Routes) {
+		r.features = flags
+	}
+}
+
+// WithInstaller sets the installer managing MCPServer resources in the cluster.
+// Without an installer, the install, installed servers and uninstall endpoints respond
+// with 501 Not Implemented.
+func WithInstaller(installer *kubernetes.Installer) RouterOption {
+	return func(r *Routes) {
+		r.installer = installer
+	}
+}
+
+// WithLinkChecker sets the link checker whose stale servers are reported.
+// Without a checker, the stale servers report endpoint responds with 501 Not Implemented.
+func WithLinkChecker(checker *linkcheck.Checker) RouterOption {
+	return func(r *Routes) {
+		r.linkChecker = checker
+	}
+}
+
+// WithExperiment sets the search ranking experiment whose outcome signals are reported.
+// Without an experiment, the search experiment report endpoint responds with 501 Not Implemented.
+func WithExperiment(exp *experiment.Experiment) RouterOption {
+	return func(r *Routes) {
+		r.experiment = exp
+	}
+}
+
+// WithFeedbackStore sets the store recording client feedback on search results.
+// Without a store, the feedback and search feedback report endpoints respond with 501 Not Implemented.
+func WithFeedbackStore(store *feedback.Store) RouterOption {
+	return func(r *Routes) {
+		r.feedbackStore = store
+	}
+}
+
+// WithSummaries sets the summaries of long descriptions used in context packs.
+// Without summaries, context packs summarize servers with their descriptions.
+func WithSummaries(summaries *summary.Summaries) RouterOption {
+	return func(r *Routes) {
+		r.summaries = summaries
+	}
+}
+
+// WithAnnotations sets the store of the annotations operators record about servers.
+// Without a store, the annotation endpoints respond with 501 Not Implemented.
+func WithAnnotations(store *annotations.Store) RouterOption {
+	return func(r *Routes) {
+		r.annotations = store
+	}
+}
+
+// WithReviews sets the store of the ratings and reviews of servers.
+// Without a store, the review endpoints respond with 501 Not Implemented.
+func WithReviews(store *reviews.Store) RouterOption {
+	return func(r *Routes) {
+		r.reviews = store
+	}
+}
+
+// WithAdmins sets the administrators of the API, allowed to change server annotations, ingest reviews,
+// read the searches users made and install servers in the cluster. Without administrators, these operations respond with 403 Forbidden.
+func WithAdmins(admins *auth.Admins) RouterOption {
+	return func(r *Routes) {
+		r.admins = admins
+	}
 }
 
 // NewRoutes creates a new Routes instance with the given service.
 func NewRoutes(svc service.RegistryService) *Routes {
+	limits := make(map[string]int, len(DefaultConcurrencyLimits))
+	for endpoint, limit := range DefaultConcurrencyLimits {
+		limits[endpoint] = limit
+	}
 	return &Routes{
-		service: svc,
+		service:           svc,
+		sbomFetcher:       sbom.NewFetcher(),
+		packageFetcher:    pkgstats.NewFetcher(),
+		changelogFetcher:  changelog.NewFetcher(),
+		concurrencyLimits: limits,
 	}
 }
 
+// limit returns the middleware enforcing the concurrency limit of an endpoint, if any
+func (r *Routes) limit(endpoint string) func(http.Handler) http.Handler {
+	return common.ConcurrencyLimit(r.concurrencyLimits[endpoint])
+}
+
+// feature returns the middleware gating an endpoint behind a feature flag
+func (r *Routes) feature(name string) func(http.Handler) http.Handler {
+	return common.FeatureGate(r.features, name)
+}
+
+// admin is the middleware restricting an endpoint to the administrators of the API
+func (r *Routes) admin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r.admins == nil {
+			common.WriteErrorResponse(w, "Administrative operations are disabled: auth.admin is not configured",
+				http.StatusForbidden)
+			return
+		}
+		if !r.admins.IsAdmin(req.Context()) {
+			common.WriteErrorResponse(w, "Administrator privileges are required", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
 // Router creates and configures the HTTP router for extension API v0 endpoints.
-func Router(svc service.RegistryService) http.Handler {
+func Router(svc service.RegistryService, opts ...RouterOption) http.Handler {
 	routes := NewRoutes(svc)
+	for _, opt := range opts {
+		opt(routes)
+	}
 
 	r := chi.NewRouter()
 
 	r.Get("/registries", routes.listRegistries)
 
 	r.Get("/registries/{registryName}", routes.getRegistry)
-	r.Put("/registries/{registryName}", routes.upsertRegistry)
-	r.Delete("/registries/{registryName}", routes.deleteRegistry)
+	writes := routes.feature(features.RegistryWrites)
+	r.With(writes).Put("/registries/{registryName}", routes.upsertRegistry)
+	r.With(writes).Delete("/registries/{registryName}", routes.deleteRegistry)
 
 	r.Route("/registries/{registryName}/servers/{serverName}", func(r chi.Router) {
-		r.Put("/versions/{version}", routes.upsertVersion)
+		r.With(writes).Put("/versions/{version}", routes.upsertVersion)
 	})
 
+	r.With(routes.limit(EndpointBadges)).Get("/badges/{serverName}/{badge}", routes.getBadge)
+	r.With(routes.limit(EndpointResolve)).Get("/servers/{serverName}/resolve", routes.resolveVersion)
+	r.With(routes.feature(features.OfflineBundle), routes.limit(EndpointOfflineBundle)).
+		Get("/servers/{serverName}/versions/{version}/offline-bundle", routes.getOfflineBundle)
+	r.Get("/servers/{serverName}/versions/{version}/injection-risk", routes.getInjectionRisk)
+	r.Get("/servers/{serverName}/versions/{version}/context-pack", routes.getContextPack)
+	r.With(routes.limit(EndpointValidate)).Post("/validate", routes.validateServer)
+	r.With(routes.limit(EndpointClientConfig)).Get("/client-config", routes.getClientConfig)
+	r.With(routes.limit(EndpointSetupGuide)).
+		Get("/servers/{serverName}/versions/{version}/setup-guide", routes.getSetupGuide)
+	r.Get("/servers/{serverName}/versions/{version}/tools", routes.getServerTools)
+	r.Get("/servers/{serverName}/versions/{version}/environment-variables", routes.getServerEnvironmentVariables)
+	r.Post("/servers/{serverName}/versions/{version}/approval-requests", routes.requestServerApproval)
+	r.Post("/servers/{serverName}/feedback", routes.reportResultFeedback)
+	r.Get("/annotations", routes.listAnnotations)
+	r.Get("/servers/{serverName}/annotation", routes.getServerAnnotation)
+	r.With(writes, routes.admin).Put("/servers/{serverName}/annotation", routes.putServerAnnotation)
+	r.With(writes, routes.admin).Delete("/servers/{serverName}/annotation", routes.deleteServerAnnotation)
+	r.With(writes, routes.admin).Post("/reviews", routes.ingestReviews)
+	r.Get("/servers/{serverName}/reviews", routes.getServerRating)
+	clusterInstall := routes.feature(features.ClusterInstall)
+	r.With(clusterInstall, routes.admin, routes.limit(EndpointInstall)).
+		Post("/servers/{serverName}/versions/{version}/install", routes.installServer)
+	r.With(clusterInstall).Get("/installed-servers", routes.listInstalledServers)
+	r.With(clusterInstall, routes.admin).Delete("/installed-servers/{namespace}/{name}", routes.uninstallServer)
+	r.With(routes.feature(features.SBOM), routes.limit(EndpointSBOM)).
+		Get("/servers/{serverName}/versions/{version}/sbom", routes.getServerSBOM)
+	r.With(routes.feature(features.Platforms), routes.limit(EndpointPlatforms)).
+		Get("/servers/{serverName}/versions/{version}/platforms", routes.getServerPlatforms)
+	r.With(routes.feature(features.PackageStats), routes.limit(EndpointPackageStats)).
+		Get("/servers/{serverName}/versions/{version}/package-stats", routes.getServerPackageStats)
+	r.With(routes.feature(features.Changelog), routes.limit(EndpointChangelog)).
+		Get("/servers/{serverName}/versions/{version}/changelog", routes.getServerChangelog)
+	r.With(routes.feature(features.Suggestions), routes.limit(EndpointSuggestions)).Get("/suggestions", routes.getSuggestions)
+	r.Get("/tags", routes.listTags)
+	r.Get("/installations", routes.getInstallations)
+	r.Get("/events", routes.getEvents)
+	r.With(routes.admin).Get("/reports/zero-result-searches", routes.getZeroResultSearches)
+	r.Get("/reports/env-vars", routes.getEnvVarReport)
+	r.Get("/reports/caches", routes.getCacheReport)
+	r.Get("/reports/stale-servers", routes.getStaleServersReport)
+	r.Get("/reports/search-experiment", routes.getSearchExperimentReport)
+	r.Get("/reports/search-feedback", routes.getSearchFeedbackReport)
+	r.Get("/reports/ratings", routes.getRatingsReport)
+
 	return r
 }
 
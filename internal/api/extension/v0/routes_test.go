@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/stacklok/toolhive-registry-server/internal/features"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
 	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
 )
@@ -666,3 +667,81 @@ func TestDeleteRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestConcurrencyLimits(t *testing.T) {
+	t.Parallel()
+
+	routes := NewRoutes(nil)
+	assert.Equal(t, DefaultConcurrencyLimits, routes.concurrencyLimits)
+
+	WithConcurrencyLimits(map[string]int{EndpointSuggestions: 1, EndpointResolve: 2})(routes)
+	assert.Equal(t, map[string]int{EndpointSuggestions: 1, EndpointResolve: 2, EndpointSBOM: 4, EndpointPlatforms: 4,
+		EndpointPackageStats: 4, EndpointChangelog: 4, EndpointValidate: 4}, routes.concurrencyLimits)
+
+	// Overrides do not leak into the defaults shared by other routers
+	assert.Equal(t, 4, DefaultConcurrencyLimits[EndpointSuggestions])
+}
+
+func TestValidateConcurrencyLimits(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateConcurrencyLimits(nil))
+	require.NoError(t, ValidateConcurrencyLimits(map[string]int{EndpointInstall: 1, EndpointSuggestions: 2}))
+	require.ErrorContains(t, ValidateConcurrencyLimits(map[string]int{"find_alternatives": 2}),
+		"concurrency.limits: unknown endpoint 'find_alternatives'")
+
+	// Every endpoint with a default limit can be configured
+	for endpoint := range DefaultConcurrencyLimits {
+		assert.Contains(t, ConcurrencyLimitedEndpoints, endpoint)
+	}
+}
+
+func TestFeatureFlags(t *testing.T) {
+	t.Parallel()
+
+	flags, err := features.New(map[string]bool{
+		features.Suggestions:    false,
+		features.SBOM:           false,
+		features.Platforms:      false,
+		features.PackageStats:   false,
+		features.Changelog:      false,
+		features.OfflineBundle:  false,
+		features.RegistryWrites: false,
+	}, features.WithLookupEnv(func(string) (string, bool) { return "", false }))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+	}{
+		{name: "suggestions", method: http.MethodGet, path: "/suggestions?search=slack"},
+		{name: "sbom", method: http.MethodGet, path: "/servers/com.example%2Fslack/versions/1.0.0/sbom"},
+		{name: "platforms", method: http.MethodGet, path: "/servers/com.example%2Fslack/versions/1.0.0/platforms"},
+		{name: "package stats", method: http.MethodGet, path: "/servers/com.example%2Fslack/versions/1.0.0/package-stats"},
+		{name: "changelog", method: http.MethodGet, path: "/servers/com.example%2Fslack/versions/1.0.0/changelog"},
+		{name: "offline bundle", method: http.MethodGet, path: "/servers/com.example%2Fslack/versions/1.0.0/offline-bundle"},
+		{name: "install", method: http.MethodPost, path: "/servers/com.example%2Fslack/versions/1.0.0/install"},
+		{name: "installed servers", method: http.MethodGet, path: "/installed-servers"},
+		{name: "uninstall", method: http.MethodDelete, path: "/installed-servers/mcp/slack?confirm=true"},
+		{name: "upsert registry", method: http.MethodPut, path: "/registries/managed"},
+		{name: "delete registry", method: http.MethodDelete, path: "/registries/managed"},
+		{name: "upsert version", method: http.MethodPut, path: "/registries/managed/servers/com.example%2Fslack/versions/1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			// No expectations: disabled endpoints never reach the service
+			router := Router(mocks.NewMockRegistryService(ctrl), WithFeatures(flags))
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusNotFound, rr.Code)
+			assert.Contains(t, rr.Body.String(), "is disabled")
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/sbom"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// ImageSBOM is the SBOM summary of a container image of a server, or why it is unavailable
+type ImageSBOM struct {
+	Image string        `json:"image"`
+	SBOM  *sbom.Summary `json:"sbom,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+// SBOMResponse lists the SBOM summaries of the container images of a server version
+type SBOMResponse struct {
+	Server  string      `json:"server"`
+	Version string      `json:"version"`
+	Images  []ImageSBOM `json:"images"`
+}
+
+// getServerSBOM handles GET /extension/v0/servers/{serverName}/versions/{version}/sbom
+//
+// @Summary		Get server SBOM
+// @Description	Get a summary of the software bills of materials (SPDX or CycloneDX) attached to the
+// @Description	container images of a server version through the OCI referrers API: package counts per
+// @Description	type and notable system packages. Images without an attached SBOM are reported with an error.
+// @Description	Summaries are cached by image digest for a freshness window, and report when the SBOM was fetched.
+// @Tags		extension
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		path	string	true	"URL-encoded version (e.g., \"1.0.0\" or \"latest\")"
+// @Success		200	{object}	SBOMResponse	"SBOM summaries"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found or without container images"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/versions/{version}/sbom [get]
+func (r *Routes) getServerSBOM(w http.ResponseWriter, req *http.Request) {
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := common.GetAndValidateURLParam(req, "version")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	server, err := r.service.GetServerVersion(
+		req.Context(),
+		service.WithName[service.GetServerVersionOptions](serverName),
+		service.WithVersion[service.GetServerVersionOptions](version),
+	)
+	if errors.Is(err, service.ErrServerNotFound) || (err == nil && server == nil) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := SBOMResponse{Server: server.Name, Version: server.Version, Images: []ImageSBOM{}}
+	for _, pkg := range server.Packages {
+		if pkg.RegistryType != model.RegistryTypeOCI {
+			continue
+		}
+		image := ImageSBOM{Image: pkg.Identifier}
+		image.SBOM, err = r.sbomFetcher.Fetch(req.Context(), pkg.Identifier)
+		if err != nil {
+			if !errors.Is(err, sbom.ErrNoSBOM) {
+				slog.WarnContext(req.Context(), "Failed to fetch SBOM", "image", pkg.Identifier, "error", err)
+			}
+			image.Error = err.Error()
+		}
+		response.Images = append(response.Images, image)
+	}
+	if len(response.Images) == 0 {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s has no container images", serverName), http.StatusNotFound)
+		return
+	}
+
+	common.WriteJSONResponse(w, response, http.StatusOK)
+}
@@ -0,0 +1,150 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/sbom"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+// newTestOCIRegistry serves example/with-sbom:1.0.0 with a CycloneDX SBOM attached
+// and example/without-sbom:1.0.0 without referrers
+func newTestOCIRegistry(t *testing.T) string {
+	t.Helper()
+	const (
+		imageDigest = "sha256:aaaa"
+		sbomDigest  = "sha256:bbbb"
+		blobDigest  = "sha256:cccc"
+		document    = `{"bomFormat":"CycloneDX","components":[{"name":"openssl","version":"3.1.4","purl":"pkg:apk/alpine/openssl@3.1.4"}]}`
+	)
+	routes := map[string]string{
+		"/v2/example/with-sbom/manifests/1.0.0":          `{}`,
+		"/v2/example/without-sbom/manifests/1.0.0":       `{}`,
+		"/v2/example/with-sbom/referrers/" + imageDigest: `{"manifests":[{"artifactType":"application/vnd.cyclonedx+json","digest":"` + sbomDigest + `"}]}`,
+		"/v2/example/with-sbom/manifests/" + sbomDigest:  `{"layers":[{"mediaType":"application/vnd.cyclonedx+json","digest":"` + blobDigest + `"}]}`,
+		"/v2/example/with-sbom/blobs/" + blobDigest:      document,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", imageDigest)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func TestGetServerSBOM(t *testing.T) {
+	t.Parallel()
+	host := newTestOCIRegistry(t)
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithOCIPackage(host+"/example/with-sbom:1.0.0"),
+	)
+	withoutSBOM := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithOCIPackage(host+"/example/without-sbom:1.0.0"),
+	)
+	remoteOnly := registry.NewTestServer("com.example/test-server",
+		registry.WithServerVersion("1.0.0"),
+		registry.WithHTTPPackage("https://example.com/mcp"),
+	)
+
+	tests := []struct {
+		name         string
+		setupMocks   func(*mocks.MockRegistryService)
+		wantStatus   int
+		wantPackages int
+		wantError    string
+	}{
+		{
+			name: "image with SBOM",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&server, nil)
+			},
+			wantStatus:   http.StatusOK,
+			wantPackages: 1,
+		},
+		{
+			name: "image without SBOM",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&withoutSBOM, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantError:  sbom.ErrNoSBOM.Error(),
+		},
+		{
+			name: "server without container images",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(&remoteOnly, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "server not found",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(nil, service.ErrServerNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "service error",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			router := Router(mockSvc, WithSBOMFetcher(sbom.NewFetcher(sbom.WithPlainHTTP())))
+
+			req, err := http.NewRequest(http.MethodGet, "/servers/com.example%2Ftest-server/versions/1.0.0/sbom", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response SBOMResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			require.Len(t, response.Images, 1)
+			image := response.Images[0]
+			assert.Equal(t, tt.wantError, image.Error)
+			if tt.wantError != "" {
+				assert.Nil(t, image.SBOM)
+				return
+			}
+			require.NotNil(t, image.SBOM)
+			assert.Equal(t, sbom.FormatCycloneDX, image.SBOM.Format)
+			assert.Equal(t, tt.wantPackages, image.SBOM.PackageCount)
+			assert.Equal(t, "openssl", image.SBOM.NotablePackages[0].Name)
+		})
+	}
+}
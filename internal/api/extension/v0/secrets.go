@@ -0,0 +1,26 @@
+package v0
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+// redactSecrets returns a copy of a server safe to embed in generated configs, with anything
+// resembling a credential in its input examples replaced by placeholders.
+// Redactions are logged as an advisory, since they usually mean a publisher leaked a secret.
+func redactSecrets(req *http.Request, server *upstreamv0.ServerJSON) *upstreamv0.ServerJSON {
+	redacted, fields := registry.RedactSecrets(server)
+	if len(fields) > 0 {
+		slog.WarnContext(req.Context(), "Redacted values resembling credentials from registry metadata",
+			"server", server.Name,
+			"version", server.Version,
+			"fields", fields,
+			"request_id", middleware.GetReqID(req.Context()))
+	}
+	return redacted
+}
@@ -0,0 +1,30 @@
+package v0
+
+import (
+	"net/http"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// latestServerVersion gets the latest version of a server. Storage backends neither resolve a "latest"
+// version nor list versions in a common order, so all versions are listed, not only the first page,
+// and compared with registry.LatestVersion.
+// It returns service.ErrServerNotFound if the server has no version.
+func (r *Routes) latestServerVersion(req *http.Request, name string) (*upstreamv0.ServerJSON, error) {
+	versions, err := r.service.ListServerVersions(
+		req.Context(),
+		service.WithName[service.ListServerVersionsOptions](name),
+		service.WithAllVersions(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	latest := registry.LatestVersion(versions)
+	if latest == nil {
+		return nil, service.ErrServerNotFound
+	}
+	return latest, nil
+}
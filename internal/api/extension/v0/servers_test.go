@@ -0,0 +1,31 @@
+package v0
+
+import (
+	"context"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+// expectServerVersions expects the versions of the named server to be listed once
+func expectServerVersions(
+	t *testing.T, m *mocks.MockRegistryService, name string, versions []*upstreamv0.ServerJSON, err error,
+) {
+	t.Helper()
+	m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, opts ...service.Option[service.ListServerVersionsOptions]) ([]*upstreamv0.ServerJSON, error) {
+			options := &service.ListServerVersionsOptions{}
+			for _, opt := range opts {
+				require.NoError(t, opt(options))
+			}
+			assert.Equal(t, name, options.Name)
+			assert.True(t, options.All, "the latest version is resolved among all versions")
+			return versions, err
+		})
+}
@@ -0,0 +1,84 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/experiment"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/setupguide"
+)
+
+// getSetupGuide handles GET /extension/v0/servers/{serverName}/versions/{version}/setup-guide
+//
+// @Summary		Get binary setup guide
+// @Description	Get the platform-specific instructions setting up the binary packages of a server version (registry
+// @Description	type binary or github-release): downloading the binary, verifying its SHA-256 hash, making it executable
+// @Description	and adding it to the PATH. Packages of other registry types are left out.
+// @Description	When an approval system is configured, servers it has not approved are answered with 403.
+// @Tags		extension
+// @Produce		json
+// @Param		serverName	path	string	true	"URL-encoded server name (e.g., \"com.example%2Fmy-server\")"
+// @Param		version		path	string	true	"URL-encoded version (e.g., \"1.0.0\" or \"latest\")"
+// @Param		platform	query	[]string	false	"Platform to write instructions for, as <os>/<arch> (default linux, darwin and windows on amd64 and arm64)"	collectionFormat(multi)
+// @Success		200	{object}	setupguide.Guide	"Setup guide"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		403	{object}	ApprovalRequiredResponse	"Server requires approval"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Failure		503	{object}	map[string]string	"Approval check failed"
+// @Security	BearerAuth
+// @Router		/extension/v0/servers/{serverName}/versions/{version}/setup-guide [get]
+func (r *Routes) getSetupGuide(w http.ResponseWriter, req *http.Request) {
+	serverName, err := common.GetAndValidateServerNameParam(req, "serverName")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := common.GetAndValidateURLParam(req, "version")
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	platforms := req.URL.Query()["platform"]
+	for _, platform := range platforms {
+		if err := setupguide.ValidatePlatform(platform); err != nil {
+			common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	server, err := r.service.GetServerVersion(
+		req.Context(),
+		service.WithName[service.GetServerVersionOptions](serverName),
+		service.WithVersion[service.GetServerVersionOptions](version),
+	)
+	if errors.Is(err, service.ErrServerNotFound) || (err == nil && server == nil) {
+		common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", serverName), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !r.checkApproval(w, req, server, EndpointSetupGuide) {
+		return
+	}
+
+	guide, err := setupguide.New(server, platforms)
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(guide.Packages) > 0 {
+		experiment.RecordOutcome(req.Context(), experiment.OutcomeInstall, server.Name)
+	}
+
+	common.WriteJSONResponse(w, guide, http.StatusOK)
+}
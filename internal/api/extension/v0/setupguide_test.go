@@ -0,0 +1,100 @@
+package v0
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+	"github.com/stacklok/toolhive-registry-server/internal/setupguide"
+)
+
+func TestGetSetupGuide(t *testing.T) {
+	t.Parallel()
+
+	server := &upstreamv0.ServerJSON{
+		Name:    "com.example/test-server",
+		Version: "1.0.0",
+		Packages: []model.Package{
+			{RegistryType: setupguide.RegistryTypeBinary, Identifier: "https://example.com/server-{os}-{arch}"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		setupMocks    func(*mocks.MockRegistryService)
+		wantStatus    int
+		wantPlatforms []string
+	}{
+		{
+			name: "default platforms",
+			path: "/servers/com.example%2Ftest-server/versions/1.0.0/setup-guide",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(server, nil)
+			},
+			wantStatus:    http.StatusOK,
+			wantPlatforms: setupguide.DefaultPlatforms,
+		},
+		{
+			name: "requested platforms",
+			path: "/servers/com.example%2Ftest-server/versions/latest/setup-guide?platform=linux/arm64&platform=windows/arm64",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(server, nil)
+			},
+			wantStatus:    http.StatusOK,
+			wantPlatforms: []string{"linux/arm64", "windows/arm64"},
+		},
+		{
+			name:       "invalid platform",
+			path:       "/servers/com.example%2Ftest-server/versions/1.0.0/setup-guide?platform=amd64",
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "server not found",
+			path: "/servers/com.example%2Fmissing/versions/1.0.0/setup-guide",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(nil, service.ErrServerNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			router := Router(mockSvc)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var guide setupguide.Guide
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &guide))
+			require.Len(t, guide.Packages, 1)
+			platforms := make([]string, 0, len(guide.Packages[0].Platforms))
+			for _, platform := range guide.Packages[0].Platforms {
+				platforms = append(platforms, platform.Platform)
+			}
+			assert.Equal(t, tt.wantPlatforms, platforms)
+		})
+	}
+}
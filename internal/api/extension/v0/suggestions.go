@@ -0,0 +1,76 @@
+package v0
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+const (
+	// defaultSuggestionLimit is the number of suggestions returned when no limit is given
+	defaultSuggestionLimit = 5
+	// maxSuggestionLimit caps the number of suggestions a client can request
+	maxSuggestionLimit = 20
+	// suggestionCandidateLimit caps the number of servers considered for suggestions
+	suggestionCandidateLimit = 1000
+)
+
+// SuggestionsResponse lists known terms close to a search query
+type SuggestionsResponse struct {
+	Search      string                `json:"search"`
+	Suggestions []registry.Suggestion `json:"suggestions"`
+}
+
+// getSuggestions handles GET /extension/v0/suggestions
+//
+// @Summary		Get search suggestions
+// @Description	Get "did you mean" suggestions for a search query, typically one that returned no servers.
+// @Description	Suggestions are server names and tags within a small edit distance of the query, closest first.
+// @Tags		extension
+// @Produce		json
+// @Param		search	query	string	true	"Search query to find suggestions for"
+// @Param		limit	query	int		false	"Maximum number of suggestions to return (default 5, max 20)"
+// @Success		200	{object}	SuggestionsResponse	"Suggestions"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/suggestions [get]
+func (r *Routes) getSuggestions(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	search := query.Get("search")
+	if search == "" {
+		common.WriteErrorResponse(w, "search parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultSuggestionLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limitVal, err := strconv.Atoi(limitStr)
+		if err != nil || limitVal <= 0 {
+			common.WriteErrorResponse(w, "Invalid limit parameter: must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = min(limitVal, maxSuggestionLimit)
+	}
+
+	servers, err := r.service.ListServers(
+		req.Context(),
+		service.WithLimit[service.ListServersOptions](suggestionCandidateLimit),
+	)
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := SuggestionsResponse{
+		Search:      search,
+		Suggestions: registry.Suggest(search, servers, limit),
+	}
+
+	common.WriteJSONResponse(w, response, http.StatusOK)
+}
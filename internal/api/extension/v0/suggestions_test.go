@@ -0,0 +1,100 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestGetSuggestions(t *testing.T) {
+	t.Parallel()
+
+	slack := registry.NewTestServer("com.example/slack", registry.WithTags("chat", "messaging"))
+	servers := []*upstreamv0.ServerJSON{&slack}
+
+	tests := []struct {
+		name            string
+		path            string
+		setupMocks      func(*mocks.MockRegistryService)
+		wantStatus      int
+		wantSuggestions []registry.Suggestion
+	}{
+		{
+			name: "misspelled query",
+			path: "/suggestions?search=slakc",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(servers, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantSuggestions: []registry.Suggestion{
+				{Value: "com.example/slack", Kind: registry.SuggestionKindName, Distance: 1},
+			},
+		},
+		{
+			name: "no close terms",
+			path: "/suggestions?search=kubernetes&limit=50",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(servers, nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantSuggestions: []registry.Suggestion{},
+		},
+		{
+			name:       "missing search",
+			path:       "/suggestions",
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid limit",
+			path:       "/suggestions?search=slakc&limit=0",
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			path: "/suggestions?search=slakc",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			router := Router(mockSvc)
+
+			req, err := http.NewRequest(http.MethodGet, tt.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var response SuggestionsResponse
+				err = json.Unmarshal(rr.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantSuggestions, response.Suggestions)
+			}
+		})
+	}
+}
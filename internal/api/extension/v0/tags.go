@@ -0,0 +1,44 @@
+package v0
+
+import (
+	"net/http"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// tagCandidateLimit caps the number of servers whose tags are counted
+const tagCandidateLimit = 1000
+
+// TagsResponse lists the tags of the catalog
+type TagsResponse struct {
+	// Tags are the tags in use with the number of servers carrying each, most used first
+	Tags []registry.TagCount `json:"tags"`
+}
+
+// listTags handles GET /extension/v0/tags
+//
+// @Summary		List tags
+// @Description	List the tags used in the catalog with the number of servers carrying each, most used first,
+// @Description	to discover the tag values to search servers with. Servers are counted once whatever the
+// @Description	number of their versions.
+// @Tags		extension
+// @Produce		json
+// @Success		200	{object}	TagsResponse	"Tags"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/tags [get]
+func (r *Routes) listTags(w http.ResponseWriter, req *http.Request) {
+	servers, err := r.service.ListServers(
+		req.Context(),
+		service.WithLimit[service.ListServersOptions](tagCandidateLimit),
+	)
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	common.WriteJSONResponse(w, TagsResponse{Tags: registry.CountTags(servers)}, http.StatusOK)
+}
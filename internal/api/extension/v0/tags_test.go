@@ -0,0 +1,82 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestListTags(t *testing.T) {
+	t.Parallel()
+
+	slack := registry.NewTestServer("com.example/slack", registry.WithTags("chat", "messaging"))
+	discord := registry.NewTestServer("com.example/discord", registry.WithTags("chat"))
+	servers := []*upstreamv0.ServerJSON{&slack, &discord}
+
+	tests := []struct {
+		name       string
+		setupMocks func(*mocks.MockRegistryService)
+		wantStatus int
+		wantTags   []registry.TagCount
+	}{
+		{
+			name: "tags counted",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(servers, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantTags:   []registry.TagCount{{Tag: "chat", Count: 2}, {Tag: "messaging", Count: 1}},
+		},
+		{
+			name: "empty catalog",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantTags:   []registry.TagCount{},
+		},
+		{
+			name: "service error",
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			router := Router(mockSvc)
+
+			req, err := http.NewRequest(http.MethodGet, "/tags", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var response TagsResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tt.wantTags, response.Tags)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package v0
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// maxValidateRequestSize caps the size of validation request bodies
+const maxValidateRequestSize = 1024 * 1024
+
+// ValidateServerRequestBody names the registry entry to validate, or holds it
+type ValidateServerRequestBody struct {
+	// Name is the name of a server of the registry to validate, with Version (latest by default)
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	// Entry is a server entry to validate before publishing it
+	Entry json.RawMessage `json:"entry,omitempty" swaggertype:"object"`
+}
+
+// ValidateServerResponse lists the problems found in a registry entry
+type ValidateServerResponse struct {
+	// Valid is false when an issue is an error
+	Valid    bool                 `json:"valid"`
+	Errors   int                  `json:"errors"`
+	Warnings int                  `json:"warnings"`
+	Issues   []registry.LintIssue `json:"issues"`
+	// LinksChecked is true when the links of the entry were requested to find broken ones.
+	// Only the links of entries of the registry, given by name, are checked.
+	LinksChecked bool `json:"linksChecked"`
+}
+
+// validateServer handles POST /extension/v0/validate
+//
+// @Summary		Validate server entry
+// @Description	Lint a registry entry, given by name or as the JSON entry itself, before publishing it: missing name,
+// @Description	version or description, no tags, malformed repository or remote URLs, no package or remote endpoint,
+// @Description	and ToolHive metadata of the wrong shape. When link checks are enabled, the repository and website
+// @Description	URLs and the npm and PyPI packages of entries given by name are also requested to find broken ones.
+// @Description	The links of pasted entries are never requested.
+// @Tags		extension
+// @Accept		json
+// @Produce		json
+// @Param		entry	body	ValidateServerRequestBody	true	"Entry to validate"
+// @Success		200	{object}	ValidateServerResponse	"Issues found"
+// @Failure		400	{object}	map[string]string	"Bad request"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Server not found"
+// @Failure		500	{object}	map[string]string	"Internal server error"
+// @Security	BearerAuth
+// @Router		/extension/v0/validate [post]
+func (r *Routes) validateServer(w http.ResponseWriter, req *http.Request) {
+	var body ValidateServerRequestBody
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxValidateRequestSize)).Decode(&body); err != nil {
+		common.WriteErrorResponse(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if (body.Name == "") == (len(body.Entry) == 0) {
+		common.WriteErrorResponse(w, "Exactly one of name or entry is required", http.StatusBadRequest)
+		return
+	}
+
+	var server *upstreamv0.ServerJSON
+	if body.Name != "" {
+		var err error
+		server, err = r.getServerReference(req, body.Name, body.Version)
+		if errors.Is(err, service.ErrServerNotFound) {
+			common.WriteErrorResponse(w, fmt.Sprintf("Server %s not found", body.Name), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	} else if err := json.Unmarshal(body.Entry, &server); err != nil || server == nil {
+		// Entries that cannot be read are reported as such rather than rejected
+		message := "entry is not a server entry"
+		if err != nil {
+			message += ": " + err.Error()
+		}
+		common.WriteJSONResponse(w, ValidateServerResponse{
+			Errors: 1,
+			Issues: []registry.LintIssue{{Severity: registry.LintError, Message: message}},
+		}, http.StatusOK)
+		return
+	}
+
+	// The links of pasted entries are not requested: the server would otherwise send requests to
+	// URLs chosen by the caller, probing the internal network on their behalf
+	response := ValidateServerResponse{Issues: registry.Lint(server)}
+	if r.linkChecker != nil && body.Name != "" {
+		for _, problem := range r.linkChecker.Problems(req.Context(), server) {
+			response.Issues = append(response.Issues, registry.LintIssue{Severity: registry.LintError, Message: problem})
+		}
+		response.LinksChecked = true
+		slices.SortStableFunc(response.Issues, func(a, b registry.LintIssue) int {
+			return cmp.Compare(severityRank(a.Severity), severityRank(b.Severity))
+		})
+	}
+	for _, issue := range response.Issues {
+		if issue.Severity == registry.LintError {
+			response.Errors++
+		} else {
+			response.Warnings++
+		}
+	}
+	response.Valid = response.Errors == 0
+
+	common.WriteJSONResponse(w, response, http.StatusOK)
+}
+
+// severityRank orders errors before warnings
+func severityRank(severity string) int {
+	if severity == registry.LintError {
+		return 0
+	}
+	return 1
+}
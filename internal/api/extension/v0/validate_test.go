@@ -0,0 +1,174 @@
+package v0
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/linkcheck"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestValidateServer(t *testing.T) {
+	t.Parallel()
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithDescription("Test server"),
+		registry.WithOCIPackage("ghcr.io/example/test-server:1.0.0"),
+	)
+
+	tests := []struct {
+		name         string
+		body         string
+		setupMocks   func(*mocks.MockRegistryService)
+		wantStatus   int
+		wantValid    bool
+		wantErrors   int
+		wantWarnings int
+	}{
+		{
+			name: "server by name",
+			body: `{"name":"com.example/test-server"}`,
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).
+					Return([]*upstreamv0.ServerJSON{&server}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantValid:  true,
+			// No repository URL and no tags
+			wantWarnings: 2,
+		},
+		{
+			name:       "pasted entry",
+			body:       `{"entry":{"name":"com.example/draft","version":"0.1.0"}}`,
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusOK,
+			// No description and no package
+			wantErrors:   2,
+			wantWarnings: 2,
+		},
+		{
+			name:       "unreadable entry",
+			body:       `{"entry":{"name":42}}`,
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusOK,
+			wantErrors: 1,
+		},
+		{
+			name:       "neither name nor entry",
+			body:       `{}`,
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "server not found",
+			body: `{"name":"com.example/missing","version":"1.0.0"}`,
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().GetServerVersion(gomock.Any(), gomock.Any()).Return(nil, service.ErrServerNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			router := Router(mockSvc)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(tt.body)))
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response ValidateServerResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantValid, response.Valid)
+			assert.Equal(t, tt.wantErrors, response.Errors)
+			assert.Equal(t, tt.wantWarnings, response.Warnings)
+			assert.Len(t, response.Issues, tt.wantErrors+tt.wantWarnings)
+			assert.False(t, response.LinksChecked)
+		})
+	}
+}
+
+func TestValidateServerLinkChecks(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	website := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(website.Close)
+
+	server := registry.NewTestServer("com.example/test-server",
+		registry.WithDescription("Test server"),
+		registry.WithOCIPackage("ghcr.io/example/test-server:1.0.0"),
+	)
+	server.WebsiteURL = website.URL + "/missing"
+	entry, err := json.Marshal(server)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		body             string
+		setupMocks       func(*mocks.MockRegistryService)
+		wantLinksChecked bool
+		wantRequests     int32
+	}{
+		{
+			name: "server by name",
+			body: `{"name":"com.example/test-server"}`,
+			setupMocks: func(m *mocks.MockRegistryService) {
+				m.EXPECT().ListServerVersions(gomock.Any(), gomock.Any()).
+					Return([]*upstreamv0.ServerJSON{&server}, nil)
+			},
+			wantLinksChecked: true,
+			wantRequests:     1,
+		},
+		{
+			name:       "pasted entry",
+			body:       `{"entry":` + string(entry) + `}`,
+			setupMocks: func(_ *mocks.MockRegistryService) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests.Store(0)
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockSvc := mocks.NewMockRegistryService(ctrl)
+			tt.setupMocks(mockSvc)
+			router := Router(mockSvc, WithLinkChecker(linkcheck.NewChecker()))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(tt.body)))
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var response ValidateServerResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantLinksChecked, response.LinksChecked)
+			assert.Equal(t, tt.wantRequests, requests.Load())
+		})
+	}
+}
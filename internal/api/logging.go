@@ -0,0 +1,102 @@
+package api
+
+import (
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+)
+
+// SlowRequestThresholds are the durations above which requests are logged as slow
+type SlowRequestThresholds struct {
+	// Default applies to the routes without a threshold of their own. Zero turns warnings off.
+	Default time.Duration
+	// Routes are the thresholds by route pattern
+	// (e.g., "/extension/v0/servers/{serverName}/versions/{version}/sbom")
+	Routes map[string]time.Duration
+}
+
+// threshold returns the slow request threshold of a route pattern, zero if slow requests are not reported
+func (s SlowRequestThresholds) threshold(route string) time.Duration {
+	if threshold, ok := s.Routes[route]; ok {
+		return threshold
+	}
+	return s.Default
+}
+
+// LoggingMiddleware logs HTTP requests, without reporting slow requests
+func LoggingMiddleware(next http.Handler) http.Handler {
+	return NewLoggingMiddleware(SlowRequestThresholds{})(next)
+}
+
+// NewLoggingMiddleware returns a middleware logging HTTP requests, and warning about the requests
+// slower than their threshold with the time they spent waiting for upstream services
+func NewLoggingMiddleware(slow SlowRequestThresholds) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ctx, upstream := httpclient.WithUpstreamTimings(r.Context())
+			r = r.WithContext(ctx)
+
+			next.ServeHTTP(ww, r)
+
+			// Determine log level based on status code
+			status := ww.Status()
+			duration := time.Since(start)
+			logLevel := slog.LevelInfo
+			if status >= 500 {
+				logLevel = slog.LevelError
+			} else if status >= 400 {
+				logLevel = slog.LevelWarn
+			}
+
+			slog.Log(r.Context(), logLevel, "HTTP request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", status,
+				"bytes", ww.BytesWritten(),
+				"duration_ms", duration.Milliseconds(),
+				"request_id", middleware.GetReqID(r.Context()),
+				"remote_addr", r.RemoteAddr,
+			)
+
+			// The route pattern is only known once the request has been routed
+			var route string
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				route = rctx.RoutePattern()
+			}
+			threshold := slow.threshold(route)
+			if threshold <= 0 || duration < threshold {
+				return
+			}
+			slog.Warn("Slow HTTP request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"route", route,
+				"status", status,
+				"duration_ms", duration.Milliseconds(),
+				"threshold_ms", threshold.Milliseconds(),
+				"upstream_ms", upstream.Total().Milliseconds(),
+				upstreamBreakdown(upstream),
+				"request_id", middleware.GetReqID(r.Context()),
+			)
+		})
+	}
+}
+
+// upstreamBreakdown returns the time spent waiting for each upstream host, in milliseconds
+func upstreamBreakdown(upstream *httpclient.UpstreamTimings) slog.Attr {
+	byHost := upstream.ByHost()
+	attrs := make([]any, 0, len(byHost))
+	for _, host := range slices.Sorted(maps.Keys(byHost)) {
+		attrs = append(attrs, slog.Int64(host, byHost[host].Milliseconds()))
+	}
+	return slog.Group("upstream", attrs...)
+}
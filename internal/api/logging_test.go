@@ -0,0 +1,87 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api"
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+)
+
+//nolint:paralleltest // replaces the default logger
+func TestLoggingMiddlewareSlowRequests(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(upstream.Close)
+	client := &http.Client{Transport: httpclient.NewTimingTransport(nil)}
+
+	r := chi.NewRouter()
+	r.Use(api.NewLoggingMiddleware(api.SlowRequestThresholds{
+		Default: 10 * time.Millisecond,
+		Routes:  map[string]time.Duration{"/fast/{name}": 0},
+	}))
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		upstreamReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, upstream.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(upstreamReq)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		_, _ = w.Write([]byte("done"))
+	}
+	r.Get("/slow/{name}", handler)
+	r.Get("/fast/{name}", handler)
+
+	tests := []struct {
+		path     string
+		wantSlow bool
+	}{
+		{path: "/slow/example", wantSlow: true},
+		{path: "/fast/example", wantSlow: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			var logs bytes.Buffer
+			previous := slog.Default()
+			slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+			t.Cleanup(func() { slog.SetDefault(previous) })
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			entries := map[string]map[string]any{}
+			for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+				var entry map[string]any
+				require.NoError(t, json.Unmarshal([]byte(line), &entry))
+				entries[entry["msg"].(string)] = entry
+			}
+
+			require.Contains(t, entries, "HTTP request")
+			assert.EqualValues(t, 4, entries["HTTP request"]["bytes"])
+
+			if !tt.wantSlow {
+				assert.NotContains(t, entries, "Slow HTTP request")
+				return
+			}
+			require.Contains(t, entries, "Slow HTTP request")
+			slow := entries["Slow HTTP request"]
+			assert.Equal(t, "/slow/{name}", slow["route"])
+			assert.EqualValues(t, 10, slow["threshold_ms"])
+			assert.GreaterOrEqual(t, slow["upstream_ms"], float64(30))
+			assert.Contains(t, slow["upstream"], strings.TrimPrefix(upstream.URL, "http://"))
+		})
+	}
+}
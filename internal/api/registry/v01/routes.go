@@ -2,10 +2,13 @@
 package v01
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -13,14 +16,86 @@ import (
 	"github.com/go-chi/chi/v5"
 	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 
+	"github.com/stacklok/toolhive-registry-server/internal/annotations"
 	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/experiment"
+	"github.com/stacklok/toolhive-registry-server/internal/features"
+	"github.com/stacklok/toolhive-registry-server/internal/linkcheck"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/reviews"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/summary"
 	"github.com/stacklok/toolhive-registry-server/internal/validators"
 )
 
 // Routes handles HTTP requests for registry API v0.1 endpoints.
 type Routes struct {
-	service service.RegistryService
+	service     service.RegistryService
+	metaFilter  registry.MetaFilter
+	features    *features.Flags
+	linkChecker *linkcheck.Checker
+	summaries   *summary.Summaries
+	annotations *annotations.Store
+	reviews     *reviews.Store
+	// sanitizeRules are the sanitization rules of the published servers by registry name
+	sanitizeRules map[string][]string
+}
+
+// RouterOption configures the registry API v0.1 router
+type RouterOption func(*Routes)
+
+// WithMetaFilter sets the filter selecting the publisher-provided metadata namespaces
+// returned in server responses
+func WithMetaFilter(filter registry.MetaFilter) RouterOption {
+	return func(r *Routes) {
+		r.metaFilter = filter
+	}
+}
+
+// WithFeatures sets the feature flags gating the publish and delete endpoints.
+// Without flags, all features are in their default state.
+func WithFeatures(flags *features.Flags) RouterOption {
+	return func(r *Routes) {
+		r.features = flags
+	}
+}
+
+// WithLinkChecker sets the link checker whose results are recorded in server responses and
+// filtered on with the health parameter. Without a checker, the health parameter is rejected.
+func WithLinkChecker(checker *linkcheck.Checker) RouterOption {
+	return func(r *Routes) {
+		r.linkChecker = checker
+	}
+}
+
+// WithSummaries sets the summaries of long descriptions recorded in server metadata
+func WithSummaries(summaries *summary.Summaries) RouterOption {
+	return func(r *Routes) {
+		r.summaries = summaries
+	}
+}
+
+// WithAnnotations sets the store whose annotations are recorded in server responses and filtered on
+// with the approval_status and owner_team parameters. Without a store, these parameters are rejected.
+func WithAnnotations(store *annotations.Store) RouterOption {
+	return func(r *Routes) {
+		r.annotations = store
+	}
+}
+
+// WithReviews sets the store whose ratings are recorded in server responses
+func WithReviews(store *reviews.Store) RouterOption {
+	return func(r *Routes) {
+		r.reviews = store
+	}
+}
+
+// WithSanitizeRules sets the sanitization rules applied to the titles and descriptions of the servers
+// published to each managed registry, by registry name, see registry.SanitizeServer
+func WithSanitizeRules(rules map[string][]string) RouterOption {
+	return func(r *Routes) {
+		r.sanitizeRules = rules
+	}
 }
 
 // NewRoutes creates a new Routes instance with the given service.
@@ -31,8 +106,11 @@ func NewRoutes(svc service.RegistryService) *Routes {
 }
 
 // Router creates and configures the HTTP router for registry API v0.1 endpoints.
-func Router(svc service.RegistryService) http.Handler {
+func Router(svc service.RegistryService, opts ...RouterOption) http.Handler {
 	routes := NewRoutes(svc)
+	for _, opt := range opts {
+		opt(routes)
+	}
 
 	r := chi.NewRouter()
 
@@ -41,80 +119,62 @@ func Router(svc service.RegistryService) http.Handler {
 		r.Get("/versions", routes.listVersions)
 		r.Get("/versions/{version}", routes.getVersion)
 	})
-	r.Post("/v0.1/publish", routes.publish)
+	writes := common.FeatureGate(routes.features, features.RegistryWrites)
+	r.With(writes).Post("/v0.1/publish", routes.publish)
 
 	r.Get("/{registryName}/v0.1/servers", routes.listServersWithRegistryName)
 	r.Route("/{registryName}/v0.1/servers/{serverName}", func(r chi.Router) {
 		r.Get("/versions", routes.listVersionsWithRegistryName)
 		r.Get("/versions/{version}", routes.getVersionWithRegistryName)
-		r.Delete("/versions/{version}", routes.deleteVersionWithRegistryName)
+		r.With(writes).Delete("/versions/{version}", routes.deleteVersionWithRegistryName)
 	})
-	r.Post("/{registryName}/v0.1/publish", routes.publishWithRegistryName)
+	r.With(writes).Post("/{registryName}/v0.1/publish", routes.publishWithRegistryName)
 
 	return r
 }
 
+// serverResponse wraps a server in a response, reporting the timestamps and lifecycle status kept by the
+// registry in its official metadata, keeping the metadata namespaces selected by the meta filter and
+// recording the health of the server found by the link checker, the summary of its description, its
+// annotation and its rating, if any
+// isLatest is only known, and set, when the response is built from the list of all versions of the server.
+func (routes *Routes) serverResponse(
+	ctx context.Context,
+	server *upstreamv0.ServerJSON,
+	isLatest bool,
+) upstreamv0.ServerResponse {
+	result := registry.ToServerResponse(*server, isLatest)
+	response := routes.metaFilter.Apply(result.Server)
+	if routes.linkChecker != nil {
+		response = routes.linkChecker.Annotate(response)
+	}
+	if routes.annotations != nil {
+		response = routes.annotations.Annotate(response)
+	}
+	if routes.reviews != nil {
+		response = routes.reviews.Annotate(response)
+	}
+	if routes.summaries != nil {
+		response = routes.summaries.Annotate(ctx, response)
+	}
+	result.Server = response
+	return result
+}
+
 // handleListServers is a shared helper that handles listing servers with an optional registry name.
 func (routes *Routes) handleListServers(w http.ResponseWriter, r *http.Request, registryName string) {
-	// Parse query parameters
-	query := r.URL.Query()
-
-	// Parse cursor (optional string)
-	cursor := query.Get("cursor")
-
-	// Parse limit (optional integer)
-	var limit *int
-	if limitStr := query.Get("limit"); limitStr != "" {
-		limitVal, err := strconv.Atoi(limitStr)
-		if err != nil {
-			common.WriteErrorResponse(w, "Invalid limit parameter: must be an integer", http.StatusBadRequest)
-			return
-		}
-		limit = &limitVal
-	}
-
-	// Parse search (optional string)
-	search := query.Get("search")
-
-	// Parse updated_since (optional RFC3339 datetime)
-	var updatedSince *time.Time
-	if updatedSinceStr := query.Get("updated_since"); updatedSinceStr != "" {
-		parsedTime, err := time.Parse(time.RFC3339, updatedSinceStr)
-		if err != nil {
-			common.WriteErrorResponse(
-				w,
-				"Invalid updated_since parameter: must be RFC3339 format (e.g., 2025-08-07T13:15:04.280Z)",
-				http.StatusBadRequest,
-			)
-			return
-		}
-		updatedSince = &parsedTime
-	}
-
-	// Parse version (optional string)
-	version := query.Get("version")
-
-	opts := []service.Option[service.ListServersOptions]{}
-	if cursor != "" {
-		opts = append(opts, service.WithCursor(cursor))
-	}
-	if limit != nil {
-		opts = append(opts, service.WithLimit[service.ListServersOptions](*limit))
-	}
-	if search != "" {
-		opts = append(opts, service.WithSearch(search))
-	}
-	if updatedSince != nil {
-		opts = append(opts, service.WithUpdatedSince(*updatedSince))
-	}
-	if version != "" {
-		opts = append(opts, service.WithVersion[service.ListServersOptions](version))
-	}
-	if registryName != "" {
-		opts = append(opts, service.WithRegistryName[service.ListServersOptions](registryName))
+	query, ok := routes.parseListServersQuery(w, r)
+	if !ok {
+		return
 	}
 
+	var nextCursor string
+	opts := append(routes.listServersOptions(query, registryName), service.WithNextCursor(&nextCursor))
 	servers, err := routes.service.ListServers(r.Context(), opts...)
+	if errors.Is(err, service.ErrTooManyServers) {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -122,21 +182,120 @@ func (routes *Routes) handleListServers(w http.ResponseWriter, r *http.Request,
 
 	serverResponses := make([]upstreamv0.ServerResponse, len(servers))
 	for i, server := range servers {
-		serverResponses[i] = upstreamv0.ServerResponse{
-			Server: *server,
-			Meta:   upstreamv0.ResponseMeta{},
-		}
+		serverResponses[i] = routes.serverResponse(r.Context(), server, false)
+	}
+
+	if !query.table.IsJSON() {
+		writeServerTable(w, query.table, serverResponses)
+		return
 	}
 
 	result := upstreamv0.ServerListResponse{
 		Servers: serverResponses,
 		Metadata: upstreamv0.Metadata{
-			NextCursor: "",
+			NextCursor: nextCursor,
 			Count:      len(servers),
 		},
 	}
 
-	common.WriteJSONResponse(w, result, http.StatusOK)
+	common.WriteJSONResponseWithTimings(w, r, result, http.StatusOK)
+}
+
+// serverColumnNames are the columns of server lists rendered as tables
+var serverColumnNames = []string{
+	"name", "title", "version", "description", "tags", "tier", "status", "stars",
+	"repository", "website", "packages", "remotes",
+}
+
+// defaultServerColumns are the columns of server lists rendered as tables when none are selected
+var defaultServerColumns = []string{"name", "version", "description", "tags"}
+
+// serverColumns return the value of each column of server lists rendered as tables. Lists are joined
+// with commas.
+var serverColumns = map[string]func(*upstreamv0.ServerJSON) string{
+	"name":        func(server *upstreamv0.ServerJSON) string { return server.Name },
+	"title":       func(server *upstreamv0.ServerJSON) string { return server.Title },
+	"version":     func(server *upstreamv0.ServerJSON) string { return server.Version },
+	"description": func(server *upstreamv0.ServerJSON) string { return server.Description },
+	"tags":        func(server *upstreamv0.ServerJSON) string { return strings.Join(registry.ExtractTags(server), ", ") },
+	"tier":        registry.ExtractTier,
+	"status":      registry.ExtractStatus,
+	"stars": func(server *upstreamv0.ServerJSON) string {
+		if stars, ok := registry.ExtractStars(server); ok {
+			return strconv.Itoa(stars)
+		}
+		return ""
+	},
+	"repository": func(server *upstreamv0.ServerJSON) string {
+		if server.Repository == nil {
+			return ""
+		}
+		return server.Repository.URL
+	},
+	"website": func(server *upstreamv0.ServerJSON) string { return server.WebsiteURL },
+	"packages": func(server *upstreamv0.ServerJSON) string {
+		identifiers := make([]string, 0, len(server.Packages))
+		for _, pkg := range server.Packages {
+			identifiers = append(identifiers, pkg.Identifier)
+		}
+		return strings.Join(identifiers, ", ")
+	},
+	"remotes": func(server *upstreamv0.ServerJSON) string {
+		urls := make([]string, 0, len(server.Remotes))
+		for _, remote := range server.Remotes {
+			urls = append(urls, remote.URL)
+		}
+		return strings.Join(urls, ", ")
+	},
+}
+
+// writeServerTable writes a server list as a table with the selected columns
+func writeServerTable(w http.ResponseWriter, table common.Table, servers []upstreamv0.ServerResponse) {
+	rows := make([][]string, len(servers))
+	for i := range servers {
+		for _, column := range table.Columns {
+			rows[i] = append(rows[i], serverColumns[column](&servers[i].Server))
+		}
+	}
+	common.WriteTable(w, table, rows, http.StatusOK)
+}
+
+// parseTimeParam parses an optional RFC3339 datetime query parameter.
+// It writes a bad request response and returns false if the value is malformed.
+func parseTimeParam(w http.ResponseWriter, query url.Values, name string) (*time.Time, bool) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, true
+	}
+	parsedTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		common.WriteErrorResponse(
+			w,
+			fmt.Sprintf("Invalid %s parameter: must be RFC3339 format (e.g., 2025-08-07T13:15:04.280Z)", name),
+			http.StatusBadRequest,
+		)
+		return nil, false
+	}
+	return &parsedTime, true
+}
+
+// parseBoolParam parses an optional boolean query parameter, defaulting to false.
+// It writes a bad request response and returns false as second value if the value is malformed.
+func parseBoolParam(w http.ResponseWriter, query url.Values, name string) (bool, bool) {
+	value := query.Get(name)
+	if value == "" {
+		return false, true
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		common.WriteErrorResponse(
+			w,
+			fmt.Sprintf("Invalid %s parameter: must be a boolean", name),
+			http.StatusBadRequest,
+		)
+		return false, false
+	}
+	return parsed, true
 }
 
 // listServers handles GET /registry/v0.1/servers
@@ -146,11 +305,26 @@ func (routes *Routes) handleListServers(w http.ResponseWriter, r *http.Request,
 // @Tags		registry,official
 // @Accept		json
 // @Produce		json
+// @Produce		text/markdown
+// @Produce		text/csv
 // @Param		cursor			query	string	false	"Pagination cursor for retrieving next set of results"
 // @Param		limit			query	int		false	"Maximum number of items to return"
 // @Param		search			query	string	false	"Search servers by name (substring match)"
 // @Param		updated_since	query	time	false	"Filter servers updated since timestamp (RFC3339 datetime)"
+// @Param		updated_after	query	time	false	"Alias of updated_since"
+// @Param		published_before	query	time	false	"Filter servers published before timestamp (RFC3339 datetime)"
+// @Param		sort			query	string	false	"Sort by name, published_at, or updated_at (prefix with '-' for descending order)"
+// @Param		include_deprecated	query	bool	false	"Include servers marked as deprecated (default false)"
+// @Param		include_deleted	query	bool	false	"Include servers marked as deleted (default false)"
+// @Param		max_injection_risk	query	int	false	"Exclude servers whose prompt-injection risk score (0-100) exceeds the value"
 // @Param		version			query	string	false	"Filter by version ('latest' for latest version, or an exact version like '1.2.3')"
+// @Param		dedupe			query	bool	false	"Merge servers found in several registries with the same repository (default false)"
+// @Param		health			query	string	false	"Filter by link check health: ok, or stale (broken links or archived repository)"
+// @Param		approval_status	query	string	false	"Filter by annotated approval status: approved, pending, or rejected"
+// @Param		owner_team		query	string	false	"Filter by annotated owner team"
+// @Param		format			query	string	false	"Output format: json (default), markdown or csv table"
+// @Param		columns			query	string	false	"Comma-separated table columns (default name,version,description,tags)"
+// @Param		debug_timings	query	bool	false	"Add the timing breakdown of the response to its metadata (default false)"
 // @Success		200		{object}	upstreamv0.ServerListResponse
 // @Failure		400		{object}	map[string]string	"Bad request"
 // @Failure		401		{object}	map[string]string	"Unauthorized"
@@ -167,12 +341,23 @@ func (routes *Routes) listServers(w http.ResponseWriter, r *http.Request) {
 // @Tags		registry,official
 // @Accept		json
 // @Produce		json
+// @Produce		text/markdown
+// @Produce		text/csv
 // @Param		registryName	path	string	true	"Registry name"
 // @Param		cursor			query	string	false	"Pagination cursor for retrieving next set of results"
 // @Param		limit			query	int		false	"Maximum number of items to return"
 // @Param		search			query	string	false	"Search servers by name (substring match)"
 // @Param		updated_since	query	time	false	"Filter servers updated since timestamp (RFC3339 datetime)"
+// @Param		updated_after	query	time	false	"Alias of updated_since"
+// @Param		published_before	query	time	false	"Filter servers published before timestamp (RFC3339 datetime)"
+// @Param		sort			query	string	false	"Sort by name, published_at, or updated_at (prefix with '-' for descending order)"
+// @Param		include_deprecated	query	bool	false	"Include servers marked as deprecated (default false)"
+// @Param		include_deleted	query	bool	false	"Include servers marked as deleted (default false)"
+// @Param		max_injection_risk	query	int	false	"Exclude servers whose prompt-injection risk score (0-100) exceeds the value"
 // @Param		version			query	string	false	"Filter by version ('latest' for latest version, or an exact version like '1.2.3')"
+// @Param		format			query	string	false	"Output format: json (default), markdown or csv table"
+// @Param		columns			query	string	false	"Comma-separated table columns (default name,version,description,tags)"
+// @Param		debug_timings	query	bool	false	"Add the timing breakdown of the response to its metadata (default false)"
 // @Success		200		{object}	upstreamv0.ServerListResponse
 // @Failure		400		{object}	map[string]string	"Bad request"
 // @Failure		401		{object}	map[string]string	"Unauthorized"
@@ -214,12 +399,10 @@ func (routes *Routes) handleListVersions(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	latest := registry.LatestVersion(versions)
 	serverResponses := make([]upstreamv0.ServerResponse, len(versions))
 	for i, version := range versions {
-		serverResponses[i] = upstreamv0.ServerResponse{
-			Server: *version,
-			Meta:   upstreamv0.ResponseMeta{},
-		}
+		serverResponses[i] = routes.serverResponse(r.Context(), version, version == latest)
 	}
 
 	result := upstreamv0.ServerListResponse{
@@ -290,6 +473,11 @@ func (routes *Routes) handleGetVersion(w http.ResponseWriter, r *http.Request, r
 		return
 	}
 
+	if registry.IsVersionConstraint(version) {
+		routes.handleResolveVersion(w, r, registryName, serverName, version)
+		return
+	}
+
 	opts := []service.Option[service.GetServerVersionOptions]{}
 	if registryName != "" {
 		opts = append(opts, service.WithRegistryName[service.GetServerVersionOptions](registryName))
@@ -311,18 +499,52 @@ func (routes *Routes) handleGetVersion(w http.ResponseWriter, r *http.Request, r
 		return
 	}
 
-	serverResponse := upstreamv0.ServerResponse{
-		Server: *server,
-		Meta:   upstreamv0.ResponseMeta{},
+	experiment.RecordOutcome(r.Context(), experiment.OutcomeDetail, server.Name)
+	common.WriteJSONResponse(w, routes.serverResponse(r.Context(), server, false), http.StatusOK)
+}
+
+// handleResolveVersion responds with the highest version of a server satisfying a semantic versioning range.
+func (routes *Routes) handleResolveVersion(
+	w http.ResponseWriter,
+	r *http.Request,
+	registryName, serverName, constraint string,
+) {
+	// Ranges are resolved among all versions, a page of them would miss the newest ones
+	opts := []service.Option[service.ListServerVersionsOptions]{
+		service.WithName[service.ListServerVersionsOptions](serverName),
+		service.WithAllVersions(),
+	}
+	if registryName != "" {
+		opts = append(opts, service.WithRegistryName[service.ListServerVersionsOptions](registryName))
+	}
+
+	versions, err := routes.service.ListServerVersions(r.Context(), opts...)
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resolution, err := registry.ResolveVersion(constraint, versions)
+	if err != nil {
+		common.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-	common.WriteJSONResponse(w, serverResponse, http.StatusOK)
+	if resolution.Server == nil {
+		common.WriteErrorResponse(w, "Server not found", http.StatusNotFound)
+		return
+	}
+
+	experiment.RecordOutcome(r.Context(), experiment.OutcomeDetail, resolution.Server.Name)
+	isLatest := resolution.Server == registry.LatestVersion(versions)
+	common.WriteJSONResponse(w, routes.serverResponse(r.Context(), resolution.Server, isLatest), http.StatusOK)
 }
 
 // getVersion handles GET /registry/v0.1/servers/{serverName}/versions/{version}
 //
 // @Summary		Get specific MCP server version
 // @Description	Returns detailed information about a specific version of an MCP server.
-// @Description	Use the special version `latest` to get the latest version.
+// @Description	Use the special version `latest` to get the latest version, or a semantic versioning
+// @Description	range (e.g. `^1.2` or `>=2.0,<3`) to get the highest version satisfying it.
 // @Tags		registry,official
 // @Accept		json
 // @Produce		json
@@ -342,7 +564,8 @@ func (routes *Routes) getVersion(w http.ResponseWriter, r *http.Request) {
 //
 // @Summary		Get specific MCP server version
 // @Description	Returns detailed information about a specific version of an MCP server.
-// @Description	Use the special version `latest` to get the latest version.
+// @Description	Use the special version `latest` to get the latest version, or a semantic versioning
+// @Description	range (e.g. `^1.2` or `>=2.0,<3`) to get the highest version satisfying it.
 // @Tags		registry,official
 // @Accept		json
 // @Produce		json
@@ -472,6 +695,16 @@ func (routes *Routes) publishWithRegistryName(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// The official metadata and the sanitization marker are owned by the registry, publishers cannot set them
+	registry.StripRegistryMeta(&serverData)
+	if rules := routes.sanitizeRules[registryName]; len(rules) > 0 && registry.SanitizeServer(&serverData, rules) {
+		slog.Warn("Sanitized published server content",
+			"registryName", registryName,
+			"serverName", serverData.Name,
+			"version", serverData.Version,
+			"fields", registry.ExtractSanitizedFields(&serverData))
+	}
+
 	// Call service layer
 	result, err := routes.service.PublishServerVersion(
 		r.Context(),
@@ -0,0 +1,226 @@
+package v01
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// listServersQuery holds the query parameters of the server list endpoints
+type listServersQuery struct {
+	cursor            string
+	limit             *int
+	search            string
+	updatedSince      *time.Time
+	publishedBefore   *time.Time
+	sort              string // one of registry.SortKeys, prefixed with "-" for descending order
+	includeDeprecated bool
+	includeDeleted    bool
+	dedupe            bool
+	maxInjectionRisk  *int
+	health            string
+	annotationFilter  registry.AnnotationFilter
+	version           string
+	table             common.Table
+}
+
+// parseListServersQuery parses the query parameters of the server list endpoints.
+// It writes a bad request response and returns false if a parameter is invalid.
+func (routes *Routes) parseListServersQuery(w http.ResponseWriter, r *http.Request) (listServersQuery, bool) {
+	query := r.URL.Query()
+	parsed := listServersQuery{
+		cursor:  query.Get("cursor"),
+		search:  query.Get("search"),
+		sort:    query.Get("sort"),
+		health:  query.Get("health"),
+		version: query.Get("version"),
+		annotationFilter: registry.AnnotationFilter{
+			ApprovalStatus: query.Get("approval_status"),
+			OwnerTeam:      query.Get("owner_team"),
+		},
+	}
+
+	var ok bool
+	if parsed.limit, ok = parseLimitParam(w, query); !ok {
+		return listServersQuery{}, false
+	}
+
+	// Parse updated_since, or its alias updated_after, and published_before (optional RFC3339 datetimes)
+	updatedSinceParam := "updated_since"
+	if query.Get(updatedSinceParam) == "" {
+		updatedSinceParam = "updated_after"
+	}
+	if parsed.updatedSince, ok = parseTimeParam(w, query, updatedSinceParam); !ok {
+		return listServersQuery{}, false
+	}
+	if parsed.publishedBefore, ok = parseTimeParam(w, query, "published_before"); !ok {
+		return listServersQuery{}, false
+	}
+
+	// Parse include_deprecated, include_deleted and dedupe (optional booleans, default false)
+	if parsed.includeDeprecated, ok = parseBoolParam(w, query, "include_deprecated"); !ok {
+		return listServersQuery{}, false
+	}
+	if parsed.includeDeleted, ok = parseBoolParam(w, query, "include_deleted"); !ok {
+		return listServersQuery{}, false
+	}
+	if parsed.dedupe, ok = parseBoolParam(w, query, "dedupe"); !ok {
+		return listServersQuery{}, false
+	}
+
+	if parsed.maxInjectionRisk, ok = parseMaxInjectionRiskParam(w, query); !ok {
+		return listServersQuery{}, false
+	}
+	if !routes.validateListServersQuery(w, parsed) {
+		return listServersQuery{}, false
+	}
+
+	// Parse format and columns (optional table rendering)
+	if parsed.table, ok = common.ParseTable(w, r, serverColumnNames, defaultServerColumns); !ok {
+		return listServersQuery{}, false
+	}
+
+	return parsed, true
+}
+
+// validateListServersQuery validates the sort, health and annotation filter parameters of the server
+// list endpoints. It writes a bad request response and returns false if a parameter is invalid.
+func (routes *Routes) validateListServersQuery(w http.ResponseWriter, query listServersQuery) bool {
+	// Sort is an optional sort key, prefixed with "-" for descending order
+	if query.sort != "" && !slices.Contains(registry.SortKeys(), strings.TrimPrefix(query.sort, "-")) {
+		common.WriteErrorResponse(
+			w,
+			fmt.Sprintf("Invalid sort parameter: must be one of %s", strings.Join(registry.SortKeys(), ", ")),
+			http.StatusBadRequest,
+		)
+		return false
+	}
+
+	// Health is an optional health status, requiring link checks
+	if query.health != "" && !slices.Contains(registry.HealthStatuses(), query.health) {
+		common.WriteErrorResponse(
+			w,
+			fmt.Sprintf("Invalid health parameter: must be one of %s", strings.Join(registry.HealthStatuses(), ", ")),
+			http.StatusBadRequest,
+		)
+		return false
+	}
+	if query.health != "" && routes.linkChecker == nil {
+		common.WriteErrorResponse(w, "Invalid health parameter: link checks are not enabled", http.StatusBadRequest)
+		return false
+	}
+
+	// Approval_status and owner_team are optional annotation filters, requiring annotations
+	if query.annotationFilter.ApprovalStatus != "" &&
+		!slices.Contains(registry.ApprovalStatuses(), query.annotationFilter.ApprovalStatus) {
+		common.WriteErrorResponse(
+			w,
+			fmt.Sprintf("Invalid approval_status parameter: must be one of %s",
+				strings.Join(registry.ApprovalStatuses(), ", ")),
+			http.StatusBadRequest,
+		)
+		return false
+	}
+	if !query.annotationFilter.IsEmpty() && routes.annotations == nil {
+		common.WriteErrorResponse(w, "Invalid annotation filter: annotations are not enabled", http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// parseLimitParam parses the optional integer limit query parameter.
+// It writes a bad request response and returns false if the value is malformed.
+func parseLimitParam(w http.ResponseWriter, query url.Values) (*int, bool) {
+	limitStr := query.Get("limit")
+	if limitStr == "" {
+		return nil, true
+	}
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		common.WriteErrorResponse(w, "Invalid limit parameter: must be an integer", http.StatusBadRequest)
+		return nil, false
+	}
+	return &limit, true
+}
+
+// parseMaxInjectionRiskParam parses the optional max_injection_risk query parameter, an integer
+// between 0 and registry.MaxInjectionRiskScore.
+// It writes a bad request response and returns false if the value is malformed.
+func parseMaxInjectionRiskParam(w http.ResponseWriter, query url.Values) (*int, bool) {
+	riskStr := query.Get("max_injection_risk")
+	if riskStr == "" {
+		return nil, true
+	}
+	risk, err := strconv.Atoi(riskStr)
+	if err != nil || risk < 0 || risk > registry.MaxInjectionRiskScore {
+		common.WriteErrorResponse(
+			w,
+			fmt.Sprintf("Invalid max_injection_risk parameter: must be an integer between 0 and %d",
+				registry.MaxInjectionRiskScore),
+			http.StatusBadRequest,
+		)
+		return nil, false
+	}
+	return &risk, true
+}
+
+// listServersOptions returns the ListServers options of the query, listing the servers of the
+// given registry, or of all registries if empty
+func (routes *Routes) listServersOptions(
+	query listServersQuery,
+	registryName string,
+) []service.Option[service.ListServersOptions] {
+	opts := []service.Option[service.ListServersOptions]{}
+	if query.cursor != "" {
+		opts = append(opts, service.WithCursor(query.cursor))
+	}
+	if query.limit != nil {
+		opts = append(opts, service.WithLimit[service.ListServersOptions](*query.limit))
+	}
+	if query.search != "" {
+		opts = append(opts, service.WithSearch(query.search))
+	}
+	if query.updatedSince != nil {
+		opts = append(opts, service.WithUpdatedSince(*query.updatedSince))
+	}
+	if query.publishedBefore != nil {
+		opts = append(opts, service.WithPublishedBefore(*query.publishedBefore))
+	}
+	if query.sort != "" {
+		opts = append(opts, service.WithSort(query.sort))
+	}
+	if query.includeDeprecated {
+		opts = append(opts, service.WithIncludeDeprecated())
+	}
+	if query.includeDeleted {
+		opts = append(opts, service.WithIncludeDeleted())
+	}
+	if query.maxInjectionRisk != nil {
+		opts = append(opts, service.WithMaxInjectionRisk(*query.maxInjectionRisk))
+	}
+	if query.dedupe {
+		opts = append(opts, service.WithDeduplicate())
+	}
+	if query.health != "" {
+		opts = append(opts, service.WithHealth(query.health, routes.linkChecker))
+	}
+	if !query.annotationFilter.IsEmpty() {
+		opts = append(opts, service.WithAnnotationFilter(query.annotationFilter, routes.annotations))
+	}
+	if query.version != "" {
+		opts = append(opts, service.WithVersion[service.ListServersOptions](query.version))
+	}
+	if registryName != "" {
+		opts = append(opts, service.WithRegistryName[service.ListServersOptions](registryName))
+	}
+	return opts
+}
@@ -0,0 +1,80 @@
+package v01
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+func TestParseListServersQuery(t *testing.T) {
+	t.Parallel()
+
+	routes := &Routes{}
+	req := httptest.NewRequest(http.MethodGet,
+		"/v0.1/servers?cursor=Mg==&limit=5&search=fetch&updated_after=2025-01-01T00:00:00Z"+
+			"&published_before=2025-06-01T00:00:00Z&sort=-updated_at&include_deprecated=true&dedupe=1"+
+			"&max_injection_risk=30&version=latest&format=csv", nil)
+	rr := httptest.NewRecorder()
+
+	query, ok := routes.parseListServersQuery(rr, req)
+	require.True(t, ok)
+	assert.Equal(t, "csv", query.table.Format)
+
+	options := &service.ListServersOptions{}
+	for _, opt := range routes.listServersOptions(query, "foo") {
+		require.NoError(t, opt(options))
+	}
+	assert.Equal(t, service.ListServersOptions{
+		RegistryName:      ptr.String("foo"),
+		Cursor:            "Mg==",
+		Limit:             5,
+		Search:            "fetch",
+		UpdatedSince:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		PublishedBefore:   time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		Sort:              registry.SortByUpdatedAt,
+		SortDescending:    true,
+		Version:           "latest",
+		IncludeDeprecated: true,
+		MaxInjectionRisk:  ptr.Int(30),
+		Deduplicate:       true,
+	}, *options)
+}
+
+func TestParseListServersQueryInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "limit", query: "limit=ten", wantErr: "Invalid limit parameter"},
+		{name: "updated_since", query: "updated_since=yesterday", wantErr: "Invalid updated_since parameter"},
+		{name: "sort", query: "sort=stars", wantErr: "Invalid sort parameter"},
+		{name: "include_deleted", query: "include_deleted=maybe", wantErr: "Invalid include_deleted parameter"},
+		{name: "max_injection_risk", query: "max_injection_risk=101", wantErr: "Invalid max_injection_risk parameter"},
+		{name: "health without link checks", query: "health=ok", wantErr: "link checks are not enabled"},
+		{name: "approval_status", query: "approval_status=maybe", wantErr: "Invalid approval_status parameter"},
+		{name: "owner_team without annotations", query: "owner_team=platform", wantErr: "annotations are not enabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			_, ok := (&Routes{}).parseListServersQuery(rr, httptest.NewRequest(http.MethodGet, "/v0.1/servers?"+tt.query, nil))
+			assert.False(t, ok)
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.wantErr)
+		})
+	}
+}
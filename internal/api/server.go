@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -14,9 +13,27 @@ import (
 
 	// Import generated docs package to register OpenAPI spec via init()
 	_ "github.com/stacklok/toolhive-registry-server/docs/thv-registry-api"
+	"github.com/stacklok/toolhive-registry-server/internal/annotations"
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
 	extensionv0 "github.com/stacklok/toolhive-registry-server/internal/api/extension/v0"
 	v01 "github.com/stacklok/toolhive-registry-server/internal/api/registry/v01"
+	"github.com/stacklok/toolhive-registry-server/internal/approval"
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/cache"
+	"github.com/stacklok/toolhive-registry-server/internal/changelog"
+	"github.com/stacklok/toolhive-registry-server/internal/experiment"
+	"github.com/stacklok/toolhive-registry-server/internal/features"
+	"github.com/stacklok/toolhive-registry-server/internal/feedback"
+	"github.com/stacklok/toolhive-registry-server/internal/kubernetes"
+	"github.com/stacklok/toolhive-registry-server/internal/linkcheck"
+	"github.com/stacklok/toolhive-registry-server/internal/pkgstats"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/reviews"
+	"github.com/stacklok/toolhive-registry-server/internal/sbom"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/searchstats"
+	"github.com/stacklok/toolhive-registry-server/internal/summary"
+	"github.com/stacklok/toolhive-registry-server/internal/supervisor"
 )
 
 // ServerOption configures the registry API server
@@ -24,8 +41,32 @@ type ServerOption func(*serverConfig)
 
 // serverConfig holds the server configuration
 type serverConfig struct {
-	middlewares     []func(http.Handler) http.Handler
-	authInfoHandler http.Handler
+	middlewares       []func(http.Handler) http.Handler
+	authInfoHandler   http.Handler
+	signingKeyHandler http.Handler
+	searchTracker     *searchstats.Tracker
+	extensionLimits   map[string]int
+	approvalChecker   approval.Checker
+	ticketFiler       approval.TicketFiler
+	cacheBudget       *cache.Budget
+	sbomFetcher       *sbom.Fetcher
+	packageFetcher    *pkgstats.Fetcher
+	changelogFetcher  *changelog.Fetcher
+	experiment        *experiment.Experiment
+	feedbackStore     *feedback.Store
+	linkChecker       *linkcheck.Checker
+	summaries         *summary.Summaries
+	annotations       *annotations.Store
+	reviews           *reviews.Store
+	admins            *auth.Admins
+	metaFilter        registry.MetaFilter
+	sanitizeRules     map[string][]string
+	supervisor        *supervisor.Supervisor
+	features          *features.Flags
+	installer         *kubernetes.Installer
+
+	// withoutOperational leaves out the operational endpoints, served by OperationsRouter instead
+	withoutOperational bool
 }
 
 // WithMiddlewares adds middleware to the server
@@ -42,6 +83,186 @@ func WithAuthInfoHandler(handler http.Handler) ServerOption {
 	}
 }
 
+// WithSigningKeyHandler sets the handler serving the response signing public key,
+// mounted at /.well-known/registry-signing-key
+func WithSigningKeyHandler(handler http.Handler) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.signingKeyHandler = handler
+	}
+}
+
+// WithSearchTracker sets the tracker backing the zero-result searches report
+func WithSearchTracker(tracker *searchstats.Tracker) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.searchTracker = tracker
+	}
+}
+
+// WithExtensionConcurrencyLimits sets the maximum number of concurrent requests per extension endpoint
+func WithExtensionConcurrencyLimits(limits map[string]int) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.extensionLimits = limits
+	}
+}
+
+// WithApprovalChecker sets the approval system consulted before install instructions are handed out
+func WithApprovalChecker(checker approval.Checker) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.approvalChecker = checker
+	}
+}
+
+// WithTicketFiler sets the ticketing system server approval requests are filed in
+func WithTicketFiler(filer approval.TicketFiler) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.ticketFiler = filer
+	}
+}
+
+// WithCacheBudget sets the memory budget whose cache usage is reported by the extension API
+func WithCacheBudget(budget *cache.Budget) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.cacheBudget = budget
+	}
+}
+
+// WithSBOMFetcher sets the fetcher retrieving the SBOMs of container images
+func WithSBOMFetcher(fetcher *sbom.Fetcher) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.sbomFetcher = fetcher
+	}
+}
+
+// WithPackageFetcher sets the fetcher retrieving the stats of npm and PyPI packages
+func WithPackageFetcher(fetcher *pkgstats.Fetcher) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.packageFetcher = fetcher
+	}
+}
+
+// WithChangelogFetcher sets the fetcher retrieving the release notes of servers from GitHub
+func WithChangelogFetcher(fetcher *changelog.Fetcher) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.changelogFetcher = fetcher
+	}
+}
+
+// WithExperiment sets the search ranking experiment reported by the extension API. Clients are
+// assigned to its variants by its middleware, to be set with WithMiddlewares.
+func WithExperiment(exp *experiment.Experiment) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.experiment = exp
+	}
+}
+
+// WithFeedbackStore sets the store recording client feedback on search results in the extension API
+func WithFeedbackStore(store *feedback.Store) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.feedbackStore = store
+	}
+}
+
+// WithLinkChecker sets the link checker flagging stale servers in the registry and extension APIs
+func WithLinkChecker(checker *linkcheck.Checker) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.linkChecker = checker
+	}
+}
+
+// WithAnnotations sets the store of the annotations operators record about servers, recorded in
+// registry API responses, filtered on in server listings and managed through the extension API
+func WithAnnotations(store *annotations.Store) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.annotations = store
+	}
+}
+
+// WithReviews sets the store of the ratings and reviews of servers, recorded in registry API responses
+// and ingested and reported through the extension API
+func WithReviews(store *reviews.Store) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.reviews = store
+	}
+}
+
+// WithAdmins sets the administrators of the API, allowed to perform the administrative operations
+// of the extension API
+func WithAdmins(admins *auth.Admins) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.admins = admins
+	}
+}
+
+// WithSummaries sets the summaries of long descriptions used in the registry API server metadata
+// and the extension API context packs
+func WithSummaries(summaries *summary.Summaries) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.summaries = summaries
+	}
+}
+
+// WithResponseMetaFilter sets the filter selecting the publisher-provided metadata namespaces
+// returned in registry API server responses
+func WithResponseMetaFilter(filter registry.MetaFilter) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.metaFilter = filter
+	}
+}
+
+// WithSanitizeRules sets the sanitization rules applied to the servers published to each managed
+// registry, by registry name
+func WithSanitizeRules(rules map[string][]string) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.sanitizeRules = rules
+	}
+}
+
+// WithSupervisor sets the supervisor of the background workers whose status is reported by the
+// health endpoint
+func WithSupervisor(sup *supervisor.Supervisor) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.supervisor = sup
+	}
+}
+
+// WithFeatures sets the feature flags gating the experimental endpoints
+func WithFeatures(flags *features.Flags) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.features = flags
+	}
+}
+
+// WithInstaller sets the installer creating MCPServer resources in the cluster
+func WithInstaller(installer *kubernetes.Installer) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.installer = installer
+	}
+}
+
+// WithoutOperationalEndpoints leaves the operational endpoints (/health, /readiness and /version)
+// out of the server, for them to be served on a separate listener by OperationsRouter
+func WithoutOperationalEndpoints() ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.withoutOperational = true
+	}
+}
+
+// OperationsRouter creates the router serving only the operational endpoints, reporting the status
+// of the background workers of sup (which may be nil)
+func OperationsRouter(svc service.RegistryService, sup *supervisor.Supervisor) *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(middleware.Recoverer)
+	mountOperationalEndpoints(r, svc, sup)
+	return r
+}
+
+// mountOperationalEndpoints mounts the operational endpoints at root
+func mountOperationalEndpoints(r chi.Router, svc service.RegistryService, sup *supervisor.Supervisor) {
+	r.Get("/health", healthHandler(sup))
+	r.Get("/readiness", readinessHandler(svc))
+	r.Get("/version", versionHandler)
+}
+
 // NewServer creates and configures the HTTP router with the given service and options
 func NewServer(svc service.RegistryService, opts ...ServerOption) *chi.Mux {
 	// Initialize configuration with defaults
@@ -60,11 +281,12 @@ func NewServer(svc service.RegistryService, opts ...ServerOption) *chi.Mux {
 	for _, mw := range cfg.middlewares {
 		r.Use(mw)
 	}
+	r.Use(common.DebugTimings)
 
-	// Mount operational endpoints at root
-	r.Get("/health", healthHandler)
-	r.Get("/readiness", readinessHandler(svc))
-	r.Get("/version", versionHandler)
+	// Mount operational endpoints at root, unless they have a listener of their own
+	if !cfg.withoutOperational {
+		mountOperationalEndpoints(r, svc, cfg.supervisor)
+	}
 
 	// Mount OpenAPI endpoint
 	r.Get("/openapi.json", openAPIHandler)
@@ -74,42 +296,51 @@ func NewServer(svc service.RegistryService, opts ...ServerOption) *chi.Mux {
 		r.Handle("/.well-known/oauth-protected-resource", cfg.authInfoHandler)
 	}
 
+	// Mount response signing public key at well-known endpoint (if configured)
+	if cfg.signingKeyHandler != nil {
+		r.Handle("/.well-known/registry-signing-key", cfg.signingKeyHandler)
+	}
+
 	// Mount MCP Registry API v0.1 routes
-	r.Mount("/registry", v01.Router(svc))
-	r.Mount("/extension/v0", extensionv0.Router(svc))
+	r.Mount("/registry", v01.Router(svc,
+		v01.WithMetaFilter(cfg.metaFilter),
+		v01.WithFeatures(cfg.features),
+		v01.WithLinkChecker(cfg.linkChecker),
+		v01.WithSummaries(cfg.summaries),
+		v01.WithAnnotations(cfg.annotations),
+		v01.WithReviews(cfg.reviews),
+		v01.WithSanitizeRules(cfg.sanitizeRules),
+	))
+	extensionOpts := []extensionv0.RouterOption{
+		extensionv0.WithSearchTracker(cfg.searchTracker),
+		extensionv0.WithConcurrencyLimits(cfg.extensionLimits),
+		extensionv0.WithApprovalChecker(cfg.approvalChecker),
+		extensionv0.WithTicketFiler(cfg.ticketFiler),
+		extensionv0.WithCacheBudget(cfg.cacheBudget),
+		extensionv0.WithFeatures(cfg.features),
+		extensionv0.WithInstaller(cfg.installer),
+		extensionv0.WithLinkChecker(cfg.linkChecker),
+		extensionv0.WithExperiment(cfg.experiment),
+		extensionv0.WithFeedbackStore(cfg.feedbackStore),
+		extensionv0.WithSummaries(cfg.summaries),
+		extensionv0.WithAnnotations(cfg.annotations),
+		extensionv0.WithReviews(cfg.reviews),
+		extensionv0.WithAdmins(cfg.admins),
+	}
+	if cfg.sbomFetcher != nil {
+		extensionOpts = append(extensionOpts, extensionv0.WithSBOMFetcher(cfg.sbomFetcher))
+	}
+	if cfg.packageFetcher != nil {
+		extensionOpts = append(extensionOpts, extensionv0.WithPackageFetcher(cfg.packageFetcher))
+	}
+	if cfg.changelogFetcher != nil {
+		extensionOpts = append(extensionOpts, extensionv0.WithChangelogFetcher(cfg.changelogFetcher))
+	}
+	r.Mount("/extension/v0", extensionv0.Router(svc, extensionOpts...))
 
 	return r
 }
 
-// LoggingMiddleware logs HTTP requests
-func LoggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
-
-		next.ServeHTTP(ww, r)
-
-		// Determine log level based on status code
-		status := ww.Status()
-		duration := time.Since(start)
-		logLevel := slog.LevelInfo
-		if status >= 500 {
-			logLevel = slog.LevelError
-		} else if status >= 400 {
-			logLevel = slog.LevelWarn
-		}
-
-		slog.Log(r.Context(), logLevel, "HTTP request",
-			"method", r.Method,
-			"path", r.URL.Path,
-			"status", status,
-			"duration_ms", duration.Milliseconds(),
-			"request_id", middleware.GetReqID(r.Context()),
-			"remote_addr", r.RemoteAddr,
-		)
-	})
-}
-
 // openAPIHandler handles OpenAPI specification requests
 //
 // @Summary		OpenAPI specification
@@ -138,18 +369,41 @@ func openAPIHandler(w http.ResponseWriter, _ *http.Request) {
 	_, _ = w.Write([]byte(doc))
 }
 
-// healthHandler handles health check requests
+// healthResponse is the response of the health endpoint
+type healthResponse struct {
+	// Status is "healthy", or "degraded" when a background worker crashed
+	Status  string                    `json:"status"`
+	Workers []supervisor.WorkerStatus `json:"workers,omitempty"`
+}
+
+// healthHandler handles health check requests, reporting the status of the background workers
+// run by sup when not nil. A crashed worker degrades the status without failing the check, as
+// workers are restarted by the supervisor rather than by restarting the server.
 //
 // @Summary		Health check
-// @Description	Check if the registry API is healthy
+// @Description	Check if the registry API is healthy and report the status of its background workers
 // @Tags		system
 // @Produce		json
-// @Success		200	{object}	map[string]string
+// @Success		200	{object}	healthResponse
 // @Router		/health [get]
-func healthHandler(w http.ResponseWriter, _ *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(`{"status":"healthy"}`))
+func healthHandler(sup *supervisor.Supervisor) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		response := healthResponse{Status: "healthy"}
+		if sup != nil {
+			response.Workers = sup.Status()
+			for _, worker := range response.Workers {
+				if !worker.Healthy() {
+					response.Status = "degraded"
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			slog.Error("Failed to encode health response", "error", err)
+		}
+	}
 }
 
 // readinessHandler handles readiness check requests
@@ -1,7 +1,9 @@
 package api_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/stacklok/toolhive-registry-server/internal/api"
 	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+	"github.com/stacklok/toolhive-registry-server/internal/supervisor"
 )
 
 func TestHealthEndpoint(t *testing.T) {
@@ -39,6 +42,38 @@ func TestHealthEndpoint(t *testing.T) {
 	assert.Equal(t, "healthy", response["status"])
 }
 
+func TestHealthEndpointWorkers(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	sup := supervisor.New()
+	sup.Add(supervisor.Worker{Name: "sync", OneShot: true, Run: func(context.Context) error {
+		return errors.New("database unavailable")
+	}})
+	require.NoError(t, sup.Run(context.Background()))
+	server := api.NewServer(mocks.NewMockRegistryService(ctrl), api.WithSupervisor(sup))
+
+	req, err := http.NewRequest("GET", "/health", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response struct {
+		Status  string                    `json:"status"`
+		Workers []supervisor.WorkerStatus `json:"workers"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "degraded", response.Status)
+	require.Len(t, response.Workers, 1)
+	assert.Equal(t, "sync", response.Workers[0].Name)
+	assert.Equal(t, supervisor.StateFailed, response.Workers[0].State)
+	assert.Equal(t, "database unavailable", response.Workers[0].LastError)
+}
+
 func TestReadinessEndpoint(t *testing.T) {
 	t.Parallel()
 
@@ -167,4 +202,39 @@ func TestOpenAPIEndpoint(t *testing.T) {
 	paths, ok := spec["paths"].(map[string]interface{})
 	require.True(t, ok)
 	assert.Contains(t, paths, "/openapi.json")
+
+	// Verify the structured responses of listings, searches and reports are described
+	for _, path := range []string{
+		"/registry/v0.1/servers",
+		"/extension/v0/suggestions",
+		"/extension/v0/reports/ratings",
+		"/extension/v0/reports/search-experiment",
+	} {
+		assert.Contains(t, paths, path)
+	}
+}
+
+func TestOperationalEndpointsOnSeparateListener(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mocks.NewMockRegistryService(ctrl)
+	mockSvc.EXPECT().CheckReadiness(gomock.Any()).Return(nil)
+	server := api.NewServer(mockSvc, api.WithoutOperationalEndpoints())
+	operations := api.OperationsRouter(mockSvc, nil)
+
+	for _, path := range []string{"/health", "/readiness", "/version"} {
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusNotFound, rr.Code, "API router serves %s", path)
+
+		rr = httptest.NewRecorder()
+		operations.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rr.Code, "operations router does not serve %s", path)
+	}
+
+	rr := httptest.NewRecorder()
+	operations.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
 }
@@ -19,32 +19,48 @@ type RegistryApp struct {
 	components *AppComponents
 	httpServer *http.Server
 
+	// operationsServer serves the operational endpoints, nil unless they have a listener of their own
+	operationsServer *http.Server
+
 	// Lifecycle management
 	ctx        context.Context
 	cancelFunc context.CancelFunc
 }
 
-// Start starts the application components (HTTP server and background sync)
-// This method blocks until the HTTP server stops or encounters an error
+// Start starts the application components (HTTP servers and background sync)
+// This method blocks until the HTTP servers stop, or one of them encounters an error
 func (app *RegistryApp) Start() error {
-	// Start sync coordinator in background
+	// Start background workers (sync coordinator, telemetry reporter)
 	go func() {
-		if err := app.components.SyncCoordinator.Start(app.ctx); err != nil {
-			slog.Error("Sync coordinator failed", "error", err)
+		if err := app.components.Supervisor.Run(app.ctx); err != nil {
+			slog.Error("Background workers failed", "error", err)
 		}
 	}()
 
-	// Start HTTP server (blocks until stopped)
-	slog.Info("Server listening", "address", app.httpServer.Addr)
-	if err := app.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		return fmt.Errorf("HTTP server failed: %w", err)
+	// Start HTTP servers (blocks until stopped)
+	servers := app.servers()
+	errs := make(chan error, len(servers))
+	for name, server := range servers {
+		slog.Info("Server listening", "listener", name, "address", server.Addr, "tls", server.TLSConfig != nil)
+		go func() {
+			if err := serveHTTP(server); err != nil {
+				errs <- fmt.Errorf("%s HTTP server failed: %w", name, err)
+				return
+			}
+			errs <- nil
+		}()
+	}
+	for range servers {
+		if err := <-errs; err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
 // Stop gracefully stops the application with the given timeout
-// It stops the sync coordinator and then shuts down the HTTP server
+// It stops the sync coordinator and then shuts down the HTTP servers
 func (app *RegistryApp) Stop(timeout time.Duration) error {
 	slog.Info("Shutting down server")
 
@@ -58,24 +74,44 @@ func (app *RegistryApp) Stop(timeout time.Duration) error {
 		app.cancelFunc()
 	}
 
-	// Graceful HTTP server shutdown
+	// Graceful HTTP servers shutdown
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	if err := app.httpServer.Shutdown(shutdownCtx); err != nil {
-		return fmt.Errorf("server forced to shutdown: %w", err)
+	var errs []error
+	for name, server := range app.servers() {
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			errs = append(errs, fmt.Errorf("%s server forced to shutdown: %w", name, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
 	}
 
 	slog.Info("Server shutdown complete")
 	return nil
 }
 
+// servers returns the HTTP servers of the application by listener name
+func (app *RegistryApp) servers() map[string]*http.Server {
+	servers := map[string]*http.Server{"api": app.httpServer}
+	if app.operationsServer != nil {
+		servers["operations"] = app.operationsServer
+	}
+	return servers
+}
+
 // GetConfig returns the application configuration
 func (app *RegistryApp) GetConfig() *config.Config {
 	return app.config
 }
 
-// GetHTTPServer returns the HTTP server (useful for testing to get the actual port)
+// GetHTTPServer returns the HTTP server of the API listener (useful for testing to get the actual port)
 func (app *RegistryApp) GetHTTPServer() *http.Server {
 	return app.httpServer
 }
+
+// GetOperationsServer returns the HTTP server of the operations listener, nil unless configured
+func (app *RegistryApp) GetOperationsServer() *http.Server {
+	return app.operationsServer
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -14,20 +15,42 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 
+	"github.com/stacklok/toolhive-registry-server/internal/annotations"
 	"github.com/stacklok/toolhive-registry-server/internal/api"
+	extensionv0 "github.com/stacklok/toolhive-registry-server/internal/api/extension/v0"
+	"github.com/stacklok/toolhive-registry-server/internal/approval"
 	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/cache"
+	"github.com/stacklok/toolhive-registry-server/internal/changelog"
 	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/experiment"
+	"github.com/stacklok/toolhive-registry-server/internal/features"
+	"github.com/stacklok/toolhive-registry-server/internal/feedback"
 	"github.com/stacklok/toolhive-registry-server/internal/kubernetes"
+	"github.com/stacklok/toolhive-registry-server/internal/linkcheck"
+	"github.com/stacklok/toolhive-registry-server/internal/pkgstats"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/reviews"
+	"github.com/stacklok/toolhive-registry-server/internal/sbom"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
 	database "github.com/stacklok/toolhive-registry-server/internal/service/db"
 	"github.com/stacklok/toolhive-registry-server/internal/service/inmemory"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mirror"
+	"github.com/stacklok/toolhive-registry-server/internal/service/ranking"
+	"github.com/stacklok/toolhive-registry-server/internal/service/searchstats"
+	"github.com/stacklok/toolhive-registry-server/internal/signing"
 	"github.com/stacklok/toolhive-registry-server/internal/sources"
 	"github.com/stacklok/toolhive-registry-server/internal/status"
+	"github.com/stacklok/toolhive-registry-server/internal/summary"
+	"github.com/stacklok/toolhive-registry-server/internal/supervisor"
 	pkgsync "github.com/stacklok/toolhive-registry-server/internal/sync"
 	"github.com/stacklok/toolhive-registry-server/internal/sync/coordinator"
 	"github.com/stacklok/toolhive-registry-server/internal/sync/state"
 	"github.com/stacklok/toolhive-registry-server/internal/sync/writer"
+	"github.com/stacklok/toolhive-registry-server/internal/telemetry"
+	"github.com/stacklok/toolhive-registry-server/pkg/client"
 )
 
 const (
@@ -75,6 +98,143 @@ type registryAppConfig struct {
 	// Auth components
 	authMiddleware  func(http.Handler) http.Handler
 	authInfoHandler http.Handler
+
+	// searchTracker records zero-result searches for the extension API report
+	searchTracker *searchstats.Tracker
+
+	// telemetryReporter aggregates anonymized usage counts, nil unless telemetry is enabled
+	telemetryReporter *telemetry.Reporter
+
+	// signer signs JSON responses, nil unless response signing is enabled
+	signer *signing.Signer
+
+	// cacheBudget is the memory budget shared by the in-memory caches, see cacheMemoryBudget
+	cacheBudget *cache.Budget
+
+	// supervisor runs the background workers, its status is reported by the health endpoint
+	supervisor *supervisor.Supervisor
+
+	// features are the feature flags gating the experimental endpoints
+	features *features.Flags
+
+	// installer creates MCPServer resources in the cluster, nil unless cluster installation is enabled
+	installer *kubernetes.Installer
+
+	// packages looks up npm and PyPI packages, see packageFetcher
+	packages *pkgstats.Fetcher
+
+	// linkChecker flags servers with broken links as stale, nil unless link checks are enabled
+	linkChecker *linkcheck.Checker
+
+	// experiment assigns clients to alternative search rankings, nil unless a search experiment is configured
+	experiment *experiment.Experiment
+
+	// feedbackStore records client feedback on search results, nil unless search feedback is configured
+	feedbackStore *feedback.Store
+
+	// annotations stores what operators record about servers, nil unless annotations are configured
+	annotations *annotations.Store
+
+	// reviews stores the ratings and reviews of servers, nil unless reviews are configured
+	reviews *reviews.Store
+
+	// snapshotRefresher refreshes the in-memory snapshot of the registry data in the background,
+	// nil unless file storage is used and a snapshot refresh interval is configured
+	snapshotRefresher inmemory.Refresher
+}
+
+// installerOptions returns the options scoping the cluster installer to the configured
+// namespaces and caller identities
+func installerOptions(cluster *config.ClusterConfig) []kubernetes.InstallerOption {
+	if cluster == nil {
+		return nil
+	}
+	opts := []kubernetes.InstallerOption{kubernetes.WithAllowedNamespaces(cluster.Namespaces...)}
+	if cluster.Impersonation != nil && cluster.Impersonation.Enabled {
+		opts = append(opts, kubernetes.WithImpersonation(
+			cluster.Impersonation.GetUserClaim(), cluster.Impersonation.GetGroupsClaim(),
+		))
+	}
+	return opts
+}
+
+// cacheMemoryBudget returns the memory budget shared by the in-memory caches, creating it on first use
+func (b *registryAppConfig) cacheMemoryBudget() *cache.Budget {
+	if b.cacheBudget == nil {
+		b.cacheBudget = cache.NewBudget(b.cacheConfig().GetMemoryBudget())
+	}
+	return b.cacheBudget
+}
+
+// packageFetcher returns the fetcher looking up npm and PyPI packages, creating it on first use
+func (b *registryAppConfig) packageFetcher() *pkgstats.Fetcher {
+	if b.packages == nil {
+		b.packages = pkgstats.NewFetcher(pkgstats.WithCache(
+			cache.WithBudget(b.cacheMemoryBudget()), cache.WithMaxBytes(b.cacheConfig().GetQuota("package-stats")),
+		))
+	}
+	return b.packages
+}
+
+// summaries returns the summaries of long descriptions, nil unless summaries are configured
+func (b *registryAppConfig) summaries() *summary.Summaries {
+	if b.config == nil || b.config.Summaries == nil {
+		return nil
+	}
+	opts := []summary.Option{summary.WithCache(
+		cache.WithBudget(b.cacheMemoryBudget()), cache.WithMaxBytes(b.cacheConfig().GetQuota("summaries")),
+	)}
+	if llm := b.config.Summaries.LLM; llm != nil {
+		opts = append(opts, summary.WithSummarizer(summary.NewLLM(llm)))
+		slog.Info("Enabled LLM summaries of long descriptions", "url", llm.URL, "model", llm.Model)
+	}
+	return summary.New(b.config.Summaries, opts...)
+}
+
+// slowRequestThresholds returns the thresholds above which requests are logged as slow, none when
+// request logging is not configured
+func (b *registryAppConfig) slowRequestThresholds() api.SlowRequestThresholds {
+	if b.config == nil || b.config.RequestLogging == nil {
+		return api.SlowRequestThresholds{}
+	}
+	return api.SlowRequestThresholds{
+		Default: b.config.RequestLogging.GetSlowThreshold(),
+		Routes:  b.config.RequestLogging.GetRouteThresholds(),
+	}
+}
+
+// cacheConfig returns the cache configuration, nil when not configured
+func (b *registryAppConfig) cacheConfig() *config.CacheConfig {
+	if b.config == nil {
+		return nil
+	}
+	return b.config.Cache
+}
+
+// sbomFetcher returns the fetcher of the SBOMs and manifests of server container images
+func (b *registryAppConfig) sbomFetcher() *sbom.Fetcher {
+	opts := []sbom.Option{sbom.WithCache(
+		cache.WithBudget(b.cacheMemoryBudget()), cache.WithMaxBytes(b.cacheConfig().GetQuota("sbom")),
+	)}
+	if b.config != nil && b.config.SBOM != nil {
+		opts = append(opts, sbom.WithTokenRealmHosts(b.config.SBOM.TokenRealmHosts...))
+	}
+	return sbom.NewFetcher(opts...)
+}
+
+// publishSanitizeRules returns the sanitization rules of the managed registries, by registry name
+func (b *registryAppConfig) publishSanitizeRules() map[string][]string {
+	if b.config == nil {
+		return nil
+	}
+	rules := make(map[string][]string)
+	for i := range b.config.Registries {
+		reg := &b.config.Registries[i]
+		if reg.GetType() == config.SourceTypeManaged && reg.Sanitize != nil {
+			rules[reg.Name] = reg.Sanitize.GetRules()
+		}
+	}
+	return rules
 }
 
 func baseConfig(opts ...RegistryAppOptions) (*registryAppConfig, error) {
@@ -96,6 +256,13 @@ func baseConfig(opts ...RegistryAppOptions) (*registryAppConfig, error) {
 		}
 	}
 
+	// Concurrency limits name extension API endpoints, which the configuration cannot check
+	if cfg.config != nil && cfg.config.Concurrency != nil {
+		if err := extensionv0.ValidateConcurrencyLimits(cfg.config.Concurrency.Limits); err != nil {
+			return nil, err
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -145,11 +312,85 @@ func NewRegistryApp(
 		}
 	}
 
+	// Build telemetry reporter (opt-in)
+	if cfg.telemetryReporter == nil && cfg.config.Telemetry != nil && cfg.config.Telemetry.Enabled {
+		cfg.telemetryReporter, err = telemetry.NewReporter(
+			cfg.config.Telemetry.Endpoint, cfg.config.Telemetry.GetInterval(), cfg.config.Telemetry.GetHashKey())
+		if err != nil {
+			poolCleanup()
+			return nil, fmt.Errorf("failed to build telemetry reporter: %w", err)
+		}
+	}
+
+	// Build response signer (opt-in)
+	if cfg.signer == nil && cfg.config.Signing != nil && cfg.config.Signing.Enabled {
+		cfg.signer, err = signing.LoadSigner(cfg.config.Signing.KeyFile, cfg.config.Signing.GetKeyID())
+		if err != nil {
+			poolCleanup()
+			return nil, fmt.Errorf("failed to build response signer: %w", err)
+		}
+	}
+
+	// Resolve feature flags from configuration and environment
+	cfg.features, err = features.New(cfg.config.Features)
+	if err != nil {
+		poolCleanup()
+		return nil, fmt.Errorf("failed to resolve feature flags: %w", err)
+	}
+
+	// Build cluster installer (opt-in)
+	if cfg.features.Enabled(features.ClusterInstall) {
+		if err := cfg.config.Cluster.ValidateInstall(cfg.config.Auth); err != nil {
+			poolCleanup()
+			return nil, fmt.Errorf("invalid cluster configuration: %w", err)
+		}
+	}
+	if cfg.installer == nil && cfg.features.Enabled(features.ClusterInstall) {
+		cfg.installer, err = kubernetes.NewInClusterInstaller(installerOptions(cfg.config.Cluster)...)
+		if err != nil {
+			poolCleanup()
+			return nil, fmt.Errorf("failed to build cluster installer: %w", err)
+		}
+	}
+
+	// Build link checker (opt-in)
+	if cfg.linkChecker == nil && cfg.config.LinkChecks != nil && cfg.config.LinkChecks.Enabled {
+		cfg.linkChecker = linkcheck.NewChecker(
+			linkcheck.WithInterval(cfg.config.LinkChecks.GetInterval()),
+			linkcheck.WithPackageFetcher(cfg.packageFetcher()),
+		)
+	}
+
+	// Build annotations store (opt-in)
+	if cfg.annotations == nil && cfg.config.Annotations != nil {
+		cfg.annotations, err = annotations.NewStore(cfg.config.Annotations.File)
+		if err != nil {
+			poolCleanup()
+			return nil, fmt.Errorf("failed to build annotations store: %w", err)
+		}
+	}
+
+	// Build reviews store (opt-in)
+	if cfg.reviews == nil && cfg.config.Reviews != nil {
+		cfg.reviews, err = reviews.NewStore(cfg.config.Reviews.File)
+		if err != nil {
+			poolCleanup()
+			return nil, fmt.Errorf("failed to build reviews store: %w", err)
+		}
+	}
+
+	// Build background workers supervisor
+	cfg.supervisor = buildSupervisor(cfg, syncCoordinator, registryService)
+
 	// Build HTTP server
 	httpServer, err := buildHTTPServer(ctx, cfg, registryService)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build HTTP server: %w", err)
 	}
+	operationsServer, err := buildOperationsServer(cfg, registryService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build operations server: %w", err)
+	}
 
 	// Create application context
 	appCtx, cancel := context.WithCancel(ctx)
@@ -162,12 +403,15 @@ func NewRegistryApp(
 	return &RegistryApp{
 		config: cfg.config,
 		components: &AppComponents{
-			SyncCoordinator: syncCoordinator,
-			RegistryService: registryService,
+			SyncCoordinator:   syncCoordinator,
+			RegistryService:   registryService,
+			TelemetryReporter: cfg.telemetryReporter,
+			Supervisor:        cfg.supervisor,
 		},
-		httpServer: httpServer,
-		ctx:        appCtx,
-		cancelFunc: cancelFunc,
+		httpServer:       httpServer,
+		operationsServer: operationsServer,
+		ctx:              appCtx,
+		cancelFunc:       cancelFunc,
 	}, nil
 }
 
@@ -267,6 +511,39 @@ func WithRegistryProvider(provider service.RegistryDataProvider) RegistryAppOpti
 	}
 }
 
+// buildSupervisor builds the supervisor of the background workers.
+// The sync coordinator cannot be restarted once it returns, so it is run as a one-shot worker.
+func buildSupervisor(
+	b *registryAppConfig,
+	syncCoordinator coordinator.Coordinator,
+	svc service.RegistryService,
+) *supervisor.Supervisor {
+	sup := supervisor.New()
+	sup.Add(supervisor.Worker{Name: "sync-coordinator", Run: syncCoordinator.Start, OneShot: true})
+	if b.snapshotRefresher != nil {
+		interval := b.config.Snapshot.GetRefreshInterval()
+		sup.Add(supervisor.Worker{Name: "snapshot-refresher", Run: func(ctx context.Context) error {
+			b.snapshotRefresher.RunRefresh(ctx, interval)
+			return nil
+		}})
+	}
+	if b.telemetryReporter != nil {
+		sup.Add(supervisor.Worker{Name: "telemetry-reporter", Run: func(ctx context.Context) error {
+			b.telemetryReporter.Run(ctx)
+			return nil
+		}})
+	}
+	if b.linkChecker != nil {
+		sup.Add(supervisor.Worker{Name: "link-checker", Run: func(ctx context.Context) error {
+			b.linkChecker.Run(ctx, func(ctx context.Context) ([]*upstreamv0.ServerJSON, error) {
+				return svc.ListServers(ctx, service.WithLimit[service.ListServersOptions](database.MaxPageSize))
+			})
+			return nil
+		}})
+	}
+	return sup
+}
+
 // buildSyncComponents builds sync manager, coordinator, and related components
 func buildSyncComponents(
 	ctx context.Context,
@@ -288,7 +565,11 @@ func buildSyncComponents(
 		if err := os.MkdirAll(baseDir, 0750); err != nil {
 			return nil, fmt.Errorf("failed to create data directory %s: %w", baseDir, err)
 		}
-		b.storageManager = sources.NewFileStorageManager(baseDir)
+		var storageOpts []sources.FileStorageOption
+		if b.config.FileStorage != nil && b.config.FileStorage.Indent {
+			storageOpts = append(storageOpts, sources.WithIndent())
+		}
+		b.storageManager = sources.NewFileStorageManager(baseDir, storageOpts...)
 	}
 
 	// Build status persistence (now uses dataDir as base path for per-registry status files)
@@ -313,8 +594,7 @@ func buildSyncComponents(
 					ctx,
 					kubernetes.WithSyncWriter(syncWriter),
 					kubernetes.WithRegistryName(reg.Name),
-					// TODO make it configurable
-					kubernetes.WithNamespaces("toolhive-system"),
+					kubernetes.WithNamespaces(reg.Kubernetes.GetNamespaces()...),
 				)
 				if err != nil {
 					return nil, fmt.Errorf("failed to create kubernetes reconciler: %w", err)
@@ -369,11 +649,23 @@ func buildServiceComponents(
 		}
 
 		// Create in-memory service (reads from file storage)
-		inMemorySvc, err := inmemory.New(ctx, b.registryProvider, inmemory.WithConfig(b.config))
+		snapshot := b.config.Snapshot
+		opts := []inmemory.Option{inmemory.WithConfig(b.config), inmemory.WithCacheDuration(snapshot.GetTTL())}
+		if snapshot.StaleWhileRevalidate() {
+			opts = append(opts, inmemory.WithStaleWhileRevalidate())
+		}
+		inMemorySvc, err := inmemory.New(ctx, b.registryProvider, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create in-memory registry service: %w", err)
 		}
-		slog.Info("Created in-memory registry service")
+		if snapshot.GetRefreshInterval() > 0 {
+			if refresher, ok := inMemorySvc.(inmemory.Refresher); ok {
+				b.snapshotRefresher = refresher
+			}
+		}
+		slog.Info("Created in-memory registry service",
+			"snapshot_ttl", snapshot.GetTTL(),
+			"snapshot_refresh_interval", snapshot.GetRefreshInterval())
 
 		svc = inMemorySvc
 	case config.StorageTypeDatabase:
@@ -393,10 +685,79 @@ func buildServiceComponents(
 		return nil, fmt.Errorf("unknown storage type: %s", storageType)
 	}
 
+	if b.config.Mirror != nil {
+		mirrorCache := cache.New[string, any]("mirror",
+			cache.WithBudget(b.cacheMemoryBudget()), cache.WithMaxBytes(b.cacheConfig().GetQuota("mirror")))
+		mirrorSvc, err := buildMirrorService(svc, b.config.Mirror, mirrorCache)
+		if err != nil {
+			return nil, err
+		}
+		svc = mirrorSvc
+	}
+
+	b.searchTracker = searchstats.NewTracker(searchstats.DefaultMaxQueries)
+	trackingSvc, err := searchstats.NewService(svc, b.searchTracker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create search tracking service: %w", err)
+	}
+	svc = trackingSvc
+
+	search := b.config.Search
+	if search != nil && (len(search.Boosts) > 0 || search.Experiment != nil || search.Feedback != nil) {
+		var rankingOpts []ranking.Option
+		if search.Experiment != nil {
+			b.experiment, err = experiment.New(search.Experiment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create search experiment: %w", err)
+			}
+			rankingOpts = append(rankingOpts, ranking.WithExperiment(search.Experiment))
+			slog.Info("Enabled search ranking experiment",
+				"experiment", search.Experiment.Name, "variant_count", len(search.Experiment.Variants))
+		}
+		if search.Feedback != nil {
+			b.feedbackStore = feedback.NewStore(search.Feedback)
+			rankingOpts = append(rankingOpts, ranking.WithFeedback(b.feedbackStore))
+			slog.Info("Enabled search result feedback",
+				"weight", search.Feedback.GetWeight(), "max_adjustment", search.Feedback.GetMaxAdjustment())
+		}
+		rankingSvc, err := ranking.New(svc, search.Boosts, rankingOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ranking service: %w", err)
+		}
+		slog.Info("Enabled search ranking boosts", "boost_count", len(search.Boosts))
+		svc = rankingSvc
+	}
+
 	slog.Info("Service components initialized successfully")
 	return svc, nil
 }
 
+// buildMirrorService wraps the registry service so that lookups missing the
+// local data read through to the configured upstream registry
+func buildMirrorService(
+	svc service.RegistryService, cfg *config.MirrorConfig, store client.CacheStore,
+) (service.RegistryService, error) {
+	upstreamClient, err := client.NewClient(cfg.Upstream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mirror upstream client: %w", err)
+	}
+
+	var opts []mirror.Option
+	if cfg.Sanitize != nil {
+		opts = append(opts, mirror.WithSanitizeRules(cfg.Sanitize.GetRules()))
+	}
+	mirrorSvc, err := mirror.New(svc,
+		client.NewCachingRegistry(upstreamClient, cfg.GetCacheTTL(), client.WithCacheStore(store)), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mirror service: %w", err)
+	}
+	slog.Info("Enabled read-through mirror",
+		"upstream", cfg.Upstream,
+		"cache_ttl", cfg.GetCacheTTL())
+
+	return mirrorSvc, nil
+}
+
 // buildDatabaseConnectionPool creates a database connection pool
 func buildDatabaseConnectionPool(
 	ctx context.Context,
@@ -484,6 +845,27 @@ func registerCustomArrayCodecs(ctx context.Context, conn *pgx.Conn) error {
 	return nil
 }
 
+// buildTicketFiler builds the filer of server approval requests from the ticketing configuration
+func buildTicketFiler(cfg *config.TicketingConfig) (approval.TicketFiler, error) {
+	token, err := cfg.GetToken()
+	if err != nil {
+		return nil, err
+	}
+	filer, err := approval.NewTicketFiler(approval.TicketSystem{
+		Type:      cfg.Type,
+		URL:       cfg.URL,
+		Project:   cfg.Project,
+		IssueType: cfg.IssueType,
+		Table:     cfg.Table,
+		Username:  cfg.Username,
+		Token:     token,
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build ticket filer: %w", err)
+	}
+	return filer, nil
+}
+
 // buildHTTPServer builds the HTTP server with router and middleware
 //
 //nolint:unparam // we prefer having a similar interface
@@ -501,10 +883,20 @@ func buildHTTPServer(
 			middleware.RealIP,
 			middleware.Recoverer,
 			middleware.Timeout(b.requestTimeout),
-			api.LoggingMiddleware,
+			api.NewLoggingMiddleware(b.slowRequestThresholds()),
 		}
 	}
 
+	// Record usage before auth so that rejected requests are counted as errors
+	if b.telemetryReporter != nil {
+		b.middlewares = append(b.middlewares, b.telemetryReporter.Middleware)
+	}
+
+	// Sign responses before auth so that authentication errors are signed too
+	if b.signer != nil {
+		b.middlewares = append(b.middlewares, b.signer.Middleware)
+	}
+
 	// Create auth middleware that bypasses public paths
 	publicPaths := defaultPublicPaths
 	if b.config != nil && b.config.Auth != nil && len(b.config.Auth.PublicPaths) > 0 {
@@ -513,18 +905,88 @@ func buildHTTPServer(
 	authMw := auth.WrapWithPublicPaths(b.authMiddleware, publicPaths)
 	b.middlewares = append(b.middlewares, authMw)
 
+	// Assign clients to experiment variants after auth so that access token subjects identify them
+	if b.experiment != nil {
+		b.middlewares = append(b.middlewares, b.experiment.Middleware)
+	}
+
 	// Create router with middlewares
-	router := api.NewServer(svc, api.WithMiddlewares(b.middlewares...), api.WithAuthInfoHandler(b.authInfoHandler))
+	serverOpts := []api.ServerOption{
+		api.WithMiddlewares(b.middlewares...),
+		api.WithAuthInfoHandler(b.authInfoHandler),
+		api.WithSearchTracker(b.searchTracker),
+		api.WithSupervisor(b.supervisor),
+		api.WithFeatures(b.features),
+		api.WithInstaller(b.installer),
+		api.WithCacheBudget(b.cacheMemoryBudget()),
+		api.WithSBOMFetcher(b.sbomFetcher()),
+		api.WithPackageFetcher(b.packageFetcher()),
+		api.WithChangelogFetcher(changelog.NewFetcher(changelog.WithCache(
+			cache.WithBudget(b.cacheMemoryBudget()), cache.WithMaxBytes(b.cacheConfig().GetQuota("changelog")),
+		))),
+		api.WithLinkChecker(b.linkChecker),
+		api.WithSummaries(b.summaries()),
+		api.WithExperiment(b.experiment),
+		api.WithFeedbackStore(b.feedbackStore),
+		api.WithAnnotations(b.annotations),
+		api.WithReviews(b.reviews),
+	}
+	if b.config != nil && b.config.Auth != nil {
+		serverOpts = append(serverOpts, api.WithAdmins(auth.NewAdmins(b.config.Auth.Admin)))
+	}
+	if b.config != nil && b.config.ResponseMeta != nil {
+		serverOpts = append(serverOpts, api.WithResponseMetaFilter(registry.MetaFilter{
+			Include: b.config.ResponseMeta.Include,
+			Exclude: b.config.ResponseMeta.Exclude,
+		}))
+	}
+	if rules := b.publishSanitizeRules(); len(rules) > 0 {
+		serverOpts = append(serverOpts, api.WithSanitizeRules(rules))
+	}
+	if b.config != nil && b.config.Concurrency != nil {
+		serverOpts = append(serverOpts, api.WithExtensionConcurrencyLimits(b.config.Concurrency.Limits))
+	}
+	if b.signer != nil {
+		serverOpts = append(serverOpts, api.WithSigningKeyHandler(b.signer.PublicKeyHandler()))
+	}
+	if b.config != nil && b.config.Approval != nil {
+		checker, err := approval.NewChecker(b.config.Approval.Type, b.config.Approval.URL, b.config.Approval.GetTimeout())
+		if err != nil {
+			return nil, fmt.Errorf("failed to build approval checker: %w", err)
+		}
+		serverOpts = append(serverOpts, api.WithApprovalChecker(checker))
+	}
+	if b.config != nil && b.config.Ticketing != nil {
+		filer, err := buildTicketFiler(b.config.Ticketing)
+		if err != nil {
+			return nil, err
+		}
+		serverOpts = append(serverOpts, api.WithTicketFiler(filer))
+	}
+	listeners := b.listenersConfig()
+	if listeners != nil && listeners.Operations != nil {
+		serverOpts = append(serverOpts, api.WithoutOperationalEndpoints())
+	}
+	router := api.NewServer(svc, serverOpts...)
+
+	var tlsConfig *tls.Config
+	if listeners != nil && listeners.API != nil {
+		var err error
+		if tlsConfig, err = newListenerTLSConfig(listeners.API.TLS); err != nil {
+			return nil, fmt.Errorf("failed to configure TLS of the API listener: %w", err)
+		}
+	}
 
 	// Create HTTP server
 	server := &http.Server{
 		Addr:         b.address,
 		Handler:      router,
+		TLSConfig:    tlsConfig,
 		ReadTimeout:  b.readTimeout,
 		WriteTimeout: b.writeTimeout,
 		IdleTimeout:  b.idleTimeout,
 	}
 
-	slog.Info("HTTP server configured", "address", b.address)
+	slog.Info("HTTP server configured", "address", b.address, "tls", tlsConfig != nil)
 	return server, nil
 }
@@ -71,6 +71,19 @@ func TestRegistryAppWithFunctionsError(t *testing.T) {
 	require.Nil(t, built)
 }
 
+func TestRegistryAppConcurrencyLimits(t *testing.T) {
+	t.Parallel()
+	cfg := createValidTestConfig()
+	cfg.Concurrency = &config.ConcurrencyConfig{Limits: map[string]int{"install": 1}}
+	_, err := baseConfig(WithConfig(cfg))
+	require.NoError(t, err)
+
+	cfg = createValidTestConfig()
+	cfg.Concurrency = &config.ConcurrencyConfig{Limits: map[string]int{"find_alternatives": 2}}
+	_, err = baseConfig(WithConfig(cfg))
+	require.ErrorContains(t, err, "concurrency.limits: unknown endpoint 'find_alternatives'")
+}
+
 func TestRegistryAppBuilder_WithAddress(t *testing.T) {
 	t.Parallel()
 	built, err := baseConfig(
@@ -478,6 +491,35 @@ func TestBuildServiceComponents(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "snapshot refresh interval - sets the snapshot refresher",
+			config: &registryAppConfig{
+				config: func() *config.Config {
+					cfg := createValidTestConfig()
+					cfg.Snapshot = &config.SnapshotConfig{RefreshInterval: "1m"}
+					return cfg
+				}(),
+				storageManager: sources.NewFileStorageManager(t.TempDir()),
+			},
+			setupMocks: func(
+				t *testing.T,
+				_ *gomock.Controller,
+			) *mocks.MockRegistryDataProvider {
+				t.Helper()
+				return nil
+			},
+			wantErr: false,
+			//nolint:thelper // we want to see these lines
+			verify: func(
+				t *testing.T,
+				svc service.RegistryService,
+				config *registryAppConfig,
+				_ service.RegistryDataProvider,
+			) {
+				assert.NotNil(t, svc)
+				assert.NotNil(t, config.snapshotRefresher, "the in-memory service should refresh the snapshot")
+			},
+		},
 		{
 			name: "success with pre-set registryProvider - skips creation",
 			config: &registryAppConfig{
@@ -816,3 +858,18 @@ func TestNewRegistryApp(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRegistryAppClusterInstall(t *testing.T) {
+	t.Parallel()
+
+	cfg := createValidTestConfig()
+	cfg.Features = map[string]bool{"cluster-install": true}
+	_, err := NewRegistryApp(context.Background(), WithConfig(cfg), WithDataDirectory(t.TempDir()))
+	require.ErrorContains(t, err, "cluster.namespaces is required")
+
+	cfg = createValidTestConfig()
+	cfg.Features = map[string]bool{"cluster-install": true}
+	cfg.Cluster = &config.ClusterConfig{Namespaces: []string{"mcp"}}
+	_, err = NewRegistryApp(context.Background(), WithConfig(cfg), WithDataDirectory(t.TempDir()))
+	require.ErrorContains(t, err, "requires authenticated callers")
+}
@@ -2,7 +2,9 @@ package app
 
 import (
 	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/supervisor"
 	"github.com/stacklok/toolhive-registry-server/internal/sync/coordinator"
+	"github.com/stacklok/toolhive-registry-server/internal/telemetry"
 )
 
 // AppComponents groups all application components
@@ -14,4 +16,10 @@ type AppComponents struct {
 
 	// RegistryService provides registry business logic
 	RegistryService service.RegistryService
+
+	// TelemetryReporter sends anonymized usage reports, nil unless telemetry is enabled
+	TelemetryReporter *telemetry.Reporter
+
+	// Supervisor runs the background workers (sync coordinator, telemetry reporter)
+	Supervisor *supervisor.Supervisor
 }
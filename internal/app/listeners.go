@@ -0,0 +1,96 @@
+package app
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api"
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// listenersConfig returns the listeners configuration, nil when not configured
+func (b *registryAppConfig) listenersConfig() *config.ListenersConfig {
+	if b.config == nil {
+		return nil
+	}
+	return b.config.Listeners
+}
+
+// buildOperationsServer builds the HTTP server of the operational endpoints, nil unless they are
+// configured to have a listener of their own
+func buildOperationsServer(b *registryAppConfig, svc service.RegistryService) (*http.Server, error) {
+	listeners := b.listenersConfig()
+	if listeners == nil || listeners.Operations == nil {
+		return nil, nil
+	}
+	if listeners.Operations.Address == b.address {
+		return nil, fmt.Errorf("operations listener address %s is the address of the API listener", b.address)
+	}
+
+	tlsConfig, err := newListenerTLSConfig(listeners.Operations.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure TLS of the operations listener: %w", err)
+	}
+
+	slog.Info("Operations server configured", "address", listeners.Operations.Address, "tls", tlsConfig != nil)
+	return &http.Server{
+		Addr:         listeners.Operations.Address,
+		Handler:      api.OperationsRouter(svc, b.supervisor),
+		TLSConfig:    tlsConfig,
+		ReadTimeout:  b.readTimeout,
+		WriteTimeout: b.writeTimeout,
+		IdleTimeout:  b.idleTimeout,
+	}, nil
+}
+
+// newListenerTLSConfig builds the TLS configuration of a listener, nil when it serves plain HTTP
+func newListenerTLSConfig(cfg *config.ListenerTLSConfig) (*tls.Config, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.ClientCACertFile != "" {
+		pem, err := os.ReadFile(filepath.Clean(cfg.ClientCACertFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA certificates: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.ClientCACertFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// serveHTTP serves HTTP, over TLS when the server has a TLS configuration, until the server is shut down
+func serveHTTP(server *http.Server) error {
+	var err error
+	if server.TLSConfig != nil {
+		// The certificate is in the TLS configuration
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = server.ListenAndServe()
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,152 @@
+package app
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+// writeSelfSignedCert writes a self-signed certificate and its key to dir, and returns their paths
+func writeSelfSignedCert(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestNewListenerTLSConfig(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSignedCert(t, dir)
+
+	tests := []struct {
+		name           string
+		cfg            *config.ListenerTLSConfig
+		wantErr        string
+		wantTLS        bool
+		wantClientAuth tls.ClientAuthType
+	}{
+		{
+			name: "plain HTTP",
+		},
+		{
+			name:    "server certificate",
+			cfg:     &config.ListenerTLSConfig{CertFile: certFile, KeyFile: keyFile},
+			wantTLS: true,
+		},
+		{
+			name:           "mutual TLS",
+			cfg:            &config.ListenerTLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCACertFile: certFile},
+			wantTLS:        true,
+			wantClientAuth: tls.RequireAndVerifyClientCert,
+		},
+		{
+			name:    "missing certificate",
+			cfg:     &config.ListenerTLSConfig{CertFile: filepath.Join(dir, "missing.crt"), KeyFile: keyFile},
+			wantErr: "failed to load certificate",
+		},
+		{
+			name:    "client CA bundle without certificates",
+			cfg:     &config.ListenerTLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCACertFile: keyFile},
+			wantErr: "no certificates found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tlsConfig, err := newListenerTLSConfig(tt.cfg)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if !tt.wantTLS {
+				assert.Nil(t, tlsConfig)
+				return
+			}
+			require.NotNil(t, tlsConfig)
+			assert.Len(t, tlsConfig.Certificates, 1)
+			assert.Equal(t, tt.wantClientAuth, tlsConfig.ClientAuth)
+		})
+	}
+}
+
+func TestBuildOperationsServer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		listeners   *config.ListenersConfig
+		wantAddress string
+		wantErr     string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:        "separate address",
+			listeners:   &config.ListenersConfig{Operations: &config.OperationsListenerConfig{Address: ":8081"}},
+			wantAddress: ":8081",
+		},
+		{
+			name:      "address of the API listener",
+			listeners: &config.ListenersConfig{Operations: &config.OperationsListenerConfig{Address: defaultHTTPAddress}},
+			wantErr:   "is the address of the API listener",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := createValidTestConfig()
+			cfg.Listeners = tt.listeners
+			b := &registryAppConfig{config: cfg, address: defaultHTTPAddress}
+
+			server, err := buildOperationsServer(b, nil)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantAddress == "" {
+				assert.Nil(t, server)
+				return
+			}
+			require.NotNil(t, server)
+			assert.Equal(t, tt.wantAddress, server.Addr)
+			assert.Nil(t, server.TLSConfig)
+		})
+	}
+}
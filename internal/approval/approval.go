@@ -0,0 +1,152 @@
+// Package approval consults external approval systems before install instructions are handed out.
+//
+// Regulated organizations often require servers to be approved before they are installed.
+// A Checker asks such a system, either a plain HTTP webhook or an Open Policy Agent (OPA)
+// data API query, whether a server version may be installed. When it may not, the decision
+// carries the reason and the ticket filed to request approval, if any. A TicketFiler files
+// such approval requests in Jira or ServiceNow on behalf of users.
+package approval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+)
+
+const (
+	// TypeWebhook posts the request to an HTTP endpoint answering with a Decision
+	TypeWebhook = "webhook"
+	// TypeOPA queries an Open Policy Agent data API document with the request as input
+	TypeOPA = "opa"
+
+	// DefaultTimeout is the default timeout of approval checks
+	DefaultTimeout = 5 * time.Second
+
+	// maxResponseSize caps the size of approval system responses
+	maxResponseSize = 1024 * 1024
+)
+
+// Request describes the install instructions about to be handed out
+type Request struct {
+	Server  string `json:"server"`
+	Version string `json:"version"`
+	// Action names the requested instructions (e.g. "offline-bundle")
+	Action string `json:"action"`
+}
+
+// Decision is the answer of an approval system
+type Decision struct {
+	Approved bool `json:"approved"`
+	// Ticket identifies the approval request filed for unapproved servers, if any
+	Ticket string `json:"ticket,omitempty"`
+	// Reason explains the decision
+	Reason string `json:"reason,omitempty"`
+}
+
+// Checker consults an approval system
+type Checker interface {
+	// Check returns whether the requested install instructions may be handed out
+	Check(ctx context.Context, req Request) (*Decision, error)
+}
+
+// NewChecker creates a checker of the given type (TypeWebhook or TypeOPA) for the endpoint URL.
+// A zero timeout uses DefaultTimeout.
+func NewChecker(checkerType, endpoint string, timeout time.Duration) (Checker, error) {
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	client := &http.Client{Timeout: timeout, Transport: httpclient.NewTimingTransport(nil)}
+	switch checkerType {
+	case TypeWebhook:
+		return &webhookChecker{endpoint: endpoint, client: client}, nil
+	case TypeOPA:
+		return &opaChecker{endpoint: endpoint, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unsupported approval checker type: %s", checkerType)
+	}
+}
+
+// webhookChecker posts the request to an HTTP endpoint answering with a Decision
+type webhookChecker struct {
+	endpoint string
+	client   *http.Client
+}
+
+// Check implements Checker
+func (c *webhookChecker) Check(ctx context.Context, req Request) (*Decision, error) {
+	var decision Decision
+	if err := post(ctx, c.client, c.endpoint, req, &decision); err != nil {
+		return nil, err
+	}
+	return &decision, nil
+}
+
+// opaChecker queries an OPA data API document, e.g. http://opa:8181/v1/data/mcp/install.
+// The document is either a boolean or an object shaped like a Decision.
+type opaChecker struct {
+	endpoint string
+	client   *http.Client
+}
+
+// Check implements Checker
+func (c *opaChecker) Check(ctx context.Context, req Request) (*Decision, error) {
+	var response struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := post(ctx, c.client, c.endpoint, map[string]Request{"input": req}, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Result) == 0 {
+		return &Decision{Reason: "policy is undefined"}, nil
+	}
+
+	var approved bool
+	if err := json.Unmarshal(response.Result, &approved); err == nil {
+		return &Decision{Approved: approved}, nil
+	}
+	var decision Decision
+	if err := json.Unmarshal(response.Result, &decision); err != nil {
+		return nil, fmt.Errorf("invalid OPA policy result: %w", err)
+	}
+	return &decision, nil
+}
+
+// post sends a JSON payload to an endpoint and decodes the JSON response of a 200 answer
+func post(ctx context.Context, client *http.Client, endpoint string, payload, target any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode approval request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create approval request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", httpclient.UserAgent())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach approval system: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return &httpclient.HTTPError{StatusCode: resp.StatusCode, URL: endpoint, Message: resp.Status}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read approval response: %w", err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("invalid approval response: %w", err)
+	}
+	return nil
+}
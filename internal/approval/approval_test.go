@@ -0,0 +1,144 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRequest = Request{Server: "io.example/server", Version: "1.0.0", Action: "offline-bundle"}
+
+func TestNewChecker(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewChecker(TypeWebhook, "https://approvals.example.com", 0)
+	assert.NoError(t, err)
+	_, err = NewChecker(TypeOPA, "http://opa:8181/v1/data/mcp/install", 0)
+	assert.NoError(t, err)
+	_, err = NewChecker("ldap", "ldap://approvals", 0)
+	assert.ErrorContains(t, err, "unsupported approval checker type")
+}
+
+func TestWebhookChecker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		status       int
+		response     string
+		wantDecision *Decision
+		wantErr      bool
+	}{
+		{
+			name:         "approved",
+			status:       http.StatusOK,
+			response:     `{"approved": true}`,
+			wantDecision: &Decision{Approved: true},
+		},
+		{
+			name:         "approval requested",
+			status:       http.StatusOK,
+			response:     `{"approved": false, "ticket": "TICKET-123", "reason": "server is not on the allow list"}`,
+			wantDecision: &Decision{Ticket: "TICKET-123", Reason: "server is not on the allow list"},
+		},
+		{
+			name:    "approval system error",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+		{
+			name:     "invalid response",
+			status:   http.StatusOK,
+			response: `approved`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req Request
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, testRequest, req)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			t.Cleanup(server.Close)
+
+			checker, err := NewChecker(TypeWebhook, server.URL, 0)
+			require.NoError(t, err)
+
+			decision, err := checker.Check(context.Background(), testRequest)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDecision, decision)
+		})
+	}
+}
+
+func TestOPAChecker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		response     string
+		wantDecision *Decision
+		wantErr      bool
+	}{
+		{
+			name:         "boolean result",
+			response:     `{"result": true}`,
+			wantDecision: &Decision{Approved: true},
+		},
+		{
+			name:         "decision result",
+			response:     `{"result": {"approved": false, "ticket": "TICKET-123"}}`,
+			wantDecision: &Decision{Ticket: "TICKET-123"},
+		},
+		{
+			name:         "undefined policy",
+			response:     `{}`,
+			wantDecision: &Decision{Reason: "policy is undefined"},
+		},
+		{
+			name:     "unexpected result",
+			response: `{"result": "yes"}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Input Request `json:"input"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, testRequest, body.Input)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			t.Cleanup(server.Close)
+
+			checker, err := NewChecker(TypeOPA, server.URL, 0)
+			require.NoError(t, err)
+
+			decision, err := checker.Check(context.Background(), testRequest)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDecision, decision)
+		})
+	}
+}
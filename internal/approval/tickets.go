@@ -0,0 +1,206 @@
+package approval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+)
+
+const (
+	// TicketSystemJira files tickets as Jira issues
+	TicketSystemJira = "jira"
+	// TicketSystemServiceNow files tickets as ServiceNow table records
+	TicketSystemServiceNow = "servicenow"
+
+	// DefaultJiraIssueType is the Jira issue type used when none is configured
+	DefaultJiraIssueType = "Task"
+	// DefaultServiceNowTable is the ServiceNow table used when none is configured
+	DefaultServiceNowTable = "sc_request"
+)
+
+// Ticket is an approval request to file
+type Ticket struct {
+	Summary     string
+	Description string
+}
+
+// FiledTicket identifies a ticket filed in the ticketing system
+type FiledTicket struct {
+	// ID is the ticket key or number (e.g. "MCP-123" or "REQ0010001")
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// TicketFiler files approval requests in a ticketing system
+type TicketFiler interface {
+	// File files a ticket and returns its identifier and URL
+	File(ctx context.Context, ticket Ticket) (*FiledTicket, error)
+}
+
+// TicketSystem describes the ticketing system approval requests are filed in
+type TicketSystem struct {
+	// Type is TicketSystemJira or TicketSystemServiceNow
+	Type string
+	// URL is the base URL of the instance (e.g. "https://example.atlassian.net")
+	URL string
+	// Project is the Jira project key
+	Project string
+	// IssueType is the Jira issue type, defaulting to DefaultJiraIssueType
+	IssueType string
+	// Table is the ServiceNow table, defaulting to DefaultServiceNowTable
+	Table string
+	// Username authenticates with basic auth alongside Token. Without a username,
+	// Token is sent as a bearer token.
+	Username string
+	Token    string
+}
+
+// NewTicketFiler creates a filer for the given ticketing system. A zero timeout uses DefaultTimeout.
+func NewTicketFiler(system TicketSystem, timeout time.Duration) (TicketFiler, error) {
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	base := ticketBase{
+		url:      strings.TrimSuffix(system.URL, "/"),
+		username: system.Username,
+		token:    system.Token,
+		client:   &http.Client{Timeout: timeout, Transport: httpclient.NewTimingTransport(nil)},
+	}
+	switch system.Type {
+	case TicketSystemJira:
+		if system.Project == "" {
+			return nil, fmt.Errorf("jira project is required")
+		}
+		issueType := system.IssueType
+		if issueType == "" {
+			issueType = DefaultJiraIssueType
+		}
+		return &jiraFiler{ticketBase: base, project: system.Project, issueType: issueType}, nil
+	case TicketSystemServiceNow:
+		table := system.Table
+		if table == "" {
+			table = DefaultServiceNowTable
+		}
+		return &serviceNowFiler{ticketBase: base, table: table}, nil
+	default:
+		return nil, fmt.Errorf("unsupported ticket system type: %s", system.Type)
+	}
+}
+
+// ticketBase holds the connection settings shared by ticketing systems
+type ticketBase struct {
+	url      string
+	username string
+	token    string
+	client   *http.Client
+}
+
+// create posts a JSON payload to the given path and decodes the JSON response of a 200 or 201 answer
+func (b *ticketBase) create(ctx context.Context, path string, payload, target any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode ticket: %w", err)
+	}
+	endpoint := b.url + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create ticket request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", httpclient.UserAgent())
+	if b.username != "" {
+		req.SetBasicAuth(b.username, b.token)
+	} else if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach ticket system: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return &httpclient.HTTPError{StatusCode: resp.StatusCode, URL: endpoint, Message: resp.Status}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read ticket response: %w", err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("invalid ticket response: %w", err)
+	}
+	return nil
+}
+
+// jiraFiler files tickets as issues through the Jira REST API
+type jiraFiler struct {
+	ticketBase
+	project   string
+	issueType string
+}
+
+// File implements TicketFiler
+func (f *jiraFiler) File(ctx context.Context, ticket Ticket) (*FiledTicket, error) {
+	payload := map[string]any{
+		"fields": map[string]any{
+			"project":     map[string]string{"key": f.project},
+			"issuetype":   map[string]string{"name": f.issueType},
+			"summary":     ticket.Summary,
+			"description": ticket.Description,
+		},
+	}
+	var issue struct {
+		Key string `json:"key"`
+	}
+	if err := f.create(ctx, "/rest/api/2/issue", payload, &issue); err != nil {
+		return nil, err
+	}
+	if issue.Key == "" {
+		return nil, fmt.Errorf("jira returned no issue key")
+	}
+	return &FiledTicket{ID: issue.Key, URL: f.url + "/browse/" + issue.Key}, nil
+}
+
+// serviceNowFiler files tickets as table records through the ServiceNow Table API
+type serviceNowFiler struct {
+	ticketBase
+	table string
+}
+
+// File implements TicketFiler
+func (f *serviceNowFiler) File(ctx context.Context, ticket Ticket) (*FiledTicket, error) {
+	payload := map[string]string{
+		"short_description": ticket.Summary,
+		"description":       ticket.Description,
+	}
+	var response struct {
+		Result struct {
+			SysID  string `json:"sys_id"`
+			Number string `json:"number"`
+		} `json:"result"`
+	}
+	if err := f.create(ctx, "/api/now/table/"+url.PathEscape(f.table), payload, &response); err != nil {
+		return nil, err
+	}
+	if response.Result.SysID == "" {
+		return nil, fmt.Errorf("servicenow returned no record id")
+	}
+	id := response.Result.Number
+	if id == "" {
+		id = response.Result.SysID
+	}
+	record := url.Values{"uri": {f.table + ".do?sys_id=" + response.Result.SysID}}
+	return &FiledTicket{ID: id, URL: f.url + "/nav_to.do?" + record.Encode()}, nil
+}
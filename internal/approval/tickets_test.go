@@ -0,0 +1,129 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTicket = Ticket{Summary: "Approve MCP server io.example/server 1.0.0", Description: "Risk profile"}
+
+func TestNewTicketFiler(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTicketFiler(TicketSystem{Type: TicketSystemJira, URL: "https://example.atlassian.net"}, 0)
+	assert.ErrorContains(t, err, "jira project is required")
+	_, err = NewTicketFiler(TicketSystem{Type: "bugzilla", URL: "https://bugs.example.com"}, 0)
+	assert.ErrorContains(t, err, "unsupported ticket system type")
+}
+
+func TestJiraFiler(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/issue", r.URL.Path)
+		username, token, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "bot@example.com", username)
+		assert.Equal(t, "secret", token)
+
+		var body struct {
+			Fields struct {
+				Project     map[string]string `json:"project"`
+				IssueType   map[string]string `json:"issuetype"`
+				Summary     string            `json:"summary"`
+				Description string            `json:"description"`
+			} `json:"fields"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "MCP", body.Fields.Project["key"])
+		assert.Equal(t, DefaultJiraIssueType, body.Fields.IssueType["name"])
+		assert.Equal(t, testTicket.Summary, body.Fields.Summary)
+		assert.Equal(t, testTicket.Description, body.Fields.Description)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"10001","key":"MCP-123","self":"https://example.atlassian.net/rest/api/2/issue/10001"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	filer, err := NewTicketFiler(TicketSystem{
+		Type:     TicketSystemJira,
+		URL:      server.URL + "/",
+		Project:  "MCP",
+		Username: "bot@example.com",
+		Token:    "secret",
+	}, 0)
+	require.NoError(t, err)
+
+	filed, err := filer.File(context.Background(), testTicket)
+	require.NoError(t, err)
+	assert.Equal(t, &FiledTicket{ID: "MCP-123", URL: server.URL + "/browse/MCP-123"}, filed)
+}
+
+func TestServiceNowFiler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		status     int
+		response   string
+		wantTicket *FiledTicket
+		wantErr    bool
+	}{
+		{
+			name:     "record created",
+			status:   http.StatusCreated,
+			response: `{"result":{"sys_id":"abc123","number":"REQ0010001"}}`,
+			wantTicket: &FiledTicket{
+				ID:  "REQ0010001",
+				URL: "/nav_to.do?uri=sc_request.do%3Fsys_id%3Dabc123",
+			},
+		},
+		{
+			name:     "no record id",
+			status:   http.StatusCreated,
+			response: `{"result":{}}`,
+			wantErr:  true,
+		},
+		{
+			name:    "unauthorized",
+			status:  http.StatusUnauthorized,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/now/table/sc_request", r.URL.Path)
+				assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+				var body map[string]string
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, testTicket.Summary, body["short_description"])
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			t.Cleanup(server.Close)
+
+			filer, err := NewTicketFiler(TicketSystem{Type: TicketSystemServiceNow, URL: server.URL, Token: "secret"}, 0)
+			require.NoError(t, err)
+
+			filed, err := filer.File(context.Background(), testTicket)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tt.wantTicket.URL = server.URL + tt.wantTicket.URL
+			assert.Equal(t, tt.wantTicket, filed)
+		})
+	}
+}
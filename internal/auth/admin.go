@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+// Admins identifies the administrators of the API from the claims of their token
+type Admins struct {
+	claim  string
+	values []string
+}
+
+// NewAdmins creates the administrators identified by the given configuration.
+// Without configuration it returns nil, which identifies no administrator.
+func NewAdmins(cfg *config.AdminConfig) *Admins {
+	if cfg == nil {
+		return nil
+	}
+	return &Admins{claim: cfg.GetClaim(), values: cfg.Values}
+}
+
+// IsAdmin reports whether the caller authenticated by the auth middleware is an administrator,
+// that is whether their admin claim, a list or a single string, holds one of the configured values.
+// Anonymous callers are never administrators.
+func (a *Admins) IsAdmin(ctx context.Context) bool {
+	if a == nil {
+		return false
+	}
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	switch value := claims[a.claim].(type) {
+	case string:
+		return a.granted(value)
+	case []string:
+		return slices.ContainsFunc(value, a.granted)
+	case []any:
+		return slices.ContainsFunc(value, func(v any) bool {
+			name, ok := v.(string)
+			return ok && a.granted(name)
+		})
+	}
+	return false
+}
+
+// granted reports whether a claim value is granted administrative operations
+func (a *Admins) granted(value string) bool {
+	return slices.Contains(a.values, value)
+}
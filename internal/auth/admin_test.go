@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+func TestAdminsIsAdmin(t *testing.T) {
+	t.Parallel()
+
+	admins := NewAdmins(&config.AdminConfig{Values: []string{"registry-admins"}})
+	roles := NewAdmins(&config.AdminConfig{Claim: "roles", Values: []string{"admin"}})
+
+	tests := []struct {
+		name   string
+		admins *Admins
+		claims jwt.MapClaims
+		want   bool
+	}{
+		{name: "group list", admins: admins, claims: jwt.MapClaims{"groups": []any{"dev", "registry-admins"}}, want: true},
+		{name: "string list", admins: admins, claims: jwt.MapClaims{"groups": []string{"registry-admins"}}, want: true},
+		{name: "single group", admins: admins, claims: jwt.MapClaims{"groups": "registry-admins"}, want: true},
+		{name: "other groups", admins: admins, claims: jwt.MapClaims{"groups": []any{"dev", 1}}},
+		{name: "missing claim", admins: admins, claims: jwt.MapClaims{"sub": "alice"}},
+		{name: "custom claim", admins: roles, claims: jwt.MapClaims{"roles": []any{"admin"}}, want: true},
+		{name: "default claim ignored with custom claim", admins: roles, claims: jwt.MapClaims{"groups": "admin"}},
+		{name: "anonymous", admins: admins},
+		{name: "not configured", claims: jwt.MapClaims{"groups": "registry-admins"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = ContextWithClaims(ctx, tt.claims)
+			}
+			assert.Equal(t, tt.want, tt.admins.IsAdmin(ctx))
+		})
+	}
+}
@@ -61,14 +61,10 @@ func createOAuthMiddleware(
 	issuerURLs := make([]string, len(oauth.Providers))
 
 	for i, p := range oauth.Providers {
-		// Read client secret from file if configured (read immediately before creating provider)
-		var clientSecret string
-		if p.ClientSecretFile != "" {
-			secret, err := p.GetClientSecret()
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to read client secret for provider %q: %w", p.Name, err)
-			}
-			clientSecret = secret
+		// Resolve the client secret if configured (read immediately before creating provider)
+		clientSecret, err := p.GetClientSecret()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read client secret for provider %q: %w", p.Name, err)
 		}
 
 		providers[i] = providerConfig{
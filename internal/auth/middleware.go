@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stacklok/toolhive/pkg/auth"
 )
 
@@ -27,11 +28,29 @@ const (
 	errorCodeInvalidToken = "invalid_token"
 )
 
+// claimsContextKey is the request context key of the claims of the authenticated caller
+type claimsContextKey struct{}
+
+// ClaimsFromContext returns the token claims of the caller authenticated by the auth middleware.
+// The boolean result is false for anonymous requests.
+func ClaimsFromContext(ctx context.Context) (jwt.MapClaims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(jwt.MapClaims)
+	return claims, ok && claims != nil
+}
+
+// ContextWithClaims returns a copy of ctx carrying the claims of an authenticated caller
+func ContextWithClaims(ctx context.Context, claims jwt.MapClaims) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
 // validationResult contains the outcome of token validation
 type validationResult struct {
 	// Provider is the name of the provider that validated the token
 	Provider string
 
+	// Claims are the claims of the validated token
+	Claims jwt.MapClaims
+
 	// Error is set if validation failed
 	Error error
 
@@ -138,8 +157,7 @@ func (m *multiProviderMiddleware) Middleware(next http.Handler) http.Handler {
 			"provider", result.Provider,
 			"remote_addr", r.RemoteAddr,
 			"path", r.URL.Path)
-		// TODO: Store claims in request context for downstream handlers (needed for authorization/scope enforcement)
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(ContextWithClaims(r.Context(), result.Claims)))
 	})
 }
 
@@ -148,7 +166,7 @@ func (m *multiProviderMiddleware) validateToken(ctx context.Context, token strin
 	providerErrors := make([]providerError, 0, len(m.validators))
 
 	for _, nv := range m.validators {
-		_, err := nv.Validator.ValidateToken(ctx, token)
+		claims, err := nv.Validator.ValidateToken(ctx, token)
 		if err != nil {
 			providerErrors = append(providerErrors, providerError{
 				Provider: nv.Name,
@@ -160,6 +178,7 @@ func (m *multiProviderMiddleware) validateToken(ctx context.Context, token strin
 
 		return validationResult{
 			Provider: nv.Name,
+			Claims:   claims,
 			Errors:   providerErrors,
 		}
 	}
@@ -107,8 +107,10 @@ func TestMultiProviderMiddleware_Middleware(t *testing.T) {
 			require.NoError(t, err)
 
 			called := false
-			handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			var claims map[string]any
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				called = true
+				claims, _ = ClaimsFromContext(r.Context())
 				w.WriteHeader(http.StatusOK)
 			})
 
@@ -124,6 +126,9 @@ func TestMultiProviderMiddleware_Middleware(t *testing.T) {
 
 			assert.Equal(t, tt.wantStatus, rr.Code)
 			assert.Equal(t, tt.wantCalled, called)
+			if called {
+				assert.Equal(t, "user", claims["sub"], "claims are available to downstream handlers")
+			}
 
 			if tt.wantStatus == http.StatusUnauthorized {
 				assert.NotEmpty(t, rr.Header().Get("WWW-Authenticate"))
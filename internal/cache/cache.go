@@ -0,0 +1,285 @@
+// Package cache provides size-bounded in-memory LRU caches sharing a global memory budget.
+//
+// Every cache accounts for the approximate size of its entries. A cache evicts its least
+// recently used entries when it exceeds its own quota, or when the Budget shared by all caches
+// has no room left for a new entry. Each cache keeps hit, miss and eviction counters that are
+// reported through Budget.Stats.
+package cache
+
+import (
+	"container/list"
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// Stats reports the usage of a cache
+type Stats struct {
+	Name string `json:"name"`
+	// Entries is the number of cached entries
+	Entries int `json:"entries"`
+	// Bytes is the approximate size of the cached entries
+	Bytes int64 `json:"bytes"`
+	// MaxBytes is the quota of the cache, 0 when it is only bound by the budget
+	MaxBytes  int64  `json:"maxBytes,omitempty"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	// Rejections counts entries that were not cached because they did not fit
+	Rejections uint64 `json:"rejections"`
+}
+
+// BudgetStats reports the usage of a memory budget and of the caches sharing it
+type BudgetStats struct {
+	// MaxBytes is the memory budget, 0 when unbounded
+	MaxBytes int64   `json:"maxBytes"`
+	Bytes    int64   `json:"bytes"`
+	Caches   []Stats `json:"caches"`
+}
+
+// Budget is a memory budget shared by several caches. It is safe for concurrent use.
+type Budget struct {
+	mu       sync.Mutex
+	maxBytes int64
+	used     int64
+	caches   []statser
+}
+
+// statser is implemented by the caches registered with a budget
+type statser interface {
+	Stats() Stats
+}
+
+// NewBudget creates a memory budget of maxBytes. A non-positive maxBytes leaves the caches
+// sharing the budget bound by their own quotas only.
+func NewBudget(maxBytes int64) *Budget {
+	return &Budget{maxBytes: max(maxBytes, 0)}
+}
+
+// reserve takes size bytes from the budget, reporting false when there is no room left
+func (b *Budget) reserve(size int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.maxBytes > 0 && b.used+size > b.maxBytes {
+		return false
+	}
+	b.used += size
+	return true
+}
+
+// release returns size bytes to the budget
+func (b *Budget) release(size int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used -= size
+}
+
+// register adds a cache to the caches reported by Stats
+func (b *Budget) register(c statser) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.caches = append(b.caches, c)
+}
+
+// Stats reports the usage of the budget and of every cache sharing it, sorted by cache name
+func (b *Budget) Stats() BudgetStats {
+	b.mu.Lock()
+	stats := BudgetStats{MaxBytes: b.maxBytes, Bytes: b.used}
+	caches := append([]statser(nil), b.caches...)
+	b.mu.Unlock()
+
+	stats.Caches = make([]Stats, 0, len(caches))
+	for _, c := range caches {
+		stats.Caches = append(stats.Caches, c.Stats())
+	}
+	sort.Slice(stats.Caches, func(i, j int) bool {
+		return stats.Caches[i].Name < stats.Caches[j].Name
+	})
+	return stats
+}
+
+// LRU is a cache evicting its least recently used entries to stay within its quota and
+// the shared budget. It is safe for concurrent use.
+type LRU[K comparable, V any] struct {
+	name       string
+	maxBytes   int64
+	maxEntries int
+	budget     *Budget
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[K]*list.Element
+	bytes   int64
+	stats   Stats
+}
+
+// entry is a cached value and its accounted size
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+	size  int64
+}
+
+// Option configures an LRU cache
+type Option func(*options)
+
+// options holds the settings of an LRU cache
+type options struct {
+	maxBytes   int64
+	maxEntries int
+	budget     *Budget
+}
+
+// WithMaxBytes sets the quota of the cache
+func WithMaxBytes(maxBytes int64) Option {
+	return func(o *options) {
+		o.maxBytes = maxBytes
+	}
+}
+
+// WithMaxEntries caps the number of cached entries
+func WithMaxEntries(maxEntries int) Option {
+	return func(o *options) {
+		o.maxEntries = maxEntries
+	}
+}
+
+// WithBudget makes the cache share the given memory budget and report its stats through it
+func WithBudget(budget *Budget) Option {
+	return func(o *options) {
+		o.budget = budget
+	}
+}
+
+// New creates an LRU cache with the given name, used to report its stats.
+// Without options, the cache is unbounded.
+func New[K comparable, V any](name string, opts ...Option) *LRU[K, V] {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	c := &LRU[K, V]{
+		name:       name,
+		maxBytes:   max(o.maxBytes, 0),
+		maxEntries: max(o.maxEntries, 0),
+		budget:     o.budget,
+		order:      list.New(),
+		entries:    make(map[K]*list.Element),
+	}
+	if c.budget != nil {
+		c.budget.register(c)
+	}
+	return c
+}
+
+// Get returns the value cached for key and marks it as recently used
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.order.MoveToFront(elem)
+	return elem.Value.(*entry[K, V]).value, true
+}
+
+// Add caches value for key, accounting for size bytes, and evicts least recently used
+// entries to make room for it. It reports false when the value does not fit in the cache
+// quota or in the shared budget, in which case it is not cached.
+func (c *LRU[K, V]) Add(key K, value V, size int64) bool {
+	size = max(size, 0)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+	if c.maxBytes > 0 && size > c.maxBytes {
+		c.stats.Rejections++
+		return false
+	}
+	for (c.maxBytes > 0 && c.bytes+size > c.maxBytes) ||
+		(c.maxEntries > 0 && len(c.entries) >= c.maxEntries) {
+		c.evictOldest()
+	}
+	for c.budget != nil && !c.budget.reserve(size) {
+		if c.order.Len() == 0 {
+			c.stats.Rejections++
+			return false
+		}
+		c.evictOldest()
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, size: size})
+	c.bytes += size
+	return true
+}
+
+// Remove drops the value cached for key, if any
+func (c *LRU[K, V]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+}
+
+// Purge drops all cached values
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.order.Len() > 0 {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Len returns the number of cached values
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Stats reports the usage of the cache
+func (c *LRU[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Name = c.name
+	stats.Entries = len(c.entries)
+	stats.Bytes = c.bytes
+	stats.MaxBytes = c.maxBytes
+	return stats
+}
+
+// evictOldest drops the least recently used entry and counts the eviction
+func (c *LRU[K, V]) evictOldest() {
+	if elem := c.order.Back(); elem != nil {
+		c.removeElement(elem)
+		c.stats.Evictions++
+	}
+}
+
+// removeElement drops an entry and returns its size to the budget
+func (c *LRU[K, V]) removeElement(elem *list.Element) {
+	e := c.order.Remove(elem).(*entry[K, V])
+	delete(c.entries, e.key)
+	c.bytes -= e.size
+	if c.budget != nil {
+		c.budget.release(e.size)
+	}
+}
+
+// JSONSize approximates the memory held by a value with the length of its JSON encoding.
+// Values that cannot be encoded are accounted for as empty.
+func JSONSize(v any) int64 {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return int64(len(data))
+}
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU(t *testing.T) {
+	t.Parallel()
+
+	c := New[string, int]("test", WithMaxBytes(30))
+	require.True(t, c.Add("a", 1, 10))
+	require.True(t, c.Add("b", 2, 10))
+	require.True(t, c.Add("c", 3, 10))
+
+	// Touch "a" so that "b" is the least recently used entry
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	require.True(t, c.Add("d", 4, 10))
+	_, ok = c.Get("b")
+	assert.False(t, ok, "least recently used entry should be evicted")
+	for _, key := range []string{"a", "c", "d"} {
+		_, ok = c.Get(key)
+		assert.True(t, ok, key)
+	}
+
+	assert.False(t, c.Add("huge", 5, 31), "entry larger than the quota should be rejected")
+
+	assert.Equal(t, Stats{
+		Name:       "test",
+		Entries:    3,
+		Bytes:      30,
+		MaxBytes:   30,
+		Hits:       4,
+		Misses:     1,
+		Evictions:  1,
+		Rejections: 1,
+	}, c.Stats())
+}
+
+func TestLRUReplaceAndRemove(t *testing.T) {
+	t.Parallel()
+
+	c := New[string, string]("test")
+	require.True(t, c.Add("a", "old", 10))
+	require.True(t, c.Add("a", "new", 20))
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, "new", value)
+	assert.Equal(t, int64(20), c.Stats().Bytes)
+
+	c.Remove("a")
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, int64(0), c.Stats().Bytes)
+}
+
+func TestLRUMaxEntries(t *testing.T) {
+	t.Parallel()
+
+	c := New[int, int]("test", WithMaxEntries(2))
+	for i := range 5 {
+		require.True(t, c.Add(i, i, 0))
+	}
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, uint64(3), c.Stats().Evictions)
+}
+
+func TestBudget(t *testing.T) {
+	t.Parallel()
+
+	budget := NewBudget(100)
+	first := New[string, int]("first", WithBudget(budget))
+	second := New[string, int]("second", WithBudget(budget), WithMaxBytes(40))
+
+	require.True(t, first.Add("a", 1, 60))
+	require.True(t, second.Add("a", 1, 40))
+
+	// The budget is exhausted, so the first cache makes room by evicting its own entries
+	require.True(t, first.Add("b", 2, 50))
+	assert.Equal(t, 1, first.Len())
+	assert.Equal(t, 1, second.Len())
+
+	// The second cache cannot make room within the budget without entries of its own
+	second.Purge()
+	require.True(t, first.Add("c", 3, 50))
+	assert.False(t, second.Add("b", 2, 10))
+
+	first.Purge()
+	assert.True(t, second.Add("b", 2, 10))
+
+	stats := budget.Stats()
+	assert.Equal(t, int64(100), stats.MaxBytes)
+	assert.Equal(t, int64(10), stats.Bytes)
+	require.Len(t, stats.Caches, 2)
+	assert.Equal(t, "first", stats.Caches[0].Name)
+	assert.Equal(t, uint64(1), stats.Caches[0].Evictions)
+	assert.Equal(t, "second", stats.Caches[1].Name)
+	assert.Equal(t, uint64(1), stats.Caches[1].Rejections)
+}
+
+func TestUnboundedBudget(t *testing.T) {
+	t.Parallel()
+
+	budget := NewBudget(0)
+	c := New[int, int]("test", WithBudget(budget))
+	for i := range 100 {
+		require.True(t, c.Add(i, i, 1<<20))
+	}
+	assert.Equal(t, int64(100<<20), budget.Stats().Bytes)
+}
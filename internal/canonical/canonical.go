@@ -0,0 +1,135 @@
+// Package canonical produces the canonical JSON form of registry documents.
+//
+// The canonical form is the compact JSON encoding of a document with object
+// keys sorted, numbers kept as written and no HTML escaping. Two documents
+// carrying the same data have the same canonical form whatever the key order,
+// whitespace or escaping of the payload they were read from, so comparing,
+// hashing or signing canonical forms avoids spurious differences.
+//
+// With the OmitEmpty option, null values and empty objects and arrays are
+// dropped as well, so that upstream payloads leaving optional fields out and
+// payloads setting them to empty values compare equal.
+package canonical
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// options holds the normalization settings
+type options struct {
+	omitEmpty bool
+}
+
+// Option configures the canonical form
+type Option func(*options)
+
+// OmitEmpty drops the object members whose value is null, an empty object or an empty array
+func OmitEmpty() Option {
+	return func(o *options) {
+		o.omitEmpty = true
+	}
+}
+
+// JSON returns the canonical form of a JSON payload
+func JSON(payload []byte, opts ...Option) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON payload: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("invalid JSON payload: unexpected data after the document")
+	}
+
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.omitEmpty {
+		value = omitEmpty(value)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	// Maps are encoded with their keys sorted
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to encode JSON payload: %w", err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Marshal returns the canonical form of the JSON encoding of v
+func Marshal(v any, opts ...Option) ([]byte, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal document: %w", err)
+	}
+	return JSON(payload, opts...)
+}
+
+// Hash returns the hex-encoded SHA256 hash of the canonical form of v
+func Hash(v any, opts ...Option) (string, error) {
+	data, err := Marshal(v, opts...)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Equal reports whether two JSON payloads have the same canonical form
+func Equal(a, b []byte, opts ...Option) (bool, error) {
+	canonicalA, err := JSON(a, opts...)
+	if err != nil {
+		return false, err
+	}
+	canonicalB, err := JSON(b, opts...)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(canonicalA, canonicalB), nil
+}
+
+// omitEmpty removes the empty members of the objects of a decoded document, innermost first so
+// that objects left empty are removed too. Array elements are kept to preserve positions.
+func omitEmpty(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, member := range v {
+			member = omitEmpty(member)
+			if isEmpty(member) {
+				delete(v, key)
+				continue
+			}
+			v[key] = member
+		}
+		return v
+	case []any:
+		for i, element := range v {
+			v[i] = omitEmpty(element)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// isEmpty reports whether a decoded value is null, an empty object or an empty array
+func isEmpty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,137 @@
+package canonical
+
+import (
+	"encoding/json"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		payload string
+		opts    []Option
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "sorts keys and removes whitespace",
+			payload: "{\n  \"b\": 1,\n  \"a\": [true, null]\n}\n",
+			want:    `{"a":[true,null],"b":1}`,
+		},
+		{
+			name:    "sorts nested keys",
+			payload: `{"server":{"version":"1.0.0","name":"io.test/server"},"_meta":{"z":1,"a":2}}`,
+			want:    `{"_meta":{"a":2,"z":1},"server":{"name":"io.test/server","version":"1.0.0"}}`,
+		},
+		{
+			name:    "keeps numbers as written",
+			payload: `{"big":12345678901234567890,"float":1.50}`,
+			want:    `{"big":12345678901234567890,"float":1.50}`,
+		},
+		{
+			name:    "does not escape HTML",
+			payload: `{"url":"https://example.com/?a=1&b=<2>"}`,
+			want:    `{"url":"https://example.com/?a=1&b=<2>"}`,
+		},
+		{
+			name:    "keeps empty values by default",
+			payload: `{"name":"a","packages":[],"_meta":{}}`,
+			want:    `{"_meta":{},"name":"a","packages":[]}`,
+		},
+		{
+			name:    "omits empty values",
+			payload: `{"name":"a","title":"","packages":[],"remotes":null,"_meta":{"x":{"y":[]}},"args":[null,{}]}`,
+			opts:    []Option{OmitEmpty()},
+			want:    `{"args":[null,{}],"name":"a","title":""}`,
+		},
+		{
+			name:    "invalid JSON",
+			payload: `{"a":`,
+			wantErr: true,
+		},
+		{
+			name:    "trailing data",
+			payload: `{"a":1} {"b":2}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			canonical, err := JSON([]byte(tt.payload), tt.opts...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(canonical))
+		})
+	}
+}
+
+func TestMarshalAndHash(t *testing.T) {
+	t.Parallel()
+
+	server := upstreamv0.ServerJSON{
+		Name:    "io.test/server",
+		Version: "1.0.0",
+		Meta: &upstreamv0.ServerMeta{PublisherProvided: map[string]interface{}{
+			"b": map[string]interface{}{"y": 1, "x": 2},
+			"a": "<tag>",
+		}},
+	}
+
+	data, err := Marshal(server)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"io.modelcontextprotocol.registry/publisher-provided":{"a":"<tag>","b":{"x":2,"y":1}}`)
+
+	hash, err := Hash(server)
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	// The same document in a differently formatted payload has the same canonical form
+	indented, err := json.MarshalIndent(server, "", "  ")
+	require.NoError(t, err)
+	equal, err := Equal(data, indented)
+	require.NoError(t, err)
+	assert.True(t, equal)
+
+	other := server
+	other.Version = "2.0.0"
+	otherHash, err := Hash(other)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+
+	_, err = Marshal(map[string]any{"invalid": make(chan int)})
+	assert.Error(t, err)
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	equal, err := Equal([]byte(`{"a":1,"b":[1,2]}`), []byte(`{ "b": [1, 2], "a": 1 }`))
+	require.NoError(t, err)
+	assert.True(t, equal)
+
+	equal, err = Equal([]byte(`{"b":[1,2]}`), []byte(`{"b":[2,1]}`))
+	require.NoError(t, err)
+	assert.False(t, equal, "array order is significant")
+
+	equal, err = Equal([]byte(`{"a":1}`), []byte(`{"a":1,"packages":[]}`))
+	require.NoError(t, err)
+	assert.False(t, equal)
+
+	equal, err = Equal([]byte(`{"a":1}`), []byte(`{"a":1,"packages":[]}`), OmitEmpty())
+	require.NoError(t, err)
+	assert.True(t, equal)
+
+	_, err = Equal([]byte(`{}`), []byte(`not json`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,404 @@
+// Package changelog retrieves the release notes of MCP servers from their GitHub repository.
+//
+// The notes of a server version are looked up, in order, in the GitHub release tagged with the
+// version (v1.2.3 or 1.2.3) and in the section of the CHANGELOG.md file of the repository headed
+// with the version. When neither exists, the notes of the latest release, or else the top of the
+// CHANGELOG.md file, are returned instead and flagged as not matching the version. Notes are
+// capped in size so that they fit in the context window of an agent.
+package changelog
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/stacklok/toolhive-registry-server/internal/cache"
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+const (
+	// DefaultCacheTTL is the default time release notes are cached
+	DefaultCacheTTL = time.Hour
+	// DefaultGitHubAPIURL is the default GitHub API
+	DefaultGitHubAPIURL = "https://api.github.com"
+	// MaxNotesSize caps the size of the returned notes, in bytes
+	MaxNotesSize = 32 * 1024
+
+	changelogFile = "CHANGELOG.md"
+
+	// maxCacheEntries caps the number of cached release notes
+	maxCacheEntries = 512
+	// maxResponseSize caps the size of GitHub API responses; the contents API serves files
+	// of up to 1 MiB, base64-encoded
+	maxResponseSize = 2 * 1024 * 1024
+
+	defaultFetchTimeout = 10 * time.Second
+)
+
+// Sources of release notes
+const (
+	// SourceRelease is a GitHub release
+	SourceRelease = "release"
+	// SourceChangelogFile is the CHANGELOG.md file of the repository
+	SourceChangelogFile = "changelog-file"
+)
+
+var (
+	// ErrUnsupportedRepository is returned for repositories not hosted on GitHub
+	ErrUnsupportedRepository = errors.New("unsupported repository")
+	// ErrNotFound is returned for repositories without releases nor changelog file
+	ErrNotFound = errors.New("release notes not found")
+)
+
+// Changelog is the release notes of a server version
+type Changelog struct {
+	// Repository is the URL of the GitHub repository the notes come from
+	Repository string `json:"repository"`
+	// Source is SourceRelease or SourceChangelogFile
+	Source string `json:"source"`
+	// MatchesVersion tells whether the notes are those of the requested version, rather than
+	// those of the latest release or the top of the changelog file
+	MatchesVersion bool       `json:"matchesVersion"`
+	Tag            string     `json:"tag,omitempty"`
+	Name           string     `json:"name,omitempty"`
+	URL            string     `json:"url,omitempty"`
+	PublishedAt    *time.Time `json:"publishedAt,omitempty"`
+	Notes          string     `json:"notes"`
+	// Truncated tells whether the notes were cut to MaxNotesSize
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Fetcher retrieves release notes from GitHub and caches them.
+// It is safe for concurrent use.
+type Fetcher struct {
+	client       *http.Client
+	githubAPIURL string
+	ttl          time.Duration
+	now          func() time.Time
+	cacheOpts    []cache.Option
+	cache        *cache.LRU[string, cacheEntry]
+}
+
+// cacheEntry is cached release notes
+type cacheEntry struct {
+	changelog *Changelog
+	expires   time.Time
+}
+
+// Option configures a Fetcher
+type Option func(*Fetcher)
+
+// WithHTTPClient sets the HTTP client used to reach the GitHub API
+func WithHTTPClient(client *http.Client) Option {
+	return func(f *Fetcher) {
+		f.client = client
+	}
+}
+
+// WithGitHubAPIURL sets the base URL of the GitHub API
+func WithGitHubAPIURL(apiURL string) Option {
+	return func(f *Fetcher) {
+		f.githubAPIURL = strings.TrimSuffix(apiURL, "/")
+	}
+}
+
+// WithCacheTTL sets how long release notes are cached
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(f *Fetcher) {
+		f.ttl = ttl
+	}
+}
+
+// WithCache bounds the memory held by cached release notes, e.g. with cache.WithBudget.
+// The cache holds at most 512 entries regardless of the options.
+func WithCache(opts ...cache.Option) Option {
+	return func(f *Fetcher) {
+		f.cacheOpts = append(f.cacheOpts, opts...)
+	}
+}
+
+// NewFetcher creates a new release notes fetcher
+func NewFetcher(opts ...Option) *Fetcher {
+	f := &Fetcher{
+		client:       &http.Client{Timeout: defaultFetchTimeout, Transport: httpclient.NewTimingTransport(nil)},
+		githubAPIURL: DefaultGitHubAPIURL,
+		ttl:          DefaultCacheTTL,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	f.cache = cache.New[string, cacheEntry]("changelog", append(f.cacheOpts, cache.WithMaxEntries(maxCacheEntries))...)
+	return f
+}
+
+// Fetch returns the release notes of a version of the server whose source code lives in the
+// given repository and subfolder. ErrUnsupportedRepository is returned for repositories not
+// hosted on GitHub, and ErrNotFound for repositories without releases nor changelog file.
+func (f *Fetcher) Fetch(ctx context.Context, repositoryURL, subfolder, version string) (*Changelog, error) {
+	owner, repo, ok := registry.GitHubRepository(repositoryURL)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedRepository, repositoryURL)
+	}
+	subfolder = strings.Trim(path.Clean("/"+subfolder), "/")
+	key := owner + "/" + repo + "//" + subfolder + "@" + version
+	if entry, ok := f.cache.Get(key); ok {
+		if f.now().Before(entry.expires) {
+			return entry.changelog, nil
+		}
+		f.cache.Remove(key)
+	}
+
+	changelog, err := f.fetch(ctx, owner, repo, subfolder, version)
+	if err != nil {
+		return nil, err
+	}
+	f.cache.Add(key, cacheEntry{changelog: changelog, expires: f.now().Add(f.ttl)}, int64(len(key))+cache.JSONSize(changelog))
+	return changelog, nil
+}
+
+// release is a GitHub release
+type release struct {
+	TagName     string     `json:"tag_name"`
+	Name        string     `json:"name"`
+	Body        string     `json:"body"`
+	HTMLURL     string     `json:"html_url"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// changelogContent is a changelog file of a repository
+type changelogContent struct {
+	content string
+	htmlURL string
+}
+
+// fetch looks the release notes of a version up in the releases and the changelog file of a repository
+func (f *Fetcher) fetch(ctx context.Context, owner, repo, subfolder, version string) (*Changelog, error) {
+	repoAPIURL := fmt.Sprintf("%s/repos/%s/%s", f.githubAPIURL, url.PathEscape(owner), url.PathEscape(repo))
+	repositoryURL := fmt.Sprintf("https://github.com/%s/%s", owner, repo)
+
+	if version != "" {
+		for _, tag := range []string{"v" + version, version} {
+			rel, err := f.getRelease(ctx, repoAPIURL+"/releases/tags/"+url.PathEscape(tag))
+			if err == nil {
+				return fromRelease(repositoryURL, rel, true), nil
+			}
+			if !errors.Is(err, ErrNotFound) {
+				return nil, err
+			}
+		}
+	}
+
+	file, err := f.getChangelogFile(ctx, repoAPIURL, subfolder)
+	if err != nil {
+		return nil, err
+	}
+	if file != nil && version != "" {
+		if section := versionSection(file.content, version); section != "" {
+			return fromFile(repositoryURL, file.htmlURL, section, true), nil
+		}
+	}
+
+	rel, err := f.getRelease(ctx, repoAPIURL+"/releases/latest")
+	if err == nil {
+		return fromRelease(repositoryURL, rel, false), nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	if file != nil {
+		return fromFile(repositoryURL, file.htmlURL, file.content, false), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, repositoryURL)
+}
+
+// getRelease fetches a GitHub release
+func (f *Fetcher) getRelease(ctx context.Context, rawURL string) (*release, error) {
+	var rel release
+	if err := f.getJSON(ctx, rawURL, &rel); err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+// getChangelogFile fetches the changelog file of the subfolder of a repository, or else of its
+// root. It returns nil if neither exists.
+func (f *Fetcher) getChangelogFile(ctx context.Context, repoAPIURL, subfolder string) (*changelogContent, error) {
+	candidates := []string{changelogFile}
+	if subfolder != "" {
+		candidates = []string{subfolder + "/" + changelogFile, changelogFile}
+	}
+
+	for _, candidate := range candidates {
+		segments := strings.Split(candidate, "/")
+		for i, segment := range segments {
+			segments[i] = url.PathEscape(segment)
+		}
+		var content struct {
+			Type     string `json:"type"`
+			Encoding string `json:"encoding"`
+			Content  string `json:"content"`
+			HTMLURL  string `json:"html_url"`
+		}
+		err := f.getJSON(ctx, repoAPIURL+"/contents/"+strings.Join(segments, "/"), &content)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Files over 1 MiB are served without content
+		if content.Type != "file" || content.Encoding != "base64" {
+			slog.DebugContext(ctx, "Skipping changelog file", "path", candidate, "type", content.Type, "encoding", content.Encoding)
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", ""))
+		if err != nil {
+			return nil, fmt.Errorf("invalid content of %s: %w", candidate, err)
+		}
+		return &changelogContent{content: string(decoded), htmlURL: content.HTMLURL}, nil
+	}
+	return nil, nil
+}
+
+// fromRelease builds the changelog of a GitHub release
+func fromRelease(repositoryURL string, rel *release, matchesVersion bool) *Changelog {
+	notes, truncated := truncate(strings.TrimSpace(rel.Body))
+	return &Changelog{
+		Repository:     repositoryURL,
+		Source:         SourceRelease,
+		MatchesVersion: matchesVersion,
+		Tag:            rel.TagName,
+		Name:           rel.Name,
+		URL:            rel.HTMLURL,
+		PublishedAt:    rel.PublishedAt,
+		Notes:          notes,
+		Truncated:      truncated,
+	}
+}
+
+// fromFile builds the changelog of (a section of) a changelog file
+func fromFile(repositoryURL, fileURL, content string, matchesVersion bool) *Changelog {
+	notes, truncated := truncate(strings.TrimSpace(content))
+	return &Changelog{
+		Repository:     repositoryURL,
+		Source:         SourceChangelogFile,
+		MatchesVersion: matchesVersion,
+		URL:            fileURL,
+		Notes:          notes,
+		Truncated:      truncated,
+	}
+}
+
+// versionSection returns the section of a Markdown changelog headed with a version, e.g.
+// "## [1.2.0] - 2025-01-01" or "## v1.2.0", up to the next heading of the same or a higher
+// level. It returns an empty string if no heading mentions the version.
+func versionSection(content, version string) string {
+	lines := strings.Split(content, "\n")
+	start, level := -1, 0
+	for i, line := range lines {
+		headingLevel, text := heading(line)
+		if headingLevel == 0 {
+			continue
+		}
+		if start >= 0 {
+			if headingLevel <= level {
+				return strings.Join(lines[start:i], "\n")
+			}
+			continue
+		}
+		if mentionsVersion(text, version) {
+			start, level = i, headingLevel
+		}
+	}
+	if start < 0 {
+		return ""
+	}
+	return strings.Join(lines[start:], "\n")
+}
+
+// heading returns the level and text of a Markdown ATX heading, or a zero level for other lines
+func heading(line string) (int, string) {
+	trimmed := strings.TrimLeft(line, "#")
+	level := len(line) - len(trimmed)
+	if level == 0 || level > 6 || (trimmed != "" && trimmed[0] != ' ' && trimmed[0] != '\t') {
+		return 0, ""
+	}
+	return level, trimmed
+}
+
+// mentionsVersion tells whether a heading mentions a version, with or without a "v" prefix
+func mentionsVersion(text, version string) bool {
+	tokens := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != '-' && r != '+'
+	})
+	for _, token := range tokens {
+		if token == version || token == "v"+version {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate cuts notes to MaxNotesSize, at the end of a line if possible
+func truncate(notes string) (string, bool) {
+	if len(notes) <= MaxNotesSize {
+		return notes, false
+	}
+	cut := MaxNotesSize
+	for cut > 0 && !utf8.RuneStart(notes[cut]) {
+		cut--
+	}
+	truncated := notes[:cut]
+	if i := strings.LastIndexByte(truncated, '\n'); i > 0 {
+		truncated = truncated[:i]
+	}
+	return strings.TrimSpace(truncated), true
+}
+
+// getJSON fetches a GitHub API document and decodes it into v
+func (f *Fetcher) getJSON(ctx context.Context, rawURL string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", httpclient.UserAgent())
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: HTTP 404 for URL %s", ErrNotFound, rawURL)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d for URL %s", resp.StatusCode, rawURL)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(body) > maxResponseSize {
+		return fmt.Errorf("response of %s exceeds the %d bytes limit", rawURL, maxResponseSize)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("invalid response of %s: %w", rawURL, err)
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+package changelog
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChangelog = `# Changelog
+
+## [Unreleased]
+
+- Work in progress
+
+## [1.1.0] - 2025-02-01
+
+### Added
+
+- Search tool
+
+## [1.0.0] - 2025-01-01
+
+- Initial release
+`
+
+// fakeGitHub serves the releases and contents of a few repositories of the GitHub API
+type fakeGitHub struct {
+	server   *httptest.Server
+	requests atomic.Int32
+}
+
+func newFakeGitHub(t *testing.T) *fakeGitHub {
+	t.Helper()
+	release := func(tag, body string) string {
+		return `{"tag_name":"` + tag + `","name":"Release ` + tag + `","body":"` + body +
+			`","html_url":"https://github.com/acme/releases/releases/tag/` + tag + `","published_at":"2025-02-01T10:00:00Z"}`
+	}
+	file := func(content string) string {
+		encoded, _ := json.Marshal(map[string]string{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+			"html_url": "https://github.com/acme/changelog/blob/main/CHANGELOG.md",
+		})
+		return string(encoded)
+	}
+	routes := map[string]string{
+		"/repos/acme/releases/releases/tags/v2.0.0":               release("v2.0.0", "Breaking changes"),
+		"/repos/acme/releases/releases/tags/1.5.0":                release("1.5.0", "Bug fixes"),
+		"/repos/acme/releases/releases/latest":                    release("v2.0.0", "Breaking changes"),
+		"/repos/acme/changelog/contents/CHANGELOG.md":             file(testChangelog),
+		"/repos/acme/monorepo/contents/servers/a/CHANGELOG.md":    file("## 0.2.0\n\nServer A fixes\n"),
+		"/repos/acme/monorepo/contents/CHANGELOG.md":              file("## 0.2.0\n\nRoot changes\n"),
+		"/repos/acme/oversized/contents/CHANGELOG.md":             `{"type":"file","encoding":"none","content":""}`,
+		"/repos/acme/large-release/releases/latest":               release("v1.0.0", strings.Repeat("x", MaxNotesSize+10)),
+		"/repos/acme/unavailable/releases/tags/v1.0.0":            "",
+		"/repos/acme/changelog-and-release/releases/latest":       release("v3.0.0", "Latest"),
+		"/repos/acme/changelog-and-release/contents/CHANGELOG.md": file(testChangelog),
+	}
+	gh := &fakeGitHub{}
+	gh.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gh.requests.Add(1)
+		body, ok := routes[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if body == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(gh.server.Close)
+	return gh
+}
+
+func (gh *fakeGitHub) fetcher(opts ...Option) *Fetcher {
+	return NewFetcher(append([]Option{WithGitHubAPIURL(gh.server.URL)}, opts...)...)
+}
+
+func TestFetcherFetch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		repository         string
+		subfolder          string
+		version            string
+		wantSource         string
+		wantTag            string
+		wantMatchesVersion bool
+		wantNotes          string
+		wantErr            error
+		wantErrIn          string
+	}{
+		{
+			name:               "release tagged with v prefix",
+			repository:         "https://github.com/acme/releases",
+			version:            "2.0.0",
+			wantSource:         SourceRelease,
+			wantTag:            "v2.0.0",
+			wantMatchesVersion: true,
+			wantNotes:          "Breaking changes",
+		},
+		{
+			name:               "release tagged without prefix",
+			repository:         "git@github.com:Acme/Releases.git",
+			version:            "1.5.0",
+			wantSource:         SourceRelease,
+			wantTag:            "1.5.0",
+			wantMatchesVersion: true,
+			wantNotes:          "Bug fixes",
+		},
+		{
+			name:       "latest release when the version has none",
+			repository: "https://github.com/acme/releases",
+			version:    "0.9.0",
+			wantSource: SourceRelease,
+			wantTag:    "v2.0.0",
+			wantNotes:  "Breaking changes",
+		},
+		{
+			name:               "changelog file section",
+			repository:         "https://github.com/acme/changelog",
+			version:            "1.1.0",
+			wantSource:         SourceChangelogFile,
+			wantMatchesVersion: true,
+			wantNotes:          "## [1.1.0] - 2025-02-01\n\n### Added\n\n- Search tool",
+		},
+		{
+			name:       "whole changelog file when the version has no section",
+			repository: "https://github.com/acme/changelog",
+			version:    "3.0.0",
+			wantSource: SourceChangelogFile,
+			wantNotes:  strings.TrimSpace(testChangelog),
+		},
+		{
+			name:               "changelog section preferred over latest release",
+			repository:         "https://github.com/acme/changelog-and-release",
+			version:            "1.0.0",
+			wantSource:         SourceChangelogFile,
+			wantMatchesVersion: true,
+			wantNotes:          "## [1.0.0] - 2025-01-01\n\n- Initial release",
+		},
+		{
+			name:               "changelog file of the subfolder",
+			repository:         "https://github.com/acme/monorepo",
+			subfolder:          "servers/a/",
+			version:            "0.2.0",
+			wantSource:         SourceChangelogFile,
+			wantMatchesVersion: true,
+			wantNotes:          "## 0.2.0\n\nServer A fixes",
+		},
+		{
+			name:       "oversized changelog file",
+			repository: "https://github.com/acme/oversized",
+			version:    "1.0.0",
+			wantErr:    ErrNotFound,
+		},
+		{
+			name:       "repository without release notes",
+			repository: "https://github.com/acme/empty",
+			version:    "1.0.0",
+			wantErr:    ErrNotFound,
+		},
+		{
+			name:       "repository not hosted on GitHub",
+			repository: "https://gitlab.com/acme/server",
+			version:    "1.0.0",
+			wantErr:    ErrUnsupportedRepository,
+		},
+		{
+			name:       "GitHub API error",
+			repository: "https://github.com/acme/unavailable",
+			version:    "1.0.0",
+			wantErrIn:  "HTTP 403",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fetcher := newFakeGitHub(t).fetcher()
+
+			changelog, err := fetcher.Fetch(context.Background(), tt.repository, tt.subfolder, tt.version)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIn != "" {
+				assert.ErrorContains(t, err, tt.wantErrIn)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSource, changelog.Source)
+			assert.Equal(t, tt.wantTag, changelog.Tag)
+			assert.Equal(t, tt.wantMatchesVersion, changelog.MatchesVersion)
+			assert.Equal(t, tt.wantNotes, changelog.Notes)
+			assert.False(t, changelog.Truncated)
+			assert.NotEmpty(t, changelog.URL)
+		})
+	}
+}
+
+func TestFetcherFetchTruncatesNotes(t *testing.T) {
+	t.Parallel()
+	fetcher := newFakeGitHub(t).fetcher()
+
+	changelog, err := fetcher.Fetch(context.Background(), "https://github.com/acme/large-release", "", "2.0.0")
+	require.NoError(t, err)
+	assert.True(t, changelog.Truncated)
+	assert.Len(t, changelog.Notes, MaxNotesSize)
+	assert.Equal(t, "https://github.com/acme/large-release", changelog.Repository)
+	require.NotNil(t, changelog.PublishedAt)
+	assert.Equal(t, time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC), *changelog.PublishedAt)
+}
+
+func TestFetcherCache(t *testing.T) {
+	t.Parallel()
+	gh := newFakeGitHub(t)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fetcher := gh.fetcher(WithCacheTTL(time.Minute))
+	fetcher.now = func() time.Time { return now }
+
+	first, err := fetcher.Fetch(context.Background(), "https://github.com/acme/changelog", "", "1.1.0")
+	require.NoError(t, err)
+	requests := gh.requests.Load()
+
+	second, err := fetcher.Fetch(context.Background(), "https://github.com/acme/changelog", "", "1.1.0")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, requests, gh.requests.Load())
+
+	now = now.Add(2 * time.Minute)
+	_, err = fetcher.Fetch(context.Background(), "https://github.com/acme/changelog", "", "1.1.0")
+	require.NoError(t, err)
+	assert.Greater(t, gh.requests.Load(), requests)
+}
+
+func TestVersionSection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		version string
+		want    string
+	}{
+		{name: "bracketed version", version: "1.0.0", want: "## [1.0.0] - 2025-01-01\n\n- Initial release\n"},
+		{name: "section ending at next heading", version: "1.1.0", want: "## [1.1.0] - 2025-02-01\n\n### Added\n\n- Search tool\n"},
+		{name: "version prefix of another version", version: "1.1", want: ""},
+		{name: "missing version", version: "2.0.0", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, versionSection(testChangelog, tt.version))
+		})
+	}
+
+	assert.Equal(t, "# v0.3.0-beta.1 (2025-03-01)\nNotes", versionSection("# v0.3.0-beta.1 (2025-03-01)\nNotes", "0.3.0-beta.1"))
+	assert.Empty(t, versionSection("#1.0.0 is not a heading", "1.0.0"))
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	notes, truncated := truncate("short")
+	assert.Equal(t, "short", notes)
+	assert.False(t, truncated)
+
+	// Notes are cut at the last line break before the limit
+	long := strings.Repeat("a", MaxNotesSize-10) + "\nnext line that goes past the limit"
+	notes, truncated = truncate(long)
+	assert.Equal(t, strings.Repeat("a", MaxNotesSize-10), notes)
+	assert.True(t, truncated)
+
+	// Multi-byte characters are never split
+	long = strings.Repeat("é", MaxNotesSize)
+	notes, truncated = truncate(long)
+	assert.True(t, truncated)
+	assert.LessOrEqual(t, len(notes), MaxNotesSize)
+	assert.True(t, strings.HasPrefix(long, notes))
+	assert.Equal(t, 0, len(notes)%2)
+}
@@ -0,0 +1,443 @@
+// Package clientconfig generates the configuration of MCP clients launching registry servers, as a
+// single JSON block to merge into the configuration file of the client. Servers exposing a remote
+// endpoint (streamable-http or sse) are connected to by URL, with placeholders for the headers users
+// must provide; other servers are launched from their first package a client can start (npm, PyPI,
+// NuGet or container image), with their declared arguments and placeholders for the environment
+// variables and argument values users must provide. Container images are run by docker, with the
+// environment variables and the files the servers are given passed to the container.
+package clientconfig
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Clients configurations can be generated for
+const (
+	ClientClaudeDesktop = "claude-desktop"
+	ClientCursor        = "cursor"
+	ClientVSCode        = "vscode"
+	ClientWindsurf      = "windsurf"
+	ClientZed           = "zed"
+)
+
+// ErrUnsupportedClient is returned when configurations cannot be generated for a client
+var ErrUnsupportedClient = errors.New("unsupported client")
+
+// Clients returns the clients configurations can be generated for
+func Clients() []string {
+	return []string{ClientClaudeDesktop, ClientCursor, ClientVSCode, ClientWindsurf, ClientZed}
+}
+
+// ValidateClient checks that configurations can be generated for a client
+func ValidateClient(client string) error {
+	if !slices.Contains(Clients(), client) {
+		return fmt.Errorf("%w %q: must be one of %s", ErrUnsupportedClient, client, strings.Join(Clients(), ", "))
+	}
+	return nil
+}
+
+// Config is the generated configuration of a client
+type Config struct {
+	Client string `json:"client"`
+	// Config is the JSON block to merge into the configuration file of the client
+	Config map[string]any `json:"config"`
+	// Servers describe the entries of the configuration
+	Servers []Entry `json:"servers"`
+	// Warnings are what users must know about servers left out or configured partially
+	Warnings []string `json:"warnings"`
+}
+
+// Entry describes how a server is launched or connected to by a configuration entry
+type Entry struct {
+	// Key is the key of the entry in the configuration
+	Key     string `json:"key"`
+	Server  string `json:"server"`
+	Version string `json:"version"`
+	// RegistryType and Identifier identify the package launched, if any
+	RegistryType string `json:"registryType,omitempty"`
+	Identifier   string `json:"identifier,omitempty"`
+	// Transport and URL identify the remote endpoint connected to, if any
+	Transport string `json:"transport,omitempty"`
+	URL       string `json:"url,omitempty"`
+	// Variables are the environment variables, header values and argument values of the entry users
+	// must provide
+	Variables []Variable `json:"variables"`
+}
+
+// Variable is an environment variable of an entry, or a value of one of its headers or arguments
+type Variable struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Secret      bool   `json:"secret"`
+	// Header is the HTTP header the value is sent in, if any
+	Header string `json:"header,omitempty"`
+	// Argument is the flag (e.g. "--port") or value hint of the argument the value is passed in, if any
+	Argument string `json:"argument,omitempty"`
+	// Placeholder is the value of the variable in the configuration, to be replaced by users
+	Placeholder string `json:"placeholder"`
+}
+
+// launcher is the command launching a server package and its environment. Its arguments are the
+// runtime flags, the declared runtime arguments, the target, the runtime options and the declared
+// package arguments, in this order (e.g. npx -y <runtime arguments> package@1.0.0 <package arguments>).
+type launcher struct {
+	command string
+	flags   []string
+	// mountFiles mounts the files and directories passed in environment variables and package
+	// arguments at the same path, for launchers running servers in containers
+	mountFiles  bool
+	runtimeArgs []model.Argument
+	target      string
+	options     []string
+	packageArgs []model.Argument
+	env         []model.KeyValueInput
+}
+
+// generator accumulates the entries of the configuration of a client
+type generator struct {
+	client  string
+	entries map[string]any
+	// inputs are the inputs prompted for by clients supporting them, once per name
+	inputs   []map[string]any
+	prompted map[string]bool
+}
+
+// Generate generates the configuration of a client launching or connecting to the servers. Servers
+// without remote endpoint nor package the client can launch are left out with a warning.
+func Generate(client string, servers []*upstreamv0.ServerJSON) (*Config, error) {
+	if err := ValidateClient(client); err != nil {
+		return nil, err
+	}
+
+	g := &generator{client: client, entries: make(map[string]any), prompted: make(map[string]bool)}
+	config := &Config{Client: client, Servers: []Entry{}, Warnings: []string{}}
+	for _, server := range servers {
+		entry := Entry{
+			Key:       entryKey(server.Name, g.entries),
+			Server:    server.Name,
+			Version:   server.Version,
+			Variables: []Variable{},
+		}
+		if remote, ok := firstRemote(server); ok {
+			entry.Transport, entry.URL = remote.Type, remote.URL
+			g.entries[entry.Key] = g.remote(&entry, remote)
+		} else if pkg, launch, ok := firstLaunchable(server); ok {
+			entry.RegistryType, entry.Identifier = pkg.RegistryType, pkg.Identifier
+			g.entries[entry.Key] = g.launch(&entry, launch)
+		} else {
+			config.Warnings = append(config.Warnings, fmt.Sprintf(
+				"%s has no remote endpoint nor package %s can launch and was left out", server.Name, client))
+			continue
+		}
+		config.Servers = append(config.Servers, entry)
+	}
+
+	switch client {
+	case ClientVSCode:
+		config.Config = map[string]any{"servers": g.entries}
+		if len(g.inputs) > 0 {
+			config.Config["inputs"] = g.inputs
+		}
+	case ClientZed:
+		config.Config = map[string]any{"context_servers": g.entries}
+	default:
+		config.Config = map[string]any{"mcpServers": g.entries}
+	}
+	return config, nil
+}
+
+// firstRemote returns the first remote endpoint of a server clients can connect to
+func firstRemote(server *upstreamv0.ServerJSON) (model.Transport, bool) {
+	for _, remote := range server.Remotes {
+		if remote.URL != "" &&
+			(remote.Type == model.TransportTypeStreamableHTTP || remote.Type == model.TransportTypeSSE) {
+			return remote, true
+		}
+	}
+	return model.Transport{}, false
+}
+
+// firstLaunchable returns the first package of a server a client can launch, and its launcher
+func firstLaunchable(server *upstreamv0.ServerJSON) (model.Package, launcher, bool) {
+	for _, pkg := range server.Packages {
+		if pkg.Transport.Type != "" && pkg.Transport.Type != model.TransportTypeStdio {
+			continue
+		}
+		if launch, ok := launchPackage(pkg); ok {
+			return pkg, launch, true
+		}
+	}
+	return model.Package{}, launcher{}, false
+}
+
+// launchPackage returns the launcher of a package run through the runtime of its ecosystem
+func launchPackage(pkg model.Package) (launcher, bool) {
+	launch := launcher{
+		env:         requiredEnv(pkg.EnvironmentVariables),
+		runtimeArgs: pkg.RuntimeArguments,
+		packageArgs: pkg.PackageArguments,
+	}
+	switch pkg.RegistryType {
+	case model.RegistryTypeNPM:
+		launch.command, launch.flags = "npx", []string{"-y"}
+		launch.target = versioned(pkg.Identifier, "@", pkg.Version)
+	case model.RegistryTypePyPI:
+		launch.command, launch.target = "uvx", versioned(pkg.Identifier, "==", pkg.Version)
+	case model.RegistryTypeNuGet:
+		launch.command, launch.target = "dnx", versioned(pkg.Identifier, "@", pkg.Version)
+		launch.options = []string{"--yes"}
+	case model.RegistryTypeOCI:
+		// The environment of the client is only passed to the container through -e flags, and its
+		// files through -v flags
+		launch.command, launch.flags = "docker", []string{"run", "-i", "--rm"}
+		for _, variable := range launch.env {
+			launch.flags = append(launch.flags, "-e", variable.Name)
+		}
+		launch.target, launch.mountFiles = pkg.Identifier, true
+	default:
+		return launcher{}, false
+	}
+	return launch, true
+}
+
+// requiredEnv returns the environment variables servers cannot start without, along with the secrets
+// they may use
+func requiredEnv(variables []model.KeyValueInput) []model.KeyValueInput {
+	env := make([]model.KeyValueInput, 0, len(variables))
+	for _, variable := range variables {
+		if variable.Name != "" && (variable.IsRequired || variable.IsSecret) {
+			env = append(env, variable)
+		}
+	}
+	return env
+}
+
+// launch returns the configuration entry of a launcher in the format of the client
+func (g *generator) launch(entry *Entry, launch launcher) map[string]any {
+	env := make(map[string]string)
+	var paths []string
+	for _, variable := range launch.env {
+		env[variable.Name] = g.value(entry, Variable{Name: variable.Name}, variable.Input)
+		if variable.Format == model.FormatFilePath {
+			paths = append(paths, env[variable.Name])
+		}
+	}
+	runtimeArgs, _ := g.arguments(entry, launch.runtimeArgs)
+	packageArgs, argumentPaths := g.arguments(entry, launch.packageArgs)
+	paths = append(paths, argumentPaths...)
+
+	args := slices.Clone(launch.flags)
+	if launch.mountFiles {
+		// Paths are mounted where the server expects them, so that values need no rewriting
+		mounted := make(map[string]bool)
+		for _, path := range paths {
+			if !mounted[path] {
+				mounted[path] = true
+				args = append(args, "-v", path+":"+path)
+			}
+		}
+	}
+	args = append(args, runtimeArgs...)
+	args = append(args, launch.target)
+	args = append(args, launch.options...)
+	args = append(args, packageArgs...)
+	return g.command(launch.command, args, env)
+}
+
+// arguments returns the command line arguments of declared arguments, with placeholders for the
+// values users must provide, and the values of the arguments naming files. Optional arguments
+// without value are left out, and repeated arguments are passed once.
+func (g *generator) arguments(entry *Entry, arguments []model.Argument) ([]string, []string) {
+	args := []string{}
+	paths := []string{}
+	for i, argument := range arguments {
+		hasValue := argument.Value != "" || argument.Default != ""
+		if !hasValue && !argument.IsRequired {
+			continue
+		}
+
+		if argument.Type == model.ArgumentTypeNamed {
+			if argument.Name == "" {
+				continue
+			}
+			args = append(args, argument.Name)
+			// Required flags without value are switches (e.g. --stdio)
+			if !hasValue && argument.Format == model.FormatBoolean {
+				continue
+			}
+			name := strings.TrimLeft(argument.Name, "-")
+			args = append(args, g.templated(entry, Variable{Name: name, Argument: argument.Name},
+				argument.InputWithVariables))
+		} else {
+			hint := argument.ValueHint
+			if hint == "" {
+				hint = fmt.Sprintf("argument_%d", i+1)
+			}
+			args = append(args, g.templated(entry, Variable{Name: hint, Argument: hint}, argument.InputWithVariables))
+		}
+		if argument.Format == model.FormatFilePath {
+			paths = append(paths, args[len(args)-1])
+		}
+	}
+	return args, paths
+}
+
+// command returns the configuration entry running a command in the format of the client
+func (g *generator) command(command string, args []string, env map[string]string) map[string]any {
+	entry := map[string]any{
+		"command": command,
+		"args":    args,
+	}
+	if g.client == ClientVSCode {
+		entry["type"] = "stdio"
+	}
+	if len(env) > 0 {
+		entry["env"] = env
+	}
+	return entry
+}
+
+// remote returns the configuration entry connecting to a remote endpoint in the format of the client
+func (g *generator) remote(entry *Entry, remote model.Transport) map[string]any {
+	headers := make(map[string]string)
+	var names []string
+	for _, header := range remote.Headers {
+		if header.Name == "" || (!header.IsRequired && !header.IsSecret && header.Value == "") {
+			continue
+		}
+		headers[header.Name] = g.templated(entry, Variable{Name: header.Name, Header: header.Name},
+			header.InputWithVariables)
+		names = append(names, header.Name)
+	}
+
+	var config map[string]any
+	switch g.client {
+	case ClientClaudeDesktop:
+		// Claude Desktop only launches commands, so remote endpoints are bridged by mcp-remote. Header
+		// values are passed in environment variables to keep them out of the command line.
+		transport := "http-only"
+		if remote.Type == model.TransportTypeSSE {
+			transport = "sse-only"
+		}
+		args := []string{"-y", "mcp-remote", remote.URL, "--transport", transport}
+		env := make(map[string]string)
+		for _, name := range names {
+			variable := headerVariable(name)
+			args = append(args, "--header", name+":${"+variable+"}")
+			env[variable] = headers[name]
+		}
+		return g.command("npx", args, env)
+	case ClientVSCode:
+		transport := "http"
+		if remote.Type == model.TransportTypeSSE {
+			transport = "sse"
+		}
+		config = map[string]any{"type": transport, "url": remote.URL}
+	case ClientWindsurf:
+		config = map[string]any{"serverUrl": remote.URL}
+	default:
+		config = map[string]any{"url": remote.URL}
+	}
+	if len(headers) > 0 {
+		config["headers"] = headers
+	}
+	return config
+}
+
+// templateVariable matches the {variables} of header value templates
+var templateVariable = regexp.MustCompile(`\{([A-Za-z0-9_.-]+)\}`)
+
+// templated returns the value of a header or argument in the configuration of the client. The
+// {variables} of templated values (e.g. "Bearer {api_key}") are replaced by their own placeholders.
+func (g *generator) templated(entry *Entry, origin Variable, input model.InputWithVariables) string {
+	if !templateVariable.MatchString(input.Value) {
+		return g.value(entry, origin, input.Input)
+	}
+	return templateVariable.ReplaceAllStringFunc(input.Value, func(match string) string {
+		variable := origin
+		variable.Name = match[1 : len(match)-1]
+		value := input.Variables[variable.Name]
+		value.IsSecret = value.IsSecret || input.IsSecret
+		return g.value(entry, variable, value)
+	})
+}
+
+// value returns the value of an environment variable, header or argument in the configuration of
+// the client. Fixed and default values are kept unless secret; other values are placeholders users
+// must replace, prompted for by clients supporting inputs. The origin names the value and tells
+// where it is passed.
+func (g *generator) value(entry *Entry, origin Variable, input model.Input) string {
+	if !input.IsSecret {
+		if input.Value != "" && !templateVariable.MatchString(input.Value) {
+			return input.Value
+		}
+		if input.Default != "" {
+			return input.Default
+		}
+	}
+
+	name := origin.Name
+	placeholder := "<" + name + ">"
+	if g.client == ClientVSCode {
+		placeholder = "${input:" + name + "}"
+		// Inputs shared by several servers are prompted for once
+		if !g.prompted[name] {
+			g.prompted[name] = true
+			description := input.Description
+			if description == "" {
+				description = name
+			}
+			g.inputs = append(g.inputs, map[string]any{
+				"type":        "promptString",
+				"id":          name,
+				"description": description,
+				"password":    input.IsSecret,
+			})
+		}
+	}
+	origin.Description = input.Description
+	origin.Secret = input.IsSecret
+	origin.Placeholder = placeholder
+	entry.Variables = append(entry.Variables, origin)
+	return placeholder
+}
+
+// headerVariable returns the name of the environment variable holding the value of a header
+// (e.g. MCP_HEADER_X_API_KEY for X-Api-Key)
+func headerVariable(header string) string {
+	return "MCP_HEADER_" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, header)
+}
+
+// entryKey returns the key of the entry of a server: the last segment of its name, or its full
+// name when another entry already uses the segment
+func entryKey(name string, entries map[string]any) string {
+	key := name[strings.LastIndex(name, "/")+1:]
+	if _, taken := entries[key]; taken || key == "" {
+		return name
+	}
+	return key
+}
+
+// versioned appends the version to a package identifier with the separator of its ecosystem
+func versioned(identifier, separator, version string) string {
+	if version == "" {
+		return identifier
+	}
+	return identifier + separator + version
+}
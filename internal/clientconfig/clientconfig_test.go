@@ -0,0 +1,311 @@
+package clientconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envVar(name string, required, secret bool, defaultValue string) model.KeyValueInput {
+	variable := model.KeyValueInput{Name: name}
+	variable.IsRequired = required
+	variable.IsSecret = secret
+	variable.Default = defaultValue
+	return variable
+}
+
+func testServers() []*upstreamv0.ServerJSON {
+	return []*upstreamv0.ServerJSON{
+		{
+			Name:    "io.github.acme/search",
+			Version: "1.0.0",
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@acme/search",
+				Version:      "1.0.0",
+				EnvironmentVariables: []model.KeyValueInput{
+					envVar("ACME_API_KEY", true, true, ""),
+					envVar("ACME_REGION", true, false, "eu"),
+					envVar("ACME_DEBUG", false, false, ""),
+				},
+			}},
+		},
+		{
+			Name:    "io.github.other/search",
+			Version: "2.0.0",
+			Packages: []model.Package{
+				{RegistryType: model.RegistryTypeMCPB, Identifier: "https://example.com/search.mcpb"},
+				{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/other/search:2.0.0",
+					EnvironmentVariables: []model.KeyValueInput{envVar("ACME_API_KEY", true, true, "")}},
+			},
+		},
+		{
+			Name:    "io.github.acme/hosted",
+			Version: "1.0.0",
+			Remotes: []model.Transport{{Type: "streamable-http", URL: "https://mcp.acme.dev"}},
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	config, err := Generate(ClientClaudeDesktop, testServers())
+	require.NoError(t, err)
+
+	body, err := json.Marshal(config.Config)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"mcpServers": {
+		"search": {
+			"command": "npx",
+			"args": ["-y", "@acme/search@1.0.0"],
+			"env": {"ACME_API_KEY": "<ACME_API_KEY>", "ACME_REGION": "eu"}
+		},
+		"io.github.other/search": {
+			"command": "docker",
+			"args": ["run", "-i", "--rm", "-e", "ACME_API_KEY", "ghcr.io/other/search:2.0.0"],
+			"env": {"ACME_API_KEY": "<ACME_API_KEY>"}
+		},
+		"hosted": {
+			"command": "npx",
+			"args": ["-y", "mcp-remote", "https://mcp.acme.dev", "--transport", "http-only"]
+		}
+	}}`, string(body))
+
+	require.Len(t, config.Servers, 3)
+	assert.Equal(t, []Variable{{Name: "ACME_API_KEY", Secret: true, Placeholder: "<ACME_API_KEY>"}},
+		config.Servers[0].Variables)
+	assert.Equal(t, model.RegistryTypeOCI, config.Servers[1].RegistryType)
+	assert.Equal(t, "https://mcp.acme.dev", config.Servers[2].URL)
+	assert.Empty(t, config.Warnings)
+
+	config, err = Generate(ClientCursor, []*upstreamv0.ServerJSON{{Name: "io.github.acme/empty"}})
+	require.NoError(t, err)
+	assert.Empty(t, config.Servers)
+	assert.Equal(t, []string{"io.github.acme/empty has no remote endpoint nor package cursor can launch and was left out"},
+		config.Warnings)
+}
+
+func TestGenerateRemote(t *testing.T) {
+	t.Parallel()
+
+	authorization := model.KeyValueInput{Name: "Authorization"}
+	authorization.IsRequired = true
+	authorization.IsSecret = true
+	authorization.Value = "Bearer {api_key}"
+	authorization.Variables = map[string]model.Input{"api_key": {Description: "Acme API key"}}
+	region := envVar("X-Acme-Region", false, false, "")
+	region.Value = "eu"
+	server := &upstreamv0.ServerJSON{
+		Name:    "io.github.acme/hosted",
+		Version: "1.0.0",
+		Remotes: []model.Transport{
+			{Type: model.TransportTypeSSE, URL: "https://mcp.acme.dev/sse",
+				Headers: []model.KeyValueInput{authorization, region, envVar("X-Debug", false, false, "")}},
+		},
+		Packages: []model.Package{{RegistryType: model.RegistryTypeNPM, Identifier: "@acme/hosted"}},
+	}
+
+	tests := []struct {
+		client string
+		want   string
+	}{
+		{client: ClientClaudeDesktop, want: `{"mcpServers": {"hosted": {
+			"command": "npx",
+			"args": ["-y", "mcp-remote", "https://mcp.acme.dev/sse", "--transport", "sse-only",
+				"--header", "Authorization:${MCP_HEADER_AUTHORIZATION}",
+				"--header", "X-Acme-Region:${MCP_HEADER_X_ACME_REGION}"],
+			"env": {"MCP_HEADER_AUTHORIZATION": "Bearer <api_key>", "MCP_HEADER_X_ACME_REGION": "eu"}
+		}}}`},
+		{client: ClientCursor, want: `{"mcpServers": {"hosted": {
+			"url": "https://mcp.acme.dev/sse",
+			"headers": {"Authorization": "Bearer <api_key>", "X-Acme-Region": "eu"}
+		}}}`},
+		{client: ClientWindsurf, want: `{"mcpServers": {"hosted": {
+			"serverUrl": "https://mcp.acme.dev/sse",
+			"headers": {"Authorization": "Bearer <api_key>", "X-Acme-Region": "eu"}
+		}}}`},
+		{client: ClientZed, want: `{"context_servers": {"hosted": {
+			"url": "https://mcp.acme.dev/sse",
+			"headers": {"Authorization": "Bearer <api_key>", "X-Acme-Region": "eu"}
+		}}}`},
+		{client: ClientVSCode, want: `{
+			"servers": {"hosted": {
+				"type": "sse",
+				"url": "https://mcp.acme.dev/sse",
+				"headers": {"Authorization": "Bearer ${input:api_key}", "X-Acme-Region": "eu"}
+			}},
+			"inputs": [{"type": "promptString", "id": "api_key", "description": "Acme API key", "password": true}]
+		}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.client, func(t *testing.T) {
+			t.Parallel()
+			config, err := Generate(tt.client, []*upstreamv0.ServerJSON{server})
+			require.NoError(t, err)
+
+			body, err := json.Marshal(config.Config)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(body))
+
+			// Remote endpoints are preferred to packages
+			require.Len(t, config.Servers, 1)
+			assert.Empty(t, config.Servers[0].RegistryType)
+			require.Len(t, config.Servers[0].Variables, 1)
+			assert.Equal(t, "Authorization", config.Servers[0].Variables[0].Header)
+			assert.True(t, config.Servers[0].Variables[0].Secret)
+		})
+	}
+}
+
+func TestGenerateArguments(t *testing.T) {
+	t.Parallel()
+
+	argument := func(argumentType model.ArgumentType, name, value string, required bool) model.Argument {
+		arg := model.Argument{Type: argumentType, Name: name}
+		arg.Value = value
+		arg.IsRequired = required
+		return arg
+	}
+	workspace := argument(model.ArgumentTypePositional, "", "", true)
+	workspace.ValueHint = "workspace"
+	workspace.Description = "Directory to serve"
+	token := argument(model.ArgumentTypeNamed, "--token", "{token}", true)
+	token.IsSecret = true
+	stdio := argument(model.ArgumentTypeNamed, "--stdio", "", true)
+	stdio.Format = model.FormatBoolean
+	server := &upstreamv0.ServerJSON{
+		Name: "io.github.acme/files",
+		Packages: []model.Package{{
+			RegistryType: model.RegistryTypeOCI,
+			Identifier:   "ghcr.io/acme/files:1.0.0",
+			RuntimeArguments: []model.Argument{
+				argument(model.ArgumentTypeNamed, "--network", "host", false),
+				argument(model.ArgumentTypeNamed, "--memory", "", false),
+			},
+			PackageArguments: []model.Argument{
+				argument(model.ArgumentTypeNamed, "--mode", "read-only", false),
+				stdio,
+				token,
+				workspace,
+			},
+		}},
+	}
+
+	config, err := Generate(ClientCursor, []*upstreamv0.ServerJSON{server})
+	require.NoError(t, err)
+	body, err := json.Marshal(config.Config)
+	require.NoError(t, err)
+	// Optional arguments without value are left out
+	assert.JSONEq(t, `{"mcpServers": {"files": {
+		"command": "docker",
+		"args": ["run", "-i", "--rm", "--network", "host", "ghcr.io/acme/files:1.0.0",
+			"--mode", "read-only", "--stdio", "--token", "<token>", "<workspace>"]
+	}}}`, string(body))
+	assert.Equal(t, []Variable{
+		{Name: "token", Secret: true, Argument: "--token", Placeholder: "<token>"},
+		{Name: "workspace", Description: "Directory to serve", Argument: "workspace", Placeholder: "<workspace>"},
+	}, config.Servers[0].Variables)
+
+	config, err = Generate(ClientVSCode, []*upstreamv0.ServerJSON{server})
+	require.NoError(t, err)
+	args := config.Config["servers"].(map[string]any)["files"].(map[string]any)["args"]
+	assert.Equal(t, []string{"run", "-i", "--rm", "--network", "host", "ghcr.io/acme/files:1.0.0",
+		"--mode", "read-only", "--stdio", "--token", "${input:token}", "${input:workspace}"}, args)
+	assert.Len(t, config.Config["inputs"], 2)
+}
+
+func TestGenerateDocker(t *testing.T) {
+	t.Parallel()
+
+	config := envVar("ACME_CONFIG", true, false, "")
+	config.Format = model.FormatFilePath
+	data := model.Argument{Type: model.ArgumentTypeNamed, Name: "--data"}
+	data.IsRequired = true
+	data.Format = model.FormatFilePath
+	output := model.Argument{Type: model.ArgumentTypePositional, ValueHint: "output"}
+	output.Default = "/tmp/acme"
+	output.Format = model.FormatFilePath
+	server := &upstreamv0.ServerJSON{
+		Name: "io.github.acme/files",
+		Packages: []model.Package{{
+			RegistryType:         model.RegistryTypeOCI,
+			Identifier:           "ghcr.io/acme/files:1.0.0",
+			EnvironmentVariables: []model.KeyValueInput{config, envVar("ACME_TOKEN", true, true, "")},
+			PackageArguments:     []model.Argument{data, output, output},
+		}},
+	}
+
+	generated, err := Generate(ClientClaudeDesktop, []*upstreamv0.ServerJSON{server})
+	require.NoError(t, err)
+	body, err := json.Marshal(generated.Config)
+	require.NoError(t, err)
+	// Files are mounted once at the path the server is given
+	assert.JSONEq(t, `{"mcpServers": {"files": {
+		"command": "docker",
+		"args": ["run", "-i", "--rm", "-e", "ACME_CONFIG", "-e", "ACME_TOKEN",
+			"-v", "<ACME_CONFIG>:<ACME_CONFIG>", "-v", "<data>:<data>", "-v", "/tmp/acme:/tmp/acme",
+			"ghcr.io/acme/files:1.0.0", "--data", "<data>", "/tmp/acme", "/tmp/acme"],
+		"env": {"ACME_CONFIG": "<ACME_CONFIG>", "ACME_TOKEN": "<ACME_TOKEN>"}
+	}}}`, string(body))
+
+	// Packages run outside containers see the files of the client
+	server.Packages[0].RegistryType, server.Packages[0].Identifier = model.RegistryTypeNPM, "@acme/files"
+	generated, err = Generate(ClientCursor, []*upstreamv0.ServerJSON{server})
+	require.NoError(t, err)
+	args := generated.Config["mcpServers"].(map[string]any)["files"].(map[string]any)["args"]
+	assert.Equal(t, []string{"-y", "@acme/files", "--data", "<data>", "/tmp/acme", "/tmp/acme"}, args)
+}
+
+func TestGenerateVSCode(t *testing.T) {
+	t.Parallel()
+
+	config, err := Generate(ClientVSCode, testServers()[:2])
+	require.NoError(t, err)
+
+	body, err := json.Marshal(config.Config)
+	require.NoError(t, err)
+	// Variables shared by several servers are prompted for once
+	assert.JSONEq(t, `{
+		"servers": {
+			"search": {
+				"type": "stdio",
+				"command": "npx",
+				"args": ["-y", "@acme/search@1.0.0"],
+				"env": {"ACME_API_KEY": "${input:ACME_API_KEY}", "ACME_REGION": "eu"}
+			},
+			"io.github.other/search": {
+				"type": "stdio",
+				"command": "docker",
+				"args": ["run", "-i", "--rm", "-e", "ACME_API_KEY", "ghcr.io/other/search:2.0.0"],
+				"env": {"ACME_API_KEY": "${input:ACME_API_KEY}"}
+			}
+		},
+		"inputs": [{"type": "promptString", "id": "ACME_API_KEY", "description": "ACME_API_KEY", "password": true}]
+	}`, string(body))
+}
+
+func TestGenerateTopLevelKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := map[string]string{
+		ClientClaudeDesktop: "mcpServers",
+		ClientCursor:        "mcpServers",
+		ClientWindsurf:      "mcpServers",
+		ClientVSCode:        "servers",
+		ClientZed:           "context_servers",
+	}
+	for _, client := range Clients() {
+		config, err := Generate(client, nil)
+		require.NoError(t, err)
+		assert.Contains(t, config.Config, keys[client], client)
+	}
+
+	_, err := Generate("notepad", nil)
+	assert.ErrorIs(t, err, ErrUnsupportedClient)
+}
@@ -2,15 +2,22 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
+	"golang.org/x/net/http/httpguts"
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
@@ -48,6 +55,10 @@ type FileStorageConfig struct {
 	// BaseDir is the base directory for file storage
 	// Defaults to "./data" if not specified
 	BaseDir string `yaml:"baseDir,omitempty"`
+
+	// Indent pretty-prints the stored registry files. Defaults to false, as
+	// indentation makes the files of large catalogs noticeably bigger.
+	Indent bool `yaml:"indent,omitempty"`
 }
 
 const (
@@ -61,132 +72,1409 @@ const (
 // Option defines the interface for configuration options
 type Option func(*loaderConfig) error
 
-// loaderConfig defines the configuration for loading a configuration
-type loaderConfig struct {
-	path string
+// loaderConfig defines the configuration for loading a configuration
+type loaderConfig struct {
+	path            string
+	secretProviders map[string]SecretProvider
+}
+
+// WithConfigPath loads configuration from a YAML file
+func WithConfigPath(path string) Option {
+	return func(cfg *loaderConfig) error {
+		if path == "" {
+			return fmt.Errorf("path is required")
+		}
+
+		// Resolve symlinks to prevent symlink attacks.
+		// Note that this calls filepath.Clean internally.
+		realPath, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate symlinks: %w", err)
+		}
+
+		// Validate the path to prevent path traversal attacks
+		if !filepath.IsAbs(realPath) {
+			if !filepath.IsLocal(realPath) {
+				return fmt.Errorf("path is not local or contains invalid traversal: %s", path)
+			}
+		}
+
+		cfg.path = realPath
+		return nil
+	}
+}
+
+// Config represents the root configuration structure
+type Config struct {
+	// RegistryName is the name/identifier for this registry instance
+	// Defaults to "default" if not specified
+	RegistryName   string                `yaml:"registryName,omitempty"`
+	Registries     []RegistryConfig      `yaml:"registries"`
+	Database       *DatabaseConfig       `yaml:"database,omitempty"`
+	FileStorage    *FileStorageConfig    `yaml:"fileStorage,omitempty"`
+	Auth           *AuthConfig           `yaml:"auth,omitempty"`
+	Mirror         *MirrorConfig         `yaml:"mirror,omitempty"`
+	Search         *SearchConfig         `yaml:"search,omitempty"`
+	Telemetry      *TelemetryConfig      `yaml:"telemetry,omitempty"`
+	Concurrency    *ConcurrencyConfig    `yaml:"concurrency,omitempty"`
+	Signing        *SigningConfig        `yaml:"signing,omitempty"`
+	Approval       *ApprovalConfig       `yaml:"approval,omitempty"`
+	Ticketing      *TicketingConfig      `yaml:"ticketing,omitempty"`
+	Cache          *CacheConfig          `yaml:"cache,omitempty"`
+	ResponseMeta   *ResponseMetaConfig   `yaml:"responseMeta,omitempty"`
+	Cluster        *ClusterConfig        `yaml:"cluster,omitempty"`
+	Attribution    *AttributionConfig    `yaml:"attribution,omitempty"`
+	LinkChecks     *LinkChecksConfig     `yaml:"linkChecks,omitempty"`
+	Summaries      *SummariesConfig      `yaml:"summaries,omitempty"`
+	Listeners      *ListenersConfig      `yaml:"listeners,omitempty"`
+	RequestLogging *RequestLoggingConfig `yaml:"requestLogging,omitempty"`
+	Snapshot       *SnapshotConfig       `yaml:"snapshot,omitempty"`
+	Annotations    *AnnotationsConfig    `yaml:"annotations,omitempty"`
+	Reviews        *ReviewsConfig        `yaml:"reviews,omitempty"`
+	SBOM           *SBOMConfig           `yaml:"sbom,omitempty"`
+
+	// Features enables or disables experimental features by name, overriding their default
+	// state. Environment variables THV_REGISTRY_FEATURE_<NAME> override these in turn.
+	Features map[string]bool `yaml:"features,omitempty"`
+}
+
+// RegistryConfig defines a single registry data source configuration
+type RegistryConfig struct {
+	// Name is the identifier for this registry
+	Name string `yaml:"name"`
+
+	// Format specifies the data format (toolhive or upstream)
+	Format string `yaml:"format"`
+
+	// Preset selects a built-in registry source ("official" or "toolhive")
+	// instead of configuring git, api, or file explicitly.
+	// Name defaults to the preset name and syncPolicy to an hourly sync.
+	Preset string `yaml:"preset,omitempty"`
+
+	// Type-specific configurations (only one should be set)
+	Git        *GitConfig        `yaml:"git,omitempty"`
+	API        *APIConfig        `yaml:"api,omitempty"`
+	File       *FileConfig       `yaml:"file,omitempty"`
+	Managed    *ManagedConfig    `yaml:"managed,omitempty"`
+	Kubernetes *KubernetesConfig `yaml:"kubernetes,omitempty"`
+
+	// Per-registry sync policy
+	// Note: Not applicable for non-synced registries (managed and kubernetes) - will be ignored if set
+	SyncPolicy *SyncPolicyConfig `yaml:"syncPolicy,omitempty"`
+
+	// Per-registry filtering rules
+	// Note: Not applicable for non-synced registries (managed and kubernetes) - will be ignored if set
+	Filter *FilterConfig `yaml:"filter,omitempty"`
+
+	// Per-registry sanitization of server titles and descriptions, applied when synced or,
+	// for managed registries, when published
+	// Note: Not applicable for kubernetes registries - will be ignored if set
+	Sanitize *SanitizeConfig `yaml:"sanitize,omitempty"`
+
+	// Transport customizes the HTTP requests fetching the source, e.g. for private mirrors
+	// behind gateways. Only applicable to api sources and file sources with a URL.
+	Transport *TransportConfig `yaml:"transport,omitempty"`
+
+	// attribution is the attribution of the deployment, set at load time
+	attribution *AttributionConfig
+}
+
+// GetAttribution returns the attribution of the deployment sent with the requests fetching the
+// source, or nil if none is configured or the source disables it
+func (r *RegistryConfig) GetAttribution() *AttributionConfig {
+	if r.Transport != nil && r.Transport.DisableAttribution {
+		return nil
+	}
+	return r.attribution
+}
+
+// TransportConfig customizes the HTTP requests fetching a registry source
+type TransportConfig struct {
+	// Headers are sent with every request (e.g., X-Org-Id). A "Host" header overrides the
+	// host the requests are addressed to.
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// SecretHeaders are sent with every request, with values given as secret references
+	// (e.g., X-Org-Token: env://ORG_TOKEN) resolved at startup
+	SecretHeaders map[string]string `yaml:"secretHeaders,omitempty"`
+
+	// TLS configures the TLS connections to the source
+	TLS *TLSConfig `yaml:"tls,omitempty"`
+
+	// UserAgent replaces the default User-Agent of the requests
+	UserAgent string `yaml:"userAgent,omitempty"`
+
+	// DisableAttribution leaves the deployment attribution (see AttributionConfig) out of the requests
+	DisableAttribution bool `yaml:"disableAttribution,omitempty"`
+
+	// secretHeaders are the values SecretHeaders resolve to
+	secretHeaders map[string]string
+}
+
+// GetHeaders returns the headers sent with every request, including the resolved secret headers
+func (t *TransportConfig) GetHeaders() map[string]string {
+	headers := make(map[string]string, len(t.Headers)+len(t.secretHeaders))
+	maps.Copy(headers, t.Headers)
+	maps.Copy(headers, t.secretHeaders)
+	return headers
+}
+
+// validate performs validation on the transport configuration
+func (t *TransportConfig) validate(prefix string) error {
+	for name := range t.Headers {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return fmt.Errorf("%s: transport.headers has invalid header name %q", prefix, name)
+		}
+		if _, ok := t.SecretHeaders[name]; ok {
+			return fmt.Errorf("%s: header %q is set in both transport.headers and transport.secretHeaders", prefix, name)
+		}
+	}
+	for name, value := range t.SecretHeaders {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return fmt.Errorf("%s: transport.secretHeaders has invalid header name %q", prefix, name)
+		}
+		if _, err := parseSecretReference(value); err != nil {
+			return fmt.Errorf("%s: transport.secretHeaders[%s] %w", prefix, name, err)
+		}
+	}
+
+	if t.UserAgent != "" && !httpguts.ValidHeaderFieldValue(t.UserAgent) {
+		return fmt.Errorf("%s: transport.userAgent is not a valid header value", prefix)
+	}
+
+	if t.TLS != nil {
+		if (t.TLS.CertFile == "") != (t.TLS.KeyFile == "") {
+			return fmt.Errorf("%s: transport.tls.certFile and transport.tls.keyFile must be set together", prefix)
+		}
+	}
+
+	return nil
+}
+
+// TLSConfig configures the TLS connections to a registry source
+type TLSConfig struct {
+	// CACertFile is the path to a PEM bundle of the certificate authorities trusted in addition
+	// to the system ones, e.g. for mirrors with certificates issued by a private CA
+	CACertFile string `yaml:"caCertFile,omitempty"`
+
+	// CertFile and KeyFile are the paths to the PEM client certificate and key presented
+	// to sources requiring mutual TLS
+	CertFile string `yaml:"certFile,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+
+	// ServerName overrides the server name sent with SNI and used to verify the certificate
+	// of the source, e.g. when the source is reached through an IP address or a tunnel
+	ServerName string `yaml:"serverName,omitempty"`
+}
+
+// deploymentIDPattern restricts deployment ids to characters safe in a User-Agent comment
+var deploymentIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// AttributionConfig identifies this deployment to the upstream registries it fetches from, so that
+// their operators can attribute and rate-limit its traffic
+type AttributionConfig struct {
+	// DeploymentID identifies this deployment (e.g., "acme-prod"). It is added to the User-Agent
+	// and sent in the X-Registry-Deployment-Id header.
+	DeploymentID string `yaml:"deploymentId,omitempty"`
+
+	// Contact is where upstream operators can reach the maintainers of this deployment
+	// (e.g., "platform-team@example.com"), sent in the From header
+	Contact string `yaml:"contact,omitempty"`
+}
+
+// validate performs validation on the attribution configuration
+func (a *AttributionConfig) validate() error {
+	if a.DeploymentID != "" && !deploymentIDPattern.MatchString(a.DeploymentID) {
+		return fmt.Errorf("attribution.deploymentId must only contain letters, digits, '.', '_' and '-'")
+	}
+	if a.Contact != "" && !httpguts.ValidHeaderFieldValue(a.Contact) {
+		return fmt.Errorf("attribution.contact is not a valid header value")
+	}
+	return nil
+}
+
+// GitConfig defines Git source settings
+type GitConfig struct {
+	// Repository is the Git repository URL (HTTP/HTTPS/SSH)
+	Repository string `yaml:"repository"`
+
+	// Branch is the Git branch to use (mutually exclusive with Tag and Commit)
+	Branch string `yaml:"branch,omitempty"`
+
+	// Tag is the Git tag to use (mutually exclusive with Branch and Commit)
+	Tag string `yaml:"tag,omitempty"`
+
+	// Commit is the Git commit SHA to use (mutually exclusive with Branch and Tag)
+	Commit string `yaml:"commit,omitempty"`
+
+	// Path is the path to the registry file within the repository
+	Path string `yaml:"path,omitempty"`
+}
+
+// APIConfig defines API source configuration for upstream MCP Registry APIs
+type APIConfig struct {
+	// Endpoint is the base API URL (without path)
+	// The registry handler will append the appropriate paths for the MCP Registry API v0.1:
+	//   - /v0.1/servers - List all servers
+	//   - /v0.1/servers/{name}/versions - List server versions
+	//   - /v0.1/servers/{name}/versions/{version} - Get specific version
+	// Example: "http://my-registry-api.default.svc.cluster.local/registry"
+	Endpoint string `yaml:"endpoint"`
+
+	// Strict rejects upstream responses containing fields unknown to the MCP Registry
+	// API schema instead of silently ignoring them, surfacing schema drift as sync failures
+	Strict bool `yaml:"strict,omitempty"`
+}
+
+// FileConfig defines file source configuration
+// Supports both local files and URL-hosted files
+type FileConfig struct {
+	// Path is the path to the registry.json file on the local filesystem
+	// Can be absolute or relative to the working directory
+	// Mutually exclusive with URL - exactly one must be specified
+	Path string `yaml:"path,omitempty"`
+
+	// URL is the HTTP/HTTPS URL to fetch the registry file from
+	// Mutually exclusive with Path - exactly one must be specified
+	// HTTPS is required unless the host is localhost or THV_REGISTRY_INSECURE_URL=true
+	URL string `yaml:"url,omitempty"`
+
+	// Timeout is the timeout for HTTP requests when using URL
+	// Defaults to 30s if not specified
+	// Only applicable when URL is set
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// ManagedConfig defines configuration for managed registries
+// Managed registries are directly manipulated via API and do not sync from external sources
+// Note: Initially empty, may be used as a placeholder for future configuration options
+type ManagedConfig struct {
+	// Future fields can be added here as needed
+}
+
+// DefaultKubernetesNamespace is the namespace watched by Kubernetes registries when none is configured
+const DefaultKubernetesNamespace = "toolhive-system"
+
+// KubernetesConfig defines configuration for Kubernetes-based registries
+// Kubernetes registries discover MCP servers from running Kubernetes resources
+type KubernetesConfig struct {
+	// Namespaces are the namespaces whose MCPServer, VirtualMCPServer and MCPRemoteProxy
+	// resources are watched. Defaults to DefaultKubernetesNamespace.
+	Namespaces []string `yaml:"namespaces,omitempty"`
+}
+
+// GetNamespaces returns the watched namespaces, or DefaultKubernetesNamespace if none is configured
+func (k *KubernetesConfig) GetNamespaces() []string {
+	if k == nil || len(k.Namespaces) == 0 {
+		return []string{DefaultKubernetesNamespace}
+	}
+	return k.Namespaces
+}
+
+// MirrorConfig defines read-through mirror settings.
+// When configured, server lookups that miss the synced data are fetched from
+// the upstream MCP Registry API and cached for CacheTTL.
+type MirrorConfig struct {
+	// Upstream is the base URL of the upstream MCP Registry API (without /v0.1)
+	// Example: "https://registry.modelcontextprotocol.io"
+	Upstream string `yaml:"upstream"`
+
+	// CacheTTL is how long upstream responses are cached (e.g., "5m")
+	// Defaults to 5m if not specified
+	CacheTTL string `yaml:"cacheTTL,omitempty"`
+
+	// Sanitize is the sanitization of the titles and descriptions of the servers read through
+	Sanitize *SanitizeConfig `yaml:"sanitize,omitempty"`
+}
+
+// DefaultMirrorCacheTTL is the mirror cache TTL used when cacheTTL is not set
+const DefaultMirrorCacheTTL = 5 * time.Minute
+
+// GetCacheTTL returns the configured cache TTL or DefaultMirrorCacheTTL if not specified.
+// The value is assumed to have been validated by LoadConfig.
+func (m *MirrorConfig) GetCacheTTL() time.Duration {
+	if m.CacheTTL == "" {
+		return DefaultMirrorCacheTTL
+	}
+	ttl, err := time.ParseDuration(m.CacheTTL)
+	if err != nil {
+		return DefaultMirrorCacheTTL
+	}
+	return ttl
+}
+
+// validate performs validation on the mirror configuration
+func (m *MirrorConfig) validate() error {
+	if m.Upstream == "" {
+		return fmt.Errorf("mirror.upstream is required")
+	}
+	upstreamURL, err := url.Parse(m.Upstream)
+	if err != nil {
+		return fmt.Errorf("mirror.upstream is invalid: %w", err)
+	}
+	if !upstreamURL.IsAbs() || upstreamURL.Host == "" {
+		return fmt.Errorf("mirror.upstream must be an absolute URL with host")
+	}
+	if upstreamURL.Scheme != "http" && upstreamURL.Scheme != "https" {
+		return fmt.Errorf("mirror.upstream must use http or https scheme")
+	}
+
+	if m.CacheTTL != "" {
+		ttl, err := time.ParseDuration(m.CacheTTL)
+		if err != nil {
+			return fmt.Errorf("mirror.cacheTTL must be a valid duration (e.g., '5m', '1h'): %w", err)
+		}
+		if ttl <= 0 {
+			return fmt.Errorf("mirror.cacheTTL must be positive")
+		}
+	}
+
+	return validateSanitize(m.Sanitize, "mirror")
+}
+
+// ConcurrencyConfig limits how many requests to expensive extension API endpoints
+// are handled at once, so that bursts cannot overload the storage or upstream
+type ConcurrencyConfig struct {
+	// Limits maps an endpoint name (one of the extension API's ConcurrencyLimitedEndpoints, e.g. "suggestions")
+	// to its maximum number of concurrent requests. Requests beyond the limit wait for a slot.
+	// Endpoint names are validated when the server is built.
+	Limits map[string]int `yaml:"limits,omitempty"`
+}
+
+// validate performs validation on the concurrency configuration
+func (c *ConcurrencyConfig) validate() error {
+	for endpoint, limit := range c.Limits {
+		if limit <= 0 {
+			return fmt.Errorf("concurrency.limits.%s must be positive", endpoint)
+		}
+	}
+	return nil
+}
+
+// SigningConfig defines optional response signing. When enabled, JSON responses carry a
+// detached Ed25519 signature over their canonical form and the method, path and query of
+// their request, so that downstream systems can verify the data came from this registry.
+type SigningConfig struct {
+	// Enabled turns response signing on. Defaults to false.
+	Enabled bool `yaml:"enabled"`
+
+	// KeyFile is the path to the PEM-encoded PKCS#8 Ed25519 private key
+	KeyFile string `yaml:"keyFile,omitempty"`
+
+	// KeyID identifies the signing key in responses, to support key rotation
+	// Defaults to "default" if not specified
+	KeyID string `yaml:"keyId,omitempty"`
+}
+
+// DefaultSigningKeyID is the signing key identifier used when keyId is not set
+const DefaultSigningKeyID = "default"
+
+// GetKeyID returns the configured signing key identifier or DefaultSigningKeyID if not specified
+func (s *SigningConfig) GetKeyID() string {
+	if s.KeyID == "" {
+		return DefaultSigningKeyID
+	}
+	return s.KeyID
+}
+
+// validate performs validation on the signing configuration.
+// A disabled configuration is not validated further.
+func (s *SigningConfig) validate() error {
+	if !s.Enabled {
+		return nil
+	}
+	if s.KeyFile == "" {
+		return fmt.Errorf("signing.keyFile is required when signing is enabled")
+	}
+	return nil
+}
+
+// ApprovalConfig defines the external approval system consulted before install instructions
+// (such as offline bundles) are handed out. Servers the system does not approve are answered
+// with the approval ticket instead of instructions.
+type ApprovalConfig struct {
+	// Type is the kind of approval system: "webhook" or "opa"
+	Type string `yaml:"type"`
+
+	// URL is the webhook endpoint, or the OPA data API document URL
+	// (e.g., "http://opa:8181/v1/data/mcp/install")
+	URL string `yaml:"url"`
+
+	// Timeout bounds each approval check (e.g., "5s")
+	// Defaults to 5s if not specified
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// approvalTypes are the supported approval system types
+var approvalTypes = []string{"webhook", "opa"}
+
+// GetTimeout returns the configured approval check timeout, or zero to use the default.
+// The value is assumed to have been validated by LoadConfig.
+func (a *ApprovalConfig) GetTimeout() time.Duration {
+	timeout, err := time.ParseDuration(a.Timeout)
+	if err != nil {
+		return 0
+	}
+	return timeout
+}
+
+// validate performs validation on the approval configuration
+func (a *ApprovalConfig) validate() error {
+	if !slices.Contains(approvalTypes, a.Type) {
+		return fmt.Errorf("approval.type must be one of: %s", strings.Join(approvalTypes, ", "))
+	}
+
+	if a.URL == "" {
+		return fmt.Errorf("approval.url is required")
+	}
+	approvalURL, err := url.Parse(a.URL)
+	if err != nil {
+		return fmt.Errorf("approval.url is invalid: %w", err)
+	}
+	if !approvalURL.IsAbs() || approvalURL.Host == "" {
+		return fmt.Errorf("approval.url must be an absolute URL with host")
+	}
+	if approvalURL.Scheme != "http" && approvalURL.Scheme != "https" {
+		return fmt.Errorf("approval.url must use http or https scheme")
+	}
+
+	if a.Timeout != "" {
+		timeout, err := time.ParseDuration(a.Timeout)
+		if err != nil {
+			return fmt.Errorf("approval.timeout must be a valid duration (e.g., '5s'): %w", err)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("approval.timeout must be positive")
+		}
+	}
+
+	return nil
+}
+
+// TicketingConfig defines the ticketing system server approval requests are filed in
+type TicketingConfig struct {
+	// Type is the kind of ticketing system: "jira" or "servicenow"
+	Type string `yaml:"type"`
+
+	// URL is the base URL of the instance (e.g., "https://example.atlassian.net")
+	URL string `yaml:"url"`
+
+	// Project is the Jira project key tickets are filed in. Required for Jira.
+	Project string `yaml:"project,omitempty"`
+
+	// IssueType is the Jira issue type of the tickets. Defaults to "Task".
+	IssueType string `yaml:"issueType,omitempty"`
+
+	// Table is the ServiceNow table tickets are filed in. Defaults to "sc_request".
+	Table string `yaml:"table,omitempty"`
+
+	// Username authenticates with basic auth alongside the token.
+	// Without a username, the token is sent as a bearer token.
+	Username string `yaml:"username,omitempty"`
+
+	// TokenFile is the path to a file containing the API token or password
+	// The file should contain only the token with optional trailing whitespace
+	TokenFile string `yaml:"tokenFile,omitempty"`
+
+	// Token is a reference to the API token or password in a secret store
+	// (e.g., "env://TICKETING_TOKEN" or "vault://secret/registry#ticketing"), resolved at startup
+	Token string `yaml:"token,omitempty"`
+
+	// token is the value Token resolves to
+	token string
+}
+
+// ticketingTypes are the supported ticketing system types
+var ticketingTypes = []string{"jira", "servicenow"}
+
+// GetToken returns the API token resolved from Token, or read from the file specified in TokenFile.
+// Returns empty string if neither is configured.
+// Returns an error if the file cannot be read.
+func (t *TicketingConfig) GetToken() (string, error) {
+	if t.token != "" {
+		return t.token, nil
+	}
+	token, err := readSecretFromFile(t.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read ticketing token: %w", err)
+	}
+	return token, nil
+}
+
+// validate performs validation on the ticketing configuration
+func (t *TicketingConfig) validate() error {
+	if !slices.Contains(ticketingTypes, t.Type) {
+		return fmt.Errorf("ticketing.type must be one of: %s", strings.Join(ticketingTypes, ", "))
+	}
+
+	if t.URL == "" {
+		return fmt.Errorf("ticketing.url is required")
+	}
+	ticketingURL, err := url.Parse(t.URL)
+	if err != nil {
+		return fmt.Errorf("ticketing.url is invalid: %w", err)
+	}
+	if !ticketingURL.IsAbs() || ticketingURL.Host == "" {
+		return fmt.Errorf("ticketing.url must be an absolute URL with host")
+	}
+	if ticketingURL.Scheme != "http" && ticketingURL.Scheme != "https" {
+		return fmt.Errorf("ticketing.url must use http or https scheme")
+	}
+
+	if t.Type == "jira" && t.Project == "" {
+		return fmt.Errorf("ticketing.project is required for jira")
+	}
+
+	if t.Token != "" {
+		if t.TokenFile != "" {
+			return fmt.Errorf("ticketing.token and ticketing.tokenFile are mutually exclusive")
+		}
+		if _, err := parseSecretReference(t.Token); err != nil {
+			return fmt.Errorf("ticketing.token %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CacheConfig bounds the memory used by the in-memory caches (mirrored upstream responses,
+// SBOM summaries and package stats). Caches evict their least recently used entries to stay within their
+// quota and the memory budget they share.
+type CacheConfig struct {
+	// MemoryBudget is the memory shared by all caches, as a quantity (e.g., "256Mi").
+	// Defaults to 256Mi if not specified.
+	MemoryBudget string `yaml:"memoryBudget,omitempty"`
+
+	// Quotas maps a cache name ("mirror", "sbom", "package-stats", "changelog" or "summaries") to the memory it may use at most
+	// (e.g., "64Mi"). Caches without a quota are only bound by the memory budget.
+	Quotas map[string]string `yaml:"quotas,omitempty"`
+}
+
+// DefaultCacheMemoryBudget is the cache memory budget used when memoryBudget is not set
+const DefaultCacheMemoryBudget = 256 * 1024 * 1024
+
+// cacheNames are the caches accepting a memory quota
+var cacheNames = []string{"mirror", "sbom", "package-stats", "changelog", "summaries"}
+
+// GetMemoryBudget returns the configured memory budget in bytes or DefaultCacheMemoryBudget if not
+// specified. It is safe to call on a nil CacheConfig. The value is assumed to have been validated.
+func (c *CacheConfig) GetMemoryBudget() int64 {
+	if c == nil || c.MemoryBudget == "" {
+		return DefaultCacheMemoryBudget
+	}
+	budget, err := resource.ParseQuantity(c.MemoryBudget)
+	if err != nil {
+		return DefaultCacheMemoryBudget
+	}
+	return budget.Value()
+}
+
+// GetQuota returns the memory quota in bytes of the named cache, or 0 if it has none.
+// It is safe to call on a nil CacheConfig. The value is assumed to have been validated.
+func (c *CacheConfig) GetQuota(name string) int64 {
+	if c == nil || c.Quotas[name] == "" {
+		return 0
+	}
+	quota, err := resource.ParseQuantity(c.Quotas[name])
+	if err != nil {
+		return 0
+	}
+	return quota.Value()
+}
+
+// validate performs validation on the cache configuration
+func (c *CacheConfig) validate() error {
+	if c.MemoryBudget != "" {
+		if err := validateMemoryQuantity(c.MemoryBudget); err != nil {
+			return fmt.Errorf("cache.memoryBudget %w", err)
+		}
+	}
+	for name, quota := range c.Quotas {
+		if !slices.Contains(cacheNames, name) {
+			return fmt.Errorf("cache.quotas: unknown cache '%s' (supported: %s)", name, strings.Join(cacheNames, ", "))
+		}
+		if err := validateMemoryQuantity(quota); err != nil {
+			return fmt.Errorf("cache.quotas.%s %w", name, err)
+		}
+	}
+	return nil
+}
+
+// validateMemoryQuantity checks that a memory size is a positive quantity
+func validateMemoryQuantity(value string) error {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return fmt.Errorf("must be a valid quantity (e.g., '64Mi', '1Gi'): %w", err)
+	}
+	if quantity.Sign() <= 0 {
+		return fmt.Errorf("must be positive")
+	}
+	return nil
+}
+
+// ResponseMetaConfig selects the publisher-provided metadata namespaces (the keys of _meta)
+// returned in registry API server responses, since clients rarely need most of them.
+// A namespace matches an entry when it is equal to it or nested below it.
+// The ToolHive extension namespace ("io.github.stacklok") is always returned.
+type ResponseMetaConfig struct {
+	// Include returns only the listed namespaces (e.g., ["io.modelcontextprotocol.registry"])
+	Include []string `yaml:"include,omitempty"`
+
+	// Exclude strips the listed namespaces (e.g., ["com.example/build"])
+	Exclude []string `yaml:"exclude,omitempty"`
+}
+
+// validate performs validation on the response metadata configuration
+func (r *ResponseMetaConfig) validate() error {
+	if len(r.Include) > 0 && len(r.Exclude) > 0 {
+		return fmt.Errorf("responseMeta.include and responseMeta.exclude are mutually exclusive")
+	}
+	if slices.Contains(r.Include, "") {
+		return fmt.Errorf("responseMeta.include entries cannot be empty")
+	}
+	if slices.Contains(r.Exclude, "") {
+		return fmt.Errorf("responseMeta.exclude entries cannot be empty")
+	}
+	return nil
+}
+
+// ClusterConfig scopes the cluster operations of the cluster-install feature (installing, listing
+// and uninstalling servers), which are performed on behalf of API callers
+type ClusterConfig struct {
+	// Namespaces restricts the operations to the listed namespaces.
+	// Required when the cluster-install feature is enabled.
+	Namespaces []string `yaml:"namespaces,omitempty"`
+
+	// Impersonation performs the operations as the caller instead of the server service account,
+	// so that the cluster RBAC rules of the caller apply
+	Impersonation *ImpersonationConfig `yaml:"impersonation,omitempty"`
+}
+
+// ImpersonationConfig maps the identity of API callers, taken from their token claims,
+// to the Kubernetes user and groups impersonated on their behalf
+type ImpersonationConfig struct {
+	// Enabled turns impersonation on. Defaults to false.
+	Enabled bool `yaml:"enabled"`
+
+	// UserClaim is the token claim holding the impersonated user name.
+	// Defaults to "sub" if not specified.
+	UserClaim string `yaml:"userClaim,omitempty"`
+
+	// GroupsClaim is the token claim holding the impersonated groups, as a list or a single string.
+	// Defaults to "groups" if not specified.
+	GroupsClaim string `yaml:"groupsClaim,omitempty"`
+}
+
+// Default token claims of the impersonated user and groups
+const (
+	DefaultImpersonationUserClaim   = "sub"
+	DefaultImpersonationGroupsClaim = "groups"
+)
+
+// GetUserClaim returns the token claim holding the impersonated user name
+func (i *ImpersonationConfig) GetUserClaim() string {
+	if i == nil || i.UserClaim == "" {
+		return DefaultImpersonationUserClaim
+	}
+	return i.UserClaim
+}
+
+// GetGroupsClaim returns the token claim holding the impersonated groups
+func (i *ImpersonationConfig) GetGroupsClaim() string {
+	if i == nil || i.GroupsClaim == "" {
+		return DefaultImpersonationGroupsClaim
+	}
+	return i.GroupsClaim
+}
+
+// ValidateInstall checks that the cluster operations can be enabled safely: they must be
+// restricted to a namespace allowlist and performed for authenticated callers, as they create
+// and delete resources in the cluster and mount its secrets. It is called once the
+// cluster-install feature flag is resolved, and accepts a nil configuration.
+func (c *ClusterConfig) ValidateInstall(authConfig *AuthConfig) error {
+	if c == nil || len(c.Namespaces) == 0 {
+		return fmt.Errorf("cluster.namespaces is required when the cluster-install feature is enabled")
+	}
+	if authConfig == nil || authConfig.Mode == AuthModeAnonymous {
+		return fmt.Errorf("the cluster-install feature requires authenticated callers, auth.mode cannot be %s",
+			AuthModeAnonymous)
+	}
+	return nil
+}
+
+// validate performs validation on the cluster configuration. Impersonation needs the identity
+// of callers, so it can't be combined with anonymous authentication.
+func (c *ClusterConfig) validate(authConfig *AuthConfig) error {
+	if slices.Contains(c.Namespaces, "") {
+		return fmt.Errorf("cluster.namespaces entries cannot be empty")
+	}
+	if c.Impersonation != nil && c.Impersonation.Enabled && authConfig != nil && authConfig.Mode == AuthModeAnonymous {
+		return fmt.Errorf("cluster.impersonation requires authenticated callers, auth.mode cannot be %s", AuthModeAnonymous)
+	}
+	return nil
+}
+
+// TelemetryConfig defines opt-in anonymized usage reporting.
+// When enabled, aggregate request counts, hashed search terms and error rates
+// are periodically sent to Endpoint. Telemetry is disabled unless Enabled is true.
+type TelemetryConfig struct {
+	// Enabled turns usage reporting on. Defaults to false.
+	Enabled bool `yaml:"enabled"`
+
+	// Endpoint is the URL the usage reports are POSTed to
+	Endpoint string `yaml:"endpoint,omitempty"`
+
+	// Interval is how often usage reports are sent (e.g., "24h")
+	// Defaults to 24h if not specified
+	Interval string `yaml:"interval,omitempty"`
+
+	// HashKey is a reference to the secret keying the search term hashes in a secret store
+	// (e.g., "env://TELEMETRY_HASH_KEY" or "vault://secret/registry#telemetry"), resolved at startup.
+	// It must stay private to the deployment: whoever holds it can match hashes against likely terms.
+	HashKey string `yaml:"hashKey,omitempty"`
+
+	// hashKey is the value HashKey resolves to
+	hashKey string
+}
+
+// DefaultTelemetryInterval is the reporting interval used when interval is not set
+const DefaultTelemetryInterval = 24 * time.Hour
+
+// minTelemetryInterval bounds how often usage reports may be sent
+const minTelemetryInterval = time.Minute
+
+// GetInterval returns the configured reporting interval or DefaultTelemetryInterval if not specified.
+// The value is assumed to have been validated by LoadConfig.
+func (t *TelemetryConfig) GetInterval() time.Duration {
+	if t.Interval == "" {
+		return DefaultTelemetryInterval
+	}
+	interval, err := time.ParseDuration(t.Interval)
+	if err != nil {
+		return DefaultTelemetryInterval
+	}
+	return interval
+}
+
+// GetHashKey returns the search term hash key resolved from HashKey
+func (t *TelemetryConfig) GetHashKey() []byte {
+	return []byte(t.hashKey)
+}
+
+// validate performs validation on the telemetry configuration.
+// A disabled configuration is not validated further.
+func (t *TelemetryConfig) validate() error {
+	if !t.Enabled {
+		return nil
+	}
+
+	if t.Endpoint == "" {
+		return fmt.Errorf("telemetry.endpoint is required when telemetry is enabled")
+	}
+	endpointURL, err := url.Parse(t.Endpoint)
+	if err != nil {
+		return fmt.Errorf("telemetry.endpoint is invalid: %w", err)
+	}
+	if !endpointURL.IsAbs() || endpointURL.Host == "" {
+		return fmt.Errorf("telemetry.endpoint must be an absolute URL with host")
+	}
+	if endpointURL.Scheme != "http" && endpointURL.Scheme != "https" {
+		return fmt.Errorf("telemetry.endpoint must use http or https scheme")
+	}
+
+	if t.HashKey == "" {
+		return fmt.Errorf("telemetry.hashKey is required when telemetry is enabled")
+	}
+	if _, err := parseSecretReference(t.HashKey); err != nil {
+		return fmt.Errorf("telemetry.hashKey %w", err)
+	}
+
+	if t.Interval != "" {
+		interval, err := time.ParseDuration(t.Interval)
+		if err != nil {
+			return fmt.Errorf("telemetry.interval must be a valid duration (e.g., '1h', '24h'): %w", err)
+		}
+		if interval < minTelemetryInterval {
+			return fmt.Errorf("telemetry.interval must be at least %s", minTelemetryInterval)
+		}
+	}
+
+	return nil
+}
+
+// LinkChecksConfig defines the periodic checks of the repository URLs, website URLs and package
+// identifiers of servers. Servers with broken links, archived repositories or packages missing
+// from their package registry are flagged as stale. Link checks are disabled unless Enabled is true.
+type LinkChecksConfig struct {
+	// Enabled turns link checks on. Defaults to false.
+	Enabled bool `yaml:"enabled"`
+
+	// Interval is how often servers are checked (e.g., "24h")
+	// Defaults to 24h if not specified
+	Interval string `yaml:"interval,omitempty"`
+}
+
+// DefaultLinkChecksInterval is the link checks interval used when interval is not set
+const DefaultLinkChecksInterval = 24 * time.Hour
+
+// minLinkChecksInterval bounds how often servers may be checked, since every check
+// sends requests to the hosts of all servers
+const minLinkChecksInterval = time.Hour
+
+// GetInterval returns the configured link checks interval or DefaultLinkChecksInterval if not specified.
+// The value is assumed to have been validated by LoadConfig.
+func (l *LinkChecksConfig) GetInterval() time.Duration {
+	if l.Interval == "" {
+		return DefaultLinkChecksInterval
+	}
+	interval, err := time.ParseDuration(l.Interval)
+	if err != nil {
+		return DefaultLinkChecksInterval
+	}
+	return interval
+}
+
+// validate performs validation on the link checks configuration.
+// A disabled configuration is not validated further.
+func (l *LinkChecksConfig) validate() error {
+	if !l.Enabled || l.Interval == "" {
+		return nil
+	}
+	interval, err := time.ParseDuration(l.Interval)
+	if err != nil {
+		return fmt.Errorf("linkChecks.interval must be a valid duration (e.g., '12h', '24h'): %w", err)
+	}
+	if interval < minLinkChecksInterval {
+		return fmt.Errorf("linkChecks.interval must be at least %s", minLinkChecksInterval)
+	}
+	return nil
+}
+
+// SBOMConfig defines how the SBOMs and manifests of server container images are downloaded
+type SBOMConfig struct {
+	// TokenRealmHosts are the hosts of the token services trusted in addition to the registry host
+	// of each image and Docker Hub's, for container registries whose token service is on another
+	// host (e.g., "gitlab.com" for registry.gitlab.com)
+	TokenRealmHosts []string `yaml:"tokenRealmHosts,omitempty"`
+}
+
+// validate performs validation on the SBOM configuration
+func (s *SBOMConfig) validate() error {
+	for i, host := range s.TokenRealmHosts {
+		if host == "" || strings.ContainsAny(host, "/:?#@ ") {
+			return fmt.Errorf("sbom.tokenRealmHosts[%d] must be a host name: %q", i, host)
+		}
+	}
+	return nil
+}
+
+// SummariesConfig defines how long server descriptions are summarized. Descriptions longer than
+// MinLength are summarized into a few sentences, by an LLM endpoint when one is configured and by
+// extracting their leading sentences otherwise.
+type SummariesConfig struct {
+	// MinLength is the length, in characters, above which descriptions are summarized.
+	// Defaults to 300.
+	MinLength int `yaml:"minLength,omitempty"`
+
+	// LLM is an OpenAI-compatible chat completions endpoint writing the summaries.
+	// Without it, summaries are extracted from the descriptions.
+	LLM *SummariesLLMConfig `yaml:"llm,omitempty"`
+}
+
+// SummariesLLMConfig defines the LLM endpoint writing summaries
+type SummariesLLMConfig struct {
+	// URL is the chat completions endpoint (e.g., "https://api.openai.com/v1/chat/completions")
+	URL string `yaml:"url"`
+
+	// Model is the model asked for summaries (e.g., "gpt-4o-mini")
+	Model string `yaml:"model"`
+
+	// APIKey is a reference to the API key in a secret store
+	// (e.g., "env://SUMMARIES_API_KEY" or "vault://secret/registry#summaries"), resolved at startup.
+	// The key is sent as a bearer token.
+	APIKey string `yaml:"apiKey,omitempty"`
+
+	// Timeout bounds each request to the endpoint (e.g., "30s").
+	// Defaults to 30s if not specified
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// apiKey is the value APIKey resolves to
+	apiKey string
+}
+
+// DefaultSummariesMinLength is the description length above which descriptions are summarized
+// when minLength is not set
+const DefaultSummariesMinLength = 300
+
+// DefaultSummariesLLMTimeout is the LLM request timeout used when timeout is not set
+const DefaultSummariesLLMTimeout = 30 * time.Second
+
+// GetMinLength returns the configured minimum length or DefaultSummariesMinLength if not specified
+func (s *SummariesConfig) GetMinLength() int {
+	if s.MinLength == 0 {
+		return DefaultSummariesMinLength
+	}
+	return s.MinLength
+}
+
+// GetAPIKey returns the API key resolved from APIKey, or empty string if none is configured
+func (l *SummariesLLMConfig) GetAPIKey() string {
+	return l.apiKey
+}
+
+// GetTimeout returns the configured request timeout or DefaultSummariesLLMTimeout if not specified.
+// The value is assumed to have been validated by LoadConfig.
+func (l *SummariesLLMConfig) GetTimeout() time.Duration {
+	if l.Timeout == "" {
+		return DefaultSummariesLLMTimeout
+	}
+	timeout, err := time.ParseDuration(l.Timeout)
+	if err != nil {
+		return DefaultSummariesLLMTimeout
+	}
+	return timeout
+}
+
+// RequestLoggingConfig defines the logging of HTTP requests. Every request is logged; requests
+// slower than their threshold are also logged as warnings, with the time spent waiting for the
+// upstream services called to serve them.
+type RequestLoggingConfig struct {
+	// SlowThreshold is the duration above which requests are slow (e.g., "2s").
+	// Slow requests are not reported if not specified.
+	SlowThreshold string `yaml:"slowThreshold,omitempty"`
+
+	// Routes overrides SlowThreshold by route pattern
+	// (e.g., "/extension/v0/servers/{serverName}/versions/{version}/sbom": "10s").
+	// A "0s" threshold turns slow-request warnings off for the route.
+	Routes map[string]string `yaml:"routes,omitempty"`
+}
+
+// GetSlowThreshold returns the configured slow request threshold, or 0 if not specified.
+// The value is assumed to have been validated by LoadConfig.
+func (r *RequestLoggingConfig) GetSlowThreshold() time.Duration {
+	threshold, err := time.ParseDuration(r.SlowThreshold)
+	if err != nil {
+		return 0
+	}
+	return threshold
+}
+
+// GetRouteThresholds returns the slow request thresholds by route pattern.
+// The values are assumed to have been validated by LoadConfig.
+func (r *RequestLoggingConfig) GetRouteThresholds() map[string]time.Duration {
+	thresholds := make(map[string]time.Duration, len(r.Routes))
+	for route, value := range r.Routes {
+		threshold, err := time.ParseDuration(value)
+		if err != nil {
+			continue
+		}
+		thresholds[route] = threshold
+	}
+	return thresholds
+}
+
+// validate performs validation on the request logging configuration
+func (r *RequestLoggingConfig) validate() error {
+	if r.SlowThreshold != "" {
+		threshold, err := time.ParseDuration(r.SlowThreshold)
+		if err != nil {
+			return fmt.Errorf("requestLogging.slowThreshold must be a valid duration (e.g., '2s', '500ms'): %w", err)
+		}
+		if threshold < 0 {
+			return fmt.Errorf("requestLogging.slowThreshold must not be negative")
+		}
+	}
+	for route, value := range r.Routes {
+		if !strings.HasPrefix(route, "/") {
+			return fmt.Errorf("requestLogging.routes[%s] must be a route pattern starting with /", route)
+		}
+		threshold, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("requestLogging.routes[%s] must be a valid duration (e.g., '2s', '500ms'): %w", route, err)
+		}
+		if threshold < 0 {
+			return fmt.Errorf("requestLogging.routes[%s] must not be negative", route)
+		}
+	}
+	return nil
+}
+
+// DefaultSnapshotTTL is the time the in-memory snapshot of the registry data is served before
+// being refreshed, when ttl is not set
+const DefaultSnapshotTTL = 30 * time.Second
+
+// SnapshotConfig defines the in-memory snapshot of the synced registry data that the API serves
+// when file storage is used. The snapshot is reloaded from storage once it expires, unless the
+// stored data did not change.
+type SnapshotConfig struct {
+	// TTL is the time the snapshot is served before being refreshed (e.g., "30s").
+	// Defaults to 30s if not specified.
+	TTL string `yaml:"ttl,omitempty"`
+
+	// RefreshInterval refreshes the snapshot in the background at this interval (e.g., "1m"),
+	// so that it is kept fresh even without requests. Disabled if not specified.
+	RefreshInterval string `yaml:"refreshInterval,omitempty"`
+
+	// DisableStaleWhileRevalidate makes requests wait for the refresh of an expired snapshot.
+	// By default, they are served the expired snapshot while it is refreshed in the background.
+	DisableStaleWhileRevalidate bool `yaml:"disableStaleWhileRevalidate,omitempty"`
+}
+
+// GetTTL returns the configured snapshot TTL or DefaultSnapshotTTL if not specified.
+// It is safe to call on a nil SnapshotConfig. The value is assumed to have been validated by LoadConfig.
+func (s *SnapshotConfig) GetTTL() time.Duration {
+	if s == nil || s.TTL == "" {
+		return DefaultSnapshotTTL
+	}
+	ttl, err := time.ParseDuration(s.TTL)
+	if err != nil {
+		return DefaultSnapshotTTL
+	}
+	return ttl
+}
+
+// GetRefreshInterval returns the configured background refresh interval, or 0 if not specified.
+// It is safe to call on a nil SnapshotConfig. The value is assumed to have been validated by LoadConfig.
+func (s *SnapshotConfig) GetRefreshInterval() time.Duration {
+	if s == nil || s.RefreshInterval == "" {
+		return 0
+	}
+	interval, err := time.ParseDuration(s.RefreshInterval)
+	if err != nil {
+		return 0
+	}
+	return interval
+}
+
+// StaleWhileRevalidate reports whether requests are served an expired snapshot while it is refreshed.
+// It is safe to call on a nil SnapshotConfig.
+func (s *SnapshotConfig) StaleWhileRevalidate() bool {
+	return s == nil || !s.DisableStaleWhileRevalidate
+}
+
+// validate performs validation on the snapshot configuration
+func (s *SnapshotConfig) validate() error {
+	fields := []struct{ name, value string }{{"ttl", s.TTL}, {"refreshInterval", s.RefreshInterval}}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(field.value)
+		if err != nil {
+			return fmt.Errorf("snapshot.%s must be a valid duration (e.g., '30s', '1m'): %w", field.name, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("snapshot.%s must be positive", field.name)
+		}
+	}
+	return nil
+}
+
+// AnnotationsConfig defines where the annotations operators record about servers for the users of
+// this deployment only (internal notes, approval status and owner team) are stored
+type AnnotationsConfig struct {
+	// File is the path of the JSON file the annotations are persisted to. It is created on the first
+	// annotation if it does not exist.
+	File string `yaml:"file"`
+}
+
+// validate performs validation on the annotations configuration
+func (a *AnnotationsConfig) validate() error {
+	if a.File == "" {
+		return fmt.Errorf("annotations.file is required")
+	}
+	return nil
+}
+
+// ReviewsConfig defines where the ratings and reviews of servers ingested from internal surveys or
+// companion UIs are stored
+type ReviewsConfig struct {
+	// File is the path of the JSON file the reviews are persisted to. It is created on the first
+	// ingestion if it does not exist.
+	File string `yaml:"file"`
+}
+
+// validate performs validation on the reviews configuration
+func (r *ReviewsConfig) validate() error {
+	if r.File == "" {
+		return fmt.Errorf("reviews.file is required")
+	}
+	return nil
+}
+
+// ListenersConfig defines the listeners of the server. By default, a single plain HTTP listener,
+// at the address given to the serve command, serves every endpoint.
+type ListenersConfig struct {
+	// API configures the listener serving the registry and extension APIs
+	API *APIListenerConfig `yaml:"api,omitempty"`
+
+	// Operations serves the operational endpoints (/health, /readiness and /version) on a separate
+	// address, e.g. one only reachable by probes. They are then no longer served by the API listener.
+	Operations *OperationsListenerConfig `yaml:"operations,omitempty"`
+}
+
+// APIListenerConfig defines the listener serving the registry and extension APIs.
+// Its address is given to the serve command.
+type APIListenerConfig struct {
+	// TLS serves the APIs over HTTPS
+	TLS *ListenerTLSConfig `yaml:"tls,omitempty"`
+}
+
+// OperationsListenerConfig defines the listener serving the operational endpoints.
+// These endpoints never require authentication.
+type OperationsListenerConfig struct {
+	// Address is the address to listen on (e.g., ":8081")
+	Address string `yaml:"address"`
+
+	// TLS serves the operational endpoints over HTTPS
+	TLS *ListenerTLSConfig `yaml:"tls,omitempty"`
+}
+
+// ListenerTLSConfig configures the TLS connections accepted by a listener
+type ListenerTLSConfig struct {
+	// CertFile and KeyFile are the paths to the PEM certificate and key of the listener
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+
+	// ClientCACertFile is the path to a PEM bundle of certificate authorities. When set, clients
+	// must present a certificate issued by one of them (mutual TLS).
+	ClientCACertFile string `yaml:"clientCACertFile,omitempty"`
+}
+
+// validate performs validation on the listeners configuration
+func (l *ListenersConfig) validate() error {
+	if l.API != nil && l.API.TLS != nil {
+		if err := l.API.TLS.validate("listeners.api.tls"); err != nil {
+			return err
+		}
+	}
+	if l.Operations == nil {
+		return nil
+	}
+	if l.Operations.Address == "" {
+		return fmt.Errorf("listeners.operations.address is required")
+	}
+	if _, _, err := net.SplitHostPort(l.Operations.Address); err != nil {
+		return fmt.Errorf("listeners.operations.address is invalid: %w", err)
+	}
+	if l.Operations.TLS != nil {
+		if err := l.Operations.TLS.validate("listeners.operations.tls"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate performs validation on the TLS configuration of a listener
+func (t *ListenerTLSConfig) validate(prefix string) error {
+	if t.CertFile == "" || t.KeyFile == "" {
+		return fmt.Errorf("%s.certFile and %s.keyFile are required", prefix, prefix)
+	}
+	return nil
+}
+
+// validate performs validation on the summaries configuration
+func (s *SummariesConfig) validate() error {
+	if s.MinLength < 0 {
+		return fmt.Errorf("summaries.minLength must not be negative")
+	}
+	if s.LLM == nil {
+		return nil
+	}
+
+	if s.LLM.URL == "" {
+		return fmt.Errorf("summaries.llm.url is required")
+	}
+	llmURL, err := url.Parse(s.LLM.URL)
+	if err != nil {
+		return fmt.Errorf("summaries.llm.url is invalid: %w", err)
+	}
+	if !llmURL.IsAbs() || llmURL.Host == "" {
+		return fmt.Errorf("summaries.llm.url must be an absolute URL with host")
+	}
+	if llmURL.Scheme != "http" && llmURL.Scheme != "https" {
+		return fmt.Errorf("summaries.llm.url must use http or https scheme")
+	}
+
+	if s.LLM.Model == "" {
+		return fmt.Errorf("summaries.llm.model is required")
+	}
+
+	if s.LLM.APIKey != "" {
+		if _, err := parseSecretReference(s.LLM.APIKey); err != nil {
+			return fmt.Errorf("summaries.llm.apiKey %w", err)
+		}
+	}
+
+	if s.LLM.Timeout != "" {
+		timeout, err := time.ParseDuration(s.LLM.Timeout)
+		if err != nil {
+			return fmt.Errorf("summaries.llm.timeout must be a valid duration (e.g., '10s', '1m'): %w", err)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("summaries.llm.timeout must be positive")
+		}
+	}
+
+	return nil
+}
+
+// SearchConfig defines how search results are ranked
+type SearchConfig struct {
+	// Boosts adjust the ranking of servers matching a search query.
+	// Boosts never filter servers out; they only reorder results.
+	Boosts []SearchBoostConfig `yaml:"boosts,omitempty"`
+
+	// Experiment assigns clients to alternative rankings and records how they act on search results
+	Experiment *SearchExperimentConfig `yaml:"experiment,omitempty"`
+
+	// Feedback lets clients report whether they accepted or rejected a search result, and ranks
+	// servers up or down accordingly
+	Feedback *SearchFeedbackConfig `yaml:"feedback,omitempty"`
 }
 
-// WithConfigPath loads configuration from a YAML file
-func WithConfigPath(path string) Option {
-	return func(cfg *loaderConfig) error {
-		if path == "" {
-			return fmt.Errorf("path is required")
-		}
+// SearchFeedbackConfig defines how client feedback on search results adjusts the ranking of servers
+type SearchFeedbackConfig struct {
+	// Weight is the score added to a server per acceptance, and removed per rejection.
+	// Defaults to 1.
+	Weight int `yaml:"weight,omitempty"`
 
-		// Resolve symlinks to prevent symlink attacks.
-		// Note that this calls filepath.Clean internally.
-		realPath, err := filepath.EvalSymlinks(path)
-		if err != nil {
-			return fmt.Errorf("failed to evaluate symlinks: %w", err)
-		}
+	// MaxAdjustment caps the score feedback adds to or removes from a server, so that feedback
+	// cannot outweigh the configured boosts. Defaults to 10.
+	MaxAdjustment int `yaml:"maxAdjustment,omitempty"`
+}
 
-		// Validate the path to prevent path traversal attacks
-		if !filepath.IsAbs(realPath) {
-			if !filepath.IsLocal(realPath) {
-				return fmt.Errorf("path is not local or contains invalid traversal: %s", path)
-			}
-		}
+// DefaultSearchFeedbackWeight is the score of a feedback signal used when weight is not set
+const DefaultSearchFeedbackWeight = 1
 
-		cfg.path = realPath
-		return nil
+// DefaultSearchFeedbackMaxAdjustment is the feedback score cap used when maxAdjustment is not set
+const DefaultSearchFeedbackMaxAdjustment = 10
+
+// GetWeight returns the configured weight or DefaultSearchFeedbackWeight if not specified
+func (f *SearchFeedbackConfig) GetWeight() int {
+	if f.Weight == 0 {
+		return DefaultSearchFeedbackWeight
 	}
+	return f.Weight
 }
 
-// Config represents the root configuration structure
-type Config struct {
-	// RegistryName is the name/identifier for this registry instance
-	// Defaults to "default" if not specified
-	RegistryName string             `yaml:"registryName,omitempty"`
-	Registries   []RegistryConfig   `yaml:"registries"`
-	Database     *DatabaseConfig    `yaml:"database,omitempty"`
-	FileStorage  *FileStorageConfig `yaml:"fileStorage,omitempty"`
-	Auth         *AuthConfig        `yaml:"auth,omitempty"`
+// GetMaxAdjustment returns the configured score cap or DefaultSearchFeedbackMaxAdjustment if not specified
+func (f *SearchFeedbackConfig) GetMaxAdjustment() int {
+	if f.MaxAdjustment == 0 {
+		return DefaultSearchFeedbackMaxAdjustment
+	}
+	return f.MaxAdjustment
 }
 
-// RegistryConfig defines a single registry data source configuration
-type RegistryConfig struct {
-	// Name is the identifier for this registry
+// SearchExperimentConfig defines a search ranking experiment. Each client is assigned to one of the
+// variants, in proportion to their weights, and its searches are ranked with the boosts of the variant.
+type SearchExperimentConfig struct {
+	// Name identifies the experiment; renaming it reassigns all clients
 	Name string `yaml:"name"`
 
-	// Format specifies the data format (toolhive or upstream)
-	Format string `yaml:"format"`
+	// Variants are the rankings compared by the experiment; at least two are required
+	Variants []SearchVariantConfig `yaml:"variants"`
+}
 
-	// Type-specific configurations (only one should be set)
-	Git        *GitConfig        `yaml:"git,omitempty"`
-	API        *APIConfig        `yaml:"api,omitempty"`
-	File       *FileConfig       `yaml:"file,omitempty"`
-	Managed    *ManagedConfig    `yaml:"managed,omitempty"`
-	Kubernetes *KubernetesConfig `yaml:"kubernetes,omitempty"`
+// SearchVariantConfig defines a variant of a search ranking experiment
+type SearchVariantConfig struct {
+	// Name identifies the variant in reports
+	Name string `yaml:"name"`
 
-	// Per-registry sync policy
-	// Note: Not applicable for non-synced registries (managed and kubernetes) - will be ignored if set
-	SyncPolicy *SyncPolicyConfig `yaml:"syncPolicy,omitempty"`
+	// Weight is the share of clients assigned to the variant, relative to the other variants
+	Weight int `yaml:"weight"`
 
-	// Per-registry filtering rules
-	// Note: Not applicable for non-synced registries (managed and kubernetes) - will be ignored if set
-	Filter *FilterConfig `yaml:"filter,omitempty"`
+	// Boosts replace search.boosts for the clients assigned to the variant.
+	// Variants without boosts rank with search.boosts, e.g. as the control group.
+	Boosts []SearchBoostConfig `yaml:"boosts,omitempty"`
 }
 
-// GitConfig defines Git source settings
-type GitConfig struct {
-	// Repository is the Git repository URL (HTTP/HTTPS/SSH)
-	Repository string `yaml:"repository"`
+// SearchBoostConfig defines a single ranking boost.
+// Exactly one of Tier, Status, Tag, or NamePrefix must be set.
+type SearchBoostConfig struct {
+	// Tier matches servers with the given ToolHive tier (e.g., "Official")
+	Tier string `yaml:"tier,omitempty"`
 
-	// Branch is the Git branch to use (mutually exclusive with Tag and Commit)
-	Branch string `yaml:"branch,omitempty"`
+	// Status matches servers with the given ToolHive status (e.g., "Deprecated")
+	Status string `yaml:"status,omitempty"`
 
-	// Tag is the Git tag to use (mutually exclusive with Branch and Commit)
+	// Tag matches servers carrying the given tag
 	Tag string `yaml:"tag,omitempty"`
 
-	// Commit is the Git commit SHA to use (mutually exclusive with Branch and Tag)
-	Commit string `yaml:"commit,omitempty"`
+	// NamePrefix matches servers whose name starts with the given prefix (e.g., "io.github.stacklok/")
+	NamePrefix string `yaml:"namePrefix,omitempty"`
 
-	// Path is the path to the registry file within the repository
-	Path string `yaml:"path,omitempty"`
+	// Weight is added to the score of matching servers; negative weights demote them
+	Weight int `yaml:"weight"`
 }
 
-// APIConfig defines API source configuration for upstream MCP Registry APIs
-type APIConfig struct {
-	// Endpoint is the base API URL (without path)
-	// The registry handler will append the appropriate paths for the MCP Registry API v0.1:
-	//   - /v0.1/servers - List all servers
-	//   - /v0.1/servers/{name}/versions - List server versions
-	//   - /v0.1/servers/{name}/versions/{version} - Get specific version
-	// Example: "http://my-registry-api.default.svc.cluster.local/registry"
-	Endpoint string `yaml:"endpoint"`
+// validate performs validation on the search configuration
+func (s *SearchConfig) validate() error {
+	if err := validateBoosts("search.boosts", s.Boosts); err != nil {
+		return err
+	}
+	if s.Experiment != nil {
+		if err := s.Experiment.validate(); err != nil {
+			return err
+		}
+	}
+	if s.Feedback != nil {
+		if s.Feedback.Weight < 0 {
+			return fmt.Errorf("search.feedback.weight must not be negative")
+		}
+		if s.Feedback.MaxAdjustment < 0 {
+			return fmt.Errorf("search.feedback.maxAdjustment must not be negative")
+		}
+	}
+	return nil
 }
 
-// FileConfig defines file source configuration
-// Supports both local files and URL-hosted files
-type FileConfig struct {
-	// Path is the path to the registry.json file on the local filesystem
-	// Can be absolute or relative to the working directory
-	// Mutually exclusive with URL - exactly one must be specified
-	Path string `yaml:"path,omitempty"`
-
-	// URL is the HTTP/HTTPS URL to fetch the registry file from
-	// Mutually exclusive with Path - exactly one must be specified
-	// HTTPS is required unless the host is localhost or THV_REGISTRY_INSECURE_URL=true
-	URL string `yaml:"url,omitempty"`
-
-	// Timeout is the timeout for HTTP requests when using URL
-	// Defaults to 30s if not specified
-	// Only applicable when URL is set
-	Timeout string `yaml:"timeout,omitempty"`
+// validate performs validation on the search experiment configuration
+func (e *SearchExperimentConfig) validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("search.experiment.name is required")
+	}
+	if len(e.Variants) < 2 {
+		return fmt.Errorf("search.experiment.variants must list at least two variants")
+	}
+	names := make(map[string]bool, len(e.Variants))
+	for i, variant := range e.Variants {
+		if variant.Name == "" {
+			return fmt.Errorf("search.experiment.variants[%d]: name is required", i)
+		}
+		if names[variant.Name] {
+			return fmt.Errorf("search.experiment.variants[%d]: duplicate variant name %q", i, variant.Name)
+		}
+		names[variant.Name] = true
+		if variant.Weight <= 0 {
+			return fmt.Errorf("search.experiment.variants[%d]: weight must be positive", i)
+		}
+		if err := validateBoosts(fmt.Sprintf("search.experiment.variants[%d].boosts", i), variant.Boosts); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// ManagedConfig defines configuration for managed registries
-// Managed registries are directly manipulated via API and do not sync from external sources
-// Note: Initially empty, may be used as a placeholder for future configuration options
-type ManagedConfig struct {
-	// Future fields can be added here as needed
+// validateBoosts performs validation on ranking boosts, reporting errors under the given field path
+func validateBoosts(field string, boosts []SearchBoostConfig) error {
+	for i, boost := range boosts {
+		matchers := 0
+		for _, value := range []string{boost.Tier, boost.Status, boost.Tag, boost.NamePrefix} {
+			if value != "" {
+				matchers++
+			}
+		}
+		if matchers != 1 {
+			return fmt.Errorf("%s[%d]: exactly one of tier, status, tag, or namePrefix must be specified", field, i)
+		}
+		if boost.Weight == 0 {
+			return fmt.Errorf("%s[%d]: weight must be non-zero", field, i)
+		}
+	}
+	return nil
 }
 
-// KubernetesConfig defines configuration for Kubernetes-based registries
-// Kubernetes registries discover MCP servers from running Kubernetes resources
-// No configuration is actually needed here.
-type KubernetesConfig struct{}
-
 // SyncPolicyConfig defines synchronization settings
 type SyncPolicyConfig struct {
 	Interval string `yaml:"interval"`
@@ -198,6 +1486,39 @@ type FilterConfig struct {
 	Tags  *TagFilterConfig  `yaml:"tags,omitempty"`
 }
 
+// SanitizeConfig defines how server titles and descriptions are sanitized when ingested,
+// so that content passed verbatim into agent context cannot carry injected instructions
+type SanitizeConfig struct {
+	// Rules lists the sanitization rules to apply: "instructions", "scripts", and "urls"
+	// All rules are applied if not specified
+	Rules []string `yaml:"rules,omitempty"`
+}
+
+// sanitizeRules are the supported sanitization rules
+var sanitizeRules = []string{"instructions", "scripts", "urls"}
+
+// GetRules returns the configured sanitization rules, or all rules if not specified
+func (s *SanitizeConfig) GetRules() []string {
+	if len(s.Rules) == 0 {
+		return slices.Clone(sanitizeRules)
+	}
+	return s.Rules
+}
+
+// validateSanitize validates the sanitization configuration of a registry
+func validateSanitize(sanitize *SanitizeConfig, prefix string) error {
+	if sanitize == nil {
+		return nil
+	}
+	for _, rule := range sanitize.Rules {
+		if !slices.Contains(sanitizeRules, rule) {
+			return fmt.Errorf("%s: sanitize.rules: unknown rule '%s' (supported: %s)",
+				prefix, rule, strings.Join(sanitizeRules, ", "))
+		}
+	}
+	return nil
+}
+
 // NameFilterConfig defines name-based filtering
 type NameFilterConfig struct {
 	Include []string `yaml:"include,omitempty"`
@@ -240,6 +1561,46 @@ type AuthConfig struct {
 	// OAuth contains OAuth/OIDC specific configuration
 	// Required when Mode is "oauth"
 	OAuth *OAuthConfig `yaml:"oauth,omitempty"`
+
+	// Admin identifies the administrators of the API, allowed to change server annotations, ingest
+	// reviews and install servers in the cluster. Without it, these operations are denied to every caller.
+	Admin *AdminConfig `yaml:"admin,omitempty"`
+}
+
+// AdminConfig identifies the administrators of the API from the claims of their token
+type AdminConfig struct {
+	// Claim is the token claim identifying administrators, as a list or a single string.
+	// Defaults to "groups" if not specified.
+	Claim string `yaml:"claim,omitempty"`
+
+	// Values are the values of the claim granted administrative operations, such as group names
+	Values []string `yaml:"values"`
+}
+
+// DefaultAdminClaim is the default token claim identifying administrators
+const DefaultAdminClaim = "groups"
+
+// GetClaim returns the token claim identifying administrators
+func (a *AdminConfig) GetClaim() string {
+	if a == nil || a.Claim == "" {
+		return DefaultAdminClaim
+	}
+	return a.Claim
+}
+
+// validate performs validation on the admin configuration. Administrators are identified by
+// their token claims, so it can't be combined with anonymous authentication.
+func (a *AdminConfig) validate(mode AuthMode) error {
+	if len(a.Values) == 0 {
+		return fmt.Errorf("auth.admin.values is required")
+	}
+	if slices.Contains(a.Values, "") {
+		return fmt.Errorf("auth.admin.values entries cannot be empty")
+	}
+	if mode == AuthModeAnonymous {
+		return fmt.Errorf("auth.admin requires authenticated callers, auth.mode cannot be %s", AuthModeAnonymous)
+	}
+	return nil
 }
 
 // OAuthConfig defines OAuth/OIDC specific authentication settings
@@ -293,16 +1654,27 @@ type OAuthProviderConfig struct {
 	// The file should contain only the secret with optional trailing whitespace
 	ClientSecretFile string `yaml:"clientSecretFile,omitempty"`
 
+	// ClientSecret is a reference to the client secret in a secret store
+	// (e.g., "env://OAUTH_CLIENT_SECRET" or "vault://secret/registry#oauth"), resolved at startup
+	ClientSecret string `yaml:"clientSecret,omitempty"`
+
+	// clientSecret is the value ClientSecret resolves to
+	clientSecret string
+
 	// CACertPath is the path to a CA certificate bundle for verifying the provider's TLS certificate
 	// Required for Kubernetes in-cluster authentication or self-signed certificates
 	// TODO: Add GetCACert() method with path validation when implementing auth middleware
 	CACertPath string `yaml:"caCertPath,omitempty"`
 }
 
-// GetClientSecret returns the client secret by reading from the file specified in ClientSecretFile.
-// Returns empty string if ClientSecretFile is not configured.
+// GetClientSecret returns the client secret resolved from ClientSecret, or read from the file
+// specified in ClientSecretFile.
+// Returns empty string if neither is configured.
 // Returns an error if the file cannot be read.
 func (p *OAuthProviderConfig) GetClientSecret() (string, error) {
+	if p.clientSecret != "" {
+		return p.clientSecret, nil
+	}
 	secret, err := readSecretFromFile(p.ClientSecretFile)
 	if err != nil {
 		return "", fmt.Errorf("failed to read client secret: %w", err)
@@ -343,6 +1715,15 @@ func (p *OAuthProviderConfig) validateProvider(index int) error {
 		return fmt.Errorf("auth.oauth.providers[%d].audience is required", index)
 	}
 
+	if p.ClientSecret != "" {
+		if p.ClientSecretFile != "" {
+			return fmt.Errorf("auth.oauth.providers[%d].clientSecret and clientSecretFile are mutually exclusive", index)
+		}
+		if _, err := parseSecretReference(p.ClientSecret); err != nil {
+			return fmt.Errorf("auth.oauth.providers[%d].clientSecret %w", index, err)
+		}
+	}
+
 	return nil
 }
 
@@ -350,6 +1731,12 @@ func (p *OAuthProviderConfig) validateProvider(index int) error {
 // This method assumes Mode has already been resolved to a valid value
 // (either explicitly set or defaulted by resolveAuthMode in serve.go).
 func (a *AuthConfig) Validate() error {
+	if a.Admin != nil {
+		if err := a.Admin.validate(a.Mode); err != nil {
+			return err
+		}
+	}
+
 	switch a.Mode {
 	case AuthModeAnonymous:
 		// Anonymous mode doesn't require OAuth config
@@ -513,11 +1900,29 @@ func LoadConfig(opts ...Option) (*Config, error) {
 		return nil, fmt.Errorf("failed to parse YAML config: %w", err)
 	}
 
+	// Resolve built-in registry presets before validation
+	if err := config.applyPresets(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	// Validate the config
 	if err := config.validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Resolve secret references once the configuration is known to be valid
+	providers := loaderCfg.secretProviders
+	if providers == nil {
+		providers = defaultSecretProviders()
+	}
+	if err := config.resolveSecrets(context.Background(), providers); err != nil {
+		return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
+	for i := range config.Registries {
+		config.Registries[i].attribution = config.Attribution
+	}
+
 	return &config, nil
 }
 
@@ -584,6 +1989,139 @@ func (c *Config) validate() error {
 		return err
 	}
 
+	// Validate mirror configuration if present
+	if c.Mirror != nil {
+		if err := c.Mirror.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate search configuration if present
+	if c.Search != nil {
+		if err := c.Search.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate link checks configuration if present
+	if c.LinkChecks != nil {
+		if err := c.LinkChecks.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate SBOM configuration if present
+	if c.SBOM != nil {
+		if err := c.SBOM.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate summaries configuration if present
+	if c.Summaries != nil {
+		if err := c.Summaries.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate listeners configuration if present
+	if c.Listeners != nil {
+		if err := c.Listeners.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate request logging configuration if present
+	if c.RequestLogging != nil {
+		if err := c.RequestLogging.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate snapshot configuration if present
+	if c.Snapshot != nil {
+		if err := c.Snapshot.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate annotations configuration if present
+	if c.Annotations != nil {
+		if err := c.Annotations.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate reviews configuration if present
+	if c.Reviews != nil {
+		if err := c.Reviews.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate telemetry configuration if present
+	if c.Telemetry != nil {
+		if err := c.Telemetry.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate concurrency configuration if present
+	if c.Concurrency != nil {
+		if err := c.Concurrency.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate signing configuration if present
+	if c.Signing != nil {
+		if err := c.Signing.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate approval configuration if present
+	if c.Approval != nil {
+		if err := c.Approval.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate ticketing configuration if present
+	if c.Ticketing != nil {
+		if err := c.Ticketing.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate cache configuration if present
+	if c.Cache != nil {
+		if err := c.Cache.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate response metadata configuration if present
+	if c.ResponseMeta != nil {
+		if err := c.ResponseMeta.validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate cluster configuration if present
+	if c.Cluster != nil {
+		if err := c.Cluster.validate(c.Auth); err != nil {
+			return err
+		}
+	}
+
+	// Validate attribution configuration if present
+	if c.Attribution != nil {
+		if err := c.Attribution.validate(); err != nil {
+			return err
+		}
+	}
+
 	// Validate auth configuration if present
 	return c.validateAuth()
 }
@@ -597,6 +2135,16 @@ func (*Config) validateRegistryConfig(reg *RegistryConfig, index int) error {
 		return err
 	}
 
+	if reg.Kubernetes != nil {
+		if err := validateKubernetesConfig(reg.Kubernetes, prefix); err != nil {
+			return err
+		}
+	}
+
+	if err := validateSanitize(reg.Sanitize, prefix); err != nil {
+		return err
+	}
+
 	// Non-synced registries (managed and kubernetes) don't require sync policy or filter
 	// If syncPolicy or filter are set for these registries, they will be silently ignored
 	if reg.IsNonSyncedRegistry() {
@@ -608,6 +2156,15 @@ func (*Config) validateRegistryConfig(reg *RegistryConfig, index int) error {
 		return err
 	}
 
+	if reg.Transport != nil {
+		if reg.API == nil && (reg.File == nil || reg.File.URL == "") {
+			return fmt.Errorf("%s: transport is only supported by api sources and file sources with a url", prefix)
+		}
+		if err := reg.Transport.validate(prefix); err != nil {
+			return err
+		}
+	}
+
 	// Validate type-specific settings
 	return validateSourceSpecificConfig(reg, prefix)
 }
@@ -672,6 +2229,14 @@ func validateSourceSpecificConfig(reg *RegistryConfig, prefix string) error {
 	return nil
 }
 
+// validateKubernetesConfig validates Kubernetes-specific configuration
+func validateKubernetesConfig(k8s *KubernetesConfig, prefix string) error {
+	if slices.Contains(k8s.Namespaces, "") {
+		return fmt.Errorf("%s: kubernetes.namespaces entries cannot be empty", prefix)
+	}
+	return nil
+}
+
 // validateGitConfig validates Git-specific configuration
 func validateGitConfig(git *GitConfig, prefix string) error {
 	if git.Repository == "" {
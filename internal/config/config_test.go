@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -710,6 +711,22 @@ func TestConfigValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "kubernetes_registry_with_empty_namespace",
+			config: &Config{
+				Registries: []RegistryConfig{
+					{
+						Name:       "kubernetes-registry",
+						Kubernetes: &KubernetesConfig{Namespaces: []string{"team-a", ""}},
+					},
+				},
+				Auth: &AuthConfig{
+					Mode: AuthModeAnonymous,
+				},
+			},
+			wantErr: true,
+			errMsg:  "kubernetes.namespaces entries cannot be empty",
+		},
 		{
 			name: "valid_kubernetes_registry_with_ignored_sync_policy",
 			config: &Config{
@@ -1838,6 +1855,46 @@ func TestAuthConfigValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid auth.mode",
 		},
+		{
+			name: "admin with oauth mode is valid",
+			config: &AuthConfig{
+				Mode: AuthModeOAuth,
+				OAuth: &OAuthConfig{
+					Providers: []OAuthProviderConfig{
+						{Name: "idp", IssuerURL: "https://example.com", Audience: "api://test"},
+					},
+				},
+				Admin: &AdminConfig{Values: []string{"registry-admins"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "admin requires values",
+			config: &AuthConfig{
+				Mode:  AuthModeOAuth,
+				Admin: &AdminConfig{Claim: "roles"},
+			},
+			wantErr: true,
+			errMsg:  "auth.admin.values is required",
+		},
+		{
+			name: "admin values cannot be empty",
+			config: &AuthConfig{
+				Mode:  AuthModeOAuth,
+				Admin: &AdminConfig{Values: []string{""}},
+			},
+			wantErr: true,
+			errMsg:  "auth.admin.values entries cannot be empty",
+		},
+		{
+			name: "admin requires authenticated callers",
+			config: &AuthConfig{
+				Mode:  AuthModeAnonymous,
+				Admin: &AdminConfig{Values: []string{"registry-admins"}},
+			},
+			wantErr: true,
+			errMsg:  "auth.admin requires authenticated callers",
+		},
 	}
 
 	for _, tt := range tests {
@@ -2078,3 +2135,1267 @@ func TestDatabaseConfigPgpassDelegation(t *testing.T) {
 	migrationConnStr := dbConfig.GetMigrationConnectionString()
 	assert.Equal(t, "postgres://migratoruser@localhost:5432/testdb?sslmode=require", migrationConnStr)
 }
+
+func TestLoadConfigWithPresets(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		yamlContent string
+		wantConfig  []RegistryConfig
+		wantErr     string
+	}{
+		{
+			name: "official_preset_with_defaults",
+			yamlContent: `registries:
+  - preset: official
+auth:
+  mode: anonymous
+`,
+			wantConfig: []RegistryConfig{
+				{
+					Name:       "official",
+					Format:     SourceFormatUpstream,
+					Preset:     PresetOfficial,
+					API:        &APIConfig{Endpoint: "https://registry.modelcontextprotocol.io"},
+					SyncPolicy: &SyncPolicyConfig{Interval: "1h"},
+				},
+			},
+		},
+		{
+			name: "toolhive_preset_with_overrides",
+			yamlContent: `registries:
+  - name: thv
+    preset: toolhive
+    syncPolicy:
+      interval: "30m"
+    filter:
+      tags:
+        include: ["database"]
+auth:
+  mode: anonymous
+`,
+			wantConfig: []RegistryConfig{
+				{
+					Name:   "thv",
+					Format: SourceFormatToolHive,
+					Preset: PresetToolHive,
+					Git: &GitConfig{
+						Repository: "https://github.com/stacklok/toolhive.git",
+						Branch:     "main",
+						Path:       "pkg/registry/data/registry.json",
+					},
+					SyncPolicy: &SyncPolicyConfig{Interval: "30m"},
+					Filter: &FilterConfig{
+						Tags: &TagFilterConfig{Include: []string{"database"}},
+					},
+				},
+			},
+		},
+		{
+			name: "unknown_preset",
+			yamlContent: `registries:
+  - preset: unknown
+auth:
+  mode: anonymous
+`,
+			wantErr: "unknown preset 'unknown' (must be one of: official, toolhive)",
+		},
+		{
+			name: "preset_combined_with_source",
+			yamlContent: `registries:
+  - preset: official
+    file:
+      path: /data/registry.json
+auth:
+  mode: anonymous
+`,
+			wantErr: "preset 'official' cannot be combined with format or source configuration",
+		},
+		{
+			name: "duplicate_preset_names",
+			yamlContent: `registries:
+  - preset: official
+  - preset: official
+auth:
+  mode: anonymous
+`,
+			wantErr: "duplicate registry name 'official'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(configPath, []byte(tt.yamlContent), 0600))
+
+			config, err := LoadConfig(WithConfigPath(configPath))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantConfig, config.Registries)
+		})
+	}
+}
+
+func TestMirrorConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		mirror  MirrorConfig
+		wantTTL time.Duration
+		wantErr string
+	}{
+		{
+			name:    "default_ttl",
+			mirror:  MirrorConfig{Upstream: "https://registry.modelcontextprotocol.io"},
+			wantTTL: DefaultMirrorCacheTTL,
+		},
+		{
+			name:    "custom_ttl",
+			mirror:  MirrorConfig{Upstream: "http://registry.internal/registry", CacheTTL: "30s"},
+			wantTTL: 30 * time.Second,
+		},
+		{
+			name:    "missing_upstream",
+			mirror:  MirrorConfig{},
+			wantErr: "mirror.upstream is required",
+		},
+		{
+			name:    "relative_upstream",
+			mirror:  MirrorConfig{Upstream: "/registry"},
+			wantErr: "mirror.upstream must be an absolute URL with host",
+		},
+		{
+			name:    "invalid_ttl",
+			mirror:  MirrorConfig{Upstream: "https://registry.example.com", CacheTTL: "soon"},
+			wantErr: "mirror.cacheTTL must be a valid duration",
+		},
+		{
+			name:    "negative_ttl",
+			mirror:  MirrorConfig{Upstream: "https://registry.example.com", CacheTTL: "-1m"},
+			wantErr: "mirror.cacheTTL must be positive",
+		},
+		{
+			name: "unknown_sanitize_rule",
+			mirror: MirrorConfig{
+				Upstream: "https://registry.example.com",
+				Sanitize: &SanitizeConfig{Rules: []string{"markdown"}},
+			},
+			wantErr: "mirror: sanitize.rules: unknown rule 'markdown'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.mirror.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTTL, tt.mirror.GetCacheTTL())
+		})
+	}
+}
+
+func TestSanitizeConfig(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"instructions", "scripts", "urls"}, (&SanitizeConfig{}).GetRules())
+	assert.Equal(t, []string{"urls"}, (&SanitizeConfig{Rules: []string{"urls"}}).GetRules())
+
+	require.NoError(t, validateSanitize(nil, "registry[0] (test)"))
+	require.NoError(t, validateSanitize(&SanitizeConfig{Rules: []string{"instructions", "scripts"}}, "registry[0] (test)"))
+	require.ErrorContains(t,
+		validateSanitize(&SanitizeConfig{Rules: []string{"markdown"}}, "registry[0] (test)"),
+		"registry[0] (test): sanitize.rules: unknown rule 'markdown'")
+}
+
+func TestConcurrencyConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		concurrency ConcurrencyConfig
+		wantErr     string
+	}{
+		{
+			name:        "no_limits",
+			concurrency: ConcurrencyConfig{},
+		},
+		{
+			name:        "valid_limits",
+			concurrency: ConcurrencyConfig{Limits: map[string]int{"suggestions": 2, "offline-bundle": 1}},
+		},
+		{
+			name:        "non_positive_limit",
+			concurrency: ConcurrencyConfig{Limits: map[string]int{"resolve": 0}},
+			wantErr:     "concurrency.limits.resolve must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.concurrency.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCacheConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		cache         CacheConfig
+		wantBudget    int64
+		wantSBOMQuota int64
+		wantErr       string
+	}{
+		{
+			name:       "defaults",
+			cache:      CacheConfig{},
+			wantBudget: DefaultCacheMemoryBudget,
+		},
+		{
+			name:          "budget_and_quota",
+			cache:         CacheConfig{MemoryBudget: "1Gi", Quotas: map[string]string{"sbom": "64Mi"}},
+			wantBudget:    1 << 30,
+			wantSBOMQuota: 64 << 20,
+		},
+		{
+			name:    "invalid_budget",
+			cache:   CacheConfig{MemoryBudget: "lots"},
+			wantErr: "cache.memoryBudget must be a valid quantity",
+		},
+		{
+			name:    "non_positive_quota",
+			cache:   CacheConfig{Quotas: map[string]string{"mirror": "0"}},
+			wantErr: "cache.quotas.mirror must be positive",
+		},
+		{
+			name:    "unknown_cache",
+			cache:   CacheConfig{Quotas: map[string]string{"search": "64Mi"}},
+			wantErr: "cache.quotas: unknown cache 'search'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.cache.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBudget, tt.cache.GetMemoryBudget())
+			assert.Equal(t, tt.wantSBOMQuota, tt.cache.GetQuota("sbom"))
+		})
+	}
+
+	var unset *CacheConfig
+	assert.Equal(t, int64(DefaultCacheMemoryBudget), unset.GetMemoryBudget())
+	assert.Zero(t, unset.GetQuota("mirror"))
+}
+
+func TestResponseMetaConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		meta    ResponseMetaConfig
+		wantErr string
+	}{
+		{
+			name: "include",
+			meta: ResponseMetaConfig{Include: []string{"io.modelcontextprotocol.registry"}},
+		},
+		{
+			name: "exclude",
+			meta: ResponseMetaConfig{Exclude: []string{"com.example/build"}},
+		},
+		{
+			name:    "include_and_exclude",
+			meta:    ResponseMetaConfig{Include: []string{"a"}, Exclude: []string{"b"}},
+			wantErr: "responseMeta.include and responseMeta.exclude are mutually exclusive",
+		},
+		{
+			name:    "empty_entry",
+			meta:    ResponseMetaConfig{Exclude: []string{""}},
+			wantErr: "responseMeta.exclude entries cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.meta.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClusterConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		cluster ClusterConfig
+		auth    *AuthConfig
+		wantErr string
+	}{
+		{
+			name:    "namespaces",
+			cluster: ClusterConfig{Namespaces: []string{"mcp", "tools"}},
+		},
+		{
+			name:    "impersonation_with_oauth",
+			cluster: ClusterConfig{Impersonation: &ImpersonationConfig{Enabled: true}},
+			auth:    &AuthConfig{Mode: AuthModeOAuth},
+		},
+		{
+			name:    "disabled_impersonation_with_anonymous_auth",
+			cluster: ClusterConfig{Impersonation: &ImpersonationConfig{}},
+			auth:    &AuthConfig{Mode: AuthModeAnonymous},
+		},
+		{
+			name:    "empty_namespace",
+			cluster: ClusterConfig{Namespaces: []string{"mcp", ""}},
+			wantErr: "cluster.namespaces entries cannot be empty",
+		},
+		{
+			name:    "impersonation_with_anonymous_auth",
+			cluster: ClusterConfig{Impersonation: &ImpersonationConfig{Enabled: true}},
+			auth:    &AuthConfig{Mode: AuthModeAnonymous},
+			wantErr: "cluster.impersonation requires authenticated callers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.cluster.validate(tt.auth)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	var unset *ImpersonationConfig
+	assert.Equal(t, DefaultImpersonationUserClaim, unset.GetUserClaim())
+	assert.Equal(t, "roles", (&ImpersonationConfig{GroupsClaim: "roles"}).GetGroupsClaim())
+}
+
+func TestClusterConfigValidateInstall(t *testing.T) {
+	t.Parallel()
+	oauth := &AuthConfig{Mode: AuthModeOAuth}
+	tests := []struct {
+		name    string
+		cluster *ClusterConfig
+		auth    *AuthConfig
+		wantErr string
+	}{
+		{name: "namespaces and oauth", cluster: &ClusterConfig{Namespaces: []string{"mcp"}}, auth: oauth},
+		{name: "no cluster configuration", auth: oauth, wantErr: "cluster.namespaces is required"},
+		{name: "no namespaces", cluster: &ClusterConfig{}, auth: oauth, wantErr: "cluster.namespaces is required"},
+		{
+			name:    "anonymous",
+			cluster: &ClusterConfig{Namespaces: []string{"mcp"}},
+			auth:    &AuthConfig{Mode: AuthModeAnonymous},
+			wantErr: "requires authenticated callers",
+		},
+		{name: "no auth", cluster: &ClusterConfig{Namespaces: []string{"mcp"}}, wantErr: "requires authenticated callers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.cluster.ValidateInstall(tt.auth)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSigningConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		signing   SigningConfig
+		wantKeyID string
+		wantErr   string
+	}{
+		{
+			name:      "disabled_without_key",
+			signing:   SigningConfig{},
+			wantKeyID: DefaultSigningKeyID,
+		},
+		{
+			name:      "custom_key_id",
+			signing:   SigningConfig{Enabled: true, KeyFile: "/etc/registry/signing.pem", KeyID: "prod-2026"},
+			wantKeyID: "prod-2026",
+		},
+		{
+			name:    "missing_key_file",
+			signing: SigningConfig{Enabled: true},
+			wantErr: "signing.keyFile is required when signing is enabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.signing.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKeyID, tt.signing.GetKeyID())
+		})
+	}
+}
+
+func TestApprovalConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		approval    ApprovalConfig
+		wantTimeout time.Duration
+		wantErr     string
+	}{
+		{
+			name:     "webhook",
+			approval: ApprovalConfig{Type: "webhook", URL: "https://approvals.example.com/check"},
+		},
+		{
+			name:        "opa_with_timeout",
+			approval:    ApprovalConfig{Type: "opa", URL: "http://opa:8181/v1/data/mcp/install", Timeout: "2s"},
+			wantTimeout: 2 * time.Second,
+		},
+		{
+			name:     "unknown_type",
+			approval: ApprovalConfig{Type: "ldap", URL: "https://approvals.example.com"},
+			wantErr:  "approval.type must be one of: webhook, opa",
+		},
+		{
+			name:     "missing_url",
+			approval: ApprovalConfig{Type: "webhook"},
+			wantErr:  "approval.url is required",
+		},
+		{
+			name:     "relative_url",
+			approval: ApprovalConfig{Type: "webhook", URL: "/check"},
+			wantErr:  "approval.url must be an absolute URL with host",
+		},
+		{
+			name:     "invalid_timeout",
+			approval: ApprovalConfig{Type: "opa", URL: "http://opa:8181/v1/data/mcp/install", Timeout: "soon"},
+			wantErr:  "approval.timeout must be a valid duration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.approval.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTimeout, tt.approval.GetTimeout())
+		})
+	}
+}
+
+func TestTicketingConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		ticketing TicketingConfig
+		wantErr   string
+	}{
+		{
+			name:      "jira",
+			ticketing: TicketingConfig{Type: "jira", URL: "https://example.atlassian.net", Project: "MCP"},
+		},
+		{
+			name:      "servicenow",
+			ticketing: TicketingConfig{Type: "servicenow", URL: "https://example.service-now.com"},
+		},
+		{
+			name:      "unknown_type",
+			ticketing: TicketingConfig{Type: "bugzilla", URL: "https://bugs.example.com"},
+			wantErr:   "ticketing.type must be one of: jira, servicenow",
+		},
+		{
+			name:      "missing_url",
+			ticketing: TicketingConfig{Type: "servicenow"},
+			wantErr:   "ticketing.url is required",
+		},
+		{
+			name:      "unsupported_scheme",
+			ticketing: TicketingConfig{Type: "servicenow", URL: "ftp://example.service-now.com"},
+			wantErr:   "ticketing.url must use http or https scheme",
+		},
+		{
+			name:      "jira_without_project",
+			ticketing: TicketingConfig{Type: "jira", URL: "https://example.atlassian.net"},
+			wantErr:   "ticketing.project is required for jira",
+		},
+		{
+			name:      "token_reference",
+			ticketing: TicketingConfig{Type: "servicenow", URL: "https://example.service-now.com", Token: "env://SNOW_TOKEN"},
+		},
+		{
+			name:      "plaintext_token",
+			ticketing: TicketingConfig{Type: "servicenow", URL: "https://example.service-now.com", Token: "s3cr3t"},
+			wantErr:   "ticketing.token must be a secret reference",
+		},
+		{
+			name: "token_and_token_file",
+			ticketing: TicketingConfig{
+				Type: "servicenow", URL: "https://example.service-now.com", Token: "env://SNOW_TOKEN", TokenFile: "/etc/token",
+			},
+			wantErr: "ticketing.token and ticketing.tokenFile are mutually exclusive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.ticketing.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTelemetryConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		telemetry    TelemetryConfig
+		wantInterval time.Duration
+		wantErr      string
+	}{
+		{
+			name:         "disabled_without_endpoint",
+			telemetry:    TelemetryConfig{},
+			wantInterval: DefaultTelemetryInterval,
+		},
+		{
+			name:         "default_interval",
+			telemetry:    TelemetryConfig{Enabled: true, Endpoint: "https://telemetry.example.com/v1/usage", HashKey: "env://TELEMETRY_HASH_KEY"},
+			wantInterval: DefaultTelemetryInterval,
+		},
+		{
+			name: "custom_interval",
+			telemetry: TelemetryConfig{
+				Enabled: true, Endpoint: "http://telemetry.internal", Interval: "1h", HashKey: "env://TELEMETRY_HASH_KEY",
+			},
+			wantInterval: time.Hour,
+		},
+		{
+			name:      "missing_endpoint",
+			telemetry: TelemetryConfig{Enabled: true},
+			wantErr:   "telemetry.endpoint is required when telemetry is enabled",
+		},
+		{
+			name:      "relative_endpoint",
+			telemetry: TelemetryConfig{Enabled: true, Endpoint: "/usage"},
+			wantErr:   "telemetry.endpoint must be an absolute URL with host",
+		},
+		{
+			name:      "unsupported_scheme",
+			telemetry: TelemetryConfig{Enabled: true, Endpoint: "ftp://telemetry.example.com"},
+			wantErr:   "telemetry.endpoint must use http or https scheme",
+		},
+		{
+			name:      "missing_hash_key",
+			telemetry: TelemetryConfig{Enabled: true, Endpoint: "https://telemetry.example.com"},
+			wantErr:   "telemetry.hashKey is required when telemetry is enabled",
+		},
+		{
+			name:      "plain_hash_key",
+			telemetry: TelemetryConfig{Enabled: true, Endpoint: "https://telemetry.example.com", HashKey: "not-a-reference"},
+			wantErr:   "telemetry.hashKey must be a secret reference",
+		},
+		{
+			name:      "invalid_interval",
+			telemetry: TelemetryConfig{Enabled: true, Endpoint: "https://telemetry.example.com", Interval: "daily", HashKey: "env://KEY"},
+			wantErr:   "telemetry.interval must be a valid duration",
+		},
+		{
+			name:      "interval_too_short",
+			telemetry: TelemetryConfig{Enabled: true, Endpoint: "https://telemetry.example.com", Interval: "10s", HashKey: "env://KEY"},
+			wantErr:   "telemetry.interval must be at least 1m0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.telemetry.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantInterval, tt.telemetry.GetInterval())
+		})
+	}
+}
+
+func TestSearchConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		search  SearchConfig
+		wantErr string
+	}{
+		{
+			name: "valid_boosts",
+			search: SearchConfig{Boosts: []SearchBoostConfig{
+				{Tier: "Official", Weight: 10},
+				{Status: "Deprecated", Weight: -10},
+				{Tag: "database", Weight: 2},
+				{NamePrefix: "io.github.stacklok/", Weight: 1},
+			}},
+		},
+		{
+			name:    "no_matcher",
+			search:  SearchConfig{Boosts: []SearchBoostConfig{{Weight: 1}}},
+			wantErr: "search.boosts[0]: exactly one of tier, status, tag, or namePrefix must be specified",
+		},
+		{
+			name:    "multiple_matchers",
+			search:  SearchConfig{Boosts: []SearchBoostConfig{{Tier: "Official", Tag: "database", Weight: 1}}},
+			wantErr: "search.boosts[0]: exactly one of tier, status, tag, or namePrefix must be specified",
+		},
+		{
+			name:    "zero_weight",
+			search:  SearchConfig{Boosts: []SearchBoostConfig{{Tier: "Official"}}},
+			wantErr: "search.boosts[0]: weight must be non-zero",
+		},
+		{
+			name: "valid_experiment",
+			search: SearchConfig{Experiment: &SearchExperimentConfig{Name: "official-first", Variants: []SearchVariantConfig{
+				{Name: "control", Weight: 1},
+				{Name: "official", Weight: 1, Boosts: []SearchBoostConfig{{Tier: "Official", Weight: 10}}},
+			}}},
+		},
+		{
+			name:    "experiment_without_name",
+			search:  SearchConfig{Experiment: &SearchExperimentConfig{Variants: []SearchVariantConfig{{Name: "a", Weight: 1}, {Name: "b", Weight: 1}}}},
+			wantErr: "search.experiment.name is required",
+		},
+		{
+			name:    "experiment_with_single_variant",
+			search:  SearchConfig{Experiment: &SearchExperimentConfig{Name: "exp", Variants: []SearchVariantConfig{{Name: "a", Weight: 1}}}},
+			wantErr: "search.experiment.variants must list at least two variants",
+		},
+		{
+			name: "duplicate_variant",
+			search: SearchConfig{Experiment: &SearchExperimentConfig{Name: "exp", Variants: []SearchVariantConfig{
+				{Name: "a", Weight: 1}, {Name: "a", Weight: 1},
+			}}},
+			wantErr: `search.experiment.variants[1]: duplicate variant name "a"`,
+		},
+		{
+			name: "variant_without_weight",
+			search: SearchConfig{Experiment: &SearchExperimentConfig{Name: "exp", Variants: []SearchVariantConfig{
+				{Name: "a", Weight: 1}, {Name: "b"},
+			}}},
+			wantErr: "search.experiment.variants[1]: weight must be positive",
+		},
+		{
+			name: "invalid_variant_boost",
+			search: SearchConfig{Experiment: &SearchExperimentConfig{Name: "exp", Variants: []SearchVariantConfig{
+				{Name: "a", Weight: 1}, {Name: "b", Weight: 1, Boosts: []SearchBoostConfig{{Weight: 1}}},
+			}}},
+			wantErr: "search.experiment.variants[1].boosts[0]: exactly one of tier, status, tag, or namePrefix must be specified",
+		},
+		{
+			name:   "valid_feedback",
+			search: SearchConfig{Feedback: &SearchFeedbackConfig{Weight: 2, MaxAdjustment: 20}},
+		},
+		{
+			name:    "negative_feedback_weight",
+			search:  SearchConfig{Feedback: &SearchFeedbackConfig{Weight: -1}},
+			wantErr: "search.feedback.weight must not be negative",
+		},
+		{
+			name:    "negative_feedback_max_adjustment",
+			search:  SearchConfig{Feedback: &SearchFeedbackConfig{MaxAdjustment: -1}},
+			wantErr: "search.feedback.maxAdjustment must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.search.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLinkChecksConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		linkChecks   LinkChecksConfig
+		wantErr      string
+		wantInterval time.Duration
+	}{
+		{
+			name:         "default_interval",
+			linkChecks:   LinkChecksConfig{Enabled: true},
+			wantInterval: DefaultLinkChecksInterval,
+		},
+		{
+			name:         "custom_interval",
+			linkChecks:   LinkChecksConfig{Enabled: true, Interval: "6h"},
+			wantInterval: 6 * time.Hour,
+		},
+		{
+			name:         "disabled_ignores_interval",
+			linkChecks:   LinkChecksConfig{Interval: "soon"},
+			wantInterval: DefaultLinkChecksInterval,
+		},
+		{
+			name:       "invalid_interval",
+			linkChecks: LinkChecksConfig{Enabled: true, Interval: "soon"},
+			wantErr:    "linkChecks.interval must be a valid duration",
+		},
+		{
+			name:       "interval_too_short",
+			linkChecks: LinkChecksConfig{Enabled: true, Interval: "5m"},
+			wantErr:    "linkChecks.interval must be at least 1h0m0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.linkChecks.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantInterval, tt.linkChecks.GetInterval())
+		})
+	}
+}
+
+func TestSBOMConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&SBOMConfig{}).validate())
+	require.NoError(t, (&SBOMConfig{TokenRealmHosts: []string{"gitlab.com", "auth.example.com"}}).validate())
+	require.ErrorContains(t, (&SBOMConfig{TokenRealmHosts: []string{"https://gitlab.com"}}).validate(),
+		"sbom.tokenRealmHosts[0] must be a host name")
+	require.ErrorContains(t, (&SBOMConfig{TokenRealmHosts: []string{"gitlab.com", ""}}).validate(),
+		"sbom.tokenRealmHosts[1] must be a host name")
+}
+
+func TestSummariesConfigValidate(t *testing.T) {
+	t.Parallel()
+	llm := func(configure func(*SummariesLLMConfig)) *SummariesLLMConfig {
+		l := &SummariesLLMConfig{URL: "https://llm.example.com/v1/chat/completions", Model: "summarizer"}
+		configure(l)
+		return l
+	}
+	tests := []struct {
+		name          string
+		summaries     SummariesConfig
+		wantErr       string
+		wantMinLength int
+		wantTimeout   time.Duration
+	}{
+		{
+			name:          "defaults",
+			summaries:     SummariesConfig{LLM: llm(func(*SummariesLLMConfig) {})},
+			wantMinLength: DefaultSummariesMinLength,
+			wantTimeout:   DefaultSummariesLLMTimeout,
+		},
+		{
+			name:          "custom_values",
+			summaries:     SummariesConfig{MinLength: 500, LLM: llm(func(l *SummariesLLMConfig) { l.Timeout = "5s" })},
+			wantMinLength: 500,
+			wantTimeout:   5 * time.Second,
+		},
+		{
+			name:          "extractive_only",
+			summaries:     SummariesConfig{},
+			wantMinLength: DefaultSummariesMinLength,
+		},
+		{
+			name:      "negative_min_length",
+			summaries: SummariesConfig{MinLength: -1},
+			wantErr:   "summaries.minLength must not be negative",
+		},
+		{
+			name:      "missing_url",
+			summaries: SummariesConfig{LLM: llm(func(l *SummariesLLMConfig) { l.URL = "" })},
+			wantErr:   "summaries.llm.url is required",
+		},
+		{
+			name:      "relative_url",
+			summaries: SummariesConfig{LLM: llm(func(l *SummariesLLMConfig) { l.URL = "/v1/chat/completions" })},
+			wantErr:   "summaries.llm.url must be an absolute URL with host",
+		},
+		{
+			name:      "unsupported_scheme",
+			summaries: SummariesConfig{LLM: llm(func(l *SummariesLLMConfig) { l.URL = "ftp://llm.example.com" })},
+			wantErr:   "summaries.llm.url must use http or https scheme",
+		},
+		{
+			name:      "missing_model",
+			summaries: SummariesConfig{LLM: llm(func(l *SummariesLLMConfig) { l.Model = "" })},
+			wantErr:   "summaries.llm.model is required",
+		},
+		{
+			name:      "invalid_api_key_reference",
+			summaries: SummariesConfig{LLM: llm(func(l *SummariesLLMConfig) { l.APIKey = "plain-key" })},
+			wantErr:   "summaries.llm.apiKey",
+		},
+		{
+			name:      "invalid_timeout",
+			summaries: SummariesConfig{LLM: llm(func(l *SummariesLLMConfig) { l.Timeout = "soon" })},
+			wantErr:   "summaries.llm.timeout must be a valid duration",
+		},
+		{
+			name:      "zero_timeout",
+			summaries: SummariesConfig{LLM: llm(func(l *SummariesLLMConfig) { l.Timeout = "0s" })},
+			wantErr:   "summaries.llm.timeout must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.summaries.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMinLength, tt.summaries.GetMinLength())
+			if tt.summaries.LLM != nil {
+				assert.Equal(t, tt.wantTimeout, tt.summaries.LLM.GetTimeout())
+			}
+		})
+	}
+}
+
+func TestKubernetesConfigGetNamespaces(t *testing.T) {
+	t.Parallel()
+
+	var unset *KubernetesConfig
+	assert.Equal(t, []string{DefaultKubernetesNamespace}, unset.GetNamespaces())
+	assert.Equal(t, []string{DefaultKubernetesNamespace}, (&KubernetesConfig{}).GetNamespaces())
+	assert.Equal(t, []string{"team-a", "team-b"}, (&KubernetesConfig{Namespaces: []string{"team-a", "team-b"}}).GetNamespaces())
+}
+
+func TestTransportConfigValidate(t *testing.T) {
+	t.Parallel()
+	syncPolicy := &SyncPolicyConfig{Interval: "30m"}
+	tests := []struct {
+		name     string
+		registry RegistryConfig
+		wantErr  string
+	}{
+		{
+			name: "api_source",
+			registry: RegistryConfig{
+				API: &APIConfig{Endpoint: "https://mirror.example.com"},
+				Transport: &TransportConfig{
+					Headers:       map[string]string{"X-Org-Id": "acme", "Host": "registry.example.com"},
+					SecretHeaders: map[string]string{"X-Org-Token": "env://ORG_TOKEN"},
+					TLS:           &TLSConfig{CACertFile: "/etc/ca.pem", ServerName: "registry.example.com"},
+				},
+			},
+		},
+		{
+			name: "file_url_source",
+			registry: RegistryConfig{
+				File:      &FileConfig{URL: "https://mirror.example.com/registry.json"},
+				Transport: &TransportConfig{TLS: &TLSConfig{CertFile: "/etc/tls.crt", KeyFile: "/etc/tls.key"}},
+			},
+		},
+		{
+			name: "file_path_source",
+			registry: RegistryConfig{
+				File:      &FileConfig{Path: "/data/registry.json"},
+				Transport: &TransportConfig{Headers: map[string]string{"X-Org-Id": "acme"}},
+			},
+			wantErr: "transport is only supported by api sources and file sources with a url",
+		},
+		{
+			name: "invalid_header_name",
+			registry: RegistryConfig{
+				API:       &APIConfig{Endpoint: "https://mirror.example.com"},
+				Transport: &TransportConfig{Headers: map[string]string{"X Org": "acme"}},
+			},
+			wantErr: `transport.headers has invalid header name "X Org"`,
+		},
+		{
+			name: "plaintext_secret_header",
+			registry: RegistryConfig{
+				API:       &APIConfig{Endpoint: "https://mirror.example.com"},
+				Transport: &TransportConfig{SecretHeaders: map[string]string{"X-Org-Token": "s3cr3t"}},
+			},
+			wantErr: "transport.secretHeaders[X-Org-Token] must be a secret reference",
+		},
+		{
+			name: "duplicate_header",
+			registry: RegistryConfig{
+				API: &APIConfig{Endpoint: "https://mirror.example.com"},
+				Transport: &TransportConfig{
+					Headers:       map[string]string{"X-Org-Token": "acme"},
+					SecretHeaders: map[string]string{"X-Org-Token": "env://ORG_TOKEN"},
+				},
+			},
+			wantErr: `header "X-Org-Token" is set in both transport.headers and transport.secretHeaders`,
+		},
+		{
+			name: "client_certificate_without_key",
+			registry: RegistryConfig{
+				API:       &APIConfig{Endpoint: "https://mirror.example.com"},
+				Transport: &TransportConfig{TLS: &TLSConfig{CertFile: "/etc/tls.crt"}},
+			},
+			wantErr: "transport.tls.certFile and transport.tls.keyFile must be set together",
+		},
+		{
+			name: "user_agent",
+			registry: RegistryConfig{
+				API:       &APIConfig{Endpoint: "https://mirror.example.com"},
+				Transport: &TransportConfig{UserAgent: "acme-bridge/2.0 (+https://acme.example.com)", DisableAttribution: true},
+			},
+		},
+		{
+			name: "invalid_user_agent",
+			registry: RegistryConfig{
+				API:       &APIConfig{Endpoint: "https://mirror.example.com"},
+				Transport: &TransportConfig{UserAgent: "acme\r\nX-Injected: 1"},
+			},
+			wantErr: "transport.userAgent is not a valid header value",
+		},
+		{
+			name: "managed_registry_sanitize_rule",
+			registry: RegistryConfig{
+				Managed:  &ManagedConfig{},
+				Sanitize: &SanitizeConfig{Rules: []string{"markdown"}},
+			},
+			wantErr: "sanitize.rules: unknown rule 'markdown'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.registry.Name = "mirror"
+			tt.registry.SyncPolicy = syncPolicy
+			err := (&Config{}).validateRegistryConfig(&tt.registry, 0)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAttributionConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		attribution AttributionConfig
+		wantErr     string
+	}{
+		{
+			name:        "deployment_and_contact",
+			attribution: AttributionConfig{DeploymentID: "acme-prod.eu_1", Contact: "platform-team@example.com"},
+		},
+		{
+			name:        "empty",
+			attribution: AttributionConfig{},
+		},
+		{
+			name:        "deployment_id_with_spaces",
+			attribution: AttributionConfig{DeploymentID: "acme prod"},
+			wantErr:     "attribution.deploymentId must only contain letters, digits",
+		},
+		{
+			name:        "deployment_id_closing_comment",
+			attribution: AttributionConfig{DeploymentID: "acme)"},
+			wantErr:     "attribution.deploymentId must only contain letters, digits",
+		},
+		{
+			name:        "invalid_contact",
+			attribution: AttributionConfig{Contact: "team@example.com\nX-Injected: 1"},
+			wantErr:     "attribution.contact is not a valid header value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.attribution.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLoadConfigWithAttribution(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `registries:
+  - name: official
+    api:
+      endpoint: https://registry.modelcontextprotocol.io
+    syncPolicy:
+      interval: "1h"
+  - name: partner
+    api:
+      endpoint: https://registry.partner.example.com
+    syncPolicy:
+      interval: "1h"
+    transport:
+      disableAttribution: true
+attribution:
+  deploymentId: acme-prod
+  contact: platform-team@example.com
+auth:
+  mode: anonymous
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(yamlContent), 0600))
+
+	config, err := LoadConfig(WithConfigPath(configPath))
+	require.NoError(t, err)
+
+	want := &AttributionConfig{DeploymentID: "acme-prod", Contact: "platform-team@example.com"}
+	assert.Equal(t, want, config.Attribution)
+	assert.Equal(t, want, config.Registries[0].GetAttribution())
+	assert.Nil(t, config.Registries[1].GetAttribution())
+}
+
+func TestListenersConfigValidate(t *testing.T) {
+	t.Parallel()
+	tlsCfg := &ListenerTLSConfig{CertFile: "/etc/tls/tls.crt", KeyFile: "/etc/tls/tls.key"}
+	tests := []struct {
+		name      string
+		listeners ListenersConfig
+		wantErr   string
+	}{
+		{
+			name:      "empty",
+			listeners: ListenersConfig{},
+		},
+		{
+			name: "api_tls_and_operations",
+			listeners: ListenersConfig{
+				API:        &APIListenerConfig{TLS: tlsCfg},
+				Operations: &OperationsListenerConfig{Address: ":8081", TLS: tlsCfg},
+			},
+		},
+		{
+			name:      "api_tls_missing_key",
+			listeners: ListenersConfig{API: &APIListenerConfig{TLS: &ListenerTLSConfig{CertFile: "/etc/tls/tls.crt"}}},
+			wantErr:   "listeners.api.tls.certFile and listeners.api.tls.keyFile are required",
+		},
+		{
+			name:      "operations_missing_address",
+			listeners: ListenersConfig{Operations: &OperationsListenerConfig{}},
+			wantErr:   "listeners.operations.address is required",
+		},
+		{
+			name:      "operations_invalid_address",
+			listeners: ListenersConfig{Operations: &OperationsListenerConfig{Address: "8081"}},
+			wantErr:   "listeners.operations.address is invalid",
+		},
+		{
+			name: "operations_tls_missing_cert",
+			listeners: ListenersConfig{Operations: &OperationsListenerConfig{
+				Address: ":8081",
+				TLS:     &ListenerTLSConfig{KeyFile: "/etc/tls/tls.key"},
+			}},
+			wantErr: "listeners.operations.tls.certFile and listeners.operations.tls.keyFile are required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.listeners.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRequestLoggingConfigValidate(t *testing.T) {
+	t.Parallel()
+	const sbomRoute = "/extension/v0/servers/{serverName}/versions/{version}/sbom"
+	tests := []struct {
+		name           string
+		requestLogging RequestLoggingConfig
+		wantErr        string
+		wantThreshold  time.Duration
+		wantRoutes     map[string]time.Duration
+	}{
+		{
+			name:       "defaults",
+			wantRoutes: map[string]time.Duration{},
+		},
+		{
+			name: "custom_values",
+			requestLogging: RequestLoggingConfig{
+				SlowThreshold: "2s",
+				Routes:        map[string]string{sbomRoute: "10s", "/health": "0s"},
+			},
+			wantThreshold: 2 * time.Second,
+			wantRoutes:    map[string]time.Duration{sbomRoute: 10 * time.Second, "/health": 0},
+		},
+		{
+			name:           "invalid_threshold",
+			requestLogging: RequestLoggingConfig{SlowThreshold: "fast"},
+			wantErr:        "requestLogging.slowThreshold must be a valid duration",
+		},
+		{
+			name:           "negative_threshold",
+			requestLogging: RequestLoggingConfig{SlowThreshold: "-1s"},
+			wantErr:        "requestLogging.slowThreshold must not be negative",
+		},
+		{
+			name:           "route_without_slash",
+			requestLogging: RequestLoggingConfig{Routes: map[string]string{"health": "1s"}},
+			wantErr:        "requestLogging.routes[health] must be a route pattern starting with /",
+		},
+		{
+			name:           "invalid_route_threshold",
+			requestLogging: RequestLoggingConfig{Routes: map[string]string{sbomRoute: "slow"}},
+			wantErr:        "must be a valid duration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.requestLogging.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantThreshold, tt.requestLogging.GetSlowThreshold())
+			assert.Equal(t, tt.wantRoutes, tt.requestLogging.GetRouteThresholds())
+		})
+	}
+}
+
+func TestSnapshotConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		snapshot     *SnapshotConfig
+		wantErr      string
+		wantTTL      time.Duration
+		wantInterval time.Duration
+		wantSWR      bool
+	}{
+		{
+			name:    "nil",
+			wantTTL: DefaultSnapshotTTL,
+			wantSWR: true,
+		},
+		{
+			name:     "defaults",
+			snapshot: &SnapshotConfig{},
+			wantTTL:  DefaultSnapshotTTL,
+			wantSWR:  true,
+		},
+		{
+			name:         "custom_values",
+			snapshot:     &SnapshotConfig{TTL: "5m", RefreshInterval: "1m", DisableStaleWhileRevalidate: true},
+			wantTTL:      5 * time.Minute,
+			wantInterval: time.Minute,
+		},
+		{
+			name:     "invalid_ttl",
+			snapshot: &SnapshotConfig{TTL: "soon"},
+			wantErr:  "snapshot.ttl must be a valid duration",
+		},
+		{
+			name:     "zero_refresh_interval",
+			snapshot: &SnapshotConfig{RefreshInterval: "0s"},
+			wantErr:  "snapshot.refreshInterval must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if tt.snapshot != nil {
+				err := tt.snapshot.validate()
+				if tt.wantErr != "" {
+					require.ErrorContains(t, err, tt.wantErr)
+					return
+				}
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantTTL, tt.snapshot.GetTTL())
+			assert.Equal(t, tt.wantInterval, tt.snapshot.GetRefreshInterval())
+			assert.Equal(t, tt.wantSWR, tt.snapshot.StaleWhileRevalidate())
+		})
+	}
+}
+
+func TestAnnotationsConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&AnnotationsConfig{File: "/data/annotations.json"}).validate())
+	require.ErrorContains(t, (&AnnotationsConfig{}).validate(), "annotations.file is required")
+}
+
+func TestReviewsConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&ReviewsConfig{File: "/data/reviews.json"}).validate())
+	require.ErrorContains(t, (&ReviewsConfig{}).validate(), "reviews.file is required")
+}
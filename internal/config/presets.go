@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// PresetOfficial is the official MCP Registry maintained by the MCP project
+	PresetOfficial = "official"
+
+	// PresetToolHive is the ToolHive registry maintained in the ToolHive repository
+	PresetToolHive = "toolhive"
+
+	// defaultPresetSyncInterval is the sync interval used by presets when syncPolicy is not set
+	defaultPresetSyncInterval = "1h"
+)
+
+// presets maps built-in preset names to their registry source configuration
+var presets = map[string]func() RegistryConfig{
+	PresetOfficial: func() RegistryConfig {
+		return RegistryConfig{
+			Format: SourceFormatUpstream,
+			API: &APIConfig{
+				Endpoint: "https://registry.modelcontextprotocol.io",
+			},
+		}
+	},
+	PresetToolHive: func() RegistryConfig {
+		return RegistryConfig{
+			Format: SourceFormatToolHive,
+			Git: &GitConfig{
+				Repository: "https://github.com/stacklok/toolhive.git",
+				Branch:     "main",
+				Path:       "pkg/registry/data/registry.json",
+			},
+		}
+	},
+}
+
+// PresetNames returns the names of all built-in registry presets, sorted alphabetically
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyPresets resolves the preset of every registry that sets one.
+// The preset provides the source and format; name defaults to the preset name
+// and syncPolicy defaults to an hourly sync. Filters are kept as configured.
+func (c *Config) applyPresets() error {
+	for i := range c.Registries {
+		reg := &c.Registries[i]
+		if reg.Preset == "" {
+			continue
+		}
+
+		newPreset, ok := presets[reg.Preset]
+		if !ok {
+			return fmt.Errorf("registry[%d]: unknown preset '%s' (must be one of: %s)",
+				i, reg.Preset, strings.Join(PresetNames(), ", "))
+		}
+		if reg.GetType() != "" || reg.Format != "" {
+			return fmt.Errorf("registry[%d]: preset '%s' cannot be combined with format or source configuration",
+				i, reg.Preset)
+		}
+
+		preset := newPreset()
+		reg.Format = preset.Format
+		reg.Git = preset.Git
+		reg.API = preset.API
+		if reg.Name == "" {
+			reg.Name = reg.Preset
+		}
+		if reg.SyncPolicy == nil {
+			reg.SyncPolicy = &SyncPolicyConfig{Interval: defaultPresetSyncInterval}
+		}
+	}
+
+	return nil
+}
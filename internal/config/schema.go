@@ -0,0 +1,126 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// SchemaURI is the JSON Schema dialect of the schema returned by Schema
+const SchemaURI = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema returns the JSON Schema of the configuration file, generated from the Config struct so
+// that it always matches the binary it is generated from. It describes the structure of the file
+// (known keys and their types); semantic constraints such as mutually exclusive fields are only
+// enforced by the server when it loads the file.
+func Schema() map[string]any {
+	g := &schemaGenerator{defs: make(map[string]any)}
+	schema := g.schemaOf(reflect.TypeFor[Config]())
+	schema["$schema"] = SchemaURI
+	schema["title"] = "ToolHive Registry Server configuration"
+	schema["$defs"] = g.defs
+	return schema
+}
+
+// schemaGenerator generates JSON Schemas from Go types, keeping named struct types in $defs
+type schemaGenerator struct {
+	defs map[string]any
+}
+
+// schemaOf returns the schema of a type. The root Config struct is returned inline,
+// other named structs are returned as references to their definition.
+func (g *schemaGenerator) schemaOf(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schemaOf(t.Elem())
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t == reflect.TypeFor[Config]() {
+			return g.structSchema(t)
+		}
+		if _, ok := g.defs[t.Name()]; !ok {
+			// Register the name first so that recursive types terminate
+			g.defs[t.Name()] = nil
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	default:
+		// Interfaces and other dynamic values accept anything
+		return map[string]any{}
+	}
+}
+
+// structSchema returns the schema of a struct, whose properties are its YAML fields
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	for field := range fields(t) {
+		name, inline := yamlName(field)
+		if name == "-" {
+			continue
+		}
+		if inline {
+			inlined := g.structSchema(indirect(field.Type))
+			for key, value := range inlined["properties"].(map[string]any) {
+				properties[key] = value
+			}
+			continue
+		}
+		properties[name] = g.schemaOf(field.Type)
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// fields iterates over the exported fields of a struct
+func fields(t reflect.Type) func(yield func(reflect.StructField) bool) {
+	return func(yield func(reflect.StructField) bool) {
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if !yield(field) {
+				return
+			}
+		}
+	}
+}
+
+// yamlName returns the YAML key of a struct field and whether it is inlined
+func yamlName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("yaml")
+	name, options, _ := strings.Cut(tag, ",")
+	inline := false
+	for _, option := range strings.Split(options, ",") {
+		if option == "inline" {
+			inline = true
+		}
+	}
+	if name == "" {
+		// yaml.v3 defaults to the lowercased field name
+		name = strings.ToLower(field.Name)
+	}
+	return name, inline
+}
+
+// indirect returns the type pointed to by t, or t itself
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := Schema()
+	assert.Equal(t, SchemaURI, schema["$schema"])
+	assert.Equal(t, false, schema["additionalProperties"])
+
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string"}, properties["registryName"])
+	assert.Equal(t,
+		map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/RegistryConfig"}},
+		properties["registries"])
+	assert.Equal(t,
+		map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "boolean"}},
+		properties["features"])
+
+	defs := schema["$defs"].(map[string]any)
+	registry := defs["RegistryConfig"].(map[string]any)["properties"].(map[string]any)
+	assert.Contains(t, registry, "syncPolicy")
+	assert.Equal(t, map[string]any{"$ref": "#/$defs/GitConfig"}, registry["git"])
+	for name, def := range defs {
+		assert.NotNil(t, def, "definition %s", name)
+	}
+
+	_, err := json.Marshal(schema)
+	require.NoError(t, err)
+}
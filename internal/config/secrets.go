@@ -0,0 +1,234 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Schemes of the built-in secret providers
+const (
+	// SecretSchemeEnv resolves env://NAME to the value of the NAME environment variable
+	SecretSchemeEnv = "env"
+	// SecretSchemeFile resolves file:///absolute/path to the trimmed content of the file
+	SecretSchemeFile = "file"
+	// SecretSchemeVault resolves vault://mount/path#key to the key of a HashiCorp Vault KV v2 secret
+	SecretSchemeVault = "vault"
+)
+
+// vaultRequestTimeout bounds each request to the Vault API
+const vaultRequestTimeout = 10 * time.Second
+
+// SecretProvider resolves the secret references of a URI scheme
+type SecretProvider interface {
+	// Resolve returns the value of the secret the reference points to
+	Resolve(ctx context.Context, reference *url.URL) (string, error)
+}
+
+// SecretProviderFunc adapts a function to a SecretProvider
+type SecretProviderFunc func(ctx context.Context, reference *url.URL) (string, error)
+
+// Resolve calls f(ctx, reference)
+func (f SecretProviderFunc) Resolve(ctx context.Context, reference *url.URL) (string, error) {
+	return f(ctx, reference)
+}
+
+// WithSecretProvider registers the provider resolving the secret references of scheme,
+// replacing the built-in provider of the scheme if any
+func WithSecretProvider(scheme string, provider SecretProvider) Option {
+	return func(cfg *loaderConfig) error {
+		if scheme == "" || provider == nil {
+			return fmt.Errorf("secret provider scheme and provider are required")
+		}
+		if cfg.secretProviders == nil {
+			cfg.secretProviders = defaultSecretProviders()
+		}
+		cfg.secretProviders[strings.ToLower(scheme)] = provider
+		return nil
+	}
+}
+
+// defaultSecretProviders returns the built-in secret providers by scheme
+func defaultSecretProviders() map[string]SecretProvider {
+	return map[string]SecretProvider{
+		SecretSchemeEnv:   SecretProviderFunc(resolveEnvSecret),
+		SecretSchemeFile:  SecretProviderFunc(resolveFileSecret),
+		SecretSchemeVault: &vaultSecretProvider{client: &http.Client{Timeout: vaultRequestTimeout}},
+	}
+}
+
+// parseSecretReference parses a secret reference of the form scheme://reference
+func parseSecretReference(value string) (*url.URL, error) {
+	scheme, _, found := strings.Cut(value, "://")
+	if !found || scheme == "" {
+		return nil, fmt.Errorf("must be a secret reference such as env://NAME, file:///path or vault://mount/path#key")
+	}
+	reference, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret reference: %w", err)
+	}
+	return reference, nil
+}
+
+// resolveSecret resolves a secret reference with the provider registered for its scheme
+func resolveSecret(ctx context.Context, providers map[string]SecretProvider, value string) (string, error) {
+	reference, err := parseSecretReference(value)
+	if err != nil {
+		return "", err
+	}
+	provider, ok := providers[reference.Scheme]
+	if !ok {
+		return "", fmt.Errorf("no secret provider for scheme %q", reference.Scheme)
+	}
+	secret, err := provider.Resolve(ctx, reference)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s secret: %w", reference.Scheme, err)
+	}
+	if secret == "" {
+		return "", fmt.Errorf("%s secret is empty", reference.Scheme)
+	}
+	return secret, nil
+}
+
+// resolveSecrets resolves the secret references of the configuration, so that secrets are read
+// once at startup and failures are reported before the server starts
+func (c *Config) resolveSecrets(ctx context.Context, providers map[string]SecretProvider) error {
+	if c.Ticketing != nil && c.Ticketing.Token != "" {
+		token, err := resolveSecret(ctx, providers, c.Ticketing.Token)
+		if err != nil {
+			return fmt.Errorf("ticketing.token: %w", err)
+		}
+		c.Ticketing.token = token
+	}
+
+	if c.Summaries != nil && c.Summaries.LLM != nil && c.Summaries.LLM.APIKey != "" {
+		apiKey, err := resolveSecret(ctx, providers, c.Summaries.LLM.APIKey)
+		if err != nil {
+			return fmt.Errorf("summaries.llm.apiKey: %w", err)
+		}
+		c.Summaries.LLM.apiKey = apiKey
+	}
+
+	if c.Telemetry != nil && c.Telemetry.Enabled {
+		hashKey, err := resolveSecret(ctx, providers, c.Telemetry.HashKey)
+		if err != nil {
+			return fmt.Errorf("telemetry.hashKey: %w", err)
+		}
+		c.Telemetry.hashKey = hashKey
+	}
+
+	for i := range c.Registries {
+		transport := c.Registries[i].Transport
+		if transport == nil || len(transport.SecretHeaders) == 0 {
+			continue
+		}
+		transport.secretHeaders = make(map[string]string, len(transport.SecretHeaders))
+		for name, value := range transport.SecretHeaders {
+			secret, err := resolveSecret(ctx, providers, value)
+			if err != nil {
+				return fmt.Errorf("registry[%d] (%s): transport.secretHeaders[%s]: %w", i, c.Registries[i].Name, name, err)
+			}
+			transport.secretHeaders[name] = secret
+		}
+	}
+
+	if c.Auth != nil && c.Auth.OAuth != nil {
+		for i := range c.Auth.OAuth.Providers {
+			p := &c.Auth.OAuth.Providers[i]
+			if p.ClientSecret == "" {
+				continue
+			}
+			secret, err := resolveSecret(ctx, providers, p.ClientSecret)
+			if err != nil {
+				return fmt.Errorf("auth.oauth.providers[%d].clientSecret: %w", i, err)
+			}
+			p.clientSecret = secret
+		}
+	}
+
+	return nil
+}
+
+// resolveEnvSecret resolves env://NAME references
+func resolveEnvSecret(_ context.Context, reference *url.URL) (string, error) {
+	name := reference.Host + reference.Path
+	if name == "" {
+		return "", fmt.Errorf("environment variable name is required")
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return strings.TrimSpace(value), nil
+}
+
+// resolveFileSecret resolves file:///absolute/path references
+func resolveFileSecret(_ context.Context, reference *url.URL) (string, error) {
+	if reference.Host != "" {
+		return "", fmt.Errorf("file references must be absolute, e.g. file:///etc/secret")
+	}
+	return readSecretFromFile(reference.Path)
+}
+
+// vaultSecretProvider resolves vault://mount/path#key references by reading the key of a KV v2
+// secret. The Vault address and token are read from the VAULT_ADDR and VAULT_TOKEN environment
+// variables; the key defaults to "value".
+type vaultSecretProvider struct {
+	client *http.Client
+}
+
+// Resolve reads the key of the secret from the Vault API
+func (v *vaultSecretProvider) Resolve(ctx context.Context, reference *url.URL) (string, error) {
+	addr := os.Getenv("VAULT_ADDR")
+	token := os.Getenv("VAULT_TOKEN")
+	if addr == "" || token == "" {
+		return "", fmt.Errorf("VAULT_ADDR and VAULT_TOKEN must be set")
+	}
+
+	mount, path, _ := strings.Cut(strings.Trim(reference.Host+reference.Path, "/"), "/")
+	if mount == "" || path == "" {
+		return "", fmt.Errorf("reference must include the mount and the secret path, e.g. vault://secret/registry#token")
+	}
+	key := reference.Fragment
+	if key == "" {
+		key = "value"
+	}
+
+	endpoint := strings.TrimSuffix(addr, "/") + "/v1/" + mount + "/data/" + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %s/%s: %w", mount, path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to read secret %s/%s: unexpected status %d", mount, path, resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode secret %s/%s: %w", mount, path, err)
+	}
+	value, ok := body.Data.Data[key].(string)
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no string key %q", mount, path, key)
+	}
+	return value, nil
+}
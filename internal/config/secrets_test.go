@@ -0,0 +1,132 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecret(t *testing.T) {
+	// Not parallel: uses t.Setenv
+	secretFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file-token\n"), 0600))
+
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" || r.URL.Path != "/v1/secret/data/registry" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"value":"vault-default","ticketing":"vault-token"}}}`))
+	}))
+	defer vault.Close()
+
+	t.Setenv("TICKETING_TOKEN", "env-token")
+	t.Setenv("VAULT_ADDR", vault.URL)
+	t.Setenv("VAULT_TOKEN", "root")
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "env", value: "env://TICKETING_TOKEN", want: "env-token"},
+		{name: "file", value: "file://" + secretFile, want: "file-token"},
+		{name: "vault key", value: "vault://secret/registry#ticketing", want: "vault-token"},
+		{name: "vault default key", value: "vault://secret/registry", want: "vault-default"},
+		{name: "plaintext", value: "s3cr3t", wantErr: "must be a secret reference"},
+		{name: "unknown scheme", value: "aws://registry/token", wantErr: `no secret provider for scheme "aws"`},
+		{name: "unset env", value: "env://UNSET_TOKEN", wantErr: "environment variable UNSET_TOKEN is not set"},
+		{name: "relative file", value: "file://token", wantErr: "file references must be absolute"},
+		{name: "vault missing key", value: "vault://secret/registry#oauth", wantErr: `has no string key "oauth"`},
+		{name: "vault denied", value: "vault://secret/other", wantErr: "unexpected status 403"},
+		{name: "vault without path", value: "vault://secret", wantErr: "must include the mount and the secret path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSecret(context.Background(), defaultSecretProviders(), tt.value)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadConfigResolvesSecrets(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `registries:
+  - name: test-registry
+    file:
+      path: /data/registry.json
+    syncPolicy:
+      interval: "30m"
+  - name: mirror
+    api:
+      endpoint: https://mirror.example.com
+    syncPolicy:
+      interval: "30m"
+    transport:
+      headers:
+        X-Org-Id: acme
+      secretHeaders:
+        X-Org-Token: test://mirror
+ticketing:
+  type: servicenow
+  url: https://example.service-now.com
+  token: test://ticketing
+summaries:
+  llm:
+    url: https://llm.example.com/v1/chat/completions
+    model: summarizer
+    apiKey: test://summaries
+telemetry:
+  enabled: true
+  endpoint: https://telemetry.example.com/v1/usage
+  hashKey: test://telemetry
+auth:
+  mode: oauth
+  oauth:
+    providers:
+      - name: keycloak
+        issuerUrl: https://keycloak.example.com
+        audience: registry
+        clientSecret: test://oauth
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(yamlContent), 0600))
+
+	provider := SecretProviderFunc(func(_ context.Context, reference *url.URL) (string, error) {
+		return "resolved-" + reference.Host, nil
+	})
+	cfg, err := LoadConfig(WithConfigPath(configPath), WithSecretProvider("test", provider))
+	require.NoError(t, err)
+
+	token, err := cfg.Ticketing.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "resolved-ticketing", token)
+	assert.Equal(t, "resolved-summaries", cfg.Summaries.LLM.GetAPIKey())
+	assert.Equal(t, []byte("resolved-telemetry"), cfg.Telemetry.GetHashKey())
+
+	secret, err := cfg.Auth.OAuth.Providers[0].GetClientSecret()
+	require.NoError(t, err)
+	assert.Equal(t, "resolved-oauth", secret)
+
+	assert.Equal(t, map[string]string{"X-Org-Id": "acme", "X-Org-Token": "resolved-mirror"},
+		cfg.Registries[1].Transport.GetHeaders())
+
+	// Without the provider, the references cannot be resolved and loading fails
+	_, err = LoadConfig(WithConfigPath(configPath))
+	require.ErrorContains(t, err, `ticketing.token: no secret provider for scheme "test"`)
+}
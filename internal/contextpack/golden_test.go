@@ -0,0 +1,116 @@
+package contextpack
+
+import (
+	"strings"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+
+	"github.com/stacklok/toolhive-registry-server/internal/golden"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+// withPackage adds a package to the server
+func withPackage(pkg model.Package) registry.ServerOption {
+	return func(server *upstreamv0.ServerJSON) {
+		server.Packages = append(server.Packages, pkg)
+	}
+}
+
+// withRemote adds a remote endpoint to the server
+func withRemote(transportType, url string) registry.ServerOption {
+	return func(server *upstreamv0.ServerJSON) {
+		server.Remotes = append(server.Remotes, model.Transport{Type: transportType, URL: url})
+	}
+}
+
+// TestPackGolden renders packs of servers published for each runtime, so that changes to their
+// rendering are reviewed in the golden files under testdata/packs
+func TestPackGolden(t *testing.T) {
+	t.Parallel()
+
+	stdio := model.Transport{Type: model.TransportTypeStdio}
+	tests := []struct {
+		name   string
+		server *upstreamv0.ServerJSON
+		budget int
+	}{
+		{
+			name:   "oci",
+			server: newTestServer(),
+			budget: MaxTokenBudget,
+		},
+		{
+			name: "npm",
+			server: newTestServer(func(server *upstreamv0.ServerJSON) {
+				server.Packages = []model.Package{{
+					RegistryType:         model.RegistryTypeNPM,
+					Identifier:           "@acme/search",
+					Version:              "1.0.0",
+					Transport:            stdio,
+					EnvironmentVariables: []model.KeyValueInput{envVar("SEARCH_API_KEY", "API key", true, true)},
+				}}
+			}),
+			budget: MaxTokenBudget,
+		},
+		{
+			name: "pypi",
+			server: newTestServer(func(server *upstreamv0.ServerJSON) {
+				server.Packages = nil
+			}, withPackage(model.Package{
+				RegistryType: model.RegistryTypePyPI,
+				Identifier:   "acme-search",
+				Version:      "1.0.0",
+				Transport:    stdio,
+			})),
+			budget: MaxTokenBudget,
+		},
+		{
+			name: "nuget and mcpb",
+			server: newTestServer(func(server *upstreamv0.ServerJSON) {
+				server.Packages = nil
+			}, withPackage(model.Package{
+				RegistryType: model.RegistryTypeNuGet,
+				Identifier:   "Acme.Search",
+				Version:      "1.0.0",
+				Transport:    stdio,
+			}), withPackage(model.Package{
+				RegistryType: model.RegistryTypeMCPB,
+				Identifier:   "https://example.com/search.mcpb",
+				FileSHA256:   strings.Repeat("a", 64),
+				Transport:    stdio,
+			})),
+			budget: MaxTokenBudget,
+		},
+		{
+			name: "remote",
+			server: newTestServer(func(server *upstreamv0.ServerJSON) {
+				server.Packages = nil
+				server.Title = "Acme Search"
+			}, withRemote(model.TransportTypeStreamableHTTP, "https://mcp.acme.example.com/search")),
+			budget: MaxTokenBudget,
+		},
+		{
+			name: "deprecated condensed",
+			server: newTestServer(
+				registry.WithLifecycleStatus(registry.StatusDeprecated),
+				registry.WithToolHiveMetadata("tools", []any{
+					"search", "fetch", "crawl", "summarize", "translate", "extract", "classify", "rank",
+					"cache", "index", "suggest", "export",
+				}),
+			),
+			budget: MinTokenBudget,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			pack := New(tt.server, tt.budget)
+			name := "packs/" + strings.ReplaceAll(tt.name, " ", "-")
+			golden.AssertString(t, name+".md", pack.Markdown()+"\n")
+			golden.AssertJSON(t, name+".json", pack)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package contextpack
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+const (
+	// DefaultPageSize is the number of items of a page when no limit is given
+	DefaultPageSize = 50
+	// MaxPageSize is the largest number of items of a page
+	MaxPageSize = 200
+)
+
+// ErrInvalidCursor is returned when a page cursor was not issued by this package
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ToolPage is a page of the tools of a server version
+type ToolPage struct {
+	Server  string   `json:"server"`
+	Version string   `json:"version"`
+	Tools   []string `json:"tools"`
+	// Total is the number of tools of the server
+	Total int `json:"total"`
+	// NextCursor fetches the next page, empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// EnvironmentVariablePage is a page of the environment variables of a server version, required first
+type EnvironmentVariablePage struct {
+	Server               string                `json:"server"`
+	Version              string                `json:"version"`
+	EnvironmentVariables []EnvironmentVariable `json:"environmentVariables"`
+	// Total is the number of environment variables of the server
+	Total int `json:"total"`
+	// NextCursor fetches the next page, empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Tools returns the page of the tools of a server starting at the cursor, the first page if empty.
+// The cursor of the tools left out of a pack is its ToolsCursor.
+func Tools(server *upstreamv0.ServerJSON, cursor string, limit int) (*ToolPage, error) {
+	tools := registry.ExtractTools(server)
+	items, next, err := page(tools, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &ToolPage{
+		Server:     server.Name,
+		Version:    server.Version,
+		Tools:      items,
+		Total:      len(tools),
+		NextCursor: next,
+	}, nil
+}
+
+// EnvironmentVariables returns the page of the environment variables of a server starting at the cursor,
+// the first page if empty. The cursor of the variables left out of a pack is its EnvironmentVariablesCursor.
+// Example values resembling credentials are expected to be redacted already (see registry.RedactSecrets).
+func EnvironmentVariables(server *upstreamv0.ServerJSON, cursor string, limit int) (*EnvironmentVariablePage, error) {
+	envs := environmentVariables(server)
+	items, next, err := page(envs, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &EnvironmentVariablePage{
+		Server:               server.Name,
+		Version:              server.Version,
+		EnvironmentVariables: items,
+		Total:                len(envs),
+		NextCursor:           next,
+	}, nil
+}
+
+// page returns the items starting at the cursor, up to limit, and the cursor of the next items
+func page[T any](items []T, cursor string, limit int) ([]T, string, error) {
+	start := 0
+	if cursor != "" {
+		var err error
+		if start, err = decodeCursor(cursor); err != nil {
+			return nil, "", err
+		}
+	}
+	if limit <= 0 || limit > MaxPageSize {
+		limit = DefaultPageSize
+	}
+
+	start = min(start, len(items))
+	end := min(start+limit, len(items))
+	next := ""
+	if end < len(items) {
+		next = encodeCursor(end)
+	}
+	return append([]T{}, items[start:end]...), next, nil
+}
+
+// encodeCursor returns the cursor of the items starting at offset
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the offset of the items a cursor starts at
+func decodeCursor(cursor string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil || offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return offset, nil
+}
@@ -0,0 +1,71 @@
+package contextpack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+func TestTools(t *testing.T) {
+	t.Parallel()
+
+	tools := make([]any, 25)
+	for i := range tools {
+		tools[i] = fmt.Sprintf("tool_number_%d", i)
+	}
+	server := newTestServer(registry.WithToolHiveMetadata("tools", tools))
+
+	first, err := Tools(server, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, "io.github.acme/search", first.Server)
+	assert.Equal(t, 25, first.Total)
+	assert.Len(t, first.Tools, 10)
+	assert.Equal(t, "tool_number_0", first.Tools[0])
+	require.NotEmpty(t, first.NextCursor)
+
+	second, err := Tools(server, first.NextCursor, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "tool_number_10", second.Tools[0])
+
+	last, err := Tools(server, second.NextCursor, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tool_number_20", "tool_number_21", "tool_number_22", "tool_number_23", "tool_number_24"},
+		last.Tools)
+	assert.Empty(t, last.NextCursor, "the last page has no next cursor")
+
+	_, err = Tools(server, "not a cursor", 10)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestPackRemainderCursors(t *testing.T) {
+	t.Parallel()
+
+	tools := make([]any, 40)
+	for i := range tools {
+		tools[i] = fmt.Sprintf("tool_number_%d", i)
+	}
+	server := newTestServer(registry.WithToolHiveMetadata("tools", tools))
+
+	full := New(server, MaxTokenBudget)
+	assert.Empty(t, full.ToolsCursor)
+	assert.Empty(t, full.EnvironmentVariablesCursor)
+
+	pack := New(server, 150)
+	require.Positive(t, pack.MoreTools)
+	require.Positive(t, pack.MoreEnvironmentVariables)
+
+	// The pages from the cursors of the pack list exactly what it left out
+	toolPage, err := Tools(server, pack.ToolsCursor, MaxPageSize)
+	require.NoError(t, err)
+	assert.Len(t, toolPage.Tools, pack.MoreTools)
+	assert.Equal(t, fmt.Sprintf("tool_number_%d", len(pack.Tools)), toolPage.Tools[0])
+
+	envPage, err := EnvironmentVariables(server, pack.EnvironmentVariablesCursor, MaxPageSize)
+	require.NoError(t, err)
+	assert.Equal(t, []EnvironmentVariable{{Name: "LOG_LEVEL", Description: "Log verbosity"}}, envPage.EnvironmentVariables)
+	assert.Equal(t, 2, envPage.Total)
+}
@@ -662,57 +662,130 @@ func (q *Queries) ListServerVersions(ctx context.Context, arg ListServerVersions
 }
 
 const listServers = `-- name: ListServers :many
-SELECT r.reg_type as registry_type,
-       s.id,
-       s.name,
-       s.version,
-       (l.latest_server_id IS NOT NULL)::boolean AS is_latest,
-       s.created_at,
-       s.updated_at,
-       s.description,
-       s.title,
-       s.website,
-       s.upstream_meta,
-       s.server_meta,
-       s.repository_url,
-       s.repository_id,
-       s.repository_subfolder,
-       s.repository_type
-  FROM mcp_server s
-  JOIN registry r ON s.reg_id = r.id
-  LEFT JOIN latest_server_version l ON s.id = l.latest_server_id
- WHERE ($1::timestamp with time zone IS NULL OR s.created_at > $1::timestamp with time zone)
-   AND ($2::timestamp with time zone IS NULL OR s.created_at < $2::timestamp with time zone)
-   AND ($3::text IS NULL OR r.name = $3::text)
-   AND ($4::text IS NULL OR (
-       LOWER(s.name) LIKE LOWER('%' || $4::text || '%')
-       OR LOWER(s.title) LIKE LOWER('%' || $4::text || '%')
-       OR LOWER(s.description) LIKE LOWER('%' || $4::text || '%')
-   ))
+SELECT listed.registry_type,
+       listed.registry_name,
+       listed.id,
+       listed.name,
+       listed.version,
+       listed.is_latest,
+       listed.created_at,
+       listed.updated_at,
+       listed.description,
+       listed.title,
+       listed.website,
+       listed.upstream_meta,
+       listed.server_meta,
+       listed.repository_url,
+       listed.repository_id,
+       listed.repository_subfolder,
+       listed.repository_type,
+       listed.sort_type_position,
+       listed.sort_time,
+       listed.sort_created_at
+  FROM (
+    SELECT r.reg_type as registry_type,
+           r.name as registry_name,
+           s.id,
+           s.name,
+           s.version,
+           (l.latest_server_id IS NOT NULL)::boolean AS is_latest,
+           s.created_at,
+           s.updated_at,
+           s.description,
+           s.title,
+           s.website,
+           s.upstream_meta,
+           s.server_meta,
+           s.repository_url,
+           s.repository_id,
+           s.repository_subfolder,
+           s.repository_type,
+           (CASE WHEN $1::text IS NULL
+                 THEN array_position(enum_range(NULL::registry_type), r.reg_type)
+                 ELSE 0 END)::integer AS sort_type_position,
+           (CASE $1::text
+                 WHEN 'published_at' THEN COALESCE(s.created_at, 'epoch')
+                 WHEN 'updated_at' THEN COALESCE(s.updated_at, s.created_at, 'epoch')
+                 ELSE 'epoch' END)::timestamp with time zone AS sort_time,
+           (CASE WHEN $1::text IS NULL
+                 THEN COALESCE(s.created_at, 'epoch')
+                 ELSE 'epoch' END)::timestamp with time zone AS sort_created_at
+      FROM mcp_server s
+      JOIN registry r ON s.reg_id = r.id
+      LEFT JOIN latest_server_version l ON s.id = l.latest_server_id
+     WHERE ($2::timestamp with time zone IS NULL OR s.created_at > $2::timestamp with time zone)
+       AND ($3::timestamp with time zone IS NULL OR s.created_at < $3::timestamp with time zone)
+       AND ($4::text IS NULL OR r.name = $4::text)
+       AND ($5::text IS NULL OR (
+           LOWER(s.name) LIKE LOWER('%' || $5::text || '%')
+           OR LOWER(s.title) LIKE LOWER('%' || $5::text || '%')
+           OR LOWER(s.description) LIKE LOWER('%' || $5::text || '%')
+       ))
+       AND ($6::timestamp with time zone IS NULL OR COALESCE(s.updated_at, s.created_at) > $6::timestamp with time zone)
+       AND ($7::timestamp with time zone IS NULL OR s.created_at < $7::timestamp with time zone)
+       -- lifecycle status kept by the registry in the official metadata, servers without one are active
+       AND ($8::boolean
+            OR LOWER(COALESCE(s.server_meta -> 'io.modelcontextprotocol.registry/official' ->> 'status', '')) <> 'deprecated')
+       AND ($9::boolean
+            OR LOWER(COALESCE(s.server_meta -> 'io.modelcontextprotocol.registry/official' ->> 'status', '')) <> 'deleted')
+  ) listed
+ -- servers are listed in a total order: the requested sorting, or else the registry type, name, creation
+ -- timestamp and version, with the server id as the final tie breaker, so that the page after a cursor
+ -- (the sort keys of the last server of the previous page) is found with a keyset condition. Names and
+ -- versions are compared byte-wise and missing timestamps sort as the epoch, although the sync and the
+ -- publish operation always set them.
+ WHERE $10::text IS NULL
+    OR (NOT $11::boolean
+        AND (listed.sort_type_position, listed.sort_time, listed.name COLLATE "C",
+             listed.sort_created_at, listed.version COLLATE "C", listed.id)
+          > ($12::integer, $13::timestamp with time zone,
+             $10::text COLLATE "C", $14::timestamp with time zone,
+             $15::text COLLATE "C", $16::uuid))
+    OR ($11::boolean
+        AND (listed.sort_type_position, listed.sort_time, listed.name COLLATE "C",
+             listed.sort_created_at, listed.version COLLATE "C", listed.id)
+          < ($12::integer, $13::timestamp with time zone,
+             $10::text COLLATE "C", $14::timestamp with time zone,
+             $15::text COLLATE "C", $16::uuid))
  ORDER BY
- -- next page sorting
- CASE WHEN $1::timestamp with time zone IS NULL THEN r.reg_type END ASC,
- CASE WHEN $1::timestamp with time zone IS NULL THEN s.name END ASC,
- CASE WHEN $1::timestamp with time zone IS NULL THEN s.created_at END ASC,
- CASE WHEN $1::timestamp with time zone IS NULL THEN s.version END ASC, -- acts as tie breaker
- -- previous page sorting
- CASE WHEN $2::timestamp with time zone IS NULL THEN r.reg_type END DESC,
- CASE WHEN $2::timestamp with time zone IS NULL THEN s.name END DESC,
- CASE WHEN $2::timestamp with time zone IS NULL THEN s.created_at END DESC,
- CASE WHEN $2::timestamp with time zone IS NULL THEN s.version END DESC -- acts as tie breaker
- LIMIT $5::bigint
+ CASE WHEN NOT $11::boolean THEN listed.sort_type_position END ASC,
+ CASE WHEN NOT $11::boolean THEN listed.sort_time END ASC,
+ CASE WHEN NOT $11::boolean THEN listed.name COLLATE "C" END ASC,
+ CASE WHEN NOT $11::boolean THEN listed.sort_created_at END ASC,
+ CASE WHEN NOT $11::boolean THEN listed.version COLLATE "C" END ASC,
+ CASE WHEN NOT $11::boolean THEN listed.id END ASC,
+ CASE WHEN $11::boolean THEN listed.sort_type_position END DESC,
+ CASE WHEN $11::boolean THEN listed.sort_time END DESC,
+ CASE WHEN $11::boolean THEN listed.name COLLATE "C" END DESC,
+ CASE WHEN $11::boolean THEN listed.sort_created_at END DESC,
+ CASE WHEN $11::boolean THEN listed.version COLLATE "C" END DESC,
+ CASE WHEN $11::boolean THEN listed.id END DESC
+ LIMIT $17::bigint
 `
 
 type ListServersParams struct {
-	Next         *time.Time `json:"next"`
-	Prev         *time.Time `json:"prev"`
-	RegistryName *string    `json:"registry_name"`
-	Search       *string    `json:"search"`
-	Size         int64      `json:"size"`
+	SortKey            *string    `json:"sort_key"`
+	Next               *time.Time `json:"next"`
+	Prev               *time.Time `json:"prev"`
+	RegistryName       *string    `json:"registry_name"`
+	Search             *string    `json:"search"`
+	UpdatedSince       *time.Time `json:"updated_since"`
+	PublishedBefore    *time.Time `json:"published_before"`
+	IncludeDeprecated  bool       `json:"include_deprecated"`
+	IncludeDeleted     bool       `json:"include_deleted"`
+	CursorName         *string    `json:"cursor_name"`
+	SortDesc           bool       `json:"sort_desc"`
+	CursorTypePosition int32      `json:"cursor_type_position"`
+	CursorTime         time.Time  `json:"cursor_time"`
+	CursorCreatedAt    time.Time  `json:"cursor_created_at"`
+	CursorVersion      string     `json:"cursor_version"`
+	CursorID           uuid.UUID  `json:"cursor_id"`
+	Size               int64      `json:"size"`
 }
 
 type ListServersRow struct {
 	RegistryType        RegistryType `json:"registry_type"`
+	RegistryName        string       `json:"registry_name"`
 	ID                  uuid.UUID    `json:"id"`
 	Name                string       `json:"name"`
 	Version             string       `json:"version"`
@@ -728,14 +801,29 @@ type ListServersRow struct {
 	RepositoryID        *string      `json:"repository_id"`
 	RepositorySubfolder *string      `json:"repository_subfolder"`
 	RepositoryType      *string      `json:"repository_type"`
+	SortTypePosition    int32        `json:"sort_type_position"`
+	SortTime            time.Time    `json:"sort_time"`
+	SortCreatedAt       time.Time    `json:"sort_created_at"`
 }
 
 func (q *Queries) ListServers(ctx context.Context, arg ListServersParams) ([]ListServersRow, error) {
 	rows, err := q.db.Query(ctx, listServers,
+		arg.SortKey,
 		arg.Next,
 		arg.Prev,
 		arg.RegistryName,
 		arg.Search,
+		arg.UpdatedSince,
+		arg.PublishedBefore,
+		arg.IncludeDeprecated,
+		arg.IncludeDeleted,
+		arg.CursorName,
+		arg.SortDesc,
+		arg.CursorTypePosition,
+		arg.CursorTime,
+		arg.CursorCreatedAt,
+		arg.CursorVersion,
+		arg.CursorID,
 		arg.Size,
 	)
 	if err != nil {
@@ -747,6 +835,7 @@ func (q *Queries) ListServers(ctx context.Context, arg ListServersParams) ([]Lis
 		var i ListServersRow
 		if err := rows.Scan(
 			&i.RegistryType,
+			&i.RegistryName,
 			&i.ID,
 			&i.Name,
 			&i.Version,
@@ -762,6 +851,9 @@ func (q *Queries) ListServers(ctx context.Context, arg ListServersParams) ([]Lis
 			&i.RepositoryID,
 			&i.RepositorySubfolder,
 			&i.RepositoryType,
+			&i.SortTypePosition,
+			&i.SortTime,
+			&i.SortCreatedAt,
 		); err != nil {
 			return nil, err
 		}
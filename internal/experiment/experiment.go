@@ -0,0 +1,340 @@
+// Package experiment runs search ranking experiments: API clients are assigned to the variants of
+// an experiment, their searches are ranked with the boosts of their variant, and the search results
+// they go on to fetch the details of or install are recorded as outcome signals, so that ranking
+// changes can be evaluated with real usage.
+//
+// Clients are identified by the session header they send (see SessionHeader), or else by the subject
+// of their access token or their IP address. Identifiers are only kept hashed, and in memory.
+package experiment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+const (
+	// SessionHeader is the request header identifying the session of a client. Clients sending it
+	// keep their variant across IP addresses and access tokens.
+	SessionHeader = "X-Registry-Session-Id"
+
+	// DefaultOutcomeWindow is how long after a search acting on one of its results counts as an outcome
+	DefaultOutcomeWindow = 30 * time.Minute
+	// DefaultMaxSessions is the default number of sessions whose last search is remembered
+	DefaultMaxSessions = 10000
+
+	// maxRecordedResults caps the number of server names recorded per search
+	maxRecordedResults = 50
+)
+
+// Outcome signals recorded for search results
+const (
+	// OutcomeDetail is recorded when the details of a search result are fetched
+	OutcomeDetail = "detail"
+	// OutcomeInstall is recorded when a search result is installed or bundled for installation
+	OutcomeInstall = "install"
+)
+
+// VariantStats are the outcome signals recorded for the clients assigned to a variant
+type VariantStats struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+	// Sessions is the number of sessions that searched
+	Sessions int `json:"sessions"`
+	// Searches is the number of searches, paging through results excluded
+	Searches int `json:"searches"`
+	// Details is the number of search results whose details were fetched
+	Details int `json:"details"`
+	// Installs is the number of search results installed or bundled for installation
+	Installs int `json:"installs"`
+	// MeanReciprocalRank is the mean of 1/rank of the search results acted upon, higher is better
+	MeanReciprocalRank float64 `json:"meanReciprocalRank"`
+
+	reciprocalRankSum float64
+}
+
+// Report is the state of an experiment
+type Report struct {
+	Experiment string         `json:"experiment"`
+	StartedAt  time.Time      `json:"startedAt"`
+	Variants   []VariantStats `json:"variants"`
+}
+
+// Experiment assigns clients to the variants of a search ranking experiment and records outcome
+// signals per variant. It is safe for concurrent use.
+type Experiment struct {
+	name          string
+	variants      []config.SearchVariantConfig
+	totalWeight   int
+	outcomeWindow time.Duration
+	maxSessions   int
+	now           func() time.Time
+	startedAt     time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	stats    map[string]*VariantStats
+}
+
+// session is the last search of a client
+type session struct {
+	variant    string
+	query      string
+	results    []string
+	searchedAt time.Time
+	// acted holds the outcome signals already recorded for the last search, keyed by outcome and server
+	acted map[string]bool
+}
+
+// Option configures an Experiment
+type Option func(*Experiment)
+
+// WithOutcomeWindow sets how long after a search acting on one of its results counts as an outcome
+func WithOutcomeWindow(window time.Duration) Option {
+	return func(e *Experiment) {
+		e.outcomeWindow = window
+	}
+}
+
+// WithMaxSessions sets the number of sessions whose last search is remembered. When more clients
+// search, the last search of the least recent one is forgotten.
+func WithMaxSessions(maxSessions int) Option {
+	return func(e *Experiment) {
+		e.maxSessions = maxSessions
+	}
+}
+
+// New creates an experiment from its validated configuration
+func New(cfg *config.SearchExperimentConfig, opts ...Option) (*Experiment, error) {
+	if cfg == nil || len(cfg.Variants) == 0 {
+		return nil, fmt.Errorf("at least one variant is required")
+	}
+
+	e := &Experiment{
+		name:          cfg.Name,
+		variants:      cfg.Variants,
+		outcomeWindow: DefaultOutcomeWindow,
+		maxSessions:   DefaultMaxSessions,
+		now:           time.Now,
+		sessions:      make(map[string]*session),
+		stats:         make(map[string]*VariantStats, len(cfg.Variants)),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	for _, variant := range cfg.Variants {
+		e.totalWeight += variant.Weight
+		e.stats[variant.Name] = &VariantStats{Name: variant.Name, Weight: variant.Weight}
+	}
+	e.startedAt = e.now().UTC()
+	return e, nil
+}
+
+// Name returns the name of the experiment
+func (e *Experiment) Name() string {
+	return e.name
+}
+
+// Assign returns the name of the variant a session is assigned to. Assignments are stable for
+// a given experiment name and spread sessions across variants in proportion to their weights.
+func (e *Experiment) Assign(sessionID string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(e.name + "/" + sessionID))
+	// #nosec G115 -- Weights are positive and the bucket is below their sum
+	bucket := int(h.Sum64() % uint64(e.totalWeight))
+	for _, variant := range e.variants {
+		if bucket < variant.Weight {
+			return variant.Name
+		}
+		bucket -= variant.Weight
+	}
+	return e.variants[len(e.variants)-1].Name
+}
+
+// assignmentContextKey is the request context key of the assignment of the client
+type assignmentContextKey struct{}
+
+// assignment is the variant a client is assigned to
+type assignment struct {
+	experiment *Experiment
+	session    string
+	variant    string
+}
+
+// Middleware assigns the client of each request to a variant, for the ranking service to rank its
+// searches with and for RecordSearch and RecordOutcome to attribute signals to. It must run after
+// the auth middleware for access token subjects to identify clients.
+func (e *Experiment) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := e.ContextWithSession(req.Context(), hashSession(clientIdentifier(req)))
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// ContextWithSession returns a copy of ctx assigning the session to its variant of the experiment
+func (e *Experiment) ContextWithSession(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, assignmentContextKey{}, &assignment{
+		experiment: e,
+		session:    sessionID,
+		variant:    e.Assign(sessionID),
+	})
+}
+
+// clientIdentifier identifies the client of a request by its session header, access token subject
+// or IP address, in this order of preference
+func clientIdentifier(req *http.Request) string {
+	if sessionID := req.Header.Get(SessionHeader); sessionID != "" {
+		return "session:" + sessionID
+	}
+	if claims, ok := auth.ClaimsFromContext(req.Context()); ok {
+		if subject, err := claims.GetSubject(); err == nil && subject != "" {
+			return "subject:" + subject
+		}
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// hashSession hashes a client identifier so that it is never kept in clear
+func hashSession(identifier string) string {
+	sum := sha256.Sum256([]byte(identifier))
+	return hex.EncodeToString(sum[:16])
+}
+
+// VariantFromContext returns the variant the client of a request is assigned to, or an empty string
+// if no experiment runs
+func VariantFromContext(ctx context.Context) string {
+	if a, ok := ctx.Value(assignmentContextKey{}).(*assignment); ok {
+		return a.variant
+	}
+	return ""
+}
+
+// RecordSearch records the results of a search by the client of a request, in rank order, replacing
+// its previous search. It does nothing if no experiment runs.
+func RecordSearch(ctx context.Context, query string, results []string) {
+	a, ok := ctx.Value(assignmentContextKey{}).(*assignment)
+	if !ok {
+		return
+	}
+	a.experiment.recordSearch(a, query, results)
+}
+
+// RecordOutcome records that the client of a request acted on a server, if the server was among
+// the results of its last search. It does nothing if no experiment runs.
+func RecordOutcome(ctx context.Context, outcome, serverName string) {
+	a, ok := ctx.Value(assignmentContextKey{}).(*assignment)
+	if !ok {
+		return
+	}
+	a.experiment.recordOutcome(ctx, a, outcome, serverName)
+}
+
+// recordSearch records the results of a search, deduplicating server names
+func (e *Experiment) recordSearch(a *assignment, query string, results []string) {
+	names := make([]string, 0, min(len(results), maxRecordedResults))
+	for _, name := range results {
+		if len(names) == maxRecordedResults {
+			break
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	stats := e.stats[a.variant]
+	stats.Searches++
+	if _, ok := e.sessions[a.session]; !ok {
+		stats.Sessions++
+		if len(e.sessions) >= e.maxSessions {
+			e.evictOldestLocked()
+		}
+	}
+	e.sessions[a.session] = &session{
+		variant:    a.variant,
+		query:      query,
+		results:    names,
+		searchedAt: e.now(),
+		acted:      make(map[string]bool),
+	}
+}
+
+// recordOutcome records an outcome signal if the server is among the results of the last search
+// of the session, within the outcome window. Each outcome is recorded once per search and server.
+func (e *Experiment) recordOutcome(ctx context.Context, a *assignment, outcome, serverName string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s, ok := e.sessions[a.session]
+	if !ok || e.now().Sub(s.searchedAt) > e.outcomeWindow {
+		return
+	}
+	index := slices.Index(s.results, serverName)
+	key := outcome + " " + serverName
+	if index < 0 || s.acted[key] {
+		return
+	}
+	s.acted[key] = true
+
+	rank := index + 1
+	stats := e.stats[s.variant]
+	switch outcome {
+	case OutcomeDetail:
+		stats.Details++
+	case OutcomeInstall:
+		stats.Installs++
+	default:
+		return
+	}
+	stats.reciprocalRankSum += 1 / float64(rank)
+	slog.InfoContext(ctx, "Search experiment outcome",
+		"experiment", e.name, "variant", s.variant, "outcome", outcome, "query", s.query, "server", serverName, "rank", rank)
+}
+
+// evictOldestLocked forgets the least recent search.
+// Caller must hold e.mu.
+func (e *Experiment) evictOldestLocked() {
+	var oldestKey string
+	var oldest *session
+	for key, s := range e.sessions {
+		if oldest == nil || s.searchedAt.Before(oldest.searchedAt) {
+			oldestKey, oldest = key, s
+		}
+	}
+	if oldest != nil {
+		delete(e.sessions, oldestKey)
+	}
+}
+
+// Report returns the outcome signals recorded for each variant, in configuration order
+func (e *Experiment) Report() Report {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	report := Report{Experiment: e.name, StartedAt: e.startedAt, Variants: make([]VariantStats, 0, len(e.variants))}
+	for _, variant := range e.variants {
+		stats := *e.stats[variant.Name]
+		if outcomes := stats.Details + stats.Installs; outcomes > 0 {
+			stats.MeanReciprocalRank = stats.reciprocalRankSum / float64(outcomes)
+		}
+		report.Variants = append(report.Variants, stats)
+	}
+	return report
+}
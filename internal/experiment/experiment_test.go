@@ -0,0 +1,170 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+func newTestExperiment(t *testing.T, opts ...Option) *Experiment {
+	t.Helper()
+	exp, err := New(&config.SearchExperimentConfig{
+		Name: "official-first",
+		Variants: []config.SearchVariantConfig{
+			{Name: "control", Weight: 3},
+			{Name: "official", Weight: 1, Boosts: []config.SearchBoostConfig{{Tier: "Official", Weight: 10}}},
+		},
+	}, opts...)
+	require.NoError(t, err)
+	return exp
+}
+
+// sessionOf returns a session assigned to the given variant
+func sessionOf(t *testing.T, exp *Experiment, variant string) string {
+	t.Helper()
+	for i := range 1000 {
+		session := fmt.Sprintf("session-%d", i)
+		if exp.Assign(session) == variant {
+			return session
+		}
+	}
+	t.Fatalf("no session assigned to variant %s", variant)
+	return ""
+}
+
+func TestAssign(t *testing.T) {
+	t.Parallel()
+	exp := newTestExperiment(t)
+
+	counts := map[string]int{}
+	for i := range 4000 {
+		session := fmt.Sprintf("session-%d", i)
+		variant := exp.Assign(session)
+		assert.Equal(t, variant, exp.Assign(session), "assignments are stable")
+		counts[variant]++
+	}
+	// Sessions are spread in proportion to the variant weights (3:1)
+	assert.InDelta(t, 3000, counts["control"], 200)
+	assert.InDelta(t, 1000, counts["official"], 200)
+
+	_, err := New(nil)
+	require.Error(t, err)
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+	exp := newTestExperiment(t)
+
+	var sessions []string
+	handler := exp.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		a, ok := req.Context().Value(assignmentContextKey{}).(*assignment)
+		require.True(t, ok)
+		assert.Equal(t, exp.Assign(a.session), VariantFromContext(req.Context()))
+		sessions = append(sessions, a.session)
+	}))
+
+	serve := func(configure func(*http.Request) *http.Request) {
+		req := httptest.NewRequest(http.MethodGet, "/v0.1/servers?search=slack", nil)
+		req.RemoteAddr = "192.0.2.1:4321"
+		handler.ServeHTTP(httptest.NewRecorder(), configure(req))
+	}
+	serve(func(req *http.Request) *http.Request {
+		req.Header.Set(SessionHeader, "abc")
+		return req
+	})
+	serve(func(req *http.Request) *http.Request {
+		req.Header.Set(SessionHeader, "abc")
+		req.RemoteAddr = "192.0.2.2:1234"
+		return req
+	})
+	serve(func(req *http.Request) *http.Request {
+		return req.WithContext(auth.ContextWithClaims(req.Context(), jwt.MapClaims{"sub": "alice"}))
+	})
+	serve(func(req *http.Request) *http.Request { return req })
+
+	require.Len(t, sessions, 4)
+	assert.Equal(t, sessions[0], sessions[1], "the session header identifies clients across addresses")
+	assert.NotEqual(t, sessions[0], sessions[2])
+	assert.NotEqual(t, sessions[2], sessions[3], "the token subject takes precedence over the address")
+	for _, session := range sessions {
+		assert.NotContains(t, session, "abc")
+		assert.NotContains(t, session, "192.0.2.1")
+	}
+
+	assert.Empty(t, VariantFromContext(context.Background()))
+}
+
+func TestRecordOutcome(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	exp := newTestExperiment(t)
+	exp.now = func() time.Time { return now }
+
+	control := exp.ContextWithSession(context.Background(), sessionOf(t, exp, "control"))
+	official := exp.ContextWithSession(context.Background(), sessionOf(t, exp, "official"))
+
+	RecordSearch(control, "slack", []string{"io.test/a", "io.test/a", "io.test/b", "io.test/c"})
+	RecordOutcome(control, OutcomeDetail, "io.test/c")
+	RecordOutcome(control, OutcomeDetail, "io.test/c") // recorded once per search
+	RecordOutcome(control, OutcomeInstall, "io.test/c")
+	RecordOutcome(control, OutcomeDetail, "io.test/unlisted")
+
+	RecordSearch(official, "slack", []string{"io.test/c", "io.test/a"})
+	RecordSearch(official, "github", []string{"io.test/d"})
+	RecordOutcome(official, OutcomeDetail, "io.test/c") // not among the results of the last search
+	RecordOutcome(official, OutcomeDetail, "io.test/d")
+
+	// Outcomes past the window are not attributed to the search
+	now = now.Add(DefaultOutcomeWindow + time.Second)
+	RecordOutcome(official, OutcomeInstall, "io.test/d")
+
+	// Without experiment, nothing is recorded
+	RecordSearch(context.Background(), "slack", []string{"io.test/a"})
+	RecordOutcome(context.Background(), OutcomeDetail, "io.test/a")
+
+	report := exp.Report()
+	assert.Equal(t, "official-first", report.Experiment)
+	assert.Equal(t, []VariantStats{
+		{
+			Name: "control", Weight: 3, Sessions: 1, Searches: 1, Details: 1, Installs: 1,
+			MeanReciprocalRank: 1.0 / 3, reciprocalRankSum: 2.0 / 3,
+		},
+		{
+			Name: "official", Weight: 1, Sessions: 1, Searches: 2, Details: 1,
+			MeanReciprocalRank: 1, reciprocalRankSum: 1,
+		},
+	}, report.Variants)
+}
+
+func TestMaxSessions(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	exp := newTestExperiment(t, WithMaxSessions(1))
+	exp.now = func() time.Time { return now }
+
+	first := exp.ContextWithSession(context.Background(), "first")
+	second := exp.ContextWithSession(context.Background(), "second")
+
+	RecordSearch(first, "slack", []string{"io.test/a"})
+	now = now.Add(time.Second)
+	RecordSearch(second, "slack", []string{"io.test/a"})
+	RecordOutcome(first, OutcomeDetail, "io.test/a")
+	RecordOutcome(second, OutcomeDetail, "io.test/a")
+
+	details := 0
+	for _, variant := range exp.Report().Variants {
+		details += variant.Details
+	}
+	assert.Equal(t, 1, details, "the search of the first session was forgotten")
+	assert.Len(t, exp.sessions, 1)
+}
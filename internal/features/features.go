@@ -0,0 +1,127 @@
+// Package features provides feature flags gating the experimental capabilities of the registry
+// server, so that they can ship dark and be enabled per deployment without separate builds.
+package features
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Names of the feature flags
+const (
+	// Suggestions gates the "did you mean" suggestions endpoint
+	Suggestions = "suggestions"
+	// SBOM gates the server SBOM endpoint, which downloads SBOMs from container registries
+	SBOM = "sbom"
+	// Platforms gates the server platforms endpoint, which reads image manifests from container registries
+	Platforms = "platforms"
+	// PackageStats gates the server package stats endpoint, which queries the npm and PyPI registries
+	PackageStats = "package-stats"
+	// Changelog gates the server changelog endpoint, which queries the GitHub API
+	Changelog = "changelog"
+	// OfflineBundle gates the offline bundle endpoint
+	OfflineBundle = "offline-bundle"
+	// RegistryWrites gates the endpoints creating, updating and deleting registries, server versions
+	// and server annotations, and ingesting reviews
+	RegistryWrites = "registry-writes"
+	// ClusterInstall gates the endpoint installing registry servers in the Kubernetes cluster
+	ClusterInstall = "cluster-install"
+
+	// EnvPrefix prefixes the environment variables overriding feature flags, e.g.
+	// THV_REGISTRY_FEATURE_OFFLINE_BUNDLE=false disables the offline-bundle feature
+	EnvPrefix = "THV_REGISTRY_FEATURE_"
+)
+
+// defaults holds the state of the features when not configured
+var defaults = map[string]bool{
+	Suggestions:    true,
+	SBOM:           true,
+	Platforms:      true,
+	PackageStats:   true,
+	Changelog:      true,
+	OfflineBundle:  true,
+	RegistryWrites: true,
+	ClusterInstall: false,
+}
+
+// Names returns the names of all feature flags, sorted
+func Names() []string {
+	return slices.Sorted(maps.Keys(defaults))
+}
+
+// Flags is the state of the feature flags. A nil *Flags has all features in their default state.
+type Flags struct {
+	enabled map[string]bool
+}
+
+// Option configures the resolution of feature flags
+type Option func(*resolver)
+
+// resolver holds the sources feature flags are resolved from
+type resolver struct {
+	lookupEnv func(string) (string, bool)
+}
+
+// WithLookupEnv sets the function looking environment variables up, os.LookupEnv by default
+func WithLookupEnv(lookupEnv func(string) (string, bool)) Option {
+	return func(r *resolver) {
+		r.lookupEnv = lookupEnv
+	}
+}
+
+// New resolves the feature flags from their defaults, overridden by the configured states,
+// themselves overridden by environment variables (see EnvPrefix).
+// Unknown feature names and invalid environment values are rejected.
+func New(configured map[string]bool, opts ...Option) (*Flags, error) {
+	r := &resolver{lookupEnv: os.LookupEnv}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	enabled := maps.Clone(defaults)
+	for name, state := range configured {
+		if _, ok := defaults[name]; !ok {
+			return nil, fmt.Errorf("unknown feature %q, must be one of %s", name, strings.Join(Names(), ", "))
+		}
+		enabled[name] = state
+	}
+	for name := range defaults {
+		variable := EnvVar(name)
+		value, ok := r.lookupEnv(variable)
+		if !ok || value == "" {
+			continue
+		}
+		state, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s: %w", value, variable, err)
+		}
+		enabled[name] = state
+	}
+	return &Flags{enabled: enabled}, nil
+}
+
+// EnvVar returns the environment variable overriding a feature flag
+func EnvVar(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// Enabled reports whether a feature is enabled. Unknown features are disabled.
+func (f *Flags) Enabled(name string) bool {
+	if f == nil {
+		return defaults[name]
+	}
+	return f.enabled[name]
+}
+
+// States returns the state of all feature flags, keyed by name
+func (f *Flags) States() map[string]bool {
+	states := make(map[string]bool, len(defaults))
+	for name := range defaults {
+		states[name] = f.Enabled(name)
+	}
+	return states
+}
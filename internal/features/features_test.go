@@ -0,0 +1,75 @@
+package features
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		configured map[string]bool
+		env        map[string]string
+		want       map[string]bool
+		wantErr    string
+	}{
+		{
+			name: "defaults",
+			want: map[string]bool{Suggestions: true, SBOM: true, Platforms: true, PackageStats: true, Changelog: true, OfflineBundle: true, RegistryWrites: true, ClusterInstall: false},
+		},
+		{
+			name:       "configured states override defaults",
+			configured: map[string]bool{SBOM: false, RegistryWrites: false, ClusterInstall: false},
+			want:       map[string]bool{Suggestions: true, SBOM: false, Platforms: true, PackageStats: true, Changelog: true, OfflineBundle: true, RegistryWrites: false, ClusterInstall: false},
+		},
+		{
+			name:       "environment overrides configuration",
+			configured: map[string]bool{SBOM: false},
+			env: map[string]string{
+				"THV_REGISTRY_FEATURE_SBOM":           "true",
+				"THV_REGISTRY_FEATURE_OFFLINE_BUNDLE": "0",
+				"THV_REGISTRY_FEATURE_SUGGESTIONS":    "",
+			},
+			want: map[string]bool{Suggestions: true, SBOM: true, Platforms: true, PackageStats: true, Changelog: true, OfflineBundle: false, RegistryWrites: true, ClusterInstall: false},
+		},
+		{
+			name:       "unknown feature",
+			configured: map[string]bool{"semantic-search": true},
+			wantErr:    `unknown feature "semantic-search"`,
+		},
+		{
+			name:    "invalid environment value",
+			env:     map[string]string{"THV_REGISTRY_FEATURE_SBOM": "maybe"},
+			wantErr: `invalid value "maybe" for THV_REGISTRY_FEATURE_SBOM`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			flags, err := New(tt.configured, WithLookupEnv(func(name string) (string, bool) {
+				value, ok := tt.env[name]
+				return value, ok
+			}))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, flags.States())
+		})
+	}
+}
+
+func TestFlagsEnabled(t *testing.T) {
+	t.Parallel()
+
+	var unset *Flags
+	assert.True(t, unset.Enabled(SBOM))
+	assert.False(t, unset.Enabled("semantic-search"))
+	assert.Equal(t, "THV_REGISTRY_FEATURE_REGISTRY_WRITES", EnvVar(RegistryWrites))
+}
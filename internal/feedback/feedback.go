@@ -0,0 +1,145 @@
+// Package feedback records whether clients accepted or rejected the servers recommended to them,
+// so that search ranking can promote the servers a deployment's users pick and demote the ones
+// they turn down.
+package feedback
+
+import (
+	"cmp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+// DefaultMaxServers is the default number of servers whose feedback is kept
+const DefaultMaxServers = 10000
+
+// ServerFeedback is the feedback recorded for a server
+type ServerFeedback struct {
+	Server   string `json:"server"`
+	Accepted int    `json:"accepted"`
+	Rejected int    `json:"rejected"`
+	// Adjustment is the score added to the server when ranking search results
+	Adjustment   int       `json:"adjustment"`
+	LastFeedback time.Time `json:"lastFeedback"`
+}
+
+// Store records feedback on servers in memory and turns it into ranking adjustments.
+// When feedback on more than maxServers servers is recorded, the server with the least recent
+// feedback is forgotten. It is safe for concurrent use.
+type Store struct {
+	weight        int
+	maxAdjustment int
+	maxServers    int
+	now           func() time.Time
+
+	mu      sync.Mutex
+	servers map[string]*ServerFeedback
+}
+
+// Option configures a Store
+type Option func(*Store)
+
+// WithMaxServers sets the number of servers whose feedback is kept
+func WithMaxServers(maxServers int) Option {
+	return func(s *Store) {
+		s.maxServers = maxServers
+	}
+}
+
+// NewStore creates a store weighting feedback as configured
+func NewStore(cfg *config.SearchFeedbackConfig, opts ...Option) *Store {
+	s := &Store{
+		weight:        cfg.GetWeight(),
+		maxAdjustment: cfg.GetMaxAdjustment(),
+		maxServers:    DefaultMaxServers,
+		now:           time.Now,
+		servers:       make(map[string]*ServerFeedback),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Record records that a client accepted or rejected a server, and returns the feedback of the server
+func (s *Store) Record(serverName string, accepted bool) ServerFeedback {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.servers[serverName]
+	if !ok {
+		if len(s.servers) >= s.maxServers {
+			s.evictOldestLocked()
+		}
+		entry = &ServerFeedback{Server: serverName}
+		s.servers[serverName] = entry
+	}
+	if accepted {
+		entry.Accepted++
+	} else {
+		entry.Rejected++
+	}
+	entry.LastFeedback = s.now()
+	return s.withAdjustment(*entry)
+}
+
+// Adjustment returns the score feedback adds to a server when ranking search results: the weight
+// times the acceptances minus the rejections, capped by the maximum adjustment
+func (s *Store) Adjustment(serverName string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.servers[serverName]
+	if !ok {
+		return 0
+	}
+	return s.withAdjustment(*entry).Adjustment
+}
+
+// Top returns the feedback of up to limit servers, largest adjustments (either way) first
+func (s *Store) Top(limit int) []ServerFeedback {
+	s.mu.Lock()
+	result := make([]ServerFeedback, 0, len(s.servers))
+	for _, entry := range s.servers {
+		result = append(result, s.withAdjustment(*entry))
+	}
+	s.mu.Unlock()
+
+	return registry.TopK(result, limit, func(a, b ServerFeedback) int {
+		return cmp.Or(
+			cmp.Compare(abs(b.Adjustment), abs(a.Adjustment)),
+			cmp.Compare(b.Accepted+b.Rejected, a.Accepted+a.Rejected),
+			strings.Compare(a.Server, b.Server),
+		)
+	})
+}
+
+// withAdjustment returns the feedback with its ranking adjustment set
+func (s *Store) withAdjustment(entry ServerFeedback) ServerFeedback {
+	entry.Adjustment = max(-s.maxAdjustment, min(s.maxAdjustment, s.weight*(entry.Accepted-entry.Rejected)))
+	return entry
+}
+
+// evictOldestLocked forgets the server with the least recent feedback.
+// Caller must hold s.mu.
+func (s *Store) evictOldestLocked() {
+	var oldest *ServerFeedback
+	for _, entry := range s.servers {
+		if oldest == nil || entry.LastFeedback.Before(oldest.LastFeedback) {
+			oldest = entry
+		}
+	}
+	if oldest != nil {
+		delete(s.servers, oldest.Server)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,68 @@
+package feedback
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore(&config.SearchFeedbackConfig{Weight: 2, MaxAdjustment: 5})
+	store.now = func() time.Time { return now }
+
+	store.Record("io.test/popular", true)
+	store.Record("io.test/popular", true)
+	store.Record("io.test/popular", true)
+	recorded := store.Record("io.test/mixed", true)
+	assert.Equal(t, ServerFeedback{Server: "io.test/mixed", Accepted: 1, Adjustment: 2, LastFeedback: now}, recorded)
+	store.Record("io.test/mixed", false)
+	store.Record("io.test/disliked", false)
+
+	// Adjustments are capped
+	assert.Equal(t, 5, store.Adjustment("io.test/popular"))
+	assert.Equal(t, 0, store.Adjustment("io.test/mixed"))
+	assert.Equal(t, -2, store.Adjustment("io.test/disliked"))
+	assert.Equal(t, 0, store.Adjustment("io.test/unknown"))
+
+	top := store.Top(0)
+	servers := make([]string, 0, len(top))
+	for _, entry := range top {
+		servers = append(servers, entry.Server)
+	}
+	assert.Equal(t, []string{"io.test/popular", "io.test/disliked", "io.test/mixed"}, servers)
+	assert.Len(t, store.Top(1), 1)
+}
+
+func TestStoreDefaults(t *testing.T) {
+	t.Parallel()
+	store := NewStore(&config.SearchFeedbackConfig{})
+
+	for range 20 {
+		store.Record("io.test/server", true)
+	}
+	assert.Equal(t, config.DefaultSearchFeedbackMaxAdjustment, store.Adjustment("io.test/server"))
+	store.Record("io.test/other", false)
+	assert.Equal(t, -config.DefaultSearchFeedbackWeight, store.Adjustment("io.test/other"))
+}
+
+func TestStoreMaxServers(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore(&config.SearchFeedbackConfig{}, WithMaxServers(2))
+	store.now = func() time.Time { return now }
+
+	for _, server := range []string{"io.test/a", "io.test/b", "io.test/a", "io.test/c"} {
+		now = now.Add(time.Second)
+		store.Record(server, true)
+	}
+
+	// io.test/b got the least recent feedback
+	assert.Equal(t, 0, store.Adjustment("io.test/b"))
+	assert.Equal(t, 2, store.Adjustment("io.test/a"))
+	assert.Equal(t, 1, store.Adjustment("io.test/c"))
+}
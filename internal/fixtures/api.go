@@ -0,0 +1,96 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+const (
+	// defaultPageSize is the number of servers of a page when no limit is given
+	defaultPageSize = 30
+	// maxPageSize is the largest number of servers of a page
+	maxPageSize = 100
+)
+
+// openAPISpec is the minimal OpenAPI document API sources check to recognize an upstream MCP Registry
+const openAPISpec = `openapi: 3.1.0
+info:
+  title: Mock MCP Registry
+  version: 1.0.0
+  description: Synthetic catalog served with the API of https://github.com/modelcontextprotocol/registry
+`
+
+// NewUpstreamAPIHandler returns a handler serving servers with the API of the upstream MCP Registry:
+// /openapi.yaml and the paginated server list at /v0/servers and /v0.1/servers. The official
+// timestamps and lifecycle status of the servers are moved to the registry-managed metadata.
+func NewUpstreamAPIHandler(servers []upstreamv0.ServerJSON) http.Handler {
+	responses := serverResponses(servers)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /openapi.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write([]byte(openAPISpec))
+	})
+	listServers := func(w http.ResponseWriter, r *http.Request) {
+		listServerResponses(w, r, responses)
+	}
+	mux.HandleFunc("GET /v0/servers", listServers)
+	mux.HandleFunc("GET /v0.1/servers", listServers)
+	return mux
+}
+
+// listServerResponses writes the page of responses selected by the cursor and limit query parameters.
+// Cursors are the offset of the first server of the page.
+func listServerResponses(w http.ResponseWriter, r *http.Request, responses []upstreamv0.ServerResponse) {
+	query := r.URL.Query()
+	offset := 0
+	if cursor := query.Get("cursor"); cursor != "" {
+		var err error
+		offset, err = strconv.Atoi(cursor)
+		if err != nil || offset < 0 || offset > len(responses) {
+			http.Error(w, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+	}
+	limit := defaultPageSize
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(limit, maxPageSize)
+	}
+
+	end := min(offset+limit, len(responses))
+	page := upstreamv0.ServerListResponse{
+		Servers:  responses[offset:end],
+		Metadata: upstreamv0.Metadata{Count: end - offset},
+	}
+	if end < len(responses) {
+		page.Metadata.NextCursor = strconv.Itoa(end)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(page)
+}
+
+// serverResponses wraps servers in list responses, flagging the last version of each server as the latest
+func serverResponses(servers []upstreamv0.ServerJSON) []upstreamv0.ServerResponse {
+	latest := make(map[string]int, len(servers))
+	for i := range servers {
+		latest[servers[i].Name] = i
+	}
+
+	responses := make([]upstreamv0.ServerResponse, len(servers))
+	for i := range servers {
+		responses[i] = registry.ToServerResponse(servers[i], latest[servers[i].Name] == i)
+	}
+	return responses
+}
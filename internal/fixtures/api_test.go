@@ -0,0 +1,94 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+func TestUpstreamAPIHandler(t *testing.T) {
+	t.Parallel()
+
+	servers := Servers(11, 40)
+	handler := NewUpstreamAPIHandler(servers)
+
+	var listed []upstreamv0.ServerResponse
+	cursor := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, len(servers), "pagination should end")
+		req := httptest.NewRequest(http.MethodGet, "/v0.1/servers?limit=25&cursor="+cursor, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var page upstreamv0.ServerListResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		assert.Len(t, page.Servers, page.Metadata.Count)
+		listed = append(listed, page.Servers...)
+		if page.Metadata.NextCursor == "" {
+			break
+		}
+		cursor = page.Metadata.NextCursor
+	}
+
+	require.Len(t, listed, len(servers))
+	latest := map[string]bool{}
+	for i, response := range listed {
+		assert.Equal(t, servers[i].Name, response.Server.Name)
+		assert.Equal(t, servers[i].Version, response.Server.Version)
+		assert.NotContains(t, response.Server.Meta.PublisherProvided, registry.OfficialMetaKey)
+		require.NotNil(t, response.Meta.Official)
+		publishedAt, _ := registry.ExtractPublishedAt(&servers[i])
+		assert.True(t, publishedAt.Equal(response.Meta.Official.PublishedAt))
+		assert.Equal(t, registry.ExtractLifecycleStatus(&servers[i]), string(response.Meta.Official.Status))
+		if response.Meta.Official.IsLatest {
+			assert.False(t, latest[response.Server.Name], "%s has several latest versions", response.Server.Name)
+			latest[response.Server.Name] = true
+		}
+	}
+	assert.Len(t, latest, 40)
+	assert.Contains(t, servers[0].Meta.PublisherProvided, registry.OfficialMetaKey, "servers should be left unmodified")
+}
+
+func TestUpstreamAPIHandlerRequests(t *testing.T) {
+	t.Parallel()
+
+	handler := NewUpstreamAPIHandler(Servers(1, 5, WithMaxVersions(1)))
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantCount  int
+	}{
+		{name: "default page", path: "/v0/servers", wantStatus: http.StatusOK, wantCount: 5},
+		{name: "last page", path: "/v0.1/servers?cursor=3", wantStatus: http.StatusOK, wantCount: 2},
+		{name: "invalid cursor", path: "/v0.1/servers?cursor=abc", wantStatus: http.StatusBadRequest},
+		{name: "cursor out of range", path: "/v0.1/servers?cursor=6", wantStatus: http.StatusBadRequest},
+		{name: "invalid limit", path: "/v0.1/servers?limit=0", wantStatus: http.StatusBadRequest},
+		{name: "openapi", path: "/openapi.yaml", wantStatus: http.StatusOK},
+		{name: "unknown path", path: "/v0/unknown", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantCount == 0 {
+				return
+			}
+			var page upstreamv0.ServerListResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+			assert.Len(t, page.Servers, tt.wantCount)
+		})
+	}
+}
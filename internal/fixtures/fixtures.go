@@ -0,0 +1,327 @@
+// Package fixtures generates reproducible synthetic registry catalogs, shared by unit tests,
+// benchmarks and the mock-registry command.
+//
+// Catalogs are drawn from a seeded random source, so the same seed and options always produce
+// the same servers. Their distributions mimic public registries: a few popular tags and many
+// rare ones, mostly npm and container packages, a minority of remote-only servers, a long tail
+// of repository stars, and metadata ranging from none at all to full ToolHive blocks.
+package fixtures
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	toolhivetypes "github.com/stacklok/toolhive/pkg/registry/registry"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+// serverSchemaURL is the server.json schema generated servers declare
+const serverSchemaURL = "https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json"
+
+const (
+	// defaultMaxVersions is the default maximum number of versions generated per server
+	defaultMaxVersions = 3
+	// maxTags is the maximum number of tags of a generated server
+	maxTags = 6
+	// maxTools is the maximum number of tools of a generated server
+	maxTools = 24
+)
+
+// DefaultEpoch is the default publication time of the oldest generated server version
+var DefaultEpoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var (
+	owners = []string{
+		"acme", "octo-labs", "datawise", "cloudnine", "bytecraft", "northwind", "initech",
+		"umbrella-dev", "hooli", "stark-tools", "wayne-ops", "globex", "vandelay", "piedpiper",
+	}
+	subjects = []string{
+		"github", "gitlab", "jira", "slack", "postgres", "mysql", "redis", "s3", "kubernetes",
+		"docker", "stripe", "notion", "linear", "sentry", "grafana", "browser", "filesystem",
+		"weather", "calendar", "email", "search", "maps", "translate", "terraform", "vault",
+	}
+	suffixes = []string{"", "-mcp", "-server", "-tools", "-lite", "-pro"}
+
+	// tagVocabulary is ordered by popularity, tags are drawn with a Zipf distribution
+	tagVocabulary = []string{
+		"api", "database", "devops", "productivity", "search", "cloud", "git", "ai", "data",
+		"monitoring", "security", "communication", "files", "analytics", "automation", "web",
+		"kubernetes", "finance", "documentation", "testing", "storage", "observability", "crm",
+		"design", "education", "gaming", "healthcare", "iot", "legal", "music",
+	}
+
+	toolVerbs   = []string{"list", "get", "create", "update", "delete", "search", "run", "describe"}
+	toolObjects = []string{
+		"items", "issues", "pull_requests", "channels", "messages", "tables", "queries", "files",
+		"buckets", "pods", "deployments", "events", "users", "documents", "alerts", "dashboards",
+	}
+
+	tiers = []string{"Official", "Community"}
+)
+
+// options holds the settings of a generated catalog
+type options struct {
+	maxVersions int
+	epoch       time.Time
+}
+
+// Option configures a generated catalog
+type Option func(*options)
+
+// WithMaxVersions sets the maximum number of versions generated per server (3 by default)
+func WithMaxVersions(maxVersions int) Option {
+	return func(o *options) {
+		o.maxVersions = max(maxVersions, 1)
+	}
+}
+
+// WithEpoch sets the publication time of the oldest generated server version (DefaultEpoch by default)
+func WithEpoch(epoch time.Time) Option {
+	return func(o *options) {
+		o.epoch = epoch
+	}
+}
+
+// generator draws the servers of a catalog from a seeded random source
+type generator struct {
+	rng  *rand.Rand
+	tags *rand.Zipf
+	opts options
+}
+
+// Servers returns a catalog of count servers generated from seed, each with one or more
+// versions, oldest first
+func Servers(seed uint64, count int, opts ...Option) []upstreamv0.ServerJSON {
+	o := options{maxVersions: defaultMaxVersions, epoch: DefaultEpoch}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	g := &generator{
+		rng:  rng,
+		tags: rand.NewZipf(rng, 1.3, 1, uint64(len(tagVocabulary)-1)),
+		opts: o,
+	}
+
+	servers := make([]upstreamv0.ServerJSON, 0, count)
+	for i := range count {
+		servers = append(servers, g.server(i)...)
+	}
+	return servers
+}
+
+// Registry returns the catalog generated by Servers as an upstream registry
+func Registry(seed uint64, count int, opts ...Option) *toolhivetypes.UpstreamRegistry {
+	return registry.NewTestUpstreamRegistry(
+		registry.WithLastUpdated(DefaultEpoch.Format(time.RFC3339)),
+		registry.WithServers(Servers(seed, count, opts...)...),
+	)
+}
+
+// server returns the versions of the i-th server of the catalog
+func (g *generator) server(i int) []upstreamv0.ServerJSON {
+	owner := pick(g.rng, owners)
+	subject := pick(g.rng, subjects)
+	// The index keeps names unique however small the vocabularies are
+	slug := fmt.Sprintf("%s%s-%d", subject, pick(g.rng, suffixes), i)
+	name := "io.github." + owner + "/" + slug
+
+	template := upstreamv0.ServerJSON{
+		Schema:      serverSchemaURL,
+		Name:        name,
+		Description: fmt.Sprintf("MCP server for %s, maintained by %s", subject, owner),
+	}
+	if g.chance(0.8) {
+		template.Repository = &model.Repository{URL: "https://github.com/" + owner + "/" + slug, Source: "github"}
+	}
+	if g.chance(0.3) {
+		template.WebsiteURL = "https://" + owner + ".example.com/" + slug
+	}
+	if g.chance(0.5) {
+		template.Title = subject + " MCP"
+	}
+
+	packages, remotes := g.drawPackages(owner, slug, subject)
+	tags := g.drawTags()
+	tools := g.drawTools()
+	stars := g.drawStars()
+	shape := g.rng.Float64()
+
+	versions := 1 + g.rng.IntN(g.opts.maxVersions)
+	published := g.opts.epoch.Add(time.Duration(g.rng.IntN(365*24)) * time.Hour)
+	major, minor := g.rng.IntN(3), g.rng.IntN(10)
+
+	servers := make([]upstreamv0.ServerJSON, 0, versions)
+	for v := range versions {
+		server := template
+		server.Version = fmt.Sprintf("%d.%d.%d", major, minor+v, g.rng.IntN(5))
+		server.Packages, server.Remotes = versionedPackages(packages, server.Version), remotes
+
+		identifier := name
+		if len(server.Packages) > 0 {
+			identifier = server.Packages[0].Identifier
+		}
+
+		server.Meta = &upstreamv0.ServerMeta{PublisherProvided: map[string]any{}}
+		switch {
+		case shape < 0.2:
+			// No metadata beyond the official timestamps
+		case shape < 0.45:
+			server.Meta.PublisherProvided[registry.ToolHiveMetaNamespace] = map[string]any{
+				identifier: map[string]any{"tags": toInterfaces(tags)},
+			}
+		default:
+			server.Meta.PublisherProvided[registry.ToolHiveMetaNamespace] = map[string]any{
+				identifier: map[string]any{
+					"tier":     pick(g.rng, tiers),
+					"status":   "Active",
+					"tags":     toInterfaces(tags),
+					"tools":    toInterfaces(tools),
+					"metadata": map[string]any{"stars": stars},
+				},
+			}
+		}
+
+		updated := published.Add(time.Duration(g.rng.IntN(30*24)) * time.Hour)
+		registry.SetTimestamps(&server, published, updated)
+		if v < versions-1 && g.chance(0.1) {
+			registry.SetLifecycleStatus(&server, registry.StatusDeprecated)
+		} else if g.chance(0.01) {
+			registry.SetLifecycleStatus(&server, registry.StatusDeleted)
+		}
+
+		servers = append(servers, server)
+		published = updated.Add(time.Duration(1+g.rng.IntN(60*24)) * time.Hour)
+	}
+	return servers
+}
+
+// drawPackages returns the packages or, for remote-only servers, the remotes of a server.
+// Package versions are left to versionedPackages.
+func (g *generator) drawPackages(owner, slug, subject string) ([]model.Package, []model.Transport) {
+	stdio := model.Transport{Type: model.TransportTypeStdio}
+	var pkg model.Package
+	switch r := g.rng.Float64(); {
+	case r < 0.45:
+		pkg = model.Package{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "@" + owner + "/" + slug,
+			RunTimeHint:  "npx",
+			Transport:    stdio,
+		}
+	case r < 0.7:
+		pkg = model.Package{
+			RegistryType: model.RegistryTypeOCI,
+			Identifier:   "ghcr.io/" + owner + "/" + slug,
+			Transport:    stdio,
+		}
+	case r < 0.9:
+		pkg = model.Package{
+			RegistryType: model.RegistryTypePyPI,
+			Identifier:   slug,
+			RunTimeHint:  "uvx",
+			Transport:    stdio,
+		}
+	default:
+		return nil, []model.Transport{{
+			Type: model.TransportTypeStreamableHTTP,
+			URL:  "https://mcp." + owner + ".example.com/" + slug,
+		}}
+	}
+
+	if g.chance(0.6) {
+		pkg.EnvironmentVariables = append(pkg.EnvironmentVariables, model.KeyValueInput{
+			Name: strings.ToUpper(subject) + "_API_KEY",
+			InputWithVariables: model.InputWithVariables{Input: model.Input{
+				Description: "API key of the " + subject + " account",
+				IsRequired:  true,
+				IsSecret:    true,
+			}},
+		})
+	}
+	if g.chance(0.3) {
+		pkg.EnvironmentVariables = append(pkg.EnvironmentVariables, model.KeyValueInput{
+			Name: "LOG_LEVEL",
+			InputWithVariables: model.InputWithVariables{Input: model.Input{
+				Description: "Log level",
+				Default:     "info",
+				Choices:     []string{"debug", "info", "warn", "error"},
+			}},
+		})
+	}
+	return []model.Package{pkg}, nil
+}
+
+// versionedPackages returns copies of packages pinned to a version, in the image tag of
+// container packages and in the version field of the others
+func versionedPackages(packages []model.Package, version string) []model.Package {
+	if packages == nil {
+		return nil
+	}
+	versioned := make([]model.Package, len(packages))
+	for i, pkg := range packages {
+		if pkg.RegistryType == model.RegistryTypeOCI {
+			pkg.Identifier += ":" + version
+		} else {
+			pkg.Version = version
+		}
+		versioned[i] = pkg
+	}
+	return versioned
+}
+
+// drawTags returns up to maxTags distinct tags, favoring the popular ones
+func (g *generator) drawTags() []string {
+	count := g.rng.IntN(maxTags + 1)
+	tags := make([]string, 0, count)
+	seen := make(map[uint64]bool, count)
+	for range count {
+		i := g.tags.Uint64()
+		if seen[i] {
+			continue
+		}
+		seen[i] = true
+		tags = append(tags, tagVocabulary[i])
+	}
+	return tags
+}
+
+// drawTools returns the tool names of a server, most servers having a handful of tools
+func (g *generator) drawTools() []string {
+	count := min(int(g.rng.ExpFloat64()*6), maxTools)
+	tools := make([]string, 0, count)
+	for j := range count {
+		tools = append(tools, fmt.Sprintf("%s_%s_%d", pick(g.rng, toolVerbs), pick(g.rng, toolObjects), j))
+	}
+	return tools
+}
+
+// drawStars returns a repository star count with a long tail
+func (g *generator) drawStars() int {
+	return int(g.rng.ExpFloat64() * g.rng.ExpFloat64() * 200)
+}
+
+// chance returns true with probability p
+func (g *generator) chance(p float64) bool {
+	return g.rng.Float64() < p
+}
+
+// pick returns a random element of values
+func pick(rng *rand.Rand, values []string) string {
+	return values[rng.IntN(len(values))]
+}
+
+// toInterfaces converts values to the []any JSON decoding produces, as expected by the metadata readers
+func toInterfaces(values []string) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
@@ -0,0 +1,120 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+func TestServersAreReproducible(t *testing.T) {
+	t.Parallel()
+
+	first, err := json.Marshal(Servers(42, 50))
+	require.NoError(t, err)
+	second, err := json.Marshal(Servers(42, 50))
+	require.NoError(t, err)
+	other, err := json.Marshal(Servers(43, 50))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(first), string(second))
+	assert.NotEqual(t, string(first), string(other))
+}
+
+func TestServers(t *testing.T) {
+	t.Parallel()
+
+	const count = 500
+	servers := Servers(7, count)
+
+	names := map[string]int{}
+	versions := map[string]bool{}
+	tagCounts := map[string]int{}
+	var withTools, withStars, remoteOnly, withoutToolHive int
+	for i := range servers {
+		server := &servers[i]
+		names[server.Name]++
+
+		key := server.Name + "@" + server.Version
+		assert.False(t, versions[key], "duplicate version %s", key)
+		versions[key] = true
+
+		assert.Regexp(t, `^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$`, server.Name)
+		assert.LessOrEqual(t, len(server.Description), 100)
+		assert.True(t, len(server.Packages) > 0 || len(server.Remotes) > 0, "%s has no packages", key)
+
+		publishedAt, ok := registry.ExtractPublishedAt(server)
+		require.True(t, ok)
+		assert.False(t, publishedAt.Before(DefaultEpoch))
+
+		for _, tag := range registry.ExtractTags(server) {
+			tagCounts[tag]++
+		}
+		if len(registry.ExtractTools(server)) > 0 {
+			withTools++
+		}
+		if _, ok := registry.ExtractStars(server); ok {
+			withStars++
+		}
+		if len(server.Packages) == 0 {
+			remoteOnly++
+		}
+		if _, ok := server.Meta.PublisherProvided[registry.ToolHiveMetaNamespace]; !ok {
+			withoutToolHive++
+		}
+	}
+
+	assert.Len(t, names, count)
+	for name, n := range names {
+		assert.LessOrEqual(t, n, defaultMaxVersions, "versions of %s", name)
+	}
+	assert.Greater(t, len(servers), count, "some servers should have several versions")
+
+	// Popular tags come first in the vocabulary
+	assert.Greater(t, tagCounts[tagVocabulary[0]], tagCounts[tagVocabulary[len(tagVocabulary)-1]])
+	assert.Positive(t, withTools)
+	assert.Positive(t, withStars)
+	assert.Positive(t, remoteOnly)
+	assert.Positive(t, withoutToolHive)
+}
+
+func TestServersOptions(t *testing.T) {
+	t.Parallel()
+
+	servers := Servers(1, 20, WithMaxVersions(1), WithEpoch(DefaultEpoch.AddDate(1, 0, 0)))
+	assert.Len(t, servers, 20)
+	for i := range servers {
+		publishedAt, ok := registry.ExtractPublishedAt(&servers[i])
+		require.True(t, ok)
+		assert.False(t, publishedAt.Before(DefaultEpoch.AddDate(1, 0, 0)))
+	}
+}
+
+func TestServersSurviveJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	servers := Servers(3, 30)
+	data, err := json.Marshal(servers)
+	require.NoError(t, err)
+	var decoded []upstreamv0.ServerJSON
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Len(t, decoded, len(servers))
+
+	for i := range servers {
+		assert.Equal(t, registry.ExtractTags(&servers[i]), registry.ExtractTags(&decoded[i]))
+		assert.Equal(t, registry.ExtractTools(&servers[i]), registry.ExtractTools(&decoded[i]))
+		assert.Equal(t, registry.ExtractLifecycleStatus(&servers[i]), registry.ExtractLifecycleStatus(&decoded[i]))
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	reg := Registry(5, 10)
+	assert.Equal(t, Servers(5, 10), reg.Data.Servers)
+	assert.Equal(t, registry.UpstreamRegistryVersion, reg.Version)
+}
@@ -0,0 +1,63 @@
+// Package golden compares generated output in tests with golden files kept under the testdata
+// directory of the package under test, so that formatting changes show up in review as diffs of
+// the golden files rather than going unnoticed.
+//
+// After a deliberate change, rewrite the golden files by running the tests with UPDATE_GOLDEN=1
+// (task test-update-golden) and review the resulting diff.
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// UpdateEnv is the environment variable that, when set to 1, rewrites golden files instead of
+// comparing them with the output
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// Path returns the path of the golden file of name, testdata/<name>.golden
+func Path(name string) string {
+	return filepath.Join("testdata", filepath.FromSlash(name)+".golden")
+}
+
+// Assert fails the test if got differs from the golden file of name, or writes got to the
+// golden file when UPDATE_GOLDEN=1
+func Assert(t testing.TB, name string, got []byte) {
+	t.Helper()
+	path := Path(name)
+
+	if os.Getenv(UpdateEnv) == "1" {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, os.WriteFile(path, got, 0600))
+		return
+	}
+
+	// #nosec G304 -- golden files are read from the testdata directory
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file, run the tests with %s=1 to create it", UpdateEnv)
+	assert.Equal(t, string(want), string(got),
+		"output differs from %s, run the tests with %s=1 to update it if the change is deliberate", path, UpdateEnv)
+}
+
+// AssertString is Assert for string output
+func AssertString(t testing.TB, name string, got string) {
+	t.Helper()
+	Assert(t, name, []byte(got))
+}
+
+// AssertJSON is Assert for the indented JSON encoding of v
+func AssertJSON(t testing.TB, name string, v any) {
+	t.Helper()
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	require.NoError(t, encoder.Encode(v))
+	Assert(t, name, buf.Bytes())
+}
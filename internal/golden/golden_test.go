@@ -0,0 +1,20 @@
+package golden
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, filepath.Join("testdata", "contextpack", "npm.md.golden"), Path("contextpack/npm.md"))
+}
+
+func TestAssertJSON(t *testing.T) {
+	t.Parallel()
+
+	AssertJSON(t, "example", map[string]string{"name": "example", "url": "https://example.com/?a=1&b=2"})
+}
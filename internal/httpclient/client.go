@@ -3,10 +3,17 @@ package httpclient
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/versions"
 )
 
 const (
@@ -16,8 +23,23 @@ const (
 	// MaxResponseSize is the maximum allowed response size (100MB)
 	MaxResponseSize = 100 * 1024 * 1024
 
-	// UserAgent is the user agent string for HTTP requests
-	UserAgent = "toolhive-registry-server/1.0"
+	// UserAgentProduct is the product token of the user agent of HTTP requests
+	UserAgentProduct = "toolhive-registry-server"
+
+	// DeploymentIDHeader is the request header identifying the deployment requests are made for
+	DeploymentIDHeader = "X-Registry-Deployment-Id"
+
+	// projectURL is advertised in the user agent so that upstream operators can find out about the server
+	projectURL = "https://github.com/stacklok/toolhive-registry-server"
+
+	// MaxRateLimitRetries is the number of times a rate-limited request is retried
+	MaxRateLimitRetries = 3
+
+	// MaxRetryAfter caps the delay honored from a Retry-After header
+	MaxRetryAfter = 60 * time.Second
+
+	// defaultRetryAfter is the delay used when a 429 response has no usable Retry-After header
+	defaultRetryAfter = time.Second
 )
 
 // Client is an interface for HTTP operations
@@ -26,37 +48,178 @@ type Client interface {
 	Get(ctx context.Context, url string) ([]byte, error)
 }
 
+// ConditionalClient is implemented by clients supporting conditional requests
+type ConditionalClient interface {
+	// GetIfNoneMatch performs an HTTP GET request conditional on the resource no longer matching
+	// the entity tag etag. If etag is empty, the request is unconditional.
+	GetIfNoneMatch(ctx context.Context, url, etag string) (*Response, error)
+}
+
+// Response is the response to a conditional request
+type Response struct {
+	// Body is the response body, empty if the resource was not modified
+	Body []byte
+	// ETag is the entity tag of the resource, if the server provided one
+	ETag string
+	// NotModified is true if the resource still matches the entity tag of the request
+	NotModified bool
+}
+
+// UserAgent returns the default user agent of HTTP requests, made of the product and version
+// of the server, e.g. "toolhive-registry-server/v1.2.0 (+https://github.com/...)"
+func UserAgent() string {
+	return fmt.Sprintf("%s/%s (+%s)", UserAgentProduct, versions.GetVersionInfo().Version, projectURL)
+}
+
+// DeploymentUserAgent returns the user agent of HTTP requests made for a deployment: the default
+// user agent with the deployment id added to its comment
+func DeploymentUserAgent(deploymentID string) string {
+	if deploymentID == "" {
+		return UserAgent()
+	}
+	return fmt.Sprintf("%s/%s (+%s; deployment=%s)",
+		UserAgentProduct, versions.GetVersionInfo().Version, projectURL, deploymentID)
+}
+
 // defaultClient is the default HTTP client implementation
 type defaultClient struct {
-	client  *http.Client
-	timeout time.Duration
+	client    *http.Client
+	timeout   time.Duration
+	userAgent string
+	headers   map[string]string
+}
+
+var _ ConditionalClient = (*defaultClient)(nil)
+
+// Option configures the default client
+type Option func(*defaultClient)
+
+// WithHeaders sends the given headers with every request. A "Host" header overrides the host
+// the requests are addressed to.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *defaultClient) {
+		c.headers = headers
+	}
+}
+
+// WithUserAgent replaces the default user agent of the requests
+func WithUserAgent(userAgent string) Option {
+	return func(c *defaultClient) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTLSConfig uses the given TLS configuration for the connections of the client
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(c *defaultClient) {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		c.client.Transport = transport
+	}
 }
 
 // NewDefaultClient creates a new default HTTP client with the specified timeout
 // If timeout is 0, uses DefaultTimeout
-func NewDefaultClient(timeout time.Duration) Client {
+func NewDefaultClient(timeout time.Duration, opts ...Option) Client {
 	if timeout == 0 {
 		timeout = DefaultTimeout
 	}
 	// TODO: Use TLS by default
-	return &defaultClient{
+	c := &defaultClient{
 		client: &http.Client{
 			Timeout: timeout,
 		},
-		timeout: timeout,
+		timeout:   timeout,
+		userAgent: UserAgent(),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-// Get performs an HTTP GET request
+// Get performs an HTTP GET request.
+// Rate-limited (429) responses are retried up to MaxRateLimitRetries times,
+// waiting for the delay requested by the Retry-After header (capped at MaxRetryAfter).
 func (c *defaultClient) Get(ctx context.Context, url string) ([]byte, error) {
+	resp, err := c.GetIfNoneMatch(ctx, url, "")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetIfNoneMatch performs an HTTP GET request with an If-None-Match header, retrying
+// rate-limited responses like Get
+func (c *defaultClient) GetIfNoneMatch(ctx context.Context, url, etag string) (*Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.get(ctx, url, etag)
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) || !httpErr.IsRateLimited() || attempt >= MaxRateLimitRetries {
+			return resp, err
+		}
+
+		delay := min(httpErr.RetryAfter, MaxRetryAfter)
+		if delay <= 0 {
+			delay = defaultRetryAfter
+		}
+		slog.Warn("Rate limited by upstream, backing off",
+			"url", url,
+			"retry_after", delay,
+			"attempt", attempt+1)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("rate limited and context done while backing off: %w", httpErr)
+		case <-timer.C:
+		}
+	}
+}
+
+// ParseRetryAfter parses a Retry-After header value, given either as a number
+// of seconds or as an HTTP date, and returns the delay relative to now.
+// It returns zero for empty, invalid, or past values.
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
+// get performs a single HTTP GET request, conditional on etag if not empty
+func (c *defaultClient) get(ctx context.Context, url, etag string) (*Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
-	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Accept", "application/json")
+	for name, value := range c.headers {
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
 
 	// Execute request
 	resp, err := c.client.Do(req)
@@ -67,9 +230,21 @@ func (c *defaultClient) Get(ctx context.Context, url string) ([]byte, error) {
 		_ = resp.Body.Close()
 	}()
 
+	if etag != "" && resp.StatusCode == http.StatusNotModified {
+		return &Response{ETag: etag, NotModified: true}, nil
+	}
+
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
-		return nil, NewHTTPError(resp.StatusCode, url, resp.Status)
+		httpErr := &HTTPError{
+			StatusCode: resp.StatusCode,
+			URL:        url,
+			Message:    resp.Status,
+		}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			httpErr.RetryAfter = ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
+		return nil, httpErr
 	}
 
 	// Check Content-Length header if available
@@ -92,5 +267,5 @@ func (c *defaultClient) Get(ctx context.Context, url string) ([]byte, error) {
 			MaxResponseSize, float64(MaxResponseSize)/(1024*1024))
 	}
 
-	return body, nil
+	return &Response{Body: body, ETag: resp.Header.Get("ETag")}, nil
 }
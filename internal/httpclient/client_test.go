@@ -2,9 +2,13 @@ package httpclient_test
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -54,7 +58,7 @@ var _ = Describe("DefaultClient", func() {
 			BeforeEach(func() {
 				mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 					// Verify headers
-					Expect(r.Header.Get("User-Agent")).To(Equal("toolhive-registry-server/1.0"))
+					Expect(r.Header.Get("User-Agent")).To(Equal(httpclient.UserAgent()))
 					Expect(r.Header.Get("Accept")).To(Equal("application/json"))
 
 					w.WriteHeader(http.StatusOK)
@@ -236,5 +240,189 @@ var _ = Describe("DefaultClient", func() {
 				Expect(data).To(HaveLen(100 * 1024 * 1024))
 			})
 		})
+
+		Context("Client options", func() {
+			It("should send the configured headers", func() {
+				mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.Header.Get("X-Org-Token")).To(Equal("org-token"))
+					Expect(r.Host).To(Equal("mirror.internal"))
+					Expect(r.Header.Get("User-Agent")).To(Equal(httpclient.UserAgent()))
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{}`))
+				}))
+				client = httpclient.NewDefaultClient(5*time.Second, httpclient.WithHeaders(map[string]string{
+					"X-Org-Token": "org-token",
+					"Host":        "mirror.internal",
+				}))
+
+				_, err := client.Get(ctx, mockServer.URL)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should send the configured user agent", func() {
+				mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.Header.Get("User-Agent")).To(Equal("acme-bridge/2.0"))
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{}`))
+				}))
+				client = httpclient.NewDefaultClient(5*time.Second, httpclient.WithUserAgent("acme-bridge/2.0"))
+
+				_, err := client.Get(ctx, mockServer.URL)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should use the configured TLS settings", func() {
+				var serverName string
+				mockServer = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					serverName = r.TLS.ServerName
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{}`))
+				}))
+				mockServer.StartTLS()
+
+				// The test server certificate is only trusted with its CA
+				client = httpclient.NewDefaultClient(5 * time.Second)
+				_, err := client.Get(ctx, mockServer.URL)
+				Expect(err).To(HaveOccurred())
+
+				roots := x509.NewCertPool()
+				roots.AddCert(mockServer.Certificate())
+				client = httpclient.NewDefaultClient(5*time.Second, httpclient.WithTLSConfig(&tls.Config{
+					RootCAs:    roots,
+					ServerName: "example.com",
+					MinVersion: tls.VersionTLS12,
+				}))
+				_, err = client.Get(ctx, mockServer.URL)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(serverName).To(Equal("example.com"))
+			})
+		})
+
+		Context("Conditional requests", func() {
+			It("should report unmodified resources", func() {
+				mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.Header.Get("If-None-Match") == `"v1"` {
+						w.WriteHeader(http.StatusNotModified)
+						return
+					}
+					w.Header().Set("ETag", `"v1"`)
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{"version":1}`))
+				}))
+				conditional, ok := httpclient.NewDefaultClient(5 * time.Second).(httpclient.ConditionalClient)
+				Expect(ok).To(BeTrue())
+
+				resp, err := conditional.GetIfNoneMatch(ctx, mockServer.URL, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.NotModified).To(BeFalse())
+				Expect(resp.ETag).To(Equal(`"v1"`))
+				Expect(string(resp.Body)).To(Equal(`{"version":1}`))
+
+				resp, err = conditional.GetIfNoneMatch(ctx, mockServer.URL, `"v1"`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.NotModified).To(BeTrue())
+				Expect(resp.Body).To(BeEmpty())
+
+				resp, err = conditional.GetIfNoneMatch(ctx, mockServer.URL, `"v0"`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.NotModified).To(BeFalse())
+				Expect(resp.ETag).To(Equal(`"v1"`))
+			})
+
+			It("should fail on not modified responses to unconditional requests", func() {
+				mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotModified)
+				}))
+				client = httpclient.NewDefaultClient(5 * time.Second)
+
+				_, err := client.Get(ctx, mockServer.URL)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("User agent", func() {
+			It("should identify the server and its version", func() {
+				Expect(httpclient.UserAgent()).To(MatchRegexp(`^toolhive-registry-server/\S+ \(\+https://\S+\)$`))
+			})
+
+			It("should add the deployment id to the comment", func() {
+				Expect(httpclient.DeploymentUserAgent("acme-prod")).To(HaveSuffix("; deployment=acme-prod)"))
+				Expect(httpclient.DeploymentUserAgent("")).To(Equal(httpclient.UserAgent()))
+			})
+		})
+
+		Context("Rate limited responses", func() {
+			BeforeEach(func() {
+				client = httpclient.NewDefaultClient(30 * time.Second)
+			})
+
+			It("should retry after the requested delay and succeed", func() {
+				var requests atomic.Int32
+				mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					if requests.Add(1) == 1 {
+						w.Header().Set("Retry-After", "1")
+						w.WriteHeader(http.StatusTooManyRequests)
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{}`))
+				}))
+
+				data, err := client.Get(ctx, mockServer.URL)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(data).To(Equal([]byte(`{}`)))
+				Expect(requests.Load()).To(Equal(int32(2)))
+			})
+
+			It("should stop backing off when the context is done", func() {
+				mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Retry-After", "30")
+					w.WriteHeader(http.StatusTooManyRequests)
+				}))
+
+				timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+				defer cancel()
+
+				_, err := client.Get(timeoutCtx, mockServer.URL)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("retry after 30s"))
+
+				var httpErr *httpclient.HTTPError
+				Expect(errors.As(err, &httpErr)).To(BeTrue())
+				Expect(httpErr.IsRateLimited()).To(BeTrue())
+				Expect(httpErr.RetryAfter).To(Equal(30 * time.Second))
+			})
+
+			It("should not retry other errors", func() {
+				var requests atomic.Int32
+				mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					requests.Add(1)
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}))
+
+				_, err := client.Get(ctx, mockServer.URL)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("retry after 1s"))
+				Expect(requests.Load()).To(Equal(int32(1)))
+			})
+		})
+	})
+
+	Describe("ParseRetryAfter", func() {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		DescribeTable("should parse Retry-After values",
+			func(value string, expected time.Duration) {
+				Expect(httpclient.ParseRetryAfter(value, now)).To(Equal(expected))
+			},
+			Entry("empty", "", time.Duration(0)),
+			Entry("seconds", "120", 2*time.Minute),
+			Entry("zero seconds", "0", time.Duration(0)),
+			Entry("negative seconds", "-5", time.Duration(0)),
+			Entry("HTTP date in the future", "Wed, 01 Jan 2025 12:00:30 GMT", 30*time.Second),
+			Entry("HTTP date in the past", "Wed, 01 Jan 2025 11:00:00 GMT", time.Duration(0)),
+			Entry("invalid", "soon", time.Duration(0)),
+		)
 	})
 })
@@ -0,0 +1,85 @@
+package httpclient
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// UpstreamTimings accumulates, by upstream host, the time the requests made while serving an
+// incoming request spent waiting for their responses. Concurrent requests are summed, so the
+// total may exceed the duration of the incoming request.
+type UpstreamTimings struct {
+	mu     sync.Mutex
+	byHost map[string]time.Duration
+}
+
+// upstreamTimingsKey is the context key of the upstream timings of an incoming request
+type upstreamTimingsKey struct{}
+
+// WithUpstreamTimings returns a context recording the time spent waiting for upstream responses
+// by requests sent through a timing transport (see NewTimingTransport) with the context
+func WithUpstreamTimings(ctx context.Context) (context.Context, *UpstreamTimings) {
+	timings := &UpstreamTimings{byHost: map[string]time.Duration{}}
+	return context.WithValue(ctx, upstreamTimingsKey{}, timings), timings
+}
+
+// ByHost returns the time spent waiting for responses by upstream host
+func (t *UpstreamTimings) ByHost() map[string]time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return maps.Clone(t.byHost)
+}
+
+// Total returns the time spent waiting for responses from all upstream hosts
+func (t *UpstreamTimings) Total() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var total time.Duration
+	for _, d := range t.byHost {
+		total += d
+	}
+	return total
+}
+
+// add records time spent waiting for a response of host
+func (t *UpstreamTimings) add(host string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.byHost[host] += d
+}
+
+// timingTransport records the time requests wait for their responses in the upstream timings
+// of their context, if any
+type timingTransport struct {
+	base http.RoundTripper
+}
+
+// NewTimingTransport returns a transport sending requests with base (http.DefaultTransport if nil),
+// and recording the time until their response headers in the upstream timings of their context
+func NewTimingTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &timingTransport{base: base}
+}
+
+// RoundTrip sends the request with the base transport
+func (t *timingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timings := UpstreamTimingsFromContext(req.Context())
+	if timings == nil {
+		return t.base.RoundTrip(req)
+	}
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	timings.add(req.URL.Host, time.Since(start))
+	return resp, err
+}
+
+// UpstreamTimingsFromContext returns the upstream timings recorded for the context, nil if none are
+func UpstreamTimingsFromContext(ctx context.Context) *UpstreamTimings {
+	timings, _ := ctx.Value(upstreamTimingsKey{}).(*UpstreamTimings)
+	return timings
+}
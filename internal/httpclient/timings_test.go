@@ -0,0 +1,45 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimingTransport(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client := &http.Client{Transport: NewTimingTransport(nil)}
+
+	get := func(ctx context.Context) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	// Requests whose context has no timings are sent as is
+	get(context.Background())
+
+	ctx, timings := WithUpstreamTimings(context.Background())
+	get(ctx)
+	get(ctx)
+
+	byHost := timings.ByHost()
+	require.Len(t, byHost, 1)
+	assert.GreaterOrEqual(t, byHost[serverURL.Host], 40*time.Millisecond)
+	assert.Equal(t, byHost[serverURL.Host], timings.Total())
+}
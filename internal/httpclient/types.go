@@ -1,19 +1,34 @@
 package httpclient
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
 
 // HTTPError represents an HTTP error
 type HTTPError struct {
 	StatusCode int
 	Message    string
 	URL        string
+
+	// RetryAfter is the delay requested by the server through the Retry-After header, if any
+	RetryAfter time.Duration
 }
 
 // Error returns the error message
 func (e *HTTPError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("HTTP %d for URL %s: %s (retry after %s)", e.StatusCode, e.URL, e.Message, e.RetryAfter)
+	}
 	return fmt.Sprintf("HTTP %d for URL %s: %s", e.StatusCode, e.URL, e.Message)
 }
 
+// IsRateLimited returns true if the server rejected the request because of rate limiting
+func (e *HTTPError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
 // NewHTTPError creates a new HTTP error
 func NewHTTPError(statusCode int, url, message string) error {
 	return &HTTPError{
@@ -0,0 +1,115 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+)
+
+var (
+	// ErrNamespaceNotAllowed is returned when an operation targets a namespace outside of the allowlist
+	ErrNamespaceNotAllowed = errors.New("namespace not allowed")
+	// ErrNoIdentity is returned when operations are impersonated and the caller identity is unknown
+	ErrNoIdentity = errors.New("caller identity unknown")
+)
+
+// Identity is the Kubernetes user and groups cluster operations are impersonated as
+type Identity struct {
+	User   string
+	Groups []string
+}
+
+// InstallerOption configures an Installer
+type InstallerOption func(*Installer)
+
+// WithAllowedNamespaces restricts the operations of the installer to the given namespaces.
+// Without namespaces, all namespaces the client has access to are allowed.
+func WithAllowedNamespaces(namespaces ...string) InstallerOption {
+	return func(i *Installer) {
+		i.namespaces = append(i.namespaces, namespaces...)
+	}
+}
+
+// WithImpersonation performs the operations of the installer as the API caller rather than as the
+// installer client, so that the cluster RBAC rules of the caller apply. The impersonated user and
+// groups are read from the given claims of the caller token (see auth.ClaimsFromContext).
+// Impersonation is only supported by installers created with NewInClusterInstaller.
+func WithImpersonation(userClaim, groupsClaim string) InstallerOption {
+	return func(i *Installer) {
+		i.userClaim = userClaim
+		i.groupsClaim = groupsClaim
+	}
+}
+
+// impersonatingClients returns the function creating clients impersonating an identity, sharing the
+// REST mapper of base so that discovery is not repeated for every caller
+func impersonatingClients(cfg *rest.Config, base client.Client) func(Identity) (client.Client, error) {
+	return func(identity Identity) (client.Client, error) {
+		impersonated := rest.CopyConfig(cfg)
+		impersonated.Impersonate = rest.ImpersonationConfig{UserName: identity.User, Groups: identity.Groups}
+		return client.New(impersonated, client.Options{Scheme: base.Scheme(), Mapper: base.RESTMapper()})
+	}
+}
+
+// authorize is the authorization layer shared by the operations of the installer. It checks the
+// namespace against the allowlist and returns the client performing the operation on behalf of the
+// caller, along with the identity it is impersonated as (empty without impersonation).
+func (i *Installer) authorize(ctx context.Context, namespace string) (client.Client, Identity, error) {
+	if len(i.namespaces) > 0 && !slices.Contains(i.namespaces, namespace) {
+		return nil, Identity{}, fmt.Errorf("%w: %q, must be one of %s",
+			ErrNamespaceNotAllowed, namespace, strings.Join(i.namespaces, ", "))
+	}
+
+	if i.userClaim == "" {
+		return i.client, Identity{}, nil
+	}
+
+	identity, err := i.callerIdentity(ctx)
+	if err != nil {
+		return nil, Identity{}, err
+	}
+	if i.impersonate == nil {
+		return nil, Identity{}, fmt.Errorf("impersonation is not supported by this installer")
+	}
+	c, err := i.impersonate(identity)
+	if err != nil {
+		return nil, Identity{}, fmt.Errorf("failed to create client impersonating %s: %w", identity.User, err)
+	}
+	return c, identity, nil
+}
+
+// callerIdentity maps the token claims of the caller to the identity to impersonate.
+// The groups claim may hold a list of groups or a single one.
+func (i *Installer) callerIdentity(ctx context.Context) (Identity, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return Identity{}, fmt.Errorf("%w: cluster operations require an authenticated caller", ErrNoIdentity)
+	}
+
+	user, _ := claims[i.userClaim].(string)
+	if user == "" {
+		return Identity{}, fmt.Errorf("%w: token has no %q claim", ErrNoIdentity, i.userClaim)
+	}
+
+	var groups []string
+	switch value := claims[i.groupsClaim].(type) {
+	case string:
+		groups = []string{value}
+	case []string:
+		groups = value
+	case []any:
+		for _, group := range value {
+			if name, ok := group.(string); ok {
+				groups = append(groups, name)
+			}
+		}
+	}
+	return Identity{User: user, Groups: groups}, nil
+}
@@ -0,0 +1,137 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/stacklok/toolhive-registry-server/internal/auth"
+)
+
+func newFakeClient(t *testing.T, funcs interceptor.Funcs) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, mcpv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(funcs).Build()
+}
+
+func TestInstallerAllowedNamespaces(t *testing.T) {
+	t.Parallel()
+
+	installer := NewInstaller(newFakeClient(t, interceptor.Funcs{}), WithAllowedNamespaces("mcp", "tools"))
+	ctx := context.Background()
+	req := InstallRequest{
+		Env:     map[string]string{"REGION": "eu"},
+		Secrets: []mcpv1alpha1.SecretRef{{Name: "weather", Key: "API_KEY"}},
+	}
+
+	req.Namespace = "kube-system"
+	_, err := installer.Install(ctx, newInstallableServer(), req)
+	require.ErrorIs(t, err, ErrNamespaceNotAllowed)
+	_, err = installer.List(ctx, "kube-system")
+	require.ErrorIs(t, err, ErrNamespaceNotAllowed)
+	_, err = installer.Uninstall(ctx, "kube-system", "weather-server")
+	require.ErrorIs(t, err, ErrNamespaceNotAllowed)
+
+	for _, namespace := range []string{"mcp", "tools"} {
+		req.Namespace = namespace
+		_, err = installer.Install(ctx, newInstallableServer(), req)
+		require.NoError(t, err)
+	}
+
+	// Listing all namespaces is restricted to the allowed ones
+	all, err := installer.List(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "mcp", all[0].Namespace)
+	assert.Equal(t, "tools", all[1].Namespace)
+}
+
+func TestInstallerImpersonation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		claims     jwt.MapClaims
+		wantErr    error
+		wantErrMsg string
+		want       Identity
+	}{
+		{
+			name:   "user and groups",
+			claims: jwt.MapClaims{"sub": "alice", "groups": []any{"platform", "sre"}},
+			want:   Identity{User: "alice", Groups: []string{"platform", "sre"}},
+		},
+		{
+			name:   "single group",
+			claims: jwt.MapClaims{"sub": "bob", "groups": "platform"},
+			want:   Identity{User: "bob", Groups: []string{"platform"}},
+		},
+		{
+			name:   "no groups",
+			claims: jwt.MapClaims{"sub": "carol"},
+			want:   Identity{User: "carol"},
+		},
+		{
+			name:       "anonymous caller",
+			wantErr:    ErrNoIdentity,
+			wantErrMsg: "require an authenticated caller",
+		},
+		{
+			name:       "missing user claim",
+			claims:     jwt.MapClaims{"groups": "platform"},
+			wantErr:    ErrNoIdentity,
+			wantErrMsg: `token has no "sub" claim`,
+		},
+		{
+			name:       "denied by the cluster",
+			claims:     jwt.MapClaims{"sub": "mallory"},
+			wantErr:    ErrForbidden,
+			wantErrMsg: "as mallory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The service account client is never used when impersonating
+			installer := NewInstaller(nil, WithImpersonation("sub", "groups"))
+			var impersonated Identity
+			installer.impersonate = func(identity Identity) (client.Client, error) {
+				impersonated = identity
+				return newFakeClient(t, interceptor.Funcs{
+					List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+						if identity.User == "mallory" {
+							return apierrors.NewForbidden(schema.GroupResource{Resource: "mcpservers"}, "", nil)
+						}
+						return c.List(ctx, list, opts...)
+					},
+				}), nil
+			}
+
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = auth.ContextWithClaims(ctx, tt.claims)
+			}
+			_, err := installer.List(ctx, "mcp")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, impersonated)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+)
+
+// Types of the lifecycle events of MCPServer resources
+const (
+	// ActivityInstalled is the creation of the resource
+	ActivityInstalled = "installed"
+	// ActivityUninstalling is the deletion of the resource, which lasts until the operator tears it down
+	ActivityUninstalling = "uninstalling"
+	// ActivityCondition is a transition of one of the status conditions reported by the operator
+	ActivityCondition = "condition"
+)
+
+// ActivityEvent is a lifecycle event of an MCPServer resource created by the Installer
+type ActivityEvent struct {
+	Time time.Time `json:"time"`
+	// Type is one of ActivityInstalled, ActivityUninstalling or ActivityCondition
+	Type string `json:"type"`
+	// Namespace and Name identify the MCPServer resource
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Server and Version identify the registry entry the resource was created from
+	Server  string `json:"server"`
+	Version string `json:"version"`
+	// Condition and Status are the type and new status of the condition of ActivityCondition events
+	Condition string `json:"condition,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// Activity returns the lifecycle events of the MCPServer resources created by the Installer that
+// happened at or after since, oldest first. An empty namespace covers all allowed namespaces.
+// Events are derived from the resources themselves, so the events of uninstalled resources are
+// no longer reported once the operator has torn them down.
+func (i *Installer) Activity(ctx context.Context, namespace string, since time.Time) ([]ActivityEvent, error) {
+	resources, err := i.list(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ActivityEvent
+	for idx := range resources {
+		resource := &resources[idx]
+		event := ActivityEvent{
+			Namespace: resource.Namespace,
+			Name:      resource.Name,
+			Server:    resource.Annotations[installedServerAnnotation],
+			Version:   resource.Annotations[installedVersionAnnotation],
+		}
+
+		if created := resource.CreationTimestamp.Time; !created.Before(since) {
+			installed := event
+			installed.Time, installed.Type = created.UTC(), ActivityInstalled
+			events = append(events, installed)
+		}
+		if deleted := resource.DeletionTimestamp; deleted != nil && !deleted.Time.Before(since) {
+			uninstalling := event
+			uninstalling.Time, uninstalling.Type = deleted.UTC(), ActivityUninstalling
+			events = append(events, uninstalling)
+		}
+		for _, condition := range resource.Status.Conditions {
+			if condition.LastTransitionTime.Time.Before(since) {
+				continue
+			}
+			transition := event
+			transition.Time, transition.Type = condition.LastTransitionTime.UTC(), ActivityCondition
+			transition.Condition, transition.Status = condition.Type, string(condition.Status)
+			transition.Reason, transition.Message = condition.Reason, condition.Message
+			events = append(events, transition)
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b ActivityEvent) int {
+		return cmp.Or(a.Time.Compare(b.Time), cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	return events, nil
+}
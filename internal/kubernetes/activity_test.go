@@ -0,0 +1,73 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestInstallerActivity(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) metav1.Time { return metav1.NewTime(since.Add(time.Duration(hours) * time.Hour)) }
+
+	weather := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "mcp",
+			Name:              "weather",
+			CreationTimestamp: at(2),
+			Labels:            map[string]string{InstalledByLabel: InstalledByValue},
+			Annotations: map[string]string{
+				installedServerAnnotation:  "io.github.example/weather",
+				installedVersionAnnotation: "1.0.0",
+			},
+		},
+		Status: mcpv1alpha1.MCPServerStatus{Conditions: []metav1.Condition{
+			{Type: "Ready", Status: metav1.ConditionTrue, Reason: "DeploymentReady", LastTransitionTime: at(3)},
+		}},
+	}
+	// Installed before since, its latest condition transition is reported
+	fetch := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "mcp",
+			Name:              "fetch",
+			CreationTimestamp: at(-48),
+			Labels:            map[string]string{InstalledByLabel: InstalledByValue},
+		},
+		Status: mcpv1alpha1.MCPServerStatus{Conditions: []metav1.Condition{
+			{Type: "Ready", Status: metav1.ConditionFalse, Reason: "ImagePullBackOff", Message: "pull failed", LastTransitionTime: at(1)},
+			{Type: "PodTemplateValid", Status: metav1.ConditionTrue, LastTransitionTime: at(-48)},
+		}},
+	}
+	unmanaged := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "mcp", Name: "hand-made", CreationTimestamp: at(1)},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, mcpv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(weather, fetch, unmanaged).Build()
+
+	events, err := NewInstaller(c).Activity(context.Background(), "", since)
+	require.NoError(t, err)
+
+	require.Len(t, events, 3)
+	assert.Equal(t, ActivityEvent{
+		Time: at(1).Time, Type: ActivityCondition, Namespace: "mcp", Name: "fetch",
+		Condition: "Ready", Status: "False", Reason: "ImagePullBackOff", Message: "pull failed",
+	}, events[0])
+	assert.Equal(t, ActivityEvent{
+		Time: at(2).Time, Type: ActivityInstalled, Namespace: "mcp", Name: "weather",
+		Server: "io.github.example/weather", Version: "1.0.0",
+	}, events[1])
+	assert.Equal(t, ActivityCondition, events[2].Type)
+	assert.Equal(t, "Ready", events[2].Condition)
+	assert.Equal(t, "True", events[2].Status)
+}
@@ -9,6 +9,7 @@ import (
 	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
 	toolhivetypes "github.com/stacklok/toolhive/pkg/registry/registry"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -33,9 +34,11 @@ type MCPServerReconciler struct {
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
-func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	// Fetch the MCPServer instance
-	registry, err := getMCPServerList(ctx, r.client, req.Namespace)
+// The registry is stored as a whole, so it is rebuilt from the resources of all watched
+// namespaces rather than only the namespace of the request.
+func (r *MCPServerReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	// Fetch the MCPServer instances of all watched namespaces
+	registry, err := getMCPServerList(ctx, r.client, metav1.NamespaceAll)
 	if err != nil {
 		slog.Error("Failed to get MCPServer list", "error", err)
 		return ctrl.Result{}, err
@@ -155,7 +158,8 @@ func (r *MCPServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-// getMCPServerList retrieves all MCPServer objects and extracts ServerJSON objects
+// getMCPServerList retrieves all MCPServer objects of a namespace, or of all namespaces
+// visible to the client when namespace is empty, and extracts ServerJSON objects
 func getMCPServerList(ctx context.Context, c client.Client, namespace string) (*toolhivetypes.UpstreamRegistry, error) {
 	listOptions := []client.ListOption{
 		client.InNamespace(namespace),
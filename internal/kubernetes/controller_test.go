@@ -1,11 +1,18 @@
 package kubernetes
 
 import (
+	"context"
 	"testing"
 
 	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+	toolhivetypes "github.com/stacklok/toolhive/pkg/registry/registry"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
@@ -348,3 +355,55 @@ func TestCheckAnnotation(t *testing.T) {
 		})
 	}
 }
+
+// recordingSyncWriter records the last registry stored
+type recordingSyncWriter struct {
+	registryName string
+	registry     *toolhivetypes.UpstreamRegistry
+}
+
+func (w *recordingSyncWriter) Store(_ context.Context, registryName string, reg *toolhivetypes.UpstreamRegistry) error {
+	w.registryName = registryName
+	w.registry = reg
+	return nil
+}
+
+func TestReconcileStoresAllNamespaces(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, mcpv1alpha1.AddToScheme(scheme))
+
+	annotations := map[string]string{
+		defaultRegistryExportAnnotation:      "true",
+		defaultRegistryDescriptionAnnotation: "Test server",
+		defaultRegistryURLAnnotation:         "http://server.example:8080/mcp",
+	}
+	newServer := func(namespace, name string) *mcpv1alpha1.MCPServer {
+		server := createTestMCPServerForPredicate(annotations)
+		server.Namespace = namespace
+		server.Name = name
+		return server
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newServer("team-a", "weather"),
+		newServer("team-b", "search"),
+		createTestMCPServerForPredicate(nil),
+	).Build()
+
+	syncWriter := &recordingSyncWriter{}
+	reconciler := &MCPServerReconciler{client: c, scheme: scheme, syncWriter: syncWriter, registryName: "cluster"}
+
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "weather"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "cluster", syncWriter.registryName)
+	require.NotNil(t, syncWriter.registry)
+	names := make([]string, 0, len(syncWriter.registry.Data.Servers))
+	for _, server := range syncWriter.registry.Data.Servers {
+		names = append(names, server.Name)
+	}
+	assert.ElementsMatch(t, []string{"com.toolhive.k8s.team-a/weather", "com.toolhive.k8s.team-b/search"}, names)
+}
@@ -1,5 +1,6 @@
 // Package kubernetes provides a Kubernetes controller that watches MCPServer
 // resources and syncs them to the registry. It includes utilities for converting
 // Kubernetes MCPServer, VirtualMCPServer, and MCPRemoteProxy resources to the MCP
-// registry ServerJSON format.
+// registry ServerJSON format, and an Installer deploying registry servers in the
+// cluster by creating MCPServer resources.
 package kubernetes
@@ -0,0 +1,414 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// InstalledByLabel is set on the MCPServer resources created by the Installer,
+	// with InstalledByValue as value, so that they can be told apart from other resources
+	InstalledByLabel = "toolhive.stacklok.dev/installed-by"
+	// InstalledByValue is the value of InstalledByLabel
+	InstalledByValue = "toolhive-registry-server"
+
+	// installedServerAnnotation and installedVersionAnnotation record the registry entry
+	// an installed MCPServer resource was created from
+	installedServerAnnotation  = "toolhive.stacklok.dev/registry-server"
+	installedVersionAnnotation = "toolhive.stacklok.dev/registry-version"
+
+	// maxResourceNameLength is the maximum length of a DNS label (RFC 1123)
+	maxResourceNameLength = 63
+)
+
+var (
+	// ErrNotInstallable is returned when a server has no container image to run
+	ErrNotInstallable = errors.New("server has no OCI package to install")
+	// ErrAlreadyInstalled is returned when the MCPServer resource to create already exists
+	ErrAlreadyInstalled = errors.New("server already installed")
+	// ErrInvalidInstallRequest is returned when the parameters of an installation are invalid
+	ErrInvalidInstallRequest = errors.New("invalid install request")
+	// ErrNotInstalled is returned when an MCPServer resource does not exist, or was not created
+	// by the Installer and is therefore not managed by it
+	ErrNotInstalled = errors.New("server not installed")
+	// ErrForbidden is returned when the cluster denies access to MCPServer resources
+	ErrForbidden = errors.New("access to MCPServer resources denied")
+
+	// invalidNameChars matches the characters not allowed in resource names
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// InstallRequest holds the user-confirmed parameters of an installation
+type InstallRequest struct {
+	// Namespace is the namespace to create the MCPServer resource in
+	Namespace string `json:"namespace"`
+	// Name is the name of the MCPServer resource, derived from the server name when empty
+	Name string `json:"name,omitempty"`
+	// Env holds the values of the environment variables of the server. Secret
+	// variables cannot be set here and must be given as Secrets instead.
+	Env map[string]string `json:"env,omitempty"`
+	// Secrets are Kubernetes secrets exposed to the server as environment variables
+	Secrets []mcpv1alpha1.SecretRef `json:"secrets,omitempty"`
+}
+
+// InstallStatus is the status of an MCPServer resource created by the Installer
+type InstallStatus struct {
+	// Namespace and Name identify the MCPServer resource
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Server and Version identify the registry entry the resource was created from
+	Server  string `json:"server"`
+	Version string `json:"version"`
+	// Image is the container image the resource runs
+	Image string `json:"image"`
+	// Phase is the phase reported by the ToolHive operator, Pending until it reconciles the resource
+	Phase mcpv1alpha1.MCPServerPhase `json:"phase"`
+	// URL is the URL the server is reachable at, once running
+	URL string `json:"url,omitempty"`
+	// Message details the phase
+	Message string `json:"message,omitempty"`
+}
+
+// Installer deploys registry servers in the cluster by creating MCPServer resources,
+// which the ToolHive operator then runs
+type Installer struct {
+	client client.Client
+	// namespaces is the allowlist of namespaces, empty to allow all
+	namespaces []string
+	// userClaim and groupsClaim are the claims mapped to the impersonated identity,
+	// userClaim is empty without impersonation
+	userClaim   string
+	groupsClaim string
+	// impersonate creates clients impersonating an identity
+	impersonate func(Identity) (client.Client, error)
+}
+
+// NewInstaller creates an Installer managing resources with the given client
+func NewInstaller(c client.Client, opts ...InstallerOption) *Installer {
+	i := &Installer{client: c}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// NewInClusterInstaller creates an Installer using the Kubernetes configuration of the
+// environment (in-cluster service account or kubeconfig)
+func NewInClusterInstaller(opts ...InstallerOption) (*Installer, error) {
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes configuration: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := mcpv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add MCPv1alpha1 scheme: %w", err)
+	}
+
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	i := NewInstaller(c, opts...)
+	i.impersonate = impersonatingClients(cfg, c)
+	return i, nil
+}
+
+// Manifest builds the MCPServer resource installing a server version with the given parameters,
+// without creating it. It runs the first OCI package of the server; required environment
+// variables without default must be given a value, and secret ones must come from Secrets.
+func (*Installer) Manifest(server *upstreamv0.ServerJSON, req InstallRequest) (*mcpv1alpha1.MCPServer, error) {
+	if req.Namespace == "" {
+		return nil, fmt.Errorf("%w: namespace is required", ErrInvalidInstallRequest)
+	}
+
+	idx := slices.IndexFunc(server.Packages, func(pkg model.Package) bool {
+		return pkg.RegistryType == model.RegistryTypeOCI
+	})
+	if idx < 0 {
+		return nil, ErrNotInstallable
+	}
+	pkg := server.Packages[idx]
+
+	name := req.Name
+	if name == "" {
+		name = resourceName(server.Name)
+	}
+	if name == "" || len(name) > maxResourceNameLength || invalidNameChars.MatchString(name) ||
+		strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") {
+		return nil, fmt.Errorf("%w: %q is not a valid resource name", ErrInvalidInstallRequest, name)
+	}
+
+	env, err := installEnv(pkg.EnvironmentVariables, req)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := pkg.Transport.Type
+	if transport == "" {
+		transport = model.TransportTypeStdio
+	}
+
+	return &mcpv1alpha1.MCPServer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: mcpv1alpha1.GroupVersion.String(),
+			Kind:       "MCPServer",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: req.Namespace,
+			Labels:    map[string]string{InstalledByLabel: InstalledByValue},
+			Annotations: map[string]string{
+				installedServerAnnotation:  server.Name,
+				installedVersionAnnotation: server.Version,
+			},
+		},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:     packageImage(pkg),
+			Transport: transport,
+			Env:       env,
+			Secrets:   req.Secrets,
+		},
+	}, nil
+}
+
+// Install creates the MCPServer resource built by Manifest and returns its status
+func (i *Installer) Install(ctx context.Context, server *upstreamv0.ServerJSON, req InstallRequest) (*InstallStatus, error) {
+	resource, err := i.Manifest(server, req)
+	if err != nil {
+		return nil, err
+	}
+
+	c, identity, err := i.authorize(ctx, resource.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Create(ctx, resource); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("%w: MCPServer %s/%s exists", ErrAlreadyInstalled, resource.Namespace, resource.Name)
+		}
+		return nil, clusterError(err, identity, "create MCPServer %s/%s", resource.Namespace, resource.Name)
+	}
+
+	return installStatus(resource), nil
+}
+
+// List returns the status of the MCPServer resources created by the Installer, sorted by namespace
+// and name. An empty namespace lists the resources of all allowed namespaces.
+func (i *Installer) List(ctx context.Context, namespace string) ([]InstallStatus, error) {
+	resources, err := i.list(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]InstallStatus, 0, len(resources))
+	for idx := range resources {
+		statuses = append(statuses, *installStatus(&resources[idx]))
+	}
+	slices.SortFunc(statuses, func(a, b InstallStatus) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	return statuses, nil
+}
+
+// list fetches the MCPServer resources created by the Installer in a namespace, or in all
+// allowed namespaces when empty
+func (i *Installer) list(ctx context.Context, namespace string) ([]mcpv1alpha1.MCPServer, error) {
+	namespaces := []string{namespace}
+	if namespace == "" && len(i.namespaces) > 0 {
+		namespaces = i.namespaces
+	}
+
+	var resources []mcpv1alpha1.MCPServer
+	for _, ns := range namespaces {
+		c, identity, err := i.authorize(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+
+		var list mcpv1alpha1.MCPServerList
+		if err := c.List(ctx, &list,
+			client.InNamespace(ns),
+			client.MatchingLabels{InstalledByLabel: InstalledByValue},
+		); err != nil {
+			return nil, clusterError(err, identity, "list MCPServers")
+		}
+		resources = append(resources, list.Items...)
+	}
+	return resources, nil
+}
+
+// Get returns the status of an MCPServer resource created by the Installer
+func (i *Installer) Get(ctx context.Context, namespace, name string) (*InstallStatus, error) {
+	c, identity, err := i.authorize(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := get(ctx, c, identity, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return installStatus(resource), nil
+}
+
+// Uninstall deletes an MCPServer resource created by the Installer and returns its last status.
+// Resources not created by the Installer are left alone.
+func (i *Installer) Uninstall(ctx context.Context, namespace, name string) (*InstallStatus, error) {
+	c, identity, err := i.authorize(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := get(ctx, c, identity, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Delete(ctx, resource); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: MCPServer %s/%s not found", ErrNotInstalled, namespace, name)
+		}
+		return nil, clusterError(err, identity, "delete MCPServer %s/%s", namespace, name)
+	}
+
+	status := installStatus(resource)
+	status.Phase = mcpv1alpha1.MCPServerPhaseTerminating
+	return status, nil
+}
+
+// get fetches an MCPServer resource, which must have been created by the Installer
+func get(ctx context.Context, c client.Client, identity Identity, namespace, name string) (*mcpv1alpha1.MCPServer, error) {
+	var resource mcpv1alpha1.MCPServer
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &resource); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: MCPServer %s/%s not found", ErrNotInstalled, namespace, name)
+		}
+		return nil, clusterError(err, identity, "get MCPServer %s/%s", namespace, name)
+	}
+	if resource.Labels[InstalledByLabel] != InstalledByValue {
+		return nil, fmt.Errorf("%w: MCPServer %s/%s was not installed from the registry", ErrNotInstalled, namespace, name)
+	}
+	return &resource, nil
+}
+
+// clusterError wraps the error of a failed cluster operation, identifying access denials
+// and the identity they were impersonated as, if any
+func clusterError(err error, identity Identity, format string, args ...any) error {
+	operation := fmt.Sprintf(format, args...)
+	if identity.User != "" {
+		operation += " as " + identity.User
+	}
+	if apierrors.IsForbidden(err) {
+		return fmt.Errorf("%w: %s: %w", ErrForbidden, operation, err)
+	}
+	return fmt.Errorf("failed to %s: %w", operation, err)
+}
+
+// installStatus returns the status of an MCPServer resource created by the Installer
+func installStatus(resource *mcpv1alpha1.MCPServer) *InstallStatus {
+	phase := resource.Status.Phase
+	if phase == "" {
+		phase = mcpv1alpha1.MCPServerPhasePending
+	}
+	return &InstallStatus{
+		Namespace: resource.Namespace,
+		Name:      resource.Name,
+		Server:    resource.Annotations[installedServerAnnotation],
+		Version:   resource.Annotations[installedVersionAnnotation],
+		Image:     resource.Spec.Image,
+		Phase:     phase,
+		URL:       resource.Status.URL,
+		Message:   resource.Status.Message,
+	}
+}
+
+// installEnv resolves the environment variables of a package from the request values and the
+// variable defaults, sorted by name
+func installEnv(variables []model.KeyValueInput, req InstallRequest) ([]mcpv1alpha1.EnvVar, error) {
+	fromSecrets := make(map[string]bool, len(req.Secrets))
+	for _, secret := range req.Secrets {
+		if secret.Name == "" || secret.Key == "" {
+			return nil, fmt.Errorf("%w: secrets need a name and a key", ErrInvalidInstallRequest)
+		}
+		target := secret.TargetEnvName
+		if target == "" {
+			target = secret.Key
+		}
+		fromSecrets[target] = true
+	}
+
+	known := make(map[string]model.KeyValueInput, len(variables))
+	for _, variable := range variables {
+		known[variable.Name] = variable
+	}
+
+	var missing []string
+	values := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		value, ok := req.Env[variable.Name]
+		switch {
+		case ok && variable.IsSecret:
+			return nil, fmt.Errorf("%w: secret variable %s must be set from a Kubernetes secret",
+				ErrInvalidInstallRequest, variable.Name)
+		case ok:
+			values[variable.Name] = value
+		case fromSecrets[variable.Name]:
+		case variable.Default != "":
+			values[variable.Name] = variable.Default
+		case variable.IsRequired:
+			missing = append(missing, variable.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing required environment variables %s",
+			ErrInvalidInstallRequest, strings.Join(missing, ", "))
+	}
+
+	for name, value := range req.Env {
+		if _, ok := known[name]; !ok {
+			values[name] = value
+		}
+	}
+
+	env := make([]mcpv1alpha1.EnvVar, 0, len(values))
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		env = append(env, mcpv1alpha1.EnvVar{Name: name, Value: values[name]})
+	}
+	return env, nil
+}
+
+// packageImage returns the image reference of an OCI package, tagged with the package version
+// when the identifier has neither tag nor digest
+func packageImage(pkg model.Package) string {
+	image := pkg.Identifier
+	hasTag := strings.LastIndex(image, ":") > strings.LastIndex(image, "/")
+	if pkg.Version != "" && !hasTag && !strings.Contains(image, "@") {
+		image += ":" + pkg.Version
+	}
+	return image
+}
+
+// resourceName derives a resource name from the last segment of a reverse-DNS server name,
+// e.g. "io.github.example/weather-server" -> "weather-server"
+func resourceName(serverName string) string {
+	name := serverName[strings.LastIndex(serverName, "/")+1:]
+	name = invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	if len(name) > maxResourceNameLength {
+		name = name[:maxResourceNameLength]
+	}
+	return strings.Trim(name, "-")
+}
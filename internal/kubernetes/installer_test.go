@@ -0,0 +1,241 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newInstallableServer() *upstreamv0.ServerJSON {
+	return &upstreamv0.ServerJSON{
+		Name:    "io.github.example/Weather_Server",
+		Version: "1.2.0",
+		Packages: []model.Package{
+			{RegistryType: model.RegistryTypeNPM, Identifier: "@example/weather"},
+			{
+				RegistryType: model.RegistryTypeOCI,
+				Identifier:   "ghcr.io/example/weather",
+				Version:      "1.2.0",
+				Transport:    model.Transport{Type: model.TransportTypeStreamableHTTP},
+				EnvironmentVariables: []model.KeyValueInput{
+					{Name: "API_KEY", InputWithVariables: model.InputWithVariables{
+						Input: model.Input{IsRequired: true, IsSecret: true},
+					}},
+					{Name: "UNITS", InputWithVariables: model.InputWithVariables{
+						Input: model.Input{IsRequired: true, Default: "metric"},
+					}},
+					{Name: "REGION", InputWithVariables: model.InputWithVariables{
+						Input: model.Input{IsRequired: true},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestInstallerManifest(t *testing.T) {
+	t.Parallel()
+
+	apiKey := mcpv1alpha1.SecretRef{Name: "weather", Key: "api-key", TargetEnvName: "API_KEY"}
+
+	tests := []struct {
+		name      string
+		server    *upstreamv0.ServerJSON
+		req       InstallRequest
+		wantName  string
+		wantImage string
+		wantEnv   []mcpv1alpha1.EnvVar
+		wantErr   error
+	}{
+		{
+			name:      "derives name and applies defaults",
+			server:    newInstallableServer(),
+			req:       InstallRequest{Namespace: "mcp", Env: map[string]string{"REGION": "eu"}, Secrets: []mcpv1alpha1.SecretRef{apiKey}},
+			wantName:  "weather-server",
+			wantImage: "ghcr.io/example/weather:1.2.0",
+			wantEnv:   []mcpv1alpha1.EnvVar{{Name: "REGION", Value: "eu"}, {Name: "UNITS", Value: "metric"}},
+		},
+		{
+			name:   "explicit name and extra variables",
+			server: newInstallableServer(),
+			req: InstallRequest{
+				Namespace: "mcp",
+				Name:      "weather",
+				Env:       map[string]string{"REGION": "us", "UNITS": "imperial", "DEBUG": "1"},
+				Secrets:   []mcpv1alpha1.SecretRef{apiKey},
+			},
+			wantName:  "weather",
+			wantImage: "ghcr.io/example/weather:1.2.0",
+			wantEnv: []mcpv1alpha1.EnvVar{
+				{Name: "DEBUG", Value: "1"}, {Name: "REGION", Value: "us"}, {Name: "UNITS", Value: "imperial"},
+			},
+		},
+		{
+			name:    "missing namespace",
+			server:  newInstallableServer(),
+			req:     InstallRequest{},
+			wantErr: ErrInvalidInstallRequest,
+		},
+		{
+			name:    "missing required variable",
+			server:  newInstallableServer(),
+			req:     InstallRequest{Namespace: "mcp", Secrets: []mcpv1alpha1.SecretRef{apiKey}},
+			wantErr: ErrInvalidInstallRequest,
+		},
+		{
+			name:    "secret variable given as plain value",
+			server:  newInstallableServer(),
+			req:     InstallRequest{Namespace: "mcp", Env: map[string]string{"REGION": "eu", "API_KEY": "s3cr3t"}},
+			wantErr: ErrInvalidInstallRequest,
+		},
+		{
+			name:    "invalid resource name",
+			server:  newInstallableServer(),
+			req:     InstallRequest{Namespace: "mcp", Name: "Weather.Server", Secrets: []mcpv1alpha1.SecretRef{apiKey}},
+			wantErr: ErrInvalidInstallRequest,
+		},
+		{
+			name: "no OCI package",
+			server: &upstreamv0.ServerJSON{
+				Name:     "io.github.example/npm-only",
+				Packages: []model.Package{{RegistryType: model.RegistryTypeNPM, Identifier: "@example/npm-only"}},
+			},
+			req:     InstallRequest{Namespace: "mcp"},
+			wantErr: ErrNotInstallable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resource, err := NewInstaller(nil).Manifest(tt.server, tt.req)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, resource.Name)
+			assert.Equal(t, tt.req.Namespace, resource.Namespace)
+			assert.Equal(t, InstalledByValue, resource.Labels[InstalledByLabel])
+			assert.Equal(t, tt.server.Name, resource.Annotations[installedServerAnnotation])
+			assert.Equal(t, tt.server.Version, resource.Annotations[installedVersionAnnotation])
+			assert.Equal(t, tt.wantImage, resource.Spec.Image)
+			assert.Equal(t, model.TransportTypeStreamableHTTP, resource.Spec.Transport)
+			assert.Equal(t, tt.wantEnv, resource.Spec.Env)
+			assert.Equal(t, tt.req.Secrets, resource.Spec.Secrets)
+		})
+	}
+}
+
+func TestInstallerInstall(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, mcpv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	installer := NewInstaller(c)
+
+	req := InstallRequest{
+		Namespace: "mcp",
+		Env:       map[string]string{"REGION": "eu"},
+		Secrets:   []mcpv1alpha1.SecretRef{{Name: "weather", Key: "API_KEY"}},
+	}
+	status, err := installer.Install(context.Background(), newInstallableServer(), req)
+	require.NoError(t, err)
+	assert.Equal(t, &InstallStatus{
+		Namespace: "mcp",
+		Name:      "weather-server",
+		Server:    "io.github.example/Weather_Server",
+		Version:   "1.2.0",
+		Image:     "ghcr.io/example/weather:1.2.0",
+		Phase:     mcpv1alpha1.MCPServerPhasePending,
+	}, status)
+
+	var created mcpv1alpha1.MCPServer
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "mcp", Name: "weather-server"}, &created))
+	assert.Equal(t, "ghcr.io/example/weather:1.2.0", created.Spec.Image)
+
+	_, err = installer.Install(context.Background(), newInstallableServer(), req)
+	require.ErrorIs(t, err, ErrAlreadyInstalled)
+}
+
+func TestPackageImage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ghcr.io/example/weather:1.0.0",
+		packageImage(model.Package{Identifier: "ghcr.io/example/weather", Version: "1.0.0"}))
+	assert.Equal(t, "ghcr.io/example/weather:2.0.0",
+		packageImage(model.Package{Identifier: "ghcr.io/example/weather:2.0.0", Version: "1.0.0"}))
+	assert.Equal(t, "localhost:5000/weather@sha256:abc",
+		packageImage(model.Package{Identifier: "localhost:5000/weather@sha256:abc", Version: "1.0.0"}))
+	assert.Equal(t, "localhost:5000/weather",
+		packageImage(model.Package{Identifier: "localhost:5000/weather"}))
+}
+
+func TestInstallerListAndUninstall(t *testing.T) {
+	t.Parallel()
+
+	installed := func(namespace, name string) *mcpv1alpha1.MCPServer {
+		return &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        name,
+				Labels:      map[string]string{InstalledByLabel: InstalledByValue},
+				Annotations: map[string]string{installedServerAnnotation: "io.github.example/" + name},
+			},
+			Spec:   mcpv1alpha1.MCPServerSpec{Image: "ghcr.io/example/" + name + ":1.0.0"},
+			Status: mcpv1alpha1.MCPServerStatus{Phase: mcpv1alpha1.MCPServerPhaseRunning, URL: "http://" + name},
+		}
+	}
+	unmanaged := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "mcp", Name: "hand-made"},
+		Spec:       mcpv1alpha1.MCPServerSpec{Image: "ghcr.io/example/hand-made:1.0.0"},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, mcpv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(installed("mcp", "weather"), installed("mcp", "fetch"), installed("tools", "github"), unmanaged).
+		Build()
+	installer := NewInstaller(c)
+	ctx := context.Background()
+
+	all, err := installer.List(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, []string{"mcp/fetch", "mcp/weather", "tools/github"},
+		[]string{all[0].Namespace + "/" + all[0].Name, all[1].Namespace + "/" + all[1].Name, all[2].Namespace + "/" + all[2].Name})
+	assert.Equal(t, mcpv1alpha1.MCPServerPhaseRunning, all[0].Phase)
+	assert.Equal(t, "http://fetch", all[0].URL)
+	assert.Equal(t, "io.github.example/fetch", all[0].Server)
+
+	inNamespace, err := installer.List(ctx, "tools")
+	require.NoError(t, err)
+	require.Len(t, inNamespace, 1)
+	assert.Equal(t, "github", inNamespace[0].Name)
+
+	_, err = installer.Uninstall(ctx, "mcp", "hand-made")
+	require.ErrorIs(t, err, ErrNotInstalled)
+	_, err = installer.Uninstall(ctx, "mcp", "missing")
+	require.ErrorIs(t, err, ErrNotInstalled)
+
+	status, err := installer.Uninstall(ctx, "mcp", "weather")
+	require.NoError(t, err)
+	assert.Equal(t, mcpv1alpha1.MCPServerPhaseTerminating, status.Phase)
+	_, err = installer.Get(ctx, "mcp", "weather")
+	require.ErrorIs(t, err, ErrNotInstalled)
+
+	remaining, err := installer.List(ctx, "mcp")
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "fetch", remaining[0].Name)
+}
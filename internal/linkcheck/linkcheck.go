@@ -0,0 +1,352 @@
+// Package linkcheck periodically checks the links of the servers of the registry and flags
+// the stale ones.
+//
+// A server is stale when its repository or website URL is broken (HTTP 404 or 410, or an
+// unknown host), when its GitHub repository is archived, or when one of its npm or PyPI
+// packages is missing from its package registry. Transient failures (timeouts, server
+// errors, rate limiting) never flag a server. Archived repositories are detected through
+// the GitHub API; when it rate limits the checks, GitHub repository URLs are only checked
+// for existence.
+package linkcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+	"github.com/stacklok/toolhive-registry-server/internal/pkgstats"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+const (
+	// DefaultInterval is the default time between two checks of all servers
+	DefaultInterval = 24 * time.Hour
+	// DefaultGitHubAPIURL is the default GitHub API
+	DefaultGitHubAPIURL = "https://api.github.com"
+
+	// initialDelay leaves time for the first sync to complete before the first check
+	initialDelay = time.Minute
+
+	defaultCheckTimeout = 10 * time.Second
+)
+
+// Result is the outcome of the check of a server
+type Result struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Health is registry.HealthOK or registry.HealthStale
+	Health string `json:"health"`
+	// Problems describe the broken links of stale servers
+	Problems  []string  `json:"problems,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// ListFunc lists the servers to check
+type ListFunc func(ctx context.Context) ([]*upstreamv0.ServerJSON, error)
+
+// Checker checks the links of servers and keeps the results of the last check.
+// It is safe for concurrent use.
+type Checker struct {
+	client         *http.Client
+	packageFetcher *pkgstats.Fetcher
+	githubAPIURL   string
+	interval       time.Duration
+	now            func() time.Time
+
+	mu        sync.RWMutex
+	results   map[string]Result
+	checkedAt time.Time
+}
+
+// Option configures a Checker
+type Option func(*Checker)
+
+// WithHTTPClient sets the HTTP client used to check URLs
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Checker) {
+		c.client = client
+	}
+}
+
+// WithPackageFetcher sets the fetcher looking up npm and PyPI packages
+func WithPackageFetcher(fetcher *pkgstats.Fetcher) Option {
+	return func(c *Checker) {
+		c.packageFetcher = fetcher
+	}
+}
+
+// WithGitHubAPIURL sets the base URL of the GitHub API, used to detect archived repositories
+func WithGitHubAPIURL(apiURL string) Option {
+	return func(c *Checker) {
+		c.githubAPIURL = strings.TrimSuffix(apiURL, "/")
+	}
+}
+
+// WithInterval sets the time between two checks of all servers
+func WithInterval(interval time.Duration) Option {
+	return func(c *Checker) {
+		c.interval = interval
+	}
+}
+
+// NewChecker creates a new link checker
+func NewChecker(opts ...Option) *Checker {
+	c := &Checker{
+		client:       &http.Client{Timeout: defaultCheckTimeout},
+		githubAPIURL: DefaultGitHubAPIURL,
+		interval:     DefaultInterval,
+		now:          time.Now,
+		results:      make(map[string]Result),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.packageFetcher == nil {
+		c.packageFetcher = pkgstats.NewFetcher()
+	}
+	return c
+}
+
+// Run checks the servers returned by list every interval until ctx is cancelled
+func (c *Checker) Run(ctx context.Context, list ListFunc) {
+	slog.Info("Link checks enabled", "interval", c.interval)
+
+	timer := time.NewTimer(initialDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			servers, err := list(ctx)
+			if err != nil {
+				slog.Warn("Failed to list servers for link checks", "error", err)
+			} else {
+				c.Check(ctx, servers)
+			}
+			timer.Reset(c.interval)
+		}
+	}
+}
+
+// Check checks the links of the given servers, once per server name, and replaces the results
+// of the previous check. The previous results are kept if ctx is cancelled during the check.
+func (c *Checker) Check(ctx context.Context, servers []*upstreamv0.ServerJSON) {
+	results := make(map[string]Result, len(servers))
+	for _, server := range servers {
+		if _, ok := results[server.Name]; ok {
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		results[server.Name] = c.checkServer(ctx, server)
+	}
+
+	stale := 0
+	for _, result := range results {
+		if result.Health == registry.HealthStale {
+			stale++
+		}
+	}
+	slog.Info("Link checks completed", "servers", len(results), "stale", stale)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = results
+	c.checkedAt = c.now().UTC()
+}
+
+// Health returns the health of a server: registry.HealthStale if the last check found broken
+// links, registry.HealthOK otherwise, including for servers not checked yet
+func (c *Checker) Health(name string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.results[name].Health == registry.HealthStale {
+		return registry.HealthStale
+	}
+	return registry.HealthOK
+}
+
+// Stale returns the stale servers found by the last check, sorted by name, and when the check completed.
+// The time is zero if no check completed yet.
+func (c *Checker) Stale() ([]Result, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	stale := make([]Result, 0)
+	for _, result := range c.results {
+		if result.Health == registry.HealthStale {
+			stale = append(stale, result)
+		}
+	}
+	slices.SortFunc(stale, func(a, b Result) int { return strings.Compare(a.Name, b.Name) })
+	return stale, c.checkedAt
+}
+
+// Annotate returns a copy of the server recording its health under registry.HealthMetaKey, if it
+// was checked. The server itself is left unmodified.
+func (c *Checker) Annotate(server upstreamv0.ServerJSON) upstreamv0.ServerJSON {
+	c.mu.RLock()
+	result, ok := c.results[server.Name]
+	c.mu.RUnlock()
+	if !ok {
+		return server
+	}
+
+	meta := upstreamv0.ServerMeta{}
+	if server.Meta != nil {
+		meta = *server.Meta
+	}
+	publisherProvided := make(map[string]interface{}, len(meta.PublisherProvided)+1)
+	for key, value := range meta.PublisherProvided {
+		publisherProvided[key] = value
+	}
+	health := map[string]interface{}{
+		"status":    result.Health,
+		"checkedAt": result.CheckedAt.Format(time.RFC3339),
+	}
+	if len(result.Problems) > 0 {
+		problems := make([]interface{}, len(result.Problems))
+		for i, problem := range result.Problems {
+			problems[i] = problem
+		}
+		health["problems"] = problems
+	}
+	publisherProvided[registry.HealthMetaKey] = health
+	meta.PublisherProvided = publisherProvided
+	server.Meta = &meta
+	return server
+}
+
+// Problems checks the links of a server on demand and returns the broken ones, without recording
+// the result
+func (c *Checker) Problems(ctx context.Context, server *upstreamv0.ServerJSON) []string {
+	return c.checkServer(ctx, server).Problems
+}
+
+// checkServer checks the repository URL, website URL and npm and PyPI packages of a server
+func (c *Checker) checkServer(ctx context.Context, server *upstreamv0.ServerJSON) Result {
+	var problems []string
+	addProblem := func(problem string) {
+		if problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	if server.Repository != nil && server.Repository.URL != "" {
+		addProblem(c.checkRepository(ctx, server.Repository.URL))
+	}
+	if server.WebsiteURL != "" {
+		addProblem(c.checkURL(ctx, "website", server.WebsiteURL))
+	}
+	for _, pkg := range server.Packages {
+		if !pkgstats.Supported(pkg.RegistryType) {
+			continue
+		}
+		_, err := c.packageFetcher.Fetch(ctx, pkg.RegistryType, pkg.Identifier)
+		if errors.Is(err, pkgstats.ErrNotFound) {
+			addProblem(fmt.Sprintf("%s package %s not found", pkg.RegistryType, pkg.Identifier))
+		} else if err != nil {
+			slog.DebugContext(ctx, "Failed to look up package", "package", pkg.Identifier, "error", err)
+		}
+	}
+
+	health := registry.HealthOK
+	if len(problems) > 0 {
+		health = registry.HealthStale
+	}
+	return Result{
+		Name:      server.Name,
+		Version:   server.Version,
+		Health:    health,
+		Problems:  problems,
+		CheckedAt: c.now().UTC(),
+	}
+}
+
+// checkRepository checks a repository URL, returning the problem found if any. GitHub
+// repositories are looked up through the GitHub API to detect archived repositories.
+func (c *Checker) checkRepository(ctx context.Context, repositoryURL string) string {
+	owner, repo, ok := registry.GitHubRepository(repositoryURL)
+	if !ok {
+		return c.checkURL(ctx, "repository", repositoryURL)
+	}
+
+	apiURL := fmt.Sprintf("%s/repos/%s/%s", c.githubAPIURL, url.PathEscape(owner), url.PathEscape(repo))
+	resp, err := c.do(ctx, http.MethodGet, apiURL)
+	if err != nil {
+		slog.DebugContext(ctx, "Failed to look up GitHub repository", "url", repositoryURL, "error", err)
+		return ""
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var repository struct {
+			Archived bool `json:"archived"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&repository); err != nil {
+			slog.DebugContext(ctx, "Invalid GitHub repository", "url", repositoryURL, "error", err)
+			return ""
+		}
+		if repository.Archived {
+			return fmt.Sprintf("repository %s is archived", repositoryURL)
+		}
+		return ""
+	case http.StatusNotFound:
+		return fmt.Sprintf("repository %s not found", repositoryURL)
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		// Rate limited: only check that the repository exists
+		return c.checkURL(ctx, "repository", repositoryURL)
+	default:
+		slog.DebugContext(ctx, "Unexpected GitHub API response", "url", repositoryURL, "status", resp.StatusCode)
+		return ""
+	}
+}
+
+// checkURL checks that a URL is not broken, returning the problem found if any. The URL is
+// requested with HEAD, or with GET if HEAD is not allowed.
+func (c *Checker) checkURL(ctx context.Context, kind, rawURL string) string {
+	resp, err := c.do(ctx, http.MethodHead, rawURL)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp.Body.Close()
+		resp, err = c.do(ctx, http.MethodGet, rawURL)
+	}
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return fmt.Sprintf("%s URL %s has unknown host", kind, rawURL)
+		}
+		slog.DebugContext(ctx, "Failed to check URL", "url", rawURL, "error", err)
+		return ""
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return fmt.Sprintf("%s URL %s returns HTTP %d", kind, rawURL, resp.StatusCode)
+	}
+	return ""
+}
+
+// do sends a request without body
+func (c *Checker) do(ctx context.Context, method, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", httpclient.UserAgent())
+	return c.client.Do(req)
+}
@@ -0,0 +1,242 @@
+package linkcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/pkgstats"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+// newTestChecker returns a checker whose GitHub API and npm registry are served by a test server,
+// along with the URL of the test server, which also serves /ok, /gone and /no-head pages
+func newTestChecker(t *testing.T) (*Checker, string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok", "/github/repos/acme/active":
+			_, _ = w.Write([]byte(`{"archived":false}`))
+		case "/github/repos/acme/archived":
+			_, _ = w.Write([]byte(`{"archived":true}`))
+		case "/github/repos/acme/limited":
+			w.WriteHeader(http.StatusForbidden)
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/flaky":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/npm/example-server/latest":
+			_, _ = w.Write([]byte(`{"version":"1.0.0"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	checker := NewChecker(
+		WithGitHubAPIURL(server.URL+"/github"),
+		WithPackageFetcher(pkgstats.NewFetcher(pkgstats.WithNPMURLs(server.URL+"/npm", server.URL+"/npm-downloads"))),
+	)
+	return checker, server.URL
+}
+
+// withLinks sets the repository and website URLs of a server
+func withLinks(repositoryURL, websiteURL string) registry.ServerOption {
+	return func(server *upstreamv0.ServerJSON) {
+		if repositoryURL != "" {
+			server.Repository = &model.Repository{URL: repositoryURL, Source: "github"}
+		}
+		server.WebsiteURL = websiteURL
+	}
+}
+
+// withNPMPackage adds an npm package to a server
+func withNPMPackage(identifier string) registry.ServerOption {
+	return func(server *upstreamv0.ServerJSON) {
+		server.Packages = append(server.Packages, model.Package{RegistryType: "npm", Identifier: identifier})
+	}
+}
+
+func TestCheckerCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		server       func(baseURL string) upstreamv0.ServerJSON
+		wantHealth   string
+		wantProblems []string
+	}{
+		{
+			name: "active repository and working links",
+			server: func(baseURL string) upstreamv0.ServerJSON {
+				return registry.NewTestServer("io.test/active",
+					withLinks("https://github.com/acme/active", baseURL+"/ok"), withNPMPackage("example-server"))
+			},
+			wantHealth: registry.HealthOK,
+		},
+		{
+			name: "archived repository",
+			server: func(string) upstreamv0.ServerJSON {
+				return registry.NewTestServer("io.test/archived", withLinks("https://github.com/acme/archived.git", ""))
+			},
+			wantHealth:   registry.HealthStale,
+			wantProblems: []string{"repository https://github.com/acme/archived.git is archived"},
+		},
+		{
+			name: "missing repository",
+			server: func(string) upstreamv0.ServerJSON {
+				return registry.NewTestServer("io.test/missing", withLinks("https://github.com/acme/missing", ""))
+			},
+			wantHealth:   registry.HealthStale,
+			wantProblems: []string{"repository https://github.com/acme/missing not found"},
+		},
+		{
+			name: "repository on another host",
+			server: func(baseURL string) upstreamv0.ServerJSON {
+				return registry.NewTestServer("io.test/gitlab", withLinks(baseURL+"/gone", ""))
+			},
+			wantHealth: registry.HealthStale,
+		},
+		{
+			name: "broken website and missing package",
+			server: func(baseURL string) upstreamv0.ServerJSON {
+				return registry.NewTestServer("io.test/broken", withLinks("", baseURL+"/missing"), withNPMPackage("left-pad-mcp"))
+			},
+			wantHealth: registry.HealthStale,
+		},
+		{
+			name: "website without HEAD support",
+			server: func(baseURL string) upstreamv0.ServerJSON {
+				return registry.NewTestServer("io.test/no-head", withLinks("", baseURL+"/no-head"))
+			},
+			wantHealth: registry.HealthOK,
+		},
+		{
+			name: "transient failures",
+			server: func(baseURL string) upstreamv0.ServerJSON {
+				return registry.NewTestServer("io.test/flaky", withLinks("", baseURL+"/flaky"))
+			},
+			wantHealth: registry.HealthOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			checker, baseURL := newTestChecker(t)
+			server := tt.server(baseURL)
+
+			checker.Check(context.Background(), []*upstreamv0.ServerJSON{&server})
+
+			assert.Equal(t, tt.wantHealth, checker.Health(server.Name))
+			stale, checkedAt := checker.Stale()
+			assert.False(t, checkedAt.IsZero())
+			if tt.wantHealth == registry.HealthOK {
+				assert.Empty(t, stale)
+				return
+			}
+			require.Len(t, stale, 1)
+			assert.Equal(t, server.Name, stale[0].Name)
+			assert.NotEmpty(t, stale[0].Problems)
+			if tt.wantProblems != nil {
+				assert.Equal(t, tt.wantProblems, stale[0].Problems)
+			}
+		})
+	}
+}
+
+func TestCheckerRateLimitedGitHub(t *testing.T) {
+	t.Parallel()
+	checker, baseURL := newTestChecker(t)
+	// The GitHub API rate limits the lookup, so the repository URL itself is requested
+	server := registry.NewTestServer("io.test/limited", withLinks("https://github.com/acme/limited", ""))
+	checker.client = &http.Client{Transport: rewriteHost(baseURL)}
+
+	checker.Check(context.Background(), []*upstreamv0.ServerJSON{&server})
+
+	stale, _ := checker.Stale()
+	require.Len(t, stale, 1)
+	assert.Equal(t, []string{"repository URL https://github.com/acme/limited returns HTTP 404"}, stale[0].Problems)
+}
+
+// rewriteHost sends requests for github.com to the test server
+func rewriteHost(baseURL string) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host == "github.com" {
+			target := baseURL + req.URL.Path
+			rewritten, err := http.NewRequestWithContext(req.Context(), req.Method, target, nil)
+			if err != nil {
+				return nil, err
+			}
+			req = rewritten
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCheckerAnnotate(t *testing.T) {
+	t.Parallel()
+	checker, _ := newTestChecker(t)
+	checker.now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	archived := registry.NewTestServer("io.test/archived",
+		withLinks("https://github.com/acme/archived", ""),
+		registry.WithMetadata("io.example/custom", "value"),
+	)
+	unchecked := registry.NewTestServer("io.test/unchecked")
+	checker.Check(context.Background(), []*upstreamv0.ServerJSON{&archived})
+
+	annotated := checker.Annotate(archived)
+	assert.Equal(t, map[string]interface{}{
+		"status":    registry.HealthStale,
+		"checkedAt": "2026-01-01T00:00:00Z",
+		"problems":  []interface{}{"repository https://github.com/acme/archived is archived"},
+	}, annotated.Meta.PublisherProvided[registry.HealthMetaKey])
+	assert.Equal(t, "value", annotated.Meta.PublisherProvided["io.example/custom"])
+	assert.NotContains(t, archived.Meta.PublisherProvided, registry.HealthMetaKey, "the server is not modified")
+
+	assert.Equal(t, unchecked, checker.Annotate(unchecked))
+	assert.Equal(t, registry.HealthOK, checker.Health(unchecked.Name))
+}
+
+func TestCheckerCheckCancelled(t *testing.T) {
+	t.Parallel()
+	checker, _ := newTestChecker(t)
+	archived := registry.NewTestServer("io.test/archived", withLinks("https://github.com/acme/archived", ""))
+	checker.Check(context.Background(), []*upstreamv0.ServerJSON{&archived})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	checker.Check(ctx, []*upstreamv0.ServerJSON{&archived})
+
+	// The results of the previous check are kept
+	assert.Equal(t, registry.HealthStale, checker.Health(archived.Name))
+}
+
+func TestCheckerProblems(t *testing.T) {
+	t.Parallel()
+	checker, _ := newTestChecker(t)
+	missing := registry.NewTestServer("io.test/missing", withLinks("https://github.com/acme/missing", ""))
+
+	assert.Equal(t, []string{"repository https://github.com/acme/missing not found"},
+		checker.Problems(context.Background(), &missing))
+	// On-demand checks are not recorded
+	assert.Equal(t, registry.HealthOK, checker.Health(missing.Name))
+}
@@ -0,0 +1,322 @@
+package offline
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Mirrors are the internal artifact stores packages are mirrored to.
+// An empty mirror leaves the packages of that type unmirrored.
+type Mirrors struct {
+	// OCI is the internal container registry host, with an optional path prefix
+	// (e.g. "registry.internal:5000/mcp")
+	OCI string
+	// NPM is the URL of the internal npm registry
+	NPM string
+	// PyPI is the upload URL of the internal Python package repository
+	PyPI string
+	// NuGet is the push URL of the internal NuGet feed
+	NuGet string
+}
+
+// Validate checks that the configured mirrors are well formed
+func (m Mirrors) Validate() error {
+	if strings.Contains(m.OCI, "://") {
+		return fmt.Errorf("OCI mirror must be a registry host without scheme: %s", m.OCI)
+	}
+	if err := validateMirrorURL("npm", m.NPM); err != nil {
+		return err
+	}
+	if err := validateMirrorURL("PyPI", m.PyPI); err != nil {
+		return err
+	}
+	return validateMirrorURL("NuGet", m.NuGet)
+}
+
+// validateMirrorURL checks that an optional mirror URL is absolute
+func validateMirrorURL(name, mirror string) error {
+	if mirror == "" {
+		return nil
+	}
+	parsed, err := url.Parse(mirror)
+	if err != nil || !parsed.IsAbs() || parsed.Host == "" {
+		return fmt.Errorf("%s mirror must be an absolute URL: %s", name, mirror)
+	}
+	return nil
+}
+
+// Artifact describes how to mirror a single package of a server
+type Artifact struct {
+	RegistryType string `json:"registryType"`
+	// Source is the original package reference
+	Source string `json:"source"`
+	// Mirror is the package reference in the internal artifact store, if mirrored
+	Mirror string `json:"mirror,omitempty"`
+	// Fetch are the commands to run on a host with network access
+	Fetch []string `json:"fetch,omitempty"`
+	// Publish are the commands to run in the disconnected environment
+	Publish []string `json:"publish,omitempty"`
+	// Note explains what is left to do for artifacts that are not fully mirrored
+	Note string `json:"note,omitempty"`
+}
+
+// Bundle is an offline install bundle for a server version
+type Bundle struct {
+	// Server is the server entry rewritten to point at the internal mirrors
+	Server    upstreamv0.ServerJSON `json:"server"`
+	Artifacts []Artifact            `json:"artifacts"`
+}
+
+// NewBundle builds the offline install bundle of a server version for the given mirrors
+func NewBundle(server *upstreamv0.ServerJSON, mirrors Mirrors) *Bundle {
+	bundle := &Bundle{
+		Server:    *server,
+		Artifacts: make([]Artifact, 0, len(server.Packages)+len(server.Remotes)),
+	}
+	bundle.Server.Packages = make([]model.Package, len(server.Packages))
+	copy(bundle.Server.Packages, server.Packages)
+
+	for i := range bundle.Server.Packages {
+		pkg := &bundle.Server.Packages[i]
+		switch pkg.RegistryType {
+		case model.RegistryTypeOCI:
+			bundle.Artifacts = append(bundle.Artifacts, ociArtifact(pkg, mirrors.OCI))
+		case model.RegistryTypeNPM:
+			bundle.Artifacts = append(bundle.Artifacts, npmArtifact(pkg, mirrors.NPM))
+		case model.RegistryTypePyPI:
+			bundle.Artifacts = append(bundle.Artifacts, pypiArtifact(pkg, mirrors.PyPI))
+		case model.RegistryTypeNuGet:
+			bundle.Artifacts = append(bundle.Artifacts, nugetArtifact(pkg, mirrors.NuGet))
+		default:
+			bundle.Artifacts = append(bundle.Artifacts, fileArtifact(pkg))
+		}
+	}
+
+	for _, remote := range server.Remotes {
+		bundle.Artifacts = append(bundle.Artifacts, Artifact{
+			RegistryType: "remote",
+			Source:       remote.URL,
+			Note:         "remote servers cannot be mirrored and require network access to " + remote.URL,
+		})
+	}
+
+	return bundle
+}
+
+// Script renders the bundle as a POSIX shell script, split into a fetch part to run on
+// a host with network access and a publish part to run in the disconnected environment
+func (b *Bundle) Script() string {
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&sb, "# Offline install bundle for %s %s\n", b.Server.Name, b.Server.Version)
+	sb.WriteString("set -eu\n\n")
+	sb.WriteString("fetch() {\n")
+	for _, artifact := range b.Artifacts {
+		for _, command := range artifact.Fetch {
+			sb.WriteString("  " + command + "\n")
+		}
+	}
+	sb.WriteString("  :\n}\n\n")
+	sb.WriteString("publish() {\n")
+	for _, artifact := range b.Artifacts {
+		for _, command := range artifact.Publish {
+			sb.WriteString("  " + command + "\n")
+		}
+	}
+	sb.WriteString("  :\n}\n\n")
+	for _, artifact := range b.Artifacts {
+		if artifact.Note != "" {
+			fmt.Fprintf(&sb, "# %s %s: %s\n", artifact.RegistryType, artifact.Source, artifact.Note)
+		}
+	}
+	sb.WriteString(`case "${1:-}" in
+  fetch) fetch ;;
+  publish) publish ;;
+  *) echo "usage: $0 fetch|publish" >&2; exit 1 ;;
+esac
+`)
+	return sb.String()
+}
+
+// ociArtifact mirrors a container image with docker save/load and rewrites its reference
+func ociArtifact(pkg *model.Package, mirror string) Artifact {
+	artifact := Artifact{RegistryType: pkg.RegistryType, Source: pkg.Identifier}
+	if mirror == "" {
+		artifact.Note = "no OCI mirror configured"
+		return artifact
+	}
+
+	repository, tag := splitImageReference(pkg.Identifier)
+	if tag == "" {
+		tag = "latest"
+	}
+	target := strings.TrimSuffix(mirror, "/") + "/" + repository + ":" + tag
+	archive := "oci/" + fileName(pkg.Identifier) + ".tar"
+
+	artifact.Mirror = target
+	artifact.Fetch = []string{
+		"mkdir -p oci",
+		"docker pull " + quote(pkg.Identifier),
+		"docker save -o " + quote(archive) + " " + quote(pkg.Identifier),
+	}
+	artifact.Publish = []string{
+		"docker load -i " + quote(archive),
+		"docker tag " + quote(pkg.Identifier) + " " + quote(target),
+		"docker push " + quote(target),
+	}
+	pkg.Identifier = target
+	return artifact
+}
+
+// npmArtifact mirrors an npm package with npm pack/publish and rewrites its registry
+func npmArtifact(pkg *model.Package, mirror string) Artifact {
+	spec := pkg.Identifier
+	if pkg.Version != "" {
+		spec += "@" + pkg.Version
+	}
+	artifact := Artifact{RegistryType: pkg.RegistryType, Source: spec}
+	if mirror == "" {
+		artifact.Note = "no npm mirror configured"
+		return artifact
+	}
+
+	// npm pack names scoped tarballs "<scope>-<name>-<version>.tgz"
+	tarball := strings.ReplaceAll(strings.TrimPrefix(pkg.Identifier, "@"), "/", "-")
+	if pkg.Version != "" {
+		tarball += "-" + pkg.Version
+	}
+	tarball += ".tgz"
+
+	artifact.Mirror = mirror
+	artifact.Fetch = []string{
+		"mkdir -p npm",
+		"npm pack --pack-destination npm " + quote(spec),
+	}
+	artifact.Publish = []string{
+		"npm publish " + quote("npm/"+tarball) + " --registry " + quote(mirror),
+	}
+	pkg.RegistryBaseURL = mirror
+	return artifact
+}
+
+// pypiArtifact mirrors a Python package with pip download/twine upload and rewrites its registry
+func pypiArtifact(pkg *model.Package, mirror string) Artifact {
+	spec := pkg.Identifier
+	if pkg.Version != "" {
+		spec += "==" + pkg.Version
+	}
+	artifact := Artifact{RegistryType: pkg.RegistryType, Source: spec}
+	if mirror == "" {
+		artifact.Note = "no PyPI mirror configured"
+		return artifact
+	}
+
+	dir := "pypi/" + fileName(pkg.Identifier)
+
+	artifact.Mirror = mirror
+	artifact.Fetch = []string{
+		"pip download --no-deps -d " + quote(dir) + " " + quote(spec),
+	}
+	artifact.Publish = []string{
+		"twine upload --repository-url " + quote(mirror) + " " + quote(dir) + "/*",
+	}
+	pkg.RegistryBaseURL = mirror
+	return artifact
+}
+
+// nugetArtifact mirrors a NuGet package by downloading it from nuget.org and pushing it with
+// dotnet nuget push, and rewrites its registry
+func nugetArtifact(pkg *model.Package, mirror string) Artifact {
+	spec := pkg.Identifier
+	if pkg.Version != "" {
+		spec += "@" + pkg.Version
+	}
+	artifact := Artifact{RegistryType: pkg.RegistryType, Source: spec}
+	if mirror == "" {
+		artifact.Note = "no NuGet mirror configured"
+		return artifact
+	}
+	if pkg.Version == "" {
+		artifact.Note = "mirroring NuGet packages without version is not supported"
+		return artifact
+	}
+
+	// The flat container API serves packages under their lowercased ID and version
+	id, version := strings.ToLower(pkg.Identifier), strings.ToLower(pkg.Version)
+	nupkg := "nuget/" + fileName(id+"."+version) + ".nupkg"
+	source := nugetFlatContainerURL + "/" + url.PathEscape(id) + "/" + url.PathEscape(version) + "/" +
+		url.PathEscape(id+"."+version) + ".nupkg"
+
+	artifact.Mirror = mirror
+	artifact.Fetch = []string{
+		"mkdir -p nuget",
+		"curl -fsSL -o " + quote(nupkg) + " " + quote(source),
+	}
+	artifact.Publish = []string{
+		"dotnet nuget push " + quote(nupkg) + " --source " + quote(mirror),
+	}
+	pkg.RegistryBaseURL = mirror
+	return artifact
+}
+
+// nugetFlatContainerURL is the base URL of the nuget.org package content API
+const nugetFlatContainerURL = "https://api.nuget.org/v3-flatcontainer"
+
+// fileArtifact downloads packages referenced by URL, verifying their hash when known.
+// The downloaded files have to be hosted manually, so the package is not rewritten.
+func fileArtifact(pkg *model.Package) Artifact {
+	artifact := Artifact{RegistryType: pkg.RegistryType, Source: pkg.Identifier}
+	parsed, err := url.Parse(pkg.Identifier)
+	if err != nil || !parsed.IsAbs() {
+		artifact.Note = "mirroring " + pkg.RegistryType + " packages is not supported"
+		return artifact
+	}
+
+	file := "files/" + fileName(pkg.Identifier)
+	artifact.Fetch = []string{
+		"mkdir -p files",
+		"curl -fsSL -o " + quote(file) + " " + quote(pkg.Identifier),
+	}
+	if pkg.FileSHA256 != "" {
+		artifact.Fetch = append(artifact.Fetch,
+			"echo "+quote(pkg.FileSHA256+"  "+file)+" | sha256sum -c -")
+	}
+	artifact.Note = "host " + file + " on an internal server and update the package identifier"
+	return artifact
+}
+
+// splitImageReference splits an image reference into its repository path, without
+// registry host and digest, and its tag
+func splitImageReference(reference string) (repository, tag string) {
+	repository, _, _ = strings.Cut(reference, "@")
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository, tag = repository[:i], repository[i+1:]
+	}
+	if host, path, ok := strings.Cut(repository, "/"); ok &&
+		(strings.ContainsAny(host, ".:") || host == "localhost") {
+		repository = path
+	}
+	return repository, tag
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// fileName turns a package reference into a safe file name
+func fileName(reference string) string {
+	return strings.Trim(unsafeFileChars.ReplaceAllString(reference, "_"), "_")
+}
+
+var safeShellWord = regexp.MustCompile(`^[A-Za-z0-9@%+=:,./_-]+$`)
+
+// quote quotes a word for POSIX shells unless it only contains safe characters
+func quote(word string) string {
+	if safeShellWord.MatchString(word) {
+		return word
+	}
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
@@ -0,0 +1,153 @@
+package offline
+
+import (
+	"strings"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer() *upstreamv0.ServerJSON {
+	return &upstreamv0.ServerJSON{
+		Name:    "io.test/server",
+		Version: "1.0.0",
+		Packages: []model.Package{
+			{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/test/server:1.0.0"},
+			{RegistryType: model.RegistryTypeNPM, Identifier: "@test/server", Version: "1.0.0"},
+			{RegistryType: model.RegistryTypePyPI, Identifier: "test-server", Version: "1.0.0"},
+			{RegistryType: model.RegistryTypeNuGet, Identifier: "Test.Server", Version: "1.0.0-Beta"},
+			{
+				RegistryType: model.RegistryTypeMCPB,
+				Identifier:   "https://example.com/server.mcpb",
+				FileSHA256:   strings.Repeat("a", 64),
+			},
+		},
+		Remotes: []model.Transport{{Type: "streamable-http", URL: "https://mcp.example.com"}},
+	}
+}
+
+func TestNewBundle(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	bundle := NewBundle(server, Mirrors{
+		OCI:   "registry.internal:5000/mcp/",
+		NPM:   "https://npm.internal",
+		PyPI:  "https://pypi.internal/simple",
+		NuGet: "https://nuget.internal/v3/index.json",
+	})
+
+	require.Len(t, bundle.Artifacts, 6)
+
+	oci := bundle.Artifacts[0]
+	assert.Equal(t, "registry.internal:5000/mcp/test/server:1.0.0", oci.Mirror)
+	assert.Contains(t, oci.Fetch, "docker save -o oci/ghcr.io_test_server_1.0.0.tar ghcr.io/test/server:1.0.0")
+	assert.Contains(t, oci.Publish, "docker push registry.internal:5000/mcp/test/server:1.0.0")
+
+	npm := bundle.Artifacts[1]
+	assert.Equal(t, "@test/server@1.0.0", npm.Source)
+	assert.Contains(t, npm.Publish, "npm publish npm/test-server-1.0.0.tgz --registry https://npm.internal")
+
+	pypi := bundle.Artifacts[2]
+	assert.Contains(t, pypi.Fetch, "pip download --no-deps -d pypi/test-server test-server==1.0.0")
+
+	nuget := bundle.Artifacts[3]
+	assert.Equal(t, "Test.Server@1.0.0-Beta", nuget.Source)
+	assert.Contains(t, nuget.Fetch,
+		"curl -fsSL -o nuget/test.server.1.0.0-beta.nupkg "+
+			"https://api.nuget.org/v3-flatcontainer/test.server/1.0.0-beta/test.server.1.0.0-beta.nupkg")
+	assert.Contains(t, nuget.Publish,
+		"dotnet nuget push nuget/test.server.1.0.0-beta.nupkg --source https://nuget.internal/v3/index.json")
+
+	file := bundle.Artifacts[4]
+	assert.Empty(t, file.Mirror)
+	assert.Contains(t, file.Fetch, "echo '"+strings.Repeat("a", 64)+"  files/https_example.com_server.mcpb' | sha256sum -c -")
+	assert.NotEmpty(t, file.Note)
+
+	remote := bundle.Artifacts[5]
+	assert.Equal(t, "remote", remote.RegistryType)
+	assert.Empty(t, remote.Fetch)
+	assert.NotEmpty(t, remote.Note)
+
+	// The bundle server points at the mirrors, the original server is untouched
+	assert.Equal(t, "registry.internal:5000/mcp/test/server:1.0.0", bundle.Server.Packages[0].Identifier)
+	assert.Equal(t, "https://npm.internal", bundle.Server.Packages[1].RegistryBaseURL)
+	assert.Equal(t, "https://pypi.internal/simple", bundle.Server.Packages[2].RegistryBaseURL)
+	assert.Equal(t, "https://nuget.internal/v3/index.json", bundle.Server.Packages[3].RegistryBaseURL)
+	assert.Equal(t, "https://example.com/server.mcpb", bundle.Server.Packages[4].Identifier)
+	assert.Equal(t, "ghcr.io/test/server:1.0.0", server.Packages[0].Identifier)
+	assert.Empty(t, server.Packages[1].RegistryBaseURL)
+}
+
+func TestNewBundle_WithoutMirrors(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	bundle := NewBundle(server, Mirrors{})
+
+	for _, artifact := range bundle.Artifacts[:4] {
+		assert.Empty(t, artifact.Mirror)
+		assert.Empty(t, artifact.Fetch)
+		assert.NotEmpty(t, artifact.Note)
+	}
+	assert.Equal(t, server.Packages, bundle.Server.Packages)
+}
+
+func TestSplitImageReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		reference      string
+		wantRepository string
+		wantTag        string
+	}{
+		{reference: "ghcr.io/test/server:1.0.0", wantRepository: "test/server", wantTag: "1.0.0"},
+		{reference: "localhost:5000/server", wantRepository: "server"},
+		{reference: "test/server@sha256:abc", wantRepository: "test/server"},
+		{reference: "server:latest", wantRepository: "server", wantTag: "latest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			t.Parallel()
+			repository, tag := splitImageReference(tt.reference)
+			assert.Equal(t, tt.wantRepository, repository)
+			assert.Equal(t, tt.wantTag, tag)
+		})
+	}
+}
+
+func TestScript(t *testing.T) {
+	t.Parallel()
+
+	bundle := NewBundle(newTestServer(), Mirrors{OCI: "registry.internal"})
+	script := bundle.Script()
+
+	assert.True(t, strings.HasPrefix(script, "#!/bin/sh\n"))
+	assert.Contains(t, script, "fetch() {\n  mkdir -p oci\n  docker pull ghcr.io/test/server:1.0.0\n")
+	assert.Contains(t, script, "publish() {\n  docker load -i oci/ghcr.io_test_server_1.0.0.tar\n")
+	assert.Contains(t, script, "# npm @test/server@1.0.0: no npm mirror configured\n")
+	assert.Contains(t, script, `case "${1:-}" in`)
+}
+
+func TestQuote(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ghcr.io/test/server:1.0.0", quote("ghcr.io/test/server:1.0.0"))
+	assert.Equal(t, "'a b'", quote("a b"))
+	assert.Equal(t, `'it'\''s'`, quote("it's"))
+}
+
+func TestMirrorsValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Mirrors{}.Validate())
+	require.NoError(t, Mirrors{OCI: "registry.internal", NPM: "https://npm.internal", PyPI: "https://pypi.internal"}.Validate())
+	require.Error(t, Mirrors{OCI: "https://registry.internal"}.Validate())
+	require.Error(t, Mirrors{NPM: "npm.internal"}.Validate())
+	require.Error(t, Mirrors{PyPI: "/simple"}.Validate())
+	require.Error(t, Mirrors{NuGet: "nuget.internal"}.Validate())
+}
@@ -0,0 +1,17 @@
+// Package offline builds install bundles for disconnected (air-gapped) environments.
+//
+// A bundle describes, for one server version, how to mirror each of its
+// packages into internal artifact stores and how the server entry looks once
+// rewritten to point at those mirrors:
+//
+//   - OCI images are pulled, saved with docker save, then loaded, retagged and
+//     pushed to the internal container registry
+//   - npm packages are fetched with npm pack and published to the internal npm registry
+//   - PyPI packages are fetched with pip download and uploaded with twine
+//   - NuGet packages are downloaded from nuget.org and pushed with dotnet nuget push
+//   - Other packages (e.g. MCPB files) are downloaded and verified against
+//     their SHA-256 hash, but have to be hosted manually
+//
+// Packages whose mirror is not configured, and remote servers, are listed as
+// not mirrored so that operators can tell what still requires network access.
+package offline
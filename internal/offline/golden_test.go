@@ -0,0 +1,38 @@
+package offline
+
+import (
+	"testing"
+
+	"github.com/stacklok/toolhive-registry-server/internal/golden"
+)
+
+// TestBundleGolden renders the install bundle of a server published for every runtime, so that
+// changes to the install commands are reviewed in the golden files under testdata/bundles
+func TestBundleGolden(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mirrors Mirrors
+	}{
+		{name: "without-mirrors"},
+		{
+			name: "with-mirrors",
+			mirrors: Mirrors{
+				OCI:   "registry.internal:5000/mcp/",
+				NPM:   "https://npm.internal",
+				PyPI:  "https://pypi.internal/simple",
+				NuGet: "https://nuget.internal/v3/index.json",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			bundle := NewBundle(newTestServer(), tt.mirrors)
+			golden.AssertString(t, "bundles/"+tt.name+".sh", bundle.Script())
+			golden.AssertJSON(t, "bundles/"+tt.name+".json", bundle)
+		})
+	}
+}
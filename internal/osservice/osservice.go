@@ -0,0 +1,107 @@
+// Package osservice runs the registry server as an operating system service: a systemd unit on
+// Linux and a Windows service on Windows.
+//
+// Install registers the serve command of the running executable as a service started at boot and
+// restarted on failure, and Uninstall stops and removes it. Run stops the server when it is asked
+// to, by a signal or by the Windows service control manager.
+package osservice
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// DefaultName is the default name of the service
+	DefaultName = "thv-registry-api"
+	// DefaultDescription is the default description of the service
+	DefaultDescription = "ToolHive Registry API server"
+
+	// restartDelay is the delay before a failed server is restarted
+	restartDelay = 5
+	// stopTimeout is the time the server is given to stop, longer than its graceful shutdown timeout
+	stopTimeout = 35
+)
+
+// ErrUnsupported is returned on platforms without a supported service manager
+var ErrUnsupported = errors.New("services are only supported with systemd on Linux and on Windows")
+
+// serviceName matches valid service names
+var serviceName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.@-]*$`)
+
+// Spec describes the service running the server
+type Spec struct {
+	// Name is the name of the service (e.g., "thv-registry-api")
+	Name string
+	// Description is shown by the service manager
+	Description string
+	// Executable is the absolute path of the server executable
+	Executable string
+	// Args are the arguments the executable is run with (e.g., "serve --config /etc/registry.yaml")
+	Args []string
+	// User is the user the server runs as. Only supported by systemd units; defaults to root.
+	User string
+}
+
+// validate checks that the spec can be installed
+func (s Spec) validate() error {
+	if err := validateName(s.Name); err != nil {
+		return err
+	}
+	if !filepath.IsAbs(s.Executable) {
+		return fmt.Errorf("executable must be an absolute path: %s", s.Executable)
+	}
+	if s.User != "" && !serviceName.MatchString(s.User) {
+		return fmt.Errorf("invalid user: %s", s.User)
+	}
+	return nil
+}
+
+// validateName checks that a service name is safe to use in file names and commands
+func validateName(name string) error {
+	if !serviceName.MatchString(name) {
+		return fmt.Errorf("invalid service name %q: must contain only letters, digits, '_', '.', '@' and '-'", name)
+	}
+	return nil
+}
+
+// SystemdUnit renders the systemd unit running the server
+func SystemdUnit(spec Spec) string {
+	var sb strings.Builder
+	sb.WriteString("[Unit]\n")
+	fmt.Fprintf(&sb, "Description=%s\n", spec.Description)
+	sb.WriteString("Documentation=https://github.com/stacklok/toolhive-registry-server\n")
+	sb.WriteString("Wants=network-online.target\n")
+	sb.WriteString("After=network-online.target\n")
+	sb.WriteString("\n[Service]\n")
+	sb.WriteString("Type=simple\n")
+	words := make([]string, 0, len(spec.Args)+1)
+	for _, word := range append([]string{spec.Executable}, spec.Args...) {
+		words = append(words, systemdQuote(word))
+	}
+	fmt.Fprintf(&sb, "ExecStart=%s\n", strings.Join(words, " "))
+	if spec.User != "" {
+		fmt.Fprintf(&sb, "User=%s\n", spec.User)
+	}
+	sb.WriteString("Restart=on-failure\n")
+	fmt.Fprintf(&sb, "RestartSec=%d\n", restartDelay)
+	fmt.Fprintf(&sb, "TimeoutStopSec=%d\n", stopTimeout)
+	sb.WriteString("NoNewPrivileges=true\n")
+	sb.WriteString("PrivateTmp=true\n")
+	sb.WriteString("\n[Install]\n")
+	sb.WriteString("WantedBy=multi-user.target\n")
+	return sb.String()
+}
+
+// systemdQuote quotes a word of a systemd command line, escaping the specifiers and variables
+// systemd would otherwise expand
+func systemdQuote(word string) string {
+	word = strings.NewReplacer("%", "%%", "$", "$$").Replace(word)
+	if word != "" && !strings.ContainsAny(word, " \t\"'\\;") {
+		return word
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(word) + `"`
+}
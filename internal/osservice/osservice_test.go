@@ -0,0 +1,101 @@
+package osservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemdUnit(t *testing.T) {
+	t.Parallel()
+
+	unit := SystemdUnit(Spec{
+		Name:        "thv-registry-api",
+		Description: DefaultDescription,
+		Executable:  "/usr/local/bin/thv-registry-api",
+		Args:        []string{"serve", "--config", "/etc/registry/my config.yaml", "--address", ":8080"},
+		User:        "registry",
+	})
+	assert.Equal(t, `[Unit]
+Description=ToolHive Registry API server
+Documentation=https://github.com/stacklok/toolhive-registry-server
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+Type=simple
+ExecStart=/usr/local/bin/thv-registry-api serve --config "/etc/registry/my config.yaml" --address :8080
+User=registry
+Restart=on-failure
+RestartSec=5
+TimeoutStopSec=35
+NoNewPrivileges=true
+PrivateTmp=true
+
+[Install]
+WantedBy=multi-user.target
+`, unit)
+}
+
+func TestSystemdQuote(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		word string
+		want string
+	}{
+		{word: "/etc/registry.yaml", want: "/etc/registry.yaml"},
+		{word: "a b", want: `"a b"`},
+		{word: `say "hi"`, want: `"say \"hi\""`},
+		{word: "100%", want: "100%%"},
+		{word: "$HOME", want: "$$HOME"},
+		{word: "", want: `""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, systemdQuote(tt.word))
+		})
+	}
+}
+
+func TestSpecValidate(t *testing.T) {
+	t.Parallel()
+	valid := Spec{Name: DefaultName, Executable: "/usr/local/bin/thv-registry-api"}
+	require.NoError(t, valid.validate())
+
+	tests := []struct {
+		name    string
+		spec    Spec
+		wantErr string
+	}{
+		{
+			name:    "path in name",
+			spec:    Spec{Name: "../evil", Executable: valid.Executable},
+			wantErr: "invalid service name",
+		},
+		{
+			name:    "empty name",
+			spec:    Spec{Executable: valid.Executable},
+			wantErr: "invalid service name",
+		},
+		{
+			name:    "relative executable",
+			spec:    Spec{Name: DefaultName, Executable: "bin/thv-registry-api"},
+			wantErr: "executable must be an absolute path",
+		},
+		{
+			name:    "invalid user",
+			spec:    Spec{Name: DefaultName, Executable: valid.Executable, User: "root\nExecStartPre=/bin/sh"},
+			wantErr: "invalid user",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.ErrorContains(t, tt.spec.validate(), tt.wantErr)
+		})
+	}
+}
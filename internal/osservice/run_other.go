@@ -0,0 +1,11 @@
+//go:build !windows
+
+package osservice
+
+import "context"
+
+// Run calls run with a context canceled when the server is asked to stop by SIGINT or SIGTERM,
+// which is how systemd stops units
+func Run(_ string, run func(ctx context.Context) error) error {
+	return runUntilSignal(run)
+}
@@ -0,0 +1,152 @@
+package osservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// resetPeriod is the time without failure after which the failure count of the service is reset
+const resetPeriod = 24 * time.Hour
+
+// Describe returns the command line of the Windows service Install would create
+func Describe(spec Spec) string {
+	words := make([]string, 0, len(spec.Args)+1)
+	for _, word := range append([]string{spec.Executable}, spec.Args...) {
+		words = append(words, syscall.EscapeArg(word))
+	}
+	return fmt.Sprintf("Service %s (%s), started automatically and restarted on failure:\n%s\n",
+		spec.Name, spec.Description, strings.Join(words, " "))
+}
+
+// Install creates the Windows service of the server, started automatically and restarted on
+// failure, and starts it. It fails if a service of the same name exists.
+func Install(_ context.Context, spec Spec) error {
+	if err := spec.validate(); err != nil {
+		return err
+	}
+	if spec.User != "" {
+		return errors.New("user is only supported by systemd units; Windows services run as LocalSystem")
+	}
+
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service control manager: %w", err)
+	}
+	defer func() { _ = m.Disconnect() }()
+
+	if existing, err := m.OpenService(spec.Name); err == nil {
+		_ = existing.Close()
+		return fmt.Errorf("service %s already exists; uninstall it first", spec.Name)
+	}
+
+	s, err := m.CreateService(spec.Name, spec.Executable, mgr.Config{
+		DisplayName: spec.Description,
+		Description: spec.Description,
+		StartType:   mgr.StartAutomatic,
+	}, spec.Args...)
+	if err != nil {
+		return fmt.Errorf("failed to create service %s: %w", spec.Name, err)
+	}
+	defer func() { _ = s.Close() }()
+
+	actions := []mgr.RecoveryAction{{Type: mgr.ServiceRestart, Delay: restartDelay * time.Second}}
+	if err := s.SetRecoveryActions(actions, uint32(resetPeriod.Seconds())); err != nil {
+		return fmt.Errorf("failed to set recovery actions of service %s: %w", spec.Name, err)
+	}
+	if err := s.Start(); err != nil {
+		return fmt.Errorf("failed to start service %s: %w", spec.Name, err)
+	}
+	return nil
+}
+
+// Uninstall stops the Windows service of the server and deletes it
+func Uninstall(_ context.Context, name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service control manager: %w", err)
+	}
+	defer func() { _ = m.Disconnect() }()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return fmt.Errorf("failed to open service %s: %w", name, err)
+	}
+	defer func() { _ = s.Close() }()
+
+	if _, err := s.Control(svc.Stop); err != nil && !errors.Is(err, windows.ERROR_SERVICE_NOT_ACTIVE) {
+		return fmt.Errorf("failed to stop service %s: %w", name, err)
+	}
+	if err := s.Delete(); err != nil {
+		return fmt.Errorf("failed to delete service %s: %w", name, err)
+	}
+	return nil
+}
+
+// Run calls run with a context canceled when the server is asked to stop: by the service control
+// manager when running as a Windows service, and by an interrupt otherwise
+func Run(name string, run func(ctx context.Context) error) error {
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		return fmt.Errorf("failed to detect the service control manager: %w", err)
+	}
+	if !isService {
+		return runUntilSignal(run)
+	}
+
+	h := &handler{run: run}
+	if err := svc.Run(name, h); err != nil {
+		return fmt.Errorf("failed to run service %s: %w", name, err)
+	}
+	return h.err
+}
+
+// handler runs the server under the service control manager
+type handler struct {
+	run func(ctx context.Context) error
+	err error
+}
+
+// Execute runs the server until the service control manager stops it, or the server fails
+func (h *handler) Execute(_ []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	status <- svc.Status{State: svc.StartPending}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- h.run(ctx) }()
+
+	status <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	for {
+		select {
+		case h.err = <-done:
+			// The server stopped on its own, so the manager should apply the recovery actions
+			slog.Error("Server stopped unexpectedly", "error", h.err)
+			return false, 1
+		case request := <-requests:
+			switch request.Cmd {
+			case svc.Interrogate:
+				status <- request.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				status <- svc.Status{State: svc.StopPending, WaitHint: stopTimeout * 1000}
+				cancel()
+				h.err = <-done
+				return false, 0
+			default:
+				slog.Warn("Unexpected service control request", "cmd", request.Cmd)
+			}
+		}
+	}
+}
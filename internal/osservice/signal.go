@@ -0,0 +1,15 @@
+package osservice
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// runUntilSignal calls run with a context canceled on SIGINT or SIGTERM
+func runUntilSignal(run func(ctx context.Context) error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return run(ctx)
+}
@@ -0,0 +1,75 @@
+package osservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// unitDir is the directory systemd units are installed in
+const unitDir = "/etc/systemd/system"
+
+// Describe returns the systemd unit Install would write
+func Describe(spec Spec) string {
+	return SystemdUnit(spec)
+}
+
+// Install writes the systemd unit of the server, then enables and starts it.
+// It fails if a unit of the same name exists.
+func Install(ctx context.Context, spec Spec) error {
+	if err := spec.validate(); err != nil {
+		return err
+	}
+	path := unitPath(spec.Name)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("unit %s already exists; uninstall it first", path)
+	}
+
+	// #nosec G306 -- systemd units are world-readable
+	if err := os.WriteFile(path, []byte(SystemdUnit(spec)), 0644); err != nil {
+		return fmt.Errorf("failed to write unit %s: %w", path, err)
+	}
+	if err := systemctl(ctx, "daemon-reload"); err != nil {
+		return err
+	}
+	return systemctl(ctx, "enable", "--now", spec.Name+".service")
+}
+
+// Uninstall stops and disables the systemd unit of the server, then removes it
+func Uninstall(ctx context.Context, name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	path := unitPath(name)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("unit %s does not exist", path)
+	}
+
+	if err := systemctl(ctx, "disable", "--now", name+".service"); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove unit %s: %w", path, err)
+	}
+	return systemctl(ctx, "daemon-reload")
+}
+
+// unitPath returns the path of the systemd unit of a service
+func unitPath(name string) string {
+	return filepath.Join(unitDir, name+".service")
+}
+
+// systemctl runs a systemctl command
+func systemctl(ctx context.Context, args ...string) error {
+	// #nosec G204 -- arguments are fixed or validated service names
+	output, err := exec.CommandContext(ctx, "systemctl", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("systemctl %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build !linux && !windows
+
+package osservice
+
+import "context"
+
+// Describe returns the systemd unit running the server, for installation on a Linux host
+func Describe(spec Spec) string {
+	return SystemdUnit(spec)
+}
+
+// Install returns ErrUnsupported
+func Install(_ context.Context, _ Spec) error {
+	return ErrUnsupported
+}
+
+// Uninstall returns ErrUnsupported
+func Uninstall(_ context.Context, _ string) error {
+	return ErrUnsupported
+}
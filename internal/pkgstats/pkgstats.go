@@ -0,0 +1,266 @@
+// Package pkgstats retrieves live information about the npm and PyPI packages of MCP servers.
+//
+// For a package identifier, the latest version published to its package registry and its
+// download count over the last week are fetched, so that registry entries lagging behind
+// the packages they point to can be flagged. Download counts come from services separate
+// from the package registries (the npm downloads API and pypistats.org); when they are
+// unavailable, the latest version is still reported.
+package pkgstats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+
+	"github.com/stacklok/toolhive-registry-server/internal/cache"
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+)
+
+const (
+	// DefaultCacheTTL is the default time package stats are cached
+	DefaultCacheTTL = time.Hour
+
+	// DefaultNPMRegistryURL is the default npm registry
+	DefaultNPMRegistryURL = "https://registry.npmjs.org"
+	// DefaultNPMDownloadsURL is the default npm downloads API
+	DefaultNPMDownloadsURL = "https://api.npmjs.org"
+	// DefaultPyPIURL is the default Python Package Index
+	DefaultPyPIURL = "https://pypi.org"
+	// DefaultPyPIStatsURL is the default PyPI download statistics API
+	DefaultPyPIStatsURL = "https://pypistats.org"
+
+	// maxCacheEntries caps the number of cached stats
+	maxCacheEntries = 1024
+	// maxResponseSize caps the size of package registry responses; PyPI project documents
+	// list every release and reach several megabytes for long-lived projects
+	maxResponseSize = 16 * 1024 * 1024
+
+	defaultFetchTimeout = 10 * time.Second
+)
+
+var (
+	// ErrUnsupportedRegistry is returned for packages of registries other than npm and PyPI
+	ErrUnsupportedRegistry = errors.New("unsupported package registry")
+	// ErrNotFound is returned for packages missing from their package registry
+	ErrNotFound = errors.New("package not found")
+)
+
+// Stats is the live information about a package
+type Stats struct {
+	RegistryType  string `json:"registryType"`
+	Identifier    string `json:"identifier"`
+	LatestVersion string `json:"latestVersion"`
+	// WeeklyDownloads is the download count over the last week, if known
+	WeeklyDownloads *int64 `json:"weeklyDownloads,omitempty"`
+}
+
+// Supported reports whether stats can be fetched for packages of the given registry type
+func Supported(registryType string) bool {
+	return registryType == model.RegistryTypeNPM || registryType == model.RegistryTypePyPI
+}
+
+// Fetcher retrieves the stats of npm and PyPI packages and caches them.
+// It is safe for concurrent use.
+type Fetcher struct {
+	client          *http.Client
+	npmRegistryURL  string
+	npmDownloadsURL string
+	pypiURL         string
+	pypiStatsURL    string
+	ttl             time.Duration
+	now             func() time.Time
+	cacheOpts       []cache.Option
+	cache           *cache.LRU[string, cacheEntry]
+}
+
+// cacheEntry is cached package stats
+type cacheEntry struct {
+	stats   *Stats
+	expires time.Time
+}
+
+// Option configures a Fetcher
+type Option func(*Fetcher)
+
+// WithHTTPClient sets the HTTP client used to reach package registries
+func WithHTTPClient(client *http.Client) Option {
+	return func(f *Fetcher) {
+		f.client = client
+	}
+}
+
+// WithNPMURLs sets the base URLs of the npm registry and of the npm downloads API
+func WithNPMURLs(registryURL, downloadsURL string) Option {
+	return func(f *Fetcher) {
+		f.npmRegistryURL = strings.TrimSuffix(registryURL, "/")
+		f.npmDownloadsURL = strings.TrimSuffix(downloadsURL, "/")
+	}
+}
+
+// WithPyPIURLs sets the base URLs of the Python Package Index and of the PyPI statistics API
+func WithPyPIURLs(indexURL, statsURL string) Option {
+	return func(f *Fetcher) {
+		f.pypiURL = strings.TrimSuffix(indexURL, "/")
+		f.pypiStatsURL = strings.TrimSuffix(statsURL, "/")
+	}
+}
+
+// WithCacheTTL sets how long package stats are cached
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(f *Fetcher) {
+		f.ttl = ttl
+	}
+}
+
+// WithCache bounds the memory held by cached stats, e.g. with cache.WithBudget.
+// The cache holds at most 1024 entries regardless of the options.
+func WithCache(opts ...cache.Option) Option {
+	return func(f *Fetcher) {
+		f.cacheOpts = append(f.cacheOpts, opts...)
+	}
+}
+
+// NewFetcher creates a new package stats fetcher
+func NewFetcher(opts ...Option) *Fetcher {
+	f := &Fetcher{
+		client:          &http.Client{Timeout: defaultFetchTimeout, Transport: httpclient.NewTimingTransport(nil)},
+		npmRegistryURL:  DefaultNPMRegistryURL,
+		npmDownloadsURL: DefaultNPMDownloadsURL,
+		pypiURL:         DefaultPyPIURL,
+		pypiStatsURL:    DefaultPyPIStatsURL,
+		ttl:             DefaultCacheTTL,
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	f.cache = cache.New[string, cacheEntry]("package-stats", append(f.cacheOpts, cache.WithMaxEntries(maxCacheEntries))...)
+	return f
+}
+
+// Fetch returns the stats of a package given its registry type ("npm" or "pypi") and
+// identifier. ErrUnsupportedRegistry is returned for other registry types, and ErrNotFound
+// for packages missing from their package registry.
+func (f *Fetcher) Fetch(ctx context.Context, registryType, identifier string) (*Stats, error) {
+	if !Supported(registryType) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedRegistry, registryType)
+	}
+	key := registryType + " " + identifier
+	if entry, ok := f.cache.Get(key); ok {
+		if f.now().Before(entry.expires) {
+			return entry.stats, nil
+		}
+		f.cache.Remove(key)
+	}
+
+	var stats *Stats
+	var err error
+	if registryType == model.RegistryTypeNPM {
+		stats, err = f.fetchNPM(ctx, identifier)
+	} else {
+		stats, err = f.fetchPyPI(ctx, identifier)
+	}
+	if err != nil {
+		return nil, err
+	}
+	f.cache.Add(key, cacheEntry{stats: stats, expires: f.now().Add(f.ttl)}, int64(len(key))+cache.JSONSize(stats))
+	return stats, nil
+}
+
+// fetchNPM fetches the latest version and weekly downloads of an npm package
+func (f *Fetcher) fetchNPM(ctx context.Context, name string) (*Stats, error) {
+	escaped := url.PathEscape(name)
+	var latest struct {
+		Version string `json:"version"`
+	}
+	if err := f.getJSON(ctx, f.npmRegistryURL+"/"+escaped+"/latest", &latest); err != nil {
+		return nil, fmt.Errorf("failed to get latest version of npm package %s: %w", name, err)
+	}
+	stats := &Stats{RegistryType: model.RegistryTypeNPM, Identifier: name, LatestVersion: latest.Version}
+
+	var downloads struct {
+		Downloads int64 `json:"downloads"`
+	}
+	if err := f.getJSON(ctx, f.npmDownloadsURL+"/downloads/point/last-week/"+escaped, &downloads); err != nil {
+		slog.WarnContext(ctx, "Failed to get npm package downloads", "package", name, "error", err)
+	} else {
+		stats.WeeklyDownloads = &downloads.Downloads
+	}
+	return stats, nil
+}
+
+// fetchPyPI fetches the latest version and weekly downloads of a PyPI package
+func (f *Fetcher) fetchPyPI(ctx context.Context, name string) (*Stats, error) {
+	escaped := url.PathEscape(name)
+	var project struct {
+		Info struct {
+			Version string `json:"version"`
+		} `json:"info"`
+	}
+	if err := f.getJSON(ctx, f.pypiURL+"/pypi/"+escaped+"/json", &project); err != nil {
+		return nil, fmt.Errorf("failed to get latest version of PyPI package %s: %w", name, err)
+	}
+	stats := &Stats{RegistryType: model.RegistryTypePyPI, Identifier: name, LatestVersion: project.Info.Version}
+
+	// pypistats.org only knows normalized project names
+	var recent struct {
+		Data struct {
+			LastWeek int64 `json:"last_week"`
+		} `json:"data"`
+	}
+	statsURL := f.pypiStatsURL + "/api/packages/" + url.PathEscape(normalizePyPIName(name)) + "/recent"
+	if err := f.getJSON(ctx, statsURL, &recent); err != nil {
+		slog.WarnContext(ctx, "Failed to get PyPI package downloads", "package", name, "error", err)
+	} else {
+		stats.WeeklyDownloads = &recent.Data.LastWeek
+	}
+	return stats, nil
+}
+
+// normalizePyPIName normalizes a Python project name as defined by PEP 503
+func normalizePyPIName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(name))
+}
+
+// getJSON fetches a JSON document and decodes it into v
+func (f *Fetcher) getJSON(ctx context.Context, rawURL string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", httpclient.UserAgent())
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: HTTP 404 for URL %s", ErrNotFound, rawURL)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d for URL %s", resp.StatusCode, rawURL)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(body) > maxResponseSize {
+		return fmt.Errorf("response of %s exceeds the %d bytes limit", rawURL, maxResponseSize)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("invalid response of %s: %w", rawURL, err)
+	}
+	return nil
+}
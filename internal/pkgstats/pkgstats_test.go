@@ -0,0 +1,151 @@
+package pkgstats
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistries serves the npm registry, the npm downloads API, PyPI and pypistats.org
+type fakeRegistries struct {
+	server   *httptest.Server
+	requests atomic.Int32
+}
+
+func newFakeRegistries(t *testing.T) *fakeRegistries {
+	t.Helper()
+	routes := map[string]string{
+		"/npm/@example%2Fserver/latest":                              `{"name":"@example/server","version":"2.1.0"}`,
+		"/npm/no-downloads/latest":                                   `{"name":"no-downloads","version":"0.3.0"}`,
+		"/npm-downloads/downloads/point/last-week/@example%2Fserver": `{"downloads":1234,"package":"@example/server"}`,
+		"/pypi/pypi/Example_Server/json":                             `{"info":{"name":"Example_Server","version":"1.4.2"},"releases":{}}`,
+		"/pypistats/api/packages/example-server/recent":              `{"data":{"last_day":10,"last_month":400,"last_week":56}}`,
+	}
+	reg := &fakeRegistries{}
+	reg.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reg.requests.Add(1)
+		body, ok := routes[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(reg.server.Close)
+	return reg
+}
+
+func (reg *fakeRegistries) fetcher(opts ...Option) *Fetcher {
+	url := reg.server.URL
+	return NewFetcher(append([]Option{
+		WithNPMURLs(url+"/npm", url+"/npm-downloads/"),
+		WithPyPIURLs(url+"/pypi", url+"/pypistats"),
+	}, opts...)...)
+}
+
+func TestFetcherFetch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		registryType  string
+		identifier    string
+		wantVersion   string
+		wantDownloads *int64
+		wantErr       error
+		wantErrIn     string
+	}{
+		{
+			name:          "scoped npm package",
+			registryType:  "npm",
+			identifier:    "@example/server",
+			wantVersion:   "2.1.0",
+			wantDownloads: int64Ptr(1234),
+		},
+		{
+			name:         "npm package without download stats",
+			registryType: "npm",
+			identifier:   "no-downloads",
+			wantVersion:  "0.3.0",
+		},
+		{
+			name:          "PyPI package with unnormalized name",
+			registryType:  "pypi",
+			identifier:    "Example_Server",
+			wantVersion:   "1.4.2",
+			wantDownloads: int64Ptr(56),
+		},
+		{
+			name:         "unknown package",
+			registryType: "npm",
+			identifier:   "missing",
+			wantErr:      ErrNotFound,
+		},
+		{
+			name:         "unsupported registry",
+			registryType: "oci",
+			identifier:   "ghcr.io/example/server:1.0.0",
+			wantErr:      ErrUnsupportedRegistry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fetcher := newFakeRegistries(t).fetcher()
+
+			stats, err := fetcher.Fetch(context.Background(), tt.registryType, tt.identifier)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIn != "" {
+				assert.ErrorContains(t, err, tt.wantErrIn)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.registryType, stats.RegistryType)
+			assert.Equal(t, tt.identifier, stats.Identifier)
+			assert.Equal(t, tt.wantVersion, stats.LatestVersion)
+			assert.Equal(t, tt.wantDownloads, stats.WeeklyDownloads)
+		})
+	}
+}
+
+func TestFetcherCache(t *testing.T) {
+	t.Parallel()
+	reg := newFakeRegistries(t)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fetcher := reg.fetcher(WithCacheTTL(time.Minute))
+	fetcher.now = func() time.Time { return now }
+
+	first, err := fetcher.Fetch(context.Background(), "pypi", "Example_Server")
+	require.NoError(t, err)
+	requests := reg.requests.Load()
+
+	second, err := fetcher.Fetch(context.Background(), "pypi", "Example_Server")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, requests, reg.requests.Load())
+
+	now = now.Add(2 * time.Minute)
+	_, err = fetcher.Fetch(context.Background(), "pypi", "Example_Server")
+	require.NoError(t, err)
+	assert.Greater(t, reg.requests.Load(), requests)
+}
+
+func TestNormalizePyPIName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "mcp-server-fetch", normalizePyPIName("MCP_Server.Fetch"))
+	assert.Equal(t, "mcp-server", normalizePyPIName("mcp-server"))
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
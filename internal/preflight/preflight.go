@@ -0,0 +1,250 @@
+// Package preflight checks, before the server starts, that its configuration can be served: that
+// the registry sources are reachable and their data parses, that the auth configuration is valid
+// and its identity providers reachable, and that the storage directory is writable.
+//
+// All checks run, and their problems are reported together, so that a misconfigured deployment
+// fails at startup with every problem listed rather than surfacing them one by one on first use.
+package preflight
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+	"github.com/stacklok/toolhive-registry-server/internal/sources"
+)
+
+// DefaultTimeout bounds each check
+const DefaultTimeout = 30 * time.Second
+
+// maxDiscoverySize caps the size of OpenID Connect discovery documents
+const maxDiscoverySize = 1024 * 1024
+
+// Kinds of checks
+const (
+	// CheckSource checks that a registry source is reachable and its data parses
+	CheckSource = "source"
+	// CheckAuth checks the auth configuration and the reachability of identity providers
+	CheckAuth = "auth"
+	// CheckStorage checks that the storage directory is writable
+	CheckStorage = "storage"
+)
+
+// Problem is a failed check
+type Problem struct {
+	// Check is the kind of check: CheckSource, CheckAuth or CheckStorage
+	Check string
+	// Subject is what was checked, e.g. a registry or an OAuth provider
+	Subject string
+	Err     error
+}
+
+// Report lists the problems found by Run
+type Report struct {
+	Problems []Problem
+}
+
+// Error lists the problems, one per line
+func (r *Report) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "configuration preflight found %d problem(s):", len(r.Problems))
+	for _, problem := range r.Problems {
+		fmt.Fprintf(&sb, "\n  - %s %s: %v", problem.Check, problem.Subject, problem.Err)
+	}
+	return sb.String()
+}
+
+// Unwrap returns the errors of the problems
+func (r *Report) Unwrap() []error {
+	errs := make([]error, len(r.Problems))
+	for i, problem := range r.Problems {
+		errs[i] = problem.Err
+	}
+	return errs
+}
+
+// options configures Run
+type options struct {
+	factory sources.RegistryHandlerFactory
+	timeout time.Duration
+}
+
+// Option configures Run
+type Option func(*options)
+
+// WithHandlerFactory sets the factory of the handlers checking registry sources
+func WithHandlerFactory(factory sources.RegistryHandlerFactory) Option {
+	return func(o *options) {
+		o.factory = factory
+	}
+}
+
+// WithTimeout sets the time each check may take
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// Run checks the configuration, and returns a *Report listing the problems found, if any.
+// Sources and identity providers are checked concurrently.
+func Run(ctx context.Context, cfg *config.Config, opts ...Option) error {
+	o := &options{factory: sources.NewRegistryHandlerFactory(), timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		problems []Problem
+	)
+	report := func(check, subject string, err error) {
+		if err == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		problems = append(problems, Problem{Check: check, Subject: subject, Err: err})
+	}
+
+	for i := range cfg.Registries {
+		regCfg := &cfg.Registries[i]
+		if regCfg.IsNonSyncedRegistry() {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report(CheckSource, fmt.Sprintf("registry %q", regCfg.Name), checkSource(ctx, o, regCfg))
+		}()
+	}
+
+	if cfg.Auth != nil {
+		if err := cfg.Auth.Validate(); err != nil {
+			report(CheckAuth, "configuration", err)
+		} else if cfg.Auth.Mode == config.AuthModeOAuth {
+			for i := range cfg.Auth.OAuth.Providers {
+				provider := &cfg.Auth.OAuth.Providers[i]
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					report(CheckAuth, fmt.Sprintf("provider %q", provider.Name), checkProvider(ctx, o, provider))
+				}()
+			}
+		}
+	}
+
+	if cfg.GetStorageType() == config.StorageTypeFile {
+		dir := cfg.GetFileStorageBaseDir()
+		report(CheckStorage, fmt.Sprintf("directory %s", dir), checkWritable(dir))
+	}
+
+	wg.Wait()
+	if len(problems) == 0 {
+		return nil
+	}
+	// Problems are listed in a stable order, whatever order the checks completed in
+	slices.SortStableFunc(problems, func(a, b Problem) int {
+		return cmp.Or(cmp.Compare(a.Check, b.Check), cmp.Compare(a.Subject, b.Subject))
+	})
+	return &Report{Problems: problems}
+}
+
+// checkSource checks that a registry source is reachable and its data parses
+func checkSource(ctx context.Context, o *options, regCfg *config.RegistryConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	handler, err := o.factory.CreateHandler(regCfg)
+	if err != nil {
+		return err
+	}
+	return handler.Check(ctx, regCfg)
+}
+
+// checkProvider checks that the client secret of an OAuth provider can be read and that the
+// OpenID Connect discovery document of its issuer is served
+func checkProvider(ctx context.Context, o *options, provider *config.OAuthProviderConfig) error {
+	if _, err := provider.GetClientSecret(); err != nil {
+		return err
+	}
+
+	client, err := discoveryClient(provider.CACertPath, o.timeout)
+	if err != nil {
+		return err
+	}
+	discoveryURL := strings.TrimSuffix(provider.IssuerURL, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", httpclient.UserAgent())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", discoveryURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d for URL %s", resp.StatusCode, discoveryURL)
+	}
+	var discovery struct {
+		Issuer string `json:"issuer"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDiscoverySize)).Decode(&discovery); err != nil {
+		return fmt.Errorf("invalid discovery document at %s: %w", discoveryURL, err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != strings.TrimSuffix(provider.IssuerURL, "/") {
+		return fmt.Errorf("discovery document at %s is for issuer %q", discoveryURL, discovery.Issuer)
+	}
+	return nil
+}
+
+// discoveryClient returns the HTTP client reaching an issuer, trusting the CA bundle at caCertPath if set
+func discoveryClient(caCertPath string, timeout time.Duration) (*http.Client, error) {
+	client := &http.Client{Timeout: timeout}
+	if caCertPath == "" {
+		return client, nil
+	}
+	pem, err := os.ReadFile(filepath.Clean(caCertPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate bundle: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", caCertPath)
+	}
+	client.Transport = &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12},
+	}
+	return client, nil
+}
+
+// checkWritable checks that files can be created in a directory, creating it if needed
+func checkWritable(dir string) error {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	f, err := os.CreateTemp(dir, ".preflight-*")
+	if err != nil {
+		return fmt.Errorf("directory is not writable: %w", err)
+	}
+	name := f.Name()
+	return errors.Join(f.Close(), os.Remove(name))
+}
@@ -0,0 +1,154 @@
+package preflight
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+const validRegistry = `{"version":"1.0.0","last_updated":"2024-01-01T00:00:00Z","servers":{}}`
+
+func fileRegistry(t *testing.T, name, content string) config.RegistryConfig {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "registry.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return config.RegistryConfig{
+		Name:   name,
+		Format: config.SourceFormatToolHive,
+		File:   &config.FileConfig{Path: path},
+	}
+}
+
+func oidcIssuer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/.well-known/openid-configuration" {
+			http.NotFound(w, req)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": server.URL})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	issuer := oidcIssuer(t)
+
+	// A directory cannot be created under a regular file, even by root
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	require.NoError(t, os.WriteFile(blocker, nil, 0600))
+
+	tests := []struct {
+		name string
+		cfg  func(t *testing.T) *config.Config
+		// wantSubjects lists the subjects of the expected problems, in report order
+		wantSubjects []string
+	}{
+		{
+			name: "valid configuration",
+			cfg: func(t *testing.T) *config.Config {
+				t.Helper()
+				return &config.Config{
+					Registries: []config.RegistryConfig{
+						fileRegistry(t, "local", validRegistry),
+						{Name: "managed", Managed: &config.ManagedConfig{}},
+					},
+					Auth: &config.AuthConfig{
+						Mode: config.AuthModeOAuth,
+						OAuth: &config.OAuthConfig{Providers: []config.OAuthProviderConfig{
+							{Name: "idp", IssuerURL: issuer.URL, Audience: "registry"},
+						}},
+					},
+					FileStorage: &config.FileStorageConfig{BaseDir: filepath.Join(t.TempDir(), "data")},
+				}
+			},
+		},
+		{
+			name: "every problem is reported",
+			cfg: func(t *testing.T) *config.Config {
+				t.Helper()
+				return &config.Config{
+					Registries: []config.RegistryConfig{
+						fileRegistry(t, "broken", "{not json"),
+						{
+							Name:   "missing",
+							Format: config.SourceFormatToolHive,
+							File:   &config.FileConfig{Path: filepath.Join(t.TempDir(), "missing.json")},
+						},
+					},
+					Auth: &config.AuthConfig{
+						Mode: config.AuthModeOAuth,
+						OAuth: &config.OAuthConfig{Providers: []config.OAuthProviderConfig{
+							{Name: "unreachable", IssuerURL: issuer.URL + "/realms/missing", Audience: "registry"},
+						}},
+					},
+					FileStorage: &config.FileStorageConfig{BaseDir: filepath.Join(blocker, "data")},
+				}
+			},
+			wantSubjects: []string{
+				`provider "unreachable"`,
+				`registry "broken"`,
+				`registry "missing"`,
+				"directory " + filepath.Join(blocker, "data"),
+			},
+		},
+		{
+			name: "invalid auth configuration",
+			cfg: func(t *testing.T) *config.Config {
+				t.Helper()
+				return &config.Config{
+					Auth:        &config.AuthConfig{Mode: config.AuthModeOAuth},
+					FileStorage: &config.FileStorageConfig{BaseDir: t.TempDir()},
+				}
+			},
+			wantSubjects: []string{"configuration"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := Run(context.Background(), tt.cfg(t))
+			if len(tt.wantSubjects) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var report *Report
+			require.True(t, errors.As(err, &report), "expected a *Report, got %v", err)
+			subjects := make([]string, len(report.Problems))
+			for i, problem := range report.Problems {
+				subjects[i] = problem.Subject
+			}
+			assert.Equal(t, tt.wantSubjects, subjects)
+			assert.Contains(t, err.Error(), "configuration preflight found")
+		})
+	}
+}
+
+func TestReportError(t *testing.T) {
+	t.Parallel()
+	cause := errors.New("connection refused")
+	report := &Report{Problems: []Problem{
+		{Check: CheckSource, Subject: `registry "remote"`, Err: cause},
+		{Check: CheckStorage, Subject: "directory /data", Err: errors.New("directory is not writable")},
+	}}
+
+	assert.Equal(t, `configuration preflight found 2 problem(s):
+  - source registry "remote": connection refused
+  - storage directory /data: directory is not writable`, report.Error())
+	assert.ErrorIs(t, report, cause)
+}
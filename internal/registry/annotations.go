@@ -0,0 +1,78 @@
+package registry
+
+import (
+	"time"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Approval statuses operators record in the internal annotations of servers
+const (
+	// ApprovalApproved is the approval status of servers approved for use in the organization
+	ApprovalApproved = "approved"
+	// ApprovalPending is the approval status of servers under review
+	ApprovalPending = "pending"
+	// ApprovalRejected is the approval status of servers not to be used in the organization
+	ApprovalRejected = "rejected"
+
+	// AnnotationMetaKey is the publisher-provided metadata key under which the internal annotation
+	// of a server is recorded in API responses
+	AnnotationMetaKey = "io.github.stacklok/annotation"
+)
+
+// ApprovalStatuses returns the supported approval statuses
+func ApprovalStatuses() []string {
+	return []string{ApprovalApproved, ApprovalPending, ApprovalRejected}
+}
+
+// Annotation is what operators of a deployment record about a server for its users only: internal
+// notes, whether the organization approved the server, and the team owning it
+type Annotation struct {
+	Notes string `json:"notes,omitempty"`
+	// ApprovalStatus is one of ApprovalStatuses, or empty if not recorded
+	ApprovalStatus string    `json:"approvalStatus,omitempty"`
+	OwnerTeam      string    `json:"ownerTeam,omitempty"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// AnnotationLookup returns the annotations of servers by name
+type AnnotationLookup interface {
+	// Annotation returns the annotation of a server, and false if it has none
+	Annotation(name string) (Annotation, bool)
+}
+
+// AnnotationFilter selects servers by their annotation. Empty fields select all servers.
+type AnnotationFilter struct {
+	ApprovalStatus string
+	OwnerTeam      string
+}
+
+// IsEmpty returns true if the filter selects all servers
+func (f AnnotationFilter) IsEmpty() bool {
+	return f.ApprovalStatus == "" && f.OwnerTeam == ""
+}
+
+// Matches returns true if the annotation has the approval status and owner team of the filter, if set
+func (f AnnotationFilter) Matches(annotation Annotation) bool {
+	return (f.ApprovalStatus == "" || annotation.ApprovalStatus == f.ApprovalStatus) &&
+		(f.OwnerTeam == "" || annotation.OwnerTeam == f.OwnerTeam)
+}
+
+// FilterByAnnotation returns the servers whose annotation, as returned by lookup, matches the filter.
+// Servers without annotation only match an empty filter.
+func FilterByAnnotation(
+	servers []*upstream.ServerJSON,
+	lookup AnnotationLookup,
+	filter AnnotationFilter,
+) []*upstream.ServerJSON {
+	if filter.IsEmpty() {
+		return servers
+	}
+	filtered := make([]*upstream.ServerJSON, 0, len(servers))
+	for _, server := range servers {
+		if annotation, ok := lookup.Annotation(server.Name); ok && filter.Matches(annotation) {
+			filtered = append(filtered, server)
+		}
+	}
+	return filtered
+}
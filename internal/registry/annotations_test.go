@@ -0,0 +1,36 @@
+package registry
+
+import (
+	"testing"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+// annotationMap is an AnnotationLookup of the annotations it holds
+type annotationMap map[string]Annotation
+
+func (m annotationMap) Annotation(name string) (Annotation, bool) {
+	annotation, ok := m[name]
+	return annotation, ok
+}
+
+func TestFilterByAnnotation(t *testing.T) {
+	t.Parallel()
+
+	approved := NewTestServer("io.test/approved")
+	rejected := NewTestServer("io.test/rejected")
+	unannotated := NewTestServer("io.test/unannotated")
+	servers := []*upstream.ServerJSON{&approved, &rejected, &unannotated}
+	lookup := annotationMap{
+		"io.test/approved": {ApprovalStatus: ApprovalApproved, OwnerTeam: "platform"},
+		"io.test/rejected": {ApprovalStatus: ApprovalRejected, OwnerTeam: "platform"},
+	}
+
+	assert.Equal(t, servers, FilterByAnnotation(servers, lookup, AnnotationFilter{}))
+	assert.Equal(t, []*upstream.ServerJSON{&approved},
+		FilterByAnnotation(servers, lookup, AnnotationFilter{ApprovalStatus: ApprovalApproved}))
+	assert.Equal(t, []*upstream.ServerJSON{&approved, &rejected},
+		FilterByAnnotation(servers, lookup, AnnotationFilter{OwnerTeam: "platform"}))
+	assert.Empty(t, FilterByAnnotation(servers, lookup, AnnotationFilter{OwnerTeam: "security"}))
+}
@@ -8,6 +8,7 @@
 // # Core Components
 //
 //   - Tag Extraction: Utilities for extracting tags from upstream server metadata
+//   - Metadata Extraction: Accessors for ToolHive fields such as tier, status, and stars
 //   - Test Utilities: Builder pattern functions for creating test registry data
 //   - Format Conversions: Helpers for converting between registry formats
 //
@@ -0,0 +1,153 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// EnvVarUsage describes how a single server uses an environment variable
+type EnvVarUsage struct {
+	Server      string   `json:"server"`
+	Version     string   `json:"version"`
+	Description string   `json:"description,omitempty"`
+	Format      string   `json:"format"`
+	Required    bool     `json:"required"`
+	Secret      bool     `json:"secret"`
+	Default     string   `json:"default,omitempty"`
+	Choices     []string `json:"choices,omitempty"`
+}
+
+// EnvVarSummary aggregates the usages of an environment variable across servers
+type EnvVarSummary struct {
+	Name string `json:"name"`
+	// Servers are the names of the servers using the variable
+	Servers []string `json:"servers"`
+	// Required is true if any server requires the variable
+	Required bool `json:"required"`
+	// Shared is true if more than one server uses the variable, e.g. a shared API key
+	Shared bool `json:"shared"`
+	// Conflicts explain how the servers disagree on the meaning of the variable
+	Conflicts []string      `json:"conflicts,omitempty"`
+	Usages    []EnvVarUsage `json:"usages"`
+}
+
+// EnvVarReport is the environment variable compatibility report of a set of servers
+type EnvVarReport struct {
+	Variables []EnvVarSummary `json:"variables"`
+	// Conflicts are the names of the variables whose usages conflict
+	Conflicts []string `json:"conflicts"`
+	// Shared are the names of the variables used by more than one server
+	Shared []string `json:"shared"`
+}
+
+// EnvVarCompatibility aggregates the environment variables of the packages of the given servers.
+// A variable is shared when several servers use it, and conflicting when the servers disagree
+// on its format, on whether it is a secret, or on its allowed values. Variables are sorted by name.
+func EnvVarCompatibility(servers []*upstream.ServerJSON) EnvVarReport {
+	byName := make(map[string]*EnvVarSummary)
+	for _, server := range servers {
+		for _, pkg := range server.Packages {
+			for _, envVar := range pkg.EnvironmentVariables {
+				summary, ok := byName[envVar.Name]
+				if !ok {
+					summary = &EnvVarSummary{Name: envVar.Name}
+					byName[envVar.Name] = summary
+				}
+				addEnvVarUsage(summary, server, envVar)
+			}
+		}
+	}
+
+	report := EnvVarReport{
+		Variables: make([]EnvVarSummary, 0, len(byName)),
+		Conflicts: []string{},
+		Shared:    []string{},
+	}
+	for _, summary := range byName {
+		summary.Shared = len(summary.Servers) > 1
+		summary.Conflicts = envVarConflicts(summary.Usages)
+		report.Variables = append(report.Variables, *summary)
+	}
+	sort.Slice(report.Variables, func(i, j int) bool {
+		return report.Variables[i].Name < report.Variables[j].Name
+	})
+	for _, summary := range report.Variables {
+		if summary.Shared {
+			report.Shared = append(report.Shared, summary.Name)
+		}
+		if len(summary.Conflicts) > 0 {
+			report.Conflicts = append(report.Conflicts, summary.Name)
+		}
+	}
+	return report
+}
+
+// addEnvVarUsage records a usage of a variable by a server. A server declaring the same
+// variable in several packages is only recorded once.
+func addEnvVarUsage(summary *EnvVarSummary, server *upstream.ServerJSON, envVar model.KeyValueInput) {
+	summary.Required = summary.Required || envVar.IsRequired
+	if slices.Contains(summary.Servers, server.Name) {
+		return
+	}
+
+	format := string(envVar.Format)
+	if format == "" {
+		format = string(model.FormatString)
+	}
+	summary.Servers = append(summary.Servers, server.Name)
+	summary.Usages = append(summary.Usages, EnvVarUsage{
+		Server:      server.Name,
+		Version:     server.Version,
+		Description: envVar.Description,
+		Format:      format,
+		Required:    envVar.IsRequired,
+		Secret:      envVar.IsSecret,
+		Default:     envVar.Default,
+		Choices:     envVar.Choices,
+	})
+}
+
+// envVarConflicts lists the disagreements between the usages of a variable
+func envVarConflicts(usages []EnvVarUsage) []string {
+	var conflicts []string
+	first := usages[0]
+	for _, usage := range usages[1:] {
+		if usage.Format != first.Format {
+			conflicts = append(conflicts, fmt.Sprintf("format differs: %s expects %s, %s expects %s",
+				first.Server, first.Format, usage.Server, usage.Format))
+		}
+		if usage.Secret != first.Secret {
+			conflicts = append(conflicts, fmt.Sprintf("secrecy differs: %s marks it %s, %s marks it %s",
+				first.Server, secrecy(first.Secret), usage.Server, secrecy(usage.Secret)))
+		}
+		if !sameChoices(first.Choices, usage.Choices) {
+			conflicts = append(conflicts, fmt.Sprintf("allowed values differ: %s accepts [%s], %s accepts [%s]",
+				first.Server, strings.Join(first.Choices, ", "), usage.Server, strings.Join(usage.Choices, ", ")))
+		}
+	}
+	return conflicts
+}
+
+// secrecy describes the secret flag of a variable
+func secrecy(secret bool) string {
+	if secret {
+		return "secret"
+	}
+	return "not secret"
+}
+
+// sameChoices reports whether two lists of allowed values are equal regardless of order
+func sameChoices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"testing"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+
+	"github.com/stacklok/toolhive-registry-server/internal/golden"
+)
+
+// TestEnvVarCompatibilityGolden compares servers sharing and disagreeing on variables, so that
+// changes to the comparison are reviewed in testdata/envvars/comparison.json.golden
+func TestEnvVarCompatibilityGolden(t *testing.T) {
+	t.Parallel()
+
+	github := NewTestServer("io.test/github", WithServerVersion("1.0.0"),
+		WithOCIPackage("ghcr.io/test/github:1.0.0"),
+		WithEnvironmentVariables(
+			envVar("GITHUB_TOKEN", secret, required),
+			envVar("LOG_LEVEL", func(v *model.KeyValueInput) { v.Choices = []string{"debug", "info"} }),
+		),
+	)
+	issues := NewTestServer("io.test/issues", WithServerVersion("2.0.0"),
+		WithOCIPackage("ghcr.io/test/issues:2.0.0"),
+		WithEnvironmentVariables(
+			envVar("GITHUB_TOKEN"),
+			envVar("LOG_LEVEL", func(v *model.KeyValueInput) { v.Choices = []string{"info", "warn"} }),
+			envVar("PORT", func(v *model.KeyValueInput) { v.Format = model.FormatNumber }),
+		),
+	)
+	proxy := NewTestServer("io.test/proxy", WithServerVersion("0.3.1"),
+		WithHTTPPackage("https://proxy.test/mcp"),
+		WithEnvironmentVariables(
+			envVar("PORT", func(v *model.KeyValueInput) { v.Format = model.FormatString }),
+		),
+	)
+
+	golden.AssertJSON(t, "envvars/comparison.json", EnvVarCompatibility([]*upstream.ServerJSON{&github, &issues, &proxy}))
+}
@@ -0,0 +1,101 @@
+package registry
+
+import (
+	"testing"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envVar(name string, opts ...func(*model.KeyValueInput)) model.KeyValueInput {
+	v := model.KeyValueInput{Name: name}
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v
+}
+
+func secret(v *model.KeyValueInput)   { v.IsSecret = true }
+func required(v *model.KeyValueInput) { v.IsRequired = true }
+
+func TestEnvVarCompatibility(t *testing.T) {
+	t.Parallel()
+
+	github := NewTestServer("io.test/github", WithServerVersion("1.0.0"),
+		WithOCIPackage("ghcr.io/test/github:1.0.0"),
+		WithEnvironmentVariables(
+			envVar("GITHUB_TOKEN", secret, required),
+			envVar("LOG_LEVEL", func(v *model.KeyValueInput) { v.Choices = []string{"debug", "info"} }),
+		),
+	)
+	issues := NewTestServer("io.test/issues", WithServerVersion("2.0.0"),
+		WithOCIPackage("ghcr.io/test/issues:2.0.0"),
+		WithEnvironmentVariables(
+			envVar("GITHUB_TOKEN", secret),
+			envVar("LOG_LEVEL", func(v *model.KeyValueInput) { v.Choices = []string{"info", "debug"} }),
+			envVar("PORT", func(v *model.KeyValueInput) { v.Format = model.FormatNumber }),
+		),
+		// The same variable in another package of the same server is not a second usage
+		WithHTTPPackage("https://example.com/issues.tar.gz"),
+		WithEnvironmentVariables(envVar("GITHUB_TOKEN", secret)),
+	)
+	proxy := NewTestServer("io.test/proxy", WithServerVersion("0.1.0"),
+		WithOCIPackage("ghcr.io/test/proxy:0.1.0"),
+		WithEnvironmentVariables(envVar("PORT")),
+	)
+
+	report := EnvVarCompatibility([]*upstream.ServerJSON{&github, &issues, &proxy})
+
+	require.Len(t, report.Variables, 3)
+	assert.Equal(t, []string{"GITHUB_TOKEN", "LOG_LEVEL", "PORT"}, report.Shared)
+	assert.Equal(t, []string{"PORT"}, report.Conflicts)
+
+	token := report.Variables[0]
+	assert.Equal(t, "GITHUB_TOKEN", token.Name)
+	assert.Equal(t, []string{"io.test/github", "io.test/issues"}, token.Servers)
+	assert.True(t, token.Required)
+	assert.True(t, token.Shared)
+	assert.Empty(t, token.Conflicts)
+	require.Len(t, token.Usages, 2)
+	assert.Equal(t, "string", token.Usages[0].Format)
+	assert.True(t, token.Usages[1].Secret)
+
+	logLevel := report.Variables[1]
+	assert.False(t, logLevel.Required)
+	assert.Empty(t, logLevel.Conflicts, "choices are compared regardless of order")
+
+	port := report.Variables[2]
+	assert.Equal(t, []string{"format differs: io.test/issues expects number, io.test/proxy expects string"}, port.Conflicts)
+}
+
+func TestEnvVarCompatibilityConflicts(t *testing.T) {
+	t.Parallel()
+
+	a := NewTestServer("io.test/a", WithOCIPackage("a"),
+		WithEnvironmentVariables(envVar("API_KEY", secret),
+			envVar("MODE", func(v *model.KeyValueInput) { v.Choices = []string{"fast"} })))
+	b := NewTestServer("io.test/b", WithOCIPackage("b"),
+		WithEnvironmentVariables(envVar("API_KEY"),
+			envVar("MODE", func(v *model.KeyValueInput) { v.Choices = []string{"safe"} })))
+
+	report := EnvVarCompatibility([]*upstream.ServerJSON{&a, &b})
+
+	assert.Equal(t, []string{"API_KEY", "MODE"}, report.Conflicts)
+	assert.Equal(t, []string{"secrecy differs: io.test/a marks it secret, io.test/b marks it not secret"},
+		report.Variables[0].Conflicts)
+	assert.Equal(t, []string{"allowed values differ: io.test/a accepts [fast], io.test/b accepts [safe]"},
+		report.Variables[1].Conflicts)
+}
+
+func TestEnvVarCompatibilityEmpty(t *testing.T) {
+	t.Parallel()
+
+	server := NewTestServer("io.test/plain", WithOCIPackage("plain"))
+	report := EnvVarCompatibility([]*upstream.ServerJSON{&server})
+
+	assert.Empty(t, report.Variables)
+	assert.NotNil(t, report.Conflicts)
+	assert.NotNil(t, report.Shared)
+}
@@ -0,0 +1,193 @@
+package registry
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Facts are the typed metadata of a server, extracted from its publisher-provided metadata
+type Facts struct {
+	// LifecycleStatus is the lifecycle status, as returned by ExtractLifecycleStatus
+	LifecycleStatus string
+	// PublishedAt is the official publication timestamp, zero when HasPublishedAt is false
+	PublishedAt    time.Time
+	HasPublishedAt bool
+	// UpdatedAt is the official update timestamp, as returned by ExtractUpdatedAt
+	UpdatedAt    time.Time
+	HasUpdatedAt bool
+}
+
+// ExtractFacts extracts the typed metadata of a server
+func ExtractFacts(server *upstream.ServerJSON) Facts {
+	facts := Facts{LifecycleStatus: ExtractLifecycleStatus(server)}
+	facts.PublishedAt, facts.HasPublishedAt = ExtractPublishedAt(server)
+	facts.UpdatedAt, facts.HasUpdatedAt = ExtractUpdatedAt(server)
+	return facts
+}
+
+// FactsIndex holds the facts of a set of servers, extracted once when the servers are loaded,
+// so that filtering and sorting them never reads their metadata maps or parses timestamps.
+// Servers are identified by address; facts of servers missing from the index are extracted on
+// demand, and a nil index extracts them all on demand. The index must be rebuilt whenever the
+// servers it was built from are modified or moved. It is safe for concurrent reads.
+type FactsIndex struct {
+	facts map[*upstream.ServerJSON]Facts
+}
+
+// NewFactsIndex extracts the facts of the given groups of servers, typically the server
+// slices of the loaded registries
+func NewFactsIndex(groups ...[]upstream.ServerJSON) *FactsIndex {
+	count := 0
+	for _, servers := range groups {
+		count += len(servers)
+	}
+	idx := &FactsIndex{facts: make(map[*upstream.ServerJSON]Facts, count)}
+	for _, servers := range groups {
+		for i := range servers {
+			idx.facts[&servers[i]] = ExtractFacts(&servers[i])
+		}
+	}
+	return idx
+}
+
+// indexOf extracts the facts of the given servers
+func indexOf(servers []*upstream.ServerJSON) *FactsIndex {
+	idx := &FactsIndex{facts: make(map[*upstream.ServerJSON]Facts, len(servers))}
+	for _, server := range servers {
+		idx.facts[server] = ExtractFacts(server)
+	}
+	return idx
+}
+
+// Get returns the facts of a server
+func (idx *FactsIndex) Get(server *upstream.ServerJSON) Facts {
+	if idx != nil {
+		if facts, ok := idx.facts[server]; ok {
+			return facts
+		}
+	}
+	return ExtractFacts(server)
+}
+
+// FilterByLifecycleStatus is FilterByLifecycleStatus using the indexed facts
+func (idx *FactsIndex) FilterByLifecycleStatus(
+	servers []*upstream.ServerJSON,
+	includeDeprecated, includeDeleted bool,
+) []*upstream.ServerJSON {
+	if includeDeprecated && includeDeleted {
+		return servers
+	}
+
+	filtered := make([]*upstream.ServerJSON, 0, len(servers))
+	for _, server := range servers {
+		switch idx.Get(server).LifecycleStatus {
+		case StatusDeprecated:
+			if !includeDeprecated {
+				continue
+			}
+		case StatusDeleted:
+			if !includeDeleted {
+				continue
+			}
+		}
+		filtered = append(filtered, server)
+	}
+	return filtered
+}
+
+// FilterByTimestamps is FilterByTimestamps using the indexed facts
+func (idx *FactsIndex) FilterByTimestamps(
+	servers []*upstream.ServerJSON,
+	updatedAfter, publishedBefore time.Time,
+) []*upstream.ServerJSON {
+	if updatedAfter.IsZero() && publishedBefore.IsZero() {
+		return servers
+	}
+
+	filtered := make([]*upstream.ServerJSON, 0, len(servers))
+	for _, server := range servers {
+		facts := idx.Get(server)
+		if !updatedAfter.IsZero() && (!facts.HasUpdatedAt || !facts.UpdatedAt.After(updatedAfter)) {
+			continue
+		}
+		if !publishedBefore.IsZero() && (!facts.HasPublishedAt || !facts.PublishedAt.Before(publishedBefore)) {
+			continue
+		}
+		filtered = append(filtered, server)
+	}
+	return filtered
+}
+
+// SortServers is SortServers using the indexed facts
+func (idx *FactsIndex) SortServers(servers []*upstream.ServerJSON, key string, descending bool) {
+	if cmp := idx.comparator(servers, key, descending); cmp != nil {
+		slices.SortStableFunc(servers, cmp)
+	}
+}
+
+// TopServers is TopServers using the indexed facts
+func (idx *FactsIndex) TopServers(
+	servers []*upstream.ServerJSON, key string, descending bool, limit int,
+) []*upstream.ServerJSON {
+	cmp := idx.comparator(servers, key, descending)
+	if cmp == nil {
+		if limit > 0 && limit < len(servers) {
+			return slices.Clone(servers[:limit])
+		}
+		return slices.Clone(servers)
+	}
+	return TopK(servers, limit, cmp)
+}
+
+// comparator returns the comparison function ordering servers by the given key, or nil when
+// the key is unknown. Without an index, the facts of the servers are extracted once up front
+// rather than at every comparison.
+func (idx *FactsIndex) comparator(
+	servers []*upstream.ServerJSON, key string, descending bool,
+) func(a, b *upstream.ServerJSON) int {
+	var timestamp func(Facts) (time.Time, bool)
+	switch key {
+	case SortByName:
+	case SortByPublishedAt:
+		timestamp = func(f Facts) (time.Time, bool) { return f.PublishedAt, f.HasPublishedAt }
+	case SortByUpdatedAt:
+		timestamp = func(f Facts) (time.Time, bool) { return f.UpdatedAt, f.HasUpdatedAt }
+	default:
+		return nil
+	}
+	if idx == nil && timestamp != nil {
+		idx = indexOf(servers)
+	}
+
+	return func(a, b *upstream.ServerJSON) int {
+		if timestamp != nil {
+			aTime, aOK := timestamp(idx.Get(a))
+			bTime, bOK := timestamp(idx.Get(b))
+			if aOK != bOK {
+				// Servers without the timestamp come last regardless of direction
+				if aOK {
+					return -1
+				}
+				return 1
+			}
+			cmp := aTime.Compare(bTime)
+			if cmp != 0 {
+				if descending {
+					return -cmp
+				}
+				return cmp
+			}
+		}
+		cmp := strings.Compare(a.Name, b.Name)
+		if cmp == 0 {
+			cmp = CompareVersions(a.Version, b.Version)
+		}
+		if descending {
+			return -cmp
+		}
+		return cmp
+	}
+}
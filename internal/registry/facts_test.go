@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFactsIndex(t *testing.T) {
+	t.Parallel()
+
+	published := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	servers := []upstream.ServerJSON{
+		NewTestServer("io.example/updated", WithTimestamps(published, updated)),
+		NewTestServer("io.example/published", WithTimestamps(published, time.Time{})),
+		NewTestServer("io.example/undated"),
+	}
+	SetLifecycleStatus(&servers[1], StatusDeprecated)
+	idx := NewFactsIndex(servers)
+
+	assert.Equal(t, Facts{
+		LifecycleStatus: StatusActive,
+		PublishedAt:     published, HasPublishedAt: true,
+		UpdatedAt: updated, HasUpdatedAt: true,
+	}, idx.Get(&servers[0]))
+	assert.Equal(t, Facts{
+		LifecycleStatus: StatusDeprecated,
+		PublishedAt:     published, HasPublishedAt: true,
+		UpdatedAt: published, HasUpdatedAt: true,
+	}, idx.Get(&servers[1]))
+	assert.Equal(t, Facts{LifecycleStatus: StatusActive}, idx.Get(&servers[2]))
+
+	// The index answers from the facts extracted when it was built
+	SetLifecycleStatus(&servers[0], StatusDeleted)
+	assert.Equal(t, StatusActive, idx.Get(&servers[0]).LifecycleStatus)
+
+	// Servers missing from the index, and a nil index, extract facts on demand
+	other := NewTestServer("io.example/other")
+	SetLifecycleStatus(&other, StatusDeleted)
+	assert.Equal(t, StatusDeleted, idx.Get(&other).LifecycleStatus)
+	assert.Equal(t, StatusDeleted, (*FactsIndex)(nil).Get(&other).LifecycleStatus)
+}
+
+func TestFactsIndexMatchesExtraction(t *testing.T) {
+	t.Parallel()
+
+	servers := benchmarkServers(200)
+	values := make([]upstream.ServerJSON, len(servers))
+	for i, server := range servers {
+		values[i] = *server
+	}
+	SetLifecycleStatus(&values[3], StatusDeprecated)
+	SetLifecycleStatus(&values[7], StatusDeleted)
+	idx := NewFactsIndex(values)
+	pointers := make([]*upstream.ServerJSON, len(values))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	since := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t,
+		FilterByLifecycleStatus(pointers, false, true),
+		idx.FilterByLifecycleStatus(pointers, false, true))
+	assert.Equal(t,
+		FilterByTimestamps(pointers, since, time.Time{}),
+		idx.FilterByTimestamps(pointers, since, time.Time{}))
+	assert.Equal(t,
+		TopServers(pointers, SortByUpdatedAt, true, 20),
+		idx.TopServers(pointers, SortByUpdatedAt, true, 20))
+}
@@ -0,0 +1,213 @@
+package registry
+
+import (
+	"maps"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// FederationMetaKey is the publisher-provided metadata key under which the source registries
+	// of a server deduplicated across registries are recorded
+	FederationMetaKey = "io.github.stacklok/federation"
+
+	federationSourcesField = "sources"
+	federationNamesField   = "names"
+)
+
+// CanonicalRepositoryURL returns the canonical form of a repository URL, so that the various ways
+// of referring to the same repository compare equal: the scheme, credentials, "www." prefix,
+// ".git" suffix and trailing slashes are dropped, the host is lowercased and SCP-like SSH URLs
+// (git@host:owner/repo) are turned into host/owner/repo. It returns an empty string for empty URLs.
+func CanonicalRepositoryURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return ""
+	}
+	rawURL = strings.TrimPrefix(rawURL, "git+")
+
+	var host, repoPath string
+	if !strings.Contains(rawURL, "://") {
+		// SCP-like syntax: [user@]host:owner/repo
+		if at := strings.Index(rawURL, "@"); at >= 0 {
+			rawURL = rawURL[at+1:]
+		}
+		host, repoPath, _ = strings.Cut(rawURL, ":")
+	} else if parsed, err := url.Parse(rawURL); err == nil {
+		host, repoPath = parsed.Hostname(), parsed.Path
+	} else {
+		return strings.ToLower(rawURL)
+	}
+
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	if repoPath == "" {
+		return host
+	}
+	// Hosting services treat owner and repository names case-insensitively
+	return host + "/" + strings.ToLower(repoPath)
+}
+
+// GitHubRepository returns the owner and name of a GitHub repository URL, lowercased as by
+// CanonicalRepositoryURL. Paths below the repository (e.g. /tree/main/servers/a) are ignored.
+func GitHubRepository(repositoryURL string) (owner, repo string, ok bool) {
+	parts := strings.Split(CanonicalRepositoryURL(repositoryURL), "/")
+	if len(parts) < 3 || parts[0] != "github.com" || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// repositoryKey returns the key identifying the source code of a server version: its canonical
+// repository URL and subfolder, and its version. It returns an empty string for servers without
+// a repository URL.
+func repositoryKey(server *upstream.ServerJSON) string {
+	if server.Repository == nil {
+		return ""
+	}
+	repository := CanonicalRepositoryURL(server.Repository.URL)
+	if repository == "" {
+		return ""
+	}
+	subfolder := strings.Trim(path.Clean("/"+server.Repository.Subfolder), "/")
+	return repository + "//" + subfolder + "@" + server.Version
+}
+
+// federatedServer is a server of the deduplicated list and the registries it was found in
+type federatedServer struct {
+	server  *upstream.ServerJSON
+	first   int
+	sources []string
+	names   []string
+}
+
+// DeduplicateByRepository merges the versions of a server published to several registries,
+// possibly under different names, into a single record. Server versions are the same when their
+// canonical repository URL (see CanonicalRepositoryURL), repository subfolder and version are.
+// sources are the names of the registries the servers come from, aligned with servers.
+//
+// The first record of a server is kept in place and completed with the fields the others provide,
+// and the names of all its source registries, and its other names, are recorded under
+// FederationMetaKey. Merged records are copies: the given servers are not modified.
+// Servers without repository URL, and servers of the same registry, are never merged.
+func DeduplicateByRepository(servers []*upstream.ServerJSON, sources []string) []*upstream.ServerJSON {
+	result, _ := DeduplicateByRepositoryIndexed(servers, sources)
+	return result
+}
+
+// DeduplicateByRepositoryIndexed deduplicates servers as DeduplicateByRepository does, also returning,
+// aligned with the deduplicated records, the index in servers of the first record of each server
+func DeduplicateByRepositoryIndexed(servers []*upstream.ServerJSON, sources []string) ([]*upstream.ServerJSON, []int) {
+	merged := make([]*federatedServer, 0, len(servers))
+	byKey := make(map[string]*federatedServer, len(servers))
+	for i, server := range servers {
+		source := ""
+		if i < len(sources) {
+			source = sources[i]
+		}
+
+		key := repositoryKey(server)
+		if existing, ok := byKey[key]; ok && key != "" && !slices.Contains(existing.sources, source) {
+			existing.merge(server, source)
+			continue
+		}
+
+		federated := &federatedServer{server: server, first: i, sources: []string{source}}
+		merged = append(merged, federated)
+		if _, ok := byKey[key]; !ok && key != "" {
+			byKey[key] = federated
+		}
+	}
+
+	result := make([]*upstream.ServerJSON, len(merged))
+	firsts := make([]int, len(merged))
+	for i, federated := range merged {
+		result[i] = federated.server
+		firsts[i] = federated.first
+	}
+	return result, firsts
+}
+
+// merge completes the record with the fields of the same server found in another registry
+func (f *federatedServer) merge(other *upstream.ServerJSON, source string) {
+	if len(f.sources) == 1 {
+		// Copy the record on its first merge so that the original server is left untouched
+		server := *f.server
+		server.Meta = &upstream.ServerMeta{PublisherProvided: make(map[string]interface{})}
+		if f.server.Meta != nil {
+			maps.Copy(server.Meta.PublisherProvided, f.server.Meta.PublisherProvided)
+		}
+		f.server = &server
+	}
+	server := f.server
+
+	f.sources = append(f.sources, source)
+	if other.Name != server.Name && !slices.Contains(f.names, other.Name) {
+		f.names = append(f.names, other.Name)
+	}
+
+	if server.Title == "" {
+		server.Title = other.Title
+	}
+	if server.Description == "" {
+		server.Description = other.Description
+	}
+	if server.WebsiteURL == "" {
+		server.WebsiteURL = other.WebsiteURL
+	}
+	if len(server.Icons) == 0 {
+		server.Icons = other.Icons
+	}
+	if len(server.Packages) == 0 {
+		server.Packages = other.Packages
+	}
+	if len(server.Remotes) == 0 {
+		server.Remotes = other.Remotes
+	}
+	if other.Meta != nil {
+		for key, value := range other.Meta.PublisherProvided {
+			if _, ok := server.Meta.PublisherProvided[key]; !ok {
+				server.Meta.PublisherProvided[key] = value
+			}
+		}
+	}
+
+	federation := map[string]interface{}{federationSourcesField: toInterfaces(f.sources)}
+	if len(f.names) > 0 {
+		federation[federationNamesField] = toInterfaces(f.names)
+	}
+	server.Meta.PublisherProvided[FederationMetaKey] = federation
+}
+
+// ExtractFederatedSources returns the registries a deduplicated server was found in, if it was
+// merged from several registries
+func ExtractFederatedSources(server *upstream.ServerJSON) []string {
+	if server == nil || server.Meta == nil {
+		return nil
+	}
+	federation, ok := server.Meta.PublisherProvided[FederationMetaKey].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	values, _ := federation[federationSourcesField].([]interface{})
+	sources := make([]string, 0, len(values))
+	for _, value := range values {
+		if source, ok := value.(string); ok {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// toInterfaces converts strings to the values of JSON arrays
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
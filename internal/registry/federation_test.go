@@ -0,0 +1,116 @@
+package registry
+
+import (
+	"testing"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalRepositoryURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://github.com/Acme/Weather", want: "github.com/acme/weather"},
+		{url: "https://www.github.com/acme/weather.git", want: "github.com/acme/weather"},
+		{url: "http://github.com/acme/weather/", want: "github.com/acme/weather"},
+		{url: "git+https://token@github.com/acme/weather.git", want: "github.com/acme/weather"},
+		{url: "git@github.com:acme/weather.git", want: "github.com/acme/weather"},
+		{url: "ssh://git@GitHub.com/acme/weather", want: "github.com/acme/weather"},
+		{url: "https://gitlab.example.com:8443/group/sub/weather", want: "gitlab.example.com/group/sub/weather"},
+		{url: "  ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, CanonicalRepositoryURL(tt.url))
+		})
+	}
+}
+
+func TestGitHubRepository(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url       string
+		wantOwner string
+		wantRepo  string
+		wantOK    bool
+	}{
+		{url: "https://github.com/Acme/Server", wantOwner: "acme", wantRepo: "server", wantOK: true},
+		{url: "git@github.com:acme/server.git", wantOwner: "acme", wantRepo: "server", wantOK: true},
+		{url: "https://github.com/acme/monorepo/tree/main/servers/a", wantOwner: "acme", wantRepo: "monorepo", wantOK: true},
+		{url: "https://github.com/acme"},
+		{url: "https://gitlab.com/acme/server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			owner, repo, ok := GitHubRepository(tt.url)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantOwner, owner)
+			assert.Equal(t, tt.wantRepo, repo)
+		})
+	}
+}
+
+func federationTestServer(name, repository, subfolder, version string) *upstream.ServerJSON {
+	server := NewTestServer(name, WithServerVersion(version))
+	server.Repository = &model.Repository{URL: repository, Subfolder: subfolder}
+	return &server
+}
+
+func TestDeduplicateByRepository(t *testing.T) {
+	t.Parallel()
+
+	official := federationTestServer("io.github.acme/weather", "https://github.com/acme/weather", "", "1.0.0")
+	official.Description = ""
+	official.Meta = &upstream.ServerMeta{PublisherProvided: map[string]interface{}{"official": true}}
+	mirrored := federationTestServer("com.example/weather", "git@github.com:acme/weather.git", "", "1.0.0")
+	mirrored.Description = "Weather forecasts"
+	mirrored.Meta = &upstream.ServerMeta{PublisherProvided: map[string]interface{}{"mirror": "internal"}}
+	internal := federationTestServer("com.example.internal/weather", "https://github.com/Acme/weather", "", "1.0.0")
+
+	otherVersion := federationTestServer("com.example/weather", "https://github.com/acme/weather", "", "0.9.0")
+	otherSubfolder := federationTestServer("com.example/forecast", "https://github.com/acme/weather", "forecast", "1.0.0")
+	sameRegistry := federationTestServer("io.github.acme/weather-beta", "https://github.com/acme/weather", "", "1.0.0")
+	noRepository := federationTestServer("com.example/notes", "", "", "1.0.0")
+
+	servers := []*upstream.ServerJSON{
+		official, otherVersion, mirrored, otherSubfolder, sameRegistry, noRepository, internal,
+	}
+	sources := []string{"official", "mirror", "mirror", "mirror", "official", "mirror", "internal"}
+
+	result := DeduplicateByRepository(servers, sources)
+	require.Len(t, result, 5)
+
+	merged := result[0]
+	assert.Equal(t, "io.github.acme/weather", merged.Name)
+	assert.Equal(t, "Weather forecasts", merged.Description, "empty fields are completed from the other records")
+	assert.Equal(t, true, merged.Meta.PublisherProvided["official"])
+	assert.Equal(t, "internal", merged.Meta.PublisherProvided["mirror"])
+	assert.Equal(t, []string{"official", "mirror", "internal"}, ExtractFederatedSources(merged))
+	assert.Equal(t,
+		[]interface{}{"com.example/weather", "com.example.internal/weather"},
+		merged.Meta.PublisherProvided[FederationMetaKey].(map[string]interface{})[federationNamesField])
+
+	assert.Same(t, otherVersion, result[1])
+	assert.Same(t, otherSubfolder, result[2])
+	assert.Same(t, sameRegistry, result[3])
+	assert.Same(t, noRepository, result[4])
+
+	// The given servers are left untouched
+	assert.Empty(t, official.Description)
+	assert.NotContains(t, official.Meta.PublisherProvided, FederationMetaKey)
+	assert.Empty(t, ExtractFederatedSources(otherVersion))
+
+	_, firsts := DeduplicateByRepositoryIndexed(servers, sources)
+	assert.Equal(t, []int{0, 1, 3, 4, 5}, firsts)
+}
@@ -0,0 +1,39 @@
+package registry
+
+import (
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Health statuses of servers, as determined by periodic link checks
+const (
+	// HealthOK is the health of servers without known broken links, including unchecked servers
+	HealthOK = "ok"
+	// HealthStale is the health of servers with broken links, archived repositories or missing packages
+	HealthStale = "stale"
+
+	// HealthMetaKey is the publisher-provided metadata key under which the health of a checked
+	// server is recorded in API responses
+	HealthMetaKey = "io.github.stacklok/health"
+)
+
+// HealthStatuses returns the supported health statuses
+func HealthStatuses() []string {
+	return []string{HealthOK, HealthStale}
+}
+
+// HealthLookup returns the health of servers by name
+type HealthLookup interface {
+	// Health returns HealthOK or HealthStale
+	Health(name string) string
+}
+
+// FilterByHealth returns the servers whose health, as returned by lookup, is the given health
+func FilterByHealth(servers []*upstream.ServerJSON, lookup HealthLookup, health string) []*upstream.ServerJSON {
+	filtered := make([]*upstream.ServerJSON, 0, len(servers))
+	for _, server := range servers {
+		if lookup.Health(server.Name) == health {
+			filtered = append(filtered, server)
+		}
+	}
+	return filtered
+}
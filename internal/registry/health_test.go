@@ -0,0 +1,33 @@
+package registry
+
+import (
+	"testing"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+// healthMap is a HealthLookup of the stale servers it holds
+type healthMap map[string]bool
+
+func (m healthMap) Health(name string) string {
+	if m[name] {
+		return HealthStale
+	}
+	return HealthOK
+}
+
+func TestFilterByHealth(t *testing.T) {
+	t.Parallel()
+
+	active := NewTestServer("io.test/active")
+	archived := NewTestServer("io.test/archived")
+	servers := []*upstream.ServerJSON{&active, &archived}
+	lookup := healthMap{"io.test/archived": true}
+
+	stale := FilterByHealth(servers, lookup, HealthStale)
+	assert.Equal(t, []*upstream.ServerJSON{&archived}, stale)
+
+	ok := FilterByHealth(servers, lookup, HealthOK)
+	assert.Equal(t, []*upstream.ServerJSON{&active}, ok)
+}
@@ -0,0 +1,68 @@
+package registry
+
+import (
+	"regexp"
+	"strings"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// MaxInjectionRiskScore is the highest prompt-injection risk score
+const MaxInjectionRiskScore = 100
+
+// InjectionRisk is the prompt-injection risk assessment of a server
+type InjectionRisk struct {
+	// Score ranges from 0 (no suspicious content) to MaxInjectionRiskScore
+	Score int `json:"score"`
+	// Signals name the suspicious patterns found, e.g. "instruction-override"
+	Signals []string `json:"signals"`
+}
+
+// injectionSignal is a suspicious pattern and the score it adds when found
+type injectionSignal struct {
+	name    string
+	weight  int
+	pattern *regexp.Regexp
+}
+
+// invisibleCharacters match zero-width, bidirectional control, and Unicode tag characters,
+// which can hide instructions from people reading a description
+var invisibleCharacters = regexp.MustCompile(`[\x{200B}-\x{200F}\x{202A}-\x{202E}\x{2060}-\x{2064}\x{FEFF}\x{E0000}-\x{E007F}]`)
+
+// injectionSignals are the patterns scored by InjectionRiskOf
+var injectionSignals = []injectionSignal{
+	{"instruction-override", 40, regexp.MustCompile(`(?i)` + instructionOverridePattern)},
+	{"role-manipulation", 30, regexp.MustCompile(`(?i)` + roleManipulationPattern)},
+	{"concealment", 30, regexp.MustCompile(`(?i)` + concealmentPattern)},
+	{"tool-invocation-bait", 25, regexp.MustCompile(`(?i)` + toolBaitPattern)},
+	{"hidden-instruction-tags", 25, regexp.MustCompile(`(?i)` + hiddenTagPattern)},
+	{"invisible-characters", 20, invisibleCharacters},
+	{"exfiltration-url", 20, suspiciousURL},
+	{"script", 15, scriptBlock},
+}
+
+// InjectionRiskOf scores the title and description of a server for patterns commonly used to
+// inject instructions into AI agents. Each kind of signal is counted once.
+func InjectionRiskOf(server *upstream.ServerJSON) InjectionRisk {
+	text := strings.Join([]string{server.Title, server.Description}, "\n")
+	risk := InjectionRisk{Signals: []string{}}
+	for _, signal := range injectionSignals {
+		if signal.pattern.MatchString(text) {
+			risk.Score += signal.weight
+			risk.Signals = append(risk.Signals, signal.name)
+		}
+	}
+	risk.Score = min(risk.Score, MaxInjectionRiskScore)
+	return risk
+}
+
+// FilterByInjectionRisk returns the servers whose prompt-injection risk score is at most maxScore
+func FilterByInjectionRisk(servers []*upstream.ServerJSON, maxScore int) []*upstream.ServerJSON {
+	filtered := make([]*upstream.ServerJSON, 0, len(servers))
+	for _, server := range servers {
+		if InjectionRiskOf(server).Score <= maxScore {
+			filtered = append(filtered, server)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,129 @@
+package registry
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Publisher-provided metadata keys set on the servers of Kubernetes registries
+// (see the kubernetes package) identifying the MCPServer resource they were built from
+const (
+	kubernetesNamespaceKey = "kubernetes_namespace"
+	kubernetesNameKey      = "kubernetes_name"
+	kubernetesImageKey     = "kubernetes_image"
+)
+
+// Installation is an MCPServer resource deployed in the cluster
+type Installation struct {
+	// Namespace and Name identify the MCPServer resource
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Image is the container image the resource runs
+	Image string `json:"image"`
+	// Version is the tag, or digest, of the image
+	Version string `json:"version"`
+}
+
+// InstalledServer is a catalog server running in the cluster
+type InstalledServer struct {
+	// Name is the name of the catalog server
+	Name string `json:"name"`
+	// Installations are the MCPServer resources running one of its images, in any version
+	Installations []Installation `json:"installations"`
+}
+
+// ExtractInstallation extracts the MCPServer resource a server of a Kubernetes registry was built
+// from. The boolean result is false for other servers, including Kubernetes servers not running
+// a container image (virtual servers and remote proxies).
+func ExtractInstallation(server *upstream.ServerJSON) (Installation, bool) {
+	if server == nil || server.Meta == nil {
+		return Installation{}, false
+	}
+	meta := server.Meta.PublisherProvided
+	namespace, _ := meta[kubernetesNamespaceKey].(string)
+	name, _ := meta[kubernetesNameKey].(string)
+	image, _ := meta[kubernetesImageKey].(string)
+	if namespace == "" || name == "" || image == "" {
+		return Installation{}, false
+	}
+	_, version := imageRepository(image)
+	return Installation{Namespace: namespace, Name: name, Image: image, Version: version}, true
+}
+
+// MatchInstallations cross-references the catalog servers with the MCPServer resources deployed in
+// the cluster, both taken from servers, and returns the catalog servers running in the cluster sorted
+// by name. A resource runs a catalog server when its image is one of the OCI packages of any version
+// of the server, regardless of tag.
+func MatchInstallations(servers []*upstream.ServerJSON) []InstalledServer {
+	var installations []Installation
+	catalog := make([]*upstream.ServerJSON, 0, len(servers))
+	for _, server := range servers {
+		if installation, ok := ExtractInstallation(server); ok {
+			installations = append(installations, installation)
+		} else {
+			catalog = append(catalog, server)
+		}
+	}
+	if len(installations) == 0 {
+		return []InstalledServer{}
+	}
+
+	byRepository := make(map[string][]Installation, len(installations))
+	for _, installation := range installations {
+		repository, _ := imageRepository(installation.Image)
+		byRepository[repository] = append(byRepository[repository], installation)
+	}
+
+	installed := make(map[string]map[Installation]struct{})
+	for _, server := range catalog {
+		for _, pkg := range server.Packages {
+			if pkg.RegistryType != model.RegistryTypeOCI {
+				continue
+			}
+			repository, _ := imageRepository(pkg.Identifier)
+			for _, installation := range byRepository[repository] {
+				if installed[server.Name] == nil {
+					installed[server.Name] = make(map[Installation]struct{})
+				}
+				installed[server.Name][installation] = struct{}{}
+			}
+		}
+	}
+
+	result := make([]InstalledServer, 0, len(installed))
+	for name, set := range installed {
+		entry := InstalledServer{Name: name, Installations: make([]Installation, 0, len(set))}
+		for installation := range set {
+			entry.Installations = append(entry.Installations, installation)
+		}
+		slices.SortFunc(entry.Installations, func(a, b Installation) int {
+			return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+		})
+		result = append(result, entry)
+	}
+	slices.SortFunc(result, func(a, b InstalledServer) int { return cmp.Compare(a.Name, b.Name) })
+	return result
+}
+
+// imageRepository splits an image reference into its repository, including the registry host
+// except for Docker Hub, and its tag or digest ("latest" when neither is set)
+func imageRepository(image string) (repository, version string) {
+	repository, digest, hasDigest := strings.Cut(image, "@")
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository, version = repository[:i], repository[i+1:]
+	}
+	if hasDigest {
+		version = digest
+	}
+	if version == "" {
+		version = "latest"
+	}
+	for _, prefix := range []string{"docker.io/", "index.docker.io/"} {
+		repository = strings.TrimPrefix(repository, prefix)
+	}
+	return strings.TrimPrefix(repository, "library/"), version
+}
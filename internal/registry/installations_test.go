@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"testing"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+// deployedServer returns a server of a Kubernetes registry built from an MCPServer resource
+func deployedServer(namespace, name, image string) upstream.ServerJSON {
+	return NewTestServer("com.toolhive.k8s."+namespace+"/"+name,
+		WithOCIPackage(image),
+		WithMetadata(kubernetesNamespaceKey, namespace),
+		WithMetadata(kubernetesNameKey, name),
+		WithMetadata(kubernetesImageKey, image),
+	)
+}
+
+func TestMatchInstallations(t *testing.T) {
+	t.Parallel()
+
+	fetchV1 := NewTestServer("io.example/fetch", WithServerVersion("1.0.0"), WithOCIPackage("ghcr.io/example/fetch:1.0.0"))
+	fetchV2 := NewTestServer("io.example/fetch", WithServerVersion("2.0.0"), WithOCIPackage("ghcr.io/example/fetch:2.0.0"))
+	github := NewTestServer("io.example/github", WithOCIPackage("docker.io/library/github-mcp:1.0.0"))
+	notes := NewTestServer("io.example/notes", WithOCIPackage("ghcr.io/example/notes:1.0.0"))
+	remote := NewTestServer("io.example/remote", WithHTTPPackage("https://remote.example/mcp"))
+	teamA := deployedServer("team-a", "fetch", "ghcr.io/example/fetch:1.0.0")
+	teamB := deployedServer("team-b", "fetch", "ghcr.io/example/fetch@sha256:abc")
+	gh := deployedServer("team-b", "gh", "github-mcp")
+	proxy := NewTestServer("com.toolhive.k8s.team-a/proxy",
+		WithMetadata(kubernetesNamespaceKey, "team-a"), WithMetadata(kubernetesNameKey, "proxy"))
+
+	servers := []*upstream.ServerJSON{&fetchV1, &fetchV2, &github, &notes, &remote, &teamB, &teamA, &gh, &proxy}
+
+	assert.Equal(t, []InstalledServer{
+		{Name: "io.example/fetch", Installations: []Installation{
+			{Namespace: "team-a", Name: "fetch", Image: "ghcr.io/example/fetch:1.0.0", Version: "1.0.0"},
+			{Namespace: "team-b", Name: "fetch", Image: "ghcr.io/example/fetch@sha256:abc", Version: "sha256:abc"},
+		}},
+		{Name: "io.example/github", Installations: []Installation{
+			{Namespace: "team-b", Name: "gh", Image: "github-mcp", Version: "latest"},
+		}},
+	}, MatchInstallations(servers))
+
+	assert.Empty(t, MatchInstallations([]*upstream.ServerJSON{&fetchV1, &notes}))
+}
+
+func TestExtractInstallation(t *testing.T) {
+	t.Parallel()
+
+	deployed := deployedServer("team-a", "fetch", "localhost:5000/fetch:dev")
+	installation, ok := ExtractInstallation(&deployed)
+	assert.True(t, ok)
+	assert.Equal(t, Installation{Namespace: "team-a", Name: "fetch", Image: "localhost:5000/fetch:dev", Version: "dev"}, installation)
+
+	catalog := NewTestServer("io.example/fetch", WithOCIPackage("ghcr.io/example/fetch:1.0.0"))
+	_, ok = ExtractInstallation(&catalog)
+	assert.False(t, ok)
+}
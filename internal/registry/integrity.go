@@ -0,0 +1,22 @@
+package registry
+
+import (
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// PackageDigest returns the content digest pinning a package, in <algorithm>:<hex> form.
+// OCI packages are pinned by the image digest of their reference (e.g. "image@sha256:..."),
+// other packages by their file SHA-256 hash. Returns an empty string for unpinned packages.
+func PackageDigest(pkg model.Package) string {
+	if pkg.RegistryType == model.RegistryTypeOCI {
+		if _, digest, ok := strings.Cut(pkg.Identifier, "@"); ok && strings.Contains(digest, ":") {
+			return digest
+		}
+	}
+	if pkg.FileSHA256 != "" {
+		return "sha256:" + pkg.FileSHA256
+	}
+	return ""
+}
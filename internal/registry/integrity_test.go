@@ -0,0 +1,49 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageDigest(t *testing.T) {
+	t.Parallel()
+
+	hash := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name string
+		pkg  model.Package
+		want string
+	}{
+		{
+			name: "oci image pinned by digest",
+			pkg:  model.Package{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/test/server:1.0.0@sha256:" + hash},
+			want: "sha256:" + hash,
+		},
+		{
+			name: "oci image pinned by tag only",
+			pkg:  model.Package{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/test/server:1.0.0"},
+			want: "",
+		},
+		{
+			name: "file hash",
+			pkg:  model.Package{RegistryType: model.RegistryTypeMCPB, Identifier: "https://example.com/server.mcpb", FileSHA256: hash},
+			want: "sha256:" + hash,
+		},
+		{
+			name: "npm package without hash",
+			pkg:  model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "@test/server", Version: "1.0.0"},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, PackageDigest(tt.pkg))
+		})
+	}
+}
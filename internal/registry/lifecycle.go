@@ -0,0 +1,46 @@
+package registry
+
+import (
+	"strings"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// StatusActive is the lifecycle status of servers that are neither deprecated nor deleted
+	StatusActive = "active"
+	// StatusDeprecated is the lifecycle status of servers that are still available but no longer recommended
+	StatusDeprecated = "deprecated"
+	// StatusDeleted is the lifecycle status of servers that were removed upstream
+	StatusDeleted = "deleted"
+
+	statusField = "status"
+)
+
+// SetLifecycleStatus records the official lifecycle status (active, deprecated or deleted)
+// of a server in its publisher-provided metadata. An empty status is not recorded.
+func SetLifecycleStatus(server *upstream.ServerJSON, status string) {
+	if status == "" {
+		return
+	}
+	setOfficialField(server, statusField, strings.ToLower(status))
+}
+
+// ExtractLifecycleStatus extracts the lifecycle status of a server, in lower case, from the official
+// metadata kept by the registry. Servers without it are considered active: a status set by publishers
+// in their own metadata is ignored.
+func ExtractLifecycleStatus(server *upstream.ServerJSON) string {
+	if status, ok := extractOfficialField(server, statusField); ok && status != "" {
+		return strings.ToLower(status)
+	}
+	return StatusActive
+}
+
+// FilterByLifecycleStatus returns the servers that are neither deprecated nor deleted,
+// unless includeDeprecated or includeDeleted is set respectively
+func FilterByLifecycleStatus(
+	servers []*upstream.ServerJSON,
+	includeDeprecated, includeDeleted bool,
+) []*upstream.ServerJSON {
+	return (*FactsIndex)(nil).FilterByLifecycleStatus(servers, includeDeprecated, includeDeleted)
+}
@@ -0,0 +1,100 @@
+package registry
+
+import (
+	"testing"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractLifecycleStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		server upstream.ServerJSON
+		want   string
+	}{
+		{
+			name:   "official status",
+			server: NewTestServer("io.test/server", WithLifecycleStatus("Deleted")),
+			want:   StatusDeleted,
+		},
+		{
+			name:   "publisher status ignored",
+			server: NewTestServer("io.test/server", WithToolHiveMetadata("status", "Deprecated")),
+			want:   StatusActive,
+		},
+		{
+			name: "official status takes precedence",
+			server: NewTestServer("io.test/server",
+				WithToolHiveMetadata("status", "Active"),
+				WithLifecycleStatus("deprecated")),
+			want: StatusDeprecated,
+		},
+		{
+			name:   "no status is active",
+			server: NewTestServer("io.test/server"),
+			want:   StatusActive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ExtractLifecycleStatus(&tt.server))
+		})
+	}
+}
+
+func TestFilterByLifecycleStatus(t *testing.T) {
+	t.Parallel()
+
+	servers := func() []*upstream.ServerJSON {
+		active := NewTestServer("io.test/active", WithLifecycleStatus(StatusActive))
+		deprecated := NewTestServer("io.test/deprecated", WithLifecycleStatus(StatusDeprecated))
+		deleted := NewTestServer("io.test/deleted", WithLifecycleStatus(StatusDeleted))
+		unknown := NewTestServer("io.test/unknown")
+		return []*upstream.ServerJSON{&active, &deprecated, &deleted, &unknown}
+	}
+
+	tests := []struct {
+		name              string
+		includeDeprecated bool
+		includeDeleted    bool
+		want              []string
+	}{
+		{
+			name: "excluded by default",
+			want: []string{"io.test/active", "io.test/unknown"},
+		},
+		{
+			name:              "include deprecated",
+			includeDeprecated: true,
+			want:              []string{"io.test/active", "io.test/deprecated", "io.test/unknown"},
+		},
+		{
+			name:           "include deleted",
+			includeDeleted: true,
+			want:           []string{"io.test/active", "io.test/deleted", "io.test/unknown"},
+		},
+		{
+			name:              "include both",
+			includeDeprecated: true,
+			includeDeleted:    true,
+			want:              []string{"io.test/active", "io.test/deprecated", "io.test/deleted", "io.test/unknown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			filtered := FilterByLifecycleStatus(servers(), tt.includeDeprecated, tt.includeDeleted)
+			names := make([]string, 0, len(filtered))
+			for _, server := range filtered {
+				names = append(names, server.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package registry
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Severities of lint issues
+const (
+	// LintError is the severity of issues the entry must not be published with
+	LintError = "error"
+	// LintWarning is the severity of issues degrading how the entry is found or presented
+	LintWarning = "warning"
+)
+
+// LintIssue is a problem found in a registry entry
+type LintIssue struct {
+	Severity string `json:"severity"`
+	// Field is the path of the field at fault (e.g. "packages[0].identifier"), empty for the whole entry
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// toolHiveStringFields are the ToolHive metadata fields holding a string
+var toolHiveStringFields = []string{"tier", "status", "transport", "repository_url"}
+
+// toolHiveListFields are the ToolHive metadata fields holding a list of strings
+var toolHiveListFields = []string{"tags", "tools"}
+
+// Lint checks a registry entry for the problems maintainers want fixed before publishing it:
+// missing name, version or description, no tags, a malformed repository URL, nothing to install
+// or connect to, and ToolHive metadata of the wrong shape. Links are not requested; see the
+// linkcheck package for broken links. Errors come first.
+func Lint(server *upstream.ServerJSON) []LintIssue {
+	issues := []LintIssue{}
+	add := func(severity, field, format string, args ...any) {
+		issues = append(issues, LintIssue{Severity: severity, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if server.Name == "" {
+		add(LintError, "name", "name is missing")
+	}
+	if server.Version == "" {
+		add(LintError, "version", "version is missing")
+	}
+	if server.Description == "" {
+		add(LintError, "description", "description is missing")
+	}
+	if server.Repository != nil && server.Repository.URL != "" {
+		if parsed, err := url.Parse(server.Repository.URL); err != nil ||
+			(parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			add(LintError, "repository.url", "repository URL %q is not an absolute http(s) URL", server.Repository.URL)
+		}
+	} else {
+		add(LintWarning, "repository.url", "repository URL is missing")
+	}
+	if len(server.Packages) == 0 && len(server.Remotes) == 0 {
+		add(LintError, "packages", "no package or remote endpoint is published")
+	}
+	for i, pkg := range server.Packages {
+		if pkg.RegistryType == "" {
+			add(LintError, fmt.Sprintf("packages[%d].registryType", i), "registry type is missing")
+		}
+		if pkg.Identifier == "" {
+			add(LintError, fmt.Sprintf("packages[%d].identifier", i), "identifier is missing")
+		}
+	}
+	for i, remote := range server.Remotes {
+		if parsed, err := url.Parse(remote.URL); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			add(LintError, fmt.Sprintf("remotes[%d].url", i), "remote URL %q is not an absolute URL", remote.URL)
+		}
+	}
+	issues = append(issues, lintToolHiveMetadata(server)...)
+	if len(ExtractTags(server)) == 0 {
+		add(LintWarning, "tags", "no tags are set; the entry cannot be found by tag")
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Severity == LintError && issues[j].Severity != LintError
+	})
+	return issues
+}
+
+// lintToolHiveMetadata checks the shape of the ToolHive metadata of a server, following the nesting
+// conventions of ExtractTags: Meta.PublisherProvided[publisher][entry][field]
+func lintToolHiveMetadata(server *upstream.ServerJSON) []LintIssue {
+	issues := []LintIssue{}
+	if server.Meta == nil {
+		return issues
+	}
+	if value, ok := server.Meta.PublisherProvided[ToolHiveMetaNamespace]; ok {
+		if _, ok := value.(map[string]interface{}); !ok {
+			issues = append(issues, LintIssue{Severity: LintError, Field: "_meta." + ToolHiveMetaNamespace,
+				Message: fmt.Sprintf("ToolHive metadata is a %s, not an object", jsonType(value))})
+		}
+	}
+
+	publishers := make([]string, 0, len(server.Meta.PublisherProvided))
+	for publisher := range server.Meta.PublisherProvided {
+		publishers = append(publishers, publisher)
+	}
+	sort.Strings(publishers)
+	for _, publisher := range publishers {
+		entries, ok := server.Meta.PublisherProvided[publisher].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		names := make([]string, 0, len(entries))
+		for name := range entries {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fields, ok := entries[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			prefix := "_meta." + publisher + "." + name + "."
+			for _, field := range toolHiveStringFields {
+				if value, ok := fields[field]; ok {
+					if _, ok := value.(string); !ok {
+						issues = append(issues, LintIssue{Severity: LintError, Field: prefix + field,
+							Message: fmt.Sprintf("%s is a %s, not a string", field, jsonType(value))})
+					}
+				}
+			}
+			for _, field := range toolHiveListFields {
+				if value, ok := fields[field]; ok && !isStringList(value) {
+					issues = append(issues, LintIssue{Severity: LintError, Field: prefix + field,
+						Message: fmt.Sprintf("%s is not a list of strings", field)})
+				}
+			}
+			if value, ok := fields["metadata"]; ok {
+				metadata, ok := value.(map[string]interface{})
+				if !ok {
+					issues = append(issues, LintIssue{Severity: LintError, Field: prefix + "metadata",
+						Message: fmt.Sprintf("metadata is a %s, not an object", jsonType(value))})
+				} else if stars, ok := metadata["stars"]; ok {
+					if _, ok := toInt(stars); !ok {
+						issues = append(issues, LintIssue{Severity: LintError, Field: prefix + "metadata.stars",
+							Message: "stars is not a number"})
+					}
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// isStringList returns true for lists whose items are all strings
+func isStringList(value interface{}) bool {
+	switch list := value.(type) {
+	case []string:
+		return true
+	case []interface{}:
+		for _, item := range list {
+			if _, ok := item.(string); !ok {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}, []string:
+		return "list"
+	default:
+		return "number"
+	}
+}
@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"testing"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		server upstream.ServerJSON
+		want   []LintIssue
+	}{
+		{
+			name: "complete entry",
+			server: NewTestServer("io.test/complete",
+				WithDescription("A complete entry"),
+				WithOCIPackage("ghcr.io/test/complete:1.0.0"),
+				WithTags("testing"),
+				func(server *upstream.ServerJSON) {
+					server.Repository = &model.Repository{URL: "https://github.com/test/complete", Source: "github"}
+				},
+			),
+			want: []LintIssue{},
+		},
+		{
+			name:   "empty entry",
+			server: upstream.ServerJSON{},
+			want: []LintIssue{
+				{Severity: LintError, Field: "name", Message: "name is missing"},
+				{Severity: LintError, Field: "version", Message: "version is missing"},
+				{Severity: LintError, Field: "description", Message: "description is missing"},
+				{Severity: LintError, Field: "packages", Message: "no package or remote endpoint is published"},
+				{Severity: LintWarning, Field: "repository.url", Message: "repository URL is missing"},
+				{Severity: LintWarning, Field: "tags", Message: "no tags are set; the entry cannot be found by tag"},
+			},
+		},
+		{
+			name: "malformed links and metadata",
+			server: NewTestServer("io.test/malformed",
+				WithDescription("Malformed"),
+				WithTags("testing"),
+				WithToolHiveMetadata("tier", 3),
+				WithToolHiveMetadata("tools", []interface{}{"search", 1}),
+				WithToolHiveMetadata("metadata", map[string]interface{}{"stars": "many"}),
+				func(server *upstream.ServerJSON) {
+					server.Repository = &model.Repository{URL: "github.com/test/malformed"}
+					server.Packages = []model.Package{{RegistryType: model.RegistryTypeNPM}}
+					server.Remotes = []model.Transport{{Type: "sse", URL: "/sse"}}
+				},
+			),
+			want: []LintIssue{
+				{Severity: LintError, Field: "repository.url",
+					Message: `repository URL "github.com/test/malformed" is not an absolute http(s) URL`},
+				{Severity: LintError, Field: "packages[0].identifier", Message: "identifier is missing"},
+				{Severity: LintError, Field: "remotes[0].url", Message: `remote URL "/sse" is not an absolute URL`},
+				{Severity: LintError, Field: "_meta.provider.toolhive.tier", Message: "tier is a number, not a string"},
+				{Severity: LintError, Field: "_meta.provider.toolhive.tools", Message: "tools is not a list of strings"},
+				{Severity: LintError, Field: "_meta.provider.toolhive.metadata.stars", Message: "stars is not a number"},
+			},
+		},
+		{
+			name: "ToolHive metadata not an object",
+			server: NewTestServer("io.test/namespace",
+				WithDescription("Namespace"),
+				WithOCIPackage("ghcr.io/test/namespace:1.0.0"),
+				WithTags("testing"),
+				WithMetadata(ToolHiveMetaNamespace, "oops"),
+				func(server *upstream.ServerJSON) {
+					server.Repository = &model.Repository{URL: "https://github.com/test/namespace"}
+				},
+			),
+			want: []LintIssue{
+				{Severity: LintError, Field: "_meta." + ToolHiveMetaNamespace,
+					Message: "ToolHive metadata is a string, not an object"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Lint(&tt.server))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package registry
+
+import (
+	"encoding/json"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ExtractTier extracts the ToolHive tier (e.g. "Official", "Community") from an upstream server
+// Returns an empty string if the server carries no tier metadata
+func ExtractTier(server *upstream.ServerJSON) string {
+	return extractStringField(server, "tier")
+}
+
+// ExtractStatus extracts the ToolHive status (e.g. "Active", "Deprecated") from an upstream server
+// Returns an empty string if the server carries no status metadata
+func ExtractStatus(server *upstream.ServerJSON) string {
+	return extractStringField(server, "status")
+}
+
+// ExtractStars extracts the repository star count from the ToolHive metadata block of an upstream server
+// The boolean result is false if the server carries no star count
+func ExtractStars(server *upstream.ServerJSON) (int, bool) {
+	value, ok := extractField(server, "metadata")
+	if !ok {
+		return 0, false
+	}
+	metadata, ok := value.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	return toInt(metadata["stars"])
+}
+
+// ExtractTools extracts the names of the tools of a server from its ToolHive metadata
+// Returns nil if the server carries no tool list
+func ExtractTools(server *upstream.ServerJSON) []string {
+	value, ok := extractField(server, "tools")
+	if !ok {
+		return nil
+	}
+	switch tools := value.(type) {
+	case []string:
+		return tools
+	case []interface{}:
+		names := make([]string, 0, len(tools))
+		for _, tool := range tools {
+			if name, ok := tool.(string); ok && name != "" {
+				names = append(names, name)
+			}
+		}
+		return names
+	default:
+		return nil
+	}
+}
+
+// extractStringField returns the first string value found for the given field
+func extractStringField(server *upstream.ServerJSON, field string) string {
+	value, ok := extractField(server, field)
+	if !ok {
+		return ""
+	}
+	s, _ := value.(string)
+	return s
+}
+
+// extractField looks up a field in the publisher-provided metadata of an upstream server.
+// It uses the same nesting conventions as ExtractTags, i.e.
+// Meta.PublisherProvided[publisher][entry][field], and returns the first match.
+func extractField(server *upstream.ServerJSON, field string) (interface{}, bool) {
+	if server == nil || server.Meta == nil {
+		return nil, false
+	}
+	for _, publisher := range server.Meta.PublisherProvided {
+		entries, ok := publisher.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, entry := range entries {
+			fields, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if value, ok := fields[field]; ok {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// toInt converts the numeric representations produced by JSON decoding and by test builders to an int
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	case json.Number:
+		i, err := v.Int64()
+		if err != nil {
+			return 0, false
+		}
+		return int(i), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,94 @@
+package registry
+
+import (
+	"encoding/json"
+	"testing"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stacklok/toolhive/pkg/registry/converters"
+	toolhivetypes "github.com/stacklok/toolhive/pkg/registry/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractMetadataFields(t *testing.T) {
+	t.Parallel()
+
+	serverFromToolhive, err := converters.ImageMetadataToServerJSON("test-server", &toolhivetypes.ImageMetadata{
+		Image: "test/image:latest",
+		BaseServerMetadata: toolhivetypes.BaseServerMetadata{
+			Name:        "test-server",
+			Description: "Test server description",
+			Tier:        "Official",
+			Status:      "Active",
+			Transport:   "stdio",
+			Tools:       []string{"search", "fetch"},
+			Metadata:    &toolhivetypes.Metadata{Stars: 17},
+		},
+	})
+	require.NoError(t, err)
+
+	// Round-trip through JSON so numbers are decoded the same way as synced data
+	raw, err := json.Marshal(serverFromToolhive)
+	require.NoError(t, err)
+	var decoded upstream.ServerJSON
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+
+	builtServer := NewTestServer("built-server",
+		WithToolHiveMetadata("tier", "Community"),
+		WithToolHiveMetadata("status", "Deprecated"),
+		WithToolHiveMetadata("metadata", map[string]any{"stars": 3}),
+		WithToolHiveMetadata("tools", []string{"query"}),
+	)
+	bareServer := NewTestServer("bare-server")
+
+	tests := []struct {
+		name          string
+		server        *upstream.ServerJSON
+		expectedTier  string
+		expectedState string
+		expectedStars int
+		hasStars      bool
+		expectedTools []string
+	}{
+		{
+			name:          "converted toolhive server",
+			server:        &decoded,
+			expectedTier:  "Official",
+			expectedState: "Active",
+			expectedStars: 17,
+			hasStars:      true,
+			expectedTools: []string{"search", "fetch"},
+		},
+		{
+			name:          "test builder server",
+			server:        &builtServer,
+			expectedTier:  "Community",
+			expectedState: "Deprecated",
+			expectedStars: 3,
+			hasStars:      true,
+			expectedTools: []string{"query"},
+		},
+		{
+			name:   "server without metadata",
+			server: &bareServer,
+		},
+		{
+			name: "nil server",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expectedTier, ExtractTier(tt.server))
+			assert.Equal(t, tt.expectedState, ExtractStatus(tt.server))
+
+			stars, ok := ExtractStars(tt.server)
+			assert.Equal(t, tt.hasStars, ok)
+			assert.Equal(t, tt.expectedStars, stars)
+			assert.Equal(t, tt.expectedTools, ExtractTools(tt.server))
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package registry
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ToolHiveMetaNamespace is the publisher-provided metadata namespace of the ToolHive extension
+const ToolHiveMetaNamespace = "io.github.stacklok"
+
+// MetaFilter selects the publisher-provided metadata namespaces (the keys of _meta) returned
+// to clients. A namespace matches an entry when it is equal to it or nested below it, e.g.
+// "io.github.stacklok/sanitization" matches "io.github.stacklok". The ToolHive extension
+// namespace is always kept. The zero value keeps all namespaces.
+type MetaFilter struct {
+	// Include keeps only the matching namespaces, when not empty
+	Include []string
+	// Exclude drops the matching namespaces
+	Exclude []string
+}
+
+// IsZero reports whether the filter keeps all namespaces
+func (f MetaFilter) IsZero() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Apply returns a copy of the server whose publisher-provided metadata only holds the namespaces
+// selected by the filter. The server itself is left unmodified.
+func (f MetaFilter) Apply(server upstream.ServerJSON) upstream.ServerJSON {
+	if f.IsZero() || server.Meta == nil || len(server.Meta.PublisherProvided) == 0 {
+		return server
+	}
+
+	meta := *server.Meta
+	meta.PublisherProvided = maps.Clone(server.Meta.PublisherProvided)
+	maps.DeleteFunc(meta.PublisherProvided, func(namespace string, _ any) bool {
+		return !f.keeps(namespace)
+	})
+	server.Meta = &meta
+	return server
+}
+
+// keeps reports whether the filter keeps a namespace
+func (f MetaFilter) keeps(namespace string) bool {
+	if matchesNamespace(namespace, ToolHiveMetaNamespace) {
+		return true
+	}
+	matches := func(entry string) bool { return matchesNamespace(namespace, entry) }
+	if len(f.Include) > 0 && !slices.ContainsFunc(f.Include, matches) {
+		return false
+	}
+	return !slices.ContainsFunc(f.Exclude, matches)
+}
+
+// matchesNamespace reports whether a namespace is equal to or nested below entry
+func matchesNamespace(namespace, entry string) bool {
+	return namespace == entry || strings.HasPrefix(namespace, entry+"/")
+}
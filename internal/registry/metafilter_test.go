@@ -0,0 +1,70 @@
+package registry
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetaFilterApply(t *testing.T) {
+	t.Parallel()
+
+	server := NewTestServer("io.example/server",
+		WithMetadata(ToolHiveMetaNamespace, map[string]any{"tier": "Official"}),
+		WithMetadata(SanitizationMetaKey, map[string]any{"fields": []any{"description"}}),
+		WithMetadata(OfficialMetaKey, map[string]any{"status": "active"}),
+		WithMetadata("com.example/build", map[string]any{"commit": "abc"}),
+		WithMetadata("com.example/build/provenance", map[string]any{"builder": "ci"}),
+		WithMetadata("com.example.other", map[string]any{}),
+	)
+
+	tests := []struct {
+		name   string
+		filter MetaFilter
+		want   []string
+	}{
+		{
+			name:   "zero filter keeps all namespaces",
+			filter: MetaFilter{},
+			want: []string{
+				"com.example.other", "com.example/build", "com.example/build/provenance",
+				ToolHiveMetaNamespace, SanitizationMetaKey, OfficialMetaKey,
+			},
+		},
+		{
+			name:   "include keeps matching and nested namespaces and the ToolHive extension",
+			filter: MetaFilter{Include: []string{"com.example/build"}},
+			want: []string{
+				"com.example/build", "com.example/build/provenance", ToolHiveMetaNamespace, SanitizationMetaKey,
+			},
+		},
+		{
+			name:   "exclude drops matching namespaces only",
+			filter: MetaFilter{Exclude: []string{"com.example/build", OfficialMetaKey}},
+			want:   []string{"com.example.other", ToolHiveMetaNamespace, SanitizationMetaKey},
+		},
+		{
+			name:   "the ToolHive extension cannot be excluded",
+			filter: MetaFilter{Exclude: []string{ToolHiveMetaNamespace}},
+			want: []string{
+				"com.example.other", "com.example/build", "com.example/build/provenance",
+				ToolHiveMetaNamespace, SanitizationMetaKey, OfficialMetaKey,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			filtered := tt.filter.Apply(server)
+			assert.ElementsMatch(t, tt.want, slices.Collect(maps.Keys(filtered.Meta.PublisherProvided)))
+		})
+	}
+
+	assert.Len(t, server.Meta.PublisherProvided, 6, "server should be left unmodified")
+
+	bare := NewTestServer("io.example/bare")
+	assert.Equal(t, bare, MetaFilter{Include: []string{"com.example"}}.Apply(bare))
+}
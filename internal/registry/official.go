@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"maps"
+	"slices"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// OfficialMetaKey is the metadata key under which the registry keeps the official metadata
+// (publishedAt/updatedAt/status) of a server while it is stored and served. This metadata is
+// owned by the registry: publisher values are dropped by StripRegistryMeta when servers are
+// ingested, and ToServerResponse moves it to the official metadata of API responses.
+const OfficialMetaKey = "io.modelcontextprotocol.registry/official"
+
+// registryMetaKeys are the publisher-provided metadata keys owned by the registry
+var registryMetaKeys = []string{OfficialMetaKey, SanitizationMetaKey}
+
+// StripRegistryMeta drops the metadata owned by the registry found in the publisher-provided
+// metadata of a server: the official metadata and the sanitization marker, so that publishers
+// cannot set the timestamps or lifecycle status the registry reports, nor claim their content
+// was sanitized. It must be called on every server received from a publisher or an upstream registry.
+func StripRegistryMeta(server *upstream.ServerJSON) {
+	deleteMeta(server, registryMetaKeys...)
+}
+
+// deleteMeta deletes keys from the publisher-provided metadata of a server.
+// The metadata is copied, not modified in place.
+func deleteMeta(server *upstream.ServerJSON, keys ...string) {
+	if server == nil || server.Meta == nil {
+		return
+	}
+	if !slices.ContainsFunc(keys, func(key string) bool {
+		_, ok := server.Meta.PublisherProvided[key]
+		return ok
+	}) {
+		return
+	}
+	meta := *server.Meta
+	meta.PublisherProvided = maps.Clone(server.Meta.PublisherProvided)
+	for _, key := range keys {
+		delete(meta.PublisherProvided, key)
+	}
+	server.Meta = &meta
+}
+
+// FromServerResponse returns the server of an upstream registry response, keeping the timestamps
+// and lifecycle status of the official metadata of the response rather than any the publisher set
+func FromServerResponse(response upstream.ServerResponse) upstream.ServerJSON {
+	server := response.Server
+	StripRegistryMeta(&server)
+	if official := response.Meta.Official; official != nil {
+		SetTimestamps(&server, official.PublishedAt, official.UpdatedAt)
+		SetLifecycleStatus(&server, string(official.Status))
+	}
+	return server
+}
+
+// ToServerResponse wraps a server in a registry API response. The official metadata kept by the
+// registry is moved out of the publisher-provided metadata of the server into the official
+// metadata of the response.
+func ToServerResponse(server upstream.ServerJSON, isLatest bool) upstream.ServerResponse {
+	official := &upstream.RegistryExtensions{
+		Status:   model.Status(ExtractLifecycleStatus(&server)),
+		IsLatest: isLatest,
+	}
+	official.PublishedAt, _ = ExtractPublishedAt(&server)
+	official.UpdatedAt, _ = ExtractUpdatedAt(&server)
+
+	deleteMeta(&server, OfficialMetaKey)
+	return upstream.ServerResponse{
+		Server: server,
+		Meta:   upstream.ResponseMeta{Official: official},
+	}
+}
+
+// setOfficialField sets a field of the official metadata of a server, creating the
+// metadata block if needed. The metadata is copied, not modified in place.
+func setOfficialField(server *upstream.ServerJSON, field, value string) {
+	if server == nil {
+		return
+	}
+	meta := upstream.ServerMeta{}
+	if server.Meta != nil {
+		meta = *server.Meta
+	}
+	meta.PublisherProvided = maps.Clone(meta.PublisherProvided)
+	if meta.PublisherProvided == nil {
+		meta.PublisherProvided = make(map[string]interface{})
+	}
+	official, _ := meta.PublisherProvided[OfficialMetaKey].(map[string]interface{})
+	official = maps.Clone(official)
+	if official == nil {
+		official = make(map[string]interface{})
+	}
+	official[field] = value
+	meta.PublisherProvided[OfficialMetaKey] = official
+	server.Meta = &meta
+}
+
+// extractOfficialField reads a string field from the official metadata of a server
+func extractOfficialField(server *upstream.ServerJSON, field string) (string, bool) {
+	if server == nil || server.Meta == nil {
+		return "", false
+	}
+	official, ok := server.Meta.PublisherProvided[OfficialMetaKey].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	value, ok := official[field].(string)
+	return value, ok
+}
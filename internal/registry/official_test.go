@@ -0,0 +1,93 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// forgedServer returns a server whose publisher claims official metadata and sanitized content
+func forgedServer() upstream.ServerJSON {
+	server := NewTestServer("io.test/server", WithTags("tag"))
+	server.Meta.PublisherProvided[OfficialMetaKey] = map[string]interface{}{
+		publishedAtField: "2020-01-01T00:00:00Z",
+		statusField:      StatusActive,
+	}
+	server.Meta.PublisherProvided[SanitizationMetaKey] = map[string]interface{}{
+		sanitizationFieldsField: []interface{}{"description"},
+	}
+	return server
+}
+
+func TestStripRegistryMeta(t *testing.T) {
+	t.Parallel()
+
+	server := forgedServer()
+	stripped := server
+	StripRegistryMeta(&stripped)
+
+	_, ok := ExtractPublishedAt(&stripped)
+	assert.False(t, ok)
+	assert.Nil(t, ExtractSanitizedFields(&stripped))
+	assert.Equal(t, []string{"tag"}, ExtractTags(&stripped))
+	// The metadata of the original server is left unmodified
+	assert.Contains(t, server.Meta.PublisherProvided, OfficialMetaKey)
+	assert.Contains(t, server.Meta.PublisherProvided, SanitizationMetaKey)
+
+	StripRegistryMeta(nil)
+}
+
+func TestFromServerResponse(t *testing.T) {
+	t.Parallel()
+
+	published := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	response := upstream.ServerResponse{
+		Server: forgedServer(),
+		Meta: upstream.ResponseMeta{Official: &upstream.RegistryExtensions{
+			Status:      model.StatusDeprecated,
+			PublishedAt: published,
+		}},
+	}
+
+	server := FromServerResponse(response)
+	publishedAt, ok := ExtractPublishedAt(&server)
+	assert.True(t, ok)
+	assert.True(t, published.Equal(publishedAt))
+	assert.Equal(t, StatusDeprecated, ExtractLifecycleStatus(&server))
+	// The response is left unmodified
+	official := response.Server.Meta.PublisherProvided[OfficialMetaKey].(map[string]interface{})
+	assert.Equal(t, "2020-01-01T00:00:00Z", official[publishedAtField])
+
+	withoutOfficial := FromServerResponse(upstream.ServerResponse{Server: forgedServer()})
+	_, ok = ExtractPublishedAt(&withoutOfficial)
+	assert.False(t, ok)
+	assert.Equal(t, StatusActive, ExtractLifecycleStatus(&withoutOfficial))
+}
+
+func TestToServerResponse(t *testing.T) {
+	t.Parallel()
+
+	published := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	server := NewTestServer("io.test/server",
+		WithTags("tag"),
+		WithTimestamps(published, updated),
+		WithLifecycleStatus(StatusDeprecated),
+	)
+
+	response := ToServerResponse(server, true)
+	assert.Equal(t, &upstream.RegistryExtensions{
+		Status:      model.StatusDeprecated,
+		PublishedAt: published,
+		UpdatedAt:   updated,
+		IsLatest:    true,
+	}, response.Meta.Official)
+	assert.NotContains(t, response.Server.Meta.PublisherProvided, OfficialMetaKey)
+	assert.Equal(t, []string{"tag"}, ExtractTags(&response.Server))
+
+	undated := ToServerResponse(NewTestServer("io.test/undated"), false)
+	assert.Equal(t, &upstream.RegistryExtensions{Status: model.StatusActive}, undated.Meta.Official)
+}
@@ -0,0 +1,52 @@
+package registry
+
+import (
+	"slices"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// RiskProfile summarizes the properties of a server relevant to an approval review
+type RiskProfile struct {
+	LifecycleStatus string        `json:"lifecycleStatus"`
+	InjectionRisk   InjectionRisk `json:"injectionRisk"`
+	// SecretEnvironmentVariables are the names of the environment variables marked as secrets
+	SecretEnvironmentVariables []string `json:"secretEnvironmentVariables"`
+	// CredentialLikeValues are the fields whose example values resemble credentials
+	CredentialLikeValues []string `json:"credentialLikeValues"`
+	// UnpinnedPackages are the identifiers of the packages not pinned by a content digest
+	UnpinnedPackages []string `json:"unpinnedPackages"`
+	// Remotes are the URLs of the remote endpoints the server connects to
+	Remotes []string `json:"remotes"`
+}
+
+// RiskProfileOf builds the risk profile of a server
+func RiskProfileOf(server *upstream.ServerJSON) RiskProfile {
+	_, redacted := RedactSecrets(server)
+	profile := RiskProfile{
+		LifecycleStatus:            ExtractLifecycleStatus(server),
+		InjectionRisk:              InjectionRiskOf(server),
+		SecretEnvironmentVariables: []string{},
+		CredentialLikeValues:       redacted,
+		UnpinnedPackages:           []string{},
+		Remotes:                    []string{},
+	}
+	if profile.CredentialLikeValues == nil {
+		profile.CredentialLikeValues = []string{}
+	}
+
+	for _, pkg := range server.Packages {
+		for _, env := range pkg.EnvironmentVariables {
+			if env.IsSecret && !slices.Contains(profile.SecretEnvironmentVariables, env.Name) {
+				profile.SecretEnvironmentVariables = append(profile.SecretEnvironmentVariables, env.Name)
+			}
+		}
+		if PackageDigest(pkg) == "" {
+			profile.UnpinnedPackages = append(profile.UnpinnedPackages, pkg.Identifier)
+		}
+	}
+	for _, remote := range server.Remotes {
+		profile.Remotes = append(profile.Remotes, remote.URL)
+	}
+	return profile
+}
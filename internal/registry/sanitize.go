@@ -0,0 +1,164 @@
+package registry
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// SanitizationMetaKey is the metadata key under which the registry records the fields of a
+	// server altered by sanitization. It is owned by the registry: publisher values are dropped
+	// by StripRegistryMeta when servers are ingested, so that only sanitization can set it.
+	SanitizationMetaKey = "io.github.stacklok/sanitization"
+
+	sanitizationFieldsField = "fields"
+)
+
+const (
+	// SanitizeInstructions removes sentences addressing an AI agent, such as
+	// "ignore previous instructions" or bait to invoke tools
+	SanitizeInstructions = "instructions"
+	// SanitizeScripts removes script blocks, HTML tags, and javascript: links
+	SanitizeScripts = "scripts"
+	// SanitizeURLs removes URLs that can leak data when rendered or fetched, such as markdown
+	// images with query strings and URLs containing template placeholders
+	SanitizeURLs = "urls"
+)
+
+// SanitizeRules returns the rules supported by SanitizeText
+func SanitizeRules() []string {
+	return []string{SanitizeInstructions, SanitizeScripts, SanitizeURLs}
+}
+
+// Phrases commonly used to inject instructions into agent context
+const (
+	instructionOverridePattern = `(?:ignore|disregard|forget|override)\s+(?:all\s+|any\s+)?(?:the\s+)?` +
+		`(?:previous|prior|above|earlier|preceding)\s+(?:instructions?|prompts?|messages?|rules?|context)`
+	roleManipulationPattern = `(?:you\s+are\s+now|from\s+now\s+on,?\s+you|act\s+as\s+(?:an?\s+)?(?:unrestricted|jailbroken)` +
+		`|system\s+prompt|developer\s+mode)`
+	concealmentPattern = `(?:do\s+not|don'?t|never)\s+(?:tell|inform|mention\s+(?:this\s+)?to|reveal\s+(?:this\s+)?to)\s+the\s+user`
+	toolBaitPattern    = `(?:always|immediately|first|must)\s+(?:call|invoke|run|execute|use)\s+(?:the\s+)?[\w.-]+\s+tool`
+	hiddenTagPattern   = `</?(?:system|assistant|instructions?|important)>`
+)
+
+// instructionPatterns are the phrases removed by the SanitizeInstructions rule
+var instructionPatterns = []string{
+	instructionOverridePattern,
+	roleManipulationPattern,
+	concealmentPattern,
+	toolBaitPattern,
+	hiddenTagPattern,
+}
+
+// sentenceChars matches characters within a sentence; punctuation only ends a sentence
+// when followed by whitespace, so that paths like ~/.ssh stay in their sentence
+const sentenceChars = `(?:[^.!?\n]|[.!?]\S)*`
+
+var (
+	instructionSentence = regexp.MustCompile(
+		`(?i)` + sentenceChars + `(?:` + strings.Join(instructionPatterns, `|`) + `)` + sentenceChars + `[.!?]?`)
+	scriptBlock      = regexp.MustCompile(`(?is)<(script|style|iframe|object|embed)\b.*?(?:</\s*\w+\s*>|$)`)
+	htmlTag          = regexp.MustCompile(`</?[A-Za-z][^<>]*>`)
+	javascriptLink   = regexp.MustCompile(`(?i)\[([^\]]*)\]\(\s*javascript:(?:[^()]|\([^()]*\))*\)|javascript:\S*`)
+	markdownImage    = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	suspiciousURL    = regexp.MustCompile(`(?i)\b(?:https?|data):[^\s)>"']*(?:\{[^}]*\}|%7B|\$\(|\$\{)[^\s)>"']*`)
+	repeatedSpaces   = regexp.MustCompile(`[ \t]{2,}`)
+	repeatedNewlines = regexp.MustCompile(`\n{3,}`)
+)
+
+// SanitizeText applies the given sanitization rules to a piece of text.
+// The boolean result reports whether the text was altered.
+func SanitizeText(text string, rules []string) (string, bool) {
+	if text == "" {
+		return text, false
+	}
+
+	sanitized := text
+	if slices.Contains(rules, SanitizeScripts) {
+		sanitized = scriptBlock.ReplaceAllString(sanitized, "")
+		sanitized = javascriptLink.ReplaceAllString(sanitized, "$1")
+		sanitized = htmlTag.ReplaceAllString(sanitized, "")
+	}
+	if slices.Contains(rules, SanitizeURLs) {
+		sanitized = markdownImage.ReplaceAllStringFunc(sanitized, func(image string) string {
+			url := markdownImage.FindStringSubmatch(image)[1]
+			if strings.Contains(url, "?") || strings.HasPrefix(strings.ToLower(url), "data:") {
+				return ""
+			}
+			return image
+		})
+		sanitized = suspiciousURL.ReplaceAllString(sanitized, "")
+	}
+	if slices.Contains(rules, SanitizeInstructions) {
+		sanitized = instructionSentence.ReplaceAllString(sanitized, "")
+	}
+
+	if sanitized == text {
+		return text, false
+	}
+	sanitized = repeatedSpaces.ReplaceAllString(sanitized, " ")
+	sanitized = repeatedNewlines.ReplaceAllString(sanitized, "\n\n")
+	return strings.TrimSpace(sanitized), true
+}
+
+// SanitizeServer applies the given sanitization rules to the title and description of a server
+// in place, and records the altered fields in its metadata, which is copied rather than modified
+// in place. The boolean result reports whether the server was altered.
+func SanitizeServer(server *upstream.ServerJSON, rules []string) bool {
+	var altered []string
+	if title, ok := SanitizeText(server.Title, rules); ok {
+		server.Title = title
+		altered = append(altered, "title")
+	}
+	if description, ok := SanitizeText(server.Description, rules); ok {
+		server.Description = description
+		altered = append(altered, "description")
+	}
+	if len(altered) == 0 {
+		return false
+	}
+
+	meta := upstream.ServerMeta{}
+	if server.Meta != nil {
+		meta = *server.Meta
+	}
+	meta.PublisherProvided = maps.Clone(meta.PublisherProvided)
+	if meta.PublisherProvided == nil {
+		meta.PublisherProvided = make(map[string]interface{})
+	}
+	fields := make([]interface{}, len(altered))
+	for i, field := range altered {
+		fields[i] = field
+	}
+	meta.PublisherProvided[SanitizationMetaKey] = map[string]interface{}{
+		sanitizationFieldsField: fields,
+	}
+	server.Meta = &meta
+	return true
+}
+
+// ExtractSanitizedFields returns the fields of a server altered by sanitization, if any
+func ExtractSanitizedFields(server *upstream.ServerJSON) []string {
+	if server == nil || server.Meta == nil {
+		return nil
+	}
+	sanitization, ok := server.Meta.PublisherProvided[SanitizationMetaKey].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	values, ok := sanitization[sanitizationFieldsField].([]interface{})
+	if !ok {
+		return nil
+	}
+	fields := make([]string, 0, len(values))
+	for _, value := range values {
+		if field, ok := value.(string); ok {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
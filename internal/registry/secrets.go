@@ -0,0 +1,154 @@
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// redactedPlaceholder replaces credentials found in inputs without a usable name
+const redactedPlaceholder = "<REDACTED>"
+
+// secretPatterns match well-known credential formats
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}`),                                       // GitHub tokens
+	regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{22,}`),                                     // GitHub fine-grained tokens
+	regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}`),                                         // GitLab tokens
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),                                      // AWS access key IDs
+	regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`),                                    // Slack tokens
+	regexp.MustCompile(`\bsk-(?:proj-|ant-)?[A-Za-z0-9_-]{20,}`),                             // OpenAI and Anthropic keys
+	regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`),                                            // Google API keys
+	regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{16,}`),                                      // Stripe keys
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`), // JWTs
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),                                 // PEM private keys
+	regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/-]{20,}=*`),                            // Bearer tokens
+}
+
+// variableReference matches values made only of {variable} references, which are placeholders themselves
+var variableReference = regexp.MustCompile(`^(?:\s*\{[A-Za-z0-9_.-]+\}\s*)+$`)
+
+// LooksLikeSecret reports whether a value contains something resembling a real credential
+func LooksLikeSecret(value string) bool {
+	for _, pattern := range secretPatterns {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactSecrets returns a copy of a server whose input examples (values, defaults and placeholders
+// of arguments, environment variables and headers) no longer contain anything resembling a credential.
+// Literal values of inputs marked as secret are redacted as well. Offending values are replaced with
+// a placeholder named after the input, and the paths of the redacted fields are returned so that
+// callers can log an advisory. The given server is not modified.
+func RedactSecrets(server *upstream.ServerJSON) (*upstream.ServerJSON, []string) {
+	redacted := *server
+	r := &redactor{}
+
+	redacted.Packages = make([]model.Package, len(server.Packages))
+	for i, pkg := range server.Packages {
+		prefix := fmt.Sprintf("packages[%d]", i)
+		pkg.RuntimeArguments = r.arguments(prefix+".runtimeArguments", pkg.RuntimeArguments)
+		pkg.PackageArguments = r.arguments(prefix+".packageArguments", pkg.PackageArguments)
+		pkg.EnvironmentVariables = r.keyValues(prefix+".environmentVariables", pkg.EnvironmentVariables)
+		pkg.Transport.Headers = r.keyValues(prefix+".transport.headers", pkg.Transport.Headers)
+		redacted.Packages[i] = pkg
+	}
+
+	redacted.Remotes = make([]model.Transport, len(server.Remotes))
+	for i, remote := range server.Remotes {
+		remote.Headers = r.keyValues(fmt.Sprintf("remotes[%d].headers", i), remote.Headers)
+		redacted.Remotes[i] = remote
+	}
+	if server.Packages == nil {
+		redacted.Packages = nil
+	}
+	if server.Remotes == nil {
+		redacted.Remotes = nil
+	}
+
+	return &redacted, r.fields
+}
+
+// redactor collects the paths of the fields it redacts
+type redactor struct {
+	fields []string
+}
+
+// arguments returns redacted copies of arguments
+func (r *redactor) arguments(path string, args []model.Argument) []model.Argument {
+	if args == nil {
+		return nil
+	}
+	redacted := make([]model.Argument, len(args))
+	for i, arg := range args {
+		name := arg.ValueHint
+		if name == "" {
+			name = strings.TrimLeft(arg.Name, "-")
+		}
+		arg.InputWithVariables = r.inputWithVariables(fmt.Sprintf("%s[%d]", path, i), name, arg.InputWithVariables)
+		redacted[i] = arg
+	}
+	return redacted
+}
+
+// keyValues returns redacted copies of environment variables or headers
+func (r *redactor) keyValues(path string, inputs []model.KeyValueInput) []model.KeyValueInput {
+	if inputs == nil {
+		return nil
+	}
+	redacted := make([]model.KeyValueInput, len(inputs))
+	for i, input := range inputs {
+		input.InputWithVariables = r.inputWithVariables(fmt.Sprintf("%s[%d]", path, i), input.Name, input.InputWithVariables)
+		redacted[i] = input
+	}
+	return redacted
+}
+
+// inputWithVariables returns a redacted copy of an input and of its variables
+func (r *redactor) inputWithVariables(path, name string, input model.InputWithVariables) model.InputWithVariables {
+	input.Input = r.input(path, name, input.Input)
+	if input.Variables != nil {
+		variables := make(map[string]model.Input, len(input.Variables))
+		for variable, value := range input.Variables {
+			variables[variable] = r.input(path+".variables."+variable, variable, value)
+		}
+		input.Variables = variables
+	}
+	return input
+}
+
+// input returns a redacted copy of a single input
+func (r *redactor) input(path, name string, input model.Input) model.Input {
+	input.Value = r.value(path+".value", name, input.Value, input.IsSecret)
+	input.Default = r.value(path+".default", name, input.Default, input.IsSecret)
+	input.Placeholder = r.value(path+".placeholder", name, input.Placeholder, false)
+	return input
+}
+
+// value redacts a value resembling a credential, or any literal value of a secret input
+func (r *redactor) value(path, name, value string, secret bool) string {
+	if value == "" || variableReference.MatchString(value) {
+		return value
+	}
+	if !secret && !LooksLikeSecret(value) {
+		return value
+	}
+	r.fields = append(r.fields, path)
+	return secretPlaceholder(name)
+}
+
+var unsafePlaceholderChars = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// secretPlaceholder builds the placeholder replacing a credential, e.g. <GITHUB_TOKEN>
+func secretPlaceholder(name string) string {
+	name = strings.Trim(unsafePlaceholderChars.ReplaceAllString(strings.ToUpper(name), "_"), "_")
+	if name == "" {
+		return redactedPlaceholder
+	}
+	return "<" + name + ">"
+}
@@ -0,0 +1,164 @@
+package registry
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// SuggestionKindName marks a suggestion taken from a server name
+	SuggestionKindName = "name"
+	// SuggestionKindTag marks a suggestion taken from a server tag
+	SuggestionKindTag = "tag"
+)
+
+// Suggestion is a known term close to a search query
+type Suggestion struct {
+	Value    string `json:"value"`
+	Kind     string `json:"kind"`
+	Distance int    `json:"distance"`
+}
+
+// parallelSuggestThreshold is the number of distinct candidate terms above which
+// they are scored concurrently across CPUs
+const parallelSuggestThreshold = 2048
+
+// suggestionCandidate is a lowercased term compared with the query, and the suggestion it yields
+type suggestionCandidate struct {
+	value string
+	kind  string
+	term  string
+}
+
+// Suggest returns up to limit server names and tags whose edit distance to the
+// query is small enough to be a likely misspelling, closest first.
+// Server names are compared both in full and by the part after the namespace.
+// Comparisons are case-insensitive.
+func Suggest(query string, servers []*upstream.ServerJSON, limit int) []Suggestion {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" || limit <= 0 {
+		return []Suggestion{}
+	}
+	maxDistance := max(1, len([]rune(query))/3)
+
+	candidates := suggestionCandidates(servers)
+	distances := make([]int, len(candidates))
+	score := func(from, to int) {
+		for i := from; i < to; i++ {
+			distances[i] = boundedEditDistance(query, candidates[i].term, maxDistance)
+		}
+	}
+	if workers := runtime.GOMAXPROCS(0); len(candidates) > parallelSuggestThreshold && workers > 1 {
+		var wg sync.WaitGroup
+		chunk := (len(candidates) + workers - 1) / workers
+		for from := 0; from < len(candidates); from += chunk {
+			wg.Add(1)
+			go func(from, to int) {
+				defer wg.Done()
+				score(from, to)
+			}(from, min(from+chunk, len(candidates)))
+		}
+		wg.Wait()
+	} else {
+		score(0, len(candidates))
+	}
+
+	best := make(map[Suggestion]int)
+	for i, candidate := range candidates {
+		distance := distances[i]
+		if distance == 0 || distance > maxDistance {
+			continue
+		}
+		key := Suggestion{Value: candidate.value, Kind: candidate.kind}
+		if current, ok := best[key]; !ok || distance < current {
+			best[key] = distance
+		}
+	}
+
+	suggestions := make([]Suggestion, 0, len(best))
+	for suggestion, distance := range best {
+		suggestion.Distance = distance
+		suggestions = append(suggestions, suggestion)
+	}
+	return TopK(suggestions, limit, func(a, b Suggestion) int {
+		if a.Distance != b.Distance {
+			return a.Distance - b.Distance
+		}
+		if a.Kind != b.Kind {
+			return strings.Compare(a.Kind, b.Kind)
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+}
+
+// suggestionCandidates collects the distinct terms of the servers that a query is compared with,
+// so that names and tags shared by many servers are only scored once
+func suggestionCandidates(servers []*upstream.ServerJSON) []suggestionCandidate {
+	seen := make(map[suggestionCandidate]struct{})
+	var candidates []suggestionCandidate
+	add := func(value, kind, term string) {
+		candidate := suggestionCandidate{value: value, kind: kind, term: strings.ToLower(term)}
+		if _, ok := seen[candidate]; ok {
+			return
+		}
+		seen[candidate] = struct{}{}
+		candidates = append(candidates, candidate)
+	}
+
+	for _, server := range servers {
+		if server == nil {
+			continue
+		}
+		add(server.Name, SuggestionKindName, server.Name)
+		if _, shortName, ok := strings.Cut(server.Name, "/"); ok {
+			add(server.Name, SuggestionKindName, shortName)
+		}
+		for _, tag := range ExtractTags(server) {
+			add(tag, SuggestionKindTag, tag)
+		}
+	}
+	return candidates
+}
+
+// boundedEditDistance returns the edit distance between a and b, or maxDistance+1 without
+// computing it when their lengths alone differ by more than maxDistance
+func boundedEditDistance(a, b string, maxDistance int) int {
+	if diff := utf8.RuneCountInString(a) - utf8.RuneCountInString(b); diff > maxDistance || -diff > maxDistance {
+		return maxDistance + 1
+	}
+	return editDistance(a, b)
+}
+
+// editDistance returns the optimal string alignment distance between a and b:
+// the Levenshtein distance extended so that swapping two adjacent characters,
+// a common typo, counts as a single edit
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(ra)][len(rb)]
+}
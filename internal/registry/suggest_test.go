@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"fmt"
+	"testing"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggest(t *testing.T) {
+	t.Parallel()
+
+	slack := NewTestServer("io.github.example/slack", WithTags("chat", "message", "messaging"))
+	github := NewTestServer("io.github.example/github", WithTags("git", "scm"))
+	servers := []*upstream.ServerJSON{&slack, &github, nil}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []Suggestion
+	}{
+		{
+			name:  "misspelled short name",
+			query: "slakc",
+			limit: 5,
+			want:  []Suggestion{{Value: "io.github.example/slack", Kind: SuggestionKindName, Distance: 1}},
+		},
+		{
+			name:  "misspelled tag is case-insensitive",
+			query: "Messagign",
+			limit: 5,
+			want: []Suggestion{
+				{Value: "messaging", Kind: SuggestionKindTag, Distance: 1},
+				{Value: "message", Kind: SuggestionKindTag, Distance: 3},
+			},
+		},
+		{
+			name:  "several candidates sorted by distance",
+			query: "messagin",
+			limit: 5,
+			want: []Suggestion{
+				{Value: "messaging", Kind: SuggestionKindTag, Distance: 1},
+				{Value: "message", Kind: SuggestionKindTag, Distance: 2},
+			},
+		},
+		{
+			name:  "exact matches are not suggested",
+			query: "chat",
+			limit: 5,
+			want:  []Suggestion{},
+		},
+		{
+			name:  "nothing close",
+			query: "kubernetes",
+			limit: 5,
+			want:  []Suggestion{},
+		},
+		{
+			name:  "limit is applied",
+			query: "slakc",
+			limit: 0,
+			want:  []Suggestion{},
+		},
+		{
+			name:  "empty query",
+			query: "  ",
+			limit: 5,
+			want:  []Suggestion{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Suggest(tt.query, servers, tt.limit))
+		})
+	}
+}
+
+func TestSuggestLargeCatalog(t *testing.T) {
+	t.Parallel()
+
+	// Enough distinct names to score candidates concurrently, all sharing the same tags
+	servers := make([]*upstream.ServerJSON, 0, parallelSuggestThreshold)
+	for i := range parallelSuggestThreshold {
+		server := NewTestServer(fmt.Sprintf("io.github.example/server-%05d", i), WithTags("database", "postgres"))
+		servers = append(servers, &server)
+	}
+
+	suggestions := Suggest("sevrer-00042", servers, 3)
+	assert.Len(t, suggestions, 3)
+	assert.Equal(t, Suggestion{Value: "io.github.example/server-00042", Kind: SuggestionKindName, Distance: 1}, suggestions[0])
+	assert.Equal(t, []Suggestion{{Value: "postgres", Kind: SuggestionKindTag, Distance: 1}}, Suggest("postgers", servers, 3))
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, editDistance("slack", "slack"))
+	assert.Equal(t, 1, editDistance("slakc", "slack"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 4, editDistance("", "test"))
+}
+
+func TestBoundedEditDistance(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1, boundedEditDistance("slakc", "slack", 1))
+	assert.Equal(t, 2, boundedEditDistance("slack", "slack-server", 1))
+	assert.Equal(t, 7, boundedEditDistance("slack", "slack-server", 7))
+}
@@ -1,6 +1,11 @@
 package registry
 
-import upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+import (
+	"sort"
+	"strings"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
 
 // ExtractTags extracts tags from an upstream server
 // It uses the conventions of the Toolhive conversions function in
@@ -9,11 +14,22 @@ func ExtractTags(server *upstream.ServerJSON) []string {
 	extractedTags := make([]string, 0)
 	if server.Meta != nil {
 		for _, metadata := range server.Meta.PublisherProvided {
-			for _, metadatas := range metadata.(map[string]interface{}) {
-				if tags, ok := metadatas.(map[string]interface{})["tags"]; ok {
+			entries, ok := metadata.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, metadatas := range entries {
+				fields, ok := metadatas.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if tags, ok := fields["tags"]; ok {
 					if tags, ok := tags.([]interface{}); ok {
 						for _, tag := range tags {
-							extractedTags = append(extractedTags, tag.(string))
+							// Malformed tags are reported by Lint rather than crashing readers
+							if tag, ok := tag.(string); ok {
+								extractedTags = append(extractedTags, tag)
+							}
 						}
 					}
 				}
@@ -22,3 +38,40 @@ func ExtractTags(server *upstream.ServerJSON) []string {
 	}
 	return extractedTags
 }
+
+// TagCount is a tag and the number of servers carrying it
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// CountTags returns the tags of the given servers with the number of servers carrying each, most
+// used first then alphabetically. Servers are counted once per name, whatever the number of their
+// versions listed or of the entries repeating a tag.
+func CountTags(servers []*upstream.ServerJSON) []TagCount {
+	names := make(map[string]map[string]struct{})
+	for _, server := range servers {
+		for _, tag := range ExtractTags(server) {
+			tag = strings.TrimSpace(tag)
+			if tag == "" {
+				continue
+			}
+			if names[tag] == nil {
+				names[tag] = make(map[string]struct{})
+			}
+			names[tag][server.Name] = struct{}{}
+		}
+	}
+
+	counts := make([]TagCount, 0, len(names))
+	for tag, servers := range names {
+		counts = append(counts, TagCount{Tag: tag, Count: len(servers)})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Tag < counts[j].Tag
+	})
+	return counts
+}
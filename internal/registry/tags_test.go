@@ -66,3 +66,22 @@ func TestExtractTags(t *testing.T) {
 		})
 	}
 }
+
+func TestCountTags(t *testing.T) {
+	t.Parallel()
+
+	slack := NewTestServer("com.example/slack", WithTags("chat", "messaging"))
+	slackNext := NewTestServer("com.example/slack", WithServerVersion("2.0.0"), WithTags("chat", "messaging", " chat "))
+	discord := NewTestServer("com.example/discord", WithTags("chat", ""))
+	github := NewTestServer("com.example/github", WithTags("git"))
+	untagged := NewTestServer("com.example/untagged")
+
+	counts := CountTags([]*upstream.ServerJSON{&slack, &slackNext, &discord, &github, &untagged})
+	assert.Equal(t, []TagCount{
+		{Tag: "chat", Count: 2},
+		{Tag: "git", Count: 1},
+		{Tag: "messaging", Count: 1},
+	}, counts)
+
+	assert.Empty(t, CountTags(nil))
+}
@@ -112,6 +112,15 @@ func WithHTTPPackage(url string) ServerOption {
 	}
 }
 
+// WithEnvironmentVariables adds environment variables to the last package of the server
+// A package option must be applied first
+func WithEnvironmentVariables(vars ...model.KeyValueInput) ServerOption {
+	return func(server *upstreamv0.ServerJSON) {
+		pkg := &server.Packages[len(server.Packages)-1]
+		pkg.EnvironmentVariables = append(pkg.EnvironmentVariables, vars...)
+	}
+}
+
 // WithTags adds tags to the server's metadata
 // Tags are stored in Meta.PublisherProvided["provider"]["metadata"]["tags"]
 func WithTags(tags ...string) ServerOption {
@@ -185,3 +194,17 @@ func WithToolHiveMetadata(key string, value any) ServerOption {
 		toolhive[key] = value
 	}
 }
+
+// WithTimestamps sets the official publication and update timestamps of the server
+func WithTimestamps(publishedAt, updatedAt time.Time) ServerOption {
+	return func(server *upstreamv0.ServerJSON) {
+		SetTimestamps(server, publishedAt, updatedAt)
+	}
+}
+
+// WithLifecycleStatus sets the official lifecycle status (active, deprecated or deleted) of the server
+func WithLifecycleStatus(status string) ServerOption {
+	return func(server *upstreamv0.ServerJSON) {
+		SetLifecycleStatus(server, status)
+	}
+}
@@ -0,0 +1,90 @@
+package registry
+
+import (
+	"time"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	publishedAtField = "publishedAt"
+	updatedAtField   = "updatedAt"
+)
+
+const (
+	// SortByName sorts servers by name, then by version
+	SortByName = "name"
+	// SortByPublishedAt sorts servers by their official publication timestamp
+	SortByPublishedAt = "published_at"
+	// SortByUpdatedAt sorts servers by their official update timestamp
+	SortByUpdatedAt = "updated_at"
+)
+
+// SortKeys returns the keys supported by SortServers
+func SortKeys() []string {
+	return []string{SortByName, SortByPublishedAt, SortByUpdatedAt}
+}
+
+// SetTimestamps records the official publication and update timestamps of a server in its
+// publisher-provided metadata. Zero timestamps are not recorded.
+func SetTimestamps(server *upstream.ServerJSON, publishedAt, updatedAt time.Time) {
+	if !publishedAt.IsZero() {
+		setOfficialField(server, publishedAtField, publishedAt.UTC().Format(time.RFC3339Nano))
+	}
+	if !updatedAt.IsZero() {
+		setOfficialField(server, updatedAtField, updatedAt.UTC().Format(time.RFC3339Nano))
+	}
+}
+
+// ExtractPublishedAt extracts the official publication timestamp of a server
+// The boolean result is false if the server carries no publication timestamp
+func ExtractPublishedAt(server *upstream.ServerJSON) (time.Time, bool) {
+	return extractTimestamp(server, publishedAtField)
+}
+
+// ExtractUpdatedAt extracts the official update timestamp of a server, falling back to the
+// publication timestamp for servers that were never updated
+// The boolean result is false if the server carries neither timestamp
+func ExtractUpdatedAt(server *upstream.ServerJSON) (time.Time, bool) {
+	if updatedAt, ok := extractTimestamp(server, updatedAtField); ok {
+		return updatedAt, true
+	}
+	return extractTimestamp(server, publishedAtField)
+}
+
+// FilterByTimestamps returns the servers updated after updatedAfter and published before
+// publishedBefore. A zero bound is ignored; servers without the relevant timestamp are
+// excluded when the bound is set.
+func FilterByTimestamps(
+	servers []*upstream.ServerJSON,
+	updatedAfter, publishedBefore time.Time,
+) []*upstream.ServerJSON {
+	return (*FactsIndex)(nil).FilterByTimestamps(servers, updatedAfter, publishedBefore)
+}
+
+// SortServers sorts servers in place by the given key, one of SortKeys.
+// Timestamp keys sort servers without the timestamp last regardless of direction,
+// and ties are broken by name and version. Unknown keys leave the order unchanged.
+func SortServers(servers []*upstream.ServerJSON, key string, descending bool) {
+	(*FactsIndex)(nil).SortServers(servers, key, descending)
+}
+
+// TopServers returns the first limit servers in the order SortServers would sort them,
+// without sorting the others. A non-positive limit returns all servers sorted.
+// Unknown keys return the first limit servers unsorted. servers is left unmodified.
+func TopServers(servers []*upstream.ServerJSON, key string, descending bool, limit int) []*upstream.ServerJSON {
+	return (*FactsIndex)(nil).TopServers(servers, key, descending, limit)
+}
+
+// extractTimestamp reads a timestamp field from the official metadata of a server
+func extractTimestamp(server *upstream.ServerJSON, field string) (time.Time, bool) {
+	value, ok := extractOfficialField(server, field)
+	if !ok {
+		return time.Time{}, false
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return timestamp, true
+}
@@ -0,0 +1,152 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractTimestamps(t *testing.T) {
+	t.Parallel()
+
+	published := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	server := NewTestServer("io.test/server", WithTimestamps(published, updated))
+	publishedAt, ok := ExtractPublishedAt(&server)
+	assert.True(t, ok)
+	assert.True(t, published.Equal(publishedAt))
+	updatedAt, ok := ExtractUpdatedAt(&server)
+	assert.True(t, ok)
+	assert.True(t, updated.Equal(updatedAt))
+
+	neverUpdated := NewTestServer("io.test/server", WithTimestamps(published, time.Time{}))
+	updatedAt, ok = ExtractUpdatedAt(&neverUpdated)
+	assert.True(t, ok)
+	assert.True(t, published.Equal(updatedAt))
+
+	withoutTimestamps := NewTestServer("io.test/server", WithTags("tag"))
+	_, ok = ExtractPublishedAt(&withoutTimestamps)
+	assert.False(t, ok)
+	_, ok = ExtractUpdatedAt(&withoutTimestamps)
+	assert.False(t, ok)
+	assert.Equal(t, []string{"tag"}, ExtractTags(&withoutTimestamps))
+}
+
+func TestFilterByTimestamps(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time {
+		return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	servers := func() []*upstream.ServerJSON {
+		old := NewTestServer("io.test/old", WithTimestamps(day(1), day(2)))
+		recent := NewTestServer("io.test/recent", WithTimestamps(day(10), day(20)))
+		undated := NewTestServer("io.test/undated")
+		return []*upstream.ServerJSON{&old, &recent, &undated}
+	}
+
+	tests := []struct {
+		name            string
+		updatedAfter    time.Time
+		publishedBefore time.Time
+		want            []string
+	}{
+		{
+			name: "no bounds",
+			want: []string{"io.test/old", "io.test/recent", "io.test/undated"},
+		},
+		{
+			name:         "updated after",
+			updatedAfter: day(5),
+			want:         []string{"io.test/recent"},
+		},
+		{
+			name:            "published before",
+			publishedBefore: day(5),
+			want:            []string{"io.test/old"},
+		},
+		{
+			name:            "both bounds",
+			updatedAfter:    day(15),
+			publishedBefore: day(11),
+			want:            []string{"io.test/recent"},
+		},
+		{
+			name:         "bounds are exclusive",
+			updatedAfter: day(20),
+			want:         []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			filtered := FilterByTimestamps(servers(), tt.updatedAfter, tt.publishedBefore)
+			names := make([]string, 0, len(filtered))
+			for _, server := range filtered {
+				names = append(names, server.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestSortServers(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time {
+		return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	servers := func() []*upstream.ServerJSON {
+		beta := NewTestServer("io.test/beta", WithTimestamps(day(1), day(30)))
+		alpha := NewTestServer("io.test/alpha", WithTimestamps(day(10), day(11)))
+		undated := NewTestServer("io.test/undated")
+		gamma := NewTestServer("io.test/gamma", WithTimestamps(day(5), time.Time{}))
+		return []*upstream.ServerJSON{&beta, &alpha, &undated, &gamma}
+	}
+
+	tests := []struct {
+		name       string
+		key        string
+		descending bool
+		want       []string
+	}{
+		{
+			name: "by name",
+			key:  SortByName,
+			want: []string{"io.test/alpha", "io.test/beta", "io.test/gamma", "io.test/undated"},
+		},
+		{
+			name: "by published at",
+			key:  SortByPublishedAt,
+			want: []string{"io.test/beta", "io.test/gamma", "io.test/alpha", "io.test/undated"},
+		},
+		{
+			name:       "by updated at descending",
+			key:        SortByUpdatedAt,
+			descending: true,
+			want:       []string{"io.test/beta", "io.test/alpha", "io.test/gamma", "io.test/undated"},
+		},
+		{
+			name: "unknown key keeps the original order",
+			key:  "stars",
+			want: []string{"io.test/beta", "io.test/alpha", "io.test/undated", "io.test/gamma"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sorted := servers()
+			SortServers(sorted, tt.key, tt.descending)
+			names := make([]string, 0, len(sorted))
+			for _, server := range sorted {
+				names = append(names, server.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package registry
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// TopK returns the first k items of items in the order defined by cmp, as a stable sort would.
+// Rather than sorting all items, it keeps the k first items seen so far in a bounded heap,
+// which takes O(n log k) instead of O(n log n) when k is much smaller than len(items).
+// A non-positive k, or one of at least len(items), sorts all items. items is left unmodified.
+func TopK[T any](items []T, k int, cmp func(a, b T) int) []T {
+	if k <= 0 || k >= len(items) {
+		sorted := slices.Clone(items)
+		slices.SortStableFunc(sorted, cmp)
+		return sorted
+	}
+
+	h := &topKHeap[T]{cmp: cmp, entries: make([]topKEntry[T], 0, k)}
+	for i, item := range items {
+		entry := topKEntry[T]{item: item, index: i}
+		if h.Len() < k {
+			heap.Push(h, entry)
+			continue
+		}
+		// The root is the last of the k first items so far; replace it if the item comes before it
+		if h.compare(entry, h.entries[0]) < 0 {
+			h.entries[0] = entry
+			heap.Fix(h, 0)
+		}
+	}
+
+	slices.SortFunc(h.entries, h.compare)
+	result := make([]T, len(h.entries))
+	for i, entry := range h.entries {
+		result[i] = entry.item
+	}
+	return result
+}
+
+// topKEntry is an item kept by TopK with its position, which breaks ties to keep the order stable
+type topKEntry[T any] struct {
+	item  T
+	index int
+}
+
+// topKHeap is a heap of TopK entries whose root is the entry coming last in order
+type topKHeap[T any] struct {
+	cmp     func(a, b T) int
+	entries []topKEntry[T]
+}
+
+// compare orders entries by cmp, then by position
+func (h *topKHeap[T]) compare(a, b topKEntry[T]) int {
+	if c := h.cmp(a.item, b.item); c != 0 {
+		return c
+	}
+	return a.index - b.index
+}
+
+// Len implements heap.Interface
+func (h *topKHeap[T]) Len() int { return len(h.entries) }
+
+// Less implements heap.Interface, placing the entry coming last in order at the root
+func (h *topKHeap[T]) Less(i, j int) bool { return h.compare(h.entries[i], h.entries[j]) > 0 }
+
+// Swap implements heap.Interface
+func (h *topKHeap[T]) Swap(i, j int) { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+
+// Push implements heap.Interface
+func (h *topKHeap[T]) Push(x any) { h.entries = append(h.entries, x.(topKEntry[T])) }
+
+// Pop implements heap.Interface
+func (h *topKHeap[T]) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopK(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		key int
+		id  string
+	}
+	byKey := func(a, b item) int { return cmp.Compare(a.key, b.key) }
+	items := []item{{3, "a"}, {1, "b"}, {2, "c"}, {1, "d"}, {5, "e"}, {2, "f"}}
+
+	tests := []struct {
+		name string
+		k    int
+		want []string
+	}{
+		{name: "first items keep their relative order", k: 3, want: []string{"b", "d", "c"}},
+		{name: "single item", k: 1, want: []string{"b"}},
+		{name: "k covering all items sorts them", k: 6, want: []string{"b", "d", "c", "f", "a", "e"}},
+		{name: "k beyond the items sorts them", k: 10, want: []string{"b", "d", "c", "f", "a", "e"}},
+		{name: "non-positive k sorts all items", k: 0, want: []string{"b", "d", "c", "f", "a", "e"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := TopK(items, tt.k, byKey)
+			ids := make([]string, 0, len(got))
+			for _, it := range got {
+				ids = append(ids, it.id)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+
+	assert.Equal(t, "a", items[0].id, "input should be left unmodified")
+	assert.Empty(t, TopK([]item{}, 3, byKey))
+}
+
+func TestTopKMatchesStableSort(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = rng.IntN(50)
+	}
+	sorted := slices.Clone(values)
+	slices.SortStableFunc(sorted, cmp.Compare[int])
+
+	for _, k := range []int{1, 7, 100, 999} {
+		assert.Equal(t, sorted[:k], TopK(values, k, cmp.Compare[int]), "k=%d", k)
+	}
+}
+
+func TestTopServers(t *testing.T) {
+	t.Parallel()
+
+	older := NewTestServer("io.example/older", WithTimestamps(time.Time{}, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+	newer := NewTestServer("io.example/newer", WithTimestamps(time.Time{}, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	undated := NewTestServer("io.example/undated")
+	servers := []*upstream.ServerJSON{&undated, &older, &newer}
+
+	assert.Equal(t, []*upstream.ServerJSON{&newer, &older}, TopServers(servers, SortByUpdatedAt, true, 2))
+	assert.Equal(t, []*upstream.ServerJSON{&newer}, TopServers(servers, SortByName, false, 1))
+	assert.Equal(t, []*upstream.ServerJSON{&undated}, TopServers(servers, "stars", false, 1))
+	assert.Equal(t, []*upstream.ServerJSON{&undated, &older, &newer}, servers, "input should be left unmodified")
+}
+
+// benchmarkServers returns a catalog of servers with random update timestamps
+func benchmarkServers(count int) []*upstream.ServerJSON {
+	rng := rand.New(rand.NewPCG(1, 2))
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	servers := make([]*upstream.ServerJSON, count)
+	for i := range servers {
+		server := NewTestServer(fmt.Sprintf("io.example/server-%06d", i),
+			WithTimestamps(base, base.Add(time.Duration(rng.IntN(count*10))*time.Minute)))
+		servers[i] = &server
+	}
+	return servers
+}
+
+func BenchmarkTopServers(b *testing.B) {
+	for _, count := range []int{1000, 50000} {
+		servers := benchmarkServers(count)
+
+		b.Run(fmt.Sprintf("sort/%d", count), func(b *testing.B) {
+			for b.Loop() {
+				sorted := slices.Clone(servers)
+				SortServers(sorted, SortByUpdatedAt, true)
+				_ = sorted[:20]
+			}
+		})
+		b.Run(fmt.Sprintf("top-k/%d", count), func(b *testing.B) {
+			for b.Loop() {
+				_ = TopServers(servers, SortByUpdatedAt, true, 20)
+			}
+		})
+	}
+}
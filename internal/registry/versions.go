@@ -0,0 +1,129 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// LatestVersions returns the latest version of each server in the given slice, sorted by server name.
+// Versions are compared using semantic versioning, falling back to string comparison
+// for versions that cannot be parsed.
+func LatestVersions(servers []upstream.ServerJSON) []*upstream.ServerJSON {
+	latest := make(map[string]*upstream.ServerJSON)
+	for i := range servers {
+		server := &servers[i]
+		existing, ok := latest[server.Name]
+		if !ok || CompareVersions(server.Version, existing.Version) > 0 {
+			latest[server.Name] = server
+		}
+	}
+
+	result := make([]*upstream.ServerJSON, 0, len(latest))
+	for _, server := range latest {
+		result = append(result, server)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// LatestVersion returns the latest of the given versions of a server, whatever their order, or nil
+// if there is none. Versions are compared with CompareVersions.
+func LatestVersion(versions []*upstream.ServerJSON) *upstream.ServerJSON {
+	var latest *upstream.ServerJSON
+	for _, server := range versions {
+		if latest == nil || CompareVersions(server.Version, latest.Version) > 0 {
+			latest = server
+		}
+	}
+	return latest
+}
+
+// CompareVersions compares two version strings and returns -1, 0, or 1 if a is
+// respectively lower than, equal to, or greater than b.
+// Falls back to string comparison if semantic versioning parsing fails.
+func CompareVersions(a, b string) int {
+	aSemver, errA := semver.NewVersion(a)
+	bSemver, errB := semver.NewVersion(b)
+
+	if errA != nil || errB != nil {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return aSemver.Compare(bSemver)
+}
+
+// VersionResolution is the outcome of resolving a version constraint against the published versions of a server
+type VersionResolution struct {
+	// Constraint is the semantic versioning range that was resolved (e.g. "^1.2" or ">=2.0 <3")
+	Constraint string
+	// Server is the highest version satisfying the constraint, or nil if none does
+	Server *upstream.ServerJSON
+	// Candidates are the published versions satisfying the constraint, highest first
+	Candidates []string
+}
+
+// IsVersionConstraint reports whether a requested version is a semantic versioning range
+// rather than an exact version or the special "latest" version
+func IsVersionConstraint(version string) bool {
+	if strings.ContainsAny(version, "^~<>=*|, ") {
+		return true
+	}
+	for _, part := range strings.Split(version, ".") {
+		if part == "x" || part == "X" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveVersion resolves a semantic versioning range against the given versions of a server.
+// Versions that are not valid semantic versions are never candidates. An error is returned
+// if the constraint cannot be parsed.
+func ResolveVersion(constraint string, versions []*upstream.ServerJSON) (*VersionResolution, error) {
+	parsed, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+	}
+
+	type candidate struct {
+		version *semver.Version
+		server  *upstream.ServerJSON
+	}
+	var candidates []candidate
+	for _, server := range versions {
+		version, err := semver.NewVersion(server.Version)
+		if err != nil || !parsed.Check(version) {
+			continue
+		}
+		candidates = append(candidates, candidate{version: version, server: server})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].version.GreaterThan(candidates[j].version)
+	})
+
+	resolution := &VersionResolution{
+		Constraint: constraint,
+		Candidates: make([]string, 0, len(candidates)),
+	}
+	for _, c := range candidates {
+		resolution.Candidates = append(resolution.Candidates, c.server.Version)
+	}
+	if len(candidates) > 0 {
+		resolution.Server = candidates[0].server
+	}
+	return resolution, nil
+}
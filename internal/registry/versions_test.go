@@ -0,0 +1,142 @@
+package registry
+
+import (
+	"testing"
+
+	upstream "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{name: "semver lower", a: "1.2.0", b: "1.10.0", want: -1},
+		{name: "semver greater", a: "2.0.0", b: "1.10.0", want: 1},
+		{name: "semver equal", a: "v1.0.0", b: "1.0.0", want: 0},
+		{name: "non-semver falls back to string comparison", a: "latest", b: "1.0.0", want: 1},
+		{name: "non-semver equal", a: "latest", b: "latest", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, CompareVersions(tt.a, tt.b))
+		})
+	}
+}
+
+func TestLatestVersions(t *testing.T) {
+	t.Parallel()
+
+	servers := []upstream.ServerJSON{
+		NewTestServer("io.test/zeta", WithServerVersion("1.0.0")),
+		NewTestServer("io.test/alpha", WithServerVersion("1.10.0")),
+		NewTestServer("io.test/alpha", WithServerVersion("1.2.0")),
+		NewTestServer("io.test/zeta", WithServerVersion("2.0.0")),
+	}
+
+	latest := LatestVersions(servers)
+
+	assert.Len(t, latest, 2)
+	assert.Equal(t, "io.test/alpha", latest[0].Name)
+	assert.Equal(t, "1.10.0", latest[0].Version)
+	assert.Equal(t, "io.test/zeta", latest[1].Name)
+	assert.Equal(t, "2.0.0", latest[1].Version)
+	assert.Empty(t, LatestVersions(nil))
+}
+
+func TestLatestVersion(t *testing.T) {
+	t.Parallel()
+
+	older := NewTestServer("io.test/alpha", WithServerVersion("1.2.0"))
+	latest := NewTestServer("io.test/alpha", WithServerVersion("1.10.0"))
+	oldest := NewTestServer("io.test/alpha", WithServerVersion("1.0.0"))
+
+	assert.Same(t, &latest, LatestVersion([]*upstream.ServerJSON{&older, &latest, &oldest}))
+	assert.Nil(t, LatestVersion(nil))
+}
+
+func TestIsVersionConstraint(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []string{"^1.2", "~1.2.3", ">=2.0 <3", ">=2.0,<3", "1.x", "*", "1.2 || 2.0"} {
+		assert.True(t, IsVersionConstraint(version), version)
+	}
+	for _, version := range []string{"1.2.3", "v1.0.0", "latest", "2025-01-01", "1.0.0-rc.1"} {
+		assert.False(t, IsVersionConstraint(version), version)
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	t.Parallel()
+
+	servers := []upstream.ServerJSON{
+		NewTestServer("io.test/server", WithServerVersion("1.1.0")),
+		NewTestServer("io.test/server", WithServerVersion("1.2.0")),
+		NewTestServer("io.test/server", WithServerVersion("1.10.0")),
+		NewTestServer("io.test/server", WithServerVersion("2.0.0")),
+		NewTestServer("io.test/server", WithServerVersion("3.0.0")),
+		NewTestServer("io.test/server", WithServerVersion("nightly")),
+	}
+	versions := make([]*upstream.ServerJSON, len(servers))
+	for i := range servers {
+		versions[i] = &servers[i]
+	}
+
+	tests := []struct {
+		name           string
+		constraint     string
+		wantVersion    string
+		wantCandidates []string
+		wantErr        bool
+	}{
+		{
+			name:           "caret range",
+			constraint:     "^1.2",
+			wantVersion:    "1.10.0",
+			wantCandidates: []string{"1.10.0", "1.2.0"},
+		},
+		{
+			name:           "space separated range",
+			constraint:     ">=2.0 <3",
+			wantVersion:    "2.0.0",
+			wantCandidates: []string{"2.0.0"},
+		},
+		{
+			name:           "no match",
+			constraint:     "^4",
+			wantCandidates: []string{},
+		},
+		{
+			name:       "invalid constraint",
+			constraint: ">>1",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resolution, err := ResolveVersion(tt.constraint, versions)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.constraint, resolution.Constraint)
+			assert.Equal(t, tt.wantCandidates, resolution.Candidates)
+			if tt.wantVersion == "" {
+				assert.Nil(t, resolution.Server)
+			} else {
+				assert.Equal(t, tt.wantVersion, resolution.Server.Version)
+			}
+		})
+	}
+}
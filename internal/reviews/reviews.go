@@ -0,0 +1,305 @@
+// Package reviews aggregates the ratings and reviews users give servers, ingested from internal surveys
+// or companion UIs. Reviews are kept in a JSON file, and their aggregate is recorded in the server
+// metadata of API responses.
+package reviews
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// MetaKey is the publisher-provided metadata key under which the rating of a server is recorded
+	MetaKey = "io.github.stacklok/reviews"
+
+	// MinRating and MaxRating bound the rating of a review
+	MinRating = 1
+	MaxRating = 5
+
+	// maxCommentLength caps the length of the comment of a review
+	maxCommentLength = 4096
+	// maxFieldLength caps the length of the author, source and ID of a review
+	maxFieldLength = 256
+	// snippetCount is the number of recent comments reported with the rating of a server
+	snippetCount = 3
+	// maxSnippetLength caps the length of the comments reported with the rating of a server
+	maxSnippetLength = 280
+)
+
+// ErrInvalidReview is returned when a review has no server, a rating out of bounds or a field too long
+var ErrInvalidReview = errors.New("invalid review")
+
+// Review is the rating a user gave a server, with an optional comment
+type Review struct {
+	Server string `json:"server"`
+	// Rating is from MinRating to MaxRating
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment,omitempty"`
+	Author  string `json:"author,omitempty"`
+	// Source is where the review was collected, e.g. a survey
+	Source string `json:"source,omitempty"`
+	// ID identifies the review in its source. A review ingested again with the same source and ID
+	// replaces the previous one.
+	ID string `json:"id,omitempty"`
+	// SubmittedAt is when the user gave the review, the time of ingestion if not set
+	SubmittedAt time.Time `json:"submittedAt"`
+}
+
+// Snippet is the comment of a recent review
+type Snippet struct {
+	Rating      int       `json:"rating"`
+	Comment     string    `json:"comment"`
+	Author      string    `json:"author,omitempty"`
+	SubmittedAt time.Time `json:"submittedAt"`
+}
+
+// Rating aggregates the reviews of a server
+type Rating struct {
+	Server string `json:"server"`
+	// Average is the mean rating, rounded to two decimals
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+	// Distribution is the number of reviews of each rating, from MinRating to MaxRating
+	Distribution [MaxRating - MinRating + 1]int `json:"distribution"`
+	// Snippets are the comments of the most recent reviews, shortened
+	Snippets []Snippet `json:"snippets"`
+}
+
+// Store keeps reviews in memory and persists them to a JSON file on every ingestion.
+// It is safe for concurrent use.
+type Store struct {
+	path string
+	now  func() time.Time
+
+	mu      sync.RWMutex
+	reviews map[string][]Review
+}
+
+// NewStore creates a store persisted to the file at path, loading the reviews it holds, if any
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:    path,
+		now:     time.Now,
+		reviews: make(map[string][]Review),
+	}
+
+	// #nosec G304 -- the path is set by the operator in the configuration
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read reviews file: %w", err)
+	}
+	var reviews []Review
+	if err := json.Unmarshal(data, &reviews); err != nil {
+		return nil, fmt.Errorf("failed to parse reviews file: %w", err)
+	}
+	for _, review := range reviews {
+		s.reviews[review.Server] = append(s.reviews[review.Server], review)
+	}
+	return s, nil
+}
+
+// Ingest validates and records reviews, replacing those with the same source and ID, and returns the
+// number of reviews recorded. Either all reviews are recorded, or none.
+func (s *Store) Ingest(reviews []Review) (int, error) {
+	now := s.now().UTC()
+	for i := range reviews {
+		if err := validate(reviews[i]); err != nil {
+			return 0, fmt.Errorf("review %d: %w", i, err)
+		}
+		if reviews[i].SubmittedAt.IsZero() {
+			reviews[i].SubmittedAt = now
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := make(map[string][]Review, len(reviews))
+	for _, review := range reviews {
+		if _, ok := previous[review.Server]; !ok {
+			previous[review.Server] = s.reviews[review.Server]
+			s.reviews[review.Server] = slices.Clone(s.reviews[review.Server])
+		}
+		s.reviews[review.Server] = replaceOrAppend(s.reviews[review.Server], review)
+	}
+	if err := s.saveLocked(); err != nil {
+		for server, reviews := range previous {
+			if reviews == nil {
+				delete(s.reviews, server)
+			} else {
+				s.reviews[server] = reviews
+			}
+		}
+		return 0, err
+	}
+	return len(reviews), nil
+}
+
+// Rating returns the aggregate of the reviews of a server, and false if it has none
+func (s *Store) Rating(server string) (Rating, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	reviews, ok := s.reviews[server]
+	if !ok {
+		return Rating{}, false
+	}
+	return aggregate(server, reviews), true
+}
+
+// Ratings returns the aggregate of the reviews of all reviewed servers, highest average first
+func (s *Store) Ratings() []Rating {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ratings := make([]Rating, 0, len(s.reviews))
+	for server, reviews := range s.reviews {
+		ratings = append(ratings, aggregate(server, reviews))
+	}
+	slices.SortFunc(ratings, func(a, b Rating) int {
+		return cmp.Or(cmp.Compare(b.Average, a.Average), cmp.Compare(b.Count, a.Count), cmp.Compare(a.Server, b.Server))
+	})
+	return ratings
+}
+
+// Annotate records the rating of a server, if reviewed, in its metadata under MetaKey
+func (s *Store) Annotate(server upstreamv0.ServerJSON) upstreamv0.ServerJSON {
+	rating, ok := s.Rating(server.Name)
+	if !ok {
+		return server
+	}
+
+	meta := upstreamv0.ServerMeta{}
+	if server.Meta != nil {
+		meta = *server.Meta
+	}
+	publisherProvided := make(map[string]interface{}, len(meta.PublisherProvided)+1)
+	for key, value := range meta.PublisherProvided {
+		publisherProvided[key] = value
+	}
+	snippets := make([]interface{}, len(rating.Snippets))
+	for i, snippet := range rating.Snippets {
+		snippets[i] = map[string]interface{}{
+			"rating":      snippet.Rating,
+			"comment":     snippet.Comment,
+			"submittedAt": snippet.SubmittedAt.Format(time.RFC3339),
+		}
+	}
+	publisherProvided[MetaKey] = map[string]interface{}{
+		"average":  rating.Average,
+		"count":    rating.Count,
+		"snippets": snippets,
+	}
+	meta.PublisherProvided = publisherProvided
+	server.Meta = &meta
+	return server
+}
+
+// saveLocked writes the reviews to the file, replacing it atomically.
+// Caller must hold s.mu write lock.
+func (s *Store) saveLocked() error {
+	reviews := []Review{}
+	for _, server := range slices.Sorted(maps.Keys(s.reviews)) {
+		reviews = append(reviews, s.reviews[server]...)
+	}
+	data, err := json.MarshalIndent(reviews, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal reviews: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0750); err != nil {
+		return fmt.Errorf("failed to create reviews directory: %w", err)
+	}
+	tempPath := s.path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write temporary reviews file: %w", err)
+	}
+	if err := os.Rename(tempPath, s.path); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to rename reviews file: %w", err)
+	}
+	return nil
+}
+
+// replaceOrAppend replaces the review with the source and ID of the given one, if it has an ID,
+// and appends it otherwise
+func replaceOrAppend(reviews []Review, review Review) []Review {
+	if review.ID != "" {
+		i := slices.IndexFunc(reviews, func(r Review) bool { return r.Source == review.Source && r.ID == review.ID })
+		if i >= 0 {
+			reviews[i] = review
+			return reviews
+		}
+	}
+	return append(reviews, review)
+}
+
+// aggregate computes the rating of a server from its reviews
+func aggregate(server string, reviews []Review) Rating {
+	rating := Rating{Server: server, Count: len(reviews), Snippets: []Snippet{}}
+	total := 0
+	for _, review := range reviews {
+		total += review.Rating
+		rating.Distribution[review.Rating-MinRating]++
+	}
+	if len(reviews) > 0 {
+		rating.Average = math.Round(float64(total)/float64(len(reviews))*100) / 100
+	}
+
+	commented := make([]Review, 0, len(reviews))
+	for _, review := range reviews {
+		if review.Comment != "" {
+			commented = append(commented, review)
+		}
+	}
+	slices.SortStableFunc(commented, func(a, b Review) int { return b.SubmittedAt.Compare(a.SubmittedAt) })
+	for _, review := range commented[:min(snippetCount, len(commented))] {
+		rating.Snippets = append(rating.Snippets, Snippet{
+			Rating:      review.Rating,
+			Comment:     shorten(review.Comment),
+			Author:      review.Author,
+			SubmittedAt: review.SubmittedAt,
+		})
+	}
+	return rating
+}
+
+// shorten cuts a comment to maxSnippetLength runes, ending it with an ellipsis
+func shorten(comment string) string {
+	if utf8.RuneCountInString(comment) <= maxSnippetLength {
+		return comment
+	}
+	return string([]rune(comment)[:maxSnippetLength-1]) + "…"
+}
+
+// validate checks the server and rating of a review and the length of its fields
+func validate(review Review) error {
+	if review.Server == "" {
+		return fmt.Errorf("%w: server is required", ErrInvalidReview)
+	}
+	if review.Rating < MinRating || review.Rating > MaxRating {
+		return fmt.Errorf("%w: rating must be between %d and %d", ErrInvalidReview, MinRating, MaxRating)
+	}
+	if len(review.Comment) > maxCommentLength {
+		return fmt.Errorf("%w: comment must be at most %d bytes", ErrInvalidReview, maxCommentLength)
+	}
+	fields := []struct{ name, value string }{{"author", review.Author}, {"source", review.Source}, {"id", review.ID}}
+	for _, field := range fields {
+		if len(field.value) > maxFieldLength {
+			return fmt.Errorf("%w: %s must be at most %d bytes", ErrInvalidReview, field.name, maxFieldLength)
+		}
+	}
+	return nil
+}
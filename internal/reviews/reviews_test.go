@@ -0,0 +1,140 @@
+package reviews
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "reviews", "reviews.json")
+	store, err := NewStore(path)
+	require.NoError(t, err)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	ingested, err := store.Ingest([]Review{
+		{Server: "io.test/liked", Rating: 5, Comment: "Works great", Source: "survey", ID: "1",
+			SubmittedAt: now.Add(-time.Hour)},
+		{Server: "io.test/liked", Rating: 4, Source: "survey", ID: "2"},
+		{Server: "io.test/disliked", Rating: 1, Comment: "Crashes on startup"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, ingested)
+
+	rating, ok := store.Rating("io.test/liked")
+	require.True(t, ok)
+	assert.Equal(t, 4.5, rating.Average)
+	assert.Equal(t, 2, rating.Count)
+	assert.Equal(t, [5]int{0, 0, 0, 1, 1}, rating.Distribution)
+	assert.Equal(t, []Snippet{{Rating: 5, Comment: "Works great", SubmittedAt: now.Add(-time.Hour)}}, rating.Snippets)
+	_, ok = store.Rating("io.test/unknown")
+	assert.False(t, ok)
+
+	// A review ingested again with the same source and ID replaces the previous one
+	_, err = store.Ingest([]Review{{Server: "io.test/liked", Rating: 2, Source: "survey", ID: "2"}})
+	require.NoError(t, err)
+	rating, _ = store.Rating("io.test/liked")
+	assert.Equal(t, 3.5, rating.Average)
+	assert.Equal(t, 2, rating.Count)
+
+	servers := func(ratings []Rating) []string {
+		names := make([]string, 0, len(ratings))
+		for _, rating := range ratings {
+			names = append(names, rating.Server)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"io.test/liked", "io.test/disliked"}, servers(store.Ratings()))
+
+	// Reviews are loaded back from the file
+	reloaded, err := NewStore(path)
+	require.NoError(t, err)
+	assert.Equal(t, store.Ratings(), reloaded.Ratings())
+}
+
+func TestStoreIngestInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		review Review
+	}{
+		{name: "missing server", review: Review{Rating: 3}},
+		{name: "rating too low", review: Review{Server: "io.test/server", Rating: 0}},
+		{name: "rating too high", review: Review{Server: "io.test/server", Rating: 6}},
+		{name: "comment too long", review: Review{Server: "io.test/server", Rating: 3,
+			Comment: strings.Repeat("a", maxCommentLength+1)}},
+		{name: "author too long", review: Review{Server: "io.test/server", Rating: 3,
+			Author: strings.Repeat("a", maxFieldLength+1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			store, err := NewStore(filepath.Join(t.TempDir(), "reviews.json"))
+			require.NoError(t, err)
+
+			// Valid reviews of the same request are not ingested either
+			_, err = store.Ingest([]Review{{Server: "io.test/valid", Rating: 3}, tt.review})
+			require.ErrorIs(t, err, ErrInvalidReview)
+			assert.Empty(t, store.Ratings())
+		})
+	}
+}
+
+func TestSnippets(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewStore(filepath.Join(t.TempDir(), "reviews.json"))
+	require.NoError(t, err)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var batch []Review
+	for i := range 5 {
+		batch = append(batch, Review{
+			Server:      "io.test/server",
+			Rating:      3,
+			Comment:     strings.Repeat(string(rune('a'+i)), maxSnippetLength+10),
+			SubmittedAt: start.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	_, err = store.Ingest(batch)
+	require.NoError(t, err)
+
+	rating, _ := store.Rating("io.test/server")
+	require.Len(t, rating.Snippets, snippetCount)
+	// The most recent comments come first, shortened
+	assert.Equal(t, start.Add(4*time.Hour), rating.Snippets[0].SubmittedAt)
+	assert.Equal(t, strings.Repeat("e", maxSnippetLength-1)+"…", rating.Snippets[0].Comment)
+}
+
+func TestAnnotate(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewStore(filepath.Join(t.TempDir(), "reviews.json"))
+	require.NoError(t, err)
+	_, err = store.Ingest([]Review{{Server: "io.test/reviewed", Rating: 4,
+		SubmittedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Comment: "Handy"}})
+	require.NoError(t, err)
+
+	unreviewed := registry.NewTestServer("io.test/unreviewed")
+	assert.Equal(t, unreviewed, store.Annotate(unreviewed))
+
+	reviewed := store.Annotate(registry.NewTestServer("io.test/reviewed"))
+	require.NotNil(t, reviewed.Meta)
+	assert.Equal(t, map[string]interface{}{
+		"average": 4.0,
+		"count":   1,
+		"snippets": []interface{}{
+			map[string]interface{}{"rating": 4, "comment": "Handy", "submittedAt": "2026-01-02T03:04:05Z"},
+		},
+	}, reviewed.Meta.PublisherProvided[MetaKey])
+}
@@ -0,0 +1,511 @@
+package sbom
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/cache"
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+)
+
+const (
+	// DefaultMaxSize is the default maximum size of an SBOM document
+	DefaultMaxSize = 10 * 1024 * 1024
+	// DefaultCacheTTL is the default time SBOM summaries are cached
+	DefaultCacheTTL = time.Hour
+
+	// maxCacheEntries caps the number of cached summaries
+	maxCacheEntries = 256
+	// maxManifestSize caps the size of image manifests and referrer indexes
+	maxManifestSize = 4 * 1024 * 1024
+
+	defaultFetchTimeout = 30 * time.Second
+
+	dockerHubHost     = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+
+	mediaTypeOCIIndex    = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest = "application/vnd.oci.image.manifest.v1+json"
+)
+
+// DefaultTokenRealmHosts are the hosts of the token services trusted in addition to the registry host
+// of each image: the token service of Docker Hub, whose registry host differs
+var DefaultTokenRealmHosts = []string{"auth.docker.io"}
+
+// manifestMediaTypes are the image manifest media types accepted when resolving an image digest
+var manifestMediaTypes = []string{
+	mediaTypeOCIIndex,
+	mediaTypeOCIManifest,
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// sbomMediaTypes are the artifact and layer media types of SPDX and CycloneDX JSON documents
+var sbomMediaTypes = []string{
+	"application/spdx+json",
+	"application/vnd.cyclonedx+json",
+	"text/spdx+json",
+}
+
+// ErrNoSBOM is returned when no SBOM is attached to an image
+var ErrNoSBOM = errors.New("no SBOM attached to image")
+
+// Fetcher retrieves the SBOMs attached to container images and caches their summaries by image
+// digest, so that an image is summarized again once its tag moves to another digest.
+// It is safe for concurrent use.
+type Fetcher struct {
+	client    *http.Client
+	maxSize   int64
+	ttl       time.Duration
+	plainHTTP bool
+	// realmHosts are the hosts of the token services trusted in addition to the registry hosts
+	realmHosts []string
+	now        func() time.Time
+	cacheOpts  []cache.Option
+	cache      *cache.LRU[string, cacheEntry]
+}
+
+// cacheEntry is a cached summary, or a cached ErrNoSBOM when summary is nil
+type cacheEntry struct {
+	summary   *Summary
+	fetchedAt time.Time
+}
+
+// Option configures a Fetcher
+type Option func(*Fetcher)
+
+// WithHTTPClient sets the HTTP client used to reach container registries
+func WithHTTPClient(client *http.Client) Option {
+	return func(f *Fetcher) {
+		f.client = client
+	}
+}
+
+// WithMaxSize sets the maximum size of an SBOM document; larger documents are rejected
+func WithMaxSize(maxSize int64) Option {
+	return func(f *Fetcher) {
+		f.maxSize = maxSize
+	}
+}
+
+// WithCacheTTL sets the freshness window of SBOM summaries, and of the absence of an SBOM,
+// during which they are served from the cache
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(f *Fetcher) {
+		f.ttl = ttl
+	}
+}
+
+// WithCache bounds the memory held by cached summaries, e.g. with cache.WithBudget.
+// The cache holds at most 256 summaries regardless of the options.
+func WithCache(opts ...cache.Option) Option {
+	return func(f *Fetcher) {
+		f.cacheOpts = append(f.cacheOpts, opts...)
+	}
+}
+
+// WithPlainHTTP reaches container registries over plain HTTP instead of HTTPS
+func WithPlainHTTP() Option {
+	return func(f *Fetcher) {
+		f.plainHTTP = true
+	}
+}
+
+// WithTokenRealmHosts trusts the token services on the given hosts, in addition to DefaultTokenRealmHosts
+// and the registry host of each image, for registries whose token service is on another host
+func WithTokenRealmHosts(hosts ...string) Option {
+	return func(f *Fetcher) {
+		f.realmHosts = append(f.realmHosts, hosts...)
+	}
+}
+
+// NewFetcher creates a new SBOM fetcher
+func NewFetcher(opts ...Option) *Fetcher {
+	f := &Fetcher{
+		client:     &http.Client{Timeout: defaultFetchTimeout, Transport: httpclient.NewTimingTransport(nil)},
+		maxSize:    DefaultMaxSize,
+		ttl:        DefaultCacheTTL,
+		realmHosts: slices.Clone(DefaultTokenRealmHosts),
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	f.cache = cache.New[string, cacheEntry]("sbom", append(f.cacheOpts, cache.WithMaxEntries(maxCacheEntries))...)
+	return f
+}
+
+// Fetch returns the summary of the SBOM attached to an image, given as an OCI reference
+// (e.g. "ghcr.io/example/server:1.0.0"). ErrNoSBOM is returned when the image carries none.
+// Tags are resolved to their digest on every call, summaries are then served from the cache
+// within their freshness window, along with their age.
+func (f *Fetcher) Fetch(ctx context.Context, image string) (*Summary, error) {
+	ref, err := parseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	session := &registrySession{fetcher: f, ref: ref}
+
+	digest := ref.digest
+	if digest == "" {
+		digest, err = session.resolveDigest(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entry, ok := f.cached(digest)
+	if !ok {
+		summary, err := f.fetch(ctx, session, image, digest)
+		if err != nil && !errors.Is(err, ErrNoSBOM) {
+			return nil, err
+		}
+		entry = f.store(digest, summary)
+	}
+	if entry.summary == nil {
+		return nil, ErrNoSBOM
+	}
+
+	// The cached summary is shared by the tags of the digest, and its age grows
+	summary := *entry.summary
+	summary.Image = image
+	summary.FetchedAt = entry.fetchedAt
+	summary.AgeSeconds = int64(f.now().Sub(entry.fetchedAt).Seconds())
+	return &summary, nil
+}
+
+// cached returns the cached summary of an image digest, if still fresh
+func (f *Fetcher) cached(digest string) (cacheEntry, bool) {
+	entry, ok := f.cache.Get(digest)
+	if !ok {
+		return cacheEntry{}, false
+	}
+	if f.now().After(entry.fetchedAt.Add(f.ttl)) {
+		f.cache.Remove(digest)
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// store caches the summary of an image digest
+func (f *Fetcher) store(digest string, summary *Summary) cacheEntry {
+	size := int64(len(digest))
+	if summary != nil {
+		size += cache.JSONSize(summary)
+	}
+	entry := cacheEntry{summary: summary, fetchedAt: f.now()}
+	f.cache.Add(digest, entry, size)
+	return entry
+}
+
+// descriptor is an OCI content descriptor
+type descriptor struct {
+	MediaType    string `json:"mediaType"`
+	ArtifactType string `json:"artifactType"`
+	Digest       string `json:"digest"`
+	Size         int64  `json:"size"`
+}
+
+// fetch finds an SBOM among the referrers of the image digest and summarizes it
+func (f *Fetcher) fetch(ctx context.Context, session *registrySession, image, digest string) (*Summary, error) {
+	var referrers struct {
+		Manifests []descriptor `json:"manifests"`
+	}
+	if err := session.getJSON(ctx, "referrers/"+digest, mediaTypeOCIIndex, &referrers); err != nil {
+		var httpErr *httpclient.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return nil, ErrNoSBOM
+		}
+		return nil, fmt.Errorf("failed to list referrers of %s: %w", image, err)
+	}
+
+	for _, referrer := range referrers.Manifests {
+		if !slices.Contains(sbomMediaTypes, referrer.ArtifactType) {
+			continue
+		}
+		var manifest struct {
+			Layers []descriptor `json:"layers"`
+		}
+		if err := session.getJSON(ctx, "manifests/"+referrer.Digest, mediaTypeOCIManifest, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to get SBOM manifest of %s: %w", image, err)
+		}
+		if len(manifest.Layers) == 0 {
+			continue
+		}
+		layer := manifest.Layers[0]
+		if layer.Size > f.maxSize {
+			return nil, fmt.Errorf("SBOM of %s is %d bytes, exceeding the %d bytes limit", image, layer.Size, f.maxSize)
+		}
+		document, _, err := session.get(ctx, "blobs/"+layer.Digest, layer.MediaType, f.maxSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SBOM of %s: %w", image, err)
+		}
+		summary, err := Summarize(document)
+		if err != nil {
+			return nil, err
+		}
+		summary.Image = image
+		summary.Digest = digest
+		return summary, nil
+	}
+	return nil, ErrNoSBOM
+}
+
+// reference is a parsed OCI image reference
+type reference struct {
+	registry   string
+	repository string
+	tag        string
+	digest     string
+}
+
+// parseReference parses an OCI image reference, defaulting to Docker Hub and the latest tag
+func parseReference(image string) (reference, error) {
+	var ref reference
+	name, digest, _ := strings.Cut(image, "@")
+	ref.digest = digest
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.tag = name[:i], name[i+1:]
+	}
+	if name == "" {
+		return reference{}, fmt.Errorf("invalid image reference: %s", image)
+	}
+
+	ref.registry, ref.repository = dockerHubHost, name
+	if host, path, ok := strings.Cut(name, "/"); ok && (strings.ContainsAny(host, ".:") || host == "localhost") {
+		ref.registry, ref.repository = host, path
+	}
+	if ref.registry == dockerHubHost {
+		ref.registry = dockerHubRegistry
+		if !strings.Contains(ref.repository, "/") {
+			ref.repository = "library/" + ref.repository
+		}
+	}
+	if ref.tag == "" && ref.digest == "" {
+		ref.tag = "latest"
+	}
+	return ref, nil
+}
+
+// registrySession performs requests against the repository of an image, requesting an
+// anonymous pull token when the registry asks for one
+type registrySession struct {
+	fetcher *Fetcher
+	ref     reference
+	token   string
+}
+
+// resolveDigest resolves the tag of the image to its manifest digest
+func (s *registrySession) resolveDigest(ctx context.Context) (string, error) {
+	body, header, err := s.get(ctx, "manifests/"+s.ref.tag, strings.Join(manifestMediaTypes, ", "), maxManifestSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve image %s/%s:%s: %w", s.ref.registry, s.ref.repository, s.ref.tag, err)
+	}
+	if digest := header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// getJSON gets a manifest or index of the repository and decodes it
+func (s *registrySession) getJSON(ctx context.Context, path, accept string, target any) error {
+	body, _, err := s.get(ctx, path, accept, maxManifestSize)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", s.ref.registry, err)
+	}
+	return nil
+}
+
+// get performs a GET request against the repository, reading at most maxSize bytes
+func (s *registrySession) get(ctx context.Context, path, accept string, maxSize int64) ([]byte, http.Header, error) {
+	scheme := "https"
+	if s.fetcher.plainHTTP {
+		scheme = "http"
+	}
+	target := fmt.Sprintf("%s://%s/v2/%s/%s", scheme, s.ref.registry, s.ref.repository, path)
+
+	resp, err := s.do(ctx, target, accept)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && s.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		if s.token, err = s.requestToken(ctx, challenge); err != nil {
+			return nil, nil, err
+		}
+		if resp, err = s.do(ctx, target, accept); err != nil {
+			return nil, nil, err
+		}
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, &httpclient.HTTPError{StatusCode: resp.StatusCode, URL: target, Message: resp.Status}
+	}
+	body, err := readLimited(resp.Body, maxSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, resp.Header, nil
+}
+
+// do sends a GET request, authenticated with the session token if any
+func (s *registrySession) do(ctx context.Context, target, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", httpclient.UserAgent())
+	req.Header.Set("Accept", accept)
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	resp, err := s.fetcher.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	return resp, nil
+}
+
+// requestToken requests an anonymous pull token from the realm of a Bearer challenge
+func (s *registrySession) requestToken(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("registry %s requires unsupported authentication: %s", s.ref.registry, challenge)
+	}
+	values := parseChallenge(params)
+	realm := values["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("registry %s sent a challenge without realm", s.ref.registry)
+	}
+	if err := s.checkRealm(realm); err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	if service := values["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := values["scope"]
+	if scope == "" {
+		scope = "repository:" + s.ref.repository + ":pull"
+	}
+	query.Set("scope", scope)
+
+	tokenURL := realm
+	if strings.Contains(realm, "?") {
+		tokenURL += "&" + query.Encode()
+	} else {
+		tokenURL += "?" + query.Encode()
+	}
+	body, err := getBody(ctx, s.fetcher.client, tokenURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to request registry token: %w", err)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("invalid registry token response: %w", err)
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", fmt.Errorf("registry %s returned an empty token", s.ref.registry)
+}
+
+// checkRealm checks that the realm of a challenge is a token service the fetcher trusts: an HTTPS
+// URL on the registry host or one of the trusted realm hosts, so that a registry cannot direct
+// requests to arbitrary hosts. Plain HTTP realms are only accepted when registries are reached
+// over plain HTTP.
+func (s *registrySession) checkRealm(realm string) error {
+	realmURL, err := url.Parse(realm)
+	if err != nil {
+		return fmt.Errorf("registry %s sent an invalid realm: %w", s.ref.registry, err)
+	}
+	if realmURL.Scheme != "https" && (realmURL.Scheme != "http" || !s.fetcher.plainHTTP) {
+		return fmt.Errorf("registry %s sent a realm that is not an HTTPS URL: %s", s.ref.registry, realm)
+	}
+
+	host := realmURL.Hostname()
+	registryHost := s.ref.registry
+	if parsed, err := url.Parse("//" + s.ref.registry); err == nil {
+		registryHost = parsed.Hostname()
+	}
+	trusted := strings.EqualFold(host, registryHost) || slices.ContainsFunc(s.fetcher.realmHosts, func(h string) bool {
+		return strings.EqualFold(host, h)
+	})
+	if host == "" || !trusted {
+		return fmt.Errorf("registry %s sent a realm on an untrusted host: %s", s.ref.registry, realm)
+	}
+	return nil
+}
+
+// getBody performs a plain GET request and returns the body of a 200 response
+func getBody(ctx context.Context, client *http.Client, target string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", httpclient.UserAgent())
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpclient.HTTPError{StatusCode: resp.StatusCode, URL: target, Message: resp.Status}
+	}
+	return readLimited(resp.Body, maxManifestSize)
+}
+
+// parseChallenge parses the comma-separated key="value" parameters of a WWW-Authenticate challenge
+func parseChallenge(params string) map[string]string {
+	values := make(map[string]string)
+	for params != "" {
+		var key, value string
+		key, params, _ = strings.Cut(strings.TrimLeft(params, ", "), "=")
+		if strings.HasPrefix(params, `"`) {
+			value, params, _ = strings.Cut(params[1:], `"`)
+		} else {
+			value, params, _ = strings.Cut(params, ",")
+		}
+		values[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	return values
+}
+
+// readLimited reads a response body, failing if it exceeds maxSize bytes
+func readLimited(body io.Reader, maxSize int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("response exceeds the %d bytes limit", maxSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,284 @@
+package sbom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testImageDigest  = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	testSBOMDigest   = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	testBlobDigest   = "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+	testConfigDigest = "sha256:4444444444444444444444444444444444444444444444444444444444444444"
+)
+
+// fakeRegistry serves an image with an SPDX SBOM attached, requiring an anonymous pull token
+type fakeRegistry struct {
+	server    *httptest.Server
+	referrers []descriptor
+	document  string
+	requests  atomic.Int32
+	// referrerRequests counts the listings of referrers, made once an SBOM is not cached
+	referrerRequests atomic.Int32
+}
+
+func newFakeRegistry(t *testing.T) *fakeRegistry {
+	t.Helper()
+	reg := &fakeRegistry{
+		referrers: []descriptor{
+			{MediaType: mediaTypeOCIManifest, ArtifactType: "application/vnd.dev.sigstore.bundle+json", Digest: "sha256:ffff"},
+			{MediaType: mediaTypeOCIManifest, ArtifactType: "application/spdx+json", Digest: testSBOMDigest},
+		},
+		document: testSPDX,
+	}
+	reg.server = httptest.NewServer(http.HandlerFunc(reg.serveHTTP))
+	t.Cleanup(reg.server.Close)
+	return reg
+}
+
+func (reg *fakeRegistry) host() string {
+	return strings.TrimPrefix(reg.server.URL, "http://")
+}
+
+func (reg *fakeRegistry) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	reg.requests.Add(1)
+	if r.URL.Path == "/token" {
+		if r.URL.Query().Get("scope") != "repository:example/server:pull" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"token":"anonymous"}`))
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer anonymous" {
+		w.Header().Set("WWW-Authenticate",
+			fmt.Sprintf(`Bearer realm="%s/token",service="registry.test",scope="repository:example/server:pull"`, reg.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/v2/example/server/manifests/1.0.0":
+		w.Header().Set("Docker-Content-Digest", testImageDigest)
+		_, _ = w.Write([]byte(`{"schemaVersion":2}`))
+	case "/v2/example/server/referrers/" + testImageDigest:
+		reg.referrerRequests.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"manifests": reg.referrers})
+	case "/v2/example/server/manifests/" + testSBOMDigest:
+		_ = json.NewEncoder(w).Encode(map[string]any{"layers": []descriptor{
+			{MediaType: "application/spdx+json", Digest: testBlobDigest, Size: int64(len(reg.document))},
+		}})
+	case "/v2/example/server/blobs/" + testBlobDigest:
+		_, _ = w.Write([]byte(reg.document))
+	case "/v2/example/server/manifests/multi-arch":
+		_, _ = w.Write([]byte(testIndex))
+	case "/v2/example/server/manifests/single-arch":
+		_ = json.NewEncoder(w).Encode(map[string]any{"config": descriptor{MediaType: "application/vnd.oci.image.config.v1+json", Digest: testConfigDigest}})
+	case "/v2/example/server/blobs/" + testConfigDigest:
+		_, _ = w.Write([]byte(`{"os":"linux","architecture":"arm","variant":"v7","rootfs":{"type":"layers"}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestFetcherFetch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		setup     func(*fakeRegistry)
+		image     func(*fakeRegistry) string
+		opts      []Option
+		wantCount int
+		wantErr   error
+		wantErrIn string
+	}{
+		{
+			name:      "SBOM attached by tag",
+			image:     func(reg *fakeRegistry) string { return reg.host() + "/example/server:1.0.0" },
+			wantCount: 4,
+		},
+		{
+			name:      "SBOM attached by digest",
+			image:     func(reg *fakeRegistry) string { return reg.host() + "/example/server@" + testImageDigest },
+			wantCount: 4,
+		},
+		{
+			name:    "no SBOM among referrers",
+			setup:   func(reg *fakeRegistry) { reg.referrers = reg.referrers[:1] },
+			image:   func(reg *fakeRegistry) string { return reg.host() + "/example/server:1.0.0" },
+			wantErr: ErrNoSBOM,
+		},
+		{
+			name:      "unknown tag",
+			image:     func(reg *fakeRegistry) string { return reg.host() + "/example/server:9.9.9" },
+			wantErrIn: "failed to resolve image",
+		},
+		{
+			name:      "SBOM exceeding the size limit",
+			image:     func(reg *fakeRegistry) string { return reg.host() + "/example/server:1.0.0" },
+			opts:      []Option{WithMaxSize(16)},
+			wantErrIn: "exceeding the 16 bytes limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reg := newFakeRegistry(t)
+			if tt.setup != nil {
+				tt.setup(reg)
+			}
+			fetcher := NewFetcher(append([]Option{WithPlainHTTP()}, tt.opts...)...)
+
+			summary, err := fetcher.Fetch(context.Background(), tt.image(reg))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIn != "" {
+				assert.ErrorContains(t, err, tt.wantErrIn)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.image(reg), summary.Image)
+			assert.Equal(t, testImageDigest, summary.Digest)
+			assert.Equal(t, FormatSPDX, summary.Format)
+			assert.Equal(t, tt.wantCount, summary.PackageCount)
+		})
+	}
+}
+
+func TestFetcherCache(t *testing.T) {
+	t.Parallel()
+	reg := newFakeRegistry(t)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fetcher := NewFetcher(WithPlainHTTP(), WithCacheTTL(time.Minute))
+	fetcher.now = func() time.Time { return now }
+	image := reg.host() + "/example/server:1.0.0"
+
+	first, err := fetcher.Fetch(context.Background(), image)
+	require.NoError(t, err)
+	assert.Equal(t, now, first.FetchedAt)
+	assert.Zero(t, first.AgeSeconds)
+	require.Equal(t, int32(1), reg.referrerRequests.Load())
+
+	// Within the freshness window, the summary of the digest is served from the cache with its age,
+	// including to references pinning the digest
+	now = now.Add(30 * time.Second)
+	second, err := fetcher.Fetch(context.Background(), image)
+	require.NoError(t, err)
+	assert.Equal(t, first.FetchedAt, second.FetchedAt)
+	assert.Equal(t, int64(30), second.AgeSeconds)
+	pinned, err := fetcher.Fetch(context.Background(), reg.host()+"/example/server@"+testImageDigest)
+	require.NoError(t, err)
+	assert.Equal(t, reg.host()+"/example/server@"+testImageDigest, pinned.Image)
+	assert.Equal(t, first.PackageCount, pinned.PackageCount)
+	assert.Equal(t, int32(1), reg.referrerRequests.Load())
+
+	now = now.Add(2 * time.Minute)
+	third, err := fetcher.Fetch(context.Background(), image)
+	require.NoError(t, err)
+	assert.Equal(t, now, third.FetchedAt)
+	assert.Equal(t, int32(2), reg.referrerRequests.Load())
+}
+
+func TestParseReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		image string
+		want  reference
+	}{
+		{
+			image: "ghcr.io/example/server:1.0.0",
+			want:  reference{registry: "ghcr.io", repository: "example/server", tag: "1.0.0"},
+		},
+		{
+			image: "localhost:5000/server@sha256:abc",
+			want:  reference{registry: "localhost:5000", repository: "server", digest: "sha256:abc"},
+		},
+		{
+			image: "alpine",
+			want:  reference{registry: dockerHubRegistry, repository: "library/alpine", tag: "latest"},
+		},
+		{
+			image: "docker.io/mcp/fetch:latest",
+			want:  reference{registry: dockerHubRegistry, repository: "mcp/fetch", tag: "latest"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			t.Parallel()
+			ref, err := parseReference(tt.image)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ref)
+		})
+	}
+
+	_, err := parseReference(":1.0.0")
+	assert.Error(t, err)
+}
+
+func TestParseChallenge(t *testing.T) {
+	t.Parallel()
+
+	values := parseChallenge(`realm="https://ghcr.io/token",service="ghcr.io",scope="repository:a/b:pull,push"`)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://ghcr.io/token",
+		"service": "ghcr.io",
+		"scope":   "repository:a/b:pull,push",
+	}, values)
+}
+
+func TestCheckRealm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		registry string
+		realm    string
+		opts     []Option
+		wantErr  string
+	}{
+		{name: "registry host", registry: "ghcr.io", realm: "https://ghcr.io/token"},
+		{name: "registry host with port", registry: "registry.example.com:5000", realm: "https://registry.example.com/auth"},
+		{name: "default trusted host", registry: dockerHubRegistry, realm: "https://auth.docker.io/token"},
+		{
+			name:     "configured trusted host",
+			registry: "registry.gitlab.com",
+			realm:    "https://gitlab.com/jwt/auth",
+			opts:     []Option{WithTokenRealmHosts("gitlab.com")},
+		},
+		{name: "untrusted host", registry: "ghcr.io", realm: "https://attacker.example.com/token", wantErr: "untrusted host"},
+		{name: "internal address", registry: "ghcr.io", realm: "https://169.254.169.254/latest", wantErr: "untrusted host"},
+		{name: "plain HTTP", registry: "ghcr.io", realm: "http://ghcr.io/token", wantErr: "not an HTTPS URL"},
+		{name: "plain HTTP registries", registry: "localhost:5000", realm: "http://localhost:5001/token", opts: []Option{WithPlainHTTP()}},
+		{name: "other scheme", registry: "ghcr.io", realm: "file:///etc/passwd", wantErr: "not an HTTPS URL"},
+		{name: "relative URL", registry: "ghcr.io", realm: "/token", wantErr: "not an HTTPS URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			session := &registrySession{fetcher: NewFetcher(tt.opts...), ref: reference{registry: tt.registry}}
+			err := session.checkRealm(tt.realm)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package sbom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// platformAliases maps common alternative architecture names to their OCI names
+var platformAliases = map[string]string{
+	"x86_64":  "amd64",
+	"x86-64":  "amd64",
+	"aarch64": "arm64",
+}
+
+// Platform is an operating system and CPU architecture an image is built for
+type Platform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// ParsePlatform parses a platform given as os/architecture[/variant] (e.g. "linux/arm64").
+// Common architecture aliases such as x86_64 and aarch64 are accepted.
+func ParsePlatform(value string) (Platform, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(value)), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return Platform{}, fmt.Errorf("invalid platform %q, expected os/architecture[/variant]", value)
+	}
+	platform := Platform{OS: parts[0], Architecture: parts[1]}
+	if alias, ok := platformAliases[platform.Architecture]; ok {
+		platform.Architecture = alias
+	}
+	if len(parts) == 3 {
+		platform.Variant = parts[2]
+	}
+	return platform, nil
+}
+
+// String returns the platform as os/architecture[/variant]
+func (p Platform) String() string {
+	if p.Variant == "" {
+		return p.OS + "/" + p.Architecture
+	}
+	return p.OS + "/" + p.Architecture + "/" + p.Variant
+}
+
+// Supports reports whether an image built for the platform runs on the requested platform.
+// Variants are only compared when both platforms have one.
+func (p Platform) Supports(requested Platform) bool {
+	if p.OS != requested.OS || p.Architecture != requested.Architecture {
+		return false
+	}
+	return p.Variant == "" || requested.Variant == "" || p.Variant == requested.Variant
+}
+
+// Platforms returns the platforms an image, given as an OCI reference, is built for: the
+// platforms of the entries of its manifest list, or the platform of its configuration for
+// single-platform images. Attestation entries, whose platform is unknown, are left out.
+func (f *Fetcher) Platforms(ctx context.Context, image string) ([]Platform, error) {
+	ref, err := parseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	session := &registrySession{fetcher: f, ref: ref}
+
+	target := ref.digest
+	if target == "" {
+		target = ref.tag
+	}
+	var manifest struct {
+		Manifests []struct {
+			Platform *Platform `json:"platform"`
+		} `json:"manifests"`
+		Config descriptor `json:"config"`
+	}
+	if err := session.getJSON(ctx, "manifests/"+target, strings.Join(manifestMediaTypes, ", "), &manifest); err != nil {
+		return nil, fmt.Errorf("failed to get manifest of %s: %w", image, err)
+	}
+
+	platforms := make([]Platform, 0, len(manifest.Manifests))
+	for _, entry := range manifest.Manifests {
+		if entry.Platform == nil || entry.Platform.OS == "unknown" || entry.Platform.Architecture == "unknown" {
+			continue
+		}
+		if !slices.Contains(platforms, *entry.Platform) {
+			platforms = append(platforms, *entry.Platform)
+		}
+	}
+	if len(manifest.Manifests) > 0 || manifest.Config.Digest == "" {
+		return platforms, nil
+	}
+
+	// Single-platform image: the platform is recorded in its configuration
+	body, _, err := session.get(ctx, "blobs/"+manifest.Config.Digest, manifest.Config.MediaType, maxManifestSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configuration of %s: %w", image, err)
+	}
+	var platform Platform
+	if err := json.Unmarshal(body, &platform); err != nil {
+		return nil, fmt.Errorf("invalid configuration of %s: %w", image, err)
+	}
+	if platform.OS == "" || platform.Architecture == "" {
+		return platforms, nil
+	}
+	return append(platforms, platform), nil
+}
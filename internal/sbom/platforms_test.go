@@ -0,0 +1,131 @@
+package sbom
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIndex is a multi-platform image index with an attestation entry
+const testIndex = `{"schemaVersion":2,"manifests":[
+	{"digest":"sha256:aaaa","platform":{"os":"linux","architecture":"amd64"}},
+	{"digest":"sha256:bbbb","platform":{"os":"linux","architecture":"arm64","variant":"v8"}},
+	{"digest":"sha256:cccc","platform":{"os":"unknown","architecture":"unknown"}},
+	{"digest":"sha256:dddd","platform":{"os":"linux","architecture":"amd64"}}
+]}`
+
+func TestParsePlatform(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    Platform
+		wantErr bool
+	}{
+		{name: "os and architecture", value: "linux/arm64", want: Platform{OS: "linux", Architecture: "arm64"}},
+		{name: "with variant", value: "linux/arm/v7", want: Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+		{name: "architecture alias", value: "Linux/x86_64", want: Platform{OS: "linux", Architecture: "amd64"}},
+		{name: "arm alias", value: "darwin/aarch64", want: Platform{OS: "darwin", Architecture: "arm64"}},
+		{name: "missing architecture", value: "linux", wantErr: true},
+		{name: "empty architecture", value: "linux/", wantErr: true},
+		{name: "too many parts", value: "linux/arm/v7/extra", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			platform, err := ParsePlatform(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, platform)
+		})
+	}
+}
+
+func TestPlatformSupports(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		platform  Platform
+		requested Platform
+		want      bool
+	}{
+		{
+			name:      "same platform",
+			platform:  Platform{OS: "linux", Architecture: "amd64"},
+			requested: Platform{OS: "linux", Architecture: "amd64"},
+			want:      true,
+		},
+		{
+			name:      "other architecture",
+			platform:  Platform{OS: "linux", Architecture: "amd64"},
+			requested: Platform{OS: "linux", Architecture: "arm64"},
+		},
+		{
+			name:      "other OS",
+			platform:  Platform{OS: "linux", Architecture: "arm64"},
+			requested: Platform{OS: "darwin", Architecture: "arm64"},
+		},
+		{
+			name:      "variant not requested",
+			platform:  Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+			requested: Platform{OS: "linux", Architecture: "arm"},
+			want:      true,
+		},
+		{
+			name:      "other variant",
+			platform:  Platform{OS: "linux", Architecture: "arm", Variant: "v6"},
+			requested: Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.platform.Supports(tt.requested))
+		})
+	}
+}
+
+func TestFetcherPlatforms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		tag       string
+		want      []string
+		wantErrIn string
+	}{
+		{name: "image index", tag: "multi-arch", want: []string{"linux/amd64", "linux/arm64/v8"}},
+		{name: "single-platform image", tag: "single-arch", want: []string{"linux/arm/v7"}},
+		{name: "image without configuration", tag: "1.0.0", want: []string{}},
+		{name: "unknown tag", tag: "9.9.9", wantErrIn: "failed to get manifest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reg := newFakeRegistry(t)
+			fetcher := NewFetcher(WithPlainHTTP())
+
+			platforms, err := fetcher.Platforms(context.Background(), reg.host()+"/example/server:"+tt.tag)
+			if tt.wantErrIn != "" {
+				assert.ErrorContains(t, err, tt.wantErrIn)
+				return
+			}
+			require.NoError(t, err)
+			got := make([]string, 0, len(platforms))
+			for _, platform := range platforms {
+				got = append(got, platform.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Package sbom retrieves and summarizes software bills of materials (SBOMs) of container images.
+//
+// SBOMs are looked up with the OCI referrers API, which lists the artifacts attached to an
+// image (e.g. by `oras attach` or `cosign attach sbom`). SPDX and CycloneDX JSON documents
+// are supported. SBOMs are not generated for images that carry none.
+//
+// The Fetcher also reads the platforms (operating system and architecture) images are built
+// for from their manifest lists, reusing the same registry access.
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatSPDX is the SPDX JSON SBOM format
+	FormatSPDX = "spdx"
+	// FormatCycloneDX is the CycloneDX JSON SBOM format
+	FormatCycloneDX = "cyclonedx"
+
+	// unknownPackageType is reported for packages without a package URL
+	unknownPackageType = "unknown"
+
+	// maxNotablePackages caps the notable packages listed in a summary
+	maxNotablePackages = 20
+)
+
+// notablePackageNames are system libraries and runtimes frequently involved in supply-chain
+// advisories, which reviewers usually want to check first
+var notablePackageNames = []string{
+	"bash", "busybox", "curl", "glibc", "libc6", "libcurl", "libssl3", "libcrypto3", "log4j-core",
+	"musl", "node", "nodejs", "openssh", "openssl", "python", "python3", "sudo", "xz-utils", "zlib",
+}
+
+// Package is a package listed in an SBOM
+type Package struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	// Type is the package URL type (e.g. "npm", "deb", "golang"), or "unknown"
+	Type string `json:"type"`
+}
+
+// Summary summarizes the SBOM of a container image
+type Summary struct {
+	Image string `json:"image"`
+	// Digest is the manifest digest of the image the SBOM is attached to
+	Digest       string `json:"digest"`
+	Format       string `json:"format"`
+	PackageCount int    `json:"packageCount"`
+	// PackagesByType counts packages per package URL type
+	PackagesByType map[string]int `json:"packagesByType"`
+	// NotablePackages are system libraries and runtimes worth reviewing first
+	NotablePackages []Package `json:"notablePackages"`
+	// FetchedAt is when the SBOM was fetched, summaries being cached for a freshness window
+	FetchedAt time.Time `json:"fetchedAt,omitzero"`
+	// AgeSeconds is the time elapsed since the SBOM was fetched
+	AgeSeconds int64 `json:"ageSeconds"`
+}
+
+// Summarize parses an SPDX or CycloneDX JSON document and summarizes its packages
+func Summarize(document []byte) (*Summary, error) {
+	var probe struct {
+		SPDXVersion string `json:"spdxVersion"`
+		BOMFormat   string `json:"bomFormat"`
+	}
+	if err := json.Unmarshal(document, &probe); err != nil {
+		return nil, fmt.Errorf("invalid SBOM document: %w", err)
+	}
+
+	var (
+		format   string
+		packages []Package
+		err      error
+	)
+	switch {
+	case probe.SPDXVersion != "":
+		format = FormatSPDX
+		packages, err = spdxPackages(document)
+	case strings.EqualFold(probe.BOMFormat, "CycloneDX"):
+		format = FormatCycloneDX
+		packages, err = cycloneDXPackages(document)
+	default:
+		return nil, errors.New("unsupported SBOM format: expected SPDX or CycloneDX JSON")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{
+		Format:          format,
+		PackageCount:    len(packages),
+		PackagesByType:  make(map[string]int),
+		NotablePackages: []Package{},
+	}
+	for _, pkg := range packages {
+		summary.PackagesByType[pkg.Type]++
+		if slices.Contains(notablePackageNames, strings.ToLower(pkg.Name)) {
+			summary.NotablePackages = append(summary.NotablePackages, pkg)
+		}
+	}
+	sort.SliceStable(summary.NotablePackages, func(i, j int) bool {
+		return summary.NotablePackages[i].Name < summary.NotablePackages[j].Name
+	})
+	if len(summary.NotablePackages) > maxNotablePackages {
+		summary.NotablePackages = summary.NotablePackages[:maxNotablePackages]
+	}
+	return summary, nil
+}
+
+// spdxPackages extracts the packages of an SPDX JSON document
+func spdxPackages(document []byte) ([]Package, error) {
+	var doc struct {
+		Packages []struct {
+			Name         string `json:"name"`
+			VersionInfo  string `json:"versionInfo"`
+			ExternalRefs []struct {
+				ReferenceType    string `json:"referenceType"`
+				ReferenceLocator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("invalid SPDX document: %w", err)
+	}
+
+	packages := make([]Package, 0, len(doc.Packages))
+	for _, p := range doc.Packages {
+		pkg := Package{Name: p.Name, Version: p.VersionInfo, Type: unknownPackageType}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				pkg.Type = purlType(ref.ReferenceLocator)
+				break
+			}
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// cycloneDXPackages extracts the components of a CycloneDX JSON document
+func cycloneDXPackages(document []byte) ([]Package, error) {
+	var doc struct {
+		Components []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			PURL    string `json:"purl"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("invalid CycloneDX document: %w", err)
+	}
+
+	packages := make([]Package, 0, len(doc.Components))
+	for _, c := range doc.Components {
+		packages = append(packages, Package{Name: c.Name, Version: c.Version, Type: purlType(c.PURL)})
+	}
+	return packages, nil
+}
+
+// purlType returns the type of a package URL (e.g. "npm" for "pkg:npm/express@4.0.0")
+func purlType(purl string) string {
+	rest, ok := strings.CutPrefix(purl, "pkg:")
+	if !ok {
+		return unknownPackageType
+	}
+	purlType, _, ok := strings.Cut(rest, "/")
+	if !ok || purlType == "" {
+		return unknownPackageType
+	}
+	return strings.ToLower(purlType)
+}
@@ -0,0 +1,100 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSPDX = `{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [
+    {"name": "openssl", "versionInfo": "3.1.4-r5",
+     "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:apk/alpine/openssl@3.1.4-r5"}]},
+    {"name": "express", "versionInfo": "4.18.2",
+     "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:npm/express@4.18.2"}]},
+    {"name": "busybox", "versionInfo": "1.36.1-r15",
+     "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:apk/alpine/busybox@1.36.1-r15"}]},
+    {"name": "app"}
+  ]
+}`
+
+const testCycloneDX = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [
+    {"name": "zlib", "version": "1.3", "purl": "pkg:deb/debian/zlib@1.3"},
+    {"name": "github.com/go-chi/chi/v5", "version": "v5.0.12", "purl": "pkg:golang/github.com/go-chi/chi/v5@v5.0.12"}
+  ]
+}`
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		document       string
+		wantFormat     string
+		wantCount      int
+		wantByType     map[string]int
+		wantNotable    []Package
+		wantErrMessage string
+	}{
+		{
+			name:       "SPDX document",
+			document:   testSPDX,
+			wantFormat: FormatSPDX,
+			wantCount:  4,
+			wantByType: map[string]int{"apk": 2, "npm": 1, "unknown": 1},
+			wantNotable: []Package{
+				{Name: "busybox", Version: "1.36.1-r15", Type: "apk"},
+				{Name: "openssl", Version: "3.1.4-r5", Type: "apk"},
+			},
+		},
+		{
+			name:        "CycloneDX document",
+			document:    testCycloneDX,
+			wantFormat:  FormatCycloneDX,
+			wantCount:   2,
+			wantByType:  map[string]int{"deb": 1, "golang": 1},
+			wantNotable: []Package{{Name: "zlib", Version: "1.3", Type: "deb"}},
+		},
+		{
+			name:           "unsupported format",
+			document:       `{"name": "not an sbom"}`,
+			wantErrMessage: "unsupported SBOM format",
+		},
+		{
+			name:           "invalid JSON",
+			document:       `{`,
+			wantErrMessage: "invalid SBOM document",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			summary, err := Summarize([]byte(tt.document))
+			if tt.wantErrMessage != "" {
+				assert.ErrorContains(t, err, tt.wantErrMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFormat, summary.Format)
+			assert.Equal(t, tt.wantCount, summary.PackageCount)
+			assert.Equal(t, tt.wantByType, summary.PackagesByType)
+			assert.Equal(t, tt.wantNotable, summary.NotablePackages)
+		})
+	}
+}
+
+func TestPurlType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "npm", purlType("pkg:npm/%40scope/name@1.0.0"))
+	assert.Equal(t, "pypi", purlType("pkg:PyPI/requests@2.31.0"))
+	assert.Equal(t, "unknown", purlType("npm/express"))
+	assert.Equal(t, "unknown", purlType("pkg:"))
+	assert.Equal(t, "unknown", purlType(""))
+}
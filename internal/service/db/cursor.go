@@ -0,0 +1,120 @@
+package database
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+)
+
+// listPosition is the position of a server in the order of the ListServers query: the sort keys the
+// query returns along with the server
+type listPosition struct {
+	TypePosition int32     `json:"type"`
+	SortTime     time.Time `json:"time"`
+	Name         string    `json:"name"`
+	CreatedAt    time.Time `json:"created"`
+	Version      string    `json:"version"`
+	ID           uuid.UUID `json:"id"`
+}
+
+// positionOf returns the position of a server returned by the ListServers query
+func positionOf(row sqlc.ListServersRow) listPosition {
+	return listPosition{
+		TypePosition: row.SortTypePosition,
+		SortTime:     row.SortTime,
+		Name:         row.Name,
+		CreatedAt:    row.SortCreatedAt,
+		Version:      row.Version,
+		ID:           row.ID,
+	}
+}
+
+// compare compares two positions as the ListServers query orders them in ascending order:
+// names and versions byte-wise, ids as the bytes of the UUIDs
+func (p listPosition) compare(other listPosition) int {
+	if c := cmp.Compare(p.TypePosition, other.TypePosition); c != 0 {
+		return c
+	}
+	if c := p.SortTime.Compare(other.SortTime); c != 0 {
+		return c
+	}
+	if c := strings.Compare(p.Name, other.Name); c != 0 {
+		return c
+	}
+	if c := p.CreatedAt.Compare(other.CreatedAt); c != 0 {
+		return c
+	}
+	if c := strings.Compare(p.Version, other.Version); c != 0 {
+		return c
+	}
+	return bytes.Compare(p.ID[:], other.ID[:])
+}
+
+// after reports whether the position comes after another one in the listing order
+func (p listPosition) after(other listPosition, descending bool) bool {
+	if descending {
+		return p.compare(other) < 0
+	}
+	return p.compare(other) > 0
+}
+
+// listCursor is a ListServers cursor. Cursors are keysets: they hold the position of the last server
+// of the previous page, so that pages neither skip nor repeat servers when servers are added or
+// removed between requests, e.g. by a sync.
+type listCursor struct {
+	// CreatedAfter is the lower bound of the creation timestamp of the listed servers. It is set by the
+	// base64-encoded RFC3339 timestamp cursors of earlier releases, which are still accepted.
+	CreatedAfter *time.Time `json:"createdAfter,omitempty"`
+	// Last is the position of the last server of the previous page, nil for the first page
+	Last *listPosition `json:"last,omitempty"`
+}
+
+// decodeListCursor decodes a ListServers cursor, as returned through NextCursor
+func decodeListCursor(cursor string) (listCursor, error) {
+	if cursor == "" {
+		return listCursor{}, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return listCursor{}, fmt.Errorf("invalid cursor format: %w", err)
+	}
+	if createdAfter, err := time.Parse(time.RFC3339, string(decoded)); err == nil {
+		return listCursor{CreatedAfter: &createdAfter}, nil
+	}
+
+	var result listCursor
+	if err := json.Unmarshal(decoded, &result); err != nil {
+		return listCursor{}, fmt.Errorf("invalid cursor format: %w", err)
+	}
+	return result, nil
+}
+
+// encode encodes the cursor
+func (c listCursor) encode() string {
+	// Marshaling a struct of strings, timestamps and UUIDs cannot fail
+	encoded, _ := json.Marshal(c)
+	return base64.StdEncoding.EncodeToString(encoded)
+}
+
+// apply sets the cursor parameters of the ListServers query, which then returns the servers after it
+func (c listCursor) apply(params *sqlc.ListServersParams) {
+	params.Next = c.CreatedAfter
+	if c.Last == nil {
+		params.CursorName = nil
+		return
+	}
+	params.CursorTypePosition = c.Last.TypePosition
+	params.CursorTime = c.Last.SortTime
+	params.CursorName = &c.Last.Name
+	params.CursorCreatedAt = c.Last.CreatedAt
+	params.CursorVersion = c.Last.Version
+	params.CursorID = c.Last.ID
+}
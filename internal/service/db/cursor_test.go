@@ -0,0 +1,124 @@
+package database
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+)
+
+func TestDecodeListCursor(t *testing.T) {
+	t.Parallel()
+
+	createdAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	position := listPosition{
+		TypePosition: 2,
+		SortTime:     time.Unix(0, 0).UTC(),
+		Name:         "com.example/server",
+		CreatedAt:    time.Date(2025, 2, 1, 10, 30, 0, 123456000, time.UTC),
+		Version:      "1.0.0",
+		ID:           uuid.New(),
+	}
+
+	cursor, err := decodeListCursor("")
+	require.NoError(t, err)
+	assert.Equal(t, listCursor{}, cursor)
+
+	// Timestamp cursors of earlier releases bound the creation timestamp of the listed servers
+	cursor, err = decodeListCursor(base64.StdEncoding.EncodeToString([]byte(createdAfter.Format(time.RFC3339))))
+	require.NoError(t, err)
+	require.NotNil(t, cursor.CreatedAfter)
+	assert.True(t, createdAfter.Equal(*cursor.CreatedAfter))
+	assert.Nil(t, cursor.Last)
+
+	encoded := listCursor{CreatedAfter: &createdAfter, Last: &position}.encode()
+	cursor, err = decodeListCursor(encoded)
+	require.NoError(t, err)
+	require.NotNil(t, cursor.Last)
+	assert.Equal(t, 0, cursor.Last.compare(position))
+	assert.True(t, createdAfter.Equal(*cursor.CreatedAfter))
+
+	_, err = decodeListCursor("invalid-base64")
+	assert.Error(t, err)
+	_, err = decodeListCursor(base64.StdEncoding.EncodeToString([]byte("not-a-cursor")))
+	assert.Error(t, err)
+}
+
+func TestListPositionAfter(t *testing.T) {
+	t.Parallel()
+
+	base := listPosition{
+		TypePosition: 1,
+		Name:         "com.example/server",
+		Version:      "1.0.0",
+		ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+	}
+	with := func(change func(*listPosition)) listPosition {
+		position := base
+		change(&position)
+		return position
+	}
+
+	tests := []struct {
+		name     string
+		position listPosition
+		want     bool
+	}{
+		{name: "same position", position: base},
+		{name: "later registry type", position: with(func(p *listPosition) { p.TypePosition = 2 }), want: true},
+		{name: "later sort time", position: with(func(p *listPosition) { p.SortTime = time.Unix(1, 0) }), want: true},
+		{name: "later name", position: with(func(p *listPosition) { p.Name = "com.example/tool" }), want: true},
+		{name: "names compared byte-wise", position: with(func(p *listPosition) { p.Name = "com.example/Server" })},
+		{name: "earlier version", position: with(func(p *listPosition) { p.Version = "0.9.0" })},
+		{
+			name:     "later id",
+			position: with(func(p *listPosition) { p.ID = uuid.MustParse("00000000-0000-0000-0000-000000000003") }),
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.position.after(base, false))
+			if tt.position.compare(base) != 0 {
+				assert.Equal(t, !tt.want, tt.position.after(base, true))
+			}
+		})
+	}
+}
+
+func TestListCursorApply(t *testing.T) {
+	t.Parallel()
+
+	createdAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	row := sqlc.ListServersRow{
+		ID:               uuid.New(),
+		Name:             "com.example/server",
+		Version:          "1.0.0",
+		SortTypePosition: 3,
+		SortTime:         time.Unix(0, 0),
+		SortCreatedAt:    createdAfter.Add(time.Hour),
+	}
+	position := positionOf(row)
+
+	var params sqlc.ListServersParams
+	listCursor{CreatedAfter: &createdAfter, Last: &position}.apply(&params)
+	assert.Equal(t, &createdAfter, params.Next)
+	require.NotNil(t, params.CursorName)
+	assert.Equal(t, row.Name, *params.CursorName)
+	assert.Equal(t, row.Version, params.CursorVersion)
+	assert.Equal(t, row.ID, params.CursorID)
+	assert.Equal(t, row.SortTypePosition, params.CursorTypePosition)
+	assert.Equal(t, row.SortTime, params.CursorTime)
+	assert.Equal(t, row.SortCreatedAt, params.CursorCreatedAt)
+
+	listCursor{}.apply(&params)
+	assert.Nil(t, params.Next)
+	assert.Nil(t, params.CursorName)
+}
@@ -3,10 +3,10 @@ package database
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
@@ -19,7 +19,9 @@ import (
 	toolhivetypes "github.com/stacklok/toolhive/pkg/registry/registry"
 
 	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/timings"
 	"github.com/stacklok/toolhive-registry-server/internal/validators"
 )
 
@@ -28,6 +30,9 @@ const (
 	DefaultPageSize = 50
 	// MaxPageSize is the maximum allowed items per page
 	MaxPageSize = 1000
+	// maxScannedServers is the maximum number of servers a listing goes through to fill a page
+	// with the servers kept by the filters the ListServers query cannot express
+	maxScannedServers = 10 * MaxPageSize
 )
 
 var (
@@ -119,31 +124,170 @@ func (s *dbService) ListServers(
 		"search", options.Search,
 		"request_id", middleware.GetReqID(ctx))
 
-	params := sqlc.ListServersParams{
-		Size: int64(options.Limit),
-	}
+	params := sqlc.ListServersParams{}
 	if options.RegistryName != nil {
 		params.RegistryName = options.RegistryName
 	}
 	if options.Search != "" {
 		params.Search = &options.Search
 	}
+	// Timestamp and lifecycle filters and sorting are applied by the query, before the page is cut
+	if !options.UpdatedSince.IsZero() {
+		params.UpdatedSince = &options.UpdatedSince
+	}
+	if !options.PublishedBefore.IsZero() {
+		params.PublishedBefore = &options.PublishedBefore
+	}
+	params.IncludeDeprecated = options.IncludeDeprecated
+	params.IncludeDeleted = options.IncludeDeleted
+	if options.Sort != "" {
+		params.SortKey = &options.Sort
+		params.SortDesc = options.SortDescending
+	}
+
+	cursor, err := decodeListCursor(options.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*upstreamv0.ServerJSON
+	var next string
+	if options.Deduplicate {
+		results, next, err = s.listDeduplicatedServers(ctx, params, options, cursor)
+	} else {
+		results, next, err = s.listFilteredServers(ctx, params, options, cursor)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if options.NextCursor != nil {
+		*options.NextCursor = next
+	}
 
-	if options.Cursor != "" {
-		decoded, err := base64.StdEncoding.DecodeString(options.Cursor)
+	slog.DebugContext(ctx, "ListServers completed",
+		"count", len(results),
+		"request_id", middleware.GetReqID(ctx))
+	return results, nil
+}
+
+// listFilteredServers lists a page of servers after the given cursor. Servers dropped by the filters
+// the query cannot express do not count towards the limit: further batches are fetched until the page
+// is full, the results are exhausted or maxScannedServers servers were scanned, in which case a short
+// page is returned. It also returns the cursor of the following page, empty on the last page.
+func (s *dbService) listFilteredServers(
+	ctx context.Context,
+	params sqlc.ListServersParams,
+	options *service.ListServersOptions,
+	cursor listCursor,
+) ([]*upstreamv0.ServerJSON, string, error) {
+	results := make([]*upstreamv0.ServerJSON, 0, options.Limit)
+	params.Size = int64(max(options.Limit, DefaultPageSize))
+	scanned := 0
+	for {
+		cursor.apply(&params)
+		batch, _, positions, err := s.fetchServers(ctx, params)
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor format: %w", err)
+			return nil, "", err
+		}
+		exhausted := len(batch) < int(params.Size)
+
+		// The filters keep the order of the servers, so the kept ones are found by walking the batch
+		kept := filterServers(ctx, batch, options)
+		k := 0
+		for i, server := range batch {
+			cursor.Last = &positions[i]
+			if k == len(kept) || kept[k] != server {
+				continue
+			}
+			k++
+			results = append(results, server)
+			if len(results) == options.Limit {
+				if exhausted && i == len(batch)-1 {
+					return results, "", nil
+				}
+				return results, cursor.encode(), nil
+			}
+		}
+
+		if exhausted {
+			return results, "", nil
+		}
+		scanned += len(batch)
+		if scanned >= maxScannedServers {
+			return results, cursor.encode(), nil
 		}
-		nextTime, err := time.Parse(time.RFC3339, string(decoded))
+	}
+}
+
+// listDeduplicatedServers lists a page of deduplicated servers after the given cursor. The records of a
+// server may be anywhere in the query results, so all of them are fetched and deduplicated before the
+// page is cut; listings of more than maxScannedServers servers fail with service.ErrTooManyServers.
+// Deduplicated records are positioned by their first record. It also returns the cursor of the
+// following page, empty on the last page.
+func (s *dbService) listDeduplicatedServers(
+	ctx context.Context,
+	params sqlc.ListServersParams,
+	options *service.ListServersOptions,
+	cursor listCursor,
+) ([]*upstreamv0.ServerJSON, string, error) {
+	var servers []*upstreamv0.ServerJSON
+	var sources []string
+	var positions []listPosition
+	scan := listCursor{CreatedAfter: cursor.CreatedAfter}
+	params.Size = MaxPageSize
+	for {
+		scan.apply(&params)
+		batch, batchSources, batchPositions, err := s.fetchServers(ctx, params)
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor format: %w", err)
+			return nil, "", err
 		}
-		params.Next = &nextTime
+		servers = append(servers, batch...)
+		sources = append(sources, batchSources...)
+		positions = append(positions, batchPositions...)
+		if len(batch) < MaxPageSize {
+			break
+		}
+		if len(servers) >= maxScannedServers {
+			return nil, "", fmt.Errorf("%w: deduplicated listings are limited to %d server versions, "+
+				"narrow the listing with a search or a registry name", service.ErrTooManyServers, maxScannedServers)
+		}
+		scan.Last = &positions[len(positions)-1]
 	}
 
+	deduplicated, firsts := registry.DeduplicateByRepositoryIndexed(servers, sources)
+	firstOf := make(map[*upstreamv0.ServerJSON]int, len(deduplicated))
+	for i, server := range deduplicated {
+		firstOf[server] = firsts[i]
+	}
+
+	results := make([]*upstreamv0.ServerJSON, 0, options.Limit)
+	next := cursor
+	for _, server := range filterServers(ctx, deduplicated, options) {
+		position := positions[firstOf[server]]
+		if cursor.Last != nil && !position.after(*cursor.Last, params.SortDesc) {
+			continue
+		}
+		if len(results) == options.Limit {
+			return results, next.encode(), nil
+		}
+		results = append(results, server)
+		next.Last = &position
+	}
+	return results, "", nil
+}
+
+// fetchServers runs the ListServers query, returning the servers along with the names of their
+// registries and their positions in the listing order
+func (s *dbService) fetchServers(
+	ctx context.Context,
+	params sqlc.ListServersParams,
+) ([]*upstreamv0.ServerJSON, []string, []listPosition, error) {
 	// Note: this function fetches a list of servers. In case no records are
 	// found, the called function should return an empty slice as it's
 	// customary in Go.
+	// sources and positions are aligned with the servers sharedListServers returns
+	var sources []string
+	var positions []listPosition
 	querierFunc := func(ctx context.Context, querier sqlc.Querier) ([]helper, error) {
 		servers, err := querier.ListServers(ctx, params)
 		if err != nil {
@@ -151,20 +295,42 @@ func (s *dbService) ListServers(
 		}
 
 		helpers := make([]helper, len(servers))
+		sources = make([]string, len(servers))
+		positions = make([]listPosition, len(servers))
 		for i, server := range servers {
 			helpers[i] = listServersRowToHelper(server)
+			sources[i] = server.RegistryName
+			positions[i] = positionOf(server)
 		}
 
 		return helpers, nil
 	}
 
-	results, err := s.sharedListServers(ctx, querierFunc)
-	if err == nil {
-		slog.DebugContext(ctx, "ListServers completed",
-			"count", len(results),
-			"request_id", middleware.GetReqID(ctx))
+	fetched := timings.Track(ctx, timings.PhaseFetch)
+	defer fetched()
+	servers, err := s.sharedListServers(ctx, querierFunc)
+	return servers, sources, positions, err
+}
+
+// filterServers applies the injection risk, health and annotation filters, which the ListServers
+// query cannot express
+func filterServers(
+	ctx context.Context,
+	servers []*upstreamv0.ServerJSON,
+	options *service.ListServersOptions,
+) []*upstreamv0.ServerJSON {
+	filtered := timings.Track(ctx, timings.PhaseFilter)
+	defer filtered()
+	if options.MaxInjectionRisk != nil {
+		servers = registry.FilterByInjectionRisk(servers, *options.MaxInjectionRisk)
+	}
+	if options.Health != "" {
+		servers = registry.FilterByHealth(servers, options.HealthLookup, options.Health)
+	}
+	if !options.AnnotationFilter.IsEmpty() {
+		servers = registry.FilterByAnnotation(servers, options.AnnotationLookup, options.AnnotationFilter)
 	}
-	return results, err
+	return servers
 }
 
 // ListServerVersions implements RegistryService.ListServerVersions
@@ -185,10 +351,14 @@ func (s *dbService) ListServerVersions(
 		return nil, fmt.Errorf("next and prev cannot be set at the same time")
 	}
 
-	// Cap the limit at MaxPageSize to prevent potential DoS
+	// Cap the limit at MaxPageSize to prevent potential DoS. Listing all versions is bounded
+	// by the versions of a single server instead.
 	if options.Limit > MaxPageSize {
 		options.Limit = MaxPageSize
 	}
+	if options.All {
+		options.Limit = math.MaxInt32
+	}
 
 	params := sqlc.ListServerVersionsParams{
 		Name: options.Name,
@@ -794,6 +964,7 @@ func (s *dbService) ListRegistries(ctx context.Context) ([]service.RegistryInfo,
 				AttemptCount: int(syncRecord.AttemptCount),
 				ServerCount:  int(syncRecord.ServerCount),
 				Message:      getStatusMessage(syncRecord.ErrorMsg),
+				ContentHash:  getContentHash(syncRecord.LastSyncHash),
 			}
 		}
 
@@ -825,6 +996,14 @@ func getStatusMessage(errorMsg *string) string {
 	return *errorMsg
 }
 
+// getContentHash converts the last sync hash pointer to string
+func getContentHash(hash *string) string {
+	if hash == nil {
+		return ""
+	}
+	return *hash
+}
+
 // GetRegistryByName returns a single registry by name
 func (s *dbService) GetRegistryByName(ctx context.Context, name string) (*service.RegistryInfo, error) {
 	// Begin a read-only transaction
@@ -882,6 +1061,7 @@ func (s *dbService) GetRegistryByName(ctx context.Context, name string) (*servic
 			AttemptCount: int(syncRecord.AttemptCount),
 			ServerCount:  int(syncRecord.ServerCount),
 			Message:      getStatusMessage(syncRecord.ErrorMsg),
+			ContentHash:  getContentHash(syncRecord.LastSyncHash),
 		}
 	}
 
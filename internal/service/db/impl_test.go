@@ -14,6 +14,7 @@ import (
 
 	"github.com/stacklok/toolhive-registry-server/database"
 	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
 )
 
@@ -364,6 +365,42 @@ func TestListServers(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "list servers sorted by publication before the page is cut",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, pool *pgxpool.Pool) {
+				setupTestData(t, pool)
+			},
+			options: []service.Option[service.ListServersOptions]{
+				service.WithSort("-published_at"),
+				service.WithLimit[service.ListServersOptions](2),
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			validateFunc: func(t *testing.T, servers []*upstreamv0.ServerJSON) {
+				require.Len(t, servers, 2)
+				// Both servers were published last, ties are broken by name in the same direction
+				require.Equal(t, "com.example/test-server-2", servers[0].Name)
+				require.Equal(t, "com.example/test-server-1", servers[1].Name)
+				require.Equal(t, "2.0.0", servers[1].Version)
+			},
+		},
+		{
+			name: "list servers updated since a timestamp before the page is cut",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, pool *pgxpool.Pool) {
+				setupTestData(t, pool)
+			},
+			options: []service.Option[service.ListServersOptions]{
+				service.WithUpdatedSince(time.Now().Add(90 * time.Minute)),
+				service.WithLimit[service.ListServersOptions](2),
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			validateFunc: func(t *testing.T, servers []*upstreamv0.ServerJSON) {
+				require.Len(t, servers, 2)
+				require.Equal(t, "2.0.0", servers[0].Version)
+				require.Equal(t, "com.example/test-server-2", servers[1].Name)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -389,6 +426,64 @@ func TestListServers(t *testing.T) {
 	}
 }
 
+// staleServerLookup reports the health of a single server as stale
+type staleServerLookup string
+
+func (l staleServerLookup) Health(name string) string {
+	if name == string(l) {
+		return registry.HealthStale
+	}
+	return registry.HealthOK
+}
+
+func TestListServersNextCursor(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	defer cleanup()
+	setupTestData(t, svc.pool)
+
+	var next string
+	servers, err := svc.ListServers(context.Background(),
+		service.WithLimit[service.ListServersOptions](3),
+		service.WithNextCursor(&next),
+	)
+	require.NoError(t, err)
+	require.Len(t, servers, 3)
+	require.NotEmpty(t, next)
+
+	servers, err = svc.ListServers(context.Background(),
+		service.WithLimit[service.ListServersOptions](3),
+		service.WithCursor(next),
+		service.WithNextCursor(&next),
+	)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	require.Equal(t, "com.example/test-server-2", servers[0].Name)
+	require.Empty(t, next)
+}
+
+func TestListServersFillsFilteredPages(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanup := setupTestService(t)
+	defer cleanup()
+	setupTestData(t, svc.pool)
+
+	// The three versions of the first server are listed first and filtered out, the page is
+	// filled from the following servers of the batch, the last one
+	var next string
+	servers, err := svc.ListServers(context.Background(),
+		service.WithLimit[service.ListServersOptions](1),
+		service.WithHealth(registry.HealthOK, staleServerLookup("com.example/test-server-1")),
+		service.WithNextCursor(&next),
+	)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	require.Equal(t, "com.example/test-server-2", servers[0].Name)
+	require.Empty(t, next)
+}
+
 func TestListServerVersions(t *testing.T) {
 	t.Parallel()
 
@@ -440,6 +535,22 @@ func TestListServerVersions(t *testing.T) {
 				require.Len(t, servers, 2)
 			},
 		},
+		{
+			name: "list all versions ignores limit",
+			//nolint:thelper // We want to see these lines in the test output
+			setupFunc: func(t *testing.T, pool *pgxpool.Pool) {
+				setupTestData(t, pool)
+			},
+			options: []service.Option[service.ListServerVersionsOptions]{
+				service.WithName[service.ListServerVersionsOptions]("com.example/test-server-1"),
+				service.WithLimit[service.ListServerVersionsOptions](2),
+				service.WithAllVersions(),
+			},
+			//nolint:thelper // We want to see these lines in the test output
+			validateFunc: func(t *testing.T, servers []*upstreamv0.ServerJSON) {
+				require.Len(t, servers, 3)
+			},
+		},
 		{
 			name: "list versions for non-existent server",
 			//nolint:thelper // We want to see these lines in the test output
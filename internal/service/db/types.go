@@ -11,6 +11,7 @@ import (
 	model "github.com/modelcontextprotocol/registry/pkg/model"
 
 	"github.com/stacklok/toolhive-registry-server/internal/db/sqlc"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
 )
 
 // helper is just a bridge between the database and the API schema,
@@ -22,6 +23,7 @@ import (
 // layer.
 type helper struct {
 	RegistryType        sqlc.RegistryType
+	RegistryName        string
 	ID                  uuid.UUID
 	Name                string
 	Version             string
@@ -44,6 +46,7 @@ func listServersRowToHelper(
 ) helper {
 	return helper{
 		RegistryType:        dbServer.RegistryType,
+		RegistryName:        dbServer.RegistryName,
 		ID:                  dbServer.ID,
 		Name:                dbServer.Name,
 		Version:             dbServer.Version,
@@ -154,10 +157,35 @@ func helperToServer(
 	if dbServer.RepositoryType != nil {
 		server.Meta.PublisherProvided["repository_type"] = ptr.ToString(dbServer.RepositoryType)
 	}
+	if dbServer.CreatedAt != nil {
+		var updatedAt time.Time
+		if dbServer.UpdatedAt != nil {
+			updatedAt = *dbServer.UpdatedAt
+		}
+		registry.SetTimestamps(&server, *dbServer.CreatedAt, updatedAt)
+	}
+	registry.SetLifecycleStatus(&server, officialStatus(dbServer.ServerMeta))
 
 	return server
 }
 
+// officialStatus reads the lifecycle status recorded by the registry in the official metadata of a
+// stored server, the status the ListServers query filters on. It returns an empty string if there is none.
+func officialStatus(serverMeta []byte) string {
+	if len(serverMeta) == 0 {
+		return ""
+	}
+	var meta struct {
+		Official struct {
+			Status string `json:"status"`
+		} `json:"io.modelcontextprotocol.registry/official"`
+	}
+	if err := json.Unmarshal(serverMeta, &meta); err != nil {
+		return ""
+	}
+	return meta.Official.Status
+}
+
 func toPackages(
 	packages []sqlc.McpServerPackage,
 ) []model.Package {
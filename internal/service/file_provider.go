@@ -86,6 +86,20 @@ func (p *fileRegistryDataProvider) GetRegistryData(ctx context.Context) (*toolhi
 	return merged, nil
 }
 
+// GetDataVersion implements VersionedRegistryDataProvider.GetDataVersion.
+// The version is unknown when the storage manager does not implement sources.VersionedStorage.
+func (p *fileRegistryDataProvider) GetDataVersion(ctx context.Context) (string, error) {
+	versioned, ok := p.storageManager.(sources.VersionedStorage)
+	if !ok {
+		return "", nil
+	}
+	version, err := versioned.Version(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get registry data version: %w", err)
+	}
+	return version, nil
+}
+
 // GetSource implements RegistryDataProvider.GetSource.
 // It returns a descriptive string indicating all configured registries.
 func (p *fileRegistryDataProvider) GetSource() string {
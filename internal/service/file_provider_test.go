@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	toolhivetypes "github.com/stacklok/toolhive/pkg/registry/registry"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/stacklok/toolhive-registry-server/internal/config"
 	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/sources"
 	sourcesmocks "github.com/stacklok/toolhive-registry-server/internal/sources/mocks"
 )
 
@@ -259,3 +261,32 @@ func TestFileRegistryDataProvider_GetSource(t *testing.T) {
 		})
 	}
 }
+
+func TestFileRegistryDataProvider_GetDataVersion(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{RegistryName: "test-registry"}
+
+	// Storage managers that cannot tell their version leave it unknown
+	provider := NewFileRegistryDataProvider(sourcesmocks.NewMockStorageManager(ctrl), cfg)
+	version, err := provider.(VersionedRegistryDataProvider).GetDataVersion(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, version)
+
+	storage := struct {
+		sources.StorageManager
+		*sourcesmocks.MockVersionedStorage
+	}{sourcesmocks.NewMockStorageManager(ctrl), sourcesmocks.NewMockVersionedStorage(ctrl)}
+	storage.MockVersionedStorage.EXPECT().Version(gomock.Any()).Return("v1", nil)
+	storage.MockVersionedStorage.EXPECT().Version(gomock.Any()).Return("", errors.New("permission denied"))
+
+	provider = NewFileRegistryDataProvider(storage, cfg)
+	version, err = provider.(VersionedRegistryDataProvider).GetDataVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "v1", version)
+	_, err = provider.(VersionedRegistryDataProvider).GetDataVersion(context.Background())
+	require.ErrorContains(t, err, "permission denied")
+}
@@ -6,9 +6,12 @@ import (
 	"encoding/base64"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -17,11 +20,12 @@ import (
 	"github.com/stacklok/toolhive-registry-server/internal/config"
 	"github.com/stacklok/toolhive-registry-server/internal/registry"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/timings"
 )
 
 // regSvc implements the RegistryService interface
 type regSvc struct {
-	mu               sync.RWMutex // Protects registryData, lastFetch
+	mu               sync.RWMutex // Protects registryData, lastFetch, facts
 	registryProvider service.RegistryDataProvider
 	config           *config.Config // Config for registry validation
 
@@ -29,13 +33,35 @@ type regSvc struct {
 	// Each entry corresponds to one registry from config.Registries
 	registryData map[string]*toolhivetypes.UpstreamRegistry
 
+	// Typed metadata of the servers in registryData, extracted once when the data changes
+	// so that listing never reads the metadata maps
+	facts *registry.FactsIndex
+
 	// Map of registry name -> last fetch time for per-registry caching
 	lastFetch     map[string]time.Time
 	cacheDuration time.Duration
+
+	// dataVersion is the version of the loaded provider data, if the provider tells it
+	// (see service.VersionedRegistryDataProvider)
+	dataVersion string
+
+	// staleWhileRevalidate serves expired data while it is refreshed in the background
+	staleWhileRevalidate bool
+	// refreshing is set while a background refresh is running
+	refreshing atomic.Bool
 }
 
 var _ service.RegistryService = (*regSvc)(nil)
 
+// Refresher is implemented by the services of this package, to keep their registry data fresh
+// in the background
+type Refresher interface {
+	// RunRefresh refreshes the registry data at every interval until ctx is cancelled
+	RunRefresh(ctx context.Context, interval time.Duration)
+}
+
+var _ Refresher = (*regSvc)(nil)
+
 // Option is a functional option for configuring the regSvc
 type Option func(*regSvc)
 
@@ -46,6 +72,14 @@ func WithCacheDuration(duration time.Duration) Option {
 	}
 }
 
+// WithStaleWhileRevalidate serves expired registry data while it is refreshed in the background,
+// instead of making requests wait for the refresh. Requests still wait when no data was loaded yet.
+func WithStaleWhileRevalidate() Option {
+	return func(s *regSvc) {
+		s.staleWhileRevalidate = true
+	}
+}
+
 // WithConfig sets the config for registry validation
 func WithConfig(cfg *config.Config) Option {
 	return func(s *regSvc) {
@@ -90,15 +124,63 @@ func New(
 // loadRegistryDataLocked loads registry data using the configured provider.
 // Caller must hold s.mu write lock.
 func (s *regSvc) loadRegistryDataLocked(ctx context.Context) error {
+	data, version, err := s.fetchRegistryData(ctx, s.dataVersion)
+	if err != nil {
+		return err
+	}
+	s.applyRegistryDataLocked(ctx, data, version)
+	return nil
+}
+
+// fetchRegistryData gets the merged data of the provider (legacy behavior), along with its version.
+// The data is nil when the provider reports knownVersion, i.e. the data did not change since it was loaded.
+func (s *regSvc) fetchRegistryData(
+	ctx context.Context,
+	knownVersion string,
+) (*toolhivetypes.UpstreamRegistry, string, error) {
 	if s.registryProvider == nil {
-		return fmt.Errorf("registry data provider not initialized")
+		return nil, "", fmt.Errorf("registry data provider not initialized")
+	}
+
+	var version string
+	if versioned, ok := s.registryProvider.(service.VersionedRegistryDataProvider); ok {
+		var err error
+		version, err = versioned.GetDataVersion(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to get registry data version, reading the data", "error", err)
+			version = ""
+		}
+		if version != "" && version == knownVersion {
+			return nil, version, nil
+		}
 	}
 
-	// Get the merged data from the provider (legacy behavior)
 	data, err := s.registryProvider.GetRegistryData(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get registry data: %w", err)
+		return nil, "", fmt.Errorf("failed to get registry data: %w", err)
+	}
+	return data, version, nil
+}
+
+// applyRegistryDataLocked replaces the data of the non-managed registries with the fetched data.
+// Nil data is unchanged, and only renews the fetch time of the registries.
+// Caller must hold s.mu write lock.
+func (s *regSvc) applyRegistryDataLocked(ctx context.Context, data *toolhivetypes.UpstreamRegistry, version string) {
+	if data == nil {
+		now := time.Now()
+		if s.config == nil {
+			s.lastFetch[s.registryProvider.GetRegistryName()] = now
+		} else {
+			for _, regCfg := range s.config.Registries {
+				if regCfg.GetType() != config.SourceTypeManaged {
+					s.lastFetch[regCfg.Name] = now
+				}
+			}
+		}
+		slog.DebugContext(ctx, "Registry data unchanged", "version", version)
+		return
 	}
+	s.dataVersion = version
 
 	// For each registry in config, initialize its entry
 	if s.config != nil {
@@ -140,7 +222,20 @@ func (s *regSvc) loadRegistryDataLocked(ctx context.Context) error {
 	}
 	slog.InfoContext(ctx, "Loaded registry data", "server_count", serverCount, "registry_count", len(s.registryData))
 
-	return nil
+	s.rebuildFactsLocked()
+}
+
+// rebuildFactsLocked extracts the facts of all servers in the registry data.
+// It must be called whenever the server slices are modified or replaced.
+// Caller must hold s.mu write lock.
+func (s *regSvc) rebuildFactsLocked() {
+	groups := make([][]upstreamv0.ServerJSON, 0, len(s.registryData))
+	for _, regData := range s.registryData {
+		if regData != nil {
+			groups = append(groups, regData.Data.Servers)
+		}
+	}
+	s.facts = registry.NewFactsIndex(groups...)
 }
 
 // loadRegistryData loads registry data using the configured provider
@@ -162,6 +257,11 @@ func (s *regSvc) refreshDataIfNeeded(ctx context.Context) error {
 	hasData := len(s.registryData) > 0
 	s.mu.RUnlock()
 
+	if needsRefresh && hasData && s.staleWhileRevalidate {
+		s.refreshInBackground(ctx)
+		return nil
+	}
+
 	if needsRefresh {
 		s.mu.Lock()
 		defer s.mu.Unlock()
@@ -179,6 +279,59 @@ func (s *regSvc) refreshDataIfNeeded(ctx context.Context) error {
 	return nil
 }
 
+// refreshInBackground starts refreshing the registry data, unless a refresh is already running
+func (s *regSvc) refreshInBackground(ctx context.Context) {
+	if !s.refreshing.CompareAndSwap(false, true) {
+		return
+	}
+	// The refresh outlives the request that started it
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer s.refreshing.Store(false)
+		if err := s.refresh(ctx); err != nil {
+			slog.WarnContext(ctx, "Failed to refresh registry data, serving stale data", "error", err)
+		}
+	}()
+}
+
+// refresh fetches the registry data without holding the lock, so that requests keep being served
+// the current data in the meantime, then swaps it in
+func (s *regSvc) refresh(ctx context.Context) error {
+	s.mu.RLock()
+	knownVersion := s.dataVersion
+	s.mu.RUnlock()
+
+	data, version, err := s.fetchRegistryData(ctx, knownVersion)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.applyRegistryDataLocked(ctx, data, version)
+	return nil
+}
+
+// RunRefresh implements Refresher.RunRefresh
+func (s *regSvc) RunRefresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.refreshing.CompareAndSwap(false, true) {
+				continue
+			}
+			if err := s.refresh(ctx); err != nil {
+				slog.WarnContext(ctx, "Failed to refresh registry data", "error", err)
+			}
+			s.refreshing.Store(false)
+		}
+	}
+}
+
 // needsRefresh checks if any registry data needs to be refreshed.
 // Caller must hold at least s.mu read lock.
 func (s *regSvc) needsRefresh() bool {
@@ -304,6 +457,7 @@ func (s *regSvc) ListServers(
 	ctx context.Context,
 	opts ...service.Option[service.ListServersOptions],
 ) ([]*upstreamv0.ServerJSON, error) {
+	fetched := timings.Track(ctx, timings.PhaseFetch)
 	if err := s.refreshDataIfNeeded(ctx); err != nil {
 		slog.Warn("Failed to refresh data", "error", err)
 	}
@@ -318,15 +472,20 @@ func (s *regSvc) ListServers(
 
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	fetched()
 
-	return s.listServersLocked(options)
+	return s.listServersLocked(ctx, options)
 }
 
 // listServersLocked performs the actual server listing logic.
 // Caller must hold s.mu read lock.
-func (s *regSvc) listServersLocked(options *service.ListServersOptions) ([]*upstreamv0.ServerJSON, error) {
-	// Collect servers from relevant registries
-	var allServers []upstreamv0.ServerJSON
+func (s *regSvc) listServersLocked(
+	ctx context.Context,
+	options *service.ListServersOptions,
+) ([]*upstreamv0.ServerJSON, error) {
+	// Collect servers from relevant registries, in place so that their indexed facts apply
+	var groups [][]upstreamv0.ServerJSON
+	var groupNames []string
 
 	if options.RegistryName != nil && *options.RegistryName != "" {
 		// Filter by specific registry
@@ -335,19 +494,52 @@ func (s *regSvc) listServersLocked(options *service.ListServersOptions) ([]*upst
 			return []*upstreamv0.ServerJSON{}, nil
 		}
 		if regData != nil {
-			allServers = regData.Data.Servers
+			groups = append(groups, regData.Data.Servers)
+			groupNames = append(groupNames, *options.RegistryName)
 		}
 	} else {
-		// Merge all registries
-		for _, regData := range s.registryData {
-			if regData != nil {
-				allServers = append(allServers, regData.Data.Servers...)
+		// Merge all registries, in a stable order so that deduplication keeps the same records
+		for _, name := range slices.Sorted(maps.Keys(s.registryData)) {
+			if regData := s.registryData[name]; regData != nil {
+				groups = append(groups, regData.Data.Servers)
+				groupNames = append(groupNames, name)
 			}
 		}
 	}
 
 	// Collect and filter servers
-	servers := s.collectAndFilterServers(allServers, options.Search)
+	filtered := timings.Track(ctx, timings.PhaseFilter)
+	servers, sources := s.collectAndFilterServers(groups, groupNames, options.Search)
+	if options.Deduplicate {
+		servers = registry.DeduplicateByRepository(servers, sources)
+	}
+	servers = s.facts.FilterByLifecycleStatus(servers, options.IncludeDeprecated, options.IncludeDeleted)
+	if options.MaxInjectionRisk != nil {
+		servers = registry.FilterByInjectionRisk(servers, *options.MaxInjectionRisk)
+	}
+	if options.Health != "" {
+		servers = registry.FilterByHealth(servers, options.HealthLookup, options.Health)
+	}
+	if !options.AnnotationFilter.IsEmpty() {
+		servers = registry.FilterByAnnotation(servers, options.AnnotationLookup, options.AnnotationFilter)
+	}
+	servers = s.facts.FilterByTimestamps(servers, options.UpdatedSince, options.PublishedBefore)
+	filtered()
+	if options.Sort != "" {
+		sorted := timings.Track(ctx, timings.PhaseSort)
+		if options.Limit > 0 {
+			// Only the servers up to the end of the requested page need to be ordered, plus one
+			// telling whether another page follows
+			startIndex, err := decodeCursor(options.Cursor)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cursor format: %w", err)
+			}
+			servers = s.facts.TopServers(servers, options.Sort, options.SortDescending, startIndex+options.Limit+1)
+		} else {
+			s.facts.SortServers(servers, options.Sort, options.SortDescending)
+		}
+		sorted()
+	}
 
 	// Apply cursor pagination
 	servers, err := s.applyCursorPagination(servers, options.Cursor)
@@ -356,28 +548,44 @@ func (s *regSvc) listServersLocked(options *service.ListServersOptions) ([]*upst
 	}
 
 	// Apply limit if provided
+	next := ""
 	if options.Limit > 0 && len(servers) > options.Limit {
 		servers = servers[:options.Limit]
+		// The cursor was validated by applyCursorPagination
+		startIndex, _ := decodeCursor(options.Cursor)
+		next = EncodeCursor(startIndex + options.Limit)
+	}
+	if options.NextCursor != nil {
+		*options.NextCursor = next
 	}
 
 	return servers, nil
 }
 
 // collectAndFilterServers collects servers and optionally filters by search term.
-func (s *regSvc) collectAndFilterServers(allServers []upstreamv0.ServerJSON, search string) []*upstreamv0.ServerJSON {
+// It also returns the names of the registries the servers come from, aligned with the servers.
+func (s *regSvc) collectAndFilterServers(
+	groups [][]upstreamv0.ServerJSON,
+	names []string,
+	search string,
+) ([]*upstreamv0.ServerJSON, []string) {
 	var servers []*upstreamv0.ServerJSON
-	for i := range allServers {
-		server := &allServers[i]
-		if search != "" && !s.serverMatchesSearch(server, search) {
-			continue
+	var sources []string
+	for g, allServers := range groups {
+		for i := range allServers {
+			server := &allServers[i]
+			if search != "" && !s.serverMatchesSearch(server, search) {
+				continue
+			}
+			servers = append(servers, server)
+			sources = append(sources, names[g])
 		}
-		servers = append(servers, server)
 	}
 
 	if servers == nil {
 		servers = []*upstreamv0.ServerJSON{}
 	}
-	return servers
+	return servers, sources
 }
 
 // applyCursorPagination applies cursor-based pagination to the server list.
@@ -435,7 +643,7 @@ func (s *regSvc) listServerVersionsLocked(
 	servers := s.filterServersByName(allServers, options.Name)
 
 	// Apply limit if provided
-	if options.Limit > 0 && len(servers) > options.Limit {
+	if !options.All && options.Limit > 0 && len(servers) > options.Limit {
 		servers = servers[:options.Limit]
 	}
 
@@ -588,6 +796,7 @@ func (s *regSvc) PublishServerVersion(
 
 	// Append the new server
 	regData.Data.Servers = append(regData.Data.Servers, *serverData)
+	s.rebuildFactsLocked()
 
 	slog.InfoContext(ctx, "Server version published to in-memory registry",
 		"registry", options.RegistryName,
@@ -644,6 +853,7 @@ func (s *regSvc) DeleteServerVersion(
 	}
 
 	regData.Data.Servers = filtered
+	s.rebuildFactsLocked()
 
 	slog.InfoContext(ctx, "Server version deleted from in-memory registry",
 		"registry", options.RegistryName,
@@ -0,0 +1,303 @@
+// Package mirror provides a RegistryService decorator that turns the registry
+// into a read-through mirror of an upstream MCP Registry API.
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/pkg/client"
+)
+
+// mirrorSvc wraps a RegistryService and falls back to an upstream registry
+// for server lookups that miss the local data
+type mirrorSvc struct {
+	service.RegistryService
+	upstream      client.Registry
+	sanitizeRules []string
+}
+
+var _ service.RegistryService = (*mirrorSvc)(nil)
+
+// Option configures the mirror service
+type Option func(*mirrorSvc)
+
+// WithSanitizeRules sets the sanitization rules applied to the titles and descriptions of the
+// servers read through, see registry.SanitizeServer. Without rules, servers are not sanitized.
+func WithSanitizeRules(rules []string) Option {
+	return func(m *mirrorSvc) {
+		m.sanitizeRules = rules
+	}
+}
+
+// New creates a RegistryService that serves from svc and reads through to upstream
+// when a server or server version is not found locally. Server listings go on with the
+// upstream servers once the local servers are exhausted, see ListServers.
+// Only aggregated lookups read through; lookups scoped to a registry name never do,
+// so per-registry endpoints keep serving exactly the configured data.
+// Upstream responses should be cached by the caller, e.g. with client.NewCachingRegistry.
+func New(svc service.RegistryService, upstream client.Registry, opts ...Option) (service.RegistryService, error) {
+	if svc == nil {
+		return nil, fmt.Errorf("registry service is required")
+	}
+	if upstream == nil {
+		return nil, fmt.Errorf("upstream registry is required")
+	}
+
+	m := &mirrorSvc{
+		RegistryService: svc,
+		upstream:        upstream,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
+}
+
+// upstreamCursorPrefix prefixes the cursors of the listing pages served from the upstream, followed by
+// the upstream cursor. The prefix is outside the base64 alphabet of local cursors.
+const upstreamCursorPrefix = "upstream."
+
+// ListServers implements RegistryService.ListServers.
+// Local servers are listed first, then the upstream servers, so that the order requested with
+// Sort holds within each part only. Upstream servers with a local version are left out, the local
+// data taking precedence, and upstream servers are filtered as local ones are.
+func (m *mirrorSvc) ListServers(
+	ctx context.Context,
+	opts ...service.Option[service.ListServersOptions],
+) ([]*upstreamv0.ServerJSON, error) {
+	options := &service.ListServersOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+	if options.RegistryName != nil {
+		return m.RegistryService.ListServers(ctx, opts...)
+	}
+
+	upstreamCursor, fromUpstream := strings.CutPrefix(options.Cursor, upstreamCursorPrefix)
+	var servers []*upstreamv0.ServerJSON
+	if !fromUpstream {
+		var localNext string
+		localOpts := append(slices.Clone(opts), func(o *service.ListServersOptions) error {
+			o.NextCursor = &localNext
+			return nil
+		})
+		local, err := m.RegistryService.ListServers(ctx, localOpts...)
+		if err != nil {
+			return nil, err
+		}
+		if localNext != "" || (options.Limit > 0 && len(local) >= options.Limit) {
+			setNextCursor(options, localNext, upstreamCursorPrefix)
+			return local, nil
+		}
+		servers = local
+	}
+
+	limit := 0
+	if options.Limit > 0 {
+		limit = options.Limit - len(servers)
+	}
+	remote, upstreamNext, err := m.listUpstreamServers(ctx, options, upstreamCursor, limit)
+	if err != nil {
+		slog.Warn("Mirror upstream listing failed", "error", err)
+		setNextCursor(options, "", "")
+		return servers, nil
+	}
+
+	next := ""
+	if upstreamNext != "" {
+		next = upstreamCursorPrefix + upstreamNext
+	}
+	setNextCursor(options, next, "")
+	return append(servers, remote...), nil
+}
+
+// setNextCursor stores the cursor of the next page, or fallback when there is none, into the
+// destination of the options
+func setNextCursor(options *service.ListServersOptions, next, fallback string) {
+	if options.NextCursor == nil {
+		return
+	}
+	if next == "" {
+		next = fallback
+	}
+	*options.NextCursor = next
+}
+
+// listUpstreamServers lists a page of upstream servers, leaving out the servers found locally and
+// the servers excluded by the filters of the options. It returns the servers and the upstream cursor
+// of the next page.
+func (m *mirrorSvc) listUpstreamServers(
+	ctx context.Context,
+	options *service.ListServersOptions,
+	cursor string,
+	limit int,
+) ([]*upstreamv0.ServerJSON, string, error) {
+	resp, err := m.upstream.ListServers(ctx, upstreamListOptions(options, cursor, limit))
+	if err != nil {
+		return nil, "", err
+	}
+
+	servers := make([]*upstreamv0.ServerJSON, 0, len(resp.Servers))
+	for i := range resp.Servers {
+		server := m.ingest(resp.Servers[i])
+		local, err := m.hasLocalServer(ctx, server.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		if !local {
+			servers = append(servers, server)
+		}
+	}
+
+	servers = registry.FilterByLifecycleStatus(servers, options.IncludeDeprecated, options.IncludeDeleted)
+	if options.MaxInjectionRisk != nil {
+		servers = registry.FilterByInjectionRisk(servers, *options.MaxInjectionRisk)
+	}
+	if options.Health != "" {
+		servers = registry.FilterByHealth(servers, options.HealthLookup, options.Health)
+	}
+	if !options.AnnotationFilter.IsEmpty() {
+		servers = registry.FilterByAnnotation(servers, options.AnnotationLookup, options.AnnotationFilter)
+	}
+	servers = registry.FilterByTimestamps(servers, options.UpdatedSince, options.PublishedBefore)
+
+	return servers, resp.Metadata.NextCursor, nil
+}
+
+// upstreamListOptions returns the upstream listing options matching the options of a listing
+func upstreamListOptions(options *service.ListServersOptions, cursor string, limit int) *client.ListServersOptions {
+	upstreamOpts := &client.ListServersOptions{
+		Cursor:            cursor,
+		Limit:             limit,
+		Search:            options.Search,
+		UpdatedSince:      options.UpdatedSince,
+		PublishedBefore:   options.PublishedBefore,
+		Version:           options.Version,
+		IncludeDeprecated: options.IncludeDeprecated,
+		IncludeDeleted:    options.IncludeDeleted,
+		MaxInjectionRisk:  options.MaxInjectionRisk,
+	}
+	if options.Sort != "" {
+		upstreamOpts.Sort = options.Sort
+		if options.SortDescending {
+			upstreamOpts.Sort = "-" + options.Sort
+		}
+	}
+	return upstreamOpts
+}
+
+// hasLocalServer reports whether a version of the named server is found locally
+func (m *mirrorSvc) hasLocalServer(ctx context.Context, name string) (bool, error) {
+	versions, err := m.RegistryService.ListServerVersions(ctx,
+		service.WithName[service.ListServerVersionsOptions](name),
+		service.WithLimit[service.ListServerVersionsOptions](1),
+	)
+	if errors.Is(err, service.ErrServerNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(versions) > 0, nil
+}
+
+// ListServerVersions implements RegistryService.ListServerVersions
+func (m *mirrorSvc) ListServerVersions(
+	ctx context.Context,
+	opts ...service.Option[service.ListServerVersionsOptions],
+) ([]*upstreamv0.ServerJSON, error) {
+	servers, err := m.RegistryService.ListServerVersions(ctx, opts...)
+	if err != nil && !errors.Is(err, service.ErrServerNotFound) {
+		return nil, err
+	}
+	if len(servers) > 0 {
+		return servers, nil
+	}
+
+	options := &service.ListServerVersionsOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+	if options.RegistryName != nil || options.Name == "" {
+		return servers, err
+	}
+
+	resp, upstreamErr := m.upstream.ListServerVersions(ctx, options.Name)
+	if upstreamErr != nil {
+		return servers, m.upstreamError(options.Name, upstreamErr, err)
+	}
+
+	result := make([]*upstreamv0.ServerJSON, 0, len(resp.Servers))
+	for i := range resp.Servers {
+		result = append(result, m.ingest(resp.Servers[i]))
+	}
+	if options.Limit > 0 && len(result) > options.Limit {
+		result = result[:options.Limit]
+	}
+
+	return result, nil
+}
+
+// GetServerVersion implements RegistryService.GetServerVersion
+func (m *mirrorSvc) GetServerVersion(
+	ctx context.Context,
+	opts ...service.Option[service.GetServerVersionOptions],
+) (*upstreamv0.ServerJSON, error) {
+	server, err := m.RegistryService.GetServerVersion(ctx, opts...)
+	if !errors.Is(err, service.ErrServerNotFound) {
+		return server, err
+	}
+
+	options := &service.GetServerVersionOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+	if options.RegistryName != nil || options.Name == "" || options.Version == "" {
+		return nil, err
+	}
+
+	resp, upstreamErr := m.upstream.GetServerVersion(ctx, options.Name, options.Version)
+	if upstreamErr != nil {
+		return nil, m.upstreamError(options.Name, upstreamErr, err)
+	}
+
+	return m.ingest(*resp), nil
+}
+
+// ingest returns the server of an upstream response as served by the mirror: with the official
+// metadata of the response and sanitized like synced servers
+func (m *mirrorSvc) ingest(response upstreamv0.ServerResponse) *upstreamv0.ServerJSON {
+	server := registry.FromServerResponse(response)
+	if len(m.sanitizeRules) > 0 && registry.SanitizeServer(&server, m.sanitizeRules) {
+		slog.Debug("Sanitized mirrored server content", "server_name", server.Name, "version", server.Version)
+	}
+	return &server
+}
+
+// upstreamError maps an upstream failure to the error returned to the caller.
+// Upstream 404s are reported as the original local error; other failures are
+// logged and also reported as the local error so that an unavailable upstream
+// never turns a not-found into a server error.
+func (*mirrorSvc) upstreamError(serverName string, upstreamErr, localErr error) error {
+	if !client.IsNotFound(upstreamErr) {
+		slog.Warn("Mirror upstream lookup failed",
+			"server_name", serverName,
+			"error", upstreamErr)
+	}
+	return localErr
+}
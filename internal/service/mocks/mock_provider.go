@@ -83,3 +83,85 @@ func (mr *MockRegistryDataProviderMockRecorder) GetSource() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSource", reflect.TypeOf((*MockRegistryDataProvider)(nil).GetSource))
 }
+
+// MockVersionedRegistryDataProvider is a mock of VersionedRegistryDataProvider interface.
+type MockVersionedRegistryDataProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockVersionedRegistryDataProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockVersionedRegistryDataProviderMockRecorder is the mock recorder for MockVersionedRegistryDataProvider.
+type MockVersionedRegistryDataProviderMockRecorder struct {
+	mock *MockVersionedRegistryDataProvider
+}
+
+// NewMockVersionedRegistryDataProvider creates a new mock instance.
+func NewMockVersionedRegistryDataProvider(ctrl *gomock.Controller) *MockVersionedRegistryDataProvider {
+	mock := &MockVersionedRegistryDataProvider{ctrl: ctrl}
+	mock.recorder = &MockVersionedRegistryDataProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVersionedRegistryDataProvider) EXPECT() *MockVersionedRegistryDataProviderMockRecorder {
+	return m.recorder
+}
+
+// GetDataVersion mocks base method.
+func (m *MockVersionedRegistryDataProvider) GetDataVersion(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataVersion", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataVersion indicates an expected call of GetDataVersion.
+func (mr *MockVersionedRegistryDataProviderMockRecorder) GetDataVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataVersion", reflect.TypeOf((*MockVersionedRegistryDataProvider)(nil).GetDataVersion), ctx)
+}
+
+// GetRegistryData mocks base method.
+func (m *MockVersionedRegistryDataProvider) GetRegistryData(ctx context.Context) (*registry.UpstreamRegistry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegistryData", ctx)
+	ret0, _ := ret[0].(*registry.UpstreamRegistry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegistryData indicates an expected call of GetRegistryData.
+func (mr *MockVersionedRegistryDataProviderMockRecorder) GetRegistryData(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegistryData", reflect.TypeOf((*MockVersionedRegistryDataProvider)(nil).GetRegistryData), ctx)
+}
+
+// GetRegistryName mocks base method.
+func (m *MockVersionedRegistryDataProvider) GetRegistryName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegistryName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetRegistryName indicates an expected call of GetRegistryName.
+func (mr *MockVersionedRegistryDataProviderMockRecorder) GetRegistryName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegistryName", reflect.TypeOf((*MockVersionedRegistryDataProvider)(nil).GetRegistryName))
+}
+
+// GetSource mocks base method.
+func (m *MockVersionedRegistryDataProvider) GetSource() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSource")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSource indicates an expected call of GetSource.
+func (mr *MockVersionedRegistryDataProviderMockRecorder) GetSource() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSource", reflect.TypeOf((*MockVersionedRegistryDataProvider)(nil).GetSource))
+}
@@ -25,3 +25,13 @@ type RegistryDataProvider interface {
 	// This name is used for business logic such as finding related Kubernetes resources.
 	GetRegistryName() string
 }
+
+// VersionedRegistryDataProvider is implemented by the providers that can tell whether their
+// registry data changed without reading it, so that unchanged data is not read again
+type VersionedRegistryDataProvider interface {
+	RegistryDataProvider
+
+	// GetDataVersion returns an opaque version of the registry data, which changes whenever
+	// the data does. An empty version is unknown and never matches another.
+	GetDataVersion(ctx context.Context) (string, error)
+}
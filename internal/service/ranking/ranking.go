@@ -0,0 +1,215 @@
+// Package ranking provides a RegistryService decorator that reorders search
+// results according to operator-configured boosts, possibly varying with the
+// search ranking experiment variant of the client, and to client feedback.
+package ranking
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/experiment"
+	"github.com/stacklok/toolhive-registry-server/internal/feedback"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// rankingSvc wraps a RegistryService and ranks search results by boost score
+type rankingSvc struct {
+	service.RegistryService
+	boosts []config.SearchBoostConfig
+	// variants are the boosts of the experiment variants replacing boosts, keyed by variant name
+	variants map[string][]config.SearchBoostConfig
+	// feedback adjusts the scores of servers clients accepted or rejected, if set
+	feedback *feedback.Store
+}
+
+var _ service.RegistryService = (*rankingSvc)(nil)
+
+// Option configures the ranking service
+type Option func(*rankingSvc)
+
+// WithExperiment ranks the searches of the clients assigned to a variant of the experiment (see
+// experiment.VariantFromContext) with the boosts of the variant. Variants without boosts rank with
+// the default boosts.
+func WithExperiment(cfg *config.SearchExperimentConfig) Option {
+	return func(r *rankingSvc) {
+		for _, variant := range cfg.Variants {
+			if len(variant.Boosts) > 0 {
+				r.variants[variant.Name] = variant.Boosts
+			}
+		}
+	}
+}
+
+// WithFeedback adds the feedback adjustment of each server (see feedback.Store.Adjustment) to its score
+func WithFeedback(store *feedback.Store) Option {
+	return func(r *rankingSvc) {
+		r.feedback = store
+	}
+}
+
+// New creates a RegistryService that ranks the results of ListServers calls
+// with a search term. Servers are ordered by the sum of the weights of the boosts
+// they match, highest first; servers with equal scores keep their original order.
+// Ranking applies to all the matching servers, before pagination. Listing without
+// a search term is left untouched.
+func New(svc service.RegistryService, boosts []config.SearchBoostConfig, opts ...Option) (service.RegistryService, error) {
+	if svc == nil {
+		return nil, fmt.Errorf("registry service is required")
+	}
+
+	r := &rankingSvc{
+		RegistryService: svc,
+		boosts:          boosts,
+		variants:        make(map[string][]config.SearchBoostConfig),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if len(r.boosts) == 0 && len(r.variants) == 0 && r.feedback == nil {
+		return nil, fmt.Errorf("at least one boost or feedback is required")
+	}
+	return r, nil
+}
+
+// candidateLimit caps the number of servers a search ranks. Searches matching more servers rank
+// the first candidateLimit of them, in the order of the wrapped service.
+const candidateLimit = 1000
+
+// ListServers implements RegistryService.ListServers. Searches are paginated after ranking: the
+// candidates are listed without cursor and limit, ranked as a whole, then cut to the requested
+// page, and the cursors returned through NextCursor are offsets into the ranking.
+func (r *rankingSvc) ListServers(
+	ctx context.Context,
+	opts ...service.Option[service.ListServersOptions],
+) ([]*upstreamv0.ServerJSON, error) {
+	options := &service.ListServersOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+	// An explicit sort order takes precedence over ranking
+	if options.Search == "" || options.Sort != "" {
+		return r.RegistryService.ListServers(ctx, opts...)
+	}
+
+	start, err := decodeCursor(options.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor format: %w", err)
+	}
+	candidates := *options
+	candidates.Cursor = ""
+	candidates.Limit = candidateLimit
+	candidates.NextCursor = nil
+	servers, err := r.RegistryService.ListServers(ctx, func(o *service.ListServersOptions) error {
+		*o = candidates
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	boosts := r.boosts
+	if variantBoosts, ok := r.variants[experiment.VariantFromContext(ctx)]; ok {
+		boosts = variantBoosts
+	}
+	scores := make(map[*upstreamv0.ServerJSON]int, len(servers))
+	for _, server := range servers {
+		scores[server] = score(boosts, server)
+		if r.feedback != nil {
+			scores[server] += r.feedback.Adjustment(server.Name)
+		}
+	}
+	sort.SliceStable(servers, func(i, j int) bool {
+		return scores[servers[i]] > scores[servers[j]]
+	})
+
+	// Cut the requested page, which holds all the remaining ranked servers without limit
+	next := ""
+	if start >= len(servers) {
+		servers = []*upstreamv0.ServerJSON{}
+	} else {
+		servers = servers[start:]
+		if options.Limit > 0 && len(servers) > options.Limit {
+			servers = servers[:options.Limit]
+			next = encodeCursor(start + options.Limit)
+		}
+	}
+	if options.NextCursor != nil {
+		*options.NextCursor = next
+	}
+
+	// The ranked results of the first page are the ones experiment outcomes are attributed to
+	if options.Cursor == "" {
+		names := make([]string, len(servers))
+		for i, server := range servers {
+			names[i] = server.Name
+		}
+		experiment.RecordSearch(ctx, options.Search, names)
+	}
+
+	return servers, nil
+}
+
+// decodeCursor decodes a base64-encoded offset into the ranking, 0 if the cursor is empty
+func decodeCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil {
+		return 0, err
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("cursor offset cannot be negative")
+	}
+	return offset, nil
+}
+
+// encodeCursor encodes an offset into the ranking as a cursor
+func encodeCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// score returns the sum of the weights of all boosts matching the server
+func score(boosts []config.SearchBoostConfig, server *upstreamv0.ServerJSON) int {
+	score := 0
+	for _, boost := range boosts {
+		if matches(boost, server) {
+			score += boost.Weight
+		}
+	}
+	return score
+}
+
+// matches reports whether the server matches the boost criterion.
+// Tier, status, and tag comparisons are case-insensitive.
+func matches(boost config.SearchBoostConfig, server *upstreamv0.ServerJSON) bool {
+	switch {
+	case boost.Tier != "":
+		return strings.EqualFold(registry.ExtractTier(server), boost.Tier)
+	case boost.Status != "":
+		return strings.EqualFold(registry.ExtractStatus(server), boost.Status)
+	case boost.Tag != "":
+		return slices.ContainsFunc(registry.ExtractTags(server), func(tag string) bool {
+			return strings.EqualFold(tag, boost.Tag)
+		})
+	case boost.NamePrefix != "":
+		return strings.HasPrefix(server.Name, boost.NamePrefix)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,288 @@
+package ranking
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/experiment"
+	"github.com/stacklok/toolhive-registry-server/internal/feedback"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+func TestListServers(t *testing.T) {
+	t.Parallel()
+
+	boosts := []config.SearchBoostConfig{
+		{Tier: "official", Weight: 10},
+		{Status: "Deprecated", Weight: -20},
+		{Tag: "Recommended", Weight: 5},
+		{NamePrefix: "io.github.stacklok/", Weight: 1},
+	}
+
+	newServers := func() []*upstreamv0.ServerJSON {
+		community := registry.NewTestServer("io.test/community",
+			registry.WithToolHiveMetadata("tier", "Community"))
+		deprecated := registry.NewTestServer("io.test/deprecated",
+			registry.WithToolHiveMetadata("tier", "Official"),
+			registry.WithToolHiveMetadata("status", "Deprecated"))
+		official := registry.NewTestServer("io.test/official",
+			registry.WithToolHiveMetadata("tier", "Official"))
+		tagged := registry.NewTestServer("io.test/tagged", registry.WithTags("recommended"))
+		stacklok := registry.NewTestServer("io.github.stacklok/fetch")
+		return []*upstreamv0.ServerJSON{&community, &deprecated, &official, &tagged, &stacklok}
+	}
+
+	tests := []struct {
+		name      string
+		opts      []service.Option[service.ListServersOptions]
+		wantOrder []string
+	}{
+		{
+			name: "search results are ranked by boost score",
+			opts: []service.Option[service.ListServersOptions]{service.WithSearch("io")},
+			wantOrder: []string{
+				"io.test/official",
+				"io.test/tagged",
+				"io.github.stacklok/fetch",
+				"io.test/community",
+				"io.test/deprecated",
+			},
+		},
+		{
+			name: "explicit sort keeps the original order",
+			opts: []service.Option[service.ListServersOptions]{
+				service.WithSearch("io"),
+				service.WithSort("name"),
+			},
+			wantOrder: []string{
+				"io.test/community",
+				"io.test/deprecated",
+				"io.test/official",
+				"io.test/tagged",
+				"io.github.stacklok/fetch",
+			},
+		},
+		{
+			name: "listing without search keeps the original order",
+			wantOrder: []string{
+				"io.test/community",
+				"io.test/deprecated",
+				"io.test/official",
+				"io.test/tagged",
+				"io.github.stacklok/fetch",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			svc := mocks.NewMockRegistryService(ctrl)
+			svc.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(newServers(), nil)
+
+			rankingSvc, err := New(svc, boosts)
+			require.NoError(t, err)
+
+			servers, err := rankingSvc.ListServers(context.Background(), tt.opts...)
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(servers))
+			for _, server := range servers {
+				names = append(names, server.Name)
+			}
+			assert.Equal(t, tt.wantOrder, names)
+		})
+	}
+}
+
+func TestListServersExperiment(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.SearchExperimentConfig{
+		Name: "deprioritize-official",
+		Variants: []config.SearchVariantConfig{
+			{Name: "control", Weight: 1},
+			{Name: "community-first", Weight: 1, Boosts: []config.SearchBoostConfig{{Tier: "Community", Weight: 10}}},
+		},
+	}
+	exp, err := experiment.New(cfg)
+	require.NoError(t, err)
+
+	// contextOf returns the context of a client assigned to the variant
+	contextOf := func(variant string) context.Context {
+		for i := range 1000 {
+			session := fmt.Sprintf("session-%d", i)
+			if exp.Assign(session) == variant {
+				return exp.ContextWithSession(context.Background(), session)
+			}
+		}
+		t.Fatalf("no session assigned to variant %s", variant)
+		return nil
+	}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantOrder []string
+	}{
+		{
+			name:      "variant without boosts ranks with the default boosts",
+			ctx:       contextOf("control"),
+			wantOrder: []string{"io.test/official", "io.test/community"},
+		},
+		{
+			name:      "variant boosts replace the default boosts",
+			ctx:       contextOf("community-first"),
+			wantOrder: []string{"io.test/community", "io.test/official"},
+		},
+		{
+			name:      "clients outside the experiment rank with the default boosts",
+			ctx:       context.Background(),
+			wantOrder: []string{"io.test/official", "io.test/community"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			svc := mocks.NewMockRegistryService(ctrl)
+			community := registry.NewTestServer("io.test/community", registry.WithToolHiveMetadata("tier", "Community"))
+			official := registry.NewTestServer("io.test/official", registry.WithToolHiveMetadata("tier", "Official"))
+			svc.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return([]*upstreamv0.ServerJSON{&community, &official}, nil)
+
+			rankingSvc, err := New(svc, []config.SearchBoostConfig{{Tier: "Official", Weight: 10}}, WithExperiment(cfg))
+			require.NoError(t, err)
+
+			servers, err := rankingSvc.ListServers(tt.ctx, service.WithSearch("io"))
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(servers))
+			for _, server := range servers {
+				names = append(names, server.Name)
+			}
+			assert.Equal(t, tt.wantOrder, names)
+		})
+	}
+}
+
+func TestListServersFeedback(t *testing.T) {
+	t.Parallel()
+
+	store := feedback.NewStore(&config.SearchFeedbackConfig{Weight: 5, MaxAdjustment: 8})
+	store.Record("io.test/community", true)
+	store.Record("io.test/community", true)
+	store.Record("io.test/rejected", false)
+
+	ctrl := gomock.NewController(t)
+	svc := mocks.NewMockRegistryService(ctrl)
+	rejected := registry.NewTestServer("io.test/rejected", registry.WithToolHiveMetadata("tier", "Official"))
+	plain := registry.NewTestServer("io.test/plain")
+	official := registry.NewTestServer("io.test/official", registry.WithToolHiveMetadata("tier", "Official"))
+	community := registry.NewTestServer("io.test/community", registry.WithToolHiveMetadata("tier", "Community"))
+	svc.EXPECT().ListServers(gomock.Any(), gomock.Any()).
+		Return([]*upstreamv0.ServerJSON{&rejected, &plain, &official, &community}, nil)
+
+	rankingSvc, err := New(svc, []config.SearchBoostConfig{{Tier: "Official", Weight: 10}}, WithFeedback(store))
+	require.NoError(t, err)
+
+	servers, err := rankingSvc.ListServers(context.Background(), service.WithSearch("io"))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(servers))
+	for _, server := range servers {
+		names = append(names, server.Name)
+	}
+	// Feedback is capped, so it cannot outweigh the boosts: official 10, community 8, rejected 5, plain 0
+	assert.Equal(t, []string{"io.test/official", "io.test/community", "io.test/rejected", "io.test/plain"}, names)
+}
+
+func TestListServersPagination(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	svc := mocks.NewMockRegistryService(ctrl)
+	svc.EXPECT().ListServers(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, opts ...service.Option[service.ListServersOptions]) ([]*upstreamv0.ServerJSON, error) {
+			options := &service.ListServersOptions{}
+			for _, opt := range opts {
+				require.NoError(t, opt(options))
+			}
+			// Candidates are listed whole, whatever page is requested
+			assert.Empty(t, options.Cursor)
+			assert.Equal(t, candidateLimit, options.Limit)
+			assert.Nil(t, options.NextCursor)
+			assert.Equal(t, "io", options.Search)
+
+			first := registry.NewTestServer("io.test/first")
+			second := registry.NewTestServer("io.test/second")
+			official := registry.NewTestServer("io.test/official", registry.WithToolHiveMetadata("tier", "Official"))
+			return []*upstreamv0.ServerJSON{&first, &second, &official}, nil
+		}).Times(2)
+
+	rankingSvc, err := New(svc, []config.SearchBoostConfig{{Tier: "Official", Weight: 10}})
+	require.NoError(t, err)
+
+	// The last candidate is ranked first even though it lies beyond the first page
+	var next string
+	servers, err := rankingSvc.ListServers(context.Background(),
+		service.WithSearch("io"),
+		service.WithLimit[service.ListServersOptions](2),
+		service.WithNextCursor(&next),
+	)
+	require.NoError(t, err)
+	require.Len(t, servers, 2)
+	assert.Equal(t, "io.test/official", servers[0].Name)
+	assert.Equal(t, "io.test/first", servers[1].Name)
+	require.NotEmpty(t, next)
+
+	servers, err = rankingSvc.ListServers(context.Background(),
+		service.WithSearch("io"),
+		service.WithLimit[service.ListServersOptions](2),
+		service.WithCursor(next),
+		service.WithNextCursor(&next),
+	)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "io.test/second", servers[0].Name)
+	assert.Empty(t, next)
+
+	_, err = rankingSvc.ListServers(context.Background(), service.WithSearch("io"), service.WithCursor("not-a-cursor"))
+	require.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	svc := mocks.NewMockRegistryService(ctrl)
+
+	_, err := New(nil, []config.SearchBoostConfig{{Tier: "Official", Weight: 1}})
+	require.ErrorContains(t, err, "registry service is required")
+
+	_, err = New(svc, nil)
+	require.ErrorContains(t, err, "at least one boost or feedback is required")
+
+	// Variant boosts are enough to rank searches
+	_, err = New(svc, nil, WithExperiment(&config.SearchExperimentConfig{
+		Name: "boosts",
+		Variants: []config.SearchVariantConfig{
+			{Name: "control", Weight: 1},
+			{Name: "official", Weight: 1, Boosts: []config.SearchBoostConfig{{Tier: "Official", Weight: 1}}},
+		},
+	}))
+	require.NoError(t, err)
+
+	_, err = New(svc, nil, WithFeedback(feedback.NewStore(&config.SearchFeedbackConfig{})))
+	require.NoError(t, err)
+}
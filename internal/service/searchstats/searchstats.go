@@ -0,0 +1,186 @@
+// Package searchstats tracks search queries that returned no servers, so that
+// registry curators can learn what users look for but cannot find.
+package searchstats
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+)
+
+// DefaultMaxQueries is the default number of distinct queries tracked
+const DefaultMaxQueries = 1000
+
+// MaxQueryLength is the maximum length in bytes of a tracked query; longer queries are truncated
+const MaxQueryLength = 256
+
+// ZeroResultQuery is a search query that returned no servers
+type ZeroResultQuery struct {
+	Query     string    `json:"query"`
+	Count     int       `json:"count"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// Tracker records zero-result search queries in memory.
+// Queries are normalized to lower case and truncated to MaxQueryLength. When more
+// than maxQueries distinct queries are tracked, the least recently seen one is evicted.
+// It is safe for concurrent use.
+type Tracker struct {
+	mu sync.Mutex
+	// order lists the tracked queries, most recently seen first
+	order      *list.List
+	queries    map[string]*list.Element
+	maxQueries int
+	now        func() time.Time
+}
+
+// NewTracker creates a new Tracker keeping at most maxQueries distinct queries.
+// A non-positive maxQueries uses DefaultMaxQueries.
+func NewTracker(maxQueries int) *Tracker {
+	if maxQueries <= 0 {
+		maxQueries = DefaultMaxQueries
+	}
+	return &Tracker{
+		order:      list.New(),
+		queries:    make(map[string]*list.Element),
+		maxQueries: maxQueries,
+		now:        time.Now,
+	}
+}
+
+// Record records a search query that returned no servers
+func (t *Tracker) Record(query string) {
+	query = normalizeQuery(query)
+	if query == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if elem, ok := t.queries[query]; ok {
+		entry := elem.Value.(*ZeroResultQuery)
+		entry.Count++
+		entry.LastSeen = now
+		t.order.MoveToFront(elem)
+		return
+	}
+
+	if len(t.queries) >= t.maxQueries {
+		t.evictOldestLocked()
+	}
+	t.queries[query] = t.order.PushFront(&ZeroResultQuery{
+		Query:     query,
+		Count:     1,
+		FirstSeen: now,
+		LastSeen:  now,
+	})
+}
+
+// normalizeQuery lower-cases and trims a query, and truncates it to MaxQueryLength
+func normalizeQuery(query string) string {
+	// Truncate first so that normalizing an arbitrarily long query is bounded, and again
+	// afterwards since lower-casing can grow the query
+	query = strings.ToLower(strings.TrimSpace(truncate(query)))
+	return strings.TrimSpace(truncate(query))
+}
+
+// truncate truncates s to MaxQueryLength bytes without splitting a UTF-8 sequence
+func truncate(s string) string {
+	if len(s) <= MaxQueryLength {
+		return s
+	}
+	end := MaxQueryLength
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end]
+}
+
+// Top returns up to limit tracked queries, most frequent first.
+// Queries with equal counts are ordered by most recently seen.
+func (t *Tracker) Top(limit int) []ZeroResultQuery {
+	t.mu.Lock()
+	result := make([]ZeroResultQuery, 0, len(t.queries))
+	for elem := t.order.Front(); elem != nil; elem = elem.Next() {
+		result = append(result, *elem.Value.(*ZeroResultQuery))
+	}
+	t.mu.Unlock()
+
+	return registry.TopK(result, limit, func(a, b ZeroResultQuery) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		if !a.LastSeen.Equal(b.LastSeen) {
+			return b.LastSeen.Compare(a.LastSeen)
+		}
+		return strings.Compare(a.Query, b.Query)
+	})
+}
+
+// evictOldestLocked removes the least recently seen query.
+// Caller must hold t.mu.
+func (t *Tracker) evictOldestLocked() {
+	if elem := t.order.Back(); elem != nil {
+		delete(t.queries, t.order.Remove(elem).(*ZeroResultQuery).Query)
+	}
+}
+
+// trackingSvc wraps a RegistryService and records zero-result searches
+type trackingSvc struct {
+	service.RegistryService
+	tracker *Tracker
+}
+
+var _ service.RegistryService = (*trackingSvc)(nil)
+
+// NewService creates a RegistryService that records every ListServers search
+// returning no servers in tracker. The first page of a search is the only one
+// recorded, so paging through results does not inflate counts.
+func NewService(svc service.RegistryService, tracker *Tracker) (service.RegistryService, error) {
+	if svc == nil {
+		return nil, fmt.Errorf("registry service is required")
+	}
+	if tracker == nil {
+		return nil, fmt.Errorf("tracker is required")
+	}
+
+	return &trackingSvc{
+		RegistryService: svc,
+		tracker:         tracker,
+	}, nil
+}
+
+// ListServers implements RegistryService.ListServers
+func (s *trackingSvc) ListServers(
+	ctx context.Context,
+	opts ...service.Option[service.ListServersOptions],
+) ([]*upstreamv0.ServerJSON, error) {
+	servers, err := s.RegistryService.ListServers(ctx, opts...)
+	if err != nil || len(servers) > 0 {
+		return servers, err
+	}
+
+	options := &service.ListServersOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return servers, nil
+		}
+	}
+	if options.Search != "" && options.Cursor == "" {
+		s.tracker.Record(options.Search)
+	}
+
+	return servers, nil
+}
@@ -0,0 +1,176 @@
+package searchstats
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/internal/service"
+	"github.com/stacklok/toolhive-registry-server/internal/service/mocks"
+)
+
+// newTestTracker returns a tracker whose clock advances by one second on every call
+func newTestTracker(maxQueries int) *Tracker {
+	tracker := NewTracker(maxQueries)
+	clock := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	return tracker
+}
+
+func TestTracker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		maxQueries int
+		record     []string
+		limit      int
+		wantOrder  []string
+		wantCounts []int
+	}{
+		{
+			name:       "most frequent first and normalized",
+			maxQueries: 10,
+			record:     []string{"slack", "Jira", " SLACK ", "jira", "slack", ""},
+			wantOrder:  []string{"slack", "jira"},
+			wantCounts: []int{3, 2},
+		},
+		{
+			name:       "ties ordered by most recently seen",
+			maxQueries: 10,
+			record:     []string{"a", "b", "c"},
+			wantOrder:  []string{"c", "b", "a"},
+			wantCounts: []int{1, 1, 1},
+		},
+		{
+			name:       "least recently seen is evicted",
+			maxQueries: 2,
+			record:     []string{"a", "b", "a", "c"},
+			wantOrder:  []string{"a", "c"},
+			wantCounts: []int{2, 1},
+		},
+		{
+			name:       "long queries are truncated",
+			maxQueries: 10,
+			record:     []string{strings.Repeat("a", 300), strings.Repeat("A", MaxQueryLength) + "b"},
+			wantOrder:  []string{strings.Repeat("a", MaxQueryLength)},
+			wantCounts: []int{2},
+		},
+		{
+			name:       "truncation keeps UTF-8 sequences whole",
+			maxQueries: 10,
+			record:     []string{"a" + strings.Repeat("é", MaxQueryLength)},
+			wantOrder:  []string{"a" + strings.Repeat("é", (MaxQueryLength-2)/2)},
+			wantCounts: []int{1},
+		},
+		{
+			name:       "limit is applied",
+			maxQueries: 10,
+			record:     []string{"a", "b", "b"},
+			limit:      1,
+			wantOrder:  []string{"b"},
+			wantCounts: []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tracker := newTestTracker(tt.maxQueries)
+			for _, query := range tt.record {
+				tracker.Record(query)
+			}
+
+			top := tracker.Top(tt.limit)
+			queries := make([]string, 0, len(top))
+			counts := make([]int, 0, len(top))
+			for _, entry := range top {
+				queries = append(queries, entry.Query)
+				counts = append(counts, entry.Count)
+			}
+			assert.Equal(t, tt.wantOrder, queries)
+			assert.Equal(t, tt.wantCounts, counts)
+		})
+	}
+}
+
+func TestListServers(t *testing.T) {
+	t.Parallel()
+
+	server := &upstreamv0.ServerJSON{Name: "io.test/server"}
+
+	tests := []struct {
+		name        string
+		opts        []service.Option[service.ListServersOptions]
+		servers     []*upstreamv0.ServerJSON
+		err         error
+		wantTracked []string
+	}{
+		{
+			name:        "zero-result search is recorded",
+			opts:        []service.Option[service.ListServersOptions]{service.WithSearch("slakc")},
+			servers:     []*upstreamv0.ServerJSON{},
+			wantTracked: []string{"slakc"},
+		},
+		{
+			name:        "search with results is not recorded",
+			opts:        []service.Option[service.ListServersOptions]{service.WithSearch("server")},
+			servers:     []*upstreamv0.ServerJSON{server},
+			wantTracked: []string{},
+		},
+		{
+			name:        "listing without search is not recorded",
+			servers:     []*upstreamv0.ServerJSON{},
+			wantTracked: []string{},
+		},
+		{
+			name: "later pages are not recorded",
+			opts: []service.Option[service.ListServersOptions]{
+				service.WithSearch("server"),
+				service.WithCursor("MQ=="),
+			},
+			servers:     []*upstreamv0.ServerJSON{},
+			wantTracked: []string{},
+		},
+		{
+			name:        "errors are not recorded",
+			opts:        []service.Option[service.ListServersOptions]{service.WithSearch("slakc")},
+			err:         errors.New("boom"),
+			wantTracked: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			svc := mocks.NewMockRegistryService(ctrl)
+			svc.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(tt.servers, tt.err)
+
+			tracker := NewTracker(0)
+			trackingSvc, err := NewService(svc, tracker)
+			require.NoError(t, err)
+
+			_, err = trackingSvc.ListServers(context.Background(), tt.opts...)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+			}
+
+			tracked := []string{}
+			for _, entry := range tracker.Top(0) {
+				tracked = append(tracked, entry.Query)
+			}
+			assert.Equal(t, tt.wantTracked, tracked)
+		})
+	}
+}
@@ -5,10 +5,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	toolhivetypes "github.com/stacklok/toolhive/pkg/registry/registry"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
 )
 
 var (
@@ -22,6 +26,8 @@ var (
 	ErrNotManagedRegistry = errors.New("registry is not managed")
 	// ErrVersionAlreadyExists is returned when attempting to publish a version that already exists
 	ErrVersionAlreadyExists = errors.New("version already exists")
+	// ErrTooManyServers is returned when a listing would go through more servers than a request may
+	ErrTooManyServers = errors.New("too many servers")
 )
 
 //go:generate mockgen -destination=mocks/mock_service.go -package=mocks -source=service.go Service
@@ -73,6 +79,8 @@ type RegistrySyncStatus struct {
 	AttemptCount int        `json:"attemptCount"`           // Number of sync attempts
 	ServerCount  int        `json:"serverCount"`            // Number of servers in registry
 	Message      string     `json:"message,omitempty"`      // Status or error message
+	// ContentHash is the SHA256 hash of the source data served, identifying the artifact revision
+	ContentHash string `json:"contentHash,omitempty"`
 }
 
 // RegistryListResponse represents the response for listing registries
@@ -89,12 +97,32 @@ type Option[
 
 // ListServersOptions is the options for the ListServers operation
 type ListServersOptions struct {
-	RegistryName *string
-	Cursor       string
-	Limit        int
-	Search       string
-	UpdatedSince time.Time
-	Version      string
+	RegistryName    *string
+	Cursor          string
+	Limit           int
+	Search          string
+	UpdatedSince    time.Time
+	PublishedBefore time.Time
+	Sort            string // one of registry.SortKeys
+	SortDescending  bool
+	Version         string
+	// Deprecated and deleted servers are excluded unless explicitly included
+	IncludeDeprecated bool
+	IncludeDeleted    bool
+	// MaxInjectionRisk excludes servers whose prompt-injection risk score exceeds it, if set
+	MaxInjectionRisk *int
+	// Deduplicate merges the server versions published to several registries into a single
+	// record (see registry.DeduplicateByRepository)
+	Deduplicate bool
+	// Health keeps only the servers of the given health according to HealthLookup, if set
+	Health       string
+	HealthLookup registry.HealthLookup
+	// AnnotationFilter keeps only the servers whose annotation according to AnnotationLookup matches it, if set
+	AnnotationFilter registry.AnnotationFilter
+	AnnotationLookup registry.AnnotationLookup
+	// NextCursor receives the cursor of the page following the listed servers, if set.
+	// It is left empty when there are no more servers to list.
+	NextCursor *string
 }
 
 // ListServerVersionsOptions is the options for the ListServerVersions operation
@@ -104,6 +132,8 @@ type ListServerVersionsOptions struct {
 	Next         *time.Time
 	Prev         *time.Time
 	Limit        int
+	// All lists every version of the server, ignoring Limit and the default page size
+	All bool
 }
 
 // GetServerVersionOptions is the options for the GetServerVersion operation
@@ -159,6 +189,112 @@ func WithUpdatedSince(updatedSince time.Time) Option[ListServersOptions] {
 	}
 }
 
+// WithPublishedBefore sets the published before for the ListServers operation
+func WithPublishedBefore(publishedBefore time.Time) Option[ListServersOptions] {
+	return func(o *ListServersOptions) error {
+		if publishedBefore.IsZero() {
+			return fmt.Errorf("invalid published before: %s", publishedBefore)
+		}
+		o.PublishedBefore = publishedBefore
+		return nil
+	}
+}
+
+// WithSort sets the sort key for the ListServers operation.
+// A leading "-" sorts in descending order (e.g. "-updated_at").
+func WithSort(sort string) Option[ListServersOptions] {
+	return func(o *ListServersOptions) error {
+		key, descending := strings.CutPrefix(sort, "-")
+		if !slices.Contains(registry.SortKeys(), key) {
+			return fmt.Errorf("invalid sort: %s (must be one of %s)", sort, strings.Join(registry.SortKeys(), ", "))
+		}
+		o.Sort = key
+		o.SortDescending = descending
+		return nil
+	}
+}
+
+// WithIncludeDeprecated includes deprecated servers in the results of the ListServers operation
+func WithIncludeDeprecated() Option[ListServersOptions] {
+	return func(o *ListServersOptions) error {
+		o.IncludeDeprecated = true
+		return nil
+	}
+}
+
+// WithIncludeDeleted includes deleted servers in the results of the ListServers operation
+func WithIncludeDeleted() Option[ListServersOptions] {
+	return func(o *ListServersOptions) error {
+		o.IncludeDeleted = true
+		return nil
+	}
+}
+
+// WithDeduplicate merges the server versions published to several registries, possibly under
+// different names, in the results of the ListServers operation
+func WithDeduplicate() Option[ListServersOptions] {
+	return func(o *ListServersOptions) error {
+		o.Deduplicate = true
+		return nil
+	}
+}
+
+// WithMaxInjectionRisk excludes servers whose prompt-injection risk score exceeds maxScore
+// from the results of the ListServers operation
+func WithMaxInjectionRisk(maxScore int) Option[ListServersOptions] {
+	return func(o *ListServersOptions) error {
+		if maxScore < 0 || maxScore > registry.MaxInjectionRiskScore {
+			return fmt.Errorf("invalid max injection risk: %d", maxScore)
+		}
+		o.MaxInjectionRisk = &maxScore
+		return nil
+	}
+}
+
+// WithHealth keeps only the servers whose health, as returned by lookup, is the given health
+// (registry.HealthOK or registry.HealthStale) in the results of the ListServers operation
+func WithHealth(health string, lookup registry.HealthLookup) Option[ListServersOptions] {
+	return func(o *ListServersOptions) error {
+		if !slices.Contains(registry.HealthStatuses(), health) {
+			return fmt.Errorf("invalid health: %s", health)
+		}
+		if lookup == nil {
+			return fmt.Errorf("health lookup is required")
+		}
+		o.Health = health
+		o.HealthLookup = lookup
+		return nil
+	}
+}
+
+// WithAnnotationFilter keeps only the servers whose annotation, as returned by lookup, matches the filter
+// in the results of the ListServers operation
+func WithAnnotationFilter(filter registry.AnnotationFilter, lookup registry.AnnotationLookup) Option[ListServersOptions] {
+	return func(o *ListServersOptions) error {
+		if filter.ApprovalStatus != "" && !slices.Contains(registry.ApprovalStatuses(), filter.ApprovalStatus) {
+			return fmt.Errorf("invalid approval status: %s", filter.ApprovalStatus)
+		}
+		if lookup == nil {
+			return fmt.Errorf("annotation lookup is required")
+		}
+		o.AnnotationFilter = filter
+		o.AnnotationLookup = lookup
+		return nil
+	}
+}
+
+// WithNextCursor stores the cursor of the page following the results of the ListServers operation
+// into next, or the empty string when there are no more servers to list
+func WithNextCursor(next *string) Option[ListServersOptions] {
+	return func(o *ListServersOptions) error {
+		if next == nil {
+			return fmt.Errorf("next cursor destination is required")
+		}
+		o.NextCursor = next
+		return nil
+	}
+}
+
 // WithRegistryName sets the registry name for the ListServers, ListServerVersions,
 // GetServerVersion, PublishServerVersion, or DeleteServerVersion operation
 func WithRegistryName[
@@ -257,6 +393,16 @@ func WithName[T ListServerVersionsOptions | GetServerVersionOptions | DeleteServ
 	}
 }
 
+// WithAllVersions lists every version of the server in the ListServerVersions operation, for callers
+// comparing versions, such as resolving the latest version or a version range, which must not
+// miss any of them
+func WithAllVersions() Option[ListServerVersionsOptions] {
+	return func(o *ListServerVersionsOptions) error {
+		o.All = true
+		return nil
+	}
+}
+
 // WithLimit sets the limit for the ListServers or ListServerVersions operation
 func WithLimit[T ListServersOptions | ListServerVersionsOptions](limit int) Option[T] {
 	return func(o *T) error {
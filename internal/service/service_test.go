@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
 	"github.com/stacklok/toolhive-registry-server/internal/service"
 )
 
@@ -147,6 +148,131 @@ func TestWithUpdatedSince(t *testing.T) {
 	}
 }
 
+func TestWithPublishedBefore(t *testing.T) {
+	t.Parallel()
+	validTime := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	opts := &service.ListServersOptions{}
+	require.NoError(t, service.WithPublishedBefore(validTime)(opts))
+	assert.Equal(t, validTime, opts.PublishedBefore)
+
+	require.Error(t, service.WithPublishedBefore(time.Time{})(&service.ListServersOptions{}))
+}
+
+func TestWithIncludeDeprecatedAndDeleted(t *testing.T) {
+	t.Parallel()
+
+	opts := &service.ListServersOptions{}
+	require.NoError(t, service.WithIncludeDeprecated()(opts))
+	assert.True(t, opts.IncludeDeprecated)
+	assert.False(t, opts.IncludeDeleted)
+
+	require.NoError(t, service.WithIncludeDeleted()(opts))
+	assert.True(t, opts.IncludeDeleted)
+}
+
+func TestWithMaxInjectionRisk(t *testing.T) {
+	t.Parallel()
+
+	opts := &service.ListServersOptions{}
+	require.NoError(t, service.WithMaxInjectionRisk(30)(opts))
+	require.NotNil(t, opts.MaxInjectionRisk)
+	assert.Equal(t, 30, *opts.MaxInjectionRisk)
+
+	require.Error(t, service.WithMaxInjectionRisk(-1)(&service.ListServersOptions{}))
+	require.Error(t, service.WithMaxInjectionRisk(101)(&service.ListServersOptions{}))
+}
+
+// staleLookup is a registry.HealthLookup reporting all servers as stale
+type staleLookup struct{}
+
+func (staleLookup) Health(string) string {
+	return registry.HealthStale
+}
+
+func TestWithHealth(t *testing.T) {
+	t.Parallel()
+
+	opts := &service.ListServersOptions{}
+	require.NoError(t, service.WithHealth(registry.HealthStale, staleLookup{})(opts))
+	assert.Equal(t, registry.HealthStale, opts.Health)
+	assert.NotNil(t, opts.HealthLookup)
+
+	require.Error(t, service.WithHealth("broken", staleLookup{})(&service.ListServersOptions{}))
+	require.Error(t, service.WithHealth(registry.HealthOK, nil)(&service.ListServersOptions{}))
+}
+
+func TestWithNextCursor(t *testing.T) {
+	t.Parallel()
+
+	var next string
+	opts := &service.ListServersOptions{}
+	require.NoError(t, service.WithNextCursor(&next)(opts))
+	assert.Same(t, &next, opts.NextCursor)
+
+	require.Error(t, service.WithNextCursor(nil)(&service.ListServersOptions{}))
+}
+
+func TestWithAllVersions(t *testing.T) {
+	t.Parallel()
+
+	opts := &service.ListServerVersionsOptions{}
+	require.NoError(t, service.WithAllVersions()(opts))
+	assert.True(t, opts.All)
+}
+
+func TestWithSort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		sort           string
+		wantSort       string
+		wantDescending bool
+		wantErr        bool
+	}{
+		{
+			name:     "ascending key",
+			sort:     "published_at",
+			wantSort: "published_at",
+		},
+		{
+			name:           "descending key",
+			sort:           "-updated_at",
+			wantSort:       "updated_at",
+			wantDescending: true,
+		},
+		{
+			name:    "unknown key",
+			sort:    "stars",
+			wantErr: true,
+		},
+		{
+			name:    "empty key",
+			sort:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := &service.ListServersOptions{}
+
+			err := service.WithSort(tt.sort)(opts)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSort, opts.Sort)
+			assert.Equal(t, tt.wantDescending, opts.SortDescending)
+		})
+	}
+}
+
 func TestWithRegistryNameListServers(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
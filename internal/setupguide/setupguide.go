@@ -0,0 +1,225 @@
+// Package setupguide writes platform-specific setup instructions for servers distributed as
+// binaries downloaded directly, rather than through a package manager: the download, the
+// verification of its SHA-256 hash, making it executable and adding it to the PATH.
+//
+// Binary packages have the registry type "binary", with the download URL as identifier, or
+// "github-release", with an "owner/repo/asset" identifier naming an asset of the GitHub release
+// tagged with the package version. Identifiers may contain {os}, {arch} and {version}
+// placeholders, expanded for each platform (e.g. "server-{os}-{arch}.tar.gz").
+package setupguide
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	// RegistryTypeBinary is the registry type of binaries downloaded from the URL of their identifier
+	RegistryTypeBinary = "binary"
+	// RegistryTypeGitHubRelease is the registry type of binaries attached to GitHub releases
+	RegistryTypeGitHubRelease = "github-release"
+)
+
+// DefaultPlatforms are the platforms guides are written for when none is requested
+var DefaultPlatforms = []string{"linux/amd64", "linux/arm64", "darwin/amd64", "darwin/arm64", "windows/amd64"}
+
+// supportedOS are the operating systems guides can be written for
+var supportedOS = []string{"linux", "darwin", "windows"}
+
+// IsBinary returns true for the registry types of binary packages
+func IsBinary(registryType string) bool {
+	return registryType == RegistryTypeBinary || registryType == RegistryTypeGitHubRelease
+}
+
+// Guide is the setup guide of the binary packages of a server version
+type Guide struct {
+	Server   string         `json:"server"`
+	Version  string         `json:"version"`
+	Packages []PackageGuide `json:"packages"`
+}
+
+// PackageGuide is the setup guide of a binary package
+type PackageGuide struct {
+	RegistryType string `json:"registryType"`
+	Identifier   string `json:"identifier"`
+	// Platforms are the instructions for each platform, a single one without platform when the
+	// identifier has no {os} or {arch} placeholder
+	Platforms []PlatformGuide `json:"platforms"`
+}
+
+// PlatformGuide are the instructions setting up a binary package on a platform
+type PlatformGuide struct {
+	// Platform is the operating system and architecture (e.g. "linux/amd64"), empty when the
+	// download is the same for all platforms
+	Platform string `json:"platform,omitempty"`
+	URL      string `json:"url"`
+	// Shell is the shell the steps are written for: sh or powershell
+	Shell string   `json:"shell"`
+	Steps []string `json:"steps"`
+	// Warnings are what to check that the steps cannot, e.g. an unpublished checksum
+	Warnings []string `json:"warnings"`
+}
+
+// ValidatePlatform checks that a platform is an operating system guides can be written for and
+// an architecture, separated by a slash (e.g. "linux/arm64")
+func ValidatePlatform(platform string) error {
+	os, arch, ok := strings.Cut(platform, "/")
+	if !ok || !slices.Contains(supportedOS, os) || !safeName.MatchString(arch) {
+		return fmt.Errorf("invalid platform %q: must be <os>/<arch> with os one of %s",
+			platform, strings.Join(supportedOS, ", "))
+	}
+	return nil
+}
+
+// New writes the setup guide of the binary packages of a server for the given platforms,
+// DefaultPlatforms if none. Packages of other registry types are left out.
+func New(server *upstreamv0.ServerJSON, platforms []string) (*Guide, error) {
+	if len(platforms) == 0 {
+		platforms = DefaultPlatforms
+	}
+	for _, platform := range platforms {
+		if err := ValidatePlatform(platform); err != nil {
+			return nil, err
+		}
+	}
+
+	guide := &Guide{Server: server.Name, Version: server.Version, Packages: []PackageGuide{}}
+	for _, pkg := range server.Packages {
+		if !IsBinary(pkg.RegistryType) {
+			continue
+		}
+		guide.Packages = append(guide.Packages, packageGuide(pkg, platforms))
+	}
+	return guide, nil
+}
+
+// packageGuide writes the setup guide of a binary package
+func packageGuide(pkg model.Package, platforms []string) PackageGuide {
+	guide := PackageGuide{RegistryType: pkg.RegistryType, Identifier: pkg.Identifier}
+	source := downloadURL(pkg)
+	if !strings.Contains(source, "{os}") && !strings.Contains(source, "{arch}") {
+		guide.Platforms = []PlatformGuide{platformGuide(pkg, "", source, pkg.FileSHA256)}
+		return guide
+	}
+
+	// A single hash cannot verify the downloads of several platforms
+	for _, platform := range platforms {
+		os, arch, _ := strings.Cut(platform, "/")
+		expanded := strings.NewReplacer("{os}", os, "{arch}", arch).Replace(source)
+		guide.Platforms = append(guide.Platforms, platformGuide(pkg, platform, expanded, ""))
+	}
+	return guide
+}
+
+// downloadURL returns the download URL of a binary package, with its {version} placeholder expanded
+func downloadURL(pkg model.Package) string {
+	identifier := strings.ReplaceAll(pkg.Identifier, "{version}", pkg.Version)
+	if pkg.RegistryType == RegistryTypeGitHubRelease && !strings.Contains(identifier, "://") {
+		owner, rest, _ := strings.Cut(identifier, "/")
+		repo, asset, _ := strings.Cut(rest, "/")
+		return "https://github.com/" + owner + "/" + repo + "/releases/download/" + pkg.Version + "/" + asset
+	}
+	return identifier
+}
+
+// platformGuide writes the instructions downloading a binary from a URL on a platform, verifying
+// it against the hash, if any
+func platformGuide(pkg model.Package, platform, source, sha256 string) PlatformGuide {
+	guide := PlatformGuide{Platform: platform, URL: source, Warnings: []string{}}
+	parsed, err := url.Parse(source)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		guide.Warnings = append(guide.Warnings, "the download URL is not an absolute https URL: "+source)
+		guide.Steps = []string{}
+		return guide
+	}
+
+	file := path.Base(parsed.Path)
+	if !safeName.MatchString(file) {
+		file = "mcp-server"
+	}
+	if sha256 == "" {
+		if platform != "" {
+			guide.Warnings = append(guide.Warnings, "no SHA-256 hash is published for the download of each "+
+				"platform; verify it against the checksums published by the project")
+		} else {
+			guide.Warnings = append(guide.Warnings, "no SHA-256 hash is published; verify the download against "+
+				"the checksums published by the project")
+		}
+	}
+
+	if strings.HasPrefix(platform, "windows/") {
+		guide.Shell = "powershell"
+		guide.Steps = windowsSteps(source, file, sha256)
+	} else {
+		guide.Shell = "sh"
+		guide.Steps = posixSteps(platform, source, file, sha256)
+	}
+	if pkg.Transport.Type != "" && pkg.Transport.Type != model.TransportTypeStdio {
+		guide.Warnings = append(guide.Warnings, fmt.Sprintf(
+			"the server serves the %s transport once started; connect clients to its URL", pkg.Transport.Type))
+	}
+	return guide
+}
+
+// posixSteps are the sh commands installing a binary into ~/.local/bin
+func posixSteps(platform, source, file, sha256 string) []string {
+	steps := []string{"curl -fsSL -o " + file + " " + quote(source)}
+	if sha256 != "" {
+		checker := "sha256sum -c -"
+		if strings.HasPrefix(platform, "darwin/") {
+			checker = "shasum -a 256 -c -"
+		}
+		steps = append(steps, "echo "+quote(sha256+"  "+file)+" | "+checker)
+	}
+	steps = append(steps, `mkdir -p "$HOME/.local/bin"`)
+	switch {
+	case strings.HasSuffix(file, ".tar.gz") || strings.HasSuffix(file, ".tgz"):
+		steps = append(steps, "tar -xzf "+file+` -C "$HOME/.local/bin"`)
+	case strings.HasSuffix(file, ".zip"):
+		steps = append(steps, "unzip -o "+file+` -d "$HOME/.local/bin"`)
+	default:
+		steps = append(steps, "chmod +x "+file, "mv "+file+` "$HOME/.local/bin/"`)
+	}
+	return append(steps, `export PATH="$HOME/.local/bin:$PATH"  # add this line to your shell profile to keep it`)
+}
+
+// windowsSteps are the PowerShell commands installing a binary into %LOCALAPPDATA%\Programs\mcp
+func windowsSteps(source, file, sha256 string) []string {
+	steps := []string{"Invoke-WebRequest -Uri " + psQuote(source) + " -OutFile " + psQuote(file)}
+	if sha256 != "" {
+		steps = append(steps, "if ((Get-FileHash "+psQuote(file)+" -Algorithm SHA256).Hash -ne "+
+			psQuote(strings.ToUpper(sha256))+") { throw 'SHA-256 hash mismatch' }")
+	}
+	steps = append(steps, `$dir = "$env:LOCALAPPDATA\Programs\mcp"`, `New-Item -ItemType Directory -Force $dir | Out-Null`)
+	if strings.HasSuffix(file, ".zip") {
+		steps = append(steps, "Expand-Archive "+psQuote(file)+" -DestinationPath $dir -Force")
+	} else {
+		steps = append(steps, "Move-Item "+psQuote(file)+" $dir -Force")
+	}
+	return append(steps,
+		`[Environment]::SetEnvironmentVariable('Path', [Environment]::GetEnvironmentVariable('Path', 'User') + ";$dir", 'User')`)
+}
+
+var safeName = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+var safeShellWord = regexp.MustCompile(`^[A-Za-z0-9@%+=:,./_-]+$`)
+
+// quote quotes a word for POSIX shells unless it only contains safe characters
+func quote(word string) string {
+	if safeShellWord.MatchString(word) {
+		return word
+	}
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
+
+// psQuote quotes a word for PowerShell
+func psQuote(word string) string {
+	return "'" + strings.ReplaceAll(word, "'", "''") + "'"
+}
@@ -0,0 +1,121 @@
+package setupguide
+
+import (
+	"testing"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sha = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	server := &upstreamv0.ServerJSON{
+		Name:    "io.github.acme/search",
+		Version: "1.2.0",
+		Packages: []model.Package{
+			{RegistryType: model.RegistryTypeNPM, Identifier: "@acme/search"},
+			{RegistryType: RegistryTypeBinary, Identifier: "https://dl.acme.dev/search", FileSHA256: sha},
+			{RegistryType: RegistryTypeGitHubRelease, Version: "v1.2.0",
+				Identifier: "acme/search/search_{os}_{arch}.tar.gz"},
+		},
+	}
+
+	guide, err := New(server, []string{"darwin/arm64", "windows/amd64"})
+	require.NoError(t, err)
+	assert.Equal(t, "io.github.acme/search", guide.Server)
+	require.Len(t, guide.Packages, 2)
+
+	// Downloads that do not depend on the platform are verified against the published hash
+	direct := guide.Packages[0]
+	require.Len(t, direct.Platforms, 1)
+	assert.Equal(t, PlatformGuide{
+		URL:   "https://dl.acme.dev/search",
+		Shell: "sh",
+		Steps: []string{
+			"curl -fsSL -o search https://dl.acme.dev/search",
+			"echo '" + sha + "  search' | sha256sum -c -",
+			`mkdir -p "$HOME/.local/bin"`,
+			"chmod +x search",
+			`mv search "$HOME/.local/bin/"`,
+			`export PATH="$HOME/.local/bin:$PATH"  # add this line to your shell profile to keep it`,
+		},
+		Warnings: []string{},
+	}, direct.Platforms[0])
+
+	release := guide.Packages[1]
+	require.Len(t, release.Platforms, 2)
+	darwin := release.Platforms[0]
+	assert.Equal(t, "darwin/arm64", darwin.Platform)
+	assert.Equal(t, "https://github.com/acme/search/releases/download/v1.2.0/search_darwin_arm64.tar.gz", darwin.URL)
+	assert.Contains(t, darwin.Steps, `tar -xzf search_darwin_arm64.tar.gz -C "$HOME/.local/bin"`)
+	assert.Len(t, darwin.Warnings, 1)
+
+	windows := release.Platforms[1]
+	assert.Equal(t, "powershell", windows.Shell)
+	assert.Equal(t, "Invoke-WebRequest -Uri "+
+		"'https://github.com/acme/search/releases/download/v1.2.0/search_windows_amd64.tar.gz' "+
+		"-OutFile 'search_windows_amd64.tar.gz'", windows.Steps[0])
+}
+
+func TestNewChecksum(t *testing.T) {
+	t.Parallel()
+
+	server := &upstreamv0.ServerJSON{Packages: []model.Package{
+		{RegistryType: RegistryTypeBinary, Identifier: "https://dl.acme.dev/search.exe", FileSHA256: sha},
+	}}
+
+	darwin, err := New(server, []string{"darwin/amd64"})
+	require.NoError(t, err)
+	// Downloads shared by all platforms are written for POSIX shells
+	assert.Contains(t, darwin.Packages[0].Platforms[0].Steps, "echo '"+sha+"  search.exe' | sha256sum -c -")
+
+	server.Packages[0].Identifier = "https://dl.acme.dev/{os}/search.exe"
+	windows, err := New(server, []string{"windows/amd64"})
+	require.NoError(t, err)
+	platform := windows.Packages[0].Platforms[0]
+	// A single hash cannot verify the downloads of several platforms
+	assert.NotContains(t, platform.Steps[1], "Get-FileHash")
+	assert.Len(t, platform.Warnings, 1)
+}
+
+func TestNewInvalid(t *testing.T) {
+	t.Parallel()
+
+	server := &upstreamv0.ServerJSON{Packages: []model.Package{
+		{RegistryType: RegistryTypeBinary, Identifier: "http://dl.acme.dev/search"},
+	}}
+
+	guide, err := New(server, nil)
+	require.NoError(t, err)
+	require.Len(t, guide.Packages[0].Platforms, 1)
+	assert.Empty(t, guide.Packages[0].Platforms[0].Steps)
+	assert.Equal(t, []string{"the download URL is not an absolute https URL: http://dl.acme.dev/search"},
+		guide.Packages[0].Platforms[0].Warnings)
+
+	_, err = New(server, []string{"plan9/amd64"})
+	assert.Error(t, err)
+}
+
+func TestValidatePlatform(t *testing.T) {
+	t.Parallel()
+
+	for _, platform := range DefaultPlatforms {
+		assert.NoError(t, ValidatePlatform(platform))
+	}
+	for _, platform := range []string{"", "linux", "freebsd/amd64", "linux/", "linux/amd 64"} {
+		assert.Error(t, ValidatePlatform(platform), platform)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "https://example.com/a.tar.gz", quote("https://example.com/a.tar.gz"))
+	assert.Equal(t, `'it'\''s'`, quote("it's"))
+	assert.Equal(t, "'it''s'", psQuote("it's"))
+}
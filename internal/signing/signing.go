@@ -0,0 +1,227 @@
+// Package signing provides detached signatures over API responses.
+//
+// When response signing is enabled, every JSON response carries an Ed25519
+// signature, so that systems consuming data relayed by agents can verify it
+// really came from this registry, in answer to the request they made.
+//
+// The signature covers exactly the following fields, joined with newlines
+// (see SignedPayload):
+//
+//  1. the request method, in upper case, e.g. GET
+//  2. the request path, escaped as in the request line, e.g.
+//     /registry/v0.1/servers/io.github.example%2Fserver/versions/latest
+//  3. the request query, with its parameters sorted by name, the values of a
+//     parameter kept in order, and names and values escaped as in
+//     url.Values.Encode, e.g. limit=10&search=weather; empty without a query
+//  4. the canonical form of the response body (see package canonical): the
+//     compact JSON encoding of the payload with object keys sorted and no HTML
+//     escaping, which makes the signature independent of whitespace and key
+//     order changes introduced in transit
+//
+// The status code and the headers of the response, including its content
+// type, and the headers and body of the request are not covered.
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/stacklok/toolhive-registry-server/internal/canonical"
+)
+
+const (
+	// SignatureHeader is the response header carrying the base64-encoded signature
+	SignatureHeader = "X-Registry-Signature"
+	// KeyIDHeader is the response header naming the key the response was signed with
+	KeyIDHeader = "X-Registry-Key-Id"
+	// Algorithm is the signature algorithm
+	Algorithm = "ed25519"
+)
+
+// PublicKey describes the public key verifying response signatures
+type PublicKey struct {
+	KeyID     string `json:"keyId"`
+	Algorithm string `json:"algorithm"`
+	// PublicKey is the base64-encoded raw Ed25519 public key
+	PublicKey string `json:"publicKey"`
+}
+
+// Signer signs response payloads with an Ed25519 private key
+type Signer struct {
+	keyID string
+	key   ed25519.PrivateKey
+}
+
+// NewSigner creates a signer for the given private key, identified by keyID
+func NewSigner(key ed25519.PrivateKey, keyID string) (*Signer, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 private key size: %d", len(key))
+	}
+	return &Signer{keyID: keyID, key: key}, nil
+}
+
+// LoadSigner creates a signer from a PEM-encoded PKCS#8 Ed25519 private key file,
+// as generated by `openssl genpkey -algorithm ed25519`
+func LoadSigner(keyFile, keyID string) (*Signer, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", keyFile)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is not an Ed25519 key", keyFile)
+	}
+	return NewSigner(key, keyID)
+}
+
+// KeyID returns the identifier of the signing key
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// PublicKey returns the public key verifying the signatures of the signer
+func (s *Signer) PublicKey() PublicKey {
+	return PublicKey{
+		KeyID:     s.keyID,
+		Algorithm: Algorithm,
+		PublicKey: base64.StdEncoding.EncodeToString(s.key.Public().(ed25519.PublicKey)),
+	}
+}
+
+// SignedPayload returns the data a response signature covers: the method, path and query of the
+// request, and the canonical form of the JSON response body, as described in the package documentation
+func SignedPayload(method string, target *url.URL, body []byte) ([]byte, error) {
+	canonicalBody, err := canonical.JSON(body)
+	if err != nil {
+		return nil, err
+	}
+	var payload bytes.Buffer
+	payload.WriteString(strings.ToUpper(method))
+	payload.WriteByte('\n')
+	payload.WriteString(target.EscapedPath())
+	payload.WriteByte('\n')
+	payload.WriteString(target.Query().Encode())
+	payload.WriteByte('\n')
+	payload.Write(canonicalBody)
+	return payload.Bytes(), nil
+}
+
+// Sign returns the base64-encoded signature of the JSON response body to a request
+// with the given method and target URL, see SignedPayload
+func (s *Signer) Sign(method string, target *url.URL, body []byte) (string, error) {
+	data, err := SignedPayload(method, target, body)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, data)), nil
+}
+
+// Verify checks a base64-encoded signature of the JSON response body to a request
+// with the given method and target URL, see SignedPayload
+func Verify(publicKey ed25519.PublicKey, method string, target *url.URL, body []byte, signature string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	data, err := SignedPayload(method, target, body)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, data, sig) {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
+
+// Middleware signs JSON responses along with the method, path and query of their request, adding
+// the signature and key id headers. Other responses are passed through unsigned. It must see the
+// request as received by the server, before any rewriting of its URL.
+func (s *Signer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		for key, values := range rec.header {
+			w.Header()[key] = values
+		}
+		if isJSON(rec.header.Get("Content-Type")) && rec.body.Len() > 0 {
+			signature, err := s.Sign(r.Method, r.URL, rec.body.Bytes())
+			if err != nil {
+				slog.WarnContext(r.Context(), "Failed to sign response", "path", r.URL.Path, "error", err)
+			} else {
+				w.Header().Set(SignatureHeader, signature)
+				w.Header().Set(KeyIDHeader, s.keyID)
+			}
+		}
+		w.WriteHeader(rec.status)
+		_, _ = w.Write(rec.body.Bytes())
+	})
+}
+
+// PublicKeyHandler serves the public key verifying response signatures
+func (s *Signer) PublicKeyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		body, err := json.Marshal(s.PublicKey())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	})
+}
+
+// bufferedResponse holds a response until it has been signed
+type bufferedResponse struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+// Header implements http.ResponseWriter
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+// WriteHeader implements http.ResponseWriter
+func (b *bufferedResponse) WriteHeader(status int) {
+	if b.wroteHeader {
+		return
+	}
+	b.status = status
+	b.wroteHeader = true
+}
+
+// Write implements http.ResponseWriter
+func (b *bufferedResponse) Write(data []byte) (int, error) {
+	b.wroteHeader = true
+	return b.body.Write(data)
+}
+
+// isJSON reports whether a content type denotes a JSON payload
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
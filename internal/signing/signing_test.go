@@ -0,0 +1,182 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(t *testing.T) (*Signer, ed25519.PublicKey) {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := NewSigner(privateKey, "test-key")
+	require.NoError(t, err)
+	return signer, publicKey
+}
+
+func writeKeyFile(t *testing.T, key any) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+	return path
+}
+
+func TestSignAndVerify(t *testing.T) {
+	t.Parallel()
+	signer, publicKey := newTestSigner(t)
+	target := func(rawURL string) *url.URL {
+		parsed, err := url.Parse(rawURL)
+		require.NoError(t, err)
+		return parsed
+	}
+
+	listURL := target("/registry/v0.1/servers?search=weather&limit=10")
+	body := []byte(`{"name":"io.test/server","version":"1.0.0"}`)
+	signature, err := signer.Sign(http.MethodGet, listURL, body)
+	require.NoError(t, err)
+
+	// Reformatting the payload or reordering the query parameters does not invalidate the signature
+	assert.NoError(t, Verify(publicKey, http.MethodGet, listURL,
+		[]byte("{\"version\": \"1.0.0\", \"name\": \"io.test/server\"}"), signature))
+	assert.NoError(t, Verify(publicKey, "get", target("/registry/v0.1/servers?limit=10&search=weather"), body, signature))
+
+	assert.Error(t, Verify(publicKey, http.MethodGet, listURL, []byte(`{"name":"io.test/server","version":"2.0.0"}`), signature))
+	assert.Error(t, Verify(publicKey, http.MethodPost, listURL, body, signature))
+	assert.Error(t, Verify(publicKey, http.MethodGet, target("/registry/v0.1/servers/io.test%2Fserver"), body, signature))
+	assert.Error(t, Verify(publicKey, http.MethodGet, target("/registry/v0.1/servers?search=weather&limit=20"), body, signature))
+	assert.Error(t, Verify(publicKey, http.MethodGet, target("/registry/v0.1/servers?search=weather"), body, signature))
+	assert.Error(t, Verify(publicKey, http.MethodGet, listURL, []byte(`{}`), "not base64!"))
+
+	_, err = signer.Sign(http.MethodGet, listURL, []byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestSignedPayload(t *testing.T) {
+	t.Parallel()
+
+	target, err := url.Parse("/registry/v0.1/servers/io.test%2Fserver/versions?version=latest&b=2&b=1&a=x%20y")
+	require.NoError(t, err)
+	payload, err := SignedPayload("get", target, []byte(`{ "b": 1, "a": "<x>" }`))
+	require.NoError(t, err)
+	assert.Equal(t,
+		"GET\n/registry/v0.1/servers/io.test%2Fserver/versions\na=x+y&b=2&b=1&version=latest\n{\"a\":\"<x>\",\"b\":1}",
+		string(payload))
+}
+
+func TestLoadSigner(t *testing.T) {
+	t.Parallel()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := LoadSigner(writeKeyFile(t, privateKey), "prod-2026")
+	require.NoError(t, err)
+	assert.Equal(t, "prod-2026", signer.KeyID())
+	assert.Equal(t, base64.StdEncoding.EncodeToString(privateKey.Public().(ed25519.PublicKey)), signer.PublicKey().PublicKey)
+
+	_, err = LoadSigner(writeKeyFile(t, ecdsaKey), "prod-2026")
+	assert.ErrorContains(t, err, "not an Ed25519 key")
+
+	notPEM := filepath.Join(t.TempDir(), "key.txt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("secret"), 0600))
+	_, err = LoadSigner(notPEM, "prod-2026")
+	assert.ErrorContains(t, err, "not PEM encoded")
+
+	_, err = LoadSigner(filepath.Join(t.TempDir(), "missing.pem"), "prod-2026")
+	assert.Error(t, err)
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+	signer, publicKey := newTestSigner(t)
+
+	tests := []struct {
+		name          string
+		contentType   string
+		status        int
+		body          string
+		wantSignature bool
+	}{
+		{
+			name:          "signs JSON responses",
+			contentType:   "application/json",
+			status:        http.StatusOK,
+			body:          `{"servers":[]}`,
+			wantSignature: true,
+		},
+		{
+			name:          "signs JSON error responses",
+			contentType:   "application/json; charset=utf-8",
+			status:        http.StatusNotFound,
+			body:          `{"error":"not found"}`,
+			wantSignature: true,
+		},
+		{
+			name:        "passes other responses through",
+			contentType: "text/x-shellscript",
+			status:      http.StatusOK,
+			body:        "#!/bin/sh\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			handler := signer.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/registry/v0.1/servers?limit=1", nil)
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+			assert.Equal(t, tt.body, rr.Body.String())
+			assert.Equal(t, tt.contentType, rr.Header().Get("Content-Type"))
+			if !tt.wantSignature {
+				assert.Empty(t, rr.Header().Get(SignatureHeader))
+				return
+			}
+			assert.Equal(t, "test-key", rr.Header().Get(KeyIDHeader))
+			assert.NoError(t, Verify(publicKey, http.MethodGet, req.URL, rr.Body.Bytes(), rr.Header().Get(SignatureHeader)))
+			assert.Error(t, Verify(publicKey, http.MethodGet, &url.URL{Path: "/registry/v0.1/servers"},
+				rr.Body.Bytes(), rr.Header().Get(SignatureHeader)))
+		})
+	}
+}
+
+func TestPublicKeyHandler(t *testing.T) {
+	t.Parallel()
+	signer, publicKey := newTestSigner(t)
+
+	rr := httptest.NewRecorder()
+	signer.PublicKeyHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var key PublicKey
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &key))
+	assert.Equal(t, "test-key", key.KeyID)
+	assert.Equal(t, Algorithm, key.Algorithm)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(publicKey), key.PublicKey)
+}
@@ -0,0 +1,19 @@
+// Package site renders synced registry data into a static, human-browsable website.
+//
+// The generator produces one HTML page per server (latest version only), an
+// index page with a client-side search box, and, when a base URL is
+// configured, a sitemap.xml suitable for search engine indexing:
+//
+//	output/
+//	├── index.html
+//	├── sitemap.xml
+//	└── servers/
+//	    └── io.github.stacklok_fetch.html
+//
+// Server names are mapped to file names by replacing the namespace separator
+// '/' with '_', which is not a valid character in reverse-DNS namespaces and
+// therefore cannot produce collisions.
+//
+// All pages are rendered with html/template, so registry-provided strings such
+// as descriptions are always escaped.
+package site
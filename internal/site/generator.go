@@ -0,0 +1,239 @@
+package site
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	toolhivetypes "github.com/stacklok/toolhive/pkg/registry/registry"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+const (
+	// ServersDir is the output subdirectory holding one page per server
+	ServersDir = "servers"
+
+	defaultTitle = "MCP Server Catalog"
+	sitemapNS    = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+// Option configures the site generator
+type Option func(*generator) error
+
+// WithBaseURL sets the public URL the site is hosted at, enabling sitemap.xml generation
+func WithBaseURL(baseURL string) Option {
+	return func(g *generator) error {
+		parsed, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("invalid base URL: %w", err)
+		}
+		if !parsed.IsAbs() || parsed.Host == "" {
+			return fmt.Errorf("base URL must be an absolute URL with host: %s", baseURL)
+		}
+		g.baseURL = strings.TrimSuffix(baseURL, "/")
+		return nil
+	}
+}
+
+// WithTitle sets the title shown on every page
+func WithTitle(title string) Option {
+	return func(g *generator) error {
+		if title == "" {
+			return fmt.Errorf("title cannot be empty")
+		}
+		g.title = title
+		return nil
+	}
+}
+
+// generator holds the rendering configuration
+type generator struct {
+	baseURL string
+	title   string
+	now     func() time.Time
+}
+
+// serverPage is the view model for a single server
+type serverPage struct {
+	Server     *upstreamv0.ServerJSON
+	File       string
+	Registries []string
+	Tags       []string
+	Tier       string
+	Status     string
+	Packages   []packageRow
+}
+
+// packageRow is the view model for a package of a server, including its integrity digest
+type packageRow struct {
+	RegistryType string
+	Identifier   string
+	Version      string
+	Transport    string
+	Digest       string
+}
+
+// Generate renders the given registries into outputDir.
+// registries maps registry names to their synced data; servers published to
+// several registries are rendered once and list every registry they appear in.
+func Generate(outputDir string, registries map[string]*toolhivetypes.UpstreamRegistry, opts ...Option) error {
+	g := &generator{
+		title: defaultTitle,
+		now:   time.Now,
+	}
+	for _, opt := range opts {
+		if err := opt(g); err != nil {
+			return err
+		}
+	}
+
+	pages := collectPages(registries)
+
+	if err := os.MkdirAll(filepath.Join(outputDir, ServersDir), 0750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, page := range pages {
+		if err := g.renderFile(filepath.Join(outputDir, page.File), serverTemplate, page); err != nil {
+			return fmt.Errorf("failed to render page for %s: %w", page.Server.Name, err)
+		}
+	}
+
+	if err := g.renderFile(filepath.Join(outputDir, "index.html"), indexTemplate, pages); err != nil {
+		return fmt.Errorf("failed to render index page: %w", err)
+	}
+
+	if g.baseURL != "" {
+		if err := g.writeSitemap(filepath.Join(outputDir, "sitemap.xml"), pages); err != nil {
+			return fmt.Errorf("failed to write sitemap: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// FileName returns the page path, relative to the output directory, for a server name
+func FileName(serverName string) string {
+	return ServersDir + "/" + strings.ReplaceAll(serverName, "/", "_") + ".html"
+}
+
+// collectPages builds one page per server name using its latest version across all registries
+func collectPages(registries map[string]*toolhivetypes.UpstreamRegistry) []*serverPage {
+	registryNames := make([]string, 0, len(registries))
+	for name := range registries {
+		registryNames = append(registryNames, name)
+	}
+	sort.Strings(registryNames)
+
+	var allServers []upstreamv0.ServerJSON
+	serverRegistries := make(map[string][]string)
+	for _, registryName := range registryNames {
+		reg := registries[registryName]
+		if reg == nil {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, server := range reg.Data.Servers {
+			allServers = append(allServers, server)
+			if !seen[server.Name] {
+				serverRegistries[server.Name] = append(serverRegistries[server.Name], registryName)
+				seen[server.Name] = true
+			}
+		}
+	}
+
+	latest := registry.LatestVersions(allServers)
+	pages := make([]*serverPage, 0, len(latest))
+	for _, server := range latest {
+		pages = append(pages, &serverPage{
+			Server:     server,
+			File:       FileName(server.Name),
+			Registries: serverRegistries[server.Name],
+			Tags:       registry.ExtractTags(server),
+			Tier:       registry.ExtractTier(server),
+			Status:     registry.ExtractStatus(server),
+			Packages:   packageRows(server),
+		})
+	}
+
+	return pages
+}
+
+// packageRows builds the package view models of a server
+func packageRows(server *upstreamv0.ServerJSON) []packageRow {
+	rows := make([]packageRow, 0, len(server.Packages))
+	for _, pkg := range server.Packages {
+		rows = append(rows, packageRow{
+			RegistryType: pkg.RegistryType,
+			Identifier:   pkg.Identifier,
+			Version:      pkg.Version,
+			Transport:    pkg.Transport.Type,
+			Digest:       registry.PackageDigest(pkg),
+		})
+	}
+	return rows
+}
+
+// renderFile executes a template with the given data and writes the result to path
+func (g *generator) renderFile(path string, tmpl *template.Template, data any) error {
+	//nolint:gosec // Output path is built from the user-provided output directory
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return tmpl.Execute(f, struct {
+		Title string
+		Data  any
+	}{
+		Title: g.title,
+		Data:  data,
+	})
+}
+
+// sitemapURLSet is the root element of a sitemap.xml document
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL is a single sitemap.xml entry
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// writeSitemap writes a sitemap.xml listing the index and all server pages
+func (g *generator) writeSitemap(path string, pages []*serverPage) error {
+	lastMod := g.now().UTC().Format("2006-01-02")
+
+	urlSet := sitemapURLSet{
+		XMLNS: sitemapNS,
+		URLs:  []sitemapURL{{Loc: g.baseURL + "/", LastMod: lastMod}},
+	}
+	for _, page := range pages {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{
+			Loc:     g.baseURL + "/" + page.File,
+			LastMod: lastMod,
+		})
+	}
+
+	data, err := xml.MarshalIndent(urlSet, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append([]byte(xml.Header), data...), 0600)
+}
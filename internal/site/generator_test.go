@@ -0,0 +1,106 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	toolhivetypes "github.com/stacklok/toolhive/pkg/registry/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	registries := map[string]*toolhivetypes.UpstreamRegistry{
+		"public": registry.NewTestUpstreamRegistry(
+			registry.WithServers(
+				registry.NewTestServer("io.test/fetch",
+					registry.WithServerVersion("1.0.0"),
+					registry.WithDescription("Old fetch"),
+				),
+				registry.NewTestServer("io.test/fetch",
+					registry.WithServerVersion("1.10.0"),
+					registry.WithDescription("Fetch <b>web</b> pages"),
+					registry.WithOCIPackage("ghcr.io/test/fetch:1.10.0"),
+				),
+			),
+		),
+		"internal": registry.NewTestUpstreamRegistry(
+			registry.WithServers(
+				registry.NewTestServer("io.test/fetch", registry.WithServerVersion("1.2.0")),
+				registry.NewTestServer("io.test/github",
+					registry.WithTags("git", "scm"),
+					registry.WithOCIPackage("ghcr.io/test/github@sha256:"+strings.Repeat("a", 64)),
+				),
+			),
+		),
+	}
+
+	tests := []struct {
+		name        string
+		opts        []Option
+		wantSitemap bool
+		wantErr     string
+	}{
+		{
+			name: "without base URL",
+		},
+		{
+			name:        "with base URL",
+			opts:        []Option{WithBaseURL("https://catalog.example.com/"), WithTitle("Example Catalog")},
+			wantSitemap: true,
+		},
+		{
+			name:    "relative base URL",
+			opts:    []Option{WithBaseURL("/catalog")},
+			wantErr: "base URL must be an absolute URL with host",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			outputDir := t.TempDir()
+
+			err := Generate(outputDir, registries, tt.opts...)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			index, err := os.ReadFile(filepath.Join(outputDir, "index.html"))
+			require.NoError(t, err)
+			assert.Contains(t, string(index), `href="servers/io.test_fetch.html"`)
+			assert.Contains(t, string(index), `href="servers/io.test_github.html"`)
+			assert.Contains(t, string(index), "2 servers")
+
+			page, err := os.ReadFile(filepath.Join(outputDir, "servers", "io.test_fetch.html"))
+			require.NoError(t, err)
+			assert.Contains(t, string(page), "version 1.10.0")
+			assert.Contains(t, string(page), "Fetch &lt;b&gt;web&lt;/b&gt; pages")
+			assert.Contains(t, string(page), "internal, public")
+			assert.Contains(t, string(page), "ghcr.io/test/fetch:1.10.0")
+			assert.Contains(t, string(page), "not pinned")
+
+			page, err = os.ReadFile(filepath.Join(outputDir, "servers", "io.test_github.html"))
+			require.NoError(t, err)
+			assert.Contains(t, string(page), "<code>sha256:"+strings.Repeat("a", 64)+"</code>")
+
+			sitemap, err := os.ReadFile(filepath.Join(outputDir, "sitemap.xml"))
+			if !tt.wantSitemap {
+				assert.True(t, os.IsNotExist(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, string(index), "Example Catalog")
+			assert.Contains(t, string(sitemap), "<loc>https://catalog.example.com/</loc>")
+			assert.Contains(t, string(sitemap), "<loc>https://catalog.example.com/servers/io.test_github.html</loc>")
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package site
+
+import "html/template"
+
+const pageHeader = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ template "title" . }}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 60rem; margin: 2rem auto; padding: 0 1rem; color: #222; }
+a { color: #0b5cad; text-decoration: none; }
+.server { border-bottom: 1px solid #ddd; padding: 0.75rem 0; }
+.tag { display: inline-block; background: #eef; border-radius: 0.25rem; padding: 0 0.4rem; margin-right: 0.25rem; font-size: 0.85rem; }
+.meta { color: #666; font-size: 0.9rem; }
+#search { width: 100%; padding: 0.5rem; font-size: 1rem; margin-bottom: 1rem; }
+table { border-collapse: collapse; }
+td, th { text-align: left; padding: 0.25rem 0.75rem 0.25rem 0; vertical-align: top; }
+</style>
+</head>
+<body>
+`
+
+const pageFooter = `</body>
+</html>
+`
+
+var indexTemplate = template.Must(template.New("index").Parse(`{{ define "title" }}{{ .Title }}{{ end }}` + pageHeader + `
+<h1>{{ .Title }}</h1>
+<input id="search" type="search" placeholder="Search servers by name, description, or tag" aria-label="Search servers">
+<p class="meta">{{ len .Data }} servers</p>
+<div id="servers">
+{{- range .Data }}
+<div class="server" data-search="{{ .Server.Name }} {{ .Server.Title }} {{ .Server.Description }}{{ range .Tags }} {{ . }}{{ end }}">
+  <a href="{{ .File }}"><strong>{{ if .Server.Title }}{{ .Server.Title }}{{ else }}{{ .Server.Name }}{{ end }}</strong></a>
+  <span class="meta">{{ .Server.Name }} {{ .Server.Version }}{{ if .Tier }} &middot; {{ .Tier }}{{ end }}</span>
+  <div>{{ .Server.Description }}</div>
+  <div>{{ range .Tags }}<span class="tag">{{ . }}</span>{{ end }}</div>
+</div>
+{{- end }}
+</div>
+<script>
+document.getElementById("search").addEventListener("input", function (e) {
+  var query = e.target.value.toLowerCase();
+  document.querySelectorAll("#servers .server").forEach(function (el) {
+    el.style.display = el.dataset.search.toLowerCase().indexOf(query) === -1 ? "none" : "";
+  });
+});
+</script>
+` + pageFooter))
+
+var serverTemplate = template.Must(template.New("server").Parse(
+	`{{ define "title" }}{{ .Data.Server.Name }} - {{ .Title }}{{ end }}` + pageHeader + `
+{{- with .Data }}
+<p><a href="../index.html">&larr; All servers</a></p>
+<h1>{{ if .Server.Title }}{{ .Server.Title }}{{ else }}{{ .Server.Name }}{{ end }}</h1>
+<p class="meta">{{ .Server.Name }} &middot; version {{ .Server.Version }}</p>
+<p>{{ .Server.Description }}</p>
+<table>
+  {{- if .Tier }}<tr><th>Tier</th><td>{{ .Tier }}</td></tr>{{ end }}
+  {{- if .Status }}<tr><th>Status</th><td>{{ .Status }}</td></tr>{{ end }}
+  {{- if .Server.Repository }}{{ if .Server.Repository.URL }}<tr><th>Repository</th><td><a href="{{ .Server.Repository.URL }}">{{ .Server.Repository.URL }}</a></td></tr>{{ end }}{{ end }}
+  {{- if .Server.WebsiteURL }}<tr><th>Website</th><td><a href="{{ .Server.WebsiteURL }}">{{ .Server.WebsiteURL }}</a></td></tr>{{ end }}
+  <tr><th>Registries</th><td>{{ range $i, $r := .Registries }}{{ if $i }}, {{ end }}{{ $r }}{{ end }}</td></tr>
+  {{- if .Tags }}<tr><th>Tags</th><td>{{ range .Tags }}<span class="tag">{{ . }}</span>{{ end }}</td></tr>{{ end }}
+</table>
+{{- if .Packages }}
+<h2>Packages</h2>
+<table>
+<tr><th>Type</th><th>Identifier</th><th>Transport</th><th>Digest</th></tr>
+{{- range .Packages }}
+<tr><td>{{ .RegistryType }}</td><td><code>{{ .Identifier }}{{ if .Version }}@{{ .Version }}{{ end }}</code></td><td>{{ .Transport }}</td><td>{{ if .Digest }}<code>{{ .Digest }}</code>{{ else }}<span class="meta">not pinned</span>{{ end }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- if .Server.Remotes }}
+<h2>Remotes</h2>
+<table>
+<tr><th>Transport</th><th>URL</th></tr>
+{{- range .Server.Remotes }}
+<tr><td>{{ .Type }}</td><td><code>{{ .URL }}</code></td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- end }}
+` + pageFooter))
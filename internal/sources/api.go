@@ -19,8 +19,11 @@ type apiRegistryHandler struct {
 
 // NewAPIRegistryHandler creates a new API registry handler
 func NewAPIRegistryHandler() RegistryHandler {
-	httpClient := httpclient.NewDefaultClient(0) // Use default timeout
+	return NewAPIRegistryHandlerWithClient(httpclient.NewDefaultClient(0)) // Use default timeout
+}
 
+// NewAPIRegistryHandlerWithClient creates a new API registry handler with a custom HTTP client
+func NewAPIRegistryHandlerWithClient(httpClient httpclient.Client) RegistryHandler {
 	return &apiRegistryHandler{
 		httpClient:      httpClient,
 		validator:       NewRegistryDataValidator(),
@@ -87,6 +90,18 @@ func (h *apiRegistryHandler) CurrentHash(ctx context.Context, regCfg *config.Reg
 	return handler.CurrentHash(ctx, regCfg)
 }
 
+// Check verifies that the API serves the upstream MCP Registry format. Servers are not fetched,
+// since large registries are paginated over many requests.
+func (h *apiRegistryHandler) Check(ctx context.Context, regCfg *config.RegistryConfig) error {
+	if err := h.Validate(regCfg); err != nil {
+		return fmt.Errorf("registry validation failed: %w", err)
+	}
+	if _, err := h.validateUstreamFormat(ctx, regCfg); err != nil {
+		return fmt.Errorf("upstream format validation failed: %w", err)
+	}
+	return nil
+}
+
 // validateUstreamFormat validates the Upstream format and returns the appropriate handler
 func (h *apiRegistryHandler) validateUstreamFormat(
 	ctx context.Context,
@@ -10,10 +10,12 @@ package sources
 // See: https://github.com/stacklok/toolhive-registry-server/issues/XXX (create issue)
 
 import (
+	"bytes"
 	"context"
-	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/url"
 	"strings"
 	"time"
@@ -23,6 +25,7 @@ import (
 	"gopkg.in/yaml.v3"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/stacklok/toolhive-registry-server/internal/canonical"
 	"github.com/stacklok/toolhive-registry-server/internal/config"
 	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
 	"github.com/stacklok/toolhive-registry-server/internal/registry"
@@ -103,13 +106,16 @@ func (h *upstreamAPIHandler) FetchRegistry(ctx context.Context, regCfg *config.R
 	logger := log.FromContext(ctx)
 	baseURL := getBaseURL(regCfg)
 
+	// Push the filters the API supports down to it, to fetch fewer servers
+	query, pushedDown := pushdownQuery(regCfg.Filter)
+
 	// Fetch all servers via pagination
-	servers, err := h.fetchAllServers(ctx, baseURL)
+	servers, err := h.fetchAllServers(ctx, baseURL, query, regCfg.API.Strict)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch servers: %w", err)
 	}
 
-	logger.Info("Fetched all servers from upstream API", "count", len(servers))
+	logger.Info("Fetched all servers from upstream API", "count", len(servers), "pushedDownFilters", pushedDown)
 
 	// Convert to UpstreamRegistry format
 	upstreamReg := h.buildUpstreamRegistry(servers)
@@ -121,7 +127,64 @@ func (h *upstreamAPIHandler) FetchRegistry(ctx context.Context, regCfg *config.R
 	}
 
 	// Return as FetchResult
-	return NewFetchResult(upstreamReg, hash, config.SourceFormatUpstream), nil
+	result := NewFetchResult(upstreamReg, hash, config.SourceFormatUpstream)
+	result.PushedDownFilters = pushedDown
+	return result, nil
+}
+
+// pushdownQuery translates the filters of a registry that the MCP Registry API can apply into query
+// parameters of its server list, and returns the names of the filters pushed down.
+// The API only matches servers by a case-insensitive substring of their name, so a single name include
+// pattern is pushed down as its longest literal part. The servers fetched are still filtered client-side,
+// as the substring matches more names than the pattern does.
+func pushdownQuery(filter *config.FilterConfig) (url.Values, []string) {
+	query := url.Values{}
+	if filter == nil || filter.Names == nil || len(filter.Names.Include) != 1 {
+		return query, nil
+	}
+
+	search := longestLiteral(filter.Names.Include[0])
+	if search == "" {
+		return query, nil
+	}
+	query.Set("search", search)
+	return query, []string{"names.include"}
+}
+
+// longestLiteral returns the longest part of a filepath.Match pattern that only matches itself
+func longestLiteral(pattern string) string {
+	var longest, current strings.Builder
+	flush := func() {
+		if current.Len() > longest.Len() {
+			longest.Reset()
+			longest.WriteString(current.String())
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*', '?':
+			flush()
+		case '[':
+			flush()
+			// Skip the character class, an unterminated class makes the pattern invalid
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return ""
+			}
+			i += end + 1
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				current.WriteByte(pattern[i])
+			}
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+	return longest.String()
 }
 
 // CurrentHash returns the current hash of the API response
@@ -135,8 +198,14 @@ func (h *upstreamAPIHandler) CurrentHash(ctx context.Context, regCfg *config.Reg
 	return result.Hash, nil
 }
 
-// fetchAllServers performs paginated fetching and returns all ServerJSON objects
-func (h *upstreamAPIHandler) fetchAllServers(ctx context.Context, baseURL string) ([]v0.ServerJSON, error) {
+// fetchAllServers performs paginated fetching and returns all ServerJSON objects matching the query
+// When strict is set, pages are decoded with decodeStrict
+func (h *upstreamAPIHandler) fetchAllServers(
+	ctx context.Context,
+	baseURL string,
+	query url.Values,
+	strict bool,
+) ([]v0.ServerJSON, error) {
 	logger := log.FromContext(ctx)
 	allServers := []v0.ServerJSON{}
 	cursor := ""
@@ -155,6 +224,9 @@ func (h *upstreamAPIHandler) fetchAllServers(ctx context.Context, baseURL string
 
 		// Build URL with pagination
 		requestURL := fmt.Sprintf("%s/v0.1/servers?limit=100", baseURL)
+		if len(query) > 0 {
+			requestURL = fmt.Sprintf("%s&%s", requestURL, query.Encode())
+		}
 		if cursor != "" {
 			// Security: URL-encode cursor to prevent injection attacks
 			requestURL = fmt.Sprintf("%s&cursor=%s", requestURL, url.QueryEscape(cursor))
@@ -170,7 +242,15 @@ func (h *upstreamAPIHandler) fetchAllServers(ctx context.Context, baseURL string
 
 		// Parse response
 		var response v0.ServerListResponse
-		if err := json.Unmarshal(data, &response); err != nil {
+		if strict {
+			if err := decodeStrict(data, &response); err != nil {
+				slog.Error("Upstream response does not match the MCP Registry API schema",
+					"url", requestURL,
+					"page", pageCount,
+					"error", err)
+				return nil, fmt.Errorf("failed to parse response page %d in strict mode: %w", pageCount, err)
+			}
+		} else if err := json.Unmarshal(data, &response); err != nil {
 			return nil, fmt.Errorf("failed to parse response page %d: %w", pageCount, err)
 		}
 
@@ -182,9 +262,9 @@ func (h *upstreamAPIHandler) fetchAllServers(ctx context.Context, baseURL string
 				len(allServers)+len(response.Servers), maxServers)
 		}
 
-		// Extract ServerJSON from each ServerResponse
+		// Extract ServerJSON from each ServerResponse, keeping the official timestamps and status
 		for _, serverResp := range response.Servers {
-			allServers = append(allServers, serverResp.Server)
+			allServers = append(allServers, registry.FromServerResponse(serverResp))
 		}
 
 		// Check if there are more pages
@@ -199,6 +279,24 @@ func (h *upstreamAPIHandler) fetchAllServers(ctx context.Context, baseURL string
 	return allServers, nil
 }
 
+// decodeStrict decodes JSON data into v, rejecting unknown fields, trailing data,
+// and decoding numbers in free-form fields (such as _meta) as json.Number so
+// that large integers are not silently rounded to float64
+func decodeStrict(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	decoder.UseNumber()
+
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after JSON value")
+	}
+
+	return nil
+}
+
 // buildUpstreamRegistry converts []ServerJSON to ToolHive's UpstreamRegistry format
 func (*upstreamAPIHandler) buildUpstreamRegistry(servers []v0.ServerJSON) *toolhivetypes.UpstreamRegistry {
 	return &toolhivetypes.UpstreamRegistry{
@@ -214,17 +312,15 @@ func (*upstreamAPIHandler) buildUpstreamRegistry(servers []v0.ServerJSON) *toolh
 	}
 }
 
-// calculateHash computes SHA256 hash of the serialized registry
+// calculateHash computes the SHA256 hash of the canonical form of the registry servers.
+// The metadata is left out as its last update time changes on every fetch, and so are
+// empty fields, so that only changes to the upstream data are detected.
 func (*upstreamAPIHandler) calculateHash(reg *toolhivetypes.UpstreamRegistry) (string, error) {
-	// Serialize to JSON
-	data, err := json.Marshal(reg)
+	hash, err := canonical.Hash(reg.Data, canonical.OmitEmpty())
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal registry: %w", err)
 	}
-
-	// Compute SHA256
-	hash := sha256.Sum256(data)
-	return fmt.Sprintf("%x", hash), nil
+	return hash, nil
 }
 
 // getBaseURL extracts and normalizes the base URL from the registry configuration
@@ -4,12 +4,14 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/stacklok/toolhive-registry-server/internal/config"
 	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
 )
 
 const (
@@ -418,6 +420,170 @@ info:
 				Expect(err.Error()).To(ContainSubstring("failed to parse response"))
 			})
 		})
+
+		Context("Response with unknown fields", func() {
+			BeforeEach(func() {
+				mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == serversAPIPath {
+						w.Header().Set("Content-Type", "application/json")
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{
+							"servers": [
+								{
+									"server": {
+										"name": "test-server",
+										"description": "A test server",
+										"displayName": "Renamed title field"
+									}
+								}
+							],
+							"metadata": {}
+						}`))
+					} else {
+						w.WriteHeader(http.StatusNotFound)
+					}
+				}))
+				registryConfig = &config.RegistryConfig{
+					Name:   "test-registry",
+					Format: config.SourceFormatUpstream,
+					API: &config.APIConfig{
+						Endpoint: mockServer.URL,
+					},
+				}
+			})
+
+			It("should ignore unknown fields by default", func() {
+				result, err := handler.FetchRegistry(ctx, registryConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.ServerCount).To(Equal(1))
+			})
+
+			It("should reject unknown fields in strict mode", func() {
+				registryConfig.API.Strict = true
+				_, err := handler.FetchRegistry(ctx, registryConfig)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("strict mode"))
+				Expect(err.Error()).To(ContainSubstring(`unknown field "displayName"`))
+			})
+		})
+
+		Context("Registry with filters", func() {
+			var receivedSearches []string
+
+			BeforeEach(func() {
+				receivedSearches = []string{}
+				mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == serversAPIPath {
+						receivedSearches = append(receivedSearches, r.URL.Query().Get("search"))
+						w.Header().Set("Content-Type", "application/json")
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"servers": [], "metadata": {}}`))
+					} else {
+						w.WriteHeader(http.StatusNotFound)
+					}
+				}))
+				registryConfig = &config.RegistryConfig{
+					Name:   "test-registry",
+					Format: config.SourceFormatUpstream,
+					API: &config.APIConfig{
+						Endpoint: mockServer.URL,
+					},
+				}
+			})
+
+			It("should push a single name include pattern down as a search", func() {
+				registryConfig.Filter = &config.FilterConfig{
+					Names: &config.NameFilterConfig{Include: []string{"io.github.*/server-[ab]*"}},
+				}
+				result, err := handler.FetchRegistry(ctx, registryConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(receivedSearches).To(Equal([]string{"io.github."}))
+				Expect(result.PushedDownFilters).To(Equal([]string{"names.include"}))
+			})
+
+			It("should not push down several name include patterns", func() {
+				registryConfig.Filter = &config.FilterConfig{
+					Names: &config.NameFilterConfig{Include: []string{"io.github.*", "com.example/*"}},
+				}
+				result, err := handler.FetchRegistry(ctx, registryConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(receivedSearches).To(Equal([]string{""}))
+				Expect(result.PushedDownFilters).To(BeEmpty())
+			})
+
+			It("should not push down tag filters", func() {
+				registryConfig.Filter = &config.FilterConfig{
+					Tags: &config.TagFilterConfig{Include: []string{"stable"}},
+				}
+				result, err := handler.FetchRegistry(ctx, registryConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(receivedSearches).To(Equal([]string{""}))
+				Expect(result.PushedDownFilters).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("FetchRegistry with official metadata", func() {
+		var registryConfig *config.RegistryConfig
+
+		BeforeEach(func() {
+			mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == serversAPIPath {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{
+						"servers": [
+							{
+								"server": {
+									"name": "test-server",
+									"description": "A test server",
+									"version": "1.0.0"
+								},
+								"_meta": {
+									"io.modelcontextprotocol.registry/official": {
+										"status": "active",
+										"publishedAt": "2025-01-02T03:04:05Z",
+										"updatedAt": "2025-02-03T04:05:06Z",
+										"isLatest": true
+									}
+								}
+							}
+						],
+						"metadata": {}
+					}`))
+				} else {
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			registryConfig = &config.RegistryConfig{
+				Name:   "test-registry",
+				Format: config.SourceFormatUpstream,
+				API: &config.APIConfig{
+					Endpoint: mockServer.URL,
+				},
+			}
+		})
+
+		It("should keep the official lifecycle status", func() {
+			result, err := handler.FetchRegistry(ctx, registryConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Registry.Data.Servers).To(HaveLen(1))
+			Expect(registry.ExtractLifecycleStatus(&result.Registry.Data.Servers[0])).To(Equal(registry.StatusActive))
+		})
+
+		It("should keep the official publication and update timestamps", func() {
+			result, err := handler.FetchRegistry(ctx, registryConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Registry.Data.Servers).To(HaveLen(1))
+
+			server := &result.Registry.Data.Servers[0]
+			publishedAt, ok := registry.ExtractPublishedAt(server)
+			Expect(ok).To(BeTrue())
+			Expect(publishedAt).To(BeTemporally("==", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)))
+			updatedAt, ok := registry.ExtractUpdatedAt(server)
+			Expect(ok).To(BeTrue())
+			Expect(updatedAt).To(BeTemporally("==", time.Date(2025, 2, 3, 4, 5, 6, 0, time.UTC)))
+		})
 	})
 
 	Describe("CurrentHash", func() {
@@ -464,6 +630,15 @@ info:
 				// Should be a valid SHA256 hex string (64 characters)
 				Expect(hash).To(HaveLen(64))
 			})
+
+			It("should return the same hash for unchanged data", func() {
+				first, err := handler.CurrentHash(ctx, registryConfig)
+				Expect(err).NotTo(HaveOccurred())
+				time.Sleep(1100 * time.Millisecond)
+				second, err := handler.CurrentHash(ctx, registryConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(second).To(Equal(first))
+			})
 		})
 
 		Context("Error propagation", func() {
@@ -0,0 +1,43 @@
+package sources
+
+import (
+	"sync"
+)
+
+// etagCache remembers the entity tag and content hash of the last download of URL sources, so that
+// checking an unchanged source for changes takes a conditional request instead of a download
+type etagCache struct {
+	mu      sync.Mutex
+	entries map[string]etagEntry
+}
+
+// etagEntry is the entity tag of a downloaded source and the hash of its content
+type etagEntry struct {
+	etag string
+	hash string
+}
+
+// newETagCache creates an empty entity tag cache
+func newETagCache() *etagCache {
+	return &etagCache{entries: make(map[string]etagEntry)}
+}
+
+// get returns the entry of a source, if any
+func (c *etagCache) get(key string) (etagEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+// put records the entity tag and content hash of a download. Sources served without entity
+// tag are forgotten, since their changes can't be checked with conditional requests.
+func (c *etagCache) put(key, etag, hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if etag == "" {
+		delete(c.entries, key)
+		return
+	}
+	c.entries[key] = etagEntry{etag: etag, hash: hash}
+}
@@ -7,18 +7,22 @@ import (
 )
 
 // defaultRegistryHandlerFactory is the default implementation of RegistryHandlerFactory
-type defaultRegistryHandlerFactory struct{}
+type defaultRegistryHandlerFactory struct {
+	// etags is shared by the file handlers, so that change checks of URL sources between syncs
+	// are conditional requests
+	etags *etagCache
+}
 
 var _ RegistryHandlerFactory = (*defaultRegistryHandlerFactory)(nil)
 
 // NewRegistryHandlerFactory creates a new registry handler factory
 func NewRegistryHandlerFactory() RegistryHandlerFactory {
-	return &defaultRegistryHandlerFactory{}
+	return &defaultRegistryHandlerFactory{etags: newETagCache()}
 }
 
 // CreateHandler creates a registry handler for the given registry configuration
 // The source type is inferred from which field is present (Git/API/File)
-func (*defaultRegistryHandlerFactory) CreateHandler(regCfg *config.RegistryConfig) (RegistryHandler, error) {
+func (f *defaultRegistryHandlerFactory) CreateHandler(regCfg *config.RegistryConfig) (RegistryHandler, error) {
 	if regCfg == nil {
 		return nil, fmt.Errorf("registry configuration cannot be nil")
 	}
@@ -32,9 +36,21 @@ func (*defaultRegistryHandlerFactory) CreateHandler(regCfg *config.RegistryConfi
 	case config.SourceTypeGit:
 		return NewGitRegistryHandler(), nil
 	case config.SourceTypeAPI:
-		return NewAPIRegistryHandler(), nil
+		client, err := newSourceClient(regCfg, 0)
+		if err != nil {
+			return nil, err
+		}
+		return NewAPIRegistryHandlerWithClient(client), nil
 	case config.SourceTypeFile:
-		return NewFileRegistryHandler(), nil
+		client, err := newSourceClient(regCfg, DefaultURLTimeout)
+		if err != nil {
+			return nil, err
+		}
+		return &fileRegistryHandler{
+			validator:  NewRegistryDataValidator(),
+			httpClient: client,
+			etags:      f.etags,
+		}, nil
 	case config.SourceTypeKubernetes:
 		return nil, fmt.Errorf("kubernetes source type is not yet implemented")
 	default:
@@ -20,6 +20,8 @@ const (
 type fileRegistryHandler struct {
 	validator  RegistryDataValidator
 	httpClient httpclient.Client
+	// etags enables conditional change checks of URL sources when set
+	etags *etagCache
 }
 
 // NewFileRegistryHandler creates a new file registry handler
@@ -119,35 +121,99 @@ func (*fileRegistryHandler) fetchLocalFileData(regCfg *config.RegistryConfig) ([
 	return data, hash, nil
 }
 
+// urlClient returns the HTTP client fetching a URL source, honoring its timeout
+func (h *fileRegistryHandler) urlClient(regCfg *config.RegistryConfig) (httpclient.Client, error) {
+	if regCfg.File.Timeout == "" {
+		return h.httpClient, nil
+	}
+	timeout, err := time.ParseDuration(regCfg.File.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeout: %w", err)
+	}
+	return newSourceClient(regCfg, timeout)
+}
+
 // fetchURLData fetches the registry file from a URL and calculates its hash
 func (h *fileRegistryHandler) fetchURLData(ctx context.Context, regCfg *config.RegistryConfig) ([]byte, string, error) {
 	fileURL := regCfg.File.URL
 
 	// Create HTTP client with configured timeout if specified
-	client := h.httpClient
-	if regCfg.File.Timeout != "" {
-		timeout, err := time.ParseDuration(regCfg.File.Timeout)
+	client, err := h.urlClient(regCfg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fetch data from URL, keeping its entity tag for the next change checks
+	conditional, ok := client.(httpclient.ConditionalClient)
+	if !ok || h.etags == nil {
+		data, err := client.Get(ctx, fileURL)
 		if err != nil {
-			return nil, "", fmt.Errorf("invalid timeout: %w", err)
+			return nil, "", fmt.Errorf("failed to fetch URL %s: %w", fileURL, err)
 		}
-		client = httpclient.NewDefaultClient(timeout)
+		return data, fmt.Sprintf("%x", sha256.Sum256(data)), nil
 	}
-
-	// Fetch data from URL
-	data, err := client.Get(ctx, fileURL)
+	resp, err := conditional.GetIfNoneMatch(ctx, fileURL, "")
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to fetch URL %s: %w", fileURL, err)
 	}
 
 	// Calculate hash
-	hash := fmt.Sprintf("%x", sha256.Sum256(data))
+	hash := fmt.Sprintf("%x", sha256.Sum256(resp.Body))
+	h.etags.put(etagKey(regCfg), resp.ETag, hash)
 
-	return data, hash, nil
+	return resp.Body, hash, nil
+}
+
+// currentURLHash returns the hash of a URL source. If the source served an entity tag with its last
+// download, it is only downloaded again if it no longer matches the tag.
+func (h *fileRegistryHandler) currentURLHash(ctx context.Context, regCfg *config.RegistryConfig) (string, error) {
+	client, err := h.urlClient(regCfg)
+	if err != nil {
+		return "", err
+	}
+	cached, found := h.etags.get(etagKey(regCfg))
+	conditional, ok := client.(httpclient.ConditionalClient)
+	if !found || !ok {
+		_, hash, err := h.fetchURLData(ctx, regCfg)
+		return hash, err
+	}
+
+	resp, err := conditional.GetIfNoneMatch(ctx, regCfg.File.URL, cached.etag)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch URL %s: %w", regCfg.File.URL, err)
+	}
+	if resp.NotModified {
+		return cached.hash, nil
+	}
+
+	hash := fmt.Sprintf("%x", sha256.Sum256(resp.Body))
+	h.etags.put(etagKey(regCfg), resp.ETag, hash)
+	return hash, nil
+}
+
+// etagKey identifies a URL source in the entity tag cache. The registry name is part of the key
+// since registries may fetch the same URL with different headers.
+func etagKey(regCfg *config.RegistryConfig) string {
+	return regCfg.Name + " " + regCfg.File.URL
+}
+
+// Check reads the file, or downloads it, and parses its registry data
+func (h *fileRegistryHandler) Check(ctx context.Context, regCfg *config.RegistryConfig) error {
+	_, err := h.FetchRegistry(ctx, regCfg)
+	return err
 }
 
 // CurrentHash returns the current hash of the source without performing a full parse
 func (h *fileRegistryHandler) CurrentHash(ctx context.Context, regCfg *config.RegistryConfig) (string, error) {
-	// For file/URL sources, we read and hash the content
+	// URL sources with an entity tag are checked with a conditional request
+	if h.etags != nil && h.isURLSource(regCfg) {
+		if err := h.Validate(regCfg); err != nil {
+			return "", fmt.Errorf("registry validation failed: %w", err)
+		}
+		return h.currentURLHash(ctx, regCfg)
+	}
+
+	// For other file/URL sources, we read and hash the content
 	// This is nearly as expensive as a full fetch, but maintains the interface
 	_, hash, err := h.fetchData(ctx, regCfg)
 	if err != nil {
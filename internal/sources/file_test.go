@@ -2,10 +2,13 @@ package sources
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -577,6 +580,54 @@ func TestFileRegistryHandler_CurrentHash_URL(t *testing.T) {
 	assert.Equal(t, hash, hash2, "Hash should be deterministic for same content")
 }
 
+func TestFileRegistryHandler_CurrentHash_URL_ETag(t *testing.T) {
+	t.Parallel()
+
+	// Serve the registry with an entity tag, counting full downloads
+	var downloads, revision atomic.Int32
+	revision.Store(1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf(`"v%d"`, revision.Load())
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads.Add(1)
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(strings.Replace(testToolhiveRegistryData, "1.0.0", fmt.Sprintf("1.0.%d", revision.Load()), 1)))
+	}))
+	defer server.Close()
+
+	handler, err := NewRegistryHandlerFactory().CreateHandler(&config.RegistryConfig{
+		Name: "test-url",
+		File: &config.FileConfig{URL: server.URL},
+	})
+	require.NoError(t, err)
+	regCfg := &config.RegistryConfig{
+		Name:   "test-url",
+		Format: config.SourceFormatToolHive,
+		File:   &config.FileConfig{URL: server.URL},
+	}
+
+	hash, err := handler.CurrentHash(context.Background(), regCfg)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), downloads.Load())
+
+	// Unchanged sources are checked without being downloaded again
+	unchanged, err := handler.CurrentHash(context.Background(), regCfg)
+	require.NoError(t, err)
+	assert.Equal(t, hash, unchanged)
+	assert.Equal(t, int32(1), downloads.Load())
+
+	// Changed sources are downloaded and hashed again
+	revision.Store(2)
+	changed, err := handler.CurrentHash(context.Background(), regCfg)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+	assert.Equal(t, int32(2), downloads.Load())
+}
+
 func TestFileRegistryHandler_FetchRegistry_URL_WithTimeout(t *testing.T) {
 	t.Parallel()
 
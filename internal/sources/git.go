@@ -159,6 +159,12 @@ func (h *gitRegistryHandler) FetchRegistry(ctx context.Context, regCfg *config.R
 	return NewFetchResult(reg, hash, regCfg.Format), nil
 }
 
+// Check clones the repository and parses its registry data
+func (h *gitRegistryHandler) Check(ctx context.Context, regCfg *config.RegistryConfig) error {
+	_, err := h.FetchRegistry(ctx, regCfg)
+	return err
+}
+
 // CurrentHash returns the current hash of the source data after fetching the registry data
 func (h *gitRegistryHandler) CurrentHash(ctx context.Context, regCfg *config.RegistryConfig) (string, error) {
 	registryData, err := h.fetchRegistryData(ctx, regCfg)
@@ -82,6 +82,20 @@ func (m *MockRegistryHandler) EXPECT() *MockRegistryHandlerMockRecorder {
 	return m.recorder
 }
 
+// Check mocks base method.
+func (m *MockRegistryHandler) Check(ctx context.Context, regCfg *config.RegistryConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, regCfg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockRegistryHandlerMockRecorder) Check(ctx, regCfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockRegistryHandler)(nil).Check), ctx, regCfg)
+}
+
 // CurrentHash mocks base method.
 func (m *MockRegistryHandler) CurrentHash(ctx context.Context, regCfg *config.RegistryConfig) (string, error) {
 	m.ctrl.T.Helper()
@@ -98,3 +98,42 @@ func (mr *MockStorageManagerMockRecorder) Store(ctx, registryName, reg any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockStorageManager)(nil).Store), ctx, registryName, reg)
 }
+
+// MockVersionedStorage is a mock of VersionedStorage interface.
+type MockVersionedStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockVersionedStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockVersionedStorageMockRecorder is the mock recorder for MockVersionedStorage.
+type MockVersionedStorageMockRecorder struct {
+	mock *MockVersionedStorage
+}
+
+// NewMockVersionedStorage creates a new mock instance.
+func NewMockVersionedStorage(ctrl *gomock.Controller) *MockVersionedStorage {
+	mock := &MockVersionedStorage{ctrl: ctrl}
+	mock.recorder = &MockVersionedStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVersionedStorage) EXPECT() *MockVersionedStorageMockRecorder {
+	return m.recorder
+}
+
+// Version mocks base method.
+func (m *MockVersionedStorage) Version(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Version", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockVersionedStorageMockRecorder) Version(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockVersionedStorage)(nil).Version), ctx)
+}
@@ -1,7 +1,10 @@
 package sources
 
 import (
+	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -32,16 +35,39 @@ type StorageManager interface {
 	Delete(ctx context.Context, registryName string) error
 }
 
+// VersionedStorage is implemented by the storage managers that can tell whether the stored
+// registry data changed without reading it, the way an entity tag does for HTTP resources
+type VersionedStorage interface {
+	// Version returns an opaque version of the data of all registries, which changes whenever it does
+	Version(ctx context.Context) (string, error)
+}
+
 // fileStorageManager implements StorageManager using local filesystem
 type fileStorageManager struct {
 	basePath string
+	indent   bool
+}
+
+// FileStorageOption configures a file-based storage manager
+type FileStorageOption func(*fileStorageManager)
+
+// WithIndent pretty-prints the stored registry files for readability.
+// Indentation makes large catalogs noticeably bigger, so it is off by default.
+func WithIndent() FileStorageOption {
+	return func(f *fileStorageManager) {
+		f.indent = true
+	}
 }
 
 // NewFileStorageManager creates a new file-based storage manager
-func NewFileStorageManager(basePath string) StorageManager {
-	return &fileStorageManager{
+func NewFileStorageManager(basePath string, opts ...FileStorageOption) StorageManager {
+	f := &fileStorageManager{
 		basePath: basePath,
 	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
 }
 
 // Store saves the registry data to a JSON file in a registry-specific subdirectory
@@ -54,16 +80,11 @@ func (f *fileStorageManager) Store(_ context.Context, registryName string, reg *
 
 	filePath := filepath.Join(registryDir, RegistryFileName)
 
-	// Marshal UpstreamRegistry to JSON with pretty printing for readability
-	data, err := json.MarshalIndent(reg, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal registry data: %w", err)
-	}
-
 	// Write to temporary file first for atomic operation
 	tempPath := filePath + ".tmp"
-	if err := os.WriteFile(tempPath, data, 0600); err != nil {
-		return fmt.Errorf("failed to write temporary registry file: %w", err)
+	if err := f.writeRegistryFile(tempPath, reg); err != nil {
+		_ = os.Remove(tempPath)
+		return err
 	}
 
 	// Atomic rename
@@ -76,24 +97,54 @@ func (f *fileStorageManager) Store(_ context.Context, registryName string, reg *
 	return nil
 }
 
+// writeRegistryFile streams the registry data as JSON to a file, so that large catalogs
+// are never held in memory as a whole document
+func (f *fileStorageManager) writeRegistryFile(path string, reg *toolhivetypes.UpstreamRegistry) error {
+	//nolint:gosec // File path is internally managed by StorageManager, not user input
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write temporary registry file: %w", err)
+	}
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	if f.indent {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(reg); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to marshal registry data: %w", err)
+	}
+	if err := writer.Flush(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write temporary registry file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write temporary registry file: %w", err)
+	}
+	return nil
+}
+
 // Get retrieves and parses registry data from the JSON file for a specific registry
 func (f *fileStorageManager) Get(_ context.Context, registryName string) (*toolhivetypes.UpstreamRegistry, error) {
 	registryDir := filepath.Join(f.basePath, registryName)
 	filePath := filepath.Join(registryDir, RegistryFileName)
 
-	// Read file
 	//nolint:gosec // File path is internally managed by StorageManager, not user input
-	data, err := os.ReadFile(filePath)
+	file, err := os.Open(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("registry file not found for registry '%s': %w", registryName, err)
 		}
 		return nil, fmt.Errorf("failed to read registry file for registry '%s': %w", registryName, err)
 	}
+	defer func() {
+		_ = file.Close()
+	}()
 
-	// Unmarshal JSON to UpstreamRegistry
+	// Decode JSON to UpstreamRegistry straight from the file
 	var reg toolhivetypes.UpstreamRegistry
-	if err := json.Unmarshal(data, &reg); err != nil {
+	if err := json.NewDecoder(bufio.NewReader(file)).Decode(&reg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal registry data for registry '%s': %w", registryName, err)
 	}
 
@@ -134,6 +185,34 @@ func (f *fileStorageManager) GetAll(ctx context.Context) (map[string]*toolhivety
 	return result, nil
 }
 
+// Version implements VersionedStorage from the size and modification time of the registry files.
+// Files are replaced on every store, so their modification time changes with their content.
+func (f *fileStorageManager) Version(_ context.Context) (string, error) {
+	entries, err := os.ReadDir(f.basePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read storage directory: %w", err)
+	}
+
+	hash := sha256.New()
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(f.basePath, entry.Name(), RegistryFileName))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to stat registry file for registry '%s': %w", entry.Name(), err)
+		}
+		_, _ = fmt.Fprintf(hash, "%s\x00%d\x00%d\n", entry.Name(), info.Size(), info.ModTime().UnixNano())
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // Delete removes the registry data file for a specific registry
 func (f *fileStorageManager) Delete(_ context.Context, registryName string) error {
 	registryDir := filepath.Join(f.basePath, registryName)
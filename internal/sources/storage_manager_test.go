@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -47,6 +48,44 @@ func TestFileStorageManager_StoreAndGet(t *testing.T) {
 	require.Equal(t, UpstreamRegistry.Meta.LastUpdated, retrieved.Meta.LastUpdated)
 }
 
+func TestFileStorageManager_Indent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		opts         []FileStorageOption
+		wantIndented bool
+	}{
+		{
+			name: "compact by default",
+		},
+		{
+			name:         "indented when requested",
+			opts:         []FileStorageOption{WithIndent()},
+			wantIndented: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tmpDir := t.TempDir()
+			manager := NewFileStorageManager(tmpDir, tt.opts...)
+
+			reg := registry.NewTestUpstreamRegistry(registry.WithVersion("1.0.0"))
+			require.NoError(t, manager.Store(context.Background(), testRegistryName, reg))
+
+			data, err := os.ReadFile(filepath.Join(tmpDir, testRegistryName, RegistryFileName))
+			require.NoError(t, err)
+			require.Equal(t, tt.wantIndented, strings.Contains(string(data), "\n  "))
+
+			retrieved, err := manager.Get(context.Background(), testRegistryName)
+			require.NoError(t, err)
+			require.Equal(t, reg.Version, retrieved.Version)
+		})
+	}
+}
+
 func TestFileStorageManager_Delete(t *testing.T) {
 	t.Parallel()
 
@@ -244,3 +283,36 @@ func TestFileStorageManager_GetAll_PartialFailure(t *testing.T) {
 	require.Contains(t, result, "registry1")
 	require.NotContains(t, result, "invalid-registry")
 }
+
+func TestFileStorageManager_Version(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	manager := NewFileStorageManager(tmpDir)
+	versioned, ok := manager.(VersionedStorage)
+	require.True(t, ok)
+
+	empty, err := versioned.Version(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, manager.Store(ctx, testRegistryName, registry.NewTestUpstreamRegistry()))
+	stored, err := versioned.Version(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, empty, stored)
+
+	unchanged, err := versioned.Version(ctx)
+	require.NoError(t, err)
+	require.Equal(t, stored, unchanged)
+
+	require.NoError(t, manager.Store(ctx, testRegistryName, registry.NewTestUpstreamRegistry(
+		registry.WithServers(registry.NewTestServer("io.test/server")),
+	)))
+	updated, err := versioned.Version(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, stored, updated)
+
+	missing, err := NewFileStorageManager(filepath.Join(tmpDir, "missing")).(VersionedStorage).Version(ctx)
+	require.NoError(t, err)
+	require.Empty(t, missing)
+}
@@ -0,0 +1,81 @@
+package sources
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"maps"
+	"os"
+	"time"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+)
+
+// newSourceClient creates the HTTP client fetching a registry source, identifying the deployment
+// with the attribution headers and applying the transport configuration of the source.
+// If timeout is 0, uses the client default timeout.
+func newSourceClient(regCfg *config.RegistryConfig, timeout time.Duration) (httpclient.Client, error) {
+	userAgent := httpclient.UserAgent()
+	headers := make(map[string]string)
+	if attribution := regCfg.GetAttribution(); attribution != nil {
+		if attribution.DeploymentID != "" {
+			userAgent = httpclient.DeploymentUserAgent(attribution.DeploymentID)
+			headers[httpclient.DeploymentIDHeader] = attribution.DeploymentID
+		}
+		if attribution.Contact != "" {
+			headers["From"] = attribution.Contact
+		}
+	}
+
+	var opts []httpclient.Option
+	if transport := regCfg.Transport; transport != nil {
+		if transport.UserAgent != "" {
+			userAgent = transport.UserAgent
+		}
+		maps.Copy(headers, transport.GetHeaders())
+		if transport.TLS != nil {
+			tlsConfig, err := newTLSConfig(transport.TLS)
+			if err != nil {
+				return nil, fmt.Errorf("invalid transport tls configuration: %w", err)
+			}
+			opts = append(opts, httpclient.WithTLSConfig(tlsConfig))
+		}
+	}
+
+	opts = append(opts, httpclient.WithUserAgent(userAgent), httpclient.WithHeaders(headers))
+	return httpclient.NewDefaultClient(timeout, opts...), nil
+}
+
+// newTLSConfig builds the TLS configuration of the connections to a registry source
+func newTLSConfig(cfg *config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+	}
+
+	if cfg.CACertFile != "" {
+		pem, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %s", cfg.CACertFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
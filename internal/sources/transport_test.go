@@ -0,0 +1,164 @@
+package sources
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/httpclient"
+)
+
+func TestNewSourceClient(t *testing.T) {
+	t.Parallel()
+
+	var gotHeader, gotServerName string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Org-Id")
+		gotServerName = r.TLS.ServerName
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0600))
+
+	regCfg := &config.RegistryConfig{
+		Name: "mirror",
+		API:  &config.APIConfig{Endpoint: server.URL},
+		Transport: &config.TransportConfig{
+			Headers: map[string]string{"X-Org-Id": "acme"},
+			TLS:     &config.TLSConfig{CACertFile: caFile, ServerName: "example.com"},
+		},
+	}
+	client, err := newSourceClient(regCfg, 0)
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", gotHeader)
+	assert.Equal(t, "example.com", gotServerName)
+
+	// Without the CA, the certificate of the source is not trusted
+	defaultClient, err := newSourceClient(&config.RegistryConfig{Name: "mirror"}, 0)
+	require.NoError(t, err)
+	_, err = defaultClient.Get(context.Background(), server.URL)
+	require.Error(t, err)
+}
+
+func TestNewSourceClientInvalidTLS(t *testing.T) {
+	t.Parallel()
+
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0600))
+
+	tests := []struct {
+		name    string
+		tls     *config.TLSConfig
+		wantErr string
+	}{
+		{
+			name:    "missing CA file",
+			tls:     &config.TLSConfig{CACertFile: "/nonexistent/ca.pem"},
+			wantErr: "failed to read CA certificate",
+		},
+		{
+			name:    "CA file without certificate",
+			tls:     &config.TLSConfig{CACertFile: notPEM},
+			wantErr: "no certificate found",
+		},
+		{
+			name:    "missing client certificate",
+			tls:     &config.TLSConfig{CertFile: "/nonexistent/tls.crt", KeyFile: "/nonexistent/tls.key"},
+			wantErr: "failed to load client certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			regCfg := &config.RegistryConfig{
+				Name:      "mirror",
+				API:       &config.APIConfig{Endpoint: "https://mirror.example.com"},
+				Transport: &config.TransportConfig{TLS: tt.tls},
+			}
+			_, err := newSourceClient(regCfg, 0)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNewSourceClientAttribution(t *testing.T) {
+	t.Parallel()
+
+	requests := make(chan http.Header, 3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r.Header.Clone()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`registries:
+  - name: official
+    api:
+      endpoint: `+server.URL+`
+    syncPolicy:
+      interval: "1h"
+  - name: partner
+    api:
+      endpoint: `+server.URL+`
+    syncPolicy:
+      interval: "1h"
+    transport:
+      userAgent: acme-bridge/2.0
+      disableAttribution: true
+  - name: mirror
+    api:
+      endpoint: `+server.URL+`
+    syncPolicy:
+      interval: "1h"
+    transport:
+      headers:
+        From: mirror-team@example.com
+attribution:
+  deploymentId: acme-prod
+  contact: platform-team@example.com
+auth:
+  mode: anonymous
+`), 0600))
+	cfg, err := config.LoadConfig(config.WithConfigPath(configPath))
+	require.NoError(t, err)
+
+	get := func(regCfg *config.RegistryConfig) http.Header {
+		t.Helper()
+		client, err := newSourceClient(regCfg, 0)
+		require.NoError(t, err)
+		_, err = client.Get(context.Background(), server.URL)
+		require.NoError(t, err)
+		return <-requests
+	}
+
+	headers := get(&cfg.Registries[0])
+	assert.Equal(t, httpclient.DeploymentUserAgent("acme-prod"), headers.Get("User-Agent"))
+	assert.Equal(t, "acme-prod", headers.Get(httpclient.DeploymentIDHeader))
+	assert.Equal(t, "platform-team@example.com", headers.Get("From"))
+
+	headers = get(&cfg.Registries[1])
+	assert.Equal(t, "acme-bridge/2.0", headers.Get("User-Agent"))
+	assert.Empty(t, headers.Get(httpclient.DeploymentIDHeader))
+	assert.Empty(t, headers.Get("From"))
+
+	// Transport headers take precedence over the attribution headers
+	headers = get(&cfg.Registries[2])
+	assert.Equal(t, "mirror-team@example.com", headers.Get("From"))
+	assert.Equal(t, "acme-prod", headers.Get(httpclient.DeploymentIDHeader))
+}
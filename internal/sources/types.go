@@ -10,6 +10,7 @@ import (
 	toolhivetypes "github.com/stacklok/toolhive/pkg/registry/registry"
 
 	"github.com/stacklok/toolhive-registry-server/internal/config"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
 )
 
 // RegistryDataValidator is an interface for validating registry source configurations
@@ -30,6 +31,10 @@ type RegistryHandler interface {
 
 	// CurrentHash returns the current hash of the source data without performing a full fetch
 	CurrentHash(ctx context.Context, regCfg *config.RegistryConfig) (string, error)
+
+	// Check verifies that the source is reachable and, for sources read as a single document,
+	// that its registry data parses. Nothing is stored.
+	Check(ctx context.Context, regCfg *config.RegistryConfig) error
 }
 
 // FetchResult contains the result of a fetch operation
@@ -45,6 +50,10 @@ type FetchResult struct {
 
 	// Format indicates the original format of the source data
 	Format string
+
+	// PushedDownFilters names the filters of the registry that the source applied when fetching
+	// the data (e.g. "names.include"), if it supports any
+	PushedDownFilters []string
 }
 
 // NewFetchResult creates a new FetchResult from a UpstreamRegistry instance and pre-calculated hash
@@ -80,20 +89,37 @@ func NewRegistryDataValidator() RegistryDataValidator {
 	return &defaultRegistryDataValidator{}
 }
 
-// ValidateData validates raw data and returns a parsed UpstreamRegistry
+// ValidateData validates raw data and returns a parsed UpstreamRegistry.
+// The metadata of the servers owned by the registry is dropped from the parsed data;
+// the status of ToolHive registry entries is recorded as their lifecycle status instead.
 func (*defaultRegistryDataValidator) ValidateData(data []byte, format string) (*toolhivetypes.UpstreamRegistry, error) {
 	if len(data) == 0 {
 		return nil, fmt.Errorf("data cannot be empty")
 	}
 
+	var reg *toolhivetypes.UpstreamRegistry
+	var err error
 	switch format {
 	case config.SourceFormatToolHive:
-		return validateToolhiveFormatAndParse(data)
+		reg, err = validateToolhiveFormatAndParse(data)
 	case config.SourceFormatUpstream:
-		return validateUpstreamFormatAndParse(data)
+		reg, err = validateUpstreamFormatAndParse(data)
 	default:
 		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range reg.Data.Servers {
+		server := &reg.Data.Servers[i]
+		registry.StripRegistryMeta(server)
+		// Entries of ToolHive registries are curated by the registry operator, so their status is kept
+		if format == config.SourceFormatToolHive {
+			registry.SetLifecycleStatus(server, registry.ExtractStatus(server))
+		}
+	}
+	return reg, nil
 }
 
 // validateToolhiveFormatAndParse validates data against ToolHive registry format and returns parsed UpstreamRegistry
@@ -0,0 +1,102 @@
+package summary
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxSentences is the number of sentences kept by extractive summaries
+	MaxSentences = 3
+	// MaxLength caps the length of summaries, in bytes
+	MaxLength = 400
+)
+
+var (
+	// markdownImage matches Markdown images, including badges
+	markdownImage = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	// markdownLink matches Markdown links, whose text is kept
+	markdownLink = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	// htmlTag matches inline HTML tags
+	htmlTag = regexp.MustCompile(`<[^>]+>`)
+	// sentenceEnd matches the end of a sentence
+	sentenceEnd = regexp.MustCompile(`[.!?]["')\]]?(\s|$)`)
+)
+
+// Extractive summarizes texts by keeping their leading sentences
+type Extractive struct{}
+
+// Summarize returns the extractive summary of a text. It never fails.
+func (Extractive) Summarize(_ context.Context, text string) (string, error) {
+	return Extract(text), nil
+}
+
+// Extract returns the first MaxSentences sentences of the prose of a Markdown text, within
+// MaxLength bytes. Headings, code blocks, tables, images and HTML are left out, and links are
+// replaced with their text.
+func Extract(text string) string {
+	prose := plainText(text)
+
+	var summary string
+	for range MaxSentences {
+		if prose == "" {
+			break
+		}
+		sentence := prose
+		rest := ""
+		if loc := sentenceEnd.FindStringIndex(prose); loc != nil {
+			sentence, rest = prose[:loc[1]], prose[loc[1]:]
+		}
+		sentence = strings.TrimSpace(sentence)
+		if summary != "" && len(summary)+1+len(sentence) > MaxLength {
+			break
+		}
+		summary = strings.TrimSpace(summary + " " + sentence)
+		prose = strings.TrimSpace(rest)
+	}
+	return clip(summary, MaxLength)
+}
+
+// plainText returns the prose of a Markdown text on a single line
+func plainText(text string) string {
+	var lines []string
+	inCode := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			inCode = !inCode
+			continue
+		}
+		if inCode || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "|") ||
+			strings.HasPrefix(line, ">") || strings.Trim(line, "-*_= ") == "" {
+			continue
+		}
+		line = markdownImage.ReplaceAllString(line, "")
+		line = markdownLink.ReplaceAllString(line, "$1")
+		line = htmlTag.ReplaceAllString(line, "")
+		line = strings.NewReplacer("**", "", "__", "", "`", "").Replace(line)
+		line = strings.TrimLeft(line, "-*+ ")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+}
+
+// clip cuts a text to maxLength bytes at a word boundary, marking the cut with an ellipsis
+func clip(text string, maxLength int) string {
+	if len(text) <= maxLength {
+		return text
+	}
+	cut := maxLength - len("…")
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	clipped := text[:cut]
+	if i := strings.LastIndexByte(clipped, ' '); i > 0 {
+		clipped = clipped[:i]
+	}
+	return strings.TrimRight(clipped, " ,;:") + "…"
+}
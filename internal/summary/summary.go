@@ -0,0 +1,184 @@
+// Package summary compresses long server descriptions into summaries of a few sentences.
+//
+// Summaries are written by a Summarizer: an LLM endpoint when one is configured, and otherwise
+// the extractive summarizer, which keeps the leading sentences of a description once Markdown
+// headings, badges and code blocks are stripped. Summaries are cached by description, and the
+// extractive summary stands in whenever the LLM endpoint fails or has not answered yet.
+package summary
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"sync"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+	"github.com/stacklok/toolhive-registry-server/internal/cache"
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+const (
+	// MetaKey is the publisher-provided metadata key under which the summary of a long description
+	// is recorded in API responses
+	MetaKey = "io.github.stacklok/summary"
+
+	// maxCacheEntries caps the number of cached summaries
+	maxCacheEntries = 4096
+	// maxPending caps the number of summaries written in the background at once
+	maxPending = 4
+)
+
+// Summarizer summarizes a text into a few sentences
+type Summarizer interface {
+	Summarize(ctx context.Context, text string) (string, error)
+}
+
+// Summaries summarizes long descriptions and caches their summaries.
+// It is safe for concurrent use.
+type Summaries struct {
+	minLength  int
+	summarizer Summarizer
+	cacheOpts  []cache.Option
+	cache      *cache.LRU[string, string]
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+}
+
+// Option configures Summaries
+type Option func(*Summaries)
+
+// WithSummarizer sets the summarizer writing summaries, e.g. an LLM.
+// Without it, summaries are extracted from the descriptions.
+func WithSummarizer(summarizer Summarizer) Option {
+	return func(s *Summaries) {
+		s.summarizer = summarizer
+	}
+}
+
+// WithCache bounds the memory held by cached summaries, e.g. with cache.WithBudget.
+// The cache holds at most 4096 entries regardless of the options.
+func WithCache(opts ...cache.Option) Option {
+	return func(s *Summaries) {
+		s.cacheOpts = append(s.cacheOpts, opts...)
+	}
+}
+
+// New creates summaries of the descriptions longer than the configured minimum length
+func New(cfg *config.SummariesConfig, opts ...Option) *Summaries {
+	s := &Summaries{
+		minLength: cfg.GetMinLength(),
+		pending:   make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.cache = cache.New[string, string]("summaries", append(s.cacheOpts, cache.WithMaxEntries(maxCacheEntries))...)
+	return s
+}
+
+// Summarize returns the summary of a description, writing it if it is not cached yet. It returns an
+// empty string for descriptions short enough to be used as is. The extractive summary is returned
+// if the summarizer fails.
+func (s *Summaries) Summarize(ctx context.Context, description string) string {
+	if len(description) <= s.minLength {
+		return ""
+	}
+	key := cacheKey(description)
+	if summary, ok := s.cache.Get(key); ok {
+		return summary
+	}
+	if s.summarizer == nil {
+		return s.extract(key, description)
+	}
+
+	summary, err := s.summarizer.Summarize(ctx, description)
+	if err != nil || summary == "" {
+		slog.WarnContext(ctx, "Failed to summarize description, extracting summary instead", "error", err)
+		return Extract(description)
+	}
+	s.cache.Add(key, summary, int64(len(key)+len(summary)))
+	return summary
+}
+
+// Lookup returns the summary of a description without waiting for the summarizer: the cached
+// summary if there is one, and otherwise the extractive summary while the summary is written in
+// the background for later lookups. It returns an empty string for descriptions short enough to
+// be used as is.
+func (s *Summaries) Lookup(ctx context.Context, description string) string {
+	if len(description) <= s.minLength {
+		return ""
+	}
+	key := cacheKey(description)
+	if summary, ok := s.cache.Get(key); ok {
+		return summary
+	}
+	if s.summarizer == nil {
+		return s.extract(key, description)
+	}
+
+	s.schedule(context.WithoutCancel(ctx), key, description)
+	return Extract(description)
+}
+
+// Annotate returns a copy of the server recording the summary of its description under MetaKey,
+// if the description is long enough to be summarized. The server itself is left unmodified.
+func (s *Summaries) Annotate(ctx context.Context, server upstreamv0.ServerJSON) upstreamv0.ServerJSON {
+	summary := s.Lookup(ctx, server.Description)
+	if summary == "" {
+		return server
+	}
+
+	meta := upstreamv0.ServerMeta{}
+	if server.Meta != nil {
+		meta = *server.Meta
+	}
+	publisherProvided := make(map[string]interface{}, len(meta.PublisherProvided)+1)
+	for key, value := range meta.PublisherProvided {
+		publisherProvided[key] = value
+	}
+	publisherProvided[MetaKey] = map[string]interface{}{"text": summary}
+	meta.PublisherProvided = publisherProvided
+	server.Meta = &meta
+	return server
+}
+
+// extract returns the extractive summary of a description and caches it
+func (s *Summaries) extract(key, description string) string {
+	summary := Extract(description)
+	s.cache.Add(key, summary, int64(len(key)+len(summary)))
+	return summary
+}
+
+// schedule writes the summary of a description in the background, unless it is already being
+// written or too many summaries are. Summaries that are not scheduled are retried on later lookups.
+func (s *Summaries) schedule(ctx context.Context, key, description string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pending[key]; ok || len(s.pending) >= maxPending {
+		return
+	}
+	s.pending[key] = struct{}{}
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.pending, key)
+			s.mu.Unlock()
+		}()
+		summary, err := s.summarizer.Summarize(ctx, description)
+		if err != nil || summary == "" {
+			slog.WarnContext(ctx, "Failed to summarize description", "error", err)
+			return
+		}
+		s.cache.Add(key, summary, int64(len(key)+len(summary)))
+	}()
+}
+
+// cacheKey returns the cache key of a description
+func cacheKey(description string) string {
+	sum := sha256.Sum256([]byte(description))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,187 @@
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/config"
+)
+
+// longDescription is a description long enough to be summarized
+var longDescription = "Connects agents to Slack. " + strings.Repeat("Messages can be searched and posted. ", 10)
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "leading sentences",
+			text: "First sentence. Second one! Third? Fourth.",
+			want: "First sentence. Second one! Third?",
+		},
+		{
+			name: "markdown",
+			text: "# Slack MCP\n\n[![CI](https://x/badge.svg)](https://x)\n\n" +
+				"Connects **agents** to [Slack](https://slack.com).\n\n```sh\nnpx slack-mcp\n```\n\n" +
+				"| a | b |\n\n- Posts `messages`.\n",
+			want: "Connects agents to Slack. Posts messages.",
+		},
+		{
+			name: "paths stay in their sentence",
+			text: "Reads ~/.config files. Then stops.",
+			want: "Reads ~/.config files. Then stops.",
+		},
+		{
+			name: "no sentence end",
+			text: "A description without punctuation",
+			want: "A description without punctuation",
+		},
+		{
+			name: "long sentence is clipped",
+			text: strings.Repeat("word ", 200),
+			want: strings.TrimSpace(strings.Repeat("word ", 79)) + "…",
+		},
+		{
+			name: "empty",
+			text: "## Heading only",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Extract(tt.text)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), MaxLength)
+		})
+	}
+}
+
+// newLLMServer starts an OpenAI-compatible endpoint answering with the given summary, or failing
+// with the given status
+func newLLMServer(t *testing.T, summary string, status int, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+		var body chatRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, "summarizer", body.Model)
+		assert.Len(t, body.Messages, 2)
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []any{map[string]any{"message": map[string]any{"role": "assistant", "content": summary}}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestLLM(url string) *LLM {
+	llm := NewLLM(&config.SummariesLLMConfig{URL: url, Model: "summarizer"})
+	llm.apiKey = "secret"
+	return llm
+}
+
+func TestLLM(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+
+	ok := newLLMServer(t, "  Connects agents\nto Slack.  ", http.StatusOK, &calls)
+	summary, err := newTestLLM(ok.URL).Summarize(context.Background(), longDescription)
+	require.NoError(t, err)
+	assert.Equal(t, "Connects agents to Slack.", summary)
+
+	empty := newLLMServer(t, "", http.StatusOK, &calls)
+	_, err = newTestLLM(empty.URL).Summarize(context.Background(), longDescription)
+	require.ErrorContains(t, err, "empty summary")
+
+	failing := newLLMServer(t, "", http.StatusTooManyRequests, &calls)
+	_, err = newTestLLM(failing.URL).Summarize(context.Background(), longDescription)
+	require.ErrorContains(t, err, "HTTP 429")
+}
+
+// summarizerFunc adapts a function to the Summarizer interface
+type summarizerFunc func(ctx context.Context, text string) (string, error)
+
+func (f summarizerFunc) Summarize(ctx context.Context, text string) (string, error) {
+	return f(ctx, text)
+}
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	summaries := New(&config.SummariesConfig{}, WithSummarizer(summarizerFunc(func(context.Context, string) (string, error) {
+		if calls.Add(1) == 1 {
+			return "", errors.New("unavailable")
+		}
+		return "Connects agents to Slack.", nil
+	})))
+
+	assert.Empty(t, summaries.Summarize(context.Background(), "Connects agents to Slack."), "short descriptions are kept")
+
+	// Failures fall back to the extractive summary, which is not cached
+	assert.Equal(t, Extract(longDescription), summaries.Summarize(context.Background(), longDescription))
+	assert.Equal(t, "Connects agents to Slack.", summaries.Summarize(context.Background(), longDescription))
+	assert.Equal(t, "Connects agents to Slack.", summaries.Summarize(context.Background(), longDescription))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	llm := newLLMServer(t, "Connects agents to Slack.", http.StatusOK, &calls)
+	summaries := New(&config.SummariesConfig{MinLength: 100}, WithSummarizer(newTestLLM(llm.URL)))
+
+	// The extractive summary stands in until the LLM answers
+	assert.Equal(t, Extract(longDescription), summaries.Lookup(context.Background(), longDescription))
+	require.Eventually(t, func() bool {
+		return summaries.Lookup(context.Background(), longDescription) == "Connects agents to Slack."
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Without summarizer, summaries are extracted
+	extractive := New(&config.SummariesConfig{MinLength: 100})
+	assert.Equal(t, Extract(longDescription), extractive.Lookup(context.Background(), longDescription))
+	assert.Empty(t, extractive.Lookup(context.Background(), "Connects agents to Slack."))
+}
+
+func TestAnnotate(t *testing.T) {
+	t.Parallel()
+	summaries := New(&config.SummariesConfig{})
+
+	server := upstreamv0.ServerJSON{
+		Name:        "io.example/slack",
+		Description: longDescription,
+		Meta: &upstreamv0.ServerMeta{PublisherProvided: map[string]interface{}{
+			"io.github.stacklok/health": "ok",
+		}},
+	}
+	annotated := summaries.Annotate(context.Background(), server)
+	assert.Equal(t, map[string]interface{}{
+		"io.github.stacklok/health": "ok",
+		MetaKey:                     map[string]interface{}{"text": Extract(longDescription)},
+	}, annotated.Meta.PublisherProvided)
+	assert.NotContains(t, server.Meta.PublisherProvided, MetaKey, "the server is left unmodified")
+
+	server.Description = "Connects agents to Slack."
+	assert.Equal(t, server, summaries.Annotate(context.Background(), server))
+}
@@ -0,0 +1,197 @@
+// Package supervisor runs the background workers of the registry server (sync, telemetry, ...)
+// for the lifetime of the server, restarting crashed workers with exponential backoff and
+// keeping track of their status for health reporting.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// StateRunning is the state of a worker that is running
+	StateRunning = "running"
+	// StateRestarting is the state of a worker waiting to be restarted after a crash
+	StateRestarting = "restarting"
+	// StateStopped is the state of a worker that returned without error, or was stopped with the server
+	StateStopped = "stopped"
+	// StateFailed is the state of a one-shot worker that crashed
+	StateFailed = "failed"
+
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = time.Minute
+)
+
+// Worker is a background worker run by a Supervisor
+type Worker struct {
+	// Name identifies the worker in logs and status reports
+	Name string
+	// Run runs the worker until ctx is cancelled. A worker returning an error or panicking
+	// before ctx is cancelled is considered crashed.
+	Run func(ctx context.Context) error
+	// OneShot workers are not restarted once they return, their crash is only reported
+	OneShot bool
+}
+
+// WorkerStatus is the status of a worker
+type WorkerStatus struct {
+	Name string `json:"name"`
+	// State is one of StateRunning, StateRestarting, StateStopped or StateFailed
+	State string `json:"state"`
+	// Restarts is the number of times the worker was restarted after a crash
+	Restarts int `json:"restarts"`
+	// LastError is the error of the last crash, if any
+	LastError string `json:"last_error,omitempty"`
+	// StartedAt is when the worker was last started
+	StartedAt time.Time `json:"started_at,omitzero"`
+}
+
+// Healthy reports whether the worker is running or stopped normally
+func (s WorkerStatus) Healthy() bool {
+	return s.State == StateRunning || s.State == StateStopped
+}
+
+// Option configures a Supervisor
+type Option func(*Supervisor)
+
+// WithBackoff sets the delay before the first restart of a crashed worker, doubled at each
+// consecutive crash up to maxBackoff. A worker that ran for at least maxBackoff before crashing
+// is restarted after the initial delay again.
+func WithBackoff(initial, maxBackoff time.Duration) Option {
+	return func(s *Supervisor) {
+		s.initialBackoff = initial
+		s.maxBackoff = maxBackoff
+	}
+}
+
+// Supervisor runs background workers. Workers are added before Run is called.
+// It is safe for concurrent use.
+type Supervisor struct {
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	mu       sync.RWMutex
+	workers  []Worker
+	statuses []WorkerStatus
+	now      func() time.Time
+}
+
+// New creates a supervisor without workers
+func New(opts ...Option) *Supervisor {
+	s := &Supervisor{
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		now:            time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Add adds a worker, to be started when Run is called
+func (s *Supervisor) Add(worker Worker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workers = append(s.workers, worker)
+	s.statuses = append(s.statuses, WorkerStatus{Name: worker.Name, State: StateStopped})
+}
+
+// Run runs all workers until ctx is cancelled, then waits for them to return.
+// It is called once.
+func (s *Supervisor) Run(ctx context.Context) error {
+	s.mu.RLock()
+	workers := s.workers
+	s.mu.RUnlock()
+
+	g, ctx := errgroup.WithContext(ctx)
+	for i, worker := range workers {
+		g.Go(func() error {
+			s.supervise(ctx, i, worker)
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// Status returns the status of all workers, in the order they were added
+func (s *Supervisor) Status() []WorkerStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	statuses := make([]WorkerStatus, len(s.statuses))
+	copy(statuses, s.statuses)
+	return statuses
+}
+
+// supervise runs a worker, restarting it after crashes until ctx is cancelled
+func (s *Supervisor) supervise(ctx context.Context, index int, worker Worker) {
+	backoff := s.initialBackoff
+	for {
+		startedAt := s.now()
+		s.update(index, func(status *WorkerStatus) {
+			status.State = StateRunning
+			status.StartedAt = startedAt
+		})
+
+		err := runWorker(ctx, worker)
+		if ctx.Err() != nil || (err == nil && worker.OneShot) {
+			s.update(index, func(status *WorkerStatus) { status.State = StateStopped })
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("worker returned before shutdown")
+		}
+
+		if worker.OneShot {
+			slog.Error("Background worker failed", "worker", worker.Name, "error", err)
+			s.update(index, func(status *WorkerStatus) {
+				status.State = StateFailed
+				status.LastError = err.Error()
+			})
+			return
+		}
+
+		if s.now().Sub(startedAt) >= s.maxBackoff {
+			backoff = s.initialBackoff
+		}
+		slog.Error("Background worker crashed, restarting",
+			"worker", worker.Name,
+			"error", err,
+			"backoff", backoff)
+		s.update(index, func(status *WorkerStatus) {
+			status.State = StateRestarting
+			status.LastError = err.Error()
+		})
+
+		select {
+		case <-ctx.Done():
+			s.update(index, func(status *WorkerStatus) { status.State = StateStopped })
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, s.maxBackoff)
+		s.update(index, func(status *WorkerStatus) { status.Restarts++ })
+	}
+}
+
+// update updates the status of a worker
+func (s *Supervisor) update(index int, fn func(*WorkerStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.statuses[index])
+}
+
+// runWorker runs a worker once, turning a panic into an error
+func runWorker(ctx context.Context, worker Worker) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("worker panicked: %v", r)
+		}
+	}()
+	return worker.Run(ctx)
+}
@@ -0,0 +1,134 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupervisor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		run   func(runs int32) error
+		once  bool
+		check func(t *testing.T, status WorkerStatus, runs int32)
+	}{
+		{
+			name: "crashed worker is restarted",
+			run: func(runs int32) error {
+				if runs < 3 {
+					return errors.New("boom")
+				}
+				return nil
+			},
+			check: func(t *testing.T, status WorkerStatus, runs int32) {
+				t.Helper()
+				assert.GreaterOrEqual(t, runs, int32(3))
+				assert.GreaterOrEqual(t, status.Restarts, 2)
+				assert.NotEmpty(t, status.LastError)
+			},
+		},
+		{
+			name: "panicking worker is restarted",
+			run: func(runs int32) error {
+				if runs == 1 {
+					panic("boom")
+				}
+				return nil
+			},
+			check: func(t *testing.T, status WorkerStatus, runs int32) {
+				t.Helper()
+				assert.GreaterOrEqual(t, runs, int32(2))
+				assert.Contains(t, status.LastError, "worker panicked: boom")
+			},
+		},
+		{
+			name: "crashed one-shot worker is reported failed",
+			run:  func(int32) error { return errors.New("boom") },
+			once: true,
+			check: func(t *testing.T, status WorkerStatus, runs int32) {
+				t.Helper()
+				assert.Equal(t, int32(1), runs)
+				assert.Equal(t, StateFailed, status.State)
+				assert.Equal(t, "boom", status.LastError)
+				assert.False(t, status.Healthy())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var runs atomic.Int32
+			s := New(WithBackoff(time.Millisecond, 5*time.Millisecond))
+			s.Add(Worker{
+				Name:    "worker",
+				OneShot: tt.once,
+				Run: func(ctx context.Context) error {
+					if err := tt.run(runs.Add(1)); err != nil {
+						return err
+					}
+					<-ctx.Done()
+					return nil
+				},
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error)
+			go func() { done <- s.Run(ctx) }()
+
+			require.Eventually(t, func() bool {
+				state := s.Status()[0].State
+				return state == StateFailed || (state == StateRunning && runs.Load() > 1)
+			}, time.Second, time.Millisecond)
+			tt.check(t, s.Status()[0], runs.Load())
+
+			cancel()
+			require.NoError(t, <-done)
+			if !tt.once {
+				assert.Equal(t, StateStopped, s.Status()[0].State)
+			}
+		})
+	}
+}
+
+func TestSupervisorStopsWorkers(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	for _, name := range []string{"sync", "telemetry"} {
+		s.Add(Worker{Name: name, Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		for _, status := range s.Status() {
+			if status.State != StateRunning || !status.Healthy() {
+				return false
+			}
+		}
+		return true
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	for _, status := range s.Status() {
+		assert.Equal(t, StateStopped, status.State, status.Name)
+		assert.Zero(t, status.Restarts, status.Name)
+		assert.Empty(t, status.LastError, status.Name)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
@@ -237,6 +238,10 @@ func (c *defaultCoordinator) checkRegistrySync(ctx context.Context, regCfg *conf
 	} else {
 		syncStatus.Phase = status.SyncPhaseComplete
 		syncStatus.Message = "Sync completed successfully"
+		if len(result.PushedDownFilters) > 0 {
+			syncStatus.Message = fmt.Sprintf("Sync completed successfully, filters applied by the source: %s",
+				strings.Join(result.PushedDownFilters, ", "))
+		}
 		syncStatus.LastSyncTime = &now
 		syncStatus.LastSyncHash = result.Hash
 		syncStatus.ServerCount = result.ServerCount
@@ -248,6 +253,7 @@ func (c *defaultCoordinator) checkRegistrySync(ctx context.Context, regCfg *conf
 		slog.Info("Sync completed successfully",
 			"registry", registryName,
 			"server_count", result.ServerCount,
+			"pushed_down_filters", result.PushedDownFilters,
 			"hash", hashPreview)
 	}
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stacklok/toolhive-registry-server/internal/config"
 	"github.com/stacklok/toolhive-registry-server/internal/filtering"
+	"github.com/stacklok/toolhive-registry-server/internal/registry"
 	"github.com/stacklok/toolhive-registry-server/internal/sources"
 	"github.com/stacklok/toolhive-registry-server/internal/status"
 	"github.com/stacklok/toolhive-registry-server/internal/sync/writer"
@@ -20,6 +21,9 @@ import (
 type Result struct {
 	Hash        string
 	ServerCount int
+
+	// PushedDownFilters names the filters applied by the source when fetching the data
+	PushedDownFilters []string
 }
 
 // Reason represents the decision and reason for whether a sync should occur
@@ -299,8 +303,9 @@ func (s *defaultSyncManager) PerformSync(
 
 	// Return sync result with data for status collector
 	syncResult := &Result{
-		Hash:        fetchResult.Hash,
-		ServerCount: fetchResult.ServerCount,
+		Hash:              fetchResult.Hash,
+		ServerCount:       fetchResult.ServerCount,
+		PushedDownFilters: fetchResult.PushedDownFilters,
 	}
 
 	return syncResult, nil
@@ -356,6 +361,9 @@ func (s *defaultSyncManager) fetchAndProcessRegistryData(
 		return nil, err
 	}
 
+	// Apply sanitization if configured
+	applySanitizationIfConfigured(regCfg, fetchResult)
+
 	return fetchResult, nil
 }
 
@@ -367,7 +375,8 @@ func (s *defaultSyncManager) applyFilteringIfConfigured(
 	if regCfg.Filter != nil {
 		slog.Info("Applying registry filters",
 			"hasNameFilters", regCfg.Filter.Names != nil,
-			"hasTagFilters", regCfg.Filter.Tags != nil)
+			"hasTagFilters", regCfg.Filter.Tags != nil,
+			"pushedDownFilters", fetchResult.PushedDownFilters)
 
 		// Apply filtering to UpstreamRegistry
 		filteredServerReg, err := s.filterService.ApplyFilters(ctx, fetchResult.Registry, regCfg.Filter)
@@ -397,6 +406,33 @@ func (s *defaultSyncManager) applyFilteringIfConfigured(
 	return nil
 }
 
+// applySanitizationIfConfigured sanitizes the titles and descriptions of the fetched servers
+// if the registry has sanitization configured. Altered servers are flagged in their metadata.
+func applySanitizationIfConfigured(regCfg *config.RegistryConfig, fetchResult *sources.FetchResult) {
+	if regCfg.Sanitize == nil || fetchResult.Registry == nil {
+		return
+	}
+
+	rules := regCfg.Sanitize.GetRules()
+	servers := fetchResult.Registry.Data.Servers
+	var sanitized []string
+	for i := range servers {
+		if registry.SanitizeServer(&servers[i], rules) {
+			sanitized = append(sanitized, servers[i].Name)
+		}
+	}
+
+	if len(sanitized) > 0 {
+		slog.Warn("Sanitized server content",
+			"registryName", regCfg.Name,
+			"rules", rules,
+			"sanitizedServerCount", len(sanitized),
+			"sanitizedServers", sanitized)
+	} else {
+		slog.Info("Registry sanitization completed, no content altered", "registryName", regCfg.Name)
+	}
+}
+
 // storeRegistryData stores the registry data using the storage manager
 func (s *defaultSyncManager) storeRegistryData(
 	ctx context.Context,
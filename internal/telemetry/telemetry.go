@@ -0,0 +1,256 @@
+// Package telemetry provides opt-in, anonymized usage reporting.
+//
+// The reporter only keeps aggregate counts: requests per route, hashed search
+// terms and error counts. No request paths, raw search terms, addresses or
+// credentials are ever recorded. Search terms are hashed with an HMAC keyed by
+// a per-deployment secret, so that the report receiver cannot recover common
+// terms by hashing a dictionary. Reports are periodically POSTed as JSON to
+// the configured endpoint, after which the counters are reset.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/stacklok/toolhive-registry-server/internal/versions"
+)
+
+const (
+	// DefaultMaxSearchTerms is the default number of distinct hashed search terms kept per report
+	DefaultMaxSearchTerms = 1000
+
+	// unmatchedRoute is the route reported for requests that matched no route
+	unmatchedRoute = "unmatched"
+
+	// searchTermHashLength is the number of hex characters kept from search term hashes
+	searchTermHashLength = 16
+
+	defaultSendTimeout = 10 * time.Second
+)
+
+// Report is an anonymized usage report covering a reporting window
+type Report struct {
+	WindowStart   time.Time `json:"windowStart"`
+	WindowEnd     time.Time `json:"windowEnd"`
+	ServerVersion string    `json:"serverVersion"`
+	// Requests counts requests per route pattern (e.g. "GET /registry/v0.1/servers")
+	Requests map[string]int64 `json:"requests"`
+	// Errors counts requests answered with a 4xx or 5xx status per route pattern
+	Errors        map[string]int64 `json:"errors"`
+	TotalRequests int64            `json:"totalRequests"`
+	ClientErrors  int64            `json:"clientErrors"`
+	ServerErrors  int64            `json:"serverErrors"`
+	// SearchTerms counts searches per truncated HMAC-SHA256 of the normalized search term
+	SearchTerms map[string]int64 `json:"searchTerms"`
+}
+
+// ErrorRate returns the share of requests answered with a 4xx or 5xx status
+func (r *Report) ErrorRate() float64 {
+	if r.TotalRequests == 0 {
+		return 0
+	}
+	return float64(r.ClientErrors+r.ServerErrors) / float64(r.TotalRequests)
+}
+
+// Reporter aggregates anonymized usage counts and periodically sends them to an endpoint.
+// It is safe for concurrent use.
+type Reporter struct {
+	endpoint       string
+	interval       time.Duration
+	client         *http.Client
+	hashKey        []byte
+	maxSearchTerms int
+	now            func() time.Time
+
+	mu     sync.Mutex
+	report *Report
+}
+
+// Option configures a Reporter
+type Option func(*Reporter)
+
+// WithHTTPClient sets the HTTP client used to send reports
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *Reporter) {
+		r.client = client
+	}
+}
+
+// WithMaxSearchTerms sets the number of distinct hashed search terms kept per report.
+// Searches for further terms are still counted as requests.
+func WithMaxSearchTerms(maxSearchTerms int) Option {
+	return func(r *Reporter) {
+		if maxSearchTerms > 0 {
+			r.maxSearchTerms = maxSearchTerms
+		}
+	}
+}
+
+// NewReporter creates a Reporter sending a report to endpoint every interval.
+// Search terms are hashed with hashKey, which must be kept private to the deployment.
+func NewReporter(endpoint string, interval time.Duration, hashKey []byte, opts ...Option) (*Reporter, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("telemetry endpoint is required")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("telemetry interval must be positive")
+	}
+	if len(hashKey) == 0 {
+		return nil, fmt.Errorf("telemetry hash key is required")
+	}
+
+	r := &Reporter{
+		endpoint:       endpoint,
+		interval:       interval,
+		client:         &http.Client{Timeout: defaultSendTimeout},
+		hashKey:        hashKey,
+		maxSearchTerms: DefaultMaxSearchTerms,
+		now:            time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.report = r.newReport()
+	return r, nil
+}
+
+// Middleware records every request handled by next
+func (r *Reporter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, req.ProtoMajor)
+
+		next.ServeHTTP(ww, req)
+
+		// The route pattern is only known once the router has matched the request
+		route := unmatchedRoute
+		if rctx := chi.RouteContext(req.Context()); rctx != nil {
+			if pattern := rctx.RoutePattern(); pattern != "" {
+				route = req.Method + " " + pattern
+			}
+		}
+		r.Record(route, ww.Status(), req.URL.Query().Get("search"))
+	})
+}
+
+// Record records a request to route answered with status, along with its search term if any
+func (r *Reporter) Record(route string, status int, search string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.report.TotalRequests++
+	r.report.Requests[route]++
+	switch {
+	case status >= http.StatusInternalServerError:
+		r.report.ServerErrors++
+		r.report.Errors[route]++
+	case status >= http.StatusBadRequest:
+		r.report.ClientErrors++
+		r.report.Errors[route]++
+	}
+
+	if hash := HashSearchTerm(r.hashKey, search); hash != "" {
+		if _, ok := r.report.SearchTerms[hash]; ok || len(r.report.SearchTerms) < r.maxSearchTerms {
+			r.report.SearchTerms[hash]++
+		}
+	}
+}
+
+// Snapshot returns the current report and starts a new reporting window
+func (r *Reporter) Snapshot() *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := r.report
+	report.WindowEnd = r.now().UTC()
+	r.report = r.newReport()
+	return report
+}
+
+// Flush sends the current report to the endpoint and starts a new reporting window.
+// Windows without requests are not sent. A report that fails to send is dropped.
+func (r *Reporter) Flush(ctx context.Context) error {
+	report := r.Snapshot()
+	if report.TotalRequests == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal telemetry report: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create telemetry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send telemetry report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Run sends a report every interval until ctx is cancelled
+func (r *Reporter) Run(ctx context.Context) {
+	slog.Info("Anonymized usage telemetry enabled",
+		"endpoint", r.endpoint,
+		"interval", r.interval)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				slog.Warn("Failed to send telemetry report", "error", err)
+			}
+		}
+	}
+}
+
+// newReport creates an empty report whose window starts now
+func (r *Reporter) newReport() *Report {
+	return &Report{
+		WindowStart:   r.now().UTC(),
+		ServerVersion: versions.Version,
+		Requests:      make(map[string]int64),
+		Errors:        make(map[string]int64),
+		SearchTerms:   make(map[string]int64),
+	}
+}
+
+// HashSearchTerm returns the truncated HMAC-SHA256 of a normalized search term keyed by key,
+// or an empty string for empty terms. Without the key, the hashes can't be matched against a
+// dictionary of likely terms.
+func HashSearchTerm(key []byte, term string) string {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(term))
+	return hex.EncodeToString(mac.Sum(nil))[:searchTermHashLength]
+}
@@ -0,0 +1,182 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testHashKey = []byte("test-hash-key")
+
+func TestNewReporter(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewReporter("", time.Hour, testHashKey)
+	assert.ErrorContains(t, err, "telemetry endpoint is required")
+
+	_, err = NewReporter("https://telemetry.example.com", 0, testHashKey)
+	assert.ErrorContains(t, err, "telemetry interval must be positive")
+
+	_, err = NewReporter("https://telemetry.example.com", time.Hour, nil)
+	assert.ErrorContains(t, err, "telemetry hash key is required")
+
+	reporter, err := NewReporter("https://telemetry.example.com", time.Hour, testHashKey)
+	require.NoError(t, err)
+	assert.NotNil(t, reporter)
+}
+
+func TestHashSearchTerm(t *testing.T) {
+	t.Parallel()
+
+	hash := HashSearchTerm(testHashKey, "GitHub")
+	assert.Len(t, hash, searchTermHashLength)
+	assert.Equal(t, hash, HashSearchTerm(testHashKey, "  github "))
+	assert.NotEqual(t, hash, HashSearchTerm(testHashKey, "gitlab"))
+	assert.NotContains(t, hash, "github")
+	// Without the deployment key, the hash of a known term can't be reproduced
+	assert.NotEqual(t, hash, HashSearchTerm([]byte("other-key"), "github"))
+	sum := sha256.Sum256([]byte("github"))
+	assert.NotEqual(t, hex.EncodeToString(sum[:])[:searchTermHashLength], hash)
+	assert.Empty(t, HashSearchTerm(testHashKey, "   "))
+}
+
+func TestReporterMiddleware(t *testing.T) {
+	t.Parallel()
+
+	reporter, err := NewReporter("https://telemetry.example.com", time.Hour, testHashKey)
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	router.Use(reporter.Middleware)
+	router.Get("/servers", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router.Get("/servers/{name}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	for _, target := range []string{
+		"/servers?search=GitHub",
+		"/servers?search=github",
+		"/servers",
+		"/servers/io.test%2Fsecret-name",
+		"/unknown",
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	report := reporter.Snapshot()
+	assert.Equal(t, int64(5), report.TotalRequests)
+	assert.Equal(t, map[string]int64{
+		"GET /servers":        3,
+		"GET /servers/{name}": 1,
+		unmatchedRoute:        1,
+	}, report.Requests)
+	assert.Equal(t, map[string]int64{
+		"GET /servers/{name}": 1,
+		unmatchedRoute:        1,
+	}, report.Errors)
+	assert.Equal(t, int64(1), report.ClientErrors)
+	assert.Equal(t, int64(1), report.ServerErrors)
+	assert.InDelta(t, 0.4, report.ErrorRate(), 0.0001)
+	assert.Equal(t, map[string]int64{HashSearchTerm(testHashKey, "github"): 2}, report.SearchTerms)
+
+	// The snapshot starts a new window
+	assert.Zero(t, reporter.Snapshot().TotalRequests)
+}
+
+func TestReporterMaxSearchTerms(t *testing.T) {
+	t.Parallel()
+
+	reporter, err := NewReporter("https://telemetry.example.com", time.Hour, testHashKey, WithMaxSearchTerms(1))
+	require.NoError(t, err)
+
+	reporter.Record("GET /servers", http.StatusOK, "first")
+	reporter.Record("GET /servers", http.StatusOK, "second")
+	reporter.Record("GET /servers", http.StatusOK, "first")
+
+	report := reporter.Snapshot()
+	assert.Equal(t, int64(3), report.TotalRequests)
+	assert.Equal(t, map[string]int64{HashSearchTerm(testHashKey, "first"): 2}, report.SearchTerms)
+}
+
+func TestReporterFlush(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		requests  int
+		status    int
+		wantSent  bool
+		wantError string
+	}{
+		{
+			name:     "sends report",
+			requests: 2,
+			status:   http.StatusAccepted,
+			wantSent: true,
+		},
+		{
+			name:     "skips empty window",
+			requests: 0,
+			status:   http.StatusAccepted,
+		},
+		{
+			name:      "endpoint failure",
+			requests:  1,
+			status:    http.StatusServiceUnavailable,
+			wantSent:  true,
+			wantError: "telemetry endpoint returned status 503",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var received *Report
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				received = &Report{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(received))
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			reporter, err := NewReporter(server.URL, time.Hour, testHashKey, WithHTTPClient(server.Client()))
+			require.NoError(t, err)
+			for range tt.requests {
+				reporter.Record("GET /servers", http.StatusOK, "")
+			}
+
+			err = reporter.Flush(context.Background())
+			if tt.wantError != "" {
+				assert.ErrorContains(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if !tt.wantSent {
+				assert.Nil(t, received)
+				return
+			}
+			require.NotNil(t, received)
+			assert.Equal(t, int64(tt.requests), received.TotalRequests)
+			assert.Equal(t, map[string]int64{"GET /servers": int64(tt.requests)}, received.Requests)
+			assert.False(t, received.WindowEnd.Before(received.WindowStart))
+
+			// Reports are dropped once flushed, even if sending failed
+			assert.Zero(t, reporter.Snapshot().TotalRequests)
+		})
+	}
+}
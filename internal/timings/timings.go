@@ -0,0 +1,78 @@
+// Package timings records how long the phases of serving a request take (fetching the registry data,
+// filtering, sorting, serializing), for the requests asking for their timing breakdown.
+package timings
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+)
+
+// Phases of serving a request
+const (
+	// PhaseFetch is the time spent fetching the registry data, from memory or the database
+	PhaseFetch = "fetch"
+	// PhaseFilter is the time spent filtering servers
+	PhaseFilter = "filter"
+	// PhaseSort is the time spent sorting servers
+	PhaseSort = "sort"
+	// PhaseSerialize is the time spent encoding the response
+	PhaseSerialize = "serialize"
+)
+
+// Recorder accumulates the durations of the phases of serving a request
+type Recorder struct {
+	start  time.Time
+	mu     sync.Mutex
+	phases map[string]time.Duration
+}
+
+// recorderKey is the context key of the recorder of a request
+type recorderKey struct{}
+
+// NewContext returns a context carrying a new recorder, started now
+func NewContext(ctx context.Context) (context.Context, *Recorder) {
+	recorder := &Recorder{start: time.Now(), phases: map[string]time.Duration{}}
+	return context.WithValue(ctx, recorderKey{}, recorder), recorder
+}
+
+// FromContext returns the recorder of the context, nil if the timings of the request are not recorded
+func FromContext(ctx context.Context) *Recorder {
+	recorder, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return recorder
+}
+
+// Track starts timing a phase of the request of the context, and returns the function ending it.
+// It does nothing when the timings of the request are not recorded.
+//
+//	defer timings.Track(ctx, timings.PhaseSort)()
+func Track(ctx context.Context, phase string) func() {
+	recorder := FromContext(ctx)
+	if recorder == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		recorder.Add(phase, time.Since(start))
+	}
+}
+
+// Add adds d to the duration of a phase
+func (r *Recorder) Add(phase string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.phases[phase] += d
+}
+
+// Phases returns the durations of the phases recorded so far
+func (r *Recorder) Phases() map[string]time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return maps.Clone(r.phases)
+}
+
+// Elapsed returns the time since the recorder was started
+func (r *Recorder) Elapsed() time.Duration {
+	return time.Since(r.start)
+}
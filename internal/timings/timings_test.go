@@ -0,0 +1,32 @@
+package timings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrack(t *testing.T) {
+	t.Parallel()
+
+	// Tracking without a recorder does nothing
+	Track(context.Background(), PhaseSort)()
+	assert.Nil(t, FromContext(context.Background()))
+
+	ctx, recorder := NewContext(context.Background())
+	require.Same(t, recorder, FromContext(ctx))
+
+	done := Track(ctx, PhaseFilter)
+	time.Sleep(5 * time.Millisecond)
+	done()
+	recorder.Add(PhaseFilter, time.Millisecond)
+	recorder.Add(PhaseSort, 2*time.Millisecond)
+
+	phases := recorder.Phases()
+	assert.GreaterOrEqual(t, phases[PhaseFilter], 6*time.Millisecond)
+	assert.Equal(t, 2*time.Millisecond, phases[PhaseSort])
+	assert.GreaterOrEqual(t, recorder.Elapsed(), 5*time.Millisecond)
+}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// DefaultCacheTTL is the default time responses are cached for
+const DefaultCacheTTL = 5 * time.Minute
+
+// CachingRegistry wraps a Registry and caches successful responses for a fixed TTL.
+// Errors are never cached. It is safe for concurrent use.
+type CachingRegistry struct {
+	registry Registry
+	ttl      time.Duration
+	store    CacheStore
+}
+
+// CacheStore holds the responses cached by a CachingRegistry. Implementations must be safe
+// for concurrent use and may decline to store a value, e.g. to stay within a memory bound.
+type CacheStore interface {
+	// Get returns the value stored for key
+	Get(key string) (any, bool)
+	// Add stores value for key; size is the approximate size of value in bytes
+	Add(key string, value any, size int64) bool
+	// Purge drops all stored values
+	Purge()
+}
+
+// CachingOption configures a CachingRegistry
+type CachingOption func(*CachingRegistry)
+
+// WithCacheStore stores cached responses in the given store instead of an unbounded map,
+// e.g. to bound the memory used by the cache
+func WithCacheStore(store CacheStore) CachingOption {
+	return func(c *CachingRegistry) {
+		c.store = store
+	}
+}
+
+// cacheEntry is a cached response and its expiry time
+type cacheEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+var _ Registry = (*CachingRegistry)(nil)
+
+// NewCachingRegistry creates a CachingRegistry around the given registry.
+// A non-positive ttl uses DefaultCacheTTL.
+func NewCachingRegistry(registry Registry, ttl time.Duration, opts ...CachingOption) *CachingRegistry {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	c := &CachingRegistry{
+		registry: registry,
+		ttl:      ttl,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.store == nil {
+		c.store = &mapStore{entries: make(map[string]any)}
+	}
+	return c
+}
+
+// ListServers returns a page of servers, served from the cache when available
+func (c *CachingRegistry) ListServers(
+	ctx context.Context, opts *ListServersOptions,
+) (*upstreamv0.ServerListResponse, error) {
+	return cached(c, "list:"+optionsKey(opts), func() (*upstreamv0.ServerListResponse, error) {
+		return c.registry.ListServers(ctx, opts)
+	})
+}
+
+// ListAllServers returns every matching server, served from the cache when available
+func (c *CachingRegistry) ListAllServers(
+	ctx context.Context, opts *ListServersOptions,
+) ([]upstreamv0.ServerResponse, error) {
+	return cached(c, "all:"+optionsKey(opts), func() ([]upstreamv0.ServerResponse, error) {
+		return c.registry.ListAllServers(ctx, opts)
+	})
+}
+
+// ListServerVersions returns all versions of a server, served from the cache when available
+func (c *CachingRegistry) ListServerVersions(
+	ctx context.Context, serverName string,
+) (*upstreamv0.ServerListResponse, error) {
+	return cached(c, "versions:"+serverName, func() (*upstreamv0.ServerListResponse, error) {
+		return c.registry.ListServerVersions(ctx, serverName)
+	})
+}
+
+// GetServerVersion returns a specific server version, served from the cache when available
+func (c *CachingRegistry) GetServerVersion(
+	ctx context.Context, serverName, version string,
+) (*upstreamv0.ServerResponse, error) {
+	return cached(c, "version:"+serverName+"@"+version, func() (*upstreamv0.ServerResponse, error) {
+		return c.registry.GetServerVersion(ctx, serverName, version)
+	})
+}
+
+// Invalidate drops all cached responses
+func (c *CachingRegistry) Invalidate() {
+	c.store.Purge()
+}
+
+// cached returns the cached value for key, or calls fetch and caches its result on success
+func cached[T any](c *CachingRegistry, key string, fetch func() (T, error)) (T, error) {
+	if stored, ok := c.store.Get(key); ok {
+		if entry := stored.(cacheEntry); time.Now().Before(entry.expiresAt) {
+			return entry.value.(T), nil
+		}
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+
+	c.store.Add(key, cacheEntry{value: value, expiresAt: time.Now().Add(c.ttl)}, c.sizeOf(key, value))
+
+	return value, nil
+}
+
+// sizeOf approximates the size of a cached response with the length of its JSON encoding.
+// The default unbounded store ignores sizes, so they are not computed for it.
+func (c *CachingRegistry) sizeOf(key string, value any) int64 {
+	if _, ok := c.store.(*mapStore); ok {
+		return 0
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return int64(len(key))
+	}
+	return int64(len(key) + len(data))
+}
+
+// mapStore is the default unbounded CacheStore
+type mapStore struct {
+	mu      sync.Mutex
+	entries map[string]any
+}
+
+// Get implements CacheStore
+func (s *mapStore) Get(key string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.entries[key]
+	return value, ok
+}
+
+// Add implements CacheStore
+func (s *mapStore) Add(key string, value any, _ int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = value
+	return true
+}
+
+// Purge implements CacheStore
+func (s *mapStore) Purge() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[string]any)
+}
+
+// optionsKey builds a cache key from list options
+func optionsKey(opts *ListServersOptions) string {
+	if opts == nil {
+		return ""
+	}
+	maxInjectionRisk := ""
+	if opts.MaxInjectionRisk != nil {
+		maxInjectionRisk = strconv.Itoa(*opts.MaxInjectionRisk)
+	}
+	return fmt.Sprintf("%s|%d|%s|%s|%s|%s|%s|%t|%t|%s",
+		opts.Cursor, opts.Limit, opts.Search,
+		opts.UpdatedSince.UTC().Format(time.RFC3339Nano), opts.PublishedBefore.UTC().Format(time.RFC3339Nano),
+		opts.Sort, opts.Version, opts.IncludeDeprecated, opts.IncludeDeleted, maxInjectionRisk)
+}
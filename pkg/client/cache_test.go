@@ -0,0 +1,140 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stacklok/toolhive-registry-server/pkg/client"
+	"github.com/stacklok/toolhive-registry-server/pkg/client/mocks"
+)
+
+func TestCachingRegistry(t *testing.T) {
+	t.Parallel()
+
+	serverResponse := &upstreamv0.ServerResponse{
+		Server: upstreamv0.ServerJSON{Name: "io.test/fetch", Version: "1.0.0"},
+	}
+
+	tests := []struct {
+		name  string
+		ttl   time.Duration
+		opts  []client.CachingOption
+		setup func(m *mocks.MockRegistry)
+		run   func(t *testing.T, r *client.CachingRegistry)
+	}{
+		{
+			name: "repeated calls are served from cache",
+			ttl:  time.Minute,
+			setup: func(m *mocks.MockRegistry) {
+				m.EXPECT().GetServerVersion(gomock.Any(), "io.test/fetch", "1.0.0").Return(serverResponse, nil).Times(1)
+			},
+			run: func(t *testing.T, r *client.CachingRegistry) {
+				for range 3 {
+					got, err := r.GetServerVersion(context.Background(), "io.test/fetch", "1.0.0")
+					require.NoError(t, err)
+					assert.Equal(t, serverResponse, got)
+				}
+			},
+		},
+		{
+			name: "different options are cached separately",
+			ttl:  time.Minute,
+			setup: func(m *mocks.MockRegistry) {
+				m.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(&upstreamv0.ServerListResponse{}, nil).Times(2)
+			},
+			run: func(t *testing.T, r *client.CachingRegistry) {
+				_, err := r.ListServers(context.Background(), &client.ListServersOptions{Search: "a"})
+				require.NoError(t, err)
+				_, err = r.ListServers(context.Background(), &client.ListServersOptions{Search: "b"})
+				require.NoError(t, err)
+				_, err = r.ListServers(context.Background(), &client.ListServersOptions{Search: "a"})
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "errors are not cached",
+			ttl:  time.Minute,
+			setup: func(m *mocks.MockRegistry) {
+				gomock.InOrder(
+					m.EXPECT().ListServerVersions(gomock.Any(), "io.test/fetch").Return(nil, errors.New("boom")),
+					m.EXPECT().ListServerVersions(gomock.Any(), "io.test/fetch").Return(&upstreamv0.ServerListResponse{}, nil),
+				)
+			},
+			run: func(t *testing.T, r *client.CachingRegistry) {
+				_, err := r.ListServerVersions(context.Background(), "io.test/fetch")
+				require.Error(t, err)
+				_, err = r.ListServerVersions(context.Background(), "io.test/fetch")
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "invalidate drops cached responses",
+			ttl:  time.Minute,
+			setup: func(m *mocks.MockRegistry) {
+				m.EXPECT().ListAllServers(gomock.Any(), nil).Return([]upstreamv0.ServerResponse{}, nil).Times(2)
+			},
+			run: func(t *testing.T, r *client.CachingRegistry) {
+				_, err := r.ListAllServers(context.Background(), nil)
+				require.NoError(t, err)
+				r.Invalidate()
+				_, err = r.ListAllServers(context.Background(), nil)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "expired entries are refetched",
+			ttl:  time.Millisecond,
+			setup: func(m *mocks.MockRegistry) {
+				m.EXPECT().GetServerVersion(gomock.Any(), "io.test/fetch", "latest").Return(serverResponse, nil).Times(2)
+			},
+			run: func(t *testing.T, r *client.CachingRegistry) {
+				_, err := r.GetServerVersion(context.Background(), "io.test/fetch", "latest")
+				require.NoError(t, err)
+				time.Sleep(5 * time.Millisecond)
+				_, err = r.GetServerVersion(context.Background(), "io.test/fetch", "latest")
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "responses declined by the store are refetched",
+			ttl:  time.Minute,
+			opts: []client.CachingOption{client.WithCacheStore(&decliningStore{})},
+			setup: func(m *mocks.MockRegistry) {
+				m.EXPECT().GetServerVersion(gomock.Any(), "io.test/fetch", "1.0.0").Return(serverResponse, nil).Times(2)
+			},
+			run: func(t *testing.T, r *client.CachingRegistry) {
+				for range 2 {
+					_, err := r.GetServerVersion(context.Background(), "io.test/fetch", "1.0.0")
+					require.NoError(t, err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRegistry := mocks.NewMockRegistry(ctrl)
+			tt.setup(mockRegistry)
+
+			tt.run(t, client.NewCachingRegistry(mockRegistry, tt.ttl, tt.opts...))
+		})
+	}
+}
+
+// decliningStore is a CacheStore that never stores anything, e.g. because it is full
+type decliningStore struct{}
+
+func (*decliningStore) Get(string) (any, bool) { return nil, false }
+
+func (*decliningStore) Add(_ string, _ any, size int64) bool { return size <= 0 }
+
+func (*decliningStore) Purge() {}
@@ -0,0 +1,302 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// UserAgent is the User-Agent header sent with every request
+	UserAgent = "toolhive-registry-client/1.0"
+
+	// DefaultTimeout is the default HTTP request timeout
+	DefaultTimeout = 30 * time.Second
+
+	// maxErrorBodySize limits how much of an error response body is read
+	maxErrorBodySize = 64 * 1024
+
+	// maxPages guards ListAllServers against servers that never stop returning cursors
+	maxPages = 1000
+)
+
+// APIError is returned when the registry API responds with a non-2xx status code
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("registry API returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is an *APIError with status 404
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Option configures the client
+type Option func(*Client) error
+
+// WithHTTPClient sets the HTTP client used to perform requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return fmt.Errorf("HTTP client cannot be nil")
+		}
+		c.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithRegistryName scopes all requests to the named registry
+func WithRegistryName(registryName string) Option {
+	return func(c *Client) error {
+		if registryName == "" {
+			return fmt.Errorf("registry name cannot be empty")
+		}
+		c.registryName = registryName
+		return nil
+	}
+}
+
+// WithBearerToken sets the token sent in the Authorization header
+func WithBearerToken(token string) Option {
+	return func(c *Client) error {
+		if token == "" {
+			return fmt.Errorf("bearer token cannot be empty")
+		}
+		c.bearerToken = token
+		return nil
+	}
+}
+
+// Client is a client for the ToolHive Registry API
+type Client struct {
+	baseURL      string
+	registryName string
+	bearerToken  string
+	httpClient   *http.Client
+}
+
+// ListServersOptions holds the optional filters for ListServers
+type ListServersOptions struct {
+	// Cursor is the pagination cursor returned by a previous call
+	Cursor string
+	// Limit is the maximum number of servers to return
+	Limit int
+	// Search filters servers by name (substring match)
+	Search string
+	// UpdatedSince filters servers updated after the given time
+	UpdatedSince time.Time
+	// PublishedBefore filters servers published before the given time (thv-registry-api only)
+	PublishedBefore time.Time
+	// Sort orders servers by "name", "published_at" or "updated_at", prefixed with
+	// "-" for descending order (thv-registry-api only)
+	Sort string
+	// Version filters by version ("latest" or an exact version)
+	Version string
+	// IncludeDeprecated includes servers marked as deprecated (thv-registry-api only)
+	IncludeDeprecated bool
+	// IncludeDeleted includes servers marked as deleted (thv-registry-api only)
+	IncludeDeleted bool
+	// MaxInjectionRisk excludes servers whose prompt-injection risk score (0-100) exceeds it,
+	// if set (thv-registry-api only)
+	MaxInjectionRisk *int
+}
+
+// NewClient creates a new registry API client for the given base URL.
+// The base URL is the root of the MCP Registry API, to which /v0.1/servers is
+// appended: https://registry.modelcontextprotocol.io for the official registry,
+// or http://<host>/registry for thv-registry-api.
+func NewClient(baseURL string, opts ...Option) (*Client, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if !parsed.IsAbs() || parsed.Host == "" {
+		return nil, fmt.Errorf("base URL must be an absolute URL with host: %s", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// ListServers returns a page of servers matching the given options.
+// opts may be nil.
+func (c *Client) ListServers(ctx context.Context, opts *ListServersOptions) (*upstreamv0.ServerListResponse, error) {
+	query := url.Values{}
+	if opts != nil {
+		if opts.Cursor != "" {
+			query.Set("cursor", opts.Cursor)
+		}
+		if opts.Limit > 0 {
+			query.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.Search != "" {
+			query.Set("search", opts.Search)
+		}
+		if !opts.UpdatedSince.IsZero() {
+			query.Set("updated_since", opts.UpdatedSince.UTC().Format(time.RFC3339))
+		}
+		if !opts.PublishedBefore.IsZero() {
+			query.Set("published_before", opts.PublishedBefore.UTC().Format(time.RFC3339))
+		}
+		if opts.Sort != "" {
+			query.Set("sort", opts.Sort)
+		}
+		if opts.IncludeDeprecated {
+			query.Set("include_deprecated", "true")
+		}
+		if opts.IncludeDeleted {
+			query.Set("include_deleted", "true")
+		}
+		if opts.MaxInjectionRisk != nil {
+			query.Set("max_injection_risk", strconv.Itoa(*opts.MaxInjectionRisk))
+		}
+		if opts.Version != "" {
+			query.Set("version", opts.Version)
+		}
+	}
+
+	var result upstreamv0.ServerListResponse
+	if err := c.get(ctx, c.registryPath("/servers"), query, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListAllServers returns every server matching the given options, following
+// pagination cursors until the last page. opts may be nil; its Cursor is ignored.
+func (c *Client) ListAllServers(ctx context.Context, opts *ListServersOptions) ([]upstreamv0.ServerResponse, error) {
+	pageOpts := ListServersOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	pageOpts.Cursor = ""
+
+	var servers []upstreamv0.ServerResponse
+	for range maxPages {
+		page, err := c.ListServers(ctx, &pageOpts)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, page.Servers...)
+		if page.Metadata.NextCursor == "" {
+			return servers, nil
+		}
+		pageOpts.Cursor = page.Metadata.NextCursor
+	}
+
+	return nil, fmt.Errorf("exceeded maximum of %d pages while listing servers", maxPages)
+}
+
+// ListServerVersions returns all versions of the named server
+func (c *Client) ListServerVersions(ctx context.Context, serverName string) (*upstreamv0.ServerListResponse, error) {
+	path := c.registryPath("/servers/" + url.PathEscape(serverName) + "/versions")
+
+	var result upstreamv0.ServerListResponse
+	if err := c.get(ctx, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetServerVersion returns a specific version of the named server.
+// Use "latest" as version to get the latest version.
+func (c *Client) GetServerVersion(ctx context.Context, serverName, version string) (*upstreamv0.ServerResponse, error) {
+	path := c.registryPath("/servers/" + url.PathEscape(serverName) + "/versions/" + url.PathEscape(version))
+
+	var result upstreamv0.ServerResponse
+	if err := c.get(ctx, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// registryPath returns the API path for the given v0.1 resource, scoped to the configured registry
+func (c *Client) registryPath(resource string) string {
+	if c.registryName != "" {
+		return "/" + url.PathEscape(c.registryName) + "/v0.1" + resource
+	}
+	return "/v0.1" + resource
+}
+
+// get performs a GET request and decodes the JSON response into out
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
+	// The path is already escaped, so it is appended to the base URL verbatim
+	// to preserve encoded slashes in server names.
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", UserAgent)
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newAPIError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// newAPIError builds an *APIError from an error response, using the
+// {"error": "..."} body when present
+func newAPIError(resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	var errorResp struct {
+		Error string `json:"error"`
+	}
+	message := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Error != "" {
+		message = errorResp.Error
+	}
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    message,
+	}
+}
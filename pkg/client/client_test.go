@@ -0,0 +1,202 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive-registry-server/internal/api/common"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		baseURL string
+		opts    []Option
+		wantErr string
+	}{
+		{
+			name:    "valid base URL",
+			baseURL: "https://registry.example.com/",
+		},
+		{
+			name:    "relative base URL",
+			baseURL: "/registry",
+			wantErr: "base URL must be an absolute URL with host",
+		},
+		{
+			name:    "empty registry name",
+			baseURL: "https://registry.example.com",
+			opts:    []Option{WithRegistryName("")},
+			wantErr: "registry name cannot be empty",
+		},
+		{
+			name:    "nil HTTP client",
+			baseURL: "https://registry.example.com",
+			opts:    []Option{WithHTTPClient(nil)},
+			wantErr: "HTTP client cannot be nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c, err := NewClient(tt.baseURL, tt.opts...)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://registry.example.com", c.baseURL)
+		})
+	}
+}
+
+func TestClient_Requests(t *testing.T) {
+	t.Parallel()
+
+	updatedSince := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		call       func(c *Client) (any, error)
+		wantURI    string
+		wantAuth   string
+		statusCode int
+		body       any
+		wantErr    string
+		notFound   bool
+	}{
+		{
+			name: "list servers with filters",
+			call: func(c *Client) (any, error) {
+				return c.ListServers(context.Background(), &ListServersOptions{
+					Cursor:            "abc",
+					Limit:             10,
+					Search:            "fetch",
+					UpdatedSince:      updatedSince,
+					PublishedBefore:   updatedSince,
+					Sort:              "-updated_at",
+					Version:           "latest",
+					IncludeDeprecated: true,
+				})
+			},
+			wantURI: "/v0.1/servers?cursor=abc&include_deprecated=true&limit=10" +
+				"&published_before=2025-01-02T03%3A04%3A05Z&search=fetch" +
+				"&sort=-updated_at&updated_since=2025-01-02T03%3A04%3A05Z&version=latest",
+			statusCode: http.StatusOK,
+			body:       upstreamv0.ServerListResponse{Servers: []upstreamv0.ServerResponse{}},
+		},
+		{
+			name: "list servers in named registry with token",
+			opts: []Option{WithRegistryName("internal"), WithBearerToken("secret")},
+			call: func(c *Client) (any, error) {
+				return c.ListServers(context.Background(), nil)
+			},
+			wantURI:    "/internal/v0.1/servers",
+			wantAuth:   "Bearer secret",
+			statusCode: http.StatusOK,
+			body:       upstreamv0.ServerListResponse{Servers: []upstreamv0.ServerResponse{}},
+		},
+		{
+			name: "list server versions escapes server name",
+			call: func(c *Client) (any, error) {
+				return c.ListServerVersions(context.Background(), "io.test/fetch")
+			},
+			wantURI:    "/v0.1/servers/io.test%2Ffetch/versions",
+			statusCode: http.StatusOK,
+			body:       upstreamv0.ServerListResponse{Servers: []upstreamv0.ServerResponse{}},
+		},
+		{
+			name: "get server version",
+			call: func(c *Client) (any, error) {
+				return c.GetServerVersion(context.Background(), "io.test/fetch", "1.0.0")
+			},
+			wantURI:    "/v0.1/servers/io.test%2Ffetch/versions/1.0.0",
+			statusCode: http.StatusOK,
+			body: upstreamv0.ServerResponse{
+				Server: upstreamv0.ServerJSON{Name: "io.test/fetch", Version: "1.0.0"},
+			},
+		},
+		{
+			name: "not found returns API error",
+			call: func(c *Client) (any, error) {
+				return c.GetServerVersion(context.Background(), "io.test/missing", "latest")
+			},
+			wantURI:    "/v0.1/servers/io.test%2Fmissing/versions/latest",
+			statusCode: http.StatusNotFound,
+			body:       map[string]string{"error": "Server not found"},
+			wantErr:    "registry API returned status 404: Server not found",
+			notFound:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.wantURI, r.RequestURI)
+				assert.Equal(t, tt.wantAuth, r.Header.Get("Authorization"))
+				assert.Equal(t, UserAgent, r.Header.Get("User-Agent"))
+				common.WriteJSONResponse(w, tt.body, tt.statusCode)
+			}))
+			defer server.Close()
+
+			c, err := NewClient(server.URL, tt.opts...)
+			require.NoError(t, err)
+
+			result, err := tt.call(c)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				assert.Equal(t, tt.notFound, IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, result)
+		})
+	}
+}
+
+func TestClient_ListAllServers(t *testing.T) {
+	t.Parallel()
+
+	pages := map[string]upstreamv0.ServerListResponse{
+		"": {
+			Servers:  []upstreamv0.ServerResponse{{Server: upstreamv0.ServerJSON{Name: "io.test/a"}}},
+			Metadata: upstreamv0.Metadata{NextCursor: "page2"},
+		},
+		"page2": {
+			Servers: []upstreamv0.ServerResponse{{Server: upstreamv0.ServerJSON{Name: "io.test/b"}}},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "fetch", r.URL.Query().Get("search"))
+		page, ok := pages[r.URL.Query().Get("cursor")]
+		if !ok {
+			common.WriteErrorResponse(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+		common.WriteJSONResponse(w, page, http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	servers, err := c.ListAllServers(context.Background(), &ListServersOptions{Search: "fetch", Cursor: "ignored"})
+	require.NoError(t, err)
+	require.Len(t, servers, 2)
+	assert.Equal(t, "io.test/a", servers[0].Server.Name)
+	assert.Equal(t, "io.test/b", servers[1].Server.Name)
+}
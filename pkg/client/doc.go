@@ -0,0 +1,26 @@
+// Package client provides a Go client for the ToolHive Registry API.
+//
+// The client covers the read-only MCP Registry API v0.1 endpoints served by
+// thv-registry-api under /registry and described by its /openapi.json
+// document. It also works against any other server implementing the MCP
+// Registry API, such as the official registry:
+//
+//	c, err := client.NewClient("https://registry.example.com/registry",
+//		client.WithRegistryName("internal"),
+//		client.WithBearerToken(token),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	servers, err := c.ListServers(ctx, &client.ListServersOptions{Search: "github"})
+//
+// Client implements the Registry interface. Wrap it with NewCachingRegistry
+// to cache responses for a fixed TTL when embedding the client in long-running
+// components:
+//
+//	registry := client.NewCachingRegistry(c, 10*time.Minute)
+//
+// Responses are decoded into the upstream MCP Registry types
+// (github.com/modelcontextprotocol/registry/pkg/api/v0). Non-2xx responses are
+// returned as *APIError.
+package client
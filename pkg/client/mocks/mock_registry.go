@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: registry.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_registry.go -package=mocks -source=registry.go Registry
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	client "github.com/stacklok/toolhive-registry-server/pkg/client"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRegistry is a mock of Registry interface.
+type MockRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistryMockRecorder
+	isgomock struct{}
+}
+
+// MockRegistryMockRecorder is the mock recorder for MockRegistry.
+type MockRegistryMockRecorder struct {
+	mock *MockRegistry
+}
+
+// NewMockRegistry creates a new mock instance.
+func NewMockRegistry(ctrl *gomock.Controller) *MockRegistry {
+	mock := &MockRegistry{ctrl: ctrl}
+	mock.recorder = &MockRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistry) EXPECT() *MockRegistryMockRecorder {
+	return m.recorder
+}
+
+// GetServerVersion mocks base method.
+func (m *MockRegistry) GetServerVersion(ctx context.Context, serverName, version string) (*v0.ServerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServerVersion", ctx, serverName, version)
+	ret0, _ := ret[0].(*v0.ServerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServerVersion indicates an expected call of GetServerVersion.
+func (mr *MockRegistryMockRecorder) GetServerVersion(ctx, serverName, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerVersion", reflect.TypeOf((*MockRegistry)(nil).GetServerVersion), ctx, serverName, version)
+}
+
+// ListAllServers mocks base method.
+func (m *MockRegistry) ListAllServers(ctx context.Context, opts *client.ListServersOptions) ([]v0.ServerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllServers", ctx, opts)
+	ret0, _ := ret[0].([]v0.ServerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllServers indicates an expected call of ListAllServers.
+func (mr *MockRegistryMockRecorder) ListAllServers(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllServers", reflect.TypeOf((*MockRegistry)(nil).ListAllServers), ctx, opts)
+}
+
+// ListServerVersions mocks base method.
+func (m *MockRegistry) ListServerVersions(ctx context.Context, serverName string) (*v0.ServerListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServerVersions", ctx, serverName)
+	ret0, _ := ret[0].(*v0.ServerListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServerVersions indicates an expected call of ListServerVersions.
+func (mr *MockRegistryMockRecorder) ListServerVersions(ctx, serverName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServerVersions", reflect.TypeOf((*MockRegistry)(nil).ListServerVersions), ctx, serverName)
+}
+
+// ListServers mocks base method.
+func (m *MockRegistry) ListServers(ctx context.Context, opts *client.ListServersOptions) (*v0.ServerListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServers", ctx, opts)
+	ret0, _ := ret[0].(*v0.ServerListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServers indicates an expected call of ListServers.
+func (mr *MockRegistryMockRecorder) ListServers(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServers", reflect.TypeOf((*MockRegistry)(nil).ListServers), ctx, opts)
+}
@@ -0,0 +1,25 @@
+package client
+
+//go:generate mockgen -destination=mocks/mock_registry.go -package=mocks -source=registry.go Registry
+
+import (
+	"context"
+
+	upstreamv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Registry is the read-only registry data access interface implemented by
+// Client and CachingRegistry. Components embedding the registry client should
+// depend on this interface rather than on a concrete implementation.
+type Registry interface {
+	// ListServers returns a page of servers matching the given options
+	ListServers(ctx context.Context, opts *ListServersOptions) (*upstreamv0.ServerListResponse, error)
+	// ListAllServers returns every server matching the given options, following pagination
+	ListAllServers(ctx context.Context, opts *ListServersOptions) ([]upstreamv0.ServerResponse, error)
+	// ListServerVersions returns all versions of the named server
+	ListServerVersions(ctx context.Context, serverName string) (*upstreamv0.ServerListResponse, error)
+	// GetServerVersion returns a specific version of the named server
+	GetServerVersion(ctx context.Context, serverName, version string) (*upstreamv0.ServerResponse, error)
+}
+
+var _ Registry = (*Client)(nil)